@@ -0,0 +1,57 @@
+// This file was generated by counterfeiter
+package auditfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/audit"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeAuditor struct {
+	RecordStub        func(logger lager.Logger, record *models.AuditRecord) error
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		logger lager.Logger
+		record *models.AuditRecord
+	}
+	recordReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeAuditor) Record(logger lager.Logger, record *models.AuditRecord) error {
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		logger lager.Logger
+		record *models.AuditRecord
+	}{logger, record})
+	fake.recordMutex.Unlock()
+	if fake.RecordStub != nil {
+		return fake.RecordStub(logger, record)
+	} else {
+		return fake.recordReturns.result1
+	}
+}
+
+func (fake *FakeAuditor) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeAuditor) RecordArgsForCall(i int) (lager.Logger, *models.AuditRecord) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return fake.recordArgsForCall[i].logger, fake.recordArgsForCall[i].record
+}
+
+func (fake *FakeAuditor) RecordReturns(result1 error) {
+	fake.RecordStub = nil
+	fake.recordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ audit.Auditor = new(FakeAuditor)
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+// A Store persists audit records. Stores only ever append; records are never
+// updated or removed by the BBS.
+type Store interface {
+	AppendAuditRecord(logger lager.Logger, record *models.AuditRecord) error
+	LastAuditRecord(logger lager.Logger) (*models.AuditRecord, error)
+}
+
+//go:generate counterfeiter . Auditor
+type Auditor interface {
+	Record(logger lager.Logger, record *models.AuditRecord) error
+}
+
+type auditor struct {
+	clock  clock.Clock
+	stores []Store
+
+	lock   sync.Mutex
+	loaded bool
+	last   *models.AuditRecord
+}
+
+// NewAuditor returns an Auditor that chains every record to the one before
+// it and appends it to each of the stores. The first store is authoritative:
+// the chain is resumed from its last record, so the other stores will have
+// gaps when more than one BBS has held the lock.
+func NewAuditor(clock clock.Clock, stores ...Store) Auditor {
+	return &auditor{
+		clock:  clock,
+		stores: stores,
+	}
+}
+
+func (a *auditor) Record(logger lager.Logger, record *models.AuditRecord) error {
+	logger = logger.Session("audit", lager.Data{"route": record.Route, "target_guid": record.TargetGuid})
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if len(a.stores) == 0 {
+		return nil
+	}
+
+	if !a.loaded {
+		last, err := a.stores[0].LastAuditRecord(logger)
+		if err != nil {
+			logger.Error("failed-fetching-last-audit-record", err)
+			return err
+		}
+		a.last = last
+		a.loaded = true
+	}
+
+	record.Index = 1
+	record.PreviousHash = ""
+	if a.last != nil {
+		record.Index = a.last.Index + 1
+		record.PreviousHash = a.last.Hash
+	}
+	record.Timestamp = a.clock.Now().UnixNano()
+	record.Hash = Hash(record)
+
+	var recordErr error
+	for i, store := range a.stores {
+		err := store.AppendAuditRecord(logger, record)
+		if err == nil {
+			continue
+		}
+
+		logger.Error("failed-appending-audit-record", err, lager.Data{"store": i})
+		if recordErr == nil {
+			recordErr = err
+		}
+
+		if i == 0 {
+			// another BBS may have extended the chain; resume from its end
+			a.loaded = false
+			return err
+		}
+	}
+
+	a.last = record
+	return recordErr
+}
+
+// Hash returns the hex encoded SHA-256 digest of the record, excluding its
+// own hash. The digest covers the previous record's hash, which chains the
+// records together.
+func Hash(record *models.AuditRecord) string {
+	unhashed := *record
+	unhashed.Hash = ""
+
+	data, err := proto.Marshal(&unhashed)
+	if err != nil {
+		panic("Unable to encode Proto: " + err.Error())
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks that each of the records is unmodified and that they form an
+// unbroken chain. The records must be consecutive, such as those returned by
+// an unfiltered query.
+func Verify(records []*models.AuditRecord) error {
+	for i, record := range records {
+		if Hash(record) != record.Hash {
+			return fmt.Errorf("audit record %d has been modified", record.Index)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		previous := records[i-1]
+		if record.Index != previous.Index+1 || record.PreviousHash != previous.Hash {
+			return fmt.Errorf("audit record %d does not follow audit record %d", record.Index, previous.Index)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package audit_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/audit"
+	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auditor", func() {
+	var (
+		logger         *lagertest.TestLogger
+		fakeClock      *fakeclock.FakeClock
+		primaryStore   *dbfakes.FakeAuditDB
+		secondaryStore *dbfakes.FakeAuditDB
+		auditor        audit.Auditor
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 1000))
+		primaryStore = &dbfakes.FakeAuditDB{}
+		secondaryStore = &dbfakes.FakeAuditDB{}
+		auditor = audit.NewAuditor(fakeClock, primaryStore, secondaryStore)
+	})
+
+	newRecord := func() *models.AuditRecord {
+		return &models.AuditRecord{
+			Identity:   "some-operator",
+			Route:      "DesireTask",
+			TargetGuid: "some-guid",
+		}
+	}
+
+	Describe("Record", func() {
+		Context("when the store is empty", func() {
+			It("starts the chain", func() {
+				record := newRecord()
+				Expect(auditor.Record(logger, record)).To(Succeed())
+
+				Expect(record.Index).To(BeEquivalentTo(1))
+				Expect(record.PreviousHash).To(BeEmpty())
+				Expect(record.Timestamp).To(BeEquivalentTo(1000))
+				Expect(record.Hash).To(Equal(audit.Hash(record)))
+			})
+
+			It("appends the record to every store", func() {
+				record := newRecord()
+				Expect(auditor.Record(logger, record)).To(Succeed())
+
+				Expect(primaryStore.AppendAuditRecordCallCount()).To(Equal(1))
+				_, appended := primaryStore.AppendAuditRecordArgsForCall(0)
+				Expect(appended).To(Equal(record))
+
+				Expect(secondaryStore.AppendAuditRecordCallCount()).To(Equal(1))
+				_, appended = secondaryStore.AppendAuditRecordArgsForCall(0)
+				Expect(appended).To(Equal(record))
+			})
+
+			It("chains subsequent records", func() {
+				first := newRecord()
+				second := newRecord()
+				Expect(auditor.Record(logger, first)).To(Succeed())
+				Expect(auditor.Record(logger, second)).To(Succeed())
+
+				Expect(second.Index).To(BeEquivalentTo(2))
+				Expect(second.PreviousHash).To(Equal(first.Hash))
+				Expect(audit.Verify([]*models.AuditRecord{first, second})).To(Succeed())
+
+				Expect(primaryStore.LastAuditRecordCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the primary store has records", func() {
+			var last *models.AuditRecord
+
+			BeforeEach(func() {
+				last = &models.AuditRecord{Index: 41, Hash: "last-hash"}
+				primaryStore.LastAuditRecordReturns(last, nil)
+			})
+
+			It("resumes the chain", func() {
+				record := newRecord()
+				Expect(auditor.Record(logger, record)).To(Succeed())
+
+				Expect(record.Index).To(BeEquivalentTo(42))
+				Expect(record.PreviousHash).To(Equal("last-hash"))
+				Expect(secondaryStore.LastAuditRecordCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when fetching the last record fails", func() {
+			BeforeEach(func() {
+				primaryStore.LastAuditRecordReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error without appending", func() {
+				Expect(auditor.Record(logger, newRecord())).To(MatchError("boom"))
+				Expect(primaryStore.AppendAuditRecordCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when appending to the primary store fails", func() {
+			BeforeEach(func() {
+				primaryStore.AppendAuditRecordReturns(errors.New("boom"))
+			})
+
+			It("returns the error and resumes the chain from the store next time", func() {
+				Expect(auditor.Record(logger, newRecord())).To(MatchError("boom"))
+				Expect(secondaryStore.AppendAuditRecordCallCount()).To(Equal(0))
+
+				primaryStore.AppendAuditRecordReturns(nil)
+				Expect(auditor.Record(logger, newRecord())).To(Succeed())
+				Expect(primaryStore.LastAuditRecordCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when appending to another store fails", func() {
+			BeforeEach(func() {
+				secondaryStore.AppendAuditRecordReturns(errors.New("boom"))
+			})
+
+			It("returns the error but continues the chain", func() {
+				first := newRecord()
+				Expect(auditor.Record(logger, first)).To(MatchError("boom"))
+
+				second := newRecord()
+				auditor.Record(logger, second)
+				Expect(second.PreviousHash).To(Equal(first.Hash))
+			})
+		})
+	})
+
+	Describe("Verify", func() {
+		var records []*models.AuditRecord
+
+		BeforeEach(func() {
+			records = []*models.AuditRecord{newRecord(), newRecord(), newRecord()}
+			for _, record := range records {
+				Expect(auditor.Record(logger, record)).To(Succeed())
+			}
+		})
+
+		It("accepts an unbroken chain", func() {
+			Expect(audit.Verify(records)).To(Succeed())
+		})
+
+		It("detects a modified record", func() {
+			records[1].Identity = "someone-else"
+			Expect(audit.Verify(records)).To(MatchError("audit record 2 has been modified"))
+		})
+
+		It("detects a removed record", func() {
+			Expect(audit.Verify([]*models.AuditRecord{records[0], records[2]})).To(MatchError("audit record 3 does not follow audit record 1"))
+		})
+
+		It("detects a rehashed record", func() {
+			records[1].Identity = "someone-else"
+			records[1].Hash = audit.Hash(records[1])
+			Expect(audit.Verify(records)).To(MatchError("audit record 3 does not follow audit record 2"))
+		})
+	})
+})
@@ -0,0 +1,197 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// FileStore appends audit records to a local file as JSON, one record per
+// line. When the file would grow beyond maxSize bytes it is rotated to
+// path.1, path.1 to path.2, and so on, keeping at most maxBackups old files.
+type FileStore struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+func NewFileStore(path string, maxSize int64, maxBackups int) (*FileStore, error) {
+	store := &FileStore{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	err := store.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *FileStore) AppendAuditRecord(logger lager.Logger, record *models.AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		err = s.rotate()
+		if err != nil {
+			logger.Error("failed-rotating-audit-log", err, lager.Data{"path": s.path})
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+// LastAuditRecord returns the last record in the current file, or in the most
+// recent backup if the file has just been rotated.
+func (s *FileStore) LastAuditRecord(logger lager.Logger) (*models.AuditRecord, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, path := range []string{s.path, s.backupPath(1)} {
+		line, err := lastLine(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == nil {
+			continue
+		}
+
+		record := &models.AuditRecord{}
+		err = json.Unmarshal(line, record)
+		if err != nil {
+			logger.Error("failed-parsing-last-audit-record", err, lager.Data{"path": path})
+			return nil, err
+		}
+		return record, nil
+	}
+
+	return nil, nil
+}
+
+func (s *FileStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *FileStore) open() error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileStore) rotate() error {
+	err := s.file.Close()
+	if err != nil {
+		return err
+	}
+
+	if s.maxBackups < 1 {
+		err = os.Remove(s.path)
+	} else {
+		err = os.Remove(s.backupPath(s.maxBackups))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		for i := s.maxBackups - 1; i >= 1; i-- {
+			err = os.Rename(s.backupPath(i), s.backupPath(i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		err = os.Rename(s.path, s.backupPath(1))
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.open()
+}
+
+func (s *FileStore) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", s.path, n)
+}
+
+// lastLine returns the last non-empty line of the file, reading backwards
+// from its end so that large files need not be read in full.
+func lastLine(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	const chunkSize = 4096
+	var tail []byte
+	offset := info.Size()
+
+	for offset > 0 {
+		readSize := int64(chunkSize)
+		if offset < readSize {
+			readSize = offset
+		}
+		offset -= readSize
+
+		chunk := make([]byte, readSize)
+		_, err = file.ReadAt(chunk, offset)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		tail = append(chunk, tail...)
+
+		trimmed := bytes.TrimRight(tail, "\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+	}
+
+	trimmed := bytes.TrimRight(tail, "\n")
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	return trimmed, nil
+}
@@ -0,0 +1,154 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/audit"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileStore", func() {
+	var (
+		logger  *lagertest.TestLogger
+		tmpDir  string
+		path    string
+		maxSize int64
+		store   *audit.FileStore
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "audit")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(tmpDir, "audit.log")
+		maxSize = 0
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		store, err = audit.NewFileStore(path, maxSize, 2)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		store.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	newRecord := func(index int64) *models.AuditRecord {
+		return &models.AuditRecord{
+			Index: index,
+			Route: "DesireTask",
+			Changes: []*models.AuditChange{
+				{Field: "state", After: "Pending"},
+			},
+			Hash: "some-hash",
+		}
+	}
+
+	readRecords := func(path string) []*models.AuditRecord {
+		data, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		records := []*models.AuditRecord{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			record := &models.AuditRecord{}
+			Expect(json.Unmarshal([]byte(line), record)).To(Succeed())
+			records = append(records, record)
+		}
+		return records
+	}
+
+	It("writes each record as a line of json", func() {
+		Expect(store.AppendAuditRecord(logger, newRecord(1))).To(Succeed())
+		Expect(store.AppendAuditRecord(logger, newRecord(2))).To(Succeed())
+
+		Expect(readRecords(path)).To(Equal([]*models.AuditRecord{newRecord(1), newRecord(2)}))
+	})
+
+	It("creates the file readable only by its owner", func() {
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	Describe("LastAuditRecord", func() {
+		It("returns nil when there are no records", func() {
+			record, err := store.LastAuditRecord(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).To(BeNil())
+		})
+
+		It("returns the last record written", func() {
+			Expect(store.AppendAuditRecord(logger, newRecord(1))).To(Succeed())
+			Expect(store.AppendAuditRecord(logger, newRecord(2))).To(Succeed())
+
+			record, err := store.LastAuditRecord(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).To(Equal(newRecord(2)))
+		})
+
+		Context("when the file already has records", func() {
+			BeforeEach(func() {
+				existing, err := audit.NewFileStore(path, 0, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(existing.AppendAuditRecord(logger, newRecord(7))).To(Succeed())
+				Expect(existing.Close()).To(Succeed())
+			})
+
+			It("appends to them", func() {
+				record, err := store.LastAuditRecord(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record).To(Equal(newRecord(7)))
+
+				Expect(store.AppendAuditRecord(logger, newRecord(8))).To(Succeed())
+				Expect(readRecords(path)).To(HaveLen(2))
+			})
+		})
+	})
+
+	Context("when the file grows beyond its maximum size", func() {
+		BeforeEach(func() {
+			line, err := json.Marshal(newRecord(1))
+			Expect(err).NotTo(HaveOccurred())
+			maxSize = int64(len(line)+1) * 2
+		})
+
+		It("rotates the file, keeping the configured number of backups", func() {
+			for i := int64(1); i <= 7; i++ {
+				Expect(store.AppendAuditRecord(logger, newRecord(i))).To(Succeed())
+			}
+
+			Expect(readRecords(path)).To(Equal([]*models.AuditRecord{newRecord(7)}))
+			Expect(readRecords(path + ".1")).To(Equal([]*models.AuditRecord{newRecord(5), newRecord(6)}))
+			Expect(readRecords(path + ".2")).To(Equal([]*models.AuditRecord{newRecord(3), newRecord(4)}))
+			Expect(path + ".3").NotTo(BeAnExistingFile())
+		})
+
+		It("finds the last record in the backup right after rotating", func() {
+			for i := int64(1); i <= 2; i++ {
+				Expect(store.AppendAuditRecord(logger, newRecord(i))).To(Succeed())
+			}
+			Expect(store.Close()).To(Succeed())
+
+			Expect(os.Rename(path, path+".1")).To(Succeed())
+			var err error
+			store, err = audit.NewFileStore(path, maxSize, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			record, err := store.LastAuditRecord(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).To(Equal(newRecord(2)))
+		})
+	})
+})
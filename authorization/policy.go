@@ -0,0 +1,130 @@
+package authorization
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+type Role string
+
+const (
+	CellRole       Role = "cell"
+	ControllerRole Role = "controller"
+	OperatorRole   Role = "operator"
+	ReadOnlyRole   Role = "read-only"
+)
+
+var AllRoles = []Role{CellRole, ControllerRole, OperatorRole, ReadOnlyRole}
+
+func (r Role) Valid() bool {
+	for _, role := range AllRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// A Rule grants a role to every client certificate whose subject common name
+// or one of whose organizational units is listed.
+type Rule struct {
+	Role                Role     `json:"role"`
+	CommonNames         []string `json:"common_names,omitempty"`
+	OrganizationalUnits []string `json:"organizational_units,omitempty"`
+}
+
+func (rule Rule) Matches(cert *x509.Certificate) bool {
+	if cert == nil {
+		return false
+	}
+
+	for _, cn := range rule.CommonNames {
+		if cert.Subject.CommonName == cn {
+			return true
+		}
+	}
+
+	for _, ou := range rule.OrganizationalUnits {
+		for _, certOU := range cert.Subject.OrganizationalUnit {
+			if certOU == ou {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{}
+	err = json.Unmarshal(data, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return errors.New("authorization policy has no rules")
+	}
+
+	for i, rule := range p.Rules {
+		if !rule.Role.Valid() {
+			return fmt.Errorf("authorization rule %d has unknown role '%s'", i, rule.Role)
+		}
+		if len(rule.CommonNames) == 0 && len(rule.OrganizationalUnits) == 0 {
+			return fmt.Errorf("authorization rule %d matches no certificates", i)
+		}
+	}
+
+	return nil
+}
+
+// RolesFor returns every role granted to the client certificate, without
+// duplicates. A nil certificate has no roles.
+func (p *Policy) RolesFor(cert *x509.Certificate) []Role {
+	roles := []Role{}
+	seen := map[Role]bool{}
+
+	for _, rule := range p.Rules {
+		if seen[rule.Role] || !rule.Matches(cert) {
+			continue
+		}
+		seen[rule.Role] = true
+		roles = append(roles, rule.Role)
+	}
+
+	return roles
+}
+
+// Permitted reports whether any of the granted roles is one of the allowed
+// roles.
+func Permitted(granted, allowed []Role) bool {
+	for _, g := range granted {
+		for _, a := range allowed {
+			if g == a {
+				return true
+			}
+		}
+	}
+	return false
+}
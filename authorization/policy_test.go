@@ -0,0 +1,166 @@
+package authorization_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"os"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy", func() {
+	newCert := func(cn string, ous ...string) *x509.Certificate {
+		return &x509.Certificate{
+			Subject: pkix.Name{CommonName: cn, OrganizationalUnit: ous},
+		}
+	}
+
+	Describe("LoadPolicy", func() {
+		var policyPath string
+
+		writePolicy := func(contents string) {
+			policyFile, err := ioutil.TempFile("", "policy")
+			Expect(err).NotTo(HaveOccurred())
+			defer policyFile.Close()
+
+			_, err = policyFile.WriteString(contents)
+			Expect(err).NotTo(HaveOccurred())
+			policyPath = policyFile.Name()
+		}
+
+		AfterEach(func() {
+			os.Remove(policyPath)
+		})
+
+		It("loads the rules", func() {
+			writePolicy(`{"rules": [
+				{"role": "cell", "organizational_units": ["cell"]},
+				{"role": "operator", "common_names": ["admin"]}
+			]}`)
+
+			policy, err := authorization.LoadPolicy(policyPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.Rules).To(Equal([]authorization.Rule{
+				{Role: authorization.CellRole, OrganizationalUnits: []string{"cell"}},
+				{Role: authorization.OperatorRole, CommonNames: []string{"admin"}},
+			}))
+		})
+
+		It("errors when the file does not exist", func() {
+			_, err := authorization.LoadPolicy("/does/not/exist")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("errors when the file is not valid JSON", func() {
+			writePolicy(`{`)
+			_, err := authorization.LoadPolicy(policyPath)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("errors when the policy is invalid", func() {
+			writePolicy(`{"rules": [{"role": "superuser", "common_names": ["admin"]}]}`)
+			_, err := authorization.LoadPolicy(policyPath)
+			Expect(err).To(MatchError("authorization rule 0 has unknown role 'superuser'"))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("requires at least one rule", func() {
+			policy := authorization.Policy{}
+			Expect(policy.Validate()).To(MatchError("authorization policy has no rules"))
+		})
+
+		It("requires each rule to match something", func() {
+			policy := authorization.Policy{Rules: []authorization.Rule{{Role: authorization.ReadOnlyRole}}}
+			Expect(policy.Validate()).To(MatchError("authorization rule 0 matches no certificates"))
+		})
+	})
+
+	Describe("RolesFor", func() {
+		var policy *authorization.Policy
+
+		BeforeEach(func() {
+			policy = &authorization.Policy{Rules: []authorization.Rule{
+				{Role: authorization.CellRole, OrganizationalUnits: []string{"cell"}},
+				{Role: authorization.ControllerRole, CommonNames: []string{"cc-bridge", "auctioneer"}},
+				{Role: authorization.OperatorRole, CommonNames: []string{"admin"}},
+				{Role: authorization.ReadOnlyRole, OrganizationalUnits: []string{"monitoring", "cell"}},
+			}}
+		})
+
+		It("matches on organizational unit", func() {
+			Expect(policy.RolesFor(newCert("cell-z1-0", "cell"))).To(ConsistOf(authorization.CellRole, authorization.ReadOnlyRole))
+		})
+
+		It("matches on common name", func() {
+			Expect(policy.RolesFor(newCert("auctioneer"))).To(ConsistOf(authorization.ControllerRole))
+		})
+
+		It("does not repeat roles", func() {
+			Expect(policy.RolesFor(newCert("admin", "monitoring", "cell"))).To(ConsistOf(
+				authorization.CellRole,
+				authorization.OperatorRole,
+				authorization.ReadOnlyRole,
+			))
+		})
+
+		It("grants no roles to unknown certificates", func() {
+			Expect(policy.RolesFor(newCert("stranger", "elsewhere"))).To(BeEmpty())
+		})
+
+		It("grants no roles without a certificate", func() {
+			Expect(policy.RolesFor(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("Permitted", func() {
+		It("permits when any granted role is allowed", func() {
+			Expect(authorization.Permitted(
+				[]authorization.Role{authorization.ReadOnlyRole, authorization.CellRole},
+				[]authorization.Role{authorization.CellRole, authorization.OperatorRole},
+			)).To(BeTrue())
+		})
+
+		It("rejects when no granted role is allowed", func() {
+			Expect(authorization.Permitted(
+				[]authorization.Role{authorization.ReadOnlyRole},
+				[]authorization.Role{authorization.CellRole, authorization.OperatorRole},
+			)).To(BeFalse())
+		})
+
+		It("rejects when no roles are granted", func() {
+			Expect(authorization.Permitted(nil, authorization.AllRoles)).To(BeFalse())
+		})
+	})
+
+	Describe("bbs.RouteRoles", func() {
+		It("assigns roles to every route", func() {
+			for _, route := range bbs.Routes {
+				Expect(bbs.RouteRoles).To(HaveKey(route.Name))
+				Expect(bbs.RouteRoles[route.Name]).NotTo(BeEmpty(), route.Name)
+			}
+		})
+
+		It("lets operators call every route", func() {
+			for name, roles := range bbs.RouteRoles {
+				Expect(roles).To(ContainElement(authorization.OperatorRole), name)
+			}
+		})
+
+		It("only lets read-only clients call routes that do not mutate state", func() {
+			for name, roles := range bbs.RouteRoles {
+				if authorization.Permitted([]authorization.Role{authorization.ReadOnlyRole}, roles) {
+					Expect(roles).To(Equal(authorization.AllRoles), name)
+				}
+			}
+			Expect(bbs.RouteRoles[bbs.DesireTaskRoute]).NotTo(ContainElement(authorization.ReadOnlyRole))
+			Expect(bbs.RouteRoles[bbs.ConvergeLRPsRoute]).NotTo(ContainElement(authorization.CellRole))
+			Expect(bbs.RouteRoles[bbs.RemoveDesiredLRPRoute]).NotTo(ContainElement(authorization.CellRole))
+		})
+	})
+})
@@ -9,12 +9,14 @@ import (
 	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/cf_http"
 	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/websocket"
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/rata"
 	"github.com/vito/go-sse/sse"
@@ -57,6 +59,7 @@ type InternalClient interface {
 
 	ConvergeTasks(logger lager.Logger, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) error
 	StartTask(logger lager.Logger, taskGuid string, cellID string) (bool, error)
+	TaskHeartbeat(logger lager.Logger, taskGuid, cellID, progressMessage string, progressPercent int32) error
 	FailTask(logger lager.Logger, taskGuid, failureReason string) error
 	CompleteTask(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) error
 }
@@ -72,12 +75,16 @@ type Client interface {
 	ExternalActualLRPClient
 	ExternalDesiredLRPClient
 	ExternalEventClient
+	ExternalTaskScheduleClient
 
 	// Returns true if the BBS server is reachable
 	Ping(logger lager.Logger) bool
 
 	// Lists all Cells
 	Cells(logger lager.Logger) ([]*models.CellPresence, error)
+
+	// Lists the audit records matching the filter; requires the SQL audit log
+	AuditRecords(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error)
 }
 
 /*
@@ -99,17 +106,56 @@ type ExternalTaskClient interface {
 	// Lists all Tasks on the given cell
 	TasksByCellID(logger lager.Logger, cellId string) ([]*models.Task, error)
 
+	// Lists all Tasks matching the given TaskFilter
+	TasksByFilter(logger lager.Logger, filter models.TaskFilter) ([]*models.Task, error)
+
 	// Returns the Task with the given guid
 	TaskByGuid(logger lager.Logger, guid string) (*models.Task, error)
 
 	// Cancels the Task with the given task guid
 	CancelTask(logger lager.Logger, taskGuid string) error
 
+	// Cancels the Task with the given task guid, recording the reason as its
+	// failure reason
+	CancelTaskWithReason(logger lager.Logger, taskGuid, reason string) error
+
+	// Cancels the Tasks matching the selector, returning the outcome for each
+	CancelTasks(logger lager.Logger, selector *models.TaskSelector, reason string) ([]*models.TaskResult, error)
+
 	// Resolves a Task with the given guid
 	ResolvingTask(logger lager.Logger, taskGuid string) error
 
 	// Deletes a completed task with the given guid
 	DeleteTask(logger lager.Logger, taskGuid string) error
+
+	// Deletes the completed and resolving Tasks matching the selector,
+	// returning the outcome for each
+	DeleteTasks(logger lager.Logger, selector *models.TaskSelector) ([]*models.TaskResult, error)
+
+	// Lists the most recently completed archived Tasks matching the filter, up
+	// to its limit or models.DefaultArchivedTasksLimit; requires the task archive
+	ArchivedTasks(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error)
+}
+
+/*
+The ExternalTaskScheduleClient is used to run tasks periodically. Task
+schedules are only available when the BBS is backed by a SQL database.
+*/
+type ExternalTaskScheduleClient interface {
+	// Lists the task schedules matching the filter
+	TaskSchedules(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error)
+
+	// Returns the task schedule with the given guid
+	TaskScheduleByGuid(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error)
+
+	// Creates a task schedule
+	DesireTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error
+
+	// Replaces the definition of an existing task schedule
+	UpdateTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error
+
+	// Removes a task schedule, leaving the tasks it created in place
+	RemoveTaskSchedule(logger lager.Logger, scheduleGuid string) error
 }
 
 /*
@@ -130,6 +176,10 @@ type ExternalActualLRPClient interface {
 	// Returns all ActualLRPGroups matching the given ActualLRPFilter
 	ActualLRPGroups(lager.Logger, models.ActualLRPFilter) ([]*models.ActualLRPGroup, error)
 
+	// Returns all ActualLRPs matching the given ActualLRPFilter, with ordinary
+	// and evacuating instances as separate records told apart by their presence
+	ActualLRPs(lager.Logger, models.ActualLRPFilter) ([]*models.ActualLRP, error)
+
 	// Returns all ActualLRPGroups that have the given process guid
 	ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) ([]*models.ActualLRPGroup, error)
 
@@ -168,6 +218,36 @@ The ExternalEventClient is used to subscribe to groups of Events.
 */
 type ExternalEventClient interface {
 	SubscribeToEvents(logger lager.Logger) (events.EventSource, error)
+
+	// Returns an EventSource for watching changes to Tasks
+	SubscribeToTaskEvents(logger lager.Logger) (events.EventSource, error)
+
+	// Returns an EventSource for watching changes to the Tasks matching the
+	// filter
+	SubscribeToTaskEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+
+	// Returns an EventSource for watching changes to the DesiredLRPs matching
+	// the filter
+	SubscribeToDesiredLRPEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+
+	// Returns an EventSource for watching changes to the ActualLRPs matching
+	// the filter
+	SubscribeToActualLRPEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+
+	// Returns an EventSource for watching cells join and leave
+	SubscribeToCellEvents(logger lager.Logger) (events.EventSource, error)
+
+	// Returns an EventSource for watching changes to the DesiredLRPs matching
+	// the filter, streamed over a WebSocket rather than server-sent events
+	SubscribeToDesiredLRPEventsOverWebSocket(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+
+	// Returns an EventSource for watching changes to the ActualLRPs matching
+	// the filter, streamed over a WebSocket rather than server-sent events
+	SubscribeToActualLRPEventsOverWebSocket(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+
+	// Returns an EventSource for watching changes to individual ActualLRPs
+	// matching the filter, rather than to ActualLRPGroups
+	SubscribeToLRPInstanceEvents(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
 }
 
 /*
@@ -281,6 +361,22 @@ func (c *client) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFil
 	return response.ActualLrpGroups, response.Error.ToError()
 }
 
+func (c *client) ActualLRPs(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRP, error) {
+	request := models.ActualLRPsRequest{
+		Domain:      filter.Domain,
+		CellId:      filter.CellID,
+		ProcessGuid: filter.ProcessGuid,
+		Index:       filter.Index,
+	}
+	response := models.ActualLRPsResponse{}
+	err := c.doRequest(logger, ActualLRPsRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ActualLrps, response.Error.ToError()
+}
+
 func (c *client) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) ([]*models.ActualLRPGroup, error) {
 	request := models.ActualLRPGroupsByProcessGuidRequest{
 		ProcessGuid: processGuid,
@@ -557,6 +653,21 @@ func (c *client) TasksByCellID(logger lager.Logger, cellId string) ([]*models.Ta
 	return response.Tasks, response.Error.ToError()
 }
 
+func (c *client) TasksByFilter(logger lager.Logger, filter models.TaskFilter) ([]*models.Task, error) {
+	request := models.TasksRequest{
+		Domain:      filter.Domain,
+		CellId:      filter.CellID,
+		MinPriority: filter.MinPriority,
+	}
+	response := models.TasksResponse{}
+	err := c.doRequest(logger, TasksRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Tasks, response.Error.ToError()
+}
+
 func (c *client) TaskByGuid(logger lager.Logger, taskGuid string) (*models.Task, error) {
 	request := models.TaskByGuidRequest{
 		TaskGuid: taskGuid,
@@ -602,14 +713,43 @@ func (c *client) StartTask(logger lager.Logger, taskGuid string, cellId string)
 	return response.ShouldStart, response.Error.ToError()
 }
 
+func (c *client) TaskHeartbeat(logger lager.Logger, taskGuid, cellID, progressMessage string, progressPercent int32) error {
+	request := models.TaskHeartbeatRequest{
+		TaskGuid:        taskGuid,
+		CellId:          cellID,
+		ProgressMessage: progressMessage,
+		ProgressPercent: progressPercent,
+	}
+	route := TaskHeartbeatRoute
+	return c.doTaskLifecycleRequest(logger, route, &request)
+}
+
 func (c *client) CancelTask(logger lager.Logger, taskGuid string) error {
-	request := models.TaskGuidRequest{
+	return c.CancelTaskWithReason(logger, taskGuid, "")
+}
+
+func (c *client) CancelTaskWithReason(logger lager.Logger, taskGuid, reason string) error {
+	request := models.CancelTaskRequest{
 		TaskGuid: taskGuid,
+		Reason:   reason,
 	}
 	route := CancelTaskRoute
 	return c.doTaskLifecycleRequest(logger, route, &request)
 }
 
+func (c *client) CancelTasks(logger lager.Logger, selector *models.TaskSelector, reason string) ([]*models.TaskResult, error) {
+	request := models.CancelTasksRequest{
+		Selector: selector,
+		Reason:   reason,
+	}
+	response := models.CancelTasksResponse{}
+	err := c.doRequest(logger, CancelTasksRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Results, response.Error.ToError()
+}
+
 func (c *client) ResolvingTask(logger lager.Logger, taskGuid string) error {
 	request := models.TaskGuidRequest{
 		TaskGuid: taskGuid,
@@ -626,6 +766,18 @@ func (c *client) DeleteTask(logger lager.Logger, taskGuid string) error {
 	return c.doTaskLifecycleRequest(logger, route, &request)
 }
 
+func (c *client) DeleteTasks(logger lager.Logger, selector *models.TaskSelector) ([]*models.TaskResult, error) {
+	request := models.DeleteTasksRequest{
+		Selector: selector,
+	}
+	response := models.DeleteTasksResponse{}
+	err := c.doRequest(logger, DeleteTasksRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Results, response.Error.ToError()
+}
+
 func (c *client) FailTask(logger lager.Logger, taskGuid, failureReason string) error {
 	request := models.FailTaskRequest{
 		TaskGuid:      taskGuid,
@@ -662,33 +814,111 @@ func (c *client) ConvergeTasks(logger lager.Logger, kickTaskDuration, expirePend
 	return response.Error.ToError()
 }
 
-func (c *client) subscribeToEvents(route string) (events.EventSource, error) {
-	eventSource, err := sse.Connect(c.streamingHTTPClient, time.Second, func() *http.Request {
-		request, err := c.reqGen.CreateRequest(route, nil, nil)
+func (c *client) subscribeToEvents(route string, filter models.EventFilter) (events.EventSource, error) {
+	values := events.NewFilterQuery(filter)
+	values.Set(events.HeartbeatParam, "true")
+	query := values.Encode()
+
+	connect := func() (events.RawEventSource, error) {
+		eventSource, err := sse.Connect(c.streamingHTTPClient, time.Second, func() *http.Request {
+			request, err := c.reqGen.CreateRequest(route, nil, nil)
+			if err != nil {
+				panic(err) // totally shouldn't happen
+			}
+			request.URL.RawQuery = query
+
+			return request
+		})
+
 		if err != nil {
-			panic(err) // totally shouldn't happen
+			return nil, err
 		}
 
-		return request
-	})
+		return eventSource, nil
+	}
 
+	eventSource, err := connect()
 	if err != nil {
 		return nil, err
 	}
 
-	return events.NewEventSource(eventSource), nil
+	return events.NewResumableEventSource(eventSource, connect), nil
+}
+
+func (c *client) subscribeToEventsOverWebSocket(route string, filter models.EventFilter) (events.EventSource, error) {
+	dialer := &websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	if tr, ok := c.streamingHTTPClient.Transport.(*http.Transport); ok {
+		dialer.TLSClientConfig = tr.TLSClientConfig
+	}
+
+	dial := func(lastEventID uint64) (*websocket.Conn, *http.Response, error) {
+		request, err := c.reqGen.CreateRequest(route, nil, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		query := events.NewFilterQuery(filter)
+		query.Set(events.WebSocketFormatParam, string(events.WebSocketProtobufFormat))
+		if lastEventID != 0 {
+			query.Set(events.WebSocketLastEventIDParam, strconv.FormatUint(lastEventID, 10))
+		}
+
+		wsURL := *request.URL
+		wsURL.RawQuery = query.Encode()
+		if wsURL.Scheme == "https" {
+			wsURL.Scheme = "wss"
+		} else {
+			wsURL.Scheme = "ws"
+		}
+
+		return dialer.Dial(wsURL.String(), nil)
+	}
+
+	return events.NewWebSocketEventSource(dial)
 }
 
 func (c *client) SubscribeToEvents(logger lager.Logger) (events.EventSource, error) {
-	return c.subscribeToEvents(EventStreamRoute_r0)
+	return c.subscribeToEvents(EventStreamRoute_r0, models.EventFilter{})
 }
 
 func (c *client) SubscribeToDesiredLRPEvents(logger lager.Logger) (events.EventSource, error) {
-	return c.subscribeToEvents(DesiredLRPEventStreamRoute)
+	return c.subscribeToEvents(DesiredLRPEventStreamRoute, models.EventFilter{})
 }
 
 func (c *client) SubscribeToActualLRPEvents(logger lager.Logger) (events.EventSource, error) {
-	return c.subscribeToEvents(ActualLRPEventStreamRoute)
+	return c.subscribeToEvents(ActualLRPEventStreamRoute, models.EventFilter{})
+}
+
+func (c *client) SubscribeToLRPInstanceEvents(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	return c.subscribeToEvents(LRPInstanceEventStreamRoute, filter)
+}
+
+func (c *client) SubscribeToTaskEvents(logger lager.Logger) (events.EventSource, error) {
+	return c.subscribeToEvents(TaskEventStreamRoute, models.EventFilter{})
+}
+
+func (c *client) SubscribeToCellEvents(logger lager.Logger) (events.EventSource, error) {
+	return c.subscribeToEvents(CellEventStreamRoute, models.EventFilter{})
+}
+
+func (c *client) SubscribeToTaskEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	return c.subscribeToEvents(TaskEventStreamRoute, filter)
+}
+
+func (c *client) SubscribeToDesiredLRPEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	return c.subscribeToEvents(DesiredLRPEventStreamRoute, filter)
+}
+
+func (c *client) SubscribeToActualLRPEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	return c.subscribeToEvents(ActualLRPEventStreamRoute, filter)
+}
+
+func (c *client) SubscribeToDesiredLRPEventsOverWebSocket(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	return c.subscribeToEventsOverWebSocket(DesiredLRPEventWebSocketRoute, filter)
+}
+
+func (c *client) SubscribeToActualLRPEventsOverWebSocket(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	return c.subscribeToEventsOverWebSocket(ActualLRPEventWebSocketRoute, filter)
 }
 
 func (c *client) Cells(logger lager.Logger) ([]*models.CellPresence, error) {
@@ -700,6 +930,92 @@ func (c *client) Cells(logger lager.Logger) ([]*models.CellPresence, error) {
 	return response.Cells, response.Error.ToError()
 }
 
+func (c *client) AuditRecords(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error) {
+	request := models.AuditRecordsRequest{
+		Since:      filter.Since,
+		Until:      filter.Until,
+		Identity:   filter.Identity,
+		Route:      filter.Route,
+		TargetGuid: filter.TargetGuid,
+		Limit:      int32(filter.Limit),
+	}
+	response := models.AuditRecordsResponse{}
+	err := c.doRequest(logger, AuditRecordsRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.AuditRecords, response.Error.ToError()
+}
+
+func (c *client) ArchivedTasks(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error) {
+	request := models.ArchivedTasksRequest{
+		Domain:         filter.Domain,
+		CompletedSince: filter.CompletedSince,
+		CompletedUntil: filter.CompletedUntil,
+		Limit:          int32(filter.Limit),
+	}
+	response := models.ArchivedTasksResponse{}
+	err := c.doRequest(logger, ArchivedTasksRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Tasks, response.Error.ToError()
+}
+
+func (c *client) TaskSchedules(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error) {
+	request := models.TaskSchedulesRequest{
+		Domain: filter.Domain,
+	}
+	response := models.TaskSchedulesResponse{}
+	err := c.doRequest(logger, TaskSchedulesRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.TaskSchedules, response.Error.ToError()
+}
+
+func (c *client) TaskScheduleByGuid(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error) {
+	request := models.TaskScheduleGuidRequest{
+		ScheduleGuid: scheduleGuid,
+	}
+	response := models.TaskScheduleResponse{}
+	err := c.doRequest(logger, TaskScheduleByGuidRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.TaskSchedule, response.Error.ToError()
+}
+
+func (c *client) DesireTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	request := models.DesireTaskScheduleRequest{
+		TaskSchedule: schedule,
+	}
+	return c.doTaskScheduleLifecycleRequest(logger, DesireTaskScheduleRoute, &request)
+}
+
+func (c *client) UpdateTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	request := models.UpdateTaskScheduleRequest{
+		TaskSchedule: schedule,
+	}
+	return c.doTaskScheduleLifecycleRequest(logger, UpdateTaskScheduleRoute, &request)
+}
+
+func (c *client) RemoveTaskSchedule(logger lager.Logger, scheduleGuid string) error {
+	request := models.TaskScheduleGuidRequest{
+		ScheduleGuid: scheduleGuid,
+	}
+	return c.doTaskScheduleLifecycleRequest(logger, RemoveTaskScheduleRoute, &request)
+}
+
+func (c *client) doTaskScheduleLifecycleRequest(logger lager.Logger, route string, request proto.Message) error {
+	response := models.TaskScheduleLifecycleResponse{}
+	err := c.doRequest(logger, route, nil, nil, request, &response)
+	if err != nil {
+		return err
+	}
+	return response.Error.ToError()
+}
+
 func (c *client) createRequest(requestName string, params rata.Params, queryParams url.Values, message proto.Message) (*http.Request, error) {
 	var messageBody []byte
 	var err error
@@ -746,6 +1062,11 @@ func (c *client) doRequest(logger lager.Logger, requestName string, params rata.
 
 		logger.Debug("doing-request", lager.Data{"attempt": attempts + 1})
 		err = c.do(request, responseBody)
+		if err == models.ErrUnauthorized {
+			// a forbidden request is forbidden however often it is retried
+			logger.Error("failed-doing-request", err)
+			return err
+		}
 		if err != nil {
 			logger.Error("failed-doing-request", err)
 			time.Sleep(500 * time.Millisecond)
@@ -802,6 +1123,10 @@ func handleProtoResponse(response *http.Response, responseObject proto.Message)
 }
 
 func handleNonProtoResponse(response *http.Response) error {
+	if response.StatusCode == http.StatusForbidden {
+		return models.ErrUnauthorized
+	}
+
 	if response.StatusCode > 299 {
 		return models.NewError(models.Error_InvalidResponse, fmt.Sprintf("Invalid Response with status code: %d", response.StatusCode))
 	}
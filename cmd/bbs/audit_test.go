@@ -0,0 +1,75 @@
+package main_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/audit"
+	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/test_helpers"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Log", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "audit")
+		Expect(err).NotTo(HaveOccurred())
+
+		bbsArgs.AuditLogFile = filepath.Join(tmpDir, "audit.log")
+	})
+
+	JustBeforeEach(func() {
+		bbsRunner = testrunner.New(bbsBinPath, bbsArgs)
+		bbsProcess = ginkgomon.Invoke(bbsRunner)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("records mutating requests to the audit log file", func() {
+		Expect(client.UpsertDomain(logger, "some-domain", 100*time.Second)).To(Succeed())
+
+		Eventually(func() string {
+			data, _ := ioutil.ReadFile(bbsArgs.AuditLogFile)
+			return string(data)
+		}).Should(ContainSubstring(`"route":"UpsertDomain"`))
+	})
+
+	if test_helpers.UseSQL() {
+		Context("when the audit log is kept in SQL", func() {
+			BeforeEach(func() {
+				bbsArgs.SQLAuditLog = true
+			})
+
+			It("serves a verifiable chain of audit records", func() {
+				Expect(client.UpsertDomain(logger, "some-domain", 100*time.Second)).To(Succeed())
+				Expect(client.DesireTask(logger, "some-task-guid", "some-domain", model_helpers.NewValidTaskDefinition())).To(Succeed())
+
+				records, err := client.AuditRecords(logger, models.AuditRecordFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].Route).To(Equal(bbs.UpsertDomainRoute))
+				Expect(records[1].Route).To(Equal(bbs.DesireTaskRoute))
+				Expect(records[1].TargetGuid).To(Equal("some-task-guid"))
+				Expect(audit.Verify(records)).To(Succeed())
+			})
+		})
+	} else {
+		It("does not serve audit records", func() {
+			_, err := client.AuditRecords(logger, models.AuditRecordFilter{})
+			Expect(err).To(HaveOccurred())
+		})
+	}
+})
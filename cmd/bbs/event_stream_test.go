@@ -325,6 +325,155 @@ var _ = Describe("Events API", func() {
 		})
 	})
 
+	Describe("Task Events", func() {
+		JustBeforeEach(func() {
+			var err error
+			eventSource, err = client.SubscribeToTaskEvents(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			eventChannel = streamEvents(eventSource)
+
+			primerTask := model_helpers.NewValidTaskDefinition()
+			primeEventStream(eventChannel, models.EventTypeTaskRemoved, func() {
+				err := client.DesireTask(logger, "primer-guid", "primer-domain", primerTask)
+				Expect(err).NotTo(HaveOccurred())
+			}, func() {
+				err := client.CancelTask(logger, "primer-guid")
+				Expect(err).NotTo(HaveOccurred())
+				err = client.ResolvingTask(logger, "primer-guid")
+				Expect(err).NotTo(HaveOccurred())
+				err = client.DeleteTask(logger, "primer-guid")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		AfterEach(func() {
+			err := eventSource.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(eventChannel).Should(BeClosed())
+		})
+
+		It("receives events", func() {
+			By("desiring a Task")
+			err := client.DesireTask(logger, "task-guid", "some-domain", model_helpers.NewValidTaskDefinition())
+			Expect(err).NotTo(HaveOccurred())
+
+			var event models.Event
+			Eventually(eventChannel).Should(Receive(&event))
+
+			taskCreatedEvent, ok := event.(*models.TaskCreatedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(taskCreatedEvent.Task.TaskGuid).To(Equal("task-guid"))
+			Expect(taskCreatedEvent.Task.State).To(Equal(models.Task_Pending))
+
+			By("starting the Task")
+			_, err = client.StartTask(logger, "task-guid", "cell-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(eventChannel).Should(Receive(&event))
+
+			taskChangedEvent, ok := event.(*models.TaskChangedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(taskChangedEvent.Before.State).To(Equal(models.Task_Pending))
+			Expect(taskChangedEvent.After.State).To(Equal(models.Task_Running))
+
+			By("completing the Task")
+			err = client.CompleteTask(logger, "task-guid", "cell-id", false, "", "result")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(eventChannel).Should(Receive(&event))
+
+			taskChangedEvent, ok = event.(*models.TaskChangedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(taskChangedEvent.After.State).To(Equal(models.Task_Completed))
+			Expect(taskChangedEvent.After.Result).To(Equal("result"))
+
+			By("resolving the Task")
+			err = client.ResolvingTask(logger, "task-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(eventChannel).Should(Receive(&event))
+			Expect(event).To(BeAssignableToTypeOf(&models.TaskChangedEvent{}))
+
+			By("deleting the Task")
+			err = client.DeleteTask(logger, "task-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(eventChannel).Should(Receive(&event))
+
+			taskRemovedEvent, ok := event.(*models.TaskRemovedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(taskRemovedEvent.Task.TaskGuid).To(Equal("task-guid"))
+		})
+	})
+
+	Describe("Filtered Task Events", func() {
+		BeforeEach(func() {
+			var err error
+			eventSource, err = client.SubscribeToTaskEventsByFilter(logger, models.EventFilter{
+				Domain:     "some-domain",
+				EventTypes: []string{models.EventTypeTaskCreated},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			eventChannel = streamEvents(eventSource)
+		})
+
+		AfterEach(func() {
+			err := eventSource.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(eventChannel).Should(BeClosed())
+		})
+
+		It("receives only the events matching the filter", func() {
+			err := client.DesireTask(logger, "other-task-guid", "other-domain", model_helpers.NewValidTaskDefinition())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.DesireTask(logger, "task-guid", "some-domain", model_helpers.NewValidTaskDefinition())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.CancelTask(logger, "task-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			var event models.Event
+			Eventually(eventChannel).Should(Receive(&event))
+
+			taskCreatedEvent, ok := event.(*models.TaskCreatedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(taskCreatedEvent.Task.TaskGuid).To(Equal("task-guid"))
+
+			Consistently(eventChannel).ShouldNot(Receive())
+		})
+	})
+
+	Describe("Cell Events", func() {
+		BeforeEach(func() {
+			var err error
+			eventSource, err = client.SubscribeToCellEvents(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			eventChannel = streamEvents(eventSource)
+		})
+
+		AfterEach(func() {
+			err := eventSource.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(eventChannel).Should(BeClosed())
+		})
+
+		It("receives an event when a cell appears", func() {
+			cellPresence := models.NewCellPresence("cell-id", "1.1.1.1", "z1", models.NewCellCapacity(128, 1024, 6), nil, nil)
+			consulHelper.RegisterCell(&cellPresence)
+
+			var event models.Event
+			Eventually(eventChannel).Should(Receive(&event))
+
+			cellAppearedEvent, ok := event.(*models.CellAppearedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(cellAppearedEvent.CellPresence).To(Equal(&cellPresence))
+		})
+	})
+
 	It("cleans up exiting connections when killing the BBS", func(done Done) {
 		var err error
 		eventSource, err = client.SubscribeToEvents(logger)
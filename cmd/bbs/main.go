@@ -12,10 +12,13 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/cloudfoundry-incubator/auctioneer"
 	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/audit"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
 	"github.com/cloudfoundry-incubator/bbs/db"
 	etcddb "github.com/cloudfoundry-incubator/bbs/db/etcd"
 	"github.com/cloudfoundry-incubator/bbs/db/migrations"
@@ -29,14 +32,21 @@ import (
 	"github.com/cloudfoundry-incubator/bbs/metrics"
 	"github.com/cloudfoundry-incubator/bbs/migration"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/outbox"
+	"github.com/cloudfoundry-incubator/bbs/taskscheduler"
 	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	"github.com/cloudfoundry-incubator/bbs/webhook"
 	"github.com/cloudfoundry-incubator/cf-debug-server"
 	"github.com/cloudfoundry-incubator/cf-lager"
 	"github.com/cloudfoundry-incubator/cf_http"
 	"github.com/cloudfoundry-incubator/consuladapter"
 	"github.com/cloudfoundry-incubator/locket"
 	"github.com/cloudfoundry-incubator/rep"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
 	"github.com/cloudfoundry/dropsonde"
+	"github.com/cloudfoundry/dropsonde/metric_sender"
+	"github.com/cloudfoundry/dropsonde/metricbatcher"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
 	etcdclient "github.com/coreos/go-etcd/etcd"
 	"github.com/go-sql-driver/mysql"
 	"github.com/hashicorp/consul/api"
@@ -79,6 +89,60 @@ var keyFile = flag.String(
 	"the private key file to use with ssl authentication",
 )
 
+var authorizationPolicyFile = flag.String(
+	"authorizationPolicyFile",
+	"",
+	"path to a JSON file mapping client certificate subjects and OUs to roles; requires requireSSL (all clients may call every route if empty)",
+)
+
+var requireCellIdentity = flag.Bool(
+	"requireCellIdentity",
+	false,
+	"whether cells may only change the state of ActualLRPs and Tasks whose cell id matches the common name of their client certificate; requires requireSSL",
+)
+
+var sqlAuditLog = flag.Bool(
+	"sqlAuditLog",
+	false,
+	"whether to record every mutating request in the audit_records table; requires a SQL database",
+)
+
+var auditLogFile = flag.String(
+	"auditLogFile",
+	"",
+	"path to a local file to which every mutating request is recorded (no file if empty)",
+)
+
+var auditLogMaxSize = flag.Int64(
+	"auditLogMaxSize",
+	100*1024*1024,
+	"size in bytes beyond which the audit log file is rotated",
+)
+
+var auditLogMaxBackups = flag.Int(
+	"auditLogMaxBackups",
+	10,
+	"number of rotated audit log files to keep",
+)
+
+var archiveCompletedTasks = flag.Bool(
+	"archiveCompletedTasks",
+	false,
+	"whether to archive completed tasks in the archived_tasks table when they expire, instead of only deleting them; requires a SQL database",
+)
+
+var archivedTaskRetention = flag.Duration(
+	"archivedTaskRetention",
+	7*24*time.Hour,
+	"how long after they completed archived tasks are kept before convergence deletes them",
+)
+
+var webhookConfigFile = flag.String(
+	"webhookConfigFile",
+	"",
+	"path to a JSON file listing the HTTP endpoints to which events are posted (no webhooks if empty)",
+)
+
 var healthAddress = flag.String(
 	"healthAddress",
 	"",
@@ -139,6 +203,12 @@ var dropsondePort = flag.Int(
 	"port the local metron agent is listening on",
 )
 
+var prometheusListenAddress = flag.String(
+	"prometheusListenAddress",
+	"",
+	"The host:port on which to serve metrics in the Prometheus text format (disabled if empty)",
+)
+
 var convergenceWorkers = flag.Int(
 	"convergenceWorkers",
 	20,
@@ -157,12 +227,54 @@ var taskCallBackWorkers = flag.Int(
 	"Max concurrency for task callback requests",
 )
 
+var taskCallbackConfigFile = flag.String(
+	"taskCallbackConfigFile",
+	"",
+	"path to a JSON file configuring the retries, signing secrets and TLS of task completion callbacks (defaults if empty)",
+)
+
 var desiredLRPCreationTimeout = flag.Duration(
 	"desiredLRPCreationTimeout",
 	1*time.Minute,
 	"Expected maximum time to create all components of a desired LRP",
 )
 
+var eventSubscriberBufferSize = flag.Int(
+	"eventSubscriberBufferSize",
+	events.MAX_PENDING_SUBSCRIBER_EVENTS,
+	"number of events queued for an event stream subscriber before the slow consumer policy applies",
+)
+
+var slowEventSubscriberPolicy = flag.String(
+	"slowEventSubscriberPolicy",
+	string(events.DisconnectSlowConsumers),
+	"what to do when an event stream subscriber's queue is full: disconnect, drop-oldest or coalesce",
+)
+
+var eventStreamHeartbeatInterval = flag.Duration(
+	"eventStreamHeartbeatInterval",
+	30*time.Second,
+	"how long an event stream may be idle before a heartbeat is sent, and how often WebSocket event streams are pinged; 0 disables both",
+)
+
+var eventOutboxPollInterval = flag.Duration(
+	"eventOutboxPollInterval",
+	100*time.Millisecond,
+	"how often to publish new events from the event outbox; requires a SQL database",
+)
+
+var taskSchedulePollInterval = flag.Duration(
+	"taskSchedulePollInterval",
+	10*time.Second,
+	"how often to create the tasks of task schedules that have come due; requires a SQL database",
+)
+
+var taskCallbackPollInterval = flag.Duration(
+	"taskCallbackPollInterval",
+	time.Second,
+	"how often to attempt the queued task completion callbacks that have come due; requires a SQL database",
+)
+
 var databaseConnectionString = flag.String(
 	"databaseConnectionString",
 	"",
@@ -190,6 +302,13 @@ var sqlCACertFile = flag.String(
 const (
 	dropsondeOrigin           = "bbs"
 	bbsWatchRetryWaitDuration = 3 * time.Second
+
+	metricBatcherFlushInterval = 5 * time.Second
+
+	desiredLRPEventSubscribers = metric.Metric("DesiredLRPEventSubscribers")
+	actualLRPEventSubscribers  = metric.Metric("ActualLRPEventSubscribers")
+	taskEventSubscribers       = metric.Metric("TaskEventSubscribers")
+	cellEventSubscribers       = metric.Metric("CellEventSubscribers")
 )
 
 func main() {
@@ -206,6 +325,7 @@ func main() {
 	logger.Info("starting")
 
 	initializeDropsonde(logger)
+	prometheusSender := initializePrometheusSender(logger)
 
 	clock := clock.NewClock()
 
@@ -238,7 +358,8 @@ func main() {
 
 	registrationRunner := initializeRegistrationRunner(logger, consulClient, portNum, clock)
 
-	cbWorkPool := taskworkpool.New(logger, *taskCallBackWorkers, taskworkpool.HandleCompletedTask)
+	callbackConfig, callbackClient := initializeTaskCallbacks(logger)
+	cbWorkPool := taskworkpool.New(logger, *taskCallBackWorkers, callbackClient, taskworkpool.NewCompletedTaskHandler(callbackConfig, clock))
 
 	var activeDB db.DB
 	var sqlDB *sqldb.SQLDB
@@ -313,11 +434,67 @@ func main() {
 		*databaseDriver,
 	)
 
-	desiredHub := events.NewHub()
-	actualHub := events.NewHub()
+	hubConfig := initializeHubConfig(logger)
+
+	var desiredHub, actualHub, taskHub events.Hub
+	if sqlDB != nil {
+		// The SQL database records each event in its outbox, in the same
+		// transaction as the change, and the outbox publisher alone feeds them
+		// to the hubs. Everything else that changes records through the
+		// database is given an OutboxOnly view of the hubs.
+		desiredHub = events.NewSequencedHubWithConfig(hubConfig)
+		actualHub = events.NewSequencedHubWithConfig(hubConfig)
+		taskHub = events.NewSequencedHubWithConfig(hubConfig)
+	} else {
+		desiredHub = events.NewHubWithConfig(hubConfig)
+		actualHub = events.NewHubWithConfig(hubConfig)
+		taskHub = events.NewHubWithConfig(hubConfig)
+	}
+	cellHub := events.NewHubWithConfig(hubConfig)
+
+	namedHubs := map[string]events.Hub{
+		"DesiredLRP": desiredHub,
+		"ActualLRP":  actualHub,
+		"Task":       taskHub,
+		"Cell":       cellHub,
+	}
 
 	repClientFactory := rep.NewClientFactory(cf_http.NewClient(), cf_http.NewClient())
 	auctioneerClient := initializeAuctioneerClient(logger)
+	authorizationPolicy := initializeAuthorizationPolicy(logger)
+
+	if *requireCellIdentity && !*requireSSL {
+		logger.Fatal("cell-identity-validation-failed", errors.New("requireCellIdentity requires requireSSL"))
+	}
+
+	auditor, auditDB, auditFileStore := initializeAuditor(logger, sqlDB, clock)
+	if auditFileStore != nil {
+		defer auditFileStore.Close()
+	}
+
+	var taskArchiveDB db.TaskArchiveDB
+	if *archiveCompletedTasks {
+		if sqlDB == nil {
+			logger.Fatal("task-archive-validation-failed", errors.New("archiveCompletedTasks requires a SQL database"))
+		}
+		if *archivedTaskRetention <= 0 {
+			logger.Fatal("task-archive-validation-failed", errors.New("archivedTaskRetention must be positive"))
+		}
+		sqlDB.EnableTaskArchive(*archivedTaskRetention)
+		taskArchiveDB = sqlDB
+	}
+
+	var taskScheduleDB db.TaskScheduleDB
+	var callbackQueue *taskworkpool.CallbackQueue
+	var taskCompletionClient taskworkpool.TaskCompletionClient = cbWorkPool
+	if sqlDB != nil {
+		taskScheduleDB = sqlDB
+
+		// Callbacks are queued in the SQL database, so that they outlive this
+		// BBS, and delivered by the same number of workers.
+		callbackQueue = taskworkpool.NewCallbackQueue(logger, *taskCallBackWorkers, callbackClient, callbackConfig, clock, sqlDB, sqlDB, events.OutboxOnly(taskHub), *taskCallbackPollInterval)
+		taskCompletionClient = callbackQueue
+	}
 
 	exitChan := make(chan struct{})
 
@@ -328,11 +505,22 @@ func main() {
 		activeDB,
 		desiredHub,
 		actualHub,
-		cbWorkPool,
+		taskHub,
+		cellHub,
+		sqlDB != nil,
+		*eventStreamHeartbeatInterval,
+		taskCompletionClient,
 		serviceClient,
 		auctioneerClient,
 		repClientFactory,
+		authorizationPolicy,
+		*requireCellIdentity,
+		auditor,
+		auditDB,
+		taskScheduleDB,
+		taskArchiveDB,
 		migrationsDone,
+		clock,
 		exitChan,
 	)
 
@@ -340,6 +528,8 @@ func main() {
 		logger,
 		*reportInterval,
 		etcdOptions,
+		sqlConn,
+		*databaseDriver,
 		clock,
 	)
 
@@ -354,7 +544,10 @@ func main() {
 		server = http_server.New(*listenAddress, handler)
 	}
 
-	healthcheckServer := http_server.New(*healthAddress, http.HandlerFunc(healthCheckHandler))
+	healthMux := http.NewServeMux()
+	healthMux.Handle("/debug/event-subscribers", events.NewStatsHandler(namedHubs))
+	healthMux.HandleFunc("/", healthCheckHandler)
+	healthcheckServer := http_server.New(*healthAddress, healthMux)
 
 	members := grouper.Members{
 		{"healthcheck", healthcheckServer},
@@ -363,9 +556,36 @@ func main() {
 		{"server", server},
 		{"migration-manager", migrationManager},
 		{"encryptor", encryptor},
-		{"hub-maintainer", hubMaintainer(logger, desiredHub, actualHub)},
+	}
+
+	if sqlDB != nil {
+		publisher := outbox.NewPublisher(logger, sqlDB, desiredHub, actualHub, taskHub, *eventOutboxPollInterval, clock)
+		members = append(members, grouper.Member{Name: "event-outbox-publisher", Runner: publisher})
+
+		// Runs the Replace policy cancels go through the same path as the
+		// CancelTask endpoint, so their dependents and callbacks are handled.
+		taskCanceller := handlers.NewTaskHandler(logger, *updateWorkers, sqlDB, events.OutboxOnly(taskHub), taskCompletionClient, auctioneerClient, serviceClient, repClientFactory, clock, exitChan)
+		scheduler := taskscheduler.NewScheduler(logger, sqlDB, taskCanceller, auctioneerClient, *taskSchedulePollInterval, clock)
+		members = append(members, grouper.Member{Name: "task-scheduler", Runner: scheduler})
+
+		members = append(members, grouper.Member{Name: "task-callback-queue", Runner: callbackQueue})
+	}
+
+	members = append(members, grouper.Members{
+		{"hub-maintainer", hubMaintainer(logger, desiredHub, actualHub, taskHub, cellHub)},
+	}...)
+
+	members = append(members, initializeWebhookSinks(logger, []events.Hub{desiredHub, actualHub, taskHub}, clock)...)
+
+	members = append(members, grouper.Members{
+		{"cell-event-forwarder", cellEventForwarder(logger, serviceClient, cellHub)},
 		{"metrics", *metricsNotifier},
+		{"hub-metrics", metrics.NewHubMetricsNotifier(logger, *reportInterval, namedHubs, clock)},
 		{"registration-runner", registrationRunner},
+	}...)
+
+	if prometheusSender != nil {
+		members = append(members, grouper.Member{Name: "prometheus-server", Runner: http_server.New(*prometheusListenAddress, prometheusSender)})
 	}
 
 	if dbgAddr := cf_debug_server.DebugAddress(flag.CommandLine); dbgAddr != "" {
@@ -435,9 +655,21 @@ func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-func hubMaintainer(logger lager.Logger, desiredHub, actualHub events.Hub) ifrit.RunFunc {
+func hubMaintainer(logger lager.Logger, desiredHub, actualHub, taskHub, cellHub events.Hub) ifrit.RunFunc {
 	return func(signals <-chan os.Signal, ready chan<- struct{}) error {
 		logger := logger.Session("hub-maintainer")
+		desiredHub.RegisterCallback(func(count int) {
+			desiredLRPEventSubscribers.Send(count)
+		})
+		actualHub.RegisterCallback(func(count int) {
+			actualLRPEventSubscribers.Send(count)
+		})
+		taskHub.RegisterCallback(func(count int) {
+			taskEventSubscribers.Send(count)
+		})
+		cellHub.RegisterCallback(func(count int) {
+			cellEventSubscribers.Send(count)
+		})
 		close(ready)
 		logger.Info("started")
 		defer logger.Info("finished")
@@ -451,10 +683,37 @@ func hubMaintainer(logger lager.Logger, desiredHub, actualHub events.Hub) ifrit.
 		if err != nil {
 			logger.Error("error-closing-actual-hub", err)
 		}
+		err = taskHub.Close()
+		if err != nil {
+			logger.Error("error-closing-task-hub", err)
+		}
+		err = cellHub.Close()
+		if err != nil {
+			logger.Error("error-closing-cell-hub", err)
+		}
 		return nil
 	}
 }
 
+func cellEventForwarder(logger lager.Logger, serviceClient bbs.ServiceClient, cellHub events.Hub) ifrit.RunFunc {
+	return func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		logger := logger.Session("cell-event-forwarder")
+		cellEvents := serviceClient.CellEvents(logger)
+		close(ready)
+		logger.Info("started")
+		defer logger.Info("finished")
+
+		for {
+			select {
+			case event := <-cellEvents:
+				cellHub.Emit(event)
+			case <-signals:
+				return nil
+			}
+		}
+	}
+}
+
 func initializeRegistrationRunner(
 	logger lager.Logger,
 	consulClient consuladapter.Client,
@@ -496,6 +755,119 @@ func initializeAuctioneerClient(logger lager.Logger) auctioneer.Client {
 	return auctioneer.NewClient(*auctioneerAddress)
 }
 
+func initializeAuthorizationPolicy(logger lager.Logger) *authorization.Policy {
+	if *authorizationPolicyFile == "" {
+		return nil
+	}
+
+	if !*requireSSL {
+		logger.Fatal("authorization-policy-validation-failed", errors.New("authorizationPolicyFile requires requireSSL"))
+	}
+
+	policy, err := authorization.LoadPolicy(*authorizationPolicyFile)
+	if err != nil {
+		logger.Fatal("failed-to-load-authorization-policy", err)
+	}
+
+	return policy
+}
+
+// initializeAuditor returns nil when no audit log is configured. The SQL
+// audit log, when configured, is the authoritative store and the only one
+// that can be queried.
+func initializeAuditor(logger lager.Logger, sqlDB *sqldb.SQLDB, clock clock.Clock) (audit.Auditor, db.AuditDB, *audit.FileStore) {
+	var stores []audit.Store
+	var auditDB db.AuditDB
+	var fileStore *audit.FileStore
+
+	if *sqlAuditLog {
+		if sqlDB == nil {
+			logger.Fatal("audit-log-validation-failed", errors.New("sqlAuditLog requires a SQL database"))
+		}
+		auditDB = sqlDB
+		stores = append(stores, sqlDB)
+	}
+
+	if *auditLogFile != "" {
+		var err error
+		fileStore, err = audit.NewFileStore(*auditLogFile, *auditLogMaxSize, *auditLogMaxBackups)
+		if err != nil {
+			logger.Fatal("failed-to-open-audit-log-file", err)
+		}
+		stores = append(stores, fileStore)
+	}
+
+	if len(stores) == 0 {
+		return nil, nil, nil
+	}
+
+	return audit.NewAuditor(clock, stores...), auditDB, fileStore
+}
+
+func initializeHubConfig(logger lager.Logger) events.HubConfig {
+	policy := events.SlowConsumerPolicy(*slowEventSubscriberPolicy)
+	if !policy.Valid() {
+		logger.Fatal("slow-event-subscriber-policy-validation-failed", fmt.Errorf("unknown slow event subscriber policy '%s'", policy))
+	}
+
+	if *eventSubscriberBufferSize <= 0 {
+		logger.Fatal("event-subscriber-buffer-size-validation-failed", errors.New("eventSubscriberBufferSize must be positive"))
+	}
+
+	return events.HubConfig{
+		MaxPendingEvents:   *eventSubscriberBufferSize,
+		SlowConsumerPolicy: policy,
+	}
+}
+
+func initializeTaskCallbacks(logger lager.Logger) (*taskworkpool.CallbackConfig, *http.Client) {
+	callbackConfig := taskworkpool.DefaultCallbackConfig()
+	if *taskCallbackConfigFile != "" {
+		var err error
+		callbackConfig, err = taskworkpool.LoadCallbackConfig(*taskCallbackConfigFile)
+		if err != nil {
+			logger.Fatal("failed-to-load-task-callback-config", err)
+		}
+	}
+
+	callbackClient, err := callbackConfig.NewHTTPClient()
+	if err != nil {
+		logger.Fatal("task-callback-tls-configuration-failed", err)
+	}
+
+	return callbackConfig, callbackClient
+}
+
+// initializeWebhookSinks returns a member for each configured webhook
+// endpoint. They start after the hubs' maintainer so that they stop before it
+// closes the hubs.
+func initializeWebhookSinks(logger lager.Logger, hubs []events.Hub, clock clock.Clock) grouper.Members {
+	if *webhookConfigFile == "" {
+		return nil
+	}
+
+	config, err := webhook.LoadConfig(*webhookConfigFile)
+	if err != nil {
+		logger.Fatal("failed-to-load-webhook-config", err)
+	}
+
+	members := grouper.Members{}
+	for _, endpoint := range config.Endpoints {
+		var deadLetters *webhook.DeadLetterStore
+		if config.DeadLetterDir != "" {
+			deadLetters, err = webhook.NewDeadLetterStore(filepath.Join(config.DeadLetterDir, endpoint.Name), endpoint.MaxDeadLetters)
+			if err != nil {
+				logger.Fatal("failed-to-open-webhook-dead-letter-store", err, lager.Data{"endpoint": endpoint.Name})
+			}
+		}
+
+		sink := webhook.NewSink(logger, endpoint, hubs, deadLetters, clock)
+		members = append(members, grouper.Member{Name: "webhook-" + endpoint.Name, Runner: sink})
+	}
+
+	return members
+}
+
 func initializeDropsonde(logger lager.Logger) {
 	dropsondeDestination := fmt.Sprint("localhost:", *dropsondePort)
 	err := dropsonde.Initialize(dropsondeDestination, dropsondeOrigin)
@@ -504,6 +876,24 @@ func initializeDropsonde(logger lager.Logger) {
 	}
 }
 
+// initializePrometheusSender tees everything sent through runtime-schema
+// metrics into a sender that can be scraped by Prometheus, so deployments
+// without a metron agent can still collect BBS metrics.
+func initializePrometheusSender(logger lager.Logger) *metrics.PrometheusSender {
+	if *prometheusListenAddress == "" {
+		return nil
+	}
+
+	_, _, err := net.SplitHostPort(*prometheusListenAddress)
+	if err != nil {
+		logger.Fatal("failed-invalid-prometheus-listen-address", err)
+	}
+
+	sender := metrics.NewPrometheusSender(metric_sender.NewMetricSender(dropsonde.AutowiredEmitter()))
+	dropsonde_metrics.Initialize(sender, metricbatcher.New(sender, metricBatcherFlushInterval))
+	return sender
+}
+
 func initializeEtcdDB(
 	logger lager.Logger,
 	cryptor encryption.Cryptor,
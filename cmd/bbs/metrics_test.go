@@ -1,6 +1,10 @@
 package main_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
 	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
 	"github.com/tedsuo/ifrit/ginkgomon"
 
@@ -18,3 +22,31 @@ var _ = Describe("ETCD Metrics", func() {
 		Eventually(testMetricsChan).Should(Receive())
 	})
 })
+
+var _ = Describe("Prometheus Metrics", func() {
+	var prometheusAddress string
+
+	BeforeEach(func() {
+		prometheusAddress = fmt.Sprintf("127.0.0.1:%d", 6800+GinkgoParallelNode())
+		bbsArgs.PrometheusListenAddress = prometheusAddress
+
+		bbsRunner = testrunner.New(bbsBinPath, bbsArgs)
+		bbsProcess = ginkgomon.Invoke(bbsRunner)
+	})
+
+	It("serves the metrics it sends to metron", func() {
+		Eventually(testMetricsChan).Should(Receive())
+
+		Eventually(func() string {
+			resp, err := http.Get("http://" + prometheusAddress + "/metrics")
+			if err != nil {
+				return ""
+			}
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			return string(body)
+		}).Should(ContainSubstring("bbs_master_elected_total 1"))
+	})
+})
@@ -1,11 +1,14 @@
 package main_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs"
 	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
+	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/tedsuo/ifrit/ginkgomon"
 
 	. "github.com/onsi/ginkgo"
@@ -75,6 +78,76 @@ var _ = Describe("Secure", func() {
 			client, err = bbs.NewSecureClient(bbsURL.String(), "", "", "", 0, 0)
 			Expect(err).To(HaveOccurred())
 		})
+
+		Context("with an authorization policy", func() {
+			var policyPath string
+
+			BeforeEach(func() {
+				policyFile, err := ioutil.TempFile("", "authorization-policy")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = policyFile.WriteString(`{"rules": [{"role": "read-only", "common_names": ["bbs client"]}]}`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policyFile.Close()).To(Succeed())
+
+				policyPath = policyFile.Name()
+				bbsArgs.AuthorizationPolicyFile = policyPath
+			})
+
+			AfterEach(func() {
+				os.Remove(policyPath)
+			})
+
+			JustBeforeEach(func() {
+				caFile := path.Join(basePath, "green-certs", "server-ca.crt")
+				certFile := path.Join(basePath, "green-certs", "client.crt")
+				keyFile := path.Join(basePath, "green-certs", "client.key")
+				client, err = bbs.NewSecureClient(bbsURL.String(), caFile, certFile, keyFile, 0, 0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("allows the routes permitted to the client's roles", func() {
+				Expect(client.Ping(logger)).To(BeTrue())
+
+				_, err := client.Domains(logger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects the routes not permitted to the client's roles", func() {
+				err := client.UpsertDomain(logger, "some-domain", 0)
+				Expect(err).To(Equal(models.ErrUnauthorized))
+			})
+
+			It("does not retry a rejected request", func() {
+				start := time.Now()
+				err := client.UpsertDomain(logger, "some-domain", 0)
+				Expect(err).To(Equal(models.ErrUnauthorized))
+				Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+			})
+		})
+
+		Context("when cell identity is required", func() {
+			BeforeEach(func() {
+				bbsArgs.RequireCellIdentity = true
+			})
+
+			JustBeforeEach(func() {
+				caFile := path.Join(basePath, "green-certs", "server-ca.crt")
+				certFile := path.Join(basePath, "green-certs", "client.crt")
+				keyFile := path.Join(basePath, "green-certs", "client.key")
+				client, err = bbs.NewSecureClient(bbsURL.String(), caFile, certFile, keyFile, 0, 0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("allows a cell to change the state of its own work", func() {
+				_, err := client.StartTask(logger, "some-task-guid", "bbs client")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+
+			It("rejects a cell changing the state of another cell's work", func() {
+				_, err := client.StartTask(logger, "some-task-guid", "some-other-cell")
+				Expect(err).To(Equal(models.ErrUnauthorized))
+			})
+		})
 	})
 
 	Context("when configuring a client without mutual SSL (skipping verification)", func() {
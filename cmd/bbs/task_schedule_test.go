@@ -0,0 +1,65 @@
+package main_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/test_helpers"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Task Schedule API", func() {
+	var schedule *models.TaskSchedule
+
+	BeforeEach(func() {
+		bbsRunner = testrunner.New(bbsBinPath, bbsArgs)
+		bbsProcess = ginkgomon.Invoke(bbsRunner)
+
+		schedule = &models.TaskSchedule{
+			ScheduleGuid:      "some-schedule-guid",
+			Domain:            "some-domain",
+			CronExpression:    "0 3 * * *",
+			TaskDefinition:    model_helpers.NewValidTaskDefinition(),
+			ConcurrencyPolicy: models.TaskSchedule_Forbid,
+			HistoryLimit:      3,
+		}
+	})
+
+	if test_helpers.UseSQL() {
+		It("creates, updates and removes task schedules", func() {
+			Expect(client.DesireTaskSchedule(logger, schedule)).To(Succeed())
+
+			schedules, err := client.TaskSchedules(logger, models.TaskScheduleFilter{Domain: "some-domain"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedules).To(HaveLen(1))
+			Expect(schedules[0].CronExpression).To(Equal("0 3 * * *"))
+
+			schedule.CronExpression = "@hourly"
+			Expect(client.UpdateTaskSchedule(logger, schedule)).To(Succeed())
+
+			fetched, err := client.TaskScheduleByGuid(logger, "some-schedule-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetched.CronExpression).To(Equal("@hourly"))
+
+			Expect(client.RemoveTaskSchedule(logger, "some-schedule-guid")).To(Succeed())
+
+			_, err = client.TaskScheduleByGuid(logger, "some-schedule-guid")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("rejects invalid schedules", func() {
+			schedule.CronExpression = "whenever"
+
+			err := client.DesireTaskSchedule(logger, schedule)
+			Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidRequest))
+		})
+	} else {
+		It("reports that task schedules require a SQL database", func() {
+			err := client.DesireTaskSchedule(logger, schedule)
+			Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidRequest))
+		})
+	}
+})
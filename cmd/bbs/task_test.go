@@ -75,6 +75,42 @@ var _ = Describe("Task API", func() {
 		})
 	})
 
+	Describe("CancelTasks", func() {
+		It("cancels the selected tasks", func() {
+			results, err := client.CancelTasks(logger, &models.TaskSelector{Domain: "b-domain"}, "clearing b-domain")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]*models.TaskResult{{TaskGuid: "b-guid"}}))
+
+			task, err := client.TaskByGuid(logger, "b-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.State).To(Equal(models.Task_Completed))
+			Expect(task.FailureReason).To(Equal("clearing b-domain"))
+
+			task, err = client.TaskByGuid(logger, "a-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.State).To(Equal(models.Task_Pending))
+		})
+	})
+
+	Describe("DeleteTasks", func() {
+		BeforeEach(func() {
+			_, err := client.CancelTasks(logger, &models.TaskSelector{Domain: "b-domain"}, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the selected completed tasks", func() {
+			results, err := client.DeleteTasks(logger, &models.TaskSelector{State: models.Task_Completed})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]*models.TaskResult{{TaskGuid: "b-guid"}}))
+
+			_, err = client.TaskByGuid(logger, "b-guid")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			_, err = client.TaskByGuid(logger, "a-guid")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("Task Lifecycle", func() {
 		var taskDef = model_helpers.NewValidTaskDefinition()
 		const taskGuid = "task-1"
@@ -115,6 +151,15 @@ var _ = Describe("Task API", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(task.FailureReason).To(Equal("task was cancelled"))
 			})
+
+			It("records the reason the task was cancelled", func() {
+				err := client.CancelTaskWithReason(logger, taskGuid, "superseded")
+				Expect(err).NotTo(HaveOccurred())
+
+				task, err := client.TaskByGuid(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.FailureReason).To(Equal("superseded"))
+			})
 		})
 
 		Context("task has been started", func() {
@@ -123,6 +168,20 @@ var _ = Describe("Task API", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			Describe("TaskHeartbeat", func() {
+				It("records the progress of the task", func() {
+					err := client.TaskHeartbeat(logger, taskGuid, cellId, "downloading", 42)
+					Expect(err).NotTo(HaveOccurred())
+
+					task, err := client.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task.State).To(Equal(models.Task_Running))
+					Expect(task.LastHeartbeatAt).NotTo(BeZero())
+					Expect(task.ProgressMessage).To(Equal("downloading"))
+					Expect(task.ProgressPercent).To(BeEquivalentTo(42))
+				})
+			})
+
 			Describe("FailTask", func() {
 				It("marks the task completed and sets FailureReason", func() {
 					err := client.FailTask(logger, taskGuid, "some failure happened")
@@ -23,6 +23,8 @@ type Args struct {
 
 	HealthAddress string
 
+	PrometheusListenAddress string
+
 	DatabaseConnectionString string
 	DatabaseDriver           string
 
@@ -35,6 +37,14 @@ type Args struct {
 	CAFile     string
 	KeyFile    string
 	CertFile   string
+
+	AuthorizationPolicyFile string
+	RequireCellIdentity     bool
+
+	SQLAuditLog  bool
+	AuditLogFile string
+
+	WebhookConfigFile string
 }
 
 func (args Args) ArgSlice() []string {
@@ -52,6 +62,7 @@ func (args Args) ArgSlice() []string {
 		"-databaseConnectionString", args.DatabaseConnectionString,
 		"-databaseDriver", args.DatabaseDriver,
 		"-healthAddress", args.HealthAddress,
+		"-prometheusListenAddress", args.PrometheusListenAddress,
 		"-listenAddress", args.Address,
 		"-logLevel", "debug",
 		"-metricsReportInterval", args.MetricsReportInterval.String(),
@@ -60,6 +71,11 @@ func (args Args) ArgSlice() []string {
 		"-caFile", args.CAFile,
 		"-certFile", args.CertFile,
 		"-keyFile", args.KeyFile,
+		"-authorizationPolicyFile", args.AuthorizationPolicyFile,
+		"-requireCellIdentity=" + strconv.FormatBool(args.RequireCellIdentity),
+		"-sqlAuditLog=" + strconv.FormatBool(args.SQLAuditLog),
+		"-auditLogFile", args.AuditLogFile,
+		"-webhookConfigFile", args.WebhookConfigFile,
 	}
 
 	for _, key := range args.EncryptionKeys {
@@ -0,0 +1,79 @@
+package main_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks", func() {
+	var (
+		tmpDir     string
+		server     *ghttp.Server
+		eventTypes chan string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "webhooks")
+		Expect(err).NotTo(HaveOccurred())
+
+		eventTypes = make(chan string, 10)
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", "/events", func(w http.ResponseWriter, req *http.Request) {
+			var batch struct {
+				Events []struct{ Type string }
+			}
+			Expect(json.NewDecoder(req.Body).Decode(&batch)).To(Succeed())
+			for _, event := range batch.Events {
+				eventTypes <- event.Type
+			}
+		})
+
+		config := fmt.Sprintf(`{
+			"dead_letter_dir": %q,
+			"endpoints": [{
+				"name": "tasks",
+				"url": %q,
+				"filter": {"event_types": ["task_created"]},
+				"batch_size": 1
+			}]
+		}`, filepath.Join(tmpDir, "dead-letters"), server.URL()+"/events")
+
+		bbsArgs.WebhookConfigFile = filepath.Join(tmpDir, "webhooks.json")
+		Expect(ioutil.WriteFile(bbsArgs.WebhookConfigFile, []byte(config), 0600)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		bbsRunner = testrunner.New(bbsBinPath, bbsArgs)
+		bbsProcess = ginkgomon.Invoke(bbsRunner)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("posts the matching events to the endpoint", func() {
+		Expect(client.DesireTask(logger, "some-task-guid", "some-domain", model_helpers.NewValidTaskDefinition())).To(Succeed())
+
+		Eventually(eventTypes).Should(Receive(Equal(models.EventTypeTaskCreated)))
+		Consistently(eventTypes).ShouldNot(Receive())
+	})
+
+	It("keeps dead letters under a directory per endpoint", func() {
+		Eventually(filepath.Join(tmpDir, "dead-letters", "tasks")).Should(BeADirectory())
+	})
+})
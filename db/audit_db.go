@@ -0,0 +1,13 @@
+package db
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . AuditDB
+type AuditDB interface {
+	AppendAuditRecord(logger lager.Logger, record *models.AuditRecord) error
+	LastAuditRecord(logger lager.Logger) (*models.AuditRecord, error)
+	AuditRecords(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error)
+}
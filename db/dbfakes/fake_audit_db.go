@@ -0,0 +1,143 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeAuditDB struct {
+	AppendAuditRecordStub        func(logger lager.Logger, record *models.AuditRecord) error
+	appendAuditRecordMutex       sync.RWMutex
+	appendAuditRecordArgsForCall []struct {
+		logger lager.Logger
+		record *models.AuditRecord
+	}
+	appendAuditRecordReturns struct {
+		result1 error
+	}
+	LastAuditRecordStub        func(logger lager.Logger) (*models.AuditRecord, error)
+	lastAuditRecordMutex       sync.RWMutex
+	lastAuditRecordArgsForCall []struct {
+		logger lager.Logger
+	}
+	lastAuditRecordReturns struct {
+		result1 *models.AuditRecord
+		result2 error
+	}
+	AuditRecordsStub        func(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error)
+	auditRecordsMutex       sync.RWMutex
+	auditRecordsArgsForCall []struct {
+		logger lager.Logger
+		filter models.AuditRecordFilter
+	}
+	auditRecordsReturns struct {
+		result1 []*models.AuditRecord
+		result2 error
+	}
+}
+
+func (fake *FakeAuditDB) AppendAuditRecord(logger lager.Logger, record *models.AuditRecord) error {
+	fake.appendAuditRecordMutex.Lock()
+	fake.appendAuditRecordArgsForCall = append(fake.appendAuditRecordArgsForCall, struct {
+		logger lager.Logger
+		record *models.AuditRecord
+	}{logger, record})
+	fake.appendAuditRecordMutex.Unlock()
+	if fake.AppendAuditRecordStub != nil {
+		return fake.AppendAuditRecordStub(logger, record)
+	} else {
+		return fake.appendAuditRecordReturns.result1
+	}
+}
+
+func (fake *FakeAuditDB) AppendAuditRecordCallCount() int {
+	fake.appendAuditRecordMutex.RLock()
+	defer fake.appendAuditRecordMutex.RUnlock()
+	return len(fake.appendAuditRecordArgsForCall)
+}
+
+func (fake *FakeAuditDB) AppendAuditRecordArgsForCall(i int) (lager.Logger, *models.AuditRecord) {
+	fake.appendAuditRecordMutex.RLock()
+	defer fake.appendAuditRecordMutex.RUnlock()
+	return fake.appendAuditRecordArgsForCall[i].logger, fake.appendAuditRecordArgsForCall[i].record
+}
+
+func (fake *FakeAuditDB) AppendAuditRecordReturns(result1 error) {
+	fake.AppendAuditRecordStub = nil
+	fake.appendAuditRecordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditDB) LastAuditRecord(logger lager.Logger) (*models.AuditRecord, error) {
+	fake.lastAuditRecordMutex.Lock()
+	fake.lastAuditRecordArgsForCall = append(fake.lastAuditRecordArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.lastAuditRecordMutex.Unlock()
+	if fake.LastAuditRecordStub != nil {
+		return fake.LastAuditRecordStub(logger)
+	} else {
+		return fake.lastAuditRecordReturns.result1, fake.lastAuditRecordReturns.result2
+	}
+}
+
+func (fake *FakeAuditDB) LastAuditRecordCallCount() int {
+	fake.lastAuditRecordMutex.RLock()
+	defer fake.lastAuditRecordMutex.RUnlock()
+	return len(fake.lastAuditRecordArgsForCall)
+}
+
+func (fake *FakeAuditDB) LastAuditRecordArgsForCall(i int) lager.Logger {
+	fake.lastAuditRecordMutex.RLock()
+	defer fake.lastAuditRecordMutex.RUnlock()
+	return fake.lastAuditRecordArgsForCall[i].logger
+}
+
+func (fake *FakeAuditDB) LastAuditRecordReturns(result1 *models.AuditRecord, result2 error) {
+	fake.LastAuditRecordStub = nil
+	fake.lastAuditRecordReturns = struct {
+		result1 *models.AuditRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditDB) AuditRecords(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error) {
+	fake.auditRecordsMutex.Lock()
+	fake.auditRecordsArgsForCall = append(fake.auditRecordsArgsForCall, struct {
+		logger lager.Logger
+		filter models.AuditRecordFilter
+	}{logger, filter})
+	fake.auditRecordsMutex.Unlock()
+	if fake.AuditRecordsStub != nil {
+		return fake.AuditRecordsStub(logger, filter)
+	} else {
+		return fake.auditRecordsReturns.result1, fake.auditRecordsReturns.result2
+	}
+}
+
+func (fake *FakeAuditDB) AuditRecordsCallCount() int {
+	fake.auditRecordsMutex.RLock()
+	defer fake.auditRecordsMutex.RUnlock()
+	return len(fake.auditRecordsArgsForCall)
+}
+
+func (fake *FakeAuditDB) AuditRecordsArgsForCall(i int) (lager.Logger, models.AuditRecordFilter) {
+	fake.auditRecordsMutex.RLock()
+	defer fake.auditRecordsMutex.RUnlock()
+	return fake.auditRecordsArgsForCall[i].logger, fake.auditRecordsArgsForCall[i].filter
+}
+
+func (fake *FakeAuditDB) AuditRecordsReturns(result1 []*models.AuditRecord, result2 error) {
+	fake.AuditRecordsStub = nil
+	fake.auditRecordsReturns = struct {
+		result1 []*models.AuditRecord
+		result2 error
+	}{result1, result2}
+}
+
+var _ db.AuditDB = new(FakeAuditDB)
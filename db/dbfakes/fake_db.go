@@ -295,7 +295,7 @@ type FakeDB struct {
 		result1 *models.Task
 		result2 error
 	}
-	DesireTaskStub        func(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid, domain string) error
+	DesireTaskStub        func(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid string, domain string) (*models.Task, error)
 	desireTaskMutex       sync.RWMutex
 	desireTaskArgsForCall []struct {
 		logger         lager.Logger
@@ -304,9 +304,10 @@ type FakeDB struct {
 		domain         string
 	}
 	desireTaskReturns struct {
-		result1 error
+		result1 *models.Task
+		result2 error
 	}
-	StartTaskStub        func(logger lager.Logger, taskGuid, cellId string) (bool, error)
+	StartTaskStub        func(logger lager.Logger, taskGuid string, cellId string) (*models.TaskChange, bool, error)
 	startTaskMutex       sync.RWMutex
 	startTaskArgsForCall []struct {
 		logger   lager.Logger
@@ -314,21 +315,35 @@ type FakeDB struct {
 		cellId   string
 	}
 	startTaskReturns struct {
-		result1 bool
-		result2 error
+		result1 *models.TaskChange
+		result2 bool
+		result3 error
 	}
-	CancelTaskStub        func(logger lager.Logger, taskGuid string) (task *models.Task, cellID string, err error)
+	CancelTaskStub        func(logger lager.Logger, taskGuid string, reason string) (*models.TaskChange, string, error)
 	cancelTaskMutex       sync.RWMutex
 	cancelTaskArgsForCall []struct {
 		logger   lager.Logger
 		taskGuid string
+		reason   string
 	}
 	cancelTaskReturns struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 string
 		result3 error
 	}
-	FailTaskStub        func(logger lager.Logger, taskGuid, failureReason string) (task *models.Task, err error)
+	CancelTasksStub        func(logger lager.Logger, filter models.TaskFilter, reason string) ([]*models.TaskChange, []*models.TaskResult, error)
+	cancelTasksMutex       sync.RWMutex
+	cancelTasksArgsForCall []struct {
+		logger lager.Logger
+		filter models.TaskFilter
+		reason string
+	}
+	cancelTasksReturns struct {
+		result1 []*models.TaskChange
+		result2 []*models.TaskResult
+		result3 error
+	}
+	FailTaskStub        func(logger lager.Logger, taskGuid string, failureReason string) (*models.TaskChange, error)
 	failTaskMutex       sync.RWMutex
 	failTaskArgsForCall []struct {
 		logger        lager.Logger
@@ -336,10 +351,10 @@ type FakeDB struct {
 		failureReason string
 	}
 	failTaskReturns struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}
-	CompleteTaskStub        func(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) (task *models.Task, err error)
+	CompleteTaskStub        func(logger lager.Logger, taskGuid string, cellId string, failed bool, failureReason string, result string) (*models.TaskChange, error)
 	completeTaskMutex       sync.RWMutex
 	completeTaskArgsForCall []struct {
 		logger        lager.Logger
@@ -350,28 +365,30 @@ type FakeDB struct {
 		result        string
 	}
 	completeTaskReturns struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}
-	ResolvingTaskStub        func(logger lager.Logger, taskGuid string) error
+	ResolvingTaskStub        func(logger lager.Logger, taskGuid string) (*models.TaskChange, error)
 	resolvingTaskMutex       sync.RWMutex
 	resolvingTaskArgsForCall []struct {
 		logger   lager.Logger
 		taskGuid string
 	}
 	resolvingTaskReturns struct {
-		result1 error
+		result1 *models.TaskChange
+		result2 error
 	}
-	DeleteTaskStub        func(logger lager.Logger, taskGuid string) error
+	DeleteTaskStub        func(logger lager.Logger, taskGuid string) (*models.Task, error)
 	deleteTaskMutex       sync.RWMutex
 	deleteTaskArgsForCall []struct {
 		logger   lager.Logger
 		taskGuid string
 	}
 	deleteTaskReturns struct {
-		result1 error
+		result1 *models.Task
+		result2 error
 	}
-	ConvergeTasksStub        func(logger lager.Logger, cellSet models.CellSet, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) (tasksToAuction []*auctioneer.TaskStartRequest, tasksToComplete []*models.Task)
+	ConvergeTasksStub        func(logger lager.Logger, cellSet models.CellSet, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) db.TaskConvergenceResult
 	convergeTasksMutex       sync.RWMutex
 	convergeTasksArgsForCall []struct {
 		logger                      lager.Logger
@@ -381,8 +398,7 @@ type FakeDB struct {
 		expireCompletedTaskDuration time.Duration
 	}
 	convergeTasksReturns struct {
-		result1 []*auctioneer.TaskStartRequest
-		result2 []*models.Task
+		result1 db.TaskConvergenceResult
 	}
 	VersionStub        func(logger lager.Logger) (*models.Version, error)
 	versionMutex       sync.RWMutex
@@ -402,6 +418,50 @@ type FakeDB struct {
 	setVersionReturns struct {
 		result1 error
 	}
+	ActualLRPsStub        func(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRP, error)
+	actualLRPsMutex       sync.RWMutex
+	actualLRPsArgsForCall []struct {
+		logger lager.Logger
+		filter models.ActualLRPFilter
+	}
+	actualLRPsReturns struct {
+		result1 []*models.ActualLRP
+		result2 error
+	}
+	ReleaseDependentTasksStub        func(logger lager.Logger, taskGuid string) ([]*models.TaskChange, error)
+	releaseDependentTasksMutex       sync.RWMutex
+	releaseDependentTasksArgsForCall []struct {
+		logger   lager.Logger
+		taskGuid string
+	}
+	releaseDependentTasksReturns struct {
+		result1 []*models.TaskChange
+		result2 error
+	}
+	DeadLetterTaskStub        func(logger lager.Logger, taskGuid string, callbackFailureReason string) (*models.TaskChange, error)
+	deadLetterTaskMutex       sync.RWMutex
+	deadLetterTaskArgsForCall []struct {
+		logger                lager.Logger
+		taskGuid              string
+		callbackFailureReason string
+	}
+	deadLetterTaskReturns struct {
+		result1 *models.TaskChange
+		result2 error
+	}
+	TaskHeartbeatStub        func(logger lager.Logger, taskGuid string, cellId string, progressMessage string, progressPercent int32) (*models.TaskChange, error)
+	taskHeartbeatMutex       sync.RWMutex
+	taskHeartbeatArgsForCall []struct {
+		logger          lager.Logger
+		taskGuid        string
+		cellId          string
+		progressMessage string
+		progressPercent int32
+	}
+	taskHeartbeatReturns struct {
+		result1 *models.TaskChange
+		result2 error
+	}
 }
 
 func (fake *FakeDB) Domains(logger lager.Logger) ([]string, error) {
@@ -1335,7 +1395,7 @@ func (fake *FakeDB) TaskByGuidReturns(result1 *models.Task, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeDB) DesireTask(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid string, domain string) error {
+func (fake *FakeDB) DesireTask(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid string, domain string) (*models.Task, error) {
 	fake.desireTaskMutex.Lock()
 	fake.desireTaskArgsForCall = append(fake.desireTaskArgsForCall, struct {
 		logger         lager.Logger
@@ -1347,7 +1407,7 @@ func (fake *FakeDB) DesireTask(logger lager.Logger, taskDefinition *models.TaskD
 	if fake.DesireTaskStub != nil {
 		return fake.DesireTaskStub(logger, taskDefinition, taskGuid, domain)
 	} else {
-		return fake.desireTaskReturns.result1
+		return fake.desireTaskReturns.result1, fake.desireTaskReturns.result2
 	}
 }
 
@@ -1363,14 +1423,15 @@ func (fake *FakeDB) DesireTaskArgsForCall(i int) (lager.Logger, *models.TaskDefi
 	return fake.desireTaskArgsForCall[i].logger, fake.desireTaskArgsForCall[i].taskDefinition, fake.desireTaskArgsForCall[i].taskGuid, fake.desireTaskArgsForCall[i].domain
 }
 
-func (fake *FakeDB) DesireTaskReturns(result1 error) {
+func (fake *FakeDB) DesireTaskReturns(result1 *models.Task, result2 error) {
 	fake.DesireTaskStub = nil
 	fake.desireTaskReturns = struct {
-		result1 error
-	}{result1}
+		result1 *models.Task
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeDB) StartTask(logger lager.Logger, taskGuid string, cellId string) (bool, error) {
+func (fake *FakeDB) StartTask(logger lager.Logger, taskGuid string, cellId string) (*models.TaskChange, bool, error) {
 	fake.startTaskMutex.Lock()
 	fake.startTaskArgsForCall = append(fake.startTaskArgsForCall, struct {
 		logger   lager.Logger
@@ -1381,7 +1442,7 @@ func (fake *FakeDB) StartTask(logger lager.Logger, taskGuid string, cellId strin
 	if fake.StartTaskStub != nil {
 		return fake.StartTaskStub(logger, taskGuid, cellId)
 	} else {
-		return fake.startTaskReturns.result1, fake.startTaskReturns.result2
+		return fake.startTaskReturns.result1, fake.startTaskReturns.result2, fake.startTaskReturns.result3
 	}
 }
 
@@ -1397,23 +1458,25 @@ func (fake *FakeDB) StartTaskArgsForCall(i int) (lager.Logger, string, string) {
 	return fake.startTaskArgsForCall[i].logger, fake.startTaskArgsForCall[i].taskGuid, fake.startTaskArgsForCall[i].cellId
 }
 
-func (fake *FakeDB) StartTaskReturns(result1 bool, result2 error) {
+func (fake *FakeDB) StartTaskReturns(result1 *models.TaskChange, result2 bool, result3 error) {
 	fake.StartTaskStub = nil
 	fake.startTaskReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+		result1 *models.TaskChange
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeDB) CancelTask(logger lager.Logger, taskGuid string) (task *models.Task, cellID string, err error) {
+func (fake *FakeDB) CancelTask(logger lager.Logger, taskGuid string, reason string) (*models.TaskChange, string, error) {
 	fake.cancelTaskMutex.Lock()
 	fake.cancelTaskArgsForCall = append(fake.cancelTaskArgsForCall, struct {
 		logger   lager.Logger
 		taskGuid string
-	}{logger, taskGuid})
+		reason   string
+	}{logger, taskGuid, reason})
 	fake.cancelTaskMutex.Unlock()
 	if fake.CancelTaskStub != nil {
-		return fake.CancelTaskStub(logger, taskGuid)
+		return fake.CancelTaskStub(logger, taskGuid, reason)
 	} else {
 		return fake.cancelTaskReturns.result1, fake.cancelTaskReturns.result2, fake.cancelTaskReturns.result3
 	}
@@ -1425,22 +1488,58 @@ func (fake *FakeDB) CancelTaskCallCount() int {
 	return len(fake.cancelTaskArgsForCall)
 }
 
-func (fake *FakeDB) CancelTaskArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeDB) CancelTaskArgsForCall(i int) (lager.Logger, string, string) {
 	fake.cancelTaskMutex.RLock()
 	defer fake.cancelTaskMutex.RUnlock()
-	return fake.cancelTaskArgsForCall[i].logger, fake.cancelTaskArgsForCall[i].taskGuid
+	return fake.cancelTaskArgsForCall[i].logger, fake.cancelTaskArgsForCall[i].taskGuid, fake.cancelTaskArgsForCall[i].reason
 }
 
-func (fake *FakeDB) CancelTaskReturns(result1 *models.Task, result2 string, result3 error) {
+func (fake *FakeDB) CancelTaskReturns(result1 *models.TaskChange, result2 string, result3 error) {
 	fake.CancelTaskStub = nil
 	fake.cancelTaskReturns = struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 string
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeDB) FailTask(logger lager.Logger, taskGuid string, failureReason string) (task *models.Task, err error) {
+func (fake *FakeDB) CancelTasks(logger lager.Logger, filter models.TaskFilter, reason string) ([]*models.TaskChange, []*models.TaskResult, error) {
+	fake.cancelTasksMutex.Lock()
+	fake.cancelTasksArgsForCall = append(fake.cancelTasksArgsForCall, struct {
+		logger lager.Logger
+		filter models.TaskFilter
+		reason string
+	}{logger, filter, reason})
+	fake.cancelTasksMutex.Unlock()
+	if fake.CancelTasksStub != nil {
+		return fake.CancelTasksStub(logger, filter, reason)
+	} else {
+		return fake.cancelTasksReturns.result1, fake.cancelTasksReturns.result2, fake.cancelTasksReturns.result3
+	}
+}
+
+func (fake *FakeDB) CancelTasksCallCount() int {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	return len(fake.cancelTasksArgsForCall)
+}
+
+func (fake *FakeDB) CancelTasksArgsForCall(i int) (lager.Logger, models.TaskFilter, string) {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	return fake.cancelTasksArgsForCall[i].logger, fake.cancelTasksArgsForCall[i].filter, fake.cancelTasksArgsForCall[i].reason
+}
+
+func (fake *FakeDB) CancelTasksReturns(result1 []*models.TaskChange, result2 []*models.TaskResult, result3 error) {
+	fake.CancelTasksStub = nil
+	fake.cancelTasksReturns = struct {
+		result1 []*models.TaskChange
+		result2 []*models.TaskResult
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) FailTask(logger lager.Logger, taskGuid string, failureReason string) (*models.TaskChange, error) {
 	fake.failTaskMutex.Lock()
 	fake.failTaskArgsForCall = append(fake.failTaskArgsForCall, struct {
 		logger        lager.Logger
@@ -1467,15 +1566,15 @@ func (fake *FakeDB) FailTaskArgsForCall(i int) (lager.Logger, string, string) {
 	return fake.failTaskArgsForCall[i].logger, fake.failTaskArgsForCall[i].taskGuid, fake.failTaskArgsForCall[i].failureReason
 }
 
-func (fake *FakeDB) FailTaskReturns(result1 *models.Task, result2 error) {
+func (fake *FakeDB) FailTaskReturns(result1 *models.TaskChange, result2 error) {
 	fake.FailTaskStub = nil
 	fake.failTaskReturns = struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeDB) CompleteTask(logger lager.Logger, taskGuid string, cellId string, failed bool, failureReason string, result string) (task *models.Task, err error) {
+func (fake *FakeDB) CompleteTask(logger lager.Logger, taskGuid string, cellId string, failed bool, failureReason string, result string) (*models.TaskChange, error) {
 	fake.completeTaskMutex.Lock()
 	fake.completeTaskArgsForCall = append(fake.completeTaskArgsForCall, struct {
 		logger        lager.Logger
@@ -1505,15 +1604,15 @@ func (fake *FakeDB) CompleteTaskArgsForCall(i int) (lager.Logger, string, string
 	return fake.completeTaskArgsForCall[i].logger, fake.completeTaskArgsForCall[i].taskGuid, fake.completeTaskArgsForCall[i].cellId, fake.completeTaskArgsForCall[i].failed, fake.completeTaskArgsForCall[i].failureReason, fake.completeTaskArgsForCall[i].result
 }
 
-func (fake *FakeDB) CompleteTaskReturns(result1 *models.Task, result2 error) {
+func (fake *FakeDB) CompleteTaskReturns(result1 *models.TaskChange, result2 error) {
 	fake.CompleteTaskStub = nil
 	fake.completeTaskReturns = struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeDB) ResolvingTask(logger lager.Logger, taskGuid string) error {
+func (fake *FakeDB) ResolvingTask(logger lager.Logger, taskGuid string) (*models.TaskChange, error) {
 	fake.resolvingTaskMutex.Lock()
 	fake.resolvingTaskArgsForCall = append(fake.resolvingTaskArgsForCall, struct {
 		logger   lager.Logger
@@ -1523,7 +1622,7 @@ func (fake *FakeDB) ResolvingTask(logger lager.Logger, taskGuid string) error {
 	if fake.ResolvingTaskStub != nil {
 		return fake.ResolvingTaskStub(logger, taskGuid)
 	} else {
-		return fake.resolvingTaskReturns.result1
+		return fake.resolvingTaskReturns.result1, fake.resolvingTaskReturns.result2
 	}
 }
 
@@ -1539,14 +1638,15 @@ func (fake *FakeDB) ResolvingTaskArgsForCall(i int) (lager.Logger, string) {
 	return fake.resolvingTaskArgsForCall[i].logger, fake.resolvingTaskArgsForCall[i].taskGuid
 }
 
-func (fake *FakeDB) ResolvingTaskReturns(result1 error) {
+func (fake *FakeDB) ResolvingTaskReturns(result1 *models.TaskChange, result2 error) {
 	fake.ResolvingTaskStub = nil
 	fake.resolvingTaskReturns = struct {
-		result1 error
-	}{result1}
+		result1 *models.TaskChange
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeDB) DeleteTask(logger lager.Logger, taskGuid string) error {
+func (fake *FakeDB) DeleteTask(logger lager.Logger, taskGuid string) (*models.Task, error) {
 	fake.deleteTaskMutex.Lock()
 	fake.deleteTaskArgsForCall = append(fake.deleteTaskArgsForCall, struct {
 		logger   lager.Logger
@@ -1556,7 +1656,7 @@ func (fake *FakeDB) DeleteTask(logger lager.Logger, taskGuid string) error {
 	if fake.DeleteTaskStub != nil {
 		return fake.DeleteTaskStub(logger, taskGuid)
 	} else {
-		return fake.deleteTaskReturns.result1
+		return fake.deleteTaskReturns.result1, fake.deleteTaskReturns.result2
 	}
 }
 
@@ -1572,14 +1672,15 @@ func (fake *FakeDB) DeleteTaskArgsForCall(i int) (lager.Logger, string) {
 	return fake.deleteTaskArgsForCall[i].logger, fake.deleteTaskArgsForCall[i].taskGuid
 }
 
-func (fake *FakeDB) DeleteTaskReturns(result1 error) {
+func (fake *FakeDB) DeleteTaskReturns(result1 *models.Task, result2 error) {
 	fake.DeleteTaskStub = nil
 	fake.deleteTaskReturns = struct {
-		result1 error
-	}{result1}
+		result1 *models.Task
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) (tasksToAuction []*auctioneer.TaskStartRequest, tasksToComplete []*models.Task) {
+func (fake *FakeDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) db.TaskConvergenceResult {
 	fake.convergeTasksMutex.Lock()
 	fake.convergeTasksArgsForCall = append(fake.convergeTasksArgsForCall, struct {
 		logger                      lager.Logger
@@ -1592,7 +1693,7 @@ func (fake *FakeDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, k
 	if fake.ConvergeTasksStub != nil {
 		return fake.ConvergeTasksStub(logger, cellSet, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
 	} else {
-		return fake.convergeTasksReturns.result1, fake.convergeTasksReturns.result2
+		return fake.convergeTasksReturns.result1
 	}
 }
 
@@ -1608,12 +1709,11 @@ func (fake *FakeDB) ConvergeTasksArgsForCall(i int) (lager.Logger, models.CellSe
 	return fake.convergeTasksArgsForCall[i].logger, fake.convergeTasksArgsForCall[i].cellSet, fake.convergeTasksArgsForCall[i].kickTaskDuration, fake.convergeTasksArgsForCall[i].expirePendingTaskDuration, fake.convergeTasksArgsForCall[i].expireCompletedTaskDuration
 }
 
-func (fake *FakeDB) ConvergeTasksReturns(result1 []*auctioneer.TaskStartRequest, result2 []*models.Task) {
+func (fake *FakeDB) ConvergeTasksReturns(result1 db.TaskConvergenceResult) {
 	fake.ConvergeTasksStub = nil
 	fake.convergeTasksReturns = struct {
-		result1 []*auctioneer.TaskStartRequest
-		result2 []*models.Task
-	}{result1, result2}
+		result1 db.TaskConvergenceResult
+	}{result1}
 }
 
 func (fake *FakeDB) Version(logger lager.Logger) (*models.Version, error) {
@@ -1682,4 +1782,144 @@ func (fake *FakeDB) SetVersionReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDB) ActualLRPs(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRP, error) {
+	fake.actualLRPsMutex.Lock()
+	fake.actualLRPsArgsForCall = append(fake.actualLRPsArgsForCall, struct {
+		logger lager.Logger
+		filter models.ActualLRPFilter
+	}{logger, filter})
+	fake.actualLRPsMutex.Unlock()
+	if fake.ActualLRPsStub != nil {
+		return fake.ActualLRPsStub(logger, filter)
+	} else {
+		return fake.actualLRPsReturns.result1, fake.actualLRPsReturns.result2
+	}
+}
+
+func (fake *FakeDB) ActualLRPsCallCount() int {
+	fake.actualLRPsMutex.RLock()
+	defer fake.actualLRPsMutex.RUnlock()
+	return len(fake.actualLRPsArgsForCall)
+}
+
+func (fake *FakeDB) ActualLRPsArgsForCall(i int) (lager.Logger, models.ActualLRPFilter) {
+	fake.actualLRPsMutex.RLock()
+	defer fake.actualLRPsMutex.RUnlock()
+	return fake.actualLRPsArgsForCall[i].logger, fake.actualLRPsArgsForCall[i].filter
+}
+
+func (fake *FakeDB) ActualLRPsReturns(result1 []*models.ActualLRP, result2 error) {
+	fake.ActualLRPsStub = nil
+	fake.actualLRPsReturns = struct {
+		result1 []*models.ActualLRP
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ReleaseDependentTasks(logger lager.Logger, taskGuid string) ([]*models.TaskChange, error) {
+	fake.releaseDependentTasksMutex.Lock()
+	fake.releaseDependentTasksArgsForCall = append(fake.releaseDependentTasksArgsForCall, struct {
+		logger   lager.Logger
+		taskGuid string
+	}{logger, taskGuid})
+	fake.releaseDependentTasksMutex.Unlock()
+	if fake.ReleaseDependentTasksStub != nil {
+		return fake.ReleaseDependentTasksStub(logger, taskGuid)
+	} else {
+		return fake.releaseDependentTasksReturns.result1, fake.releaseDependentTasksReturns.result2
+	}
+}
+
+func (fake *FakeDB) ReleaseDependentTasksCallCount() int {
+	fake.releaseDependentTasksMutex.RLock()
+	defer fake.releaseDependentTasksMutex.RUnlock()
+	return len(fake.releaseDependentTasksArgsForCall)
+}
+
+func (fake *FakeDB) ReleaseDependentTasksArgsForCall(i int) (lager.Logger, string) {
+	fake.releaseDependentTasksMutex.RLock()
+	defer fake.releaseDependentTasksMutex.RUnlock()
+	return fake.releaseDependentTasksArgsForCall[i].logger, fake.releaseDependentTasksArgsForCall[i].taskGuid
+}
+
+func (fake *FakeDB) ReleaseDependentTasksReturns(result1 []*models.TaskChange, result2 error) {
+	fake.ReleaseDependentTasksStub = nil
+	fake.releaseDependentTasksReturns = struct {
+		result1 []*models.TaskChange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DeadLetterTask(logger lager.Logger, taskGuid string, callbackFailureReason string) (*models.TaskChange, error) {
+	fake.deadLetterTaskMutex.Lock()
+	fake.deadLetterTaskArgsForCall = append(fake.deadLetterTaskArgsForCall, struct {
+		logger                lager.Logger
+		taskGuid              string
+		callbackFailureReason string
+	}{logger, taskGuid, callbackFailureReason})
+	fake.deadLetterTaskMutex.Unlock()
+	if fake.DeadLetterTaskStub != nil {
+		return fake.DeadLetterTaskStub(logger, taskGuid, callbackFailureReason)
+	} else {
+		return fake.deadLetterTaskReturns.result1, fake.deadLetterTaskReturns.result2
+	}
+}
+
+func (fake *FakeDB) DeadLetterTaskCallCount() int {
+	fake.deadLetterTaskMutex.RLock()
+	defer fake.deadLetterTaskMutex.RUnlock()
+	return len(fake.deadLetterTaskArgsForCall)
+}
+
+func (fake *FakeDB) DeadLetterTaskArgsForCall(i int) (lager.Logger, string, string) {
+	fake.deadLetterTaskMutex.RLock()
+	defer fake.deadLetterTaskMutex.RUnlock()
+	return fake.deadLetterTaskArgsForCall[i].logger, fake.deadLetterTaskArgsForCall[i].taskGuid, fake.deadLetterTaskArgsForCall[i].callbackFailureReason
+}
+
+func (fake *FakeDB) DeadLetterTaskReturns(result1 *models.TaskChange, result2 error) {
+	fake.DeadLetterTaskStub = nil
+	fake.deadLetterTaskReturns = struct {
+		result1 *models.TaskChange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) TaskHeartbeat(logger lager.Logger, taskGuid string, cellId string, progressMessage string, progressPercent int32) (*models.TaskChange, error) {
+	fake.taskHeartbeatMutex.Lock()
+	fake.taskHeartbeatArgsForCall = append(fake.taskHeartbeatArgsForCall, struct {
+		logger          lager.Logger
+		taskGuid        string
+		cellId          string
+		progressMessage string
+		progressPercent int32
+	}{logger, taskGuid, cellId, progressMessage, progressPercent})
+	fake.taskHeartbeatMutex.Unlock()
+	if fake.TaskHeartbeatStub != nil {
+		return fake.TaskHeartbeatStub(logger, taskGuid, cellId, progressMessage, progressPercent)
+	} else {
+		return fake.taskHeartbeatReturns.result1, fake.taskHeartbeatReturns.result2
+	}
+}
+
+func (fake *FakeDB) TaskHeartbeatCallCount() int {
+	fake.taskHeartbeatMutex.RLock()
+	defer fake.taskHeartbeatMutex.RUnlock()
+	return len(fake.taskHeartbeatArgsForCall)
+}
+
+func (fake *FakeDB) TaskHeartbeatArgsForCall(i int) (lager.Logger, string, string, string, int32) {
+	fake.taskHeartbeatMutex.RLock()
+	defer fake.taskHeartbeatMutex.RUnlock()
+	return fake.taskHeartbeatArgsForCall[i].logger, fake.taskHeartbeatArgsForCall[i].taskGuid, fake.taskHeartbeatArgsForCall[i].cellId, fake.taskHeartbeatArgsForCall[i].progressMessage, fake.taskHeartbeatArgsForCall[i].progressPercent
+}
+
+func (fake *FakeDB) TaskHeartbeatReturns(result1 *models.TaskChange, result2 error) {
+	fake.TaskHeartbeatStub = nil
+	fake.taskHeartbeatReturns = struct {
+		result1 *models.TaskChange
+		result2 error
+	}{result1, result2}
+}
+
 var _ db.DB = new(FakeDB)
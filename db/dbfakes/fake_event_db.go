@@ -0,0 +1,145 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeEventDB struct {
+	EventsAfterStub        func(logger lager.Logger, sequence uint64, limit int) ([]*models.SequencedEvent, error)
+	eventsAfterMutex       sync.RWMutex
+	eventsAfterArgsForCall []struct {
+		logger   lager.Logger
+		sequence uint64
+		limit    int
+	}
+	eventsAfterReturns struct {
+		result1 []*models.SequencedEvent
+		result2 error
+	}
+	LastEventSequenceStub        func(logger lager.Logger) (uint64, error)
+	lastEventSequenceMutex       sync.RWMutex
+	lastEventSequenceArgsForCall []struct {
+		logger lager.Logger
+	}
+	lastEventSequenceReturns struct {
+		result1 uint64
+		result2 error
+	}
+	PruneEventsStub        func(logger lager.Logger, throughSequence uint64) error
+	pruneEventsMutex       sync.RWMutex
+	pruneEventsArgsForCall []struct {
+		logger          lager.Logger
+		throughSequence uint64
+	}
+	pruneEventsReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeEventDB) EventsAfter(logger lager.Logger, sequence uint64, limit int) ([]*models.SequencedEvent, error) {
+	fake.eventsAfterMutex.Lock()
+	fake.eventsAfterArgsForCall = append(fake.eventsAfterArgsForCall, struct {
+		logger   lager.Logger
+		sequence uint64
+		limit    int
+	}{logger, sequence, limit})
+	fake.eventsAfterMutex.Unlock()
+	if fake.EventsAfterStub != nil {
+		return fake.EventsAfterStub(logger, sequence, limit)
+	} else {
+		return fake.eventsAfterReturns.result1, fake.eventsAfterReturns.result2
+	}
+}
+
+func (fake *FakeEventDB) EventsAfterCallCount() int {
+	fake.eventsAfterMutex.RLock()
+	defer fake.eventsAfterMutex.RUnlock()
+	return len(fake.eventsAfterArgsForCall)
+}
+
+func (fake *FakeEventDB) EventsAfterArgsForCall(i int) (lager.Logger, uint64, int) {
+	fake.eventsAfterMutex.RLock()
+	defer fake.eventsAfterMutex.RUnlock()
+	return fake.eventsAfterArgsForCall[i].logger, fake.eventsAfterArgsForCall[i].sequence, fake.eventsAfterArgsForCall[i].limit
+}
+
+func (fake *FakeEventDB) EventsAfterReturns(result1 []*models.SequencedEvent, result2 error) {
+	fake.EventsAfterStub = nil
+	fake.eventsAfterReturns = struct {
+		result1 []*models.SequencedEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventDB) LastEventSequence(logger lager.Logger) (uint64, error) {
+	fake.lastEventSequenceMutex.Lock()
+	fake.lastEventSequenceArgsForCall = append(fake.lastEventSequenceArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.lastEventSequenceMutex.Unlock()
+	if fake.LastEventSequenceStub != nil {
+		return fake.LastEventSequenceStub(logger)
+	} else {
+		return fake.lastEventSequenceReturns.result1, fake.lastEventSequenceReturns.result2
+	}
+}
+
+func (fake *FakeEventDB) LastEventSequenceCallCount() int {
+	fake.lastEventSequenceMutex.RLock()
+	defer fake.lastEventSequenceMutex.RUnlock()
+	return len(fake.lastEventSequenceArgsForCall)
+}
+
+func (fake *FakeEventDB) LastEventSequenceArgsForCall(i int) lager.Logger {
+	fake.lastEventSequenceMutex.RLock()
+	defer fake.lastEventSequenceMutex.RUnlock()
+	return fake.lastEventSequenceArgsForCall[i].logger
+}
+
+func (fake *FakeEventDB) LastEventSequenceReturns(result1 uint64, result2 error) {
+	fake.LastEventSequenceStub = nil
+	fake.lastEventSequenceReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventDB) PruneEvents(logger lager.Logger, throughSequence uint64) error {
+	fake.pruneEventsMutex.Lock()
+	fake.pruneEventsArgsForCall = append(fake.pruneEventsArgsForCall, struct {
+		logger          lager.Logger
+		throughSequence uint64
+	}{logger, throughSequence})
+	fake.pruneEventsMutex.Unlock()
+	if fake.PruneEventsStub != nil {
+		return fake.PruneEventsStub(logger, throughSequence)
+	} else {
+		return fake.pruneEventsReturns.result1
+	}
+}
+
+func (fake *FakeEventDB) PruneEventsCallCount() int {
+	fake.pruneEventsMutex.RLock()
+	defer fake.pruneEventsMutex.RUnlock()
+	return len(fake.pruneEventsArgsForCall)
+}
+
+func (fake *FakeEventDB) PruneEventsArgsForCall(i int) (lager.Logger, uint64) {
+	fake.pruneEventsMutex.RLock()
+	defer fake.pruneEventsMutex.RUnlock()
+	return fake.pruneEventsArgsForCall[i].logger, fake.pruneEventsArgsForCall[i].throughSequence
+}
+
+func (fake *FakeEventDB) PruneEventsReturns(result1 error) {
+	fake.PruneEventsStub = nil
+	fake.pruneEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ db.EventDB = new(FakeEventDB)
@@ -0,0 +1,59 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeTaskArchiveDB struct {
+	ArchivedTasksStub        func(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error)
+	archivedTasksMutex       sync.RWMutex
+	archivedTasksArgsForCall []struct {
+		logger lager.Logger
+		filter models.ArchivedTaskFilter
+	}
+	archivedTasksReturns struct {
+		result1 []*models.Task
+		result2 error
+	}
+}
+
+func (fake *FakeTaskArchiveDB) ArchivedTasks(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error) {
+	fake.archivedTasksMutex.Lock()
+	fake.archivedTasksArgsForCall = append(fake.archivedTasksArgsForCall, struct {
+		logger lager.Logger
+		filter models.ArchivedTaskFilter
+	}{logger, filter})
+	fake.archivedTasksMutex.Unlock()
+	if fake.ArchivedTasksStub != nil {
+		return fake.ArchivedTasksStub(logger, filter)
+	} else {
+		return fake.archivedTasksReturns.result1, fake.archivedTasksReturns.result2
+	}
+}
+
+func (fake *FakeTaskArchiveDB) ArchivedTasksCallCount() int {
+	fake.archivedTasksMutex.RLock()
+	defer fake.archivedTasksMutex.RUnlock()
+	return len(fake.archivedTasksArgsForCall)
+}
+
+func (fake *FakeTaskArchiveDB) ArchivedTasksArgsForCall(i int) (lager.Logger, models.ArchivedTaskFilter) {
+	fake.archivedTasksMutex.RLock()
+	defer fake.archivedTasksMutex.RUnlock()
+	return fake.archivedTasksArgsForCall[i].logger, fake.archivedTasksArgsForCall[i].filter
+}
+
+func (fake *FakeTaskArchiveDB) ArchivedTasksReturns(result1 []*models.Task, result2 error) {
+	fake.ArchivedTasksStub = nil
+	fake.archivedTasksReturns = struct {
+		result1 []*models.Task
+		result2 error
+	}{result1, result2}
+}
+
+var _ db.TaskArchiveDB = new(FakeTaskArchiveDB)
@@ -0,0 +1,231 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeTaskCallbackDB struct {
+	EnqueueTaskCallbackStub        func(logger lager.Logger, taskGuid string) error
+	enqueueTaskCallbackMutex       sync.RWMutex
+	enqueueTaskCallbackArgsForCall []struct {
+		logger   lager.Logger
+		taskGuid string
+	}
+	enqueueTaskCallbackReturns struct {
+		result1 error
+	}
+	DueTaskCallbacksStub        func(logger lager.Logger, limit int) ([]*models.QueuedTaskCallback, error)
+	dueTaskCallbacksMutex       sync.RWMutex
+	dueTaskCallbacksArgsForCall []struct {
+		logger lager.Logger
+		limit  int
+	}
+	dueTaskCallbacksReturns struct {
+		result1 []*models.QueuedTaskCallback
+		result2 error
+	}
+	RescheduleTaskCallbackStub        func(logger lager.Logger, taskGuid string, attempts int32, nextAttemptAt int64) error
+	rescheduleTaskCallbackMutex       sync.RWMutex
+	rescheduleTaskCallbackArgsForCall []struct {
+		logger        lager.Logger
+		taskGuid      string
+		attempts      int32
+		nextAttemptAt int64
+	}
+	rescheduleTaskCallbackReturns struct {
+		result1 error
+	}
+	RemoveTaskCallbackStub        func(logger lager.Logger, taskGuid string) error
+	removeTaskCallbackMutex       sync.RWMutex
+	removeTaskCallbackArgsForCall []struct {
+		logger   lager.Logger
+		taskGuid string
+	}
+	removeTaskCallbackReturns struct {
+		result1 error
+	}
+	TaskCallbackQueueDepthStub        func(logger lager.Logger) (int, error)
+	taskCallbackQueueDepthMutex       sync.RWMutex
+	taskCallbackQueueDepthArgsForCall []struct {
+		logger lager.Logger
+	}
+	taskCallbackQueueDepthReturns struct {
+		result1 int
+		result2 error
+	}
+}
+
+func (fake *FakeTaskCallbackDB) EnqueueTaskCallback(logger lager.Logger, taskGuid string) error {
+	fake.enqueueTaskCallbackMutex.Lock()
+	fake.enqueueTaskCallbackArgsForCall = append(fake.enqueueTaskCallbackArgsForCall, struct {
+		logger   lager.Logger
+		taskGuid string
+	}{logger, taskGuid})
+	fake.enqueueTaskCallbackMutex.Unlock()
+	if fake.EnqueueTaskCallbackStub != nil {
+		return fake.EnqueueTaskCallbackStub(logger, taskGuid)
+	} else {
+		return fake.enqueueTaskCallbackReturns.result1
+	}
+}
+
+func (fake *FakeTaskCallbackDB) EnqueueTaskCallbackCallCount() int {
+	fake.enqueueTaskCallbackMutex.RLock()
+	defer fake.enqueueTaskCallbackMutex.RUnlock()
+	return len(fake.enqueueTaskCallbackArgsForCall)
+}
+
+func (fake *FakeTaskCallbackDB) EnqueueTaskCallbackArgsForCall(i int) (lager.Logger, string) {
+	fake.enqueueTaskCallbackMutex.RLock()
+	defer fake.enqueueTaskCallbackMutex.RUnlock()
+	return fake.enqueueTaskCallbackArgsForCall[i].logger, fake.enqueueTaskCallbackArgsForCall[i].taskGuid
+}
+
+func (fake *FakeTaskCallbackDB) EnqueueTaskCallbackReturns(result1 error) {
+	fake.EnqueueTaskCallbackStub = nil
+	fake.enqueueTaskCallbackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskCallbackDB) DueTaskCallbacks(logger lager.Logger, limit int) ([]*models.QueuedTaskCallback, error) {
+	fake.dueTaskCallbacksMutex.Lock()
+	fake.dueTaskCallbacksArgsForCall = append(fake.dueTaskCallbacksArgsForCall, struct {
+		logger lager.Logger
+		limit  int
+	}{logger, limit})
+	fake.dueTaskCallbacksMutex.Unlock()
+	if fake.DueTaskCallbacksStub != nil {
+		return fake.DueTaskCallbacksStub(logger, limit)
+	} else {
+		return fake.dueTaskCallbacksReturns.result1, fake.dueTaskCallbacksReturns.result2
+	}
+}
+
+func (fake *FakeTaskCallbackDB) DueTaskCallbacksCallCount() int {
+	fake.dueTaskCallbacksMutex.RLock()
+	defer fake.dueTaskCallbacksMutex.RUnlock()
+	return len(fake.dueTaskCallbacksArgsForCall)
+}
+
+func (fake *FakeTaskCallbackDB) DueTaskCallbacksArgsForCall(i int) (lager.Logger, int) {
+	fake.dueTaskCallbacksMutex.RLock()
+	defer fake.dueTaskCallbacksMutex.RUnlock()
+	return fake.dueTaskCallbacksArgsForCall[i].logger, fake.dueTaskCallbacksArgsForCall[i].limit
+}
+
+func (fake *FakeTaskCallbackDB) DueTaskCallbacksReturns(result1 []*models.QueuedTaskCallback, result2 error) {
+	fake.DueTaskCallbacksStub = nil
+	fake.dueTaskCallbacksReturns = struct {
+		result1 []*models.QueuedTaskCallback
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskCallbackDB) RescheduleTaskCallback(logger lager.Logger, taskGuid string, attempts int32, nextAttemptAt int64) error {
+	fake.rescheduleTaskCallbackMutex.Lock()
+	fake.rescheduleTaskCallbackArgsForCall = append(fake.rescheduleTaskCallbackArgsForCall, struct {
+		logger        lager.Logger
+		taskGuid      string
+		attempts      int32
+		nextAttemptAt int64
+	}{logger, taskGuid, attempts, nextAttemptAt})
+	fake.rescheduleTaskCallbackMutex.Unlock()
+	if fake.RescheduleTaskCallbackStub != nil {
+		return fake.RescheduleTaskCallbackStub(logger, taskGuid, attempts, nextAttemptAt)
+	} else {
+		return fake.rescheduleTaskCallbackReturns.result1
+	}
+}
+
+func (fake *FakeTaskCallbackDB) RescheduleTaskCallbackCallCount() int {
+	fake.rescheduleTaskCallbackMutex.RLock()
+	defer fake.rescheduleTaskCallbackMutex.RUnlock()
+	return len(fake.rescheduleTaskCallbackArgsForCall)
+}
+
+func (fake *FakeTaskCallbackDB) RescheduleTaskCallbackArgsForCall(i int) (lager.Logger, string, int32, int64) {
+	fake.rescheduleTaskCallbackMutex.RLock()
+	defer fake.rescheduleTaskCallbackMutex.RUnlock()
+	return fake.rescheduleTaskCallbackArgsForCall[i].logger, fake.rescheduleTaskCallbackArgsForCall[i].taskGuid, fake.rescheduleTaskCallbackArgsForCall[i].attempts, fake.rescheduleTaskCallbackArgsForCall[i].nextAttemptAt
+}
+
+func (fake *FakeTaskCallbackDB) RescheduleTaskCallbackReturns(result1 error) {
+	fake.RescheduleTaskCallbackStub = nil
+	fake.rescheduleTaskCallbackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskCallbackDB) RemoveTaskCallback(logger lager.Logger, taskGuid string) error {
+	fake.removeTaskCallbackMutex.Lock()
+	fake.removeTaskCallbackArgsForCall = append(fake.removeTaskCallbackArgsForCall, struct {
+		logger   lager.Logger
+		taskGuid string
+	}{logger, taskGuid})
+	fake.removeTaskCallbackMutex.Unlock()
+	if fake.RemoveTaskCallbackStub != nil {
+		return fake.RemoveTaskCallbackStub(logger, taskGuid)
+	} else {
+		return fake.removeTaskCallbackReturns.result1
+	}
+}
+
+func (fake *FakeTaskCallbackDB) RemoveTaskCallbackCallCount() int {
+	fake.removeTaskCallbackMutex.RLock()
+	defer fake.removeTaskCallbackMutex.RUnlock()
+	return len(fake.removeTaskCallbackArgsForCall)
+}
+
+func (fake *FakeTaskCallbackDB) RemoveTaskCallbackArgsForCall(i int) (lager.Logger, string) {
+	fake.removeTaskCallbackMutex.RLock()
+	defer fake.removeTaskCallbackMutex.RUnlock()
+	return fake.removeTaskCallbackArgsForCall[i].logger, fake.removeTaskCallbackArgsForCall[i].taskGuid
+}
+
+func (fake *FakeTaskCallbackDB) RemoveTaskCallbackReturns(result1 error) {
+	fake.RemoveTaskCallbackStub = nil
+	fake.removeTaskCallbackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskCallbackDB) TaskCallbackQueueDepth(logger lager.Logger) (int, error) {
+	fake.taskCallbackQueueDepthMutex.Lock()
+	fake.taskCallbackQueueDepthArgsForCall = append(fake.taskCallbackQueueDepthArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.taskCallbackQueueDepthMutex.Unlock()
+	if fake.TaskCallbackQueueDepthStub != nil {
+		return fake.TaskCallbackQueueDepthStub(logger)
+	} else {
+		return fake.taskCallbackQueueDepthReturns.result1, fake.taskCallbackQueueDepthReturns.result2
+	}
+}
+
+func (fake *FakeTaskCallbackDB) TaskCallbackQueueDepthCallCount() int {
+	fake.taskCallbackQueueDepthMutex.RLock()
+	defer fake.taskCallbackQueueDepthMutex.RUnlock()
+	return len(fake.taskCallbackQueueDepthArgsForCall)
+}
+
+func (fake *FakeTaskCallbackDB) TaskCallbackQueueDepthArgsForCall(i int) lager.Logger {
+	fake.taskCallbackQueueDepthMutex.RLock()
+	defer fake.taskCallbackQueueDepthMutex.RUnlock()
+	return fake.taskCallbackQueueDepthArgsForCall[i].logger
+}
+
+func (fake *FakeTaskCallbackDB) TaskCallbackQueueDepthReturns(result1 int, result2 error) {
+	fake.TaskCallbackQueueDepthStub = nil
+	fake.taskCallbackQueueDepthReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+var _ db.TaskCallbackDB = new(FakeTaskCallbackDB)
@@ -5,7 +5,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cloudfoundry-incubator/auctioneer"
 	"github.com/cloudfoundry-incubator/bbs/db"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/pivotal-golang/lager"
@@ -32,7 +31,7 @@ type FakeTaskDB struct {
 		result1 *models.Task
 		result2 error
 	}
-	DesireTaskStub        func(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid, domain string) error
+	DesireTaskStub        func(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid string, domain string) (*models.Task, error)
 	desireTaskMutex       sync.RWMutex
 	desireTaskArgsForCall []struct {
 		logger         lager.Logger
@@ -41,9 +40,10 @@ type FakeTaskDB struct {
 		domain         string
 	}
 	desireTaskReturns struct {
-		result1 error
+		result1 *models.Task
+		result2 error
 	}
-	StartTaskStub        func(logger lager.Logger, taskGuid, cellId string) (bool, error)
+	StartTaskStub        func(logger lager.Logger, taskGuid string, cellId string) (*models.TaskChange, bool, error)
 	startTaskMutex       sync.RWMutex
 	startTaskArgsForCall []struct {
 		logger   lager.Logger
@@ -51,21 +51,35 @@ type FakeTaskDB struct {
 		cellId   string
 	}
 	startTaskReturns struct {
-		result1 bool
-		result2 error
+		result1 *models.TaskChange
+		result2 bool
+		result3 error
 	}
-	CancelTaskStub        func(logger lager.Logger, taskGuid string) (task *models.Task, cellID string, err error)
+	CancelTaskStub        func(logger lager.Logger, taskGuid string, reason string) (*models.TaskChange, string, error)
 	cancelTaskMutex       sync.RWMutex
 	cancelTaskArgsForCall []struct {
 		logger   lager.Logger
 		taskGuid string
+		reason   string
 	}
 	cancelTaskReturns struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 string
 		result3 error
 	}
-	FailTaskStub        func(logger lager.Logger, taskGuid, failureReason string) (task *models.Task, err error)
+	CancelTasksStub        func(logger lager.Logger, filter models.TaskFilter, reason string) ([]*models.TaskChange, []*models.TaskResult, error)
+	cancelTasksMutex       sync.RWMutex
+	cancelTasksArgsForCall []struct {
+		logger lager.Logger
+		filter models.TaskFilter
+		reason string
+	}
+	cancelTasksReturns struct {
+		result1 []*models.TaskChange
+		result2 []*models.TaskResult
+		result3 error
+	}
+	FailTaskStub        func(logger lager.Logger, taskGuid string, failureReason string) (*models.TaskChange, error)
 	failTaskMutex       sync.RWMutex
 	failTaskArgsForCall []struct {
 		logger        lager.Logger
@@ -73,10 +87,10 @@ type FakeTaskDB struct {
 		failureReason string
 	}
 	failTaskReturns struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}
-	CompleteTaskStub        func(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) (task *models.Task, err error)
+	CompleteTaskStub        func(logger lager.Logger, taskGuid string, cellId string, failed bool, failureReason string, result string) (*models.TaskChange, error)
 	completeTaskMutex       sync.RWMutex
 	completeTaskArgsForCall []struct {
 		logger        lager.Logger
@@ -87,28 +101,30 @@ type FakeTaskDB struct {
 		result        string
 	}
 	completeTaskReturns struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}
-	ResolvingTaskStub        func(logger lager.Logger, taskGuid string) error
+	ResolvingTaskStub        func(logger lager.Logger, taskGuid string) (*models.TaskChange, error)
 	resolvingTaskMutex       sync.RWMutex
 	resolvingTaskArgsForCall []struct {
 		logger   lager.Logger
 		taskGuid string
 	}
 	resolvingTaskReturns struct {
-		result1 error
+		result1 *models.TaskChange
+		result2 error
 	}
-	DeleteTaskStub        func(logger lager.Logger, taskGuid string) error
+	DeleteTaskStub        func(logger lager.Logger, taskGuid string) (*models.Task, error)
 	deleteTaskMutex       sync.RWMutex
 	deleteTaskArgsForCall []struct {
 		logger   lager.Logger
 		taskGuid string
 	}
 	deleteTaskReturns struct {
-		result1 error
+		result1 *models.Task
+		result2 error
 	}
-	ConvergeTasksStub        func(logger lager.Logger, cellSet models.CellSet, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) (tasksToAuction []*auctioneer.TaskStartRequest, tasksToComplete []*models.Task)
+	ConvergeTasksStub        func(logger lager.Logger, cellSet models.CellSet, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) db.TaskConvergenceResult
 	convergeTasksMutex       sync.RWMutex
 	convergeTasksArgsForCall []struct {
 		logger                      lager.Logger
@@ -118,8 +134,41 @@ type FakeTaskDB struct {
 		expireCompletedTaskDuration time.Duration
 	}
 	convergeTasksReturns struct {
-		result1 []*auctioneer.TaskStartRequest
-		result2 []*models.Task
+		result1 db.TaskConvergenceResult
+	}
+	ReleaseDependentTasksStub        func(logger lager.Logger, taskGuid string) ([]*models.TaskChange, error)
+	releaseDependentTasksMutex       sync.RWMutex
+	releaseDependentTasksArgsForCall []struct {
+		logger   lager.Logger
+		taskGuid string
+	}
+	releaseDependentTasksReturns struct {
+		result1 []*models.TaskChange
+		result2 error
+	}
+	DeadLetterTaskStub        func(logger lager.Logger, taskGuid string, callbackFailureReason string) (*models.TaskChange, error)
+	deadLetterTaskMutex       sync.RWMutex
+	deadLetterTaskArgsForCall []struct {
+		logger                lager.Logger
+		taskGuid              string
+		callbackFailureReason string
+	}
+	deadLetterTaskReturns struct {
+		result1 *models.TaskChange
+		result2 error
+	}
+	TaskHeartbeatStub        func(logger lager.Logger, taskGuid string, cellId string, progressMessage string, progressPercent int32) (*models.TaskChange, error)
+	taskHeartbeatMutex       sync.RWMutex
+	taskHeartbeatArgsForCall []struct {
+		logger          lager.Logger
+		taskGuid        string
+		cellId          string
+		progressMessage string
+		progressPercent int32
+	}
+	taskHeartbeatReturns struct {
+		result1 *models.TaskChange
+		result2 error
 	}
 }
 
@@ -191,7 +240,7 @@ func (fake *FakeTaskDB) TaskByGuidReturns(result1 *models.Task, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeTaskDB) DesireTask(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid string, domain string) error {
+func (fake *FakeTaskDB) DesireTask(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid string, domain string) (*models.Task, error) {
 	fake.desireTaskMutex.Lock()
 	fake.desireTaskArgsForCall = append(fake.desireTaskArgsForCall, struct {
 		logger         lager.Logger
@@ -203,7 +252,7 @@ func (fake *FakeTaskDB) DesireTask(logger lager.Logger, taskDefinition *models.T
 	if fake.DesireTaskStub != nil {
 		return fake.DesireTaskStub(logger, taskDefinition, taskGuid, domain)
 	} else {
-		return fake.desireTaskReturns.result1
+		return fake.desireTaskReturns.result1, fake.desireTaskReturns.result2
 	}
 }
 
@@ -219,14 +268,15 @@ func (fake *FakeTaskDB) DesireTaskArgsForCall(i int) (lager.Logger, *models.Task
 	return fake.desireTaskArgsForCall[i].logger, fake.desireTaskArgsForCall[i].taskDefinition, fake.desireTaskArgsForCall[i].taskGuid, fake.desireTaskArgsForCall[i].domain
 }
 
-func (fake *FakeTaskDB) DesireTaskReturns(result1 error) {
+func (fake *FakeTaskDB) DesireTaskReturns(result1 *models.Task, result2 error) {
 	fake.DesireTaskStub = nil
 	fake.desireTaskReturns = struct {
-		result1 error
-	}{result1}
+		result1 *models.Task
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTaskDB) StartTask(logger lager.Logger, taskGuid string, cellId string) (bool, error) {
+func (fake *FakeTaskDB) StartTask(logger lager.Logger, taskGuid string, cellId string) (*models.TaskChange, bool, error) {
 	fake.startTaskMutex.Lock()
 	fake.startTaskArgsForCall = append(fake.startTaskArgsForCall, struct {
 		logger   lager.Logger
@@ -237,7 +287,7 @@ func (fake *FakeTaskDB) StartTask(logger lager.Logger, taskGuid string, cellId s
 	if fake.StartTaskStub != nil {
 		return fake.StartTaskStub(logger, taskGuid, cellId)
 	} else {
-		return fake.startTaskReturns.result1, fake.startTaskReturns.result2
+		return fake.startTaskReturns.result1, fake.startTaskReturns.result2, fake.startTaskReturns.result3
 	}
 }
 
@@ -253,23 +303,25 @@ func (fake *FakeTaskDB) StartTaskArgsForCall(i int) (lager.Logger, string, strin
 	return fake.startTaskArgsForCall[i].logger, fake.startTaskArgsForCall[i].taskGuid, fake.startTaskArgsForCall[i].cellId
 }
 
-func (fake *FakeTaskDB) StartTaskReturns(result1 bool, result2 error) {
+func (fake *FakeTaskDB) StartTaskReturns(result1 *models.TaskChange, result2 bool, result3 error) {
 	fake.StartTaskStub = nil
 	fake.startTaskReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+		result1 *models.TaskChange
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeTaskDB) CancelTask(logger lager.Logger, taskGuid string) (task *models.Task, cellID string, err error) {
+func (fake *FakeTaskDB) CancelTask(logger lager.Logger, taskGuid string, reason string) (*models.TaskChange, string, error) {
 	fake.cancelTaskMutex.Lock()
 	fake.cancelTaskArgsForCall = append(fake.cancelTaskArgsForCall, struct {
 		logger   lager.Logger
 		taskGuid string
-	}{logger, taskGuid})
+		reason   string
+	}{logger, taskGuid, reason})
 	fake.cancelTaskMutex.Unlock()
 	if fake.CancelTaskStub != nil {
-		return fake.CancelTaskStub(logger, taskGuid)
+		return fake.CancelTaskStub(logger, taskGuid, reason)
 	} else {
 		return fake.cancelTaskReturns.result1, fake.cancelTaskReturns.result2, fake.cancelTaskReturns.result3
 	}
@@ -281,22 +333,58 @@ func (fake *FakeTaskDB) CancelTaskCallCount() int {
 	return len(fake.cancelTaskArgsForCall)
 }
 
-func (fake *FakeTaskDB) CancelTaskArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeTaskDB) CancelTaskArgsForCall(i int) (lager.Logger, string, string) {
 	fake.cancelTaskMutex.RLock()
 	defer fake.cancelTaskMutex.RUnlock()
-	return fake.cancelTaskArgsForCall[i].logger, fake.cancelTaskArgsForCall[i].taskGuid
+	return fake.cancelTaskArgsForCall[i].logger, fake.cancelTaskArgsForCall[i].taskGuid, fake.cancelTaskArgsForCall[i].reason
 }
 
-func (fake *FakeTaskDB) CancelTaskReturns(result1 *models.Task, result2 string, result3 error) {
+func (fake *FakeTaskDB) CancelTaskReturns(result1 *models.TaskChange, result2 string, result3 error) {
 	fake.CancelTaskStub = nil
 	fake.cancelTaskReturns = struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 string
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTaskDB) FailTask(logger lager.Logger, taskGuid string, failureReason string) (task *models.Task, err error) {
+func (fake *FakeTaskDB) CancelTasks(logger lager.Logger, filter models.TaskFilter, reason string) ([]*models.TaskChange, []*models.TaskResult, error) {
+	fake.cancelTasksMutex.Lock()
+	fake.cancelTasksArgsForCall = append(fake.cancelTasksArgsForCall, struct {
+		logger lager.Logger
+		filter models.TaskFilter
+		reason string
+	}{logger, filter, reason})
+	fake.cancelTasksMutex.Unlock()
+	if fake.CancelTasksStub != nil {
+		return fake.CancelTasksStub(logger, filter, reason)
+	} else {
+		return fake.cancelTasksReturns.result1, fake.cancelTasksReturns.result2, fake.cancelTasksReturns.result3
+	}
+}
+
+func (fake *FakeTaskDB) CancelTasksCallCount() int {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	return len(fake.cancelTasksArgsForCall)
+}
+
+func (fake *FakeTaskDB) CancelTasksArgsForCall(i int) (lager.Logger, models.TaskFilter, string) {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	return fake.cancelTasksArgsForCall[i].logger, fake.cancelTasksArgsForCall[i].filter, fake.cancelTasksArgsForCall[i].reason
+}
+
+func (fake *FakeTaskDB) CancelTasksReturns(result1 []*models.TaskChange, result2 []*models.TaskResult, result3 error) {
+	fake.CancelTasksStub = nil
+	fake.cancelTasksReturns = struct {
+		result1 []*models.TaskChange
+		result2 []*models.TaskResult
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskDB) FailTask(logger lager.Logger, taskGuid string, failureReason string) (*models.TaskChange, error) {
 	fake.failTaskMutex.Lock()
 	fake.failTaskArgsForCall = append(fake.failTaskArgsForCall, struct {
 		logger        lager.Logger
@@ -323,15 +411,15 @@ func (fake *FakeTaskDB) FailTaskArgsForCall(i int) (lager.Logger, string, string
 	return fake.failTaskArgsForCall[i].logger, fake.failTaskArgsForCall[i].taskGuid, fake.failTaskArgsForCall[i].failureReason
 }
 
-func (fake *FakeTaskDB) FailTaskReturns(result1 *models.Task, result2 error) {
+func (fake *FakeTaskDB) FailTaskReturns(result1 *models.TaskChange, result2 error) {
 	fake.FailTaskStub = nil
 	fake.failTaskReturns = struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTaskDB) CompleteTask(logger lager.Logger, taskGuid string, cellId string, failed bool, failureReason string, result string) (task *models.Task, err error) {
+func (fake *FakeTaskDB) CompleteTask(logger lager.Logger, taskGuid string, cellId string, failed bool, failureReason string, result string) (*models.TaskChange, error) {
 	fake.completeTaskMutex.Lock()
 	fake.completeTaskArgsForCall = append(fake.completeTaskArgsForCall, struct {
 		logger        lager.Logger
@@ -361,15 +449,15 @@ func (fake *FakeTaskDB) CompleteTaskArgsForCall(i int) (lager.Logger, string, st
 	return fake.completeTaskArgsForCall[i].logger, fake.completeTaskArgsForCall[i].taskGuid, fake.completeTaskArgsForCall[i].cellId, fake.completeTaskArgsForCall[i].failed, fake.completeTaskArgsForCall[i].failureReason, fake.completeTaskArgsForCall[i].result
 }
 
-func (fake *FakeTaskDB) CompleteTaskReturns(result1 *models.Task, result2 error) {
+func (fake *FakeTaskDB) CompleteTaskReturns(result1 *models.TaskChange, result2 error) {
 	fake.CompleteTaskStub = nil
 	fake.completeTaskReturns = struct {
-		result1 *models.Task
+		result1 *models.TaskChange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTaskDB) ResolvingTask(logger lager.Logger, taskGuid string) error {
+func (fake *FakeTaskDB) ResolvingTask(logger lager.Logger, taskGuid string) (*models.TaskChange, error) {
 	fake.resolvingTaskMutex.Lock()
 	fake.resolvingTaskArgsForCall = append(fake.resolvingTaskArgsForCall, struct {
 		logger   lager.Logger
@@ -379,7 +467,7 @@ func (fake *FakeTaskDB) ResolvingTask(logger lager.Logger, taskGuid string) erro
 	if fake.ResolvingTaskStub != nil {
 		return fake.ResolvingTaskStub(logger, taskGuid)
 	} else {
-		return fake.resolvingTaskReturns.result1
+		return fake.resolvingTaskReturns.result1, fake.resolvingTaskReturns.result2
 	}
 }
 
@@ -395,14 +483,15 @@ func (fake *FakeTaskDB) ResolvingTaskArgsForCall(i int) (lager.Logger, string) {
 	return fake.resolvingTaskArgsForCall[i].logger, fake.resolvingTaskArgsForCall[i].taskGuid
 }
 
-func (fake *FakeTaskDB) ResolvingTaskReturns(result1 error) {
+func (fake *FakeTaskDB) ResolvingTaskReturns(result1 *models.TaskChange, result2 error) {
 	fake.ResolvingTaskStub = nil
 	fake.resolvingTaskReturns = struct {
-		result1 error
-	}{result1}
+		result1 *models.TaskChange
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTaskDB) DeleteTask(logger lager.Logger, taskGuid string) error {
+func (fake *FakeTaskDB) DeleteTask(logger lager.Logger, taskGuid string) (*models.Task, error) {
 	fake.deleteTaskMutex.Lock()
 	fake.deleteTaskArgsForCall = append(fake.deleteTaskArgsForCall, struct {
 		logger   lager.Logger
@@ -412,7 +501,7 @@ func (fake *FakeTaskDB) DeleteTask(logger lager.Logger, taskGuid string) error {
 	if fake.DeleteTaskStub != nil {
 		return fake.DeleteTaskStub(logger, taskGuid)
 	} else {
-		return fake.deleteTaskReturns.result1
+		return fake.deleteTaskReturns.result1, fake.deleteTaskReturns.result2
 	}
 }
 
@@ -428,14 +517,15 @@ func (fake *FakeTaskDB) DeleteTaskArgsForCall(i int) (lager.Logger, string) {
 	return fake.deleteTaskArgsForCall[i].logger, fake.deleteTaskArgsForCall[i].taskGuid
 }
 
-func (fake *FakeTaskDB) DeleteTaskReturns(result1 error) {
+func (fake *FakeTaskDB) DeleteTaskReturns(result1 *models.Task, result2 error) {
 	fake.DeleteTaskStub = nil
 	fake.deleteTaskReturns = struct {
-		result1 error
-	}{result1}
+		result1 *models.Task
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTaskDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) (tasksToAuction []*auctioneer.TaskStartRequest, tasksToComplete []*models.Task) {
+func (fake *FakeTaskDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) db.TaskConvergenceResult {
 	fake.convergeTasksMutex.Lock()
 	fake.convergeTasksArgsForCall = append(fake.convergeTasksArgsForCall, struct {
 		logger                      lager.Logger
@@ -448,7 +538,7 @@ func (fake *FakeTaskDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSe
 	if fake.ConvergeTasksStub != nil {
 		return fake.ConvergeTasksStub(logger, cellSet, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
 	} else {
-		return fake.convergeTasksReturns.result1, fake.convergeTasksReturns.result2
+		return fake.convergeTasksReturns.result1
 	}
 }
 
@@ -464,11 +554,116 @@ func (fake *FakeTaskDB) ConvergeTasksArgsForCall(i int) (lager.Logger, models.Ce
 	return fake.convergeTasksArgsForCall[i].logger, fake.convergeTasksArgsForCall[i].cellSet, fake.convergeTasksArgsForCall[i].kickTaskDuration, fake.convergeTasksArgsForCall[i].expirePendingTaskDuration, fake.convergeTasksArgsForCall[i].expireCompletedTaskDuration
 }
 
-func (fake *FakeTaskDB) ConvergeTasksReturns(result1 []*auctioneer.TaskStartRequest, result2 []*models.Task) {
+func (fake *FakeTaskDB) ConvergeTasksReturns(result1 db.TaskConvergenceResult) {
 	fake.ConvergeTasksStub = nil
 	fake.convergeTasksReturns = struct {
-		result1 []*auctioneer.TaskStartRequest
-		result2 []*models.Task
+		result1 db.TaskConvergenceResult
+	}{result1}
+}
+
+func (fake *FakeTaskDB) ReleaseDependentTasks(logger lager.Logger, taskGuid string) ([]*models.TaskChange, error) {
+	fake.releaseDependentTasksMutex.Lock()
+	fake.releaseDependentTasksArgsForCall = append(fake.releaseDependentTasksArgsForCall, struct {
+		logger   lager.Logger
+		taskGuid string
+	}{logger, taskGuid})
+	fake.releaseDependentTasksMutex.Unlock()
+	if fake.ReleaseDependentTasksStub != nil {
+		return fake.ReleaseDependentTasksStub(logger, taskGuid)
+	} else {
+		return fake.releaseDependentTasksReturns.result1, fake.releaseDependentTasksReturns.result2
+	}
+}
+
+func (fake *FakeTaskDB) ReleaseDependentTasksCallCount() int {
+	fake.releaseDependentTasksMutex.RLock()
+	defer fake.releaseDependentTasksMutex.RUnlock()
+	return len(fake.releaseDependentTasksArgsForCall)
+}
+
+func (fake *FakeTaskDB) ReleaseDependentTasksArgsForCall(i int) (lager.Logger, string) {
+	fake.releaseDependentTasksMutex.RLock()
+	defer fake.releaseDependentTasksMutex.RUnlock()
+	return fake.releaseDependentTasksArgsForCall[i].logger, fake.releaseDependentTasksArgsForCall[i].taskGuid
+}
+
+func (fake *FakeTaskDB) ReleaseDependentTasksReturns(result1 []*models.TaskChange, result2 error) {
+	fake.ReleaseDependentTasksStub = nil
+	fake.releaseDependentTasksReturns = struct {
+		result1 []*models.TaskChange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDB) DeadLetterTask(logger lager.Logger, taskGuid string, callbackFailureReason string) (*models.TaskChange, error) {
+	fake.deadLetterTaskMutex.Lock()
+	fake.deadLetterTaskArgsForCall = append(fake.deadLetterTaskArgsForCall, struct {
+		logger                lager.Logger
+		taskGuid              string
+		callbackFailureReason string
+	}{logger, taskGuid, callbackFailureReason})
+	fake.deadLetterTaskMutex.Unlock()
+	if fake.DeadLetterTaskStub != nil {
+		return fake.DeadLetterTaskStub(logger, taskGuid, callbackFailureReason)
+	} else {
+		return fake.deadLetterTaskReturns.result1, fake.deadLetterTaskReturns.result2
+	}
+}
+
+func (fake *FakeTaskDB) DeadLetterTaskCallCount() int {
+	fake.deadLetterTaskMutex.RLock()
+	defer fake.deadLetterTaskMutex.RUnlock()
+	return len(fake.deadLetterTaskArgsForCall)
+}
+
+func (fake *FakeTaskDB) DeadLetterTaskArgsForCall(i int) (lager.Logger, string, string) {
+	fake.deadLetterTaskMutex.RLock()
+	defer fake.deadLetterTaskMutex.RUnlock()
+	return fake.deadLetterTaskArgsForCall[i].logger, fake.deadLetterTaskArgsForCall[i].taskGuid, fake.deadLetterTaskArgsForCall[i].callbackFailureReason
+}
+
+func (fake *FakeTaskDB) DeadLetterTaskReturns(result1 *models.TaskChange, result2 error) {
+	fake.DeadLetterTaskStub = nil
+	fake.deadLetterTaskReturns = struct {
+		result1 *models.TaskChange
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDB) TaskHeartbeat(logger lager.Logger, taskGuid string, cellId string, progressMessage string, progressPercent int32) (*models.TaskChange, error) {
+	fake.taskHeartbeatMutex.Lock()
+	fake.taskHeartbeatArgsForCall = append(fake.taskHeartbeatArgsForCall, struct {
+		logger          lager.Logger
+		taskGuid        string
+		cellId          string
+		progressMessage string
+		progressPercent int32
+	}{logger, taskGuid, cellId, progressMessage, progressPercent})
+	fake.taskHeartbeatMutex.Unlock()
+	if fake.TaskHeartbeatStub != nil {
+		return fake.TaskHeartbeatStub(logger, taskGuid, cellId, progressMessage, progressPercent)
+	} else {
+		return fake.taskHeartbeatReturns.result1, fake.taskHeartbeatReturns.result2
+	}
+}
+
+func (fake *FakeTaskDB) TaskHeartbeatCallCount() int {
+	fake.taskHeartbeatMutex.RLock()
+	defer fake.taskHeartbeatMutex.RUnlock()
+	return len(fake.taskHeartbeatArgsForCall)
+}
+
+func (fake *FakeTaskDB) TaskHeartbeatArgsForCall(i int) (lager.Logger, string, string, string, int32) {
+	fake.taskHeartbeatMutex.RLock()
+	defer fake.taskHeartbeatMutex.RUnlock()
+	return fake.taskHeartbeatArgsForCall[i].logger, fake.taskHeartbeatArgsForCall[i].taskGuid, fake.taskHeartbeatArgsForCall[i].cellId, fake.taskHeartbeatArgsForCall[i].progressMessage, fake.taskHeartbeatArgsForCall[i].progressPercent
+}
+
+func (fake *FakeTaskDB) TaskHeartbeatReturns(result1 *models.TaskChange, result2 error) {
+	fake.TaskHeartbeatStub = nil
+	fake.taskHeartbeatReturns = struct {
+		result1 *models.TaskChange
+		result2 error
 	}{result1, result2}
 }
 
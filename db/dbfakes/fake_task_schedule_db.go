@@ -0,0 +1,409 @@
+// This file was generated by counterfeiter
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeTaskScheduleDB struct {
+	TaskSchedulesStub        func(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error)
+	taskSchedulesMutex       sync.RWMutex
+	taskSchedulesArgsForCall []struct {
+		logger lager.Logger
+		filter models.TaskScheduleFilter
+	}
+	taskSchedulesReturns struct {
+		result1 []*models.TaskSchedule
+		result2 error
+	}
+	TaskScheduleByGuidStub        func(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error)
+	taskScheduleByGuidMutex       sync.RWMutex
+	taskScheduleByGuidArgsForCall []struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}
+	taskScheduleByGuidReturns struct {
+		result1 *models.TaskSchedule
+		result2 error
+	}
+	DesireTaskScheduleStub        func(logger lager.Logger, schedule *models.TaskSchedule) error
+	desireTaskScheduleMutex       sync.RWMutex
+	desireTaskScheduleArgsForCall []struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}
+	desireTaskScheduleReturns struct {
+		result1 error
+	}
+	UpdateTaskScheduleStub        func(logger lager.Logger, schedule *models.TaskSchedule) error
+	updateTaskScheduleMutex       sync.RWMutex
+	updateTaskScheduleArgsForCall []struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}
+	updateTaskScheduleReturns struct {
+		result1 error
+	}
+	RemoveTaskScheduleStub        func(logger lager.Logger, scheduleGuid string) error
+	removeTaskScheduleMutex       sync.RWMutex
+	removeTaskScheduleArgsForCall []struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}
+	removeTaskScheduleReturns struct {
+		result1 error
+	}
+	ScheduledTasksStub        func(logger lager.Logger, scheduleGuid string) ([]*models.Task, error)
+	scheduledTasksMutex       sync.RWMutex
+	scheduledTasksArgsForCall []struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}
+	scheduledTasksReturns struct {
+		result1 []*models.Task
+		result2 error
+	}
+	ScheduleTaskStub        func(logger lager.Logger, schedule *models.TaskSchedule, scheduledAt int64) (*models.Task, error)
+	scheduleTaskMutex       sync.RWMutex
+	scheduleTaskArgsForCall []struct {
+		logger      lager.Logger
+		schedule    *models.TaskSchedule
+		scheduledAt int64
+	}
+	scheduleTaskReturns struct {
+		result1 *models.Task
+		result2 error
+	}
+	SkipScheduledRunStub        func(logger lager.Logger, scheduleGuid string, scheduledAt int64) error
+	skipScheduledRunMutex       sync.RWMutex
+	skipScheduledRunArgsForCall []struct {
+		logger       lager.Logger
+		scheduleGuid string
+		scheduledAt  int64
+	}
+	skipScheduledRunReturns struct {
+		result1 error
+	}
+	PruneScheduledTasksStub        func(logger lager.Logger, scheduleGuid string, historyLimit int32) (int, error)
+	pruneScheduledTasksMutex       sync.RWMutex
+	pruneScheduledTasksArgsForCall []struct {
+		logger       lager.Logger
+		scheduleGuid string
+		historyLimit int32
+	}
+	pruneScheduledTasksReturns struct {
+		result1 int
+		result2 error
+	}
+}
+
+func (fake *FakeTaskScheduleDB) TaskSchedules(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error) {
+	fake.taskSchedulesMutex.Lock()
+	fake.taskSchedulesArgsForCall = append(fake.taskSchedulesArgsForCall, struct {
+		logger lager.Logger
+		filter models.TaskScheduleFilter
+	}{logger, filter})
+	fake.taskSchedulesMutex.Unlock()
+	if fake.TaskSchedulesStub != nil {
+		return fake.TaskSchedulesStub(logger, filter)
+	} else {
+		return fake.taskSchedulesReturns.result1, fake.taskSchedulesReturns.result2
+	}
+}
+
+func (fake *FakeTaskScheduleDB) TaskSchedulesCallCount() int {
+	fake.taskSchedulesMutex.RLock()
+	defer fake.taskSchedulesMutex.RUnlock()
+	return len(fake.taskSchedulesArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) TaskSchedulesArgsForCall(i int) (lager.Logger, models.TaskScheduleFilter) {
+	fake.taskSchedulesMutex.RLock()
+	defer fake.taskSchedulesMutex.RUnlock()
+	return fake.taskSchedulesArgsForCall[i].logger, fake.taskSchedulesArgsForCall[i].filter
+}
+
+func (fake *FakeTaskScheduleDB) TaskSchedulesReturns(result1 []*models.TaskSchedule, result2 error) {
+	fake.TaskSchedulesStub = nil
+	fake.taskSchedulesReturns = struct {
+		result1 []*models.TaskSchedule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskScheduleDB) TaskScheduleByGuid(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error) {
+	fake.taskScheduleByGuidMutex.Lock()
+	fake.taskScheduleByGuidArgsForCall = append(fake.taskScheduleByGuidArgsForCall, struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}{logger, scheduleGuid})
+	fake.taskScheduleByGuidMutex.Unlock()
+	if fake.TaskScheduleByGuidStub != nil {
+		return fake.TaskScheduleByGuidStub(logger, scheduleGuid)
+	} else {
+		return fake.taskScheduleByGuidReturns.result1, fake.taskScheduleByGuidReturns.result2
+	}
+}
+
+func (fake *FakeTaskScheduleDB) TaskScheduleByGuidCallCount() int {
+	fake.taskScheduleByGuidMutex.RLock()
+	defer fake.taskScheduleByGuidMutex.RUnlock()
+	return len(fake.taskScheduleByGuidArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) TaskScheduleByGuidArgsForCall(i int) (lager.Logger, string) {
+	fake.taskScheduleByGuidMutex.RLock()
+	defer fake.taskScheduleByGuidMutex.RUnlock()
+	return fake.taskScheduleByGuidArgsForCall[i].logger, fake.taskScheduleByGuidArgsForCall[i].scheduleGuid
+}
+
+func (fake *FakeTaskScheduleDB) TaskScheduleByGuidReturns(result1 *models.TaskSchedule, result2 error) {
+	fake.TaskScheduleByGuidStub = nil
+	fake.taskScheduleByGuidReturns = struct {
+		result1 *models.TaskSchedule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskScheduleDB) DesireTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	fake.desireTaskScheduleMutex.Lock()
+	fake.desireTaskScheduleArgsForCall = append(fake.desireTaskScheduleArgsForCall, struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}{logger, schedule})
+	fake.desireTaskScheduleMutex.Unlock()
+	if fake.DesireTaskScheduleStub != nil {
+		return fake.DesireTaskScheduleStub(logger, schedule)
+	} else {
+		return fake.desireTaskScheduleReturns.result1
+	}
+}
+
+func (fake *FakeTaskScheduleDB) DesireTaskScheduleCallCount() int {
+	fake.desireTaskScheduleMutex.RLock()
+	defer fake.desireTaskScheduleMutex.RUnlock()
+	return len(fake.desireTaskScheduleArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) DesireTaskScheduleArgsForCall(i int) (lager.Logger, *models.TaskSchedule) {
+	fake.desireTaskScheduleMutex.RLock()
+	defer fake.desireTaskScheduleMutex.RUnlock()
+	return fake.desireTaskScheduleArgsForCall[i].logger, fake.desireTaskScheduleArgsForCall[i].schedule
+}
+
+func (fake *FakeTaskScheduleDB) DesireTaskScheduleReturns(result1 error) {
+	fake.DesireTaskScheduleStub = nil
+	fake.desireTaskScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskScheduleDB) UpdateTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	fake.updateTaskScheduleMutex.Lock()
+	fake.updateTaskScheduleArgsForCall = append(fake.updateTaskScheduleArgsForCall, struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}{logger, schedule})
+	fake.updateTaskScheduleMutex.Unlock()
+	if fake.UpdateTaskScheduleStub != nil {
+		return fake.UpdateTaskScheduleStub(logger, schedule)
+	} else {
+		return fake.updateTaskScheduleReturns.result1
+	}
+}
+
+func (fake *FakeTaskScheduleDB) UpdateTaskScheduleCallCount() int {
+	fake.updateTaskScheduleMutex.RLock()
+	defer fake.updateTaskScheduleMutex.RUnlock()
+	return len(fake.updateTaskScheduleArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) UpdateTaskScheduleArgsForCall(i int) (lager.Logger, *models.TaskSchedule) {
+	fake.updateTaskScheduleMutex.RLock()
+	defer fake.updateTaskScheduleMutex.RUnlock()
+	return fake.updateTaskScheduleArgsForCall[i].logger, fake.updateTaskScheduleArgsForCall[i].schedule
+}
+
+func (fake *FakeTaskScheduleDB) UpdateTaskScheduleReturns(result1 error) {
+	fake.UpdateTaskScheduleStub = nil
+	fake.updateTaskScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskScheduleDB) RemoveTaskSchedule(logger lager.Logger, scheduleGuid string) error {
+	fake.removeTaskScheduleMutex.Lock()
+	fake.removeTaskScheduleArgsForCall = append(fake.removeTaskScheduleArgsForCall, struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}{logger, scheduleGuid})
+	fake.removeTaskScheduleMutex.Unlock()
+	if fake.RemoveTaskScheduleStub != nil {
+		return fake.RemoveTaskScheduleStub(logger, scheduleGuid)
+	} else {
+		return fake.removeTaskScheduleReturns.result1
+	}
+}
+
+func (fake *FakeTaskScheduleDB) RemoveTaskScheduleCallCount() int {
+	fake.removeTaskScheduleMutex.RLock()
+	defer fake.removeTaskScheduleMutex.RUnlock()
+	return len(fake.removeTaskScheduleArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) RemoveTaskScheduleArgsForCall(i int) (lager.Logger, string) {
+	fake.removeTaskScheduleMutex.RLock()
+	defer fake.removeTaskScheduleMutex.RUnlock()
+	return fake.removeTaskScheduleArgsForCall[i].logger, fake.removeTaskScheduleArgsForCall[i].scheduleGuid
+}
+
+func (fake *FakeTaskScheduleDB) RemoveTaskScheduleReturns(result1 error) {
+	fake.RemoveTaskScheduleStub = nil
+	fake.removeTaskScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskScheduleDB) ScheduledTasks(logger lager.Logger, scheduleGuid string) ([]*models.Task, error) {
+	fake.scheduledTasksMutex.Lock()
+	fake.scheduledTasksArgsForCall = append(fake.scheduledTasksArgsForCall, struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}{logger, scheduleGuid})
+	fake.scheduledTasksMutex.Unlock()
+	if fake.ScheduledTasksStub != nil {
+		return fake.ScheduledTasksStub(logger, scheduleGuid)
+	} else {
+		return fake.scheduledTasksReturns.result1, fake.scheduledTasksReturns.result2
+	}
+}
+
+func (fake *FakeTaskScheduleDB) ScheduledTasksCallCount() int {
+	fake.scheduledTasksMutex.RLock()
+	defer fake.scheduledTasksMutex.RUnlock()
+	return len(fake.scheduledTasksArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) ScheduledTasksArgsForCall(i int) (lager.Logger, string) {
+	fake.scheduledTasksMutex.RLock()
+	defer fake.scheduledTasksMutex.RUnlock()
+	return fake.scheduledTasksArgsForCall[i].logger, fake.scheduledTasksArgsForCall[i].scheduleGuid
+}
+
+func (fake *FakeTaskScheduleDB) ScheduledTasksReturns(result1 []*models.Task, result2 error) {
+	fake.ScheduledTasksStub = nil
+	fake.scheduledTasksReturns = struct {
+		result1 []*models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskScheduleDB) ScheduleTask(logger lager.Logger, schedule *models.TaskSchedule, scheduledAt int64) (*models.Task, error) {
+	fake.scheduleTaskMutex.Lock()
+	fake.scheduleTaskArgsForCall = append(fake.scheduleTaskArgsForCall, struct {
+		logger      lager.Logger
+		schedule    *models.TaskSchedule
+		scheduledAt int64
+	}{logger, schedule, scheduledAt})
+	fake.scheduleTaskMutex.Unlock()
+	if fake.ScheduleTaskStub != nil {
+		return fake.ScheduleTaskStub(logger, schedule, scheduledAt)
+	} else {
+		return fake.scheduleTaskReturns.result1, fake.scheduleTaskReturns.result2
+	}
+}
+
+func (fake *FakeTaskScheduleDB) ScheduleTaskCallCount() int {
+	fake.scheduleTaskMutex.RLock()
+	defer fake.scheduleTaskMutex.RUnlock()
+	return len(fake.scheduleTaskArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) ScheduleTaskArgsForCall(i int) (lager.Logger, *models.TaskSchedule, int64) {
+	fake.scheduleTaskMutex.RLock()
+	defer fake.scheduleTaskMutex.RUnlock()
+	return fake.scheduleTaskArgsForCall[i].logger, fake.scheduleTaskArgsForCall[i].schedule, fake.scheduleTaskArgsForCall[i].scheduledAt
+}
+
+func (fake *FakeTaskScheduleDB) ScheduleTaskReturns(result1 *models.Task, result2 error) {
+	fake.ScheduleTaskStub = nil
+	fake.scheduleTaskReturns = struct {
+		result1 *models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskScheduleDB) SkipScheduledRun(logger lager.Logger, scheduleGuid string, scheduledAt int64) error {
+	fake.skipScheduledRunMutex.Lock()
+	fake.skipScheduledRunArgsForCall = append(fake.skipScheduledRunArgsForCall, struct {
+		logger       lager.Logger
+		scheduleGuid string
+		scheduledAt  int64
+	}{logger, scheduleGuid, scheduledAt})
+	fake.skipScheduledRunMutex.Unlock()
+	if fake.SkipScheduledRunStub != nil {
+		return fake.SkipScheduledRunStub(logger, scheduleGuid, scheduledAt)
+	} else {
+		return fake.skipScheduledRunReturns.result1
+	}
+}
+
+func (fake *FakeTaskScheduleDB) SkipScheduledRunCallCount() int {
+	fake.skipScheduledRunMutex.RLock()
+	defer fake.skipScheduledRunMutex.RUnlock()
+	return len(fake.skipScheduledRunArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) SkipScheduledRunArgsForCall(i int) (lager.Logger, string, int64) {
+	fake.skipScheduledRunMutex.RLock()
+	defer fake.skipScheduledRunMutex.RUnlock()
+	return fake.skipScheduledRunArgsForCall[i].logger, fake.skipScheduledRunArgsForCall[i].scheduleGuid, fake.skipScheduledRunArgsForCall[i].scheduledAt
+}
+
+func (fake *FakeTaskScheduleDB) SkipScheduledRunReturns(result1 error) {
+	fake.SkipScheduledRunStub = nil
+	fake.skipScheduledRunReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskScheduleDB) PruneScheduledTasks(logger lager.Logger, scheduleGuid string, historyLimit int32) (int, error) {
+	fake.pruneScheduledTasksMutex.Lock()
+	fake.pruneScheduledTasksArgsForCall = append(fake.pruneScheduledTasksArgsForCall, struct {
+		logger       lager.Logger
+		scheduleGuid string
+		historyLimit int32
+	}{logger, scheduleGuid, historyLimit})
+	fake.pruneScheduledTasksMutex.Unlock()
+	if fake.PruneScheduledTasksStub != nil {
+		return fake.PruneScheduledTasksStub(logger, scheduleGuid, historyLimit)
+	} else {
+		return fake.pruneScheduledTasksReturns.result1, fake.pruneScheduledTasksReturns.result2
+	}
+}
+
+func (fake *FakeTaskScheduleDB) PruneScheduledTasksCallCount() int {
+	fake.pruneScheduledTasksMutex.RLock()
+	defer fake.pruneScheduledTasksMutex.RUnlock()
+	return len(fake.pruneScheduledTasksArgsForCall)
+}
+
+func (fake *FakeTaskScheduleDB) PruneScheduledTasksArgsForCall(i int) (lager.Logger, string, int32) {
+	fake.pruneScheduledTasksMutex.RLock()
+	defer fake.pruneScheduledTasksMutex.RUnlock()
+	return fake.pruneScheduledTasksArgsForCall[i].logger, fake.pruneScheduledTasksArgsForCall[i].scheduleGuid, fake.pruneScheduledTasksArgsForCall[i].historyLimit
+}
+
+func (fake *FakeTaskScheduleDB) PruneScheduledTasksReturns(result1 int, result2 error) {
+	fake.PruneScheduledTasksStub = nil
+	fake.pruneScheduledTasksReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+var _ db.TaskScheduleDB = new(FakeTaskScheduleDB)
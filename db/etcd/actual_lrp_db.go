@@ -72,6 +72,29 @@ func (db *ETCDDB) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFi
 	return groups, nil
 }
 
+// ActualLRPs lists the instance and evacuating ActualLRPs individually, with
+// their presence set from the key they are stored under.
+func (db *ETCDDB) ActualLRPs(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRP, error) {
+	var groups []*models.ActualLRPGroup
+	var err error
+	if filter.ProcessGuid != "" {
+		groups, err = db.ActualLRPGroupsByProcessGuid(logger, filter.ProcessGuid)
+	} else {
+		groups, err = db.ActualLRPGroups(logger, models.ActualLRPFilter{Domain: filter.Domain, CellID: filter.CellID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	actualLRPs := []*models.ActualLRP{}
+	for _, actualLRP := range models.FlattenActualLRPGroups(groups) {
+		if filter.Matches(actualLRP) {
+			actualLRPs = append(actualLRPs, actualLRP)
+		}
+	}
+	return actualLRPs, nil
+}
+
 func (db *ETCDDB) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) ([]*models.ActualLRPGroup, error) {
 	node, err := db.fetchRecursiveRaw(logger, ActualLRPProcessDir(processGuid))
 	bbsErr := models.ConvertError(err)
@@ -194,6 +194,51 @@ var _ = Describe("ActualLRPDB", func() {
 		})
 	})
 
+	Describe("ActualLRPs", func() {
+		var filter models.ActualLRPFilter
+
+		BeforeEach(func() {
+			filter = models.ActualLRPFilter{}
+			etcdHelper.SetRawActualLRP(baseLRP)
+			etcdHelper.SetRawEvacuatingActualLRP(evacuatingLRP, noExpirationTTL)
+			etcdHelper.SetRawActualLRP(otherDomainLRP)
+			etcdHelper.SetRawEvacuatingActualLRP(otherIndexLRP, noExpirationTTL)
+			etcdHelper.SetRawActualLRP(otherCellIdLRP)
+		})
+
+		It("returns the /instance and /evacuating LRPs individually with their presence", func() {
+			actualLRPs, err := etcdDB.ActualLRPs(logger, filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPs).To(ConsistOf(
+				baseLRP,
+				evacuatingLRP.WithPresence(models.ActualLRP_Evacuating),
+				otherDomainLRP,
+				otherIndexLRP.WithPresence(models.ActualLRP_Evacuating),
+				otherCellIdLRP,
+			))
+		})
+
+		It("can filter by process guid and index", func() {
+			index := int32(baseIndex)
+			filter.ProcessGuid = baseProcessGuid
+			filter.Index = &index
+			actualLRPs, err := etcdDB.ActualLRPs(logger, filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPs).To(ConsistOf(
+				baseLRP,
+				evacuatingLRP.WithPresence(models.ActualLRP_Evacuating),
+			))
+		})
+
+		It("can filter by domain and cell id", func() {
+			filter.Domain = otherDomain
+			filter.CellID = otherCellID
+			actualLRPs, err := etcdDB.ActualLRPs(logger, filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPs).To(ConsistOf(otherCellIdLRP))
+		})
+	})
+
 	Describe("ActualLRPGroupsByProcessGuid", func() {
 		Context("when there are both /instance and /evacuating LRPs", func() {
 			BeforeEach(func() {
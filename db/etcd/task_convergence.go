@@ -1,9 +1,11 @@
 package etcd
 
 import (
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry-incubator/auctioneer"
+	bbsdb "github.com/cloudfoundry-incubator/bbs/db"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/runtime-schema/metric"
 	"github.com/cloudfoundry/gunk/workpool"
@@ -25,6 +27,7 @@ const (
 
 type compareAndSwappableTask struct {
 	OldIndex uint64
+	OldTask  *models.Task
 	NewTask  *models.Task
 }
 
@@ -32,7 +35,7 @@ func (db *ETCDDB) ConvergeTasks(
 	logger lager.Logger,
 	cellSet models.CellSet,
 	kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration,
-) ([]*auctioneer.TaskStartRequest, []*models.Task) {
+) bbsdb.TaskConvergenceResult {
 	logger.Info("starting-convergence")
 	defer logger.Info("finished-convergence")
 
@@ -52,7 +55,7 @@ func (db *ETCDDB) ConvergeTasks(
 	if modelErr != nil {
 		logger.Debug("failed-listing-task")
 		sendTaskMetrics(logger, -1, -1, -1, -1)
-		return nil, nil
+		return bbsdb.TaskConvergenceResult{}
 	}
 	logger.Debug("succeeded-listing-task")
 
@@ -71,18 +74,47 @@ func (db *ETCDDB) ConvergeTasks(
 	}
 
 	keysToDelete := []string{}
+	tasksByKey := map[string]*models.Task{}
+	originalTasks := map[string]*models.Task{}
 
 	tasksToCAS := []compareAndSwappableTask{}
 	scheduleForCASByIndex := func(index uint64, newTask *models.Task) {
 		tasksToCAS = append(tasksToCAS, compareAndSwappableTask{
 			OldIndex: index,
+			OldTask:  originalTasks[newTask.TaskGuid],
 			NewTask:  newTask,
 		})
 	}
 
-	tasksToAuction := []*auctioneer.TaskStartRequest{}
+	pendingTasksToKick := []*models.Task{}
+	scheduleRetriedTaskForAuction := func(task *models.Task) {
+		if task.State == models.Task_Pending && task.RetryBackoffElapsed(db.clock.Now().UnixNano()) {
+			logger.Info("requesting-auction-for-retried-task", lager.Data{"task_guid": task.TaskGuid})
+			pendingTasksToKick = append(pendingTasksToKick, task)
+		}
+	}
+
+	tasksToCancel := []*models.Task{}
+	failTaskPastDeadline := func(index uint64, task *models.Task, reason string) {
+		logger.Info("failing-task-past-deadline", lager.Data{"task_guid": task.TaskGuid, "failure_reason": reason})
+		if task.State == models.Task_Running {
+			before := *task
+			tasksToCancel = append(tasksToCancel, &before)
+		}
+
+		db.markTaskFailed(task, reason)
+		scheduleForCASByIndex(index, task)
+		scheduleRetriedTaskForAuction(task)
+		if task.State == models.Task_Completed {
+			scheduleForCompletion(task)
+		}
+	}
+
+	tasksByGuid := map[string]*models.Task{}
+	blockedTasks := []compareAndSwappableTask{}
 
 	var tasksKicked uint64 = 0
+	now := db.clock.Now().UnixNano()
 
 	pendingCount := 0
 	runningCount := 0
@@ -103,21 +135,44 @@ func (db *ETCDDB) ConvergeTasks(
 			continue
 		}
 
+		tasksByGuid[task.TaskGuid] = task
+		tasksByKey[node.Key] = task
+		originalTasks[task.TaskGuid] = task.Copy()
+
 		shouldKickTask := db.durationSinceTaskUpdated(task) >= kickTaskDuration
 
 		switch task.State {
+		case models.Task_Blocked:
+			shouldMarkAsFailed := db.durationSinceTaskCreated(task) >= expirePendingTaskDuration
+			if reason, exceeded := task.ExceededDeadline(now); exceeded {
+				failTaskPastDeadline(node.ModifiedIndex, task, reason)
+				tasksKicked++
+			} else if shouldMarkAsFailed {
+				logError(task, "failed-to-start-in-time")
+				db.markTaskFailed(task, "not started within time limit")
+				scheduleForCASByIndex(node.ModifiedIndex, task)
+				tasksKicked++
+			} else {
+				blockedTasks = append(blockedTasks, compareAndSwappableTask{
+					OldIndex: node.ModifiedIndex,
+					NewTask:  task,
+				})
+			}
 		case models.Task_Pending:
 			pendingCount++
-			shouldMarkAsFailed := db.durationSinceTaskCreated(task) >= expirePendingTaskDuration
-			if shouldMarkAsFailed {
+			shouldMarkAsFailed := db.durationSinceRetryBackoffEnded(task) >= expirePendingTaskDuration
+			if reason, exceeded := task.ExceededDeadline(now); exceeded {
+				failTaskPastDeadline(node.ModifiedIndex, task, reason)
+				tasksKicked++
+			} else if shouldMarkAsFailed {
 				logError(task, "failed-to-start-in-time")
 				db.markTaskFailed(task, "not started within time limit")
 				scheduleForCASByIndex(node.ModifiedIndex, task)
+				scheduleRetriedTaskForAuction(task)
 				tasksKicked++
-			} else if shouldKickTask {
+			} else if shouldKickTask && task.RetryBackoffElapsed(db.clock.Now().UnixNano()) {
 				logger.Info("requesting-auction-for-pending-task", lager.Data{"task_guid": task.TaskGuid})
-				start := auctioneer.NewTaskStartRequestFromModel(task.TaskGuid, task.Domain, task.TaskDefinition)
-				tasksToAuction = append(tasksToAuction, &start)
+				pendingTasksToKick = append(pendingTasksToKick, task)
 				tasksKicked++
 			}
 		case models.Task_Running:
@@ -127,22 +182,26 @@ func (db *ETCDDB) ConvergeTasks(
 				logError(task, "cell-disappeared")
 				db.markTaskFailed(task, "cell disappeared before completion")
 				scheduleForCASByIndex(node.ModifiedIndex, task)
+				scheduleRetriedTaskForAuction(task)
+				tasksKicked++
+			} else if reason, exceeded := task.ExceededDeadline(now); exceeded {
+				failTaskPastDeadline(node.ModifiedIndex, task, reason)
 				tasksKicked++
 			}
 		case models.Task_Completed:
 			completedCount++
-			shouldDeleteTask := db.durationSinceTaskFirstCompleted(task) >= expireCompletedTaskDuration
+			shouldDeleteTask := db.durationSinceTaskFirstCompleted(task) >= task.CompletedRetention(expireCompletedTaskDuration)
 			if shouldDeleteTask {
 				logError(task, "failed-to-start-resolving-in-time")
 				keysToDelete = append(keysToDelete, node.Key)
-			} else if shouldKickTask {
+			} else if shouldKickTask && !task.CallbackDeadLettered {
 				logger.Info("kicking-completed-task", lager.Data{"task_guid": task.TaskGuid})
 				scheduleForCompletion(task)
 				tasksKicked++
 			}
 		case models.Task_Resolving:
 			resolvingCount++
-			shouldDeleteTask := db.durationSinceTaskFirstCompleted(task) >= expireCompletedTaskDuration
+			shouldDeleteTask := db.durationSinceTaskFirstCompleted(task) >= task.CompletedRetention(expireCompletedTaskDuration)
 			if shouldDeleteTask {
 				logError(task, "failed-to-resolve-in-time")
 				keysToDelete = append(keysToDelete, node.Key)
@@ -155,6 +214,46 @@ func (db *ETCDDB) ConvergeTasks(
 			}
 		}
 	}
+
+	// resolve blocked tasks once every task has been seen, so that dependencies
+	// which completed without releasing their dependents are caught up on
+	for _, blocked := range blockedTasks {
+		task := blocked.NewTask
+		changed := false
+		for _, guid := range append([]string{}, task.BlockedOn...) {
+			dependency, ok := tasksByGuid[guid]
+			if !ok || !dependency.HasCompleted() {
+				continue
+			}
+
+			changed = true
+			if dependency.Failed {
+				logError(task, "dependency-failed")
+				db.markTaskCompleted(task, true, models.DependencyFailureReason(guid), "")
+				break
+			}
+			task.Unblock(guid)
+		}
+
+		if !changed {
+			continue
+		}
+
+		scheduleForCASByIndex(blocked.OldIndex, task)
+		if task.State == models.Task_Pending {
+			logger.Info("requesting-auction-for-unblocked-task", lager.Data{"task_guid": task.TaskGuid})
+			pendingTasksToKick = append(pendingTasksToKick, task)
+		}
+		tasksKicked++
+	}
+
+	models.SortTasksByPriority(pendingTasksToKick)
+	tasksToAuction := make([]*auctioneer.TaskStartRequest, 0, len(pendingTasksToKick))
+	for _, task := range pendingTasksToKick {
+		start := auctioneer.NewTaskStartRequestFromModel(task.TaskGuid, task.Domain, task.TaskDefinition)
+		tasksToAuction = append(tasksToAuction, &start)
+	}
+
 	logger.Debug("done-determining-convergence-work", lager.Data{
 		"num_tasks_to_auction":  len(tasksToAuction),
 		"num_tasks_to_cas":      len(tasksToCAS),
@@ -166,18 +265,27 @@ func (db *ETCDDB) ConvergeTasks(
 
 	tasksKickedCounter.Add(tasksKicked)
 	logger.Debug("compare-and-swapping-tasks", lager.Data{"num_tasks_to_cas": len(tasksToCAS)})
-	err := db.batchCompareAndSwapTasks(tasksToCAS, logger)
+	changes, err := db.batchCompareAndSwapTasks(tasksToCAS, logger)
 	if err != nil {
-		return nil, nil
+		return bbsdb.TaskConvergenceResult{}
 	}
 	logger.Debug("done-compare-and-swapping-tasks", lager.Data{"num_tasks_to_cas": len(tasksToCAS)})
 
 	tasksPrunedCounter.Add(uint64(len(keysToDelete)))
 	logger.Debug("deleting-keys", lager.Data{"num_keys_to_delete": len(keysToDelete)})
-	db.batchDeleteTasks(keysToDelete, logger)
+	for _, key := range db.batchDeleteTasks(keysToDelete, logger) {
+		if task, ok := tasksByKey[key]; ok {
+			changes = append(changes, &models.TaskChange{Before: originalTasks[task.TaskGuid]})
+		}
+	}
 	logger.Debug("done-deleting-keys", lager.Data{"num_keys_to_delete": len(keysToDelete)})
 
-	return tasksToAuction, tasksToComplete
+	return bbsdb.TaskConvergenceResult{
+		TasksToAuction:  tasksToAuction,
+		TasksToComplete: tasksToComplete,
+		TasksToCancel:   tasksToCancel,
+		Changes:         changes,
+	}
 }
 
 func (db *ETCDDB) durationSinceTaskCreated(task *models.Task) time.Duration {
@@ -188,6 +296,12 @@ func (db *ETCDDB) durationSinceTaskUpdated(task *models.Task) time.Duration {
 	return db.clock.Now().Sub(time.Unix(0, task.UpdatedAt))
 }
 
+// durationSinceRetryBackoffEnded times a pending task from when it became
+// pending, or for a retried task from when its backoff ended.
+func (db *ETCDDB) durationSinceRetryBackoffEnded(task *models.Task) time.Duration {
+	return db.clock.Now().Sub(time.Unix(0, task.RetryBackoffEndsAt()))
+}
+
 func (db *ETCDDB) durationSinceTaskFirstCompleted(task *models.Task) time.Duration {
 	if task.FirstCompletedAt == 0 {
 		return 0
@@ -195,7 +309,13 @@ func (db *ETCDDB) durationSinceTaskFirstCompleted(task *models.Task) time.Durati
 	return db.clock.Now().Sub(time.Unix(0, task.FirstCompletedAt))
 }
 
+// markTaskFailed fails the task, unless its retry policy allows another
+// attempt, in which case it is moved back to pending.
 func (db *ETCDDB) markTaskFailed(task *models.Task, reason string) {
+	if task.ShouldRetry(reason) {
+		task.Retry(reason, db.clock.Now().UnixNano())
+		return
+	}
 	db.markTaskCompleted(task, true, reason, "")
 }
 
@@ -204,11 +324,15 @@ func demoteToCompleted(task *models.Task) *models.Task {
 	return task
 }
 
-func (db *ETCDDB) batchCompareAndSwapTasks(tasksToCAS []compareAndSwappableTask, logger lager.Logger) error {
+// batchCompareAndSwapTasks persists the converged tasks concurrently and
+// returns how each task it managed to swap changed.
+func (db *ETCDDB) batchCompareAndSwapTasks(tasksToCAS []compareAndSwappableTask, logger lager.Logger) ([]*models.TaskChange, error) {
+	changes := []*models.TaskChange{}
 	if len(tasksToCAS) == 0 {
-		return nil
+		return changes, nil
 	}
 
+	var changesLock sync.Mutex
 	works := []func(){}
 
 	for _, taskToCAS := range tasksToCAS {
@@ -223,30 +347,40 @@ func (db *ETCDDB) batchCompareAndSwapTasks(tasksToCAS []compareAndSwappableTask,
 		}
 
 		index := taskToCAS.OldIndex
+		change := &models.TaskChange{Before: taskToCAS.OldTask, After: task}
 		works = append(works, func() {
 			_, err := db.client.CompareAndSwap(TaskSchemaPathByGuid(task.TaskGuid), value, NO_TTL, index)
 			if err != nil {
 				logger.Error("failed-to-compare-and-swap", err, lager.Data{
 					"task_guid": task.TaskGuid,
 				})
+				return
 			}
+
+			changesLock.Lock()
+			changes = append(changes, change)
+			changesLock.Unlock()
 		})
 	}
 
 	throttler, err := workpool.NewThrottler(db.convergenceWorkersSize, works)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	throttler.Work()
-	return nil
+	return changes, nil
 }
 
-func (db *ETCDDB) batchDeleteTasks(taskGuids []string, logger lager.Logger) {
+// batchDeleteTasks deletes the keys concurrently and returns the keys it
+// managed to delete.
+func (db *ETCDDB) batchDeleteTasks(taskGuids []string, logger lager.Logger) []string {
+	deleted := []string{}
 	if len(taskGuids) == 0 {
-		return
+		return deleted
 	}
 
+	var deletedLock sync.Mutex
 	works := []func(){}
 
 	for _, taskGuid := range taskGuids {
@@ -257,7 +391,12 @@ func (db *ETCDDB) batchDeleteTasks(taskGuids []string, logger lager.Logger) {
 				logger.Error("failed-to-delete", err, lager.Data{
 					"task_guid": taskGuid,
 				})
+				return
 			}
+
+			deletedLock.Lock()
+			deleted = append(deleted, taskGuid)
+			deletedLock.Unlock()
 		})
 	}
 
@@ -267,7 +406,7 @@ func (db *ETCDDB) batchDeleteTasks(taskGuids []string, logger lager.Logger) {
 	}
 
 	throttler.Work()
-	return
+	return deleted
 }
 
 func sendTaskMetrics(logger lager.Logger, pendingCount, runningCount, completedCount, resolvingCount int) {
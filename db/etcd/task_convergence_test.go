@@ -44,6 +44,8 @@ var _ = Describe("Convergence of Tasks", func() {
 		var (
 			tasksToAuction  []*auctioneer.TaskStartRequest
 			tasksToComplete []*models.Task
+			tasksToCancel   []*models.Task
+			changes         []*models.TaskChange
 			cells           models.CellSet
 		)
 
@@ -52,7 +54,9 @@ var _ = Describe("Convergence of Tasks", func() {
 		})
 
 		JustBeforeEach(func() {
-			tasksToAuction, tasksToComplete = etcdDB.ConvergeTasks(logger, cells, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			result := etcdDB.ConvergeTasks(logger, cells, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			tasksToAuction, tasksToComplete, tasksToCancel = result.TasksToAuction, result.TasksToComplete, result.TasksToCancel
+			changes = result.Changes
 		})
 
 		It("bumps the convergence counter", func() {
@@ -145,6 +149,22 @@ var _ = Describe("Convergence of Tasks", func() {
 					Expect(tasksToAuction).To(HaveLen(2))
 					Expect([]string{tasksToAuction[0].TaskGuid, tasksToAuction[1].TaskGuid}).To(ConsistOf(taskGuid, taskGuid2))
 				})
+
+				Context("when one of them has a higher priority", func() {
+					BeforeEach(func() {
+						urgentTask := model_helpers.NewValidTask(taskGuid2)
+						urgentTask.Priority = 90
+						urgentTask.CreatedAt = clock.Now().Add(-kickTasksDuration - time.Second).UnixNano()
+						urgentTask.UpdatedAt = urgentTask.CreatedAt
+						etcdHelper.SetRawTask(urgentTask)
+					})
+
+					It("returns it first", func() {
+						Expect(tasksToAuction).To(HaveLen(2))
+						Expect(tasksToAuction[0].TaskGuid).To(Equal(taskGuid2))
+						Expect(tasksToAuction[1].TaskGuid).To(Equal(taskGuid))
+					})
+				})
 			})
 
 			Context("when the Task has been pending for longer than the expirePendingTasksDuration", func() {
@@ -164,15 +184,116 @@ var _ = Describe("Convergence of Tasks", func() {
 				It("bumps the compare-and-swap counter", func() {
 					Expect(sender.GetCounter("ConvergenceTasksKicked")).To(Equal(uint64(2)))
 				})
+
+				Context("when one of them is a retry still backing off", func() {
+					BeforeEach(func() {
+						retriedTask := model_helpers.NewValidTask(taskGuid2)
+						retriedTask.RetryPolicy = &models.RetryPolicy{
+							MaxAttempts:             2,
+							BackoffMs:               60000,
+							RetryableFailureReasons: []string{"cell disappeared before completion"},
+						}
+						retriedTask.Attempts = 1
+						retriedTask.CreatedAt = clock.Now().Add(-expirePendingTaskDuration - time.Second).UnixNano()
+						retriedTask.UpdatedAt = retriedTask.CreatedAt
+						retriedTask.FirstCompletedAt = 0
+						etcdHelper.SetRawTask(retriedTask)
+					})
+
+					It("leaves it pending", func() {
+						returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid2)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(returnedTask.State).To(Equal(models.Task_Pending))
+						Expect(returnedTask.Failed).To(BeFalse())
+					})
+
+					It("does not auction it yet", func() {
+						Expect(tasksToAuction).To(BeEmpty())
+					})
+				})
+			})
+		})
+
+		Context("when a Task is blocked", func() {
+			var dependency *models.Task
+
+			BeforeEach(func() {
+				dependency = model_helpers.NewValidTask(taskGuid2)
+				dependency.State = models.Task_Completed
+				dependency.CreatedAt = clock.Now().UnixNano()
+				dependency.UpdatedAt = clock.Now().UnixNano()
+				dependency.FirstCompletedAt = clock.Now().UnixNano()
+
+				blockedTask := model_helpers.NewValidTask(taskGuid)
+				blockedTask.DependsOn = []string{taskGuid2}
+				blockedTask.BlockedOn = []string{taskGuid2}
+				blockedTask.State = models.Task_Blocked
+				blockedTask.CreatedAt = clock.Now().UnixNano()
+				blockedTask.UpdatedAt = clock.Now().UnixNano()
+				etcdHelper.SetRawTask(blockedTask)
+			})
+
+			Context("when its dependency has succeeded", func() {
+				BeforeEach(func() {
+					etcdHelper.SetRawTask(dependency)
+				})
+
+				It("unblocks the task and returns it to be auctioned", func() {
+					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedTask.State).To(Equal(models.Task_Pending))
+
+					Expect(tasksToAuction).To(HaveLen(1))
+					Expect(tasksToAuction[0].TaskGuid).To(Equal(taskGuid))
+				})
+			})
+
+			Context("when its dependency has failed", func() {
+				BeforeEach(func() {
+					dependency.Failed = true
+					dependency.FailureReason = "boom"
+					etcdHelper.SetRawTask(dependency)
+				})
+
+				It("fails the task", func() {
+					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedTask.State).To(Equal(models.Task_Completed))
+					Expect(returnedTask.Failed).To(BeTrue())
+					Expect(returnedTask.FailureReason).To(Equal(models.DependencyFailureReason(taskGuid2)))
+
+					Expect(tasksToAuction).To(BeEmpty())
+				})
+			})
+
+			Context("when its dependency has not completed", func() {
+				It("leaves the task blocked", func() {
+					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedTask.State).To(Equal(models.Task_Blocked))
+				})
+			})
+
+			Context("when it has been blocked for longer than the expirePendingTasksDuration", func() {
+				BeforeEach(func() {
+					clock.IncrementBySeconds(expirePendingTaskDurationInSeconds + 1)
+				})
+
+				It("should mark the Task as completed & failed", func() {
+					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedTask.State).To(Equal(models.Task_Completed))
+					Expect(returnedTask.FailureReason).To(ContainSubstring("time limit"))
+				})
 			})
 		})
 
 		Context("when a Task is running", func() {
 			BeforeEach(func() {
-				err := etcdDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, "cell-id")
+				_, _, err = etcdDB.StartTask(logger, taskGuid, "cell-id")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -193,6 +314,43 @@ var _ = Describe("Convergence of Tasks", func() {
 				})
 			})
 
+			Context("when the associated cell is present and the Task has exceeded its max run time", func() {
+				BeforeEach(func() {
+					cellPresence := models.NewCellPresence("cell-id", "1.2.3.4", "the-zone", models.NewCellCapacity(128, 1024, 3), []string{}, []string{})
+					cells["cell-id"] = &cellPresence
+
+					taskDef := model_helpers.NewValidTaskDefinition()
+					taskDef.MaxRunTimeMs = 1000
+					_, err := etcdDB.DesireTask(logger, taskDef, taskGuid2, domain)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, err = etcdDB.StartTask(logger, taskGuid2, "cell-id")
+					Expect(err).NotTo(HaveOccurred())
+
+					clock.IncrementBySeconds(2)
+				})
+
+				It("should mark the Task as completed & failed", func() {
+					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid2)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedTask.State).To(Equal(models.Task_Completed))
+					Expect(returnedTask.Failed).To(BeTrue())
+					Expect(returnedTask.FailureReason).To(Equal(models.MaxRunTimeExceededReason))
+				})
+
+				It("returns the Task to be cancelled on its cell", func() {
+					Expect(tasksToCancel).To(HaveLen(1))
+					Expect(tasksToCancel[0].TaskGuid).To(Equal(taskGuid2))
+					Expect(tasksToCancel[0].CellId).To(Equal("cell-id"))
+				})
+
+				It("leaves the Task within its deadline running", func() {
+					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedTask.State).To(Equal(models.Task_Running))
+				})
+			})
+
 			Context("when the associated cell is missing", func() {
 				It("should mark the Task as completed & failed", func() {
 					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid)
@@ -203,10 +361,42 @@ var _ = Describe("Convergence of Tasks", func() {
 					Expect(returnedTask.FailureReason).To(ContainSubstring("cell"))
 				})
 
+				It("returns how it changed the Task", func() {
+					Expect(changes).To(HaveLen(1))
+					Expect(changes[0].Before.State).To(Equal(models.Task_Running))
+					Expect(changes[0].After.State).To(Equal(models.Task_Completed))
+					Expect(changes[0].After.Failed).To(BeTrue())
+				})
+
 				It("bumps the compare-and-swap counter", func() {
 					Expect(sender.GetCounter("ConvergenceTasksKicked")).To(Equal(uint64(1)))
 				})
 			})
+
+			Context("when the associated cell is missing and the retry policy retries the failure", func() {
+				BeforeEach(func() {
+					taskDef := model_helpers.NewValidTaskDefinition()
+					taskDef.RetryPolicy = &models.RetryPolicy{
+						MaxAttempts:             2,
+						RetryableFailureReasons: []string{"cell disappeared before completion"},
+					}
+					_, err := etcdDB.DesireTask(logger, taskDef, taskGuid2, domain)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, err = etcdDB.StartTask(logger, taskGuid2, "cell-id")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("moves the Task back to pending and returns it to be auctioned", func() {
+					returnedTask, err := etcdDB.TaskByGuid(logger, taskGuid2)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedTask.State).To(Equal(models.Task_Pending))
+					Expect(returnedTask.Attempts).To(BeEquivalentTo(1))
+
+					Expect(tasksToAuction).To(HaveLen(1))
+					Expect(tasksToAuction[0].TaskGuid).To(Equal(taskGuid2))
+				})
+			})
 		})
 
 		Describe("Completed tasks", func() {
@@ -215,24 +405,24 @@ var _ = Describe("Convergence of Tasks", func() {
 					taskDef := model_helpers.NewValidTaskDefinition()
 					taskDef.CompletionCallbackUrl = "blah"
 
-					err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+					_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+					_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 					Expect(err).NotTo(HaveOccurred())
 
-					task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "'cause I said so", "a magical result")
+					change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "'cause I said so", "a magical result")
 					Expect(err).NotTo(HaveOccurred())
-					Expect(task.TaskGuid).To(Equal(taskGuid))
+					Expect(change.After.TaskGuid).To(Equal(taskGuid))
 
-					err = etcdDB.DesireTask(logger, taskDef, taskGuid2, domain)
+					_, err = etcdDB.DesireTask(logger, taskDef, taskGuid2, domain)
 
-					_, err = etcdDB.StartTask(logger, taskGuid2, cellId)
+					_, _, err = etcdDB.StartTask(logger, taskGuid2, cellId)
 					Expect(err).NotTo(HaveOccurred())
 
-					task, err = etcdDB.CompleteTask(logger, taskGuid2, cellId, true, "'cause I said so", "a magical result")
+					change, err = etcdDB.CompleteTask(logger, taskGuid2, cellId, true, "'cause I said so", "a magical result")
 					Expect(err).NotTo(HaveOccurred())
-					Expect(task.TaskGuid).To(Equal(taskGuid2))
+					Expect(change.After.TaskGuid).To(Equal(taskGuid2))
 				})
 
 				It("emits a completed metric", func() {
@@ -263,6 +453,14 @@ var _ = Describe("Convergence of Tasks", func() {
 						_, modelErr := etcdDB.TaskByGuid(logger, taskGuid)
 						Expect(modelErr).To(Equal(models.ErrResourceNotFound))
 					})
+
+					It("returns the deleted tasks as removed", func() {
+						Expect(changes).To(HaveLen(2))
+						for _, change := range changes {
+							Expect(change.Before.State).To(Equal(models.Task_Completed))
+							Expect(change.After).To(BeNil())
+						}
+					})
 				})
 
 				Context("when the task has been completed for less than the convergence interval", func() {
@@ -281,6 +479,52 @@ var _ = Describe("Convergence of Tasks", func() {
 					})
 				})
 			})
+
+			Context("when Tasks have their own retention period", func() {
+				BeforeEach(func() {
+					shortLived := model_helpers.NewValidTaskDefinition()
+					shortLived.CompletedRetentionMs = 1000
+					longLived := model_helpers.NewValidTaskDefinition()
+					longLived.CompletedRetentionMs = int64(2 * expireCompletedTaskDuration / time.Millisecond)
+
+					for guid, taskDef := range map[string]*models.TaskDefinition{taskGuid: shortLived, taskGuid2: longLived} {
+						_, err := etcdDB.DesireTask(logger, taskDef, guid, domain)
+						Expect(err).NotTo(HaveOccurred())
+
+						_, _, err = etcdDB.StartTask(logger, guid, cellId)
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = etcdDB.CompleteTask(logger, guid, cellId, false, "", "a magical result")
+						Expect(err).NotTo(HaveOccurred())
+					}
+
+				})
+
+				Context("once a shorter retention period has passed", func() {
+					BeforeEach(func() {
+						clock.IncrementBySeconds(2)
+					})
+
+					It("deletes the task before the default expiry", func() {
+						_, modelErr := etcdDB.TaskByGuid(logger, taskGuid)
+						Expect(modelErr).To(Equal(models.ErrResourceNotFound))
+
+						_, modelErr = etcdDB.TaskByGuid(logger, taskGuid2)
+						Expect(modelErr).NotTo(HaveOccurred())
+					})
+				})
+
+				Context("once the default expiry has passed", func() {
+					BeforeEach(func() {
+						clock.IncrementBySeconds(uint64(expireCompletedTaskDuration.Seconds()) + 1)
+					})
+
+					It("keeps the task with a longer retention period", func() {
+						_, modelErr := etcdDB.TaskByGuid(logger, taskGuid2)
+						Expect(modelErr).NotTo(HaveOccurred())
+					})
+				})
+			})
 		})
 
 		Context("when a Task is resolving", func() {
@@ -288,17 +532,17 @@ var _ = Describe("Convergence of Tasks", func() {
 				taskDef := model_helpers.NewValidTaskDefinition()
 				taskDef.CompletionCallbackUrl = "blah"
 
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "'cause I said so", "a magical result")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "'cause I said so", "a magical result")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(task.TaskGuid).To(Equal(taskGuid))
+				Expect(change.After.TaskGuid).To(Equal(taskGuid))
 
-				err = etcdDB.ResolvingTask(logger, taskGuid)
+				_, err = etcdDB.ResolvingTask(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -7,7 +7,7 @@ import (
 
 const NO_TTL = 0
 
-func (db *ETCDDB) DesireTask(logger lager.Logger, taskDef *models.TaskDefinition, taskGuid, domain string) error {
+func (db *ETCDDB) DesireTask(logger lager.Logger, taskDef *models.TaskDefinition, taskGuid, domain string) (*models.Task, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 	logger.Info("starting")
 	defer logger.Info("finished")
@@ -22,19 +22,24 @@ func (db *ETCDDB) DesireTask(logger lager.Logger, taskDef *models.TaskDefinition
 		UpdatedAt:      now,
 	}
 
+	if len(taskDef.DependsOn) > 0 {
+		task.State = models.Task_Blocked
+		task.BlockedOn = append([]string{}, taskDef.DependsOn...)
+	}
+
 	value, err := db.serializeModel(logger, task)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Debug("persisting-task")
 	_, err = db.client.Create(TaskSchemaPathByGuid(task.TaskGuid), value, NO_TTL)
 	if err != nil {
-		return ErrorFromEtcdError(logger, err)
+		return nil, ErrorFromEtcdError(logger, err)
 	}
 	logger.Debug("succeeded-persisting-task")
 
-	return nil
+	return task, nil
 }
 
 func (db *ETCDDB) Tasks(logger lager.Logger, filter models.TaskFilter) ([]*models.Task, error) {
@@ -59,10 +64,7 @@ func (db *ETCDDB) Tasks(logger lager.Logger, filter models.TaskFilter) ([]*model
 			return nil, err
 		}
 
-		if filter.Domain != "" && task.Domain != filter.Domain {
-			continue
-		}
-		if filter.CellID != "" && task.CellId != filter.CellID {
+		if !filter.Matches(task) {
 			continue
 		}
 
@@ -95,49 +97,50 @@ func (db *ETCDDB) taskByGuidWithIndex(logger lager.Logger, taskGuid string) (*mo
 	return task, node.ModifiedIndex, nil
 }
 
-func (db *ETCDDB) StartTask(logger lager.Logger, taskGuid, cellID string) (bool, error) {
+func (db *ETCDDB) StartTask(logger lager.Logger, taskGuid, cellID string) (*models.TaskChange, bool, error) {
 	logger.Debug("starting")
 	defer logger.Debug("finished")
 
 	task, index, err := db.taskByGuidWithIndex(logger, taskGuid)
 	if err != nil {
 		logger.Error("failed-to-fetch-task", err)
-		return false, err
+		return nil, false, err
 	}
 
 	logger = logger.WithData(lager.Data{"task": task.LagerData()})
 
 	if task.State == models.Task_Running && task.CellId == cellID {
 		logger.Info("task-already-running")
-		return false, nil
+		return nil, false, nil
 	}
 
 	if err = task.ValidateTransitionTo(models.Task_Running); err != nil {
-		return false, err
+		return nil, false, err
 	}
 
+	before := task.Copy()
 	task.UpdatedAt = db.clock.Now().UnixNano()
 	task.State = models.Task_Running
 	task.CellId = cellID
 
 	value, err := db.serializeModel(logger, task)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
 	_, err = db.client.CompareAndSwap(TaskSchemaPathByGuid(taskGuid), value, NO_TTL, index)
 	if err != nil {
 		logger.Error("failed-persisting-task", err)
-		return false, ErrorFromEtcdError(logger, err)
+		return nil, false, ErrorFromEtcdError(logger, err)
 	}
 
-	return true, nil
+	return &models.TaskChange{Before: before, After: task}, true, nil
 }
 
 // The cell calls this when the user requested to cancel the task
 // stagerTaskBBS will retry this repeatedly if it gets a StoreTimeout error (up to N seconds?)
 // Will fail if the task has already been cancelled or completed normally
-func (db *ETCDDB) CancelTask(logger lager.Logger, taskGuid string) (*models.Task, string, error) {
+func (db *ETCDDB) CancelTask(logger lager.Logger, taskGuid, reason string) (*models.TaskChange, string, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 
 	logger.Info("starting")
@@ -150,29 +153,57 @@ func (db *ETCDDB) CancelTask(logger lager.Logger, taskGuid string) (*models.Task
 	}
 
 	if err = task.ValidateTransitionTo(models.Task_Completed); err != nil {
-		if task.State != models.Task_Pending {
+		if task.State != models.Task_Pending && task.State != models.Task_Blocked {
 			logger.Error("invalid-state-transition", err)
 			return nil, "", err
 		}
 	}
 
 	logger.Info("completing-task")
+	before := task.Copy()
 	cellID := task.CellId
-	err = db.completeTask(logger, task, index, true, "task was cancelled", "")
+	err = db.completeTask(logger, task, index, true, reason, "")
 	if err != nil {
 		logger.Error("failed-completing-task", err)
 		return nil, "", err
 	}
 
 	logger.Info("succeeded-completing-task")
-	return task, cellID, nil
+	return &models.TaskChange{Before: before, After: task}, cellID, nil
+}
+
+// CancelTasks cancels every task matching the filter, one task at a time.
+func (db *ETCDDB) CancelTasks(logger lager.Logger, filter models.TaskFilter, reason string) ([]*models.TaskChange, []*models.TaskResult, error) {
+	logger = logger.Session("cancel-tasks", lager.Data{"filter": filter})
+
+	logger.Info("starting")
+	defer logger.Info("finished")
+
+	tasks, err := db.Tasks(logger, filter)
+	if err != nil {
+		logger.Error("failed-fetching-tasks", err)
+		return nil, nil, err
+	}
+
+	changes := []*models.TaskChange{}
+	results := make([]*models.TaskResult, 0, len(tasks))
+	for _, task := range tasks {
+		change, _, err := db.CancelTask(logger, task.TaskGuid, reason)
+		results = append(results, &models.TaskResult{TaskGuid: task.TaskGuid, Error: models.ConvertError(err)})
+		if err != nil {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, results, nil
 }
 
 // The cell calls this when it has finished running the task (be it success or failure)
 // stagerTaskBBS will retry this repeatedly if it gets a StoreTimeout error (up to N seconds?)
 // This really really shouldn't fail.  If it does, blog about it and walk away. If it failed in a
 // consistent way (i.e. key already exists), there's probably a flaw in our design.
-func (db *ETCDDB) CompleteTask(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) (*models.Task, error) {
+func (db *ETCDDB) CompleteTask(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) (*models.TaskChange, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid, "cell_id": cellId})
 
 	logger.Info("starting")
@@ -195,10 +226,20 @@ func (db *ETCDDB) CompleteTask(logger lager.Logger, taskGuid, cellId string, fai
 		return nil, err
 	}
 
-	return task, db.completeTask(logger, task, index, failed, failureReason, result)
+	before := task.Copy()
+	if failed && task.ShouldRetry(failureReason) {
+		err = db.retryTask(logger, task, index, failureReason)
+	} else {
+		err = db.completeTask(logger, task, index, failed, failureReason, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TaskChange{Before: before, After: task}, nil
 }
 
-func (db *ETCDDB) FailTask(logger lager.Logger, taskGuid, failureReason string) (*models.Task, error) {
+func (db *ETCDDB) FailTask(logger lager.Logger, taskGuid, failureReason string) (*models.TaskChange, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 
 	logger.Info("starting")
@@ -213,13 +254,23 @@ func (db *ETCDDB) FailTask(logger lager.Logger, taskGuid, failureReason string)
 	logger.Info("succeeded-getting-task")
 
 	if err = task.ValidateTransitionTo(models.Task_Completed); err != nil {
-		if task.State != models.Task_Pending {
+		if task.State != models.Task_Pending && task.State != models.Task_Blocked {
 			logger.Error("invalid-state-transition", err)
 			return nil, err
 		}
 	}
 
-	return task, db.completeTask(logger, task, index, true, failureReason, "")
+	before := task.Copy()
+	if task.ShouldRetry(failureReason) {
+		err = db.retryTask(logger, task, index, failureReason)
+	} else {
+		err = db.completeTask(logger, task, index, true, failureReason, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TaskChange{Before: before, After: task}, nil
 }
 
 func (db *ETCDDB) completeTask(logger lager.Logger, task *models.Task, index uint64, failed bool, failureReason, result string) error {
@@ -240,6 +291,25 @@ func (db *ETCDDB) completeTask(logger lager.Logger, task *models.Task, index uin
 	return nil
 }
 
+func (db *ETCDDB) retryTask(logger lager.Logger, task *models.Task, index uint64, failureReason string) error {
+	logger.Info("retrying-task", lager.Data{"attempts": task.Attempts + 1, "failure_reason": failureReason})
+	task.Retry(failureReason, db.clock.Now().UnixNano())
+
+	value, err := db.serializeModel(logger, task)
+	if err != nil {
+		logger.Error("failed-serializing-model", err)
+		return err
+	}
+
+	_, err = db.client.CompareAndSwap(TaskSchemaPathByGuid(task.TaskGuid), value, NO_TTL, index)
+	if err != nil {
+		logger.Error("failed-persisting-task", err)
+		return ErrorFromEtcdError(logger, err)
+	}
+
+	return nil
+}
+
 func (db *ETCDDB) markTaskCompleted(task *models.Task, failed bool, failureReason, result string) {
 	now := db.clock.Now().UnixNano()
 	task.CellId = ""
@@ -251,9 +321,81 @@ func (db *ETCDDB) markTaskCompleted(task *models.Task, failed bool, failureReaso
 	task.Result = result
 }
 
+// ReleaseDependentTasks updates the tasks blocked on the given task once it has
+// completed: they are unblocked if it succeeded, and failed if it failed.
+func (db *ETCDDB) ReleaseDependentTasks(logger lager.Logger, taskGuid string) ([]*models.TaskChange, error) {
+	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
+
+	logger.Info("starting")
+	defer logger.Info("finished")
+
+	dependency, err := db.TaskByGuid(logger, taskGuid)
+	if err != nil {
+		logger.Error("failed-getting-task", err)
+		return nil, err
+	}
+
+	changes := []*models.TaskChange{}
+	if !dependency.HasCompleted() {
+		return changes, nil
+	}
+
+	root, err := db.fetchRecursiveRaw(logger, TaskSchemaRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range root.Nodes {
+		task := new(models.Task)
+		err := db.deserializeModel(logger, node, task)
+		if err != nil {
+			logger.Error("failed-parsing-task", err, lager.Data{"key": node.Key})
+			continue
+		}
+
+		if !task.IsBlockedOn(taskGuid) {
+			continue
+		}
+
+		before := *task
+		if dependency.Failed {
+			err = db.completeTask(logger, task, node.ModifiedIndex, true, models.DependencyFailureReason(taskGuid), "")
+		} else {
+			err = db.unblockTask(logger, task, node.ModifiedIndex, taskGuid)
+		}
+		if err != nil {
+			logger.Error("failed-releasing-task", err, lager.Data{"blocked_task_guid": task.TaskGuid})
+			return nil, err
+		}
+
+		changes = append(changes, &models.TaskChange{Before: &before, After: task})
+	}
+
+	return changes, nil
+}
+
+func (db *ETCDDB) unblockTask(logger lager.Logger, task *models.Task, index uint64, dependencyGuid string) error {
+	task.Unblock(dependencyGuid)
+	task.UpdatedAt = db.clock.Now().UnixNano()
+
+	value, err := db.serializeModel(logger, task)
+	if err != nil {
+		logger.Error("failed-serializing-model", err)
+		return err
+	}
+
+	_, err = db.client.CompareAndSwap(TaskSchemaPathByGuid(task.TaskGuid), value, NO_TTL, index)
+	if err != nil {
+		logger.Error("failed-persisting-task", err)
+		return ErrorFromEtcdError(logger, err)
+	}
+
+	return nil
+}
+
 // The stager calls this when it wants to claim a completed task.  This ensures that only one
 // stager ever attempts to handle a completed task
-func (db *ETCDDB) ResolvingTask(logger lager.Logger, taskGuid string) error {
+func (db *ETCDDB) ResolvingTask(logger lager.Logger, taskGuid string) (*models.TaskChange, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 
 	logger.Info("starting")
@@ -262,34 +404,97 @@ func (db *ETCDDB) ResolvingTask(logger lager.Logger, taskGuid string) error {
 	task, index, err := db.taskByGuidWithIndex(logger, taskGuid)
 	if err != nil {
 		logger.Error("failed-getting-task", err)
-		return err
+		return nil, err
 	}
 
 	err = task.ValidateTransitionTo(models.Task_Resolving)
 	if err != nil {
 		logger.Error("invalid-state-transition", err)
-		return err
+		return nil, err
 	}
 
+	before := task.Copy()
 	task.UpdatedAt = db.clock.Now().UnixNano()
 	task.State = models.Task_Resolving
 
 	value, err := db.serializeModel(logger, task)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	_, err = db.client.CompareAndSwap(TaskSchemaPathByGuid(taskGuid), value, NO_TTL, index)
 	if err != nil {
-		return ErrorFromEtcdError(logger, err)
+		return nil, ErrorFromEtcdError(logger, err)
 	}
-	return nil
+	return &models.TaskChange{Before: before, After: task}, nil
 }
 
 // The stager calls this when it wants to signal that it has received a completion and is handling it
 // stagerTaskBBS will retry this repeatedly if it gets a StoreTimeout error (up to N seconds?)
 // If this fails, the stager should assume that someone else is handling the completion and should bail
-func (db *ETCDDB) DeleteTask(logger lager.Logger, taskGuid string) error {
+func (db *ETCDDB) TaskHeartbeat(logger lager.Logger, taskGuid, cellID, progressMessage string, progressPercent int32) (*models.TaskChange, error) {
+	logger = logger.WithData(lager.Data{"task_guid": taskGuid, "cell_id": cellID})
+
+	logger.Debug("starting")
+	defer logger.Debug("finished")
+
+	task, index, err := db.taskByGuidWithIndex(logger, taskGuid)
+	if err != nil {
+		logger.Error("failed-getting-task", err)
+		return nil, err
+	}
+
+	before := task.Copy()
+	err = task.Heartbeat(cellID, progressMessage, progressPercent, db.clock.Now().UnixNano())
+	if err != nil {
+		logger.Error("failed-recording-heartbeat", err)
+		return nil, err
+	}
+
+	value, err := db.serializeModel(logger, task)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.client.CompareAndSwap(TaskSchemaPathByGuid(taskGuid), value, NO_TTL, index)
+	if err != nil {
+		return nil, ErrorFromEtcdError(logger, err)
+	}
+	return &models.TaskChange{Before: before, After: task}, nil
+}
+
+func (db *ETCDDB) DeadLetterTask(logger lager.Logger, taskGuid, callbackFailureReason string) (*models.TaskChange, error) {
+	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
+
+	logger.Info("starting")
+	defer logger.Info("finished")
+
+	task, index, err := db.taskByGuidWithIndex(logger, taskGuid)
+	if err != nil {
+		logger.Error("failed-getting-task", err)
+		return nil, err
+	}
+
+	before := task.Copy()
+	err = task.DeadLetterCallback(callbackFailureReason, db.clock.Now().UnixNano())
+	if err != nil {
+		logger.Error("invalid-state-transition", err)
+		return nil, err
+	}
+
+	value, err := db.serializeModel(logger, task)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.client.CompareAndSwap(TaskSchemaPathByGuid(taskGuid), value, NO_TTL, index)
+	if err != nil {
+		return nil, ErrorFromEtcdError(logger, err)
+	}
+	return &models.TaskChange{Before: before, After: task}, nil
+}
+
+func (db *ETCDDB) DeleteTask(logger lager.Logger, taskGuid string) (*models.Task, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 
 	logger.Info("starting")
@@ -298,15 +503,18 @@ func (db *ETCDDB) DeleteTask(logger lager.Logger, taskGuid string) error {
 	task, _, err := db.taskByGuidWithIndex(logger, taskGuid)
 	if err != nil {
 		logger.Error("failed-getting-task", err)
-		return err
+		return nil, err
 	}
 
 	if task.State != models.Task_Resolving {
 		err = models.NewTaskTransitionError(task.State, models.Task_Resolving)
 		logger.Error("invalid-state-transition", err)
-		return err
+		return nil, err
 	}
 
 	_, err = db.client.Delete(TaskSchemaPathByGuid(taskGuid), false)
-	return ErrorFromEtcdError(logger, err)
+	if err != nil {
+		return nil, ErrorFromEtcdError(logger, err)
+	}
+	return task, nil
 }
@@ -162,7 +162,7 @@ var _ = Describe("TaskDB", func() {
 		var task *models.Task
 
 		JustBeforeEach(func() {
-			errDesire = etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+			_, errDesire = etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 		})
 
 		BeforeEach(func() {
@@ -196,11 +196,24 @@ var _ = Describe("TaskDB", func() {
 			})
 		})
 
+		Context("when the task depends on other tasks", func() {
+			BeforeEach(func() {
+				taskDef.DependsOn = []string{"first-guid", "second-guid"}
+			})
+
+			It("persists the task as blocked on its dependencies", func() {
+				persistedTask, err := etcdDB.TaskByGuid(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persistedTask.State).To(Equal(models.Task_Blocked))
+				Expect(persistedTask.BlockedOn).To(Equal([]string{"first-guid", "second-guid"}))
+			})
+		})
+
 		Context("when a task is already present at the desired key", func() {
 			const initialDomain = "other-domain"
 
 			BeforeEach(func() {
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, initialDomain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, initialDomain)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -224,12 +237,12 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when starting a pending Task", func() {
 			BeforeEach(func() {
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("returns shouldStart as true", func() {
-				started, err := etcdDB.StartTask(logger, taskGuid, cellId)
+				_, started, err := etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(started).To(BeTrue())
 			})
@@ -237,7 +250,7 @@ var _ = Describe("TaskDB", func() {
 			It("correctly updates the task record", func() {
 				clock.IncrementBySeconds(1)
 
-				_, err := etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err := etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 
 				task, err := etcdDB.TaskByGuid(logger, taskGuid)
@@ -252,16 +265,16 @@ var _ = Describe("TaskDB", func() {
 
 		Context("When starting a Task that is already started", func() {
 			BeforeEach(func() {
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, "domain")
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, "domain")
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			Context("on the same cell", func() {
 				It("returns shouldStart as false", func() {
-					changed, err := etcdDB.StartTask(logger, taskGuid, cellId)
+					_, changed, err := etcdDB.StartTask(logger, taskGuid, cellId)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(changed).To(BeFalse())
 				})
@@ -270,7 +283,7 @@ var _ = Describe("TaskDB", func() {
 					previousTime := clock.Now().UnixNano()
 					clock.IncrementBySeconds(1)
 
-					_, err := etcdDB.StartTask(logger, taskGuid, cellId)
+					_, _, err := etcdDB.StartTask(logger, taskGuid, cellId)
 					Expect(err).NotTo(HaveOccurred())
 
 					task, err := etcdDB.TaskByGuid(logger, taskGuid)
@@ -282,7 +295,7 @@ var _ = Describe("TaskDB", func() {
 
 			Context("on another cell", func() {
 				It("returns an error", func() {
-					_, err := etcdDB.StartTask(logger, taskGuid, "some-other-cell")
+					_, _, err := etcdDB.StartTask(logger, taskGuid, "some-other-cell")
 					modelErr := models.ConvertError(err)
 					Expect(modelErr).NotTo(BeNil())
 					Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
@@ -292,7 +305,7 @@ var _ = Describe("TaskDB", func() {
 					previousTime := clock.Now().UnixNano()
 					clock.IncrementBySeconds(1)
 
-					_, err := etcdDB.StartTask(logger, taskGuid, cellId)
+					_, _, err := etcdDB.StartTask(logger, taskGuid, cellId)
 					Expect(err).NotTo(HaveOccurred())
 
 					task, err := etcdDB.TaskByGuid(logger, taskGuid)
@@ -308,12 +321,12 @@ var _ = Describe("TaskDB", func() {
 		var (
 			cancelError     error
 			taskAfterCancel *models.Task
-			taskReturned    *models.Task
+			changeReturned  *models.TaskChange
 			cellIDReturned  string
 		)
 
 		JustBeforeEach(func() {
-			taskReturned, cellIDReturned, cancelError = etcdDB.CancelTask(logger, taskGuid)
+			changeReturned, cellIDReturned, cancelError = etcdDB.CancelTask(logger, taskGuid, "cancelled by operator")
 			taskAfterCancel, _ = etcdDB.TaskByGuid(logger, taskGuid)
 		})
 
@@ -322,8 +335,9 @@ var _ = Describe("TaskDB", func() {
 				Expect(cancelError).NotTo(HaveOccurred())
 			})
 
-			It("returns the right task", func() {
-				Expect(taskReturned).To(Equal(taskAfterCancel))
+			It("returns the change to the task", func() {
+				Expect(changeReturned.After).To(Equal(taskAfterCancel))
+				Expect(changeReturned.Before.State).NotTo(Equal(models.Task_Completed))
 			})
 
 			It("marks the task as completed", func() {
@@ -334,8 +348,8 @@ var _ = Describe("TaskDB", func() {
 				Expect(taskAfterCancel.Failed).To(BeTrue())
 			})
 
-			It("sets the failure reason to cancelled", func() {
-				Expect(taskAfterCancel.FailureReason).To(Equal("task was cancelled"))
+			It("sets the failure reason to the cancellation reason", func() {
+				Expect(taskAfterCancel.FailureReason).To(Equal("cancelled by operator"))
 			})
 
 			It("bumps UpdatedAt", func() {
@@ -346,7 +360,7 @@ var _ = Describe("TaskDB", func() {
 		Context("when the task is in pending state", func() {
 			BeforeEach(func() {
 				taskDef = model_helpers.NewValidTaskDefinition()
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -360,10 +374,10 @@ var _ = Describe("TaskDB", func() {
 		Context("when the task is in running state", func() {
 			BeforeEach(func() {
 				taskDef = model_helpers.NewValidTaskDefinition()
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -377,15 +391,15 @@ var _ = Describe("TaskDB", func() {
 		Context("when the task is in completed state", func() {
 			BeforeEach(func() {
 				taskDef = model_helpers.NewValidTaskDefinition()
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(task.TaskGuid).To(Equal(taskGuid))
+				Expect(change.After.TaskGuid).To(Equal(taskGuid))
 			})
 
 			It("returns an error", func() {
@@ -397,17 +411,17 @@ var _ = Describe("TaskDB", func() {
 		Context("when the task is in resolving state", func() {
 			BeforeEach(func() {
 				taskDef = model_helpers.NewValidTaskDefinition()
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(task.TaskGuid).To(Equal(taskGuid))
+				Expect(change.After.TaskGuid).To(Equal(taskGuid))
 
-				err = etcdDB.ResolvingTask(logger, taskGuid)
+				_, err = etcdDB.ResolvingTask(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -440,18 +454,57 @@ var _ = Describe("TaskDB", func() {
 		})
 	})
 
+	Describe("CancelTasks", func() {
+		BeforeEach(func() {
+			taskDef = model_helpers.NewValidTaskDefinition()
+			_, err := etcdDB.DesireTask(logger, taskDef, "pending-task", domain)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = etcdDB.DesireTask(logger, taskDef, "completed-task", domain)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = etcdDB.CancelTask(logger, "completed-task", "cancelled earlier")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = etcdDB.DesireTask(logger, taskDef, "other-domain-task", "other-domain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("cancels the matching tasks and reports the outcome for each of them", func() {
+			changes, results, err := etcdDB.CancelTasks(logger, models.TaskFilter{Domain: domain}, "cancelled by operator")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].After.TaskGuid).To(Equal("pending-task"))
+			Expect(changes[0].After.State).To(Equal(models.Task_Completed))
+			Expect(changes[0].After.FailureReason).To(Equal("cancelled by operator"))
+
+			Expect(results).To(HaveLen(2))
+			for _, result := range results {
+				if result.TaskGuid == "completed-task" {
+					Expect(result.Error).NotTo(BeNil())
+				} else {
+					Expect(result.Error).To(BeNil())
+				}
+			}
+
+			task, err := etcdDB.TaskByGuid(logger, "other-domain-task")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.State).To(Equal(models.Task_Pending))
+		})
+	})
+
 	Describe("CompleteTask", func() {
 		Context("when completing a pending Task", func() {
 			JustBeforeEach(func() {
 				taskDef = model_helpers.NewValidTaskDefinition()
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("returns an error", func() {
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "another failure reason", "")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "another failure reason", "")
 				Expect(err).To(HaveOccurred())
-				Expect(task).To(BeNil())
+				Expect(change).To(BeNil())
 			})
 		})
 
@@ -461,18 +514,18 @@ var _ = Describe("TaskDB", func() {
 			})
 
 			JustBeforeEach(func() {
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			Context("when the cell id is not the same", func() {
 				It("returns an error", func() {
-					task, err := etcdDB.CompleteTask(logger, taskGuid, "another-cell", true, "another failure reason", "")
+					change, err := etcdDB.CompleteTask(logger, taskGuid, "another-cell", true, "another failure reason", "")
 					Expect(err).To(Equal(models.NewRunningOnDifferentCellError("another-cell", cellId)))
-					Expect(task).To(BeNil())
+					Expect(change).To(BeNil())
 				})
 			})
 
@@ -480,9 +533,9 @@ var _ = Describe("TaskDB", func() {
 				It("sets the Task in the completed state", func() {
 					clock.IncrementBySeconds(1)
 
-					returnedTask, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "because i said so", "a result")
+					returnedChange, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "because i said so", "a result")
 					Expect(err).NotTo(HaveOccurred())
-					Expect(returnedTask.TaskGuid).To(Equal(taskGuid))
+					Expect(returnedChange.After.TaskGuid).To(Equal(taskGuid))
 
 					tasks := filterByState(models.Task_Completed)
 
@@ -494,50 +547,76 @@ var _ = Describe("TaskDB", func() {
 					Expect(task.CellId).To(BeEmpty())
 				})
 			})
+
+			Context("when the retry policy of the task retries the failure", func() {
+				BeforeEach(func() {
+					taskDef.RetryPolicy = &models.RetryPolicy{
+						MaxAttempts:             2,
+						RetryableFailureReasons: []string{"because i said so"},
+					}
+				})
+
+				It("moves the task back to pending for another attempt", func() {
+					clock.IncrementBySeconds(1)
+
+					returnedChange, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "because i said so", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returnedChange.After.State).To(Equal(models.Task_Pending))
+
+					task, err := etcdDB.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task.State).To(Equal(models.Task_Pending))
+					Expect(task.Attempts).To(BeEquivalentTo(1))
+					Expect(task.Failed).To(BeFalse())
+					Expect(task.FailureReason).To(Equal("because i said so"))
+					Expect(task.UpdatedAt).To(Equal(clock.Now().UnixNano()))
+					Expect(task.CellId).To(BeEmpty())
+				})
+			})
 		})
 
 		Context("When completing a Task that is already completed", func() {
 			BeforeEach(func() {
 				taskDef = model_helpers.NewValidTaskDefinition()
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "some failure reason", "")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "some failure reason", "")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(task.TaskGuid).To(Equal(taskGuid))
+				Expect(change.After.TaskGuid).To(Equal(taskGuid))
 			})
 
 			It("returns an error", func() {
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "another failure reason", "")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "another failure reason", "")
 				Expect(err).To(HaveOccurred())
-				Expect(task).To(BeNil())
+				Expect(change).To(BeNil())
 			})
 		})
 
 		Context("When completing a Task that is resolving", func() {
 			BeforeEach(func() {
 				taskDef = model_helpers.NewValidTaskDefinition()
-				err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+				_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+				_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 				Expect(err).NotTo(HaveOccurred())
 
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(task.TaskGuid).To(Equal(taskGuid))
+				Expect(change.After.TaskGuid).To(Equal(taskGuid))
 
-				err = etcdDB.ResolvingTask(logger, taskGuid)
+				_, err = etcdDB.ResolvingTask(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("returns an error", func() {
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "")
 				Expect(err).To(HaveOccurred())
-				Expect(task).To(BeNil())
+				Expect(change).To(BeNil())
 			})
 		})
 	})
@@ -550,16 +629,16 @@ var _ = Describe("TaskDB", func() {
 		Context("when failing a Task", func() {
 			Context("when the task is pending", func() {
 				JustBeforeEach(func() {
-					err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+					_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
 				It("sets the Task in the completed state", func() {
 					clock.IncrementBySeconds(1)
 
-					returnedTask, err := etcdDB.FailTask(logger, taskGuid, "because i said so")
+					returnedChange, err := etcdDB.FailTask(logger, taskGuid, "because i said so")
 					Expect(err).NotTo(HaveOccurred())
-					Expect(returnedTask.TaskGuid).To(Equal(taskGuid))
+					Expect(returnedChange.After.TaskGuid).To(Equal(taskGuid))
 
 					tasks := filterByState(models.Task_Completed)
 
@@ -575,51 +654,51 @@ var _ = Describe("TaskDB", func() {
 			Context("when the task is completed", func() {
 				JustBeforeEach(func() {
 					taskDef = model_helpers.NewValidTaskDefinition()
-					err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+					_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+					_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 					Expect(err).NotTo(HaveOccurred())
 
-					task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "some failure reason", "")
+					change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "some failure reason", "")
 					Expect(err).NotTo(HaveOccurred())
-					Expect(task.TaskGuid).To(Equal(taskGuid))
+					Expect(change.After.TaskGuid).To(Equal(taskGuid))
 				})
 
 				It("fails", func() {
-					task, err := etcdDB.FailTask(logger,
+					change, err := etcdDB.FailTask(logger,
 						taskGuid,
 						"because i said so",
 					)
 					Expect(err).To(HaveOccurred())
-					Expect(task).To(BeNil())
+					Expect(change).To(BeNil())
 				})
 			})
 
 			Context("when the task is resolving", func() {
 				JustBeforeEach(func() {
 					taskDef = model_helpers.NewValidTaskDefinition()
-					err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+					_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+					_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 					Expect(err).NotTo(HaveOccurred())
 
-					task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "some failure reason", "")
+					change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "some failure reason", "")
 					Expect(err).NotTo(HaveOccurred())
-					Expect(task.TaskGuid).To(Equal(taskGuid))
+					Expect(change.After.TaskGuid).To(Equal(taskGuid))
 
-					err = etcdDB.ResolvingTask(logger, taskGuid)
+					_, err = etcdDB.ResolvingTask(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
 				It("fails", func() {
-					task, err := etcdDB.FailTask(logger,
+					change, err := etcdDB.FailTask(logger,
 						taskGuid,
 						"because i said so",
 					)
 					Expect(err).To(HaveOccurred())
-					Expect(task).To(BeNil())
+					Expect(change).To(BeNil())
 				})
 			})
 		})
@@ -628,22 +707,22 @@ var _ = Describe("TaskDB", func() {
 	Describe("ResolvingTask", func() {
 		BeforeEach(func() {
 			taskDef = model_helpers.NewValidTaskDefinition()
-			err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+			_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+			_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
 		Context("when the task is complete", func() {
 			BeforeEach(func() {
-				task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "because i said so", "a result")
+				change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "because i said so", "a result")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(task.TaskGuid).To(Equal(taskGuid))
+				Expect(change.After.TaskGuid).To(Equal(taskGuid))
 			})
 
 			It("swaps /task/<guid>'s state to resolving", func() {
-				err := etcdDB.ResolvingTask(logger, taskGuid)
+				_, err := etcdDB.ResolvingTask(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
 
 				tasks := filterByState(models.Task_Resolving)
@@ -654,7 +733,7 @@ var _ = Describe("TaskDB", func() {
 			It("bumps UpdatedAt", func() {
 				clock.IncrementBySeconds(1)
 
-				err := etcdDB.ResolvingTask(logger, taskGuid)
+				_, err := etcdDB.ResolvingTask(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
 
 				tasks := filterByState(models.Task_Resolving)
@@ -663,12 +742,12 @@ var _ = Describe("TaskDB", func() {
 
 			Context("when the Task is already resolving", func() {
 				BeforeEach(func() {
-					err := etcdDB.ResolvingTask(logger, taskGuid)
+					_, err := etcdDB.ResolvingTask(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
 				It("fails", func() {
-					err := etcdDB.ResolvingTask(logger, taskGuid)
+					_, err := etcdDB.ResolvingTask(logger, taskGuid)
 					Expect(err).To(HaveOccurred())
 				})
 			})
@@ -676,34 +755,194 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task is not complete", func() {
 			It("should fail", func() {
-				err := etcdDB.ResolvingTask(logger, taskGuid)
+				_, err := etcdDB.ResolvingTask(logger, taskGuid)
 				Expect(err).To(Equal(models.NewTaskTransitionError(models.Task_Running, models.Task_Resolving)))
 			})
 		})
 	})
 
+	Describe("ReleaseDependentTasks", func() {
+		BeforeEach(func() {
+			taskDef = model_helpers.NewValidTaskDefinition()
+			_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+			Expect(err).NotTo(HaveOccurred())
+
+			dependentDef := model_helpers.NewValidTaskDefinition()
+			dependentDef.DependsOn = []string{taskGuid}
+			_, err = etcdDB.DesireTask(logger, dependentDef, "dependent-guid", domain)
+			Expect(err).NotTo(HaveOccurred())
+
+			dependentDef.DependsOn = []string{taskGuid, "other-guid"}
+			_, err = etcdDB.DesireTask(logger, dependentDef, "partially-dependent-guid", domain)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the task has not completed", func() {
+			It("does not release any tasks", func() {
+				changes, err := etcdDB.ReleaseDependentTasks(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changes).To(BeEmpty())
+				Expect(filterByState(models.Task_Blocked)).To(HaveLen(2))
+			})
+		})
+
+		Context("when the task succeeded", func() {
+			BeforeEach(func() {
+				_, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "a result")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("unblocks the tasks blocked on it", func() {
+				changes, err := etcdDB.ReleaseDependentTasks(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changes).To(HaveLen(2))
+
+				task, err := etcdDB.TaskByGuid(logger, "dependent-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Pending))
+				Expect(task.BlockedOn).To(BeEmpty())
+
+				task, err = etcdDB.TaskByGuid(logger, "partially-dependent-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Blocked))
+				Expect(task.BlockedOn).To(Equal([]string{"other-guid"}))
+			})
+		})
+
+		Context("when the task failed", func() {
+			BeforeEach(func() {
+				_, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "boom", "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails the tasks blocked on it", func() {
+				changes, err := etcdDB.ReleaseDependentTasks(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changes).To(HaveLen(2))
+
+				for _, change := range changes {
+					Expect(change.Before.State).To(Equal(models.Task_Blocked))
+					Expect(change.After.State).To(Equal(models.Task_Completed))
+					Expect(change.After.Failed).To(BeTrue())
+					Expect(change.After.FailureReason).To(Equal(models.DependencyFailureReason(taskGuid)))
+				}
+			})
+		})
+	})
+
+	Describe("TaskHeartbeat", func() {
+		BeforeEach(func() {
+			taskDef = model_helpers.NewValidTaskDefinition()
+			_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the task is running on the cell", func() {
+			var startedAt int64
+
+			BeforeEach(func() {
+				_, _, err := etcdDB.StartTask(logger, taskGuid, cellId)
+				Expect(err).NotTo(HaveOccurred())
+				startedAt = clock.Now().UnixNano()
+			})
+
+			It("records the heartbeat and progress without changing UpdatedAt", func() {
+				clock.IncrementBySeconds(1)
+
+				change, err := etcdDB.TaskHeartbeat(logger, taskGuid, cellId, "downloading", 42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(change.After.LastHeartbeatAt).To(Equal(clock.Now().UnixNano()))
+				Expect(change.After.ProgressMessage).To(Equal("downloading"))
+				Expect(change.After.ProgressPercent).To(BeEquivalentTo(42))
+				Expect(change.After.UpdatedAt).To(Equal(startedAt))
+
+				stored, err := etcdDB.TaskByGuid(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).To(Equal(change.After))
+			})
+
+			It("fails for another cell", func() {
+				_, err := etcdDB.TaskHeartbeat(logger, taskGuid, "other-cell", "downloading", 42)
+				Expect(models.ConvertError(err).Type).To(Equal(models.Error_RunningOnDifferentCell))
+			})
+		})
+
+		Context("when the task is not running", func() {
+			It("fails", func() {
+				_, err := etcdDB.TaskHeartbeat(logger, taskGuid, cellId, "downloading", 42)
+				Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidStateTransition))
+			})
+		})
+	})
+
+	Describe("DeadLetterTask", func() {
+		BeforeEach(func() {
+			taskDef = model_helpers.NewValidTaskDefinition()
+			_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the task is resolving", func() {
+			BeforeEach(func() {
+				_, err := etcdDB.CompleteTask(logger, taskGuid, cellId, false, "", "a result")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = etcdDB.ResolvingTask(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the task to completed and marks its callback as dead-lettered", func() {
+				clock.IncrementBySeconds(1)
+
+				change, err := etcdDB.DeadLetterTask(logger, taskGuid, "callback failed")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(change.After.State).To(Equal(models.Task_Completed))
+				Expect(change.After.CallbackDeadLettered).To(BeTrue())
+				Expect(change.After.CallbackFailureReason).To(Equal("callback failed"))
+				Expect(change.After.UpdatedAt).To(Equal(clock.Now().UnixNano()))
+
+				stored, err := etcdDB.TaskByGuid(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).To(Equal(change.After))
+			})
+		})
+
+		Context("when the task is still running", func() {
+			It("fails", func() {
+				_, err := etcdDB.DeadLetterTask(logger, taskGuid, "callback failed")
+				Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidStateTransition))
+			})
+		})
+	})
+
 	Describe("DeleteTask", func() {
 		BeforeEach(func() {
 			taskDef = model_helpers.NewValidTaskDefinition()
-			err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
+			_, err := etcdDB.DesireTask(logger, taskDef, taskGuid, domain)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = etcdDB.StartTask(logger, taskGuid, cellId)
+			_, _, err = etcdDB.StartTask(logger, taskGuid, cellId)
 			Expect(err).NotTo(HaveOccurred())
 
-			task, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "because i said so", "a result")
+			change, err := etcdDB.CompleteTask(logger, taskGuid, cellId, true, "because i said so", "a result")
 			Expect(err).NotTo(HaveOccurred())
-			Expect(task.TaskGuid).To(Equal(taskGuid))
+			Expect(change.After.TaskGuid).To(Equal(taskGuid))
 		})
 
 		Context("when the task is resolving", func() {
 			BeforeEach(func() {
-				err := etcdDB.ResolvingTask(logger, taskGuid)
+				_, err := etcdDB.ResolvingTask(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("should remove /task/<guid>", func() {
-				err := etcdDB.DeleteTask(logger, taskGuid)
+				_, err := etcdDB.DeleteTask(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
 
 				tasks, err := etcdDB.Tasks(logger, models.TaskFilter{})
@@ -714,7 +953,7 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task is not resolving", func() {
 			It("should fail", func() {
-				err := etcdDB.DeleteTask(logger, taskGuid)
+				_, err := etcdDB.DeleteTask(logger, taskGuid)
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -0,0 +1,15 @@
+package db
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . EventDB
+type EventDB interface {
+	// EventsAfter returns up to limit of the events recorded after the given
+	// sequence, oldest first.
+	EventsAfter(logger lager.Logger, sequence uint64, limit int) ([]*models.SequencedEvent, error)
+	LastEventSequence(logger lager.Logger) (uint64, error)
+	PruneEvents(logger lager.Logger, throughSequence uint64) error
+}
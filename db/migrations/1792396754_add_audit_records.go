@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddAuditRecords())
+}
+
+type AddAuditRecords struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddAuditRecords() migration.Migration {
+	return &AddAuditRecords{}
+}
+
+func (a *AddAuditRecords) String() string {
+	return "1792396754"
+}
+
+func (a *AddAuditRecords) Version() int64 {
+	return 1792396754
+}
+
+func (a *AddAuditRecords) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddAuditRecords) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddAuditRecords) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddAuditRecords) RequiresSQL() bool                           { return true }
+func (a *AddAuditRecords) SetClock(c clock.Clock)                      {}
+func (a *AddAuditRecords) SetDBFlavor(flavor string)                   {}
+
+func (a *AddAuditRecords) Up(logger lager.Logger) error {
+	logger = logger.Session("add-audit-records")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	for _, query := range append([]string{createAuditRecordsSQL}, createAuditRecordsIndices...) {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-creating-audit-records", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddAuditRecords) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const createAuditRecordsSQL = `CREATE TABLE audit_records(
+	record_index BIGINT PRIMARY KEY,
+	recorded_at BIGINT NOT NULL,
+	identity VARCHAR(255) NOT NULL DEFAULT '',
+	remote_addr VARCHAR(255) NOT NULL DEFAULT '',
+	route VARCHAR(255) NOT NULL,
+	target_guid VARCHAR(255) NOT NULL DEFAULT '',
+	changes TEXT,
+	error TEXT,
+	previous_hash VARCHAR(64) NOT NULL DEFAULT '',
+	hash VARCHAR(64) NOT NULL
+);`
+
+var createAuditRecordsIndices = []string{
+	`CREATE INDEX audit_records_recorded_at_idx ON audit_records (recorded_at)`,
+	`CREATE INDEX audit_records_target_guid_idx ON audit_records (target_guid)`,
+}
@@ -0,0 +1,55 @@
+package migrations_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/db/migrations"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/cloudfoundry-incubator/bbs/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add Audit Records Migration", func() {
+	var migration migration.Migration
+
+	BeforeEach(func() {
+		migration = migrations.NewAddAuditRecords()
+	})
+
+	It("appends itself to the migration list", func() {
+		Expect(migrations.Migrations).To(ContainElement(migration))
+	})
+
+	Describe("Version", func() {
+		It("returns the timestamp from which it was created", func() {
+			Expect(migration.Version()).To(BeEquivalentTo(1792396754))
+		})
+	})
+
+	Describe("RequiresSQL", func() {
+		It("requires a SQL backend", func() {
+			Expect(migration.RequiresSQL()).To(BeTrue())
+		})
+	})
+
+	if test_helpers.UseSQL() {
+		Describe("Up", func() {
+			BeforeEach(func() {
+				rawSQLDB.Exec("DROP TABLE audit_records;")
+
+				migration.SetRawSQLDB(rawSQLDB)
+				migration.SetDBFlavor(sqlRunner.DriverName())
+			})
+
+			It("creates the audit records table", func() {
+				Expect(migration.Up(logger)).To(Succeed())
+
+				_, err := rawSQLDB.Exec(`
+					INSERT INTO audit_records
+						(record_index, recorded_at, route, hash)
+					VALUES (1, 1, 'UpsertDomain', 'some-hash')
+				`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	}
+})
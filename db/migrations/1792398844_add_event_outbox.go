@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddEventOutbox())
+}
+
+type AddEventOutbox struct {
+	rawSQLDB *sql.DB
+	dbFlavor string
+}
+
+func NewAddEventOutbox() migration.Migration {
+	return &AddEventOutbox{}
+}
+
+func (a *AddEventOutbox) String() string {
+	return "1792398844"
+}
+
+func (a *AddEventOutbox) Version() int64 {
+	return 1792398844
+}
+
+func (a *AddEventOutbox) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddEventOutbox) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddEventOutbox) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddEventOutbox) RequiresSQL() bool                           { return true }
+func (a *AddEventOutbox) SetClock(c clock.Clock)                      {}
+func (a *AddEventOutbox) SetDBFlavor(flavor string)                   { a.dbFlavor = flavor }
+
+func (a *AddEventOutbox) Up(logger lager.Logger) error {
+	logger = logger.Session("add-event-outbox")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	createEventOutboxSQL := createPostgresEventOutboxSQL
+	if a.dbFlavor == sqldb.MySQL {
+		createEventOutboxSQL = createMySQLEventOutboxSQL
+	}
+
+	logger.Info("executing", lager.Data{"query": createEventOutboxSQL})
+	_, err := a.rawSQLDB.Exec(createEventOutboxSQL)
+	if err != nil {
+		logger.Error("failed-creating-event-outbox", err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *AddEventOutbox) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+// Events are numbered by the database as they are inserted, so that writers
+// never contend for a counter. They can carry two copies of a DesiredLRP,
+// which may not fit in a MySQL TEXT column.
+const createMySQLEventOutboxSQL = `CREATE TABLE IF NOT EXISTS event_outbox(
+	sequence BIGINT AUTO_INCREMENT PRIMARY KEY,
+	event_type VARCHAR(255) NOT NULL,
+	created_at BIGINT NOT NULL,
+	payload MEDIUMTEXT NOT NULL
+);`
+
+const createPostgresEventOutboxSQL = `CREATE TABLE IF NOT EXISTS event_outbox(
+	sequence BIGSERIAL PRIMARY KEY,
+	event_type VARCHAR(255) NOT NULL,
+	created_at BIGINT NOT NULL,
+	payload TEXT NOT NULL
+);`
@@ -0,0 +1,78 @@
+package migrations_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/db/migrations"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/cloudfoundry-incubator/bbs/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add Event Outbox Migration", func() {
+	var migration migration.Migration
+
+	BeforeEach(func() {
+		migration = migrations.NewAddEventOutbox()
+	})
+
+	It("appends itself to the migration list", func() {
+		Expect(migrations.Migrations).To(ContainElement(migration))
+	})
+
+	Describe("Version", func() {
+		It("returns the timestamp from which it was created", func() {
+			Expect(migration.Version()).To(BeEquivalentTo(1792398844))
+		})
+	})
+
+	Describe("RequiresSQL", func() {
+		It("requires a SQL backend", func() {
+			Expect(migration.RequiresSQL()).To(BeTrue())
+		})
+	})
+
+	if test_helpers.UseSQL() {
+		Describe("Up", func() {
+			BeforeEach(func() {
+				rawSQLDB.Exec("DROP TABLE event_outbox;")
+
+				migration.SetRawSQLDB(rawSQLDB)
+				migration.SetDBFlavor(sqlRunner.DriverName())
+			})
+
+			It("creates the event outbox table", func() {
+				Expect(migration.Up(logger)).To(Succeed())
+
+				_, err := rawSQLDB.Exec(`
+					INSERT INTO event_outbox
+						(sequence, event_type, created_at, payload)
+					VALUES (1, 'task_created', 1, 'some-payload')
+				`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("numbers the events as they are inserted", func() {
+				Expect(migration.Up(logger)).To(Succeed())
+
+				for i := 0; i < 2; i++ {
+					_, err := rawSQLDB.Exec(`
+						INSERT INTO event_outbox
+							(event_type, created_at, payload)
+						VALUES ('task_created', 1, 'some-payload')
+					`)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				var lastSequence int64
+				err := rawSQLDB.QueryRow("SELECT MAX(sequence) FROM event_outbox").Scan(&lastSequence)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lastSequence).To(BeEquivalentTo(2))
+			})
+
+			It("can be run more than once", func() {
+				Expect(migration.Up(logger)).To(Succeed())
+				Expect(migration.Up(logger)).To(Succeed())
+			})
+		})
+	}
+})
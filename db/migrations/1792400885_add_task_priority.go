@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskPriority())
+}
+
+type AddTaskPriority struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskPriority() migration.Migration {
+	return &AddTaskPriority{}
+}
+
+func (a *AddTaskPriority) String() string {
+	return "1792400885"
+}
+
+func (a *AddTaskPriority) Version() int64 {
+	return 1792400885
+}
+
+func (a *AddTaskPriority) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskPriority) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskPriority) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskPriority) RequiresSQL() bool                           { return true }
+func (a *AddTaskPriority) SetClock(c clock.Clock)                      {}
+func (a *AddTaskPriority) SetDBFlavor(flavor string)                   {}
+
+// Up adds the priority column the task convergence orders kicked tasks by.
+// Tasks desired before it existed have the default priority of zero.
+func (a *AddTaskPriority) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-priority")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	for _, query := range []string{addTaskPrioritySQL, createTaskPriorityIndexSQL} {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-adding-task-priority", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddTaskPriority) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const addTaskPrioritySQL = `ALTER TABLE tasks ADD COLUMN priority INT NOT NULL DEFAULT 0`
+
+const createTaskPriorityIndexSQL = `CREATE INDEX tasks_priority_idx ON tasks (priority)`
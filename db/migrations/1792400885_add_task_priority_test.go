@@ -0,0 +1,61 @@
+package migrations_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/db/migrations"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/cloudfoundry-incubator/bbs/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add Task Priority Migration", func() {
+	var migration migration.Migration
+
+	BeforeEach(func() {
+		migration = migrations.NewAddTaskPriority()
+	})
+
+	It("appends itself to the migration list", func() {
+		Expect(migrations.Migrations).To(ContainElement(migration))
+	})
+
+	Describe("Version", func() {
+		It("returns the timestamp from which it was created", func() {
+			Expect(migration.Version()).To(BeEquivalentTo(1792400885))
+		})
+	})
+
+	Describe("RequiresSQL", func() {
+		It("requires a SQL backend", func() {
+			Expect(migration.RequiresSQL()).To(BeTrue())
+		})
+	})
+
+	if test_helpers.UseSQL() {
+		Describe("Up", func() {
+			BeforeEach(func() {
+				rawSQLDB.Exec("DROP TABLE tasks;")
+				_, err := rawSQLDB.Exec(`CREATE TABLE tasks(
+					guid VARCHAR(255) PRIMARY KEY,
+					domain VARCHAR(255) NOT NULL
+				);`)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = rawSQLDB.Exec(`INSERT INTO tasks (guid, domain) VALUES ('old-task', 'some-domain')`)
+				Expect(err).NotTo(HaveOccurred())
+
+				migration.SetRawSQLDB(rawSQLDB)
+				migration.SetDBFlavor(sqlRunner.DriverName())
+			})
+
+			It("gives existing tasks the default priority", func() {
+				Expect(migration.Up(logger)).To(Succeed())
+
+				var priority int32
+				err := rawSQLDB.QueryRow("SELECT priority FROM tasks WHERE guid = 'old-task'").Scan(&priority)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(priority).To(BeEquivalentTo(0))
+			})
+		})
+	}
+})
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskDependencies())
+}
+
+type AddTaskDependencies struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskDependencies() migration.Migration {
+	return &AddTaskDependencies{}
+}
+
+func (a *AddTaskDependencies) String() string {
+	return "1792403028"
+}
+
+func (a *AddTaskDependencies) Version() int64 {
+	return 1792403028
+}
+
+func (a *AddTaskDependencies) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskDependencies) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskDependencies) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskDependencies) RequiresSQL() bool                           { return true }
+func (a *AddTaskDependencies) SetClock(c clock.Clock)                      {}
+func (a *AddTaskDependencies) SetDBFlavor(flavor string)                   {}
+
+// Up adds the blocked_on column holding the guids of the tasks a blocked task
+// is still waiting on. Tasks desired before it existed have no dependencies.
+func (a *AddTaskDependencies) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-dependencies")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	logger.Info("executing", lager.Data{"query": addTaskBlockedOnSQL})
+	_, err := a.rawSQLDB.Exec(addTaskBlockedOnSQL)
+	if err != nil {
+		logger.Error("failed-adding-task-dependencies", err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *AddTaskDependencies) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const addTaskBlockedOnSQL = `ALTER TABLE tasks ADD COLUMN blocked_on TEXT`
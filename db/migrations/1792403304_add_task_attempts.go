@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskAttempts())
+}
+
+type AddTaskAttempts struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskAttempts() migration.Migration {
+	return &AddTaskAttempts{}
+}
+
+func (a *AddTaskAttempts) String() string {
+	return "1792403304"
+}
+
+func (a *AddTaskAttempts) Version() int64 {
+	return 1792403304
+}
+
+func (a *AddTaskAttempts) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskAttempts) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskAttempts) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskAttempts) RequiresSQL() bool                           { return true }
+func (a *AddTaskAttempts) SetClock(c clock.Clock)                      {}
+func (a *AddTaskAttempts) SetDBFlavor(flavor string)                   {}
+
+// Up adds the attempts column counting how many times a task has been retried
+// by its retry policy. Tasks desired before it existed have not been retried.
+func (a *AddTaskAttempts) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-attempts")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	logger.Info("executing", lager.Data{"query": addTaskAttemptsSQL})
+	_, err := a.rawSQLDB.Exec(addTaskAttemptsSQL)
+	if err != nil {
+		logger.Error("failed-adding-task-dependencies", err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *AddTaskAttempts) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const addTaskAttemptsSQL = `ALTER TABLE tasks ADD COLUMN attempts INT NOT NULL DEFAULT 0`
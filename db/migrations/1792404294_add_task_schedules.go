@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskSchedules())
+}
+
+type AddTaskSchedules struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskSchedules() migration.Migration {
+	return &AddTaskSchedules{}
+}
+
+func (a *AddTaskSchedules) String() string {
+	return "1792404294"
+}
+
+func (a *AddTaskSchedules) Version() int64 {
+	return 1792404294
+}
+
+func (a *AddTaskSchedules) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskSchedules) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskSchedules) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskSchedules) RequiresSQL() bool                           { return true }
+func (a *AddTaskSchedules) SetClock(c clock.Clock)                      {}
+func (a *AddTaskSchedules) SetDBFlavor(flavor string)                   {}
+
+func (a *AddTaskSchedules) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-schedules")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	queries := append([]string{createTaskSchedulesSQL, createTaskScheduleRunsSQL}, createTaskScheduleRunsIndices...)
+	for _, query := range queries {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-creating-task-schedules", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddTaskSchedules) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const createTaskSchedulesSQL = `CREATE TABLE task_schedules(
+	guid VARCHAR(255) PRIMARY KEY,
+	domain VARCHAR(255) NOT NULL,
+	cron_expression VARCHAR(255) NOT NULL,
+	concurrency_policy INT NOT NULL DEFAULT 0,
+	history_limit INT NOT NULL DEFAULT 0,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL,
+	last_scheduled_at BIGINT NOT NULL DEFAULT 0,
+	task_definition TEXT NOT NULL
+);`
+
+const createTaskScheduleRunsSQL = `CREATE TABLE task_schedule_runs(
+	task_guid VARCHAR(255) PRIMARY KEY,
+	schedule_guid VARCHAR(255) NOT NULL,
+	scheduled_at BIGINT NOT NULL
+);`
+
+var createTaskScheduleRunsIndices = []string{
+	`CREATE INDEX task_schedule_runs_schedule_guid_idx ON task_schedule_runs (schedule_guid)`,
+}
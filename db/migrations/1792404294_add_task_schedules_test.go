@@ -0,0 +1,63 @@
+package migrations_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/db/migrations"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/cloudfoundry-incubator/bbs/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add Task Schedules Migration", func() {
+	var migration migration.Migration
+
+	BeforeEach(func() {
+		migration = migrations.NewAddTaskSchedules()
+	})
+
+	It("appends itself to the migration list", func() {
+		Expect(migrations.Migrations).To(ContainElement(migration))
+	})
+
+	Describe("Version", func() {
+		It("returns the timestamp from which it was created", func() {
+			Expect(migration.Version()).To(BeEquivalentTo(1792404294))
+		})
+	})
+
+	Describe("RequiresSQL", func() {
+		It("requires a SQL backend", func() {
+			Expect(migration.RequiresSQL()).To(BeTrue())
+		})
+	})
+
+	if test_helpers.UseSQL() {
+		Describe("Up", func() {
+			BeforeEach(func() {
+				rawSQLDB.Exec("DROP TABLE task_schedules;")
+				rawSQLDB.Exec("DROP TABLE task_schedule_runs;")
+
+				migration.SetRawSQLDB(rawSQLDB)
+				migration.SetDBFlavor(sqlRunner.DriverName())
+			})
+
+			It("creates the task schedules tables", func() {
+				Expect(migration.Up(logger)).To(Succeed())
+
+				_, err := rawSQLDB.Exec(`
+					INSERT INTO task_schedules
+						(guid, domain, cron_expression, created_at, updated_at, task_definition)
+					VALUES ('some-schedule', 'some-domain', '* * * * *', 1, 1, 'some-definition')
+				`)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = rawSQLDB.Exec(`
+					INSERT INTO task_schedule_runs
+						(task_guid, schedule_guid, scheduled_at)
+					VALUES ('some-schedule-60', 'some-schedule', 60000000000)
+				`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	}
+})
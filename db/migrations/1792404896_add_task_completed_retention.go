@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskCompletedRetention())
+}
+
+type AddTaskCompletedRetention struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskCompletedRetention() migration.Migration {
+	return &AddTaskCompletedRetention{}
+}
+
+func (a *AddTaskCompletedRetention) String() string {
+	return "1792404896"
+}
+
+func (a *AddTaskCompletedRetention) Version() int64 {
+	return 1792404896
+}
+
+func (a *AddTaskCompletedRetention) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskCompletedRetention) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskCompletedRetention) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskCompletedRetention) RequiresSQL() bool                           { return true }
+func (a *AddTaskCompletedRetention) SetClock(c clock.Clock)                      {}
+func (a *AddTaskCompletedRetention) SetDBFlavor(flavor string)                   {}
+
+// Up adds the completed_retention column the task convergence expires
+// completed tasks by. Tasks desired before it existed have no retention of
+// their own and are kept for the default period.
+func (a *AddTaskCompletedRetention) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-completed-retention")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	logger.Info("executing", lager.Data{"query": addTaskCompletedRetentionSQL})
+	_, err := a.rawSQLDB.Exec(addTaskCompletedRetentionSQL)
+	if err != nil {
+		logger.Error("failed-adding-task-completed-retention", err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *AddTaskCompletedRetention) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const addTaskCompletedRetentionSQL = `ALTER TABLE tasks ADD COLUMN completed_retention BIGINT NOT NULL DEFAULT 0`
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskCallbackDeadLetters())
+}
+
+type AddTaskCallbackDeadLetters struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskCallbackDeadLetters() migration.Migration {
+	return &AddTaskCallbackDeadLetters{}
+}
+
+func (a *AddTaskCallbackDeadLetters) String() string {
+	return "1792405436"
+}
+
+func (a *AddTaskCallbackDeadLetters) Version() int64 {
+	return 1792405436
+}
+
+func (a *AddTaskCallbackDeadLetters) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskCallbackDeadLetters) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskCallbackDeadLetters) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskCallbackDeadLetters) RequiresSQL() bool                           { return true }
+func (a *AddTaskCallbackDeadLetters) SetClock(c clock.Clock)                      {}
+func (a *AddTaskCallbackDeadLetters) SetDBFlavor(flavor string)                   {}
+
+// Up adds the columns marking the tasks whose completion callbacks could not
+// be delivered. Tasks completed before they existed have not been
+// dead-lettered.
+func (a *AddTaskCallbackDeadLetters) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-callback-dead-letters")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	for _, query := range addTaskCallbackDeadLettersSQL {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-adding-task-callback-dead-letters", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddTaskCallbackDeadLetters) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+var addTaskCallbackDeadLettersSQL = []string{
+	`ALTER TABLE tasks ADD COLUMN callback_dead_lettered BOOL NOT NULL DEFAULT false`,
+	`ALTER TABLE tasks ADD COLUMN callback_failure_reason VARCHAR(255) NOT NULL DEFAULT ''`,
+}
@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskCallbackQueue())
+}
+
+type AddTaskCallbackQueue struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskCallbackQueue() migration.Migration {
+	return &AddTaskCallbackQueue{}
+}
+
+func (a *AddTaskCallbackQueue) String() string {
+	return "1792405711"
+}
+
+func (a *AddTaskCallbackQueue) Version() int64 {
+	return 1792405711
+}
+
+func (a *AddTaskCallbackQueue) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskCallbackQueue) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskCallbackQueue) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskCallbackQueue) RequiresSQL() bool                           { return true }
+func (a *AddTaskCallbackQueue) SetClock(c clock.Clock)                      {}
+func (a *AddTaskCallbackQueue) SetDBFlavor(flavor string)                   {}
+
+func (a *AddTaskCallbackQueue) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-callback-queue")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	queries := append([]string{createTaskCallbacksSQL}, createTaskCallbacksIndices...)
+	for _, query := range queries {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-creating-task-callback-queue", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddTaskCallbackQueue) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const createTaskCallbacksSQL = `CREATE TABLE task_callbacks(
+	task_guid VARCHAR(255) PRIMARY KEY,
+	attempts INT NOT NULL DEFAULT 0,
+	enqueued_at BIGINT NOT NULL,
+	next_attempt_at BIGINT NOT NULL
+);`
+
+var createTaskCallbacksIndices = []string{
+	`CREATE INDEX task_callbacks_next_attempt_at_idx ON task_callbacks (next_attempt_at)`,
+}
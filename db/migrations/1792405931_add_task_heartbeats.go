@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskHeartbeats())
+}
+
+type AddTaskHeartbeats struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskHeartbeats() migration.Migration {
+	return &AddTaskHeartbeats{}
+}
+
+func (a *AddTaskHeartbeats) String() string {
+	return "1792405931"
+}
+
+func (a *AddTaskHeartbeats) Version() int64 {
+	return 1792405931
+}
+
+func (a *AddTaskHeartbeats) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskHeartbeats) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskHeartbeats) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskHeartbeats) RequiresSQL() bool                           { return true }
+func (a *AddTaskHeartbeats) SetClock(c clock.Clock)                      {}
+func (a *AddTaskHeartbeats) SetDBFlavor(flavor string)                   {}
+
+// Up adds the columns recording the heartbeats and progress reported for
+// running tasks. Tasks started before they existed have not heartbeated.
+func (a *AddTaskHeartbeats) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-heartbeats")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	for _, query := range addTaskHeartbeatsSQL {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-adding-task-heartbeats", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddTaskHeartbeats) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+var addTaskHeartbeatsSQL = []string{
+	`ALTER TABLE tasks ADD COLUMN last_heartbeat_at BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE tasks ADD COLUMN progress_message VARCHAR(1024) NOT NULL DEFAULT ''`,
+	`ALTER TABLE tasks ADD COLUMN progress_percent INT NOT NULL DEFAULT 0`,
+}
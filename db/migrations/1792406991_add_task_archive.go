@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskArchive())
+}
+
+type AddTaskArchive struct {
+	rawSQLDB *sql.DB
+	dbFlavor string
+}
+
+func NewAddTaskArchive() migration.Migration {
+	return &AddTaskArchive{}
+}
+
+func (a *AddTaskArchive) String() string {
+	return "1792406991"
+}
+
+func (a *AddTaskArchive) Version() int64 {
+	return 1792406991
+}
+
+func (a *AddTaskArchive) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskArchive) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskArchive) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskArchive) RequiresSQL() bool                           { return true }
+func (a *AddTaskArchive) SetClock(c clock.Clock)                      {}
+func (a *AddTaskArchive) SetDBFlavor(flavor string)                   { a.dbFlavor = flavor }
+
+func (a *AddTaskArchive) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-archive")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	createArchivedTasksSQL := createPostgresArchivedTasksSQL
+	if a.dbFlavor == sqldb.MySQL {
+		createArchivedTasksSQL = createMySQLArchivedTasksSQL
+	}
+
+	queries := append([]string{createArchivedTasksSQL}, createArchivedTasksIndices...)
+	for _, query := range queries {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-creating-task-archive", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddTaskArchive) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+// Archived tasks keep their whole serialized task, result included, which may
+// not fit in a MySQL TEXT column.
+const createMySQLArchivedTasksSQL = `CREATE TABLE archived_tasks(
+	guid VARCHAR(255) PRIMARY KEY,
+	domain VARCHAR(255) NOT NULL,
+	completed_at BIGINT NOT NULL,
+	archived_at BIGINT NOT NULL,
+	task MEDIUMTEXT NOT NULL
+);`
+
+const createPostgresArchivedTasksSQL = `CREATE TABLE archived_tasks(
+	guid VARCHAR(255) PRIMARY KEY,
+	domain VARCHAR(255) NOT NULL,
+	completed_at BIGINT NOT NULL,
+	archived_at BIGINT NOT NULL,
+	task TEXT NOT NULL
+);`
+
+var createArchivedTasksIndices = []string{
+	`CREATE INDEX archived_tasks_domain_idx ON archived_tasks (domain)`,
+	`CREATE INDEX archived_tasks_completed_at_idx ON archived_tasks (completed_at)`,
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskDeadlines())
+}
+
+type AddTaskDeadlines struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskDeadlines() migration.Migration {
+	return &AddTaskDeadlines{}
+}
+
+func (a *AddTaskDeadlines) String() string {
+	return "1792408091"
+}
+
+func (a *AddTaskDeadlines) Version() int64 {
+	return 1792408091
+}
+
+func (a *AddTaskDeadlines) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskDeadlines) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskDeadlines) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskDeadlines) RequiresSQL() bool                           { return true }
+func (a *AddTaskDeadlines) SetClock(c clock.Clock)                      {}
+func (a *AddTaskDeadlines) SetDBFlavor(flavor string)                   {}
+
+// Up adds the columns the task convergence enforces the deadlines of task
+// definitions by. Tasks desired before they existed have no deadlines.
+func (a *AddTaskDeadlines) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-deadlines")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	for _, query := range addTaskDeadlinesSQL {
+		logger.Info("executing", lager.Data{"query": query})
+		_, err := a.rawSQLDB.Exec(query)
+		if err != nil {
+			logger.Error("failed-adding-task-deadlines", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AddTaskDeadlines) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+var addTaskDeadlinesSQL = []string{
+	`ALTER TABLE tasks ADD COLUMN max_pending_time BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE tasks ADD COLUMN max_run_time BIGINT NOT NULL DEFAULT 0`,
+}
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+func init() {
+	AppendMigration(NewAddTaskHeartbeatTimeout())
+}
+
+type AddTaskHeartbeatTimeout struct {
+	rawSQLDB *sql.DB
+}
+
+func NewAddTaskHeartbeatTimeout() migration.Migration {
+	return &AddTaskHeartbeatTimeout{}
+}
+
+func (a *AddTaskHeartbeatTimeout) String() string {
+	return "1792408143"
+}
+
+func (a *AddTaskHeartbeatTimeout) Version() int64 {
+	return 1792408143
+}
+
+func (a *AddTaskHeartbeatTimeout) SetStoreClient(storeClient etcd.StoreClient) {}
+func (a *AddTaskHeartbeatTimeout) SetCryptor(cryptor encryption.Cryptor)       {}
+func (a *AddTaskHeartbeatTimeout) SetRawSQLDB(db *sql.DB)                      { a.rawSQLDB = db }
+func (a *AddTaskHeartbeatTimeout) RequiresSQL() bool                           { return true }
+func (a *AddTaskHeartbeatTimeout) SetClock(c clock.Clock)                      {}
+func (a *AddTaskHeartbeatTimeout) SetDBFlavor(flavor string)                   {}
+
+// Up adds the heartbeat_timeout column the task convergence fails running
+// tasks whose heartbeats stopped by. Tasks desired before it existed have no
+// heartbeat timeout.
+func (a *AddTaskHeartbeatTimeout) Up(logger lager.Logger) error {
+	logger = logger.Session("add-task-heartbeat-timeout")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	logger.Info("executing", lager.Data{"query": addTaskHeartbeatTimeoutSQL})
+	_, err := a.rawSQLDB.Exec(addTaskHeartbeatTimeoutSQL)
+	if err != nil {
+		logger.Error("failed-adding-task-heartbeat-timeout", err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *AddTaskHeartbeatTimeout) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
+
+const addTaskHeartbeatTimeoutSQL = `ALTER TABLE tasks ADD COLUMN heartbeat_timeout BIGINT NOT NULL DEFAULT 0`
@@ -39,6 +39,53 @@ func (db *SQLDB) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFil
 	return db.scanAndCleanupActualLRPs(logger, db.db, rows)
 }
 
+// ActualLRPs lists the rows of the instance and evacuating ActualLRPs
+// individually, with their presence set from the evacuating column.
+func (db *SQLDB) ActualLRPs(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRP, error) {
+	logger = logger.WithData(lager.Data{"filter": filter})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var wheres []string
+	var values []interface{}
+
+	if filter.Domain != "" {
+		wheres = append(wheres, "domain = ?")
+		values = append(values, filter.Domain)
+	}
+
+	if filter.CellID != "" {
+		wheres = append(wheres, "cell_id = ?")
+		values = append(values, filter.CellID)
+	}
+
+	if filter.ProcessGuid != "" {
+		wheres = append(wheres, "process_guid = ?")
+		values = append(values, filter.ProcessGuid)
+	}
+
+	if filter.Index != nil {
+		wheres = append(wheres, "instance_index = ?")
+		values = append(values, *filter.Index)
+	}
+
+	rows, err := db.all(logger, db.db, actualLRPsTable,
+		actualLRPColumns, NoLockRow,
+		strings.Join(wheres, " AND "), values...,
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	groups, err := db.scanAndCleanupActualLRPs(logger, db.db, rows)
+	if err != nil {
+		return nil, err
+	}
+	return models.FlattenActualLRPGroups(groups), nil
+}
+
 func (db *SQLDB) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) ([]*models.ActualLRPGroup, error) {
 	logger = logger.WithData(lager.Data{"process_guid": processGuid})
 	logger.Debug("starting")
@@ -94,31 +141,43 @@ func (db *SQLDB) CreateUnclaimedActualLRP(logger lager.Logger, key *models.Actua
 		return nil, models.ErrGUIDGeneration
 	}
 
-	now := db.clock.Now().UnixNano()
-	_, err = db.insert(logger, db.db, actualLRPsTable,
-		SQLAttributes{
-			"process_guid":           key.ProcessGuid,
-			"instance_index":         key.Index,
-			"domain":                 key.Domain,
-			"state":                  models.ActualLRPStateUnclaimed,
-			"since":                  now,
-			"net_info":               []byte{},
-			"modification_tag_epoch": guid,
-			"modification_tag_index": 0,
-		},
-	)
+	var group *models.ActualLRPGroup
+
+	err = db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		now := db.clock.Now().UnixNano()
+		_, err := db.insert(logger, tx, actualLRPsTable,
+			SQLAttributes{
+				"process_guid":           key.ProcessGuid,
+				"instance_index":         key.Index,
+				"domain":                 key.Domain,
+				"state":                  models.ActualLRPStateUnclaimed,
+				"since":                  now,
+				"net_info":               []byte{},
+				"modification_tag_epoch": guid,
+				"modification_tag_index": 0,
+			},
+		)
+		if err != nil {
+			logger.Error("failed-to-create-unclaimed-actual-lrp", err)
+			return db.convertSQLError(err)
+		}
+
+		group = &models.ActualLRPGroup{
+			Instance: &models.ActualLRP{
+				ActualLRPKey:    *key,
+				State:           models.ActualLRPStateUnclaimed,
+				Since:           now,
+				ModificationTag: models.ModificationTag{Epoch: guid, Index: 0},
+			},
+		}
+
+		return db.appendEvents(logger, tx, models.NewActualLRPCreatedEvent(group))
+	})
 	if err != nil {
-		logger.Error("failed-to-create-unclaimed-actual-lrp", err)
-		return nil, db.convertSQLError(err)
+		return nil, err
 	}
-	return &models.ActualLRPGroup{
-		Instance: &models.ActualLRP{
-			ActualLRPKey:    *key,
-			State:           models.ActualLRPStateUnclaimed,
-			Since:           now,
-			ModificationTag: models.ModificationTag{Epoch: guid, Index: 0},
-		},
-	}, nil
+
+	return group, nil
 }
 
 func (db *SQLDB) UnclaimActualLRP(logger lager.Logger, key *models.ActualLRPKey) (*models.ActualLRPGroup, *models.ActualLRPGroup, error) {
@@ -170,7 +229,7 @@ func (db *SQLDB) UnclaimActualLRP(logger lager.Logger, key *models.ActualLRPKey)
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		return db.appendActualLRPChangedEvent(logger, tx, &beforeActualLRP, actualLRP)
 	})
 
 	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
@@ -226,7 +285,7 @@ func (db *SQLDB) ClaimActualLRP(logger lager.Logger, processGuid string, index i
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		return db.appendActualLRPChangedEvent(logger, tx, &beforeActualLRP, actualLRP)
 	})
 
 	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
@@ -243,7 +302,10 @@ func (db *SQLDB) StartActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 		actualLRP, err = db.fetchActualLRPForUpdate(logger, key.ProcessGuid, key.Index, false, tx)
 		if err == models.ErrResourceNotFound {
 			actualLRP, err = db.createRunningActualLRP(logger, key, instanceKey, netInfo, tx)
-			return err
+			if err != nil {
+				return err
+			}
+			return db.appendEvents(logger, tx, models.NewActualLRPCreatedEvent(&models.ActualLRPGroup{Instance: actualLRP}))
 		}
 
 		if err != nil {
@@ -303,7 +365,7 @@ func (db *SQLDB) StartActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		return db.appendActualLRPChangedEvent(logger, tx, &beforeActualLRP, actualLRP)
 	})
 
 	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
@@ -379,7 +441,10 @@ func (db *SQLDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		return db.appendEvents(logger, tx,
+			models.NewActualLRPCrashedEvent(actualLRP),
+			models.NewActualLRPChangedEvent(&models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}),
+		)
 	})
 
 	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, immediateRestart, err
@@ -427,7 +492,7 @@ func (db *SQLDB) FailActualLRP(logger lager.Logger, key *models.ActualLRPKey, pl
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		return db.appendActualLRPChangedEvent(logger, tx, &beforeActualLRP, actualLRP)
 	})
 
 	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
@@ -439,7 +504,12 @@ func (db *SQLDB) RemoveActualLRP(logger lager.Logger, processGuid string, index
 	defer logger.Info("complete")
 
 	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
-		var err error
+		actualLRP, err := db.fetchActualLRPForUpdate(logger, processGuid, index, false, tx)
+		if err != nil {
+			logger.Error("failed-fetching-actual-lrp", err)
+			return err
+		}
+
 		var result sql.Result
 		if instanceKey == nil {
 			result, err = db.delete(logger, tx, actualLRPsTable,
@@ -467,10 +537,17 @@ func (db *SQLDB) RemoveActualLRP(logger lager.Logger, processGuid string, index
 			return models.ErrResourceNotFound
 		}
 
-		return nil
+		return db.appendEvents(logger, tx, models.NewActualLRPRemovedEvent(&models.ActualLRPGroup{Instance: actualLRP}))
 	})
 }
 
+func (db *SQLDB) appendActualLRPChangedEvent(logger lager.Logger, tx *sql.Tx, before, after *models.ActualLRP) error {
+	return db.appendEvents(logger, tx, models.NewActualLRPChangedEvent(
+		&models.ActualLRPGroup{Instance: before},
+		&models.ActualLRPGroup{Instance: after},
+	))
+}
+
 func (db *SQLDB) createRunningActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, tx *sql.Tx) (*models.ActualLRP, error) {
 	now := db.clock.Now().UnixNano()
 	guid, err := db.guidProvider.NextGUID()
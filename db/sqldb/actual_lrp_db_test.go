@@ -149,6 +149,64 @@ var _ = Describe("ActualLRPDB", func() {
 		})
 	})
 
+	Describe("ActualLRPs", func() {
+		var instanceLRP, evacuatingLRP *models.ActualLRP
+
+		BeforeEach(func() {
+			fakeGUIDProvider.NextGUIDReturns("mod-tag-guid", nil)
+
+			instanceKey := models.NewActualLRPKey("guid1", 0, "domain1")
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, &instanceKey)
+			Expect(err).NotTo(HaveOccurred())
+			instanceLRP = &models.ActualLRP{
+				ActualLRPKey: instanceKey,
+				State:        models.ActualLRPStateUnclaimed,
+				Since:        fakeClock.Now().UnixNano(),
+				ModificationTag: models.ModificationTag{
+					Epoch: "mod-tag-guid",
+					Index: 0,
+				},
+			}
+
+			evacuatingKey := models.NewActualLRPKey("guid1", 1, "domain1")
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &evacuatingKey)
+			Expect(err).NotTo(HaveOccurred())
+			queryStr := "UPDATE actual_lrps SET evacuating = ? WHERE process_guid = ? AND instance_index = ? AND evacuating = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			_, err = db.Exec(queryStr, true, evacuatingKey.ProcessGuid, evacuatingKey.Index, false)
+			Expect(err).NotTo(HaveOccurred())
+			evacuatingLRP = &models.ActualLRP{
+				ActualLRPKey: evacuatingKey,
+				State:        models.ActualLRPStateUnclaimed,
+				Since:        fakeClock.Now().UnixNano(),
+				ModificationTag: models.ModificationTag{
+					Epoch: "mod-tag-guid",
+					Index: 0,
+				},
+				Presence: models.ActualLRP_Evacuating,
+			}
+
+			otherKey := models.NewActualLRPKey("guid2", 0, "domain2")
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &otherKey)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("can filter by process guid", func() {
+			actualLRPs, err := sqlDB.ActualLRPs(logger, models.ActualLRPFilter{ProcessGuid: "guid1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPs).To(ConsistOf(instanceLRP, evacuatingLRP))
+		})
+
+		It("can filter by index", func() {
+			index := int32(1)
+			actualLRPs, err := sqlDB.ActualLRPs(logger, models.ActualLRPFilter{Domain: "domain1", Index: &index})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPs).To(ConsistOf(evacuatingLRP))
+		})
+	})
+
 	Describe("ActualLRPGroups", func() {
 		var allActualLRPGroups []*models.ActualLRPGroup
 
@@ -0,0 +1,174 @@
+package sqldb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *SQLDB) AppendAuditRecord(logger lager.Logger, record *models.AuditRecord) error {
+	logger = logger.Session("append-audit-record-sqldb", lager.Data{"index": record.Index})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	changes, err := json.Marshal(record.Changes)
+	if err != nil {
+		logger.Error("failed-to-serialize-changes", err)
+		return models.NewError(models.Error_InvalidRecord, err.Error())
+	}
+
+	_, err = db.insert(logger, db.db, auditTable,
+		SQLAttributes{
+			"record_index":  record.Index,
+			"recorded_at":   record.Timestamp,
+			"identity":      record.Identity,
+			"remote_addr":   record.RemoteAddr,
+			"route":         record.Route,
+			"target_guid":   record.TargetGuid,
+			"changes":       changes,
+			"error":         record.Error,
+			"previous_hash": record.PreviousHash,
+			"hash":          record.Hash,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-inserting-audit-record", err)
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
+
+func (db *SQLDB) LastAuditRecord(logger lager.Logger) (*models.AuditRecord, error) {
+	logger = logger.Session("last-audit-record-sqldb")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		ORDER BY record_index DESC
+		LIMIT 1
+	`, strings.Join(auditColumns, ", "), auditTable)
+
+	record, err := db.fetchAuditRecord(logger, db.db.QueryRow(query))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error("failed-fetching-last-audit-record", err)
+		return nil, db.convertSQLError(err)
+	}
+
+	return record, nil
+}
+
+// AuditRecords returns the records matching the filter in the order they
+// were appended. When the filter has a limit, the most recent records are
+// returned.
+func (db *SQLDB) AuditRecords(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error) {
+	logger = logger.Session("audit-records-sqldb", lager.Data{"filter": filter})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	wheres := []string{}
+	values := []interface{}{}
+
+	if filter.Since != 0 {
+		wheres = append(wheres, "recorded_at >= ?")
+		values = append(values, filter.Since)
+	}
+	if filter.Until != 0 {
+		wheres = append(wheres, "recorded_at <= ?")
+		values = append(values, filter.Until)
+	}
+	if filter.Identity != "" {
+		wheres = append(wheres, "identity = ?")
+		values = append(values, filter.Identity)
+	}
+	if filter.Route != "" {
+		wheres = append(wheres, "route = ?")
+		values = append(values, filter.Route)
+	}
+	if filter.TargetGuid != "" {
+		wheres = append(wheres, "target_guid = ?")
+		values = append(values, filter.TargetGuid)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s\n", strings.Join(auditColumns, ", "), auditTable)
+	if len(wheres) > 0 {
+		query += "WHERE " + strings.Join(wheres, " AND ") + "\n"
+	}
+	if filter.Limit > 0 {
+		query += fmt.Sprintf("ORDER BY record_index DESC\nLIMIT %d", filter.Limit)
+	} else {
+		query += "ORDER BY record_index ASC"
+	}
+
+	rows, err := db.db.Query(db.rebind(query), values...)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	records := []*models.AuditRecord{}
+	for rows.Next() {
+		record, err := db.fetchAuditRecord(logger, rows)
+		if err != nil {
+			logger.Error("failed-reading-row", err)
+			return nil, db.convertSQLError(err)
+		}
+		records = append(records, record)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-fetching-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	if filter.Limit > 0 {
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+	}
+
+	return records, nil
+}
+
+func (db *SQLDB) fetchAuditRecord(logger lager.Logger, scanner RowScanner) (*models.AuditRecord, error) {
+	record := &models.AuditRecord{}
+	var changes []byte
+	var errorMessage sql.NullString
+
+	err := scanner.Scan(
+		&record.Index,
+		&record.Timestamp,
+		&record.Identity,
+		&record.RemoteAddr,
+		&record.Route,
+		&record.TargetGuid,
+		&changes,
+		&errorMessage,
+		&record.PreviousHash,
+		&record.Hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Error = errorMessage.String
+
+	if len(changes) > 0 {
+		err = json.Unmarshal(changes, &record.Changes)
+		if err != nil {
+			logger.Error("failed-to-deserialize-changes", err)
+			return nil, err
+		}
+	}
+
+	return record, nil
+}
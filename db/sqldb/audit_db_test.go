@@ -0,0 +1,131 @@
+package sqldb_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditDB", func() {
+	newRecord := func(index int64, route, targetGuid string) *models.AuditRecord {
+		return &models.AuditRecord{
+			Index:      index,
+			Timestamp:  index * 100,
+			Identity:   "some-operator",
+			RemoteAddr: "10.0.0.1:1234",
+			Route:      route,
+			TargetGuid: targetGuid,
+			Changes: []*models.AuditChange{
+				{Field: "instances", Before: "1", After: "2"},
+			},
+			PreviousHash: "previous-hash",
+			Hash:         "hash",
+		}
+	}
+
+	Describe("AppendAuditRecord", func() {
+		It("persists the record", func() {
+			record := newRecord(1, "UpdateDesiredLRP", "some-guid")
+			record.Error = "the request failed"
+
+			Expect(sqlDB.AppendAuditRecord(logger, record)).To(Succeed())
+
+			records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(Equal([]*models.AuditRecord{record}))
+		})
+
+		Context("when a record with the same index exists", func() {
+			BeforeEach(func() {
+				Expect(sqlDB.AppendAuditRecord(logger, newRecord(1, "DesireTask", "some-guid"))).To(Succeed())
+			})
+
+			It("refuses to overwrite it", func() {
+				err := sqlDB.AppendAuditRecord(logger, newRecord(1, "DeleteTask", "some-guid"))
+				Expect(err).To(HaveOccurred())
+
+				records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].Route).To(Equal("DesireTask"))
+			})
+		})
+	})
+
+	Describe("LastAuditRecord", func() {
+		Context("when there are no records", func() {
+			It("returns nil", func() {
+				record, err := sqlDB.LastAuditRecord(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record).To(BeNil())
+			})
+		})
+
+		Context("when there are records", func() {
+			BeforeEach(func() {
+				Expect(sqlDB.AppendAuditRecord(logger, newRecord(1, "DesireTask", "guid-1"))).To(Succeed())
+				Expect(sqlDB.AppendAuditRecord(logger, newRecord(2, "DesireTask", "guid-2"))).To(Succeed())
+			})
+
+			It("returns the record with the highest index", func() {
+				record, err := sqlDB.LastAuditRecord(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record.Index).To(BeEquivalentTo(2))
+			})
+		})
+	})
+
+	Describe("AuditRecords", func() {
+		BeforeEach(func() {
+			Expect(sqlDB.AppendAuditRecord(logger, newRecord(1, "DesireTask", "guid-1"))).To(Succeed())
+			Expect(sqlDB.AppendAuditRecord(logger, newRecord(2, "CancelTask", "guid-1"))).To(Succeed())
+			Expect(sqlDB.AppendAuditRecord(logger, newRecord(3, "DesireTask", "guid-2"))).To(Succeed())
+			Expect(sqlDB.AppendAuditRecord(logger, newRecord(4, "UpsertDomain", "some-domain"))).To(Succeed())
+		})
+
+		indices := func(records []*models.AuditRecord) []int64 {
+			result := []int64{}
+			for _, record := range records {
+				result = append(result, record.Index)
+			}
+			return result
+		}
+
+		It("returns every record in order", func() {
+			records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indices(records)).To(Equal([]int64{1, 2, 3, 4}))
+		})
+
+		It("filters by time", func() {
+			records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{Since: 200, Until: 300})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indices(records)).To(Equal([]int64{2, 3}))
+		})
+
+		It("filters by route", func() {
+			records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{Route: "DesireTask"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indices(records)).To(Equal([]int64{1, 3}))
+		})
+
+		It("filters by target guid", func() {
+			records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{TargetGuid: "guid-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indices(records)).To(Equal([]int64{1, 2}))
+		})
+
+		It("filters by identity", func() {
+			records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{Identity: "someone-else"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		It("returns the most recent records up to the limit", func() {
+			records, err := sqlDB.AuditRecords(logger, models.AuditRecordFilter{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indices(records)).To(Equal([]int64{3, 4}))
+		})
+	})
+})
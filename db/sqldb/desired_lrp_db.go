@@ -65,7 +65,8 @@ func (db *SQLDB) DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) e
 			logger.Error("failed-inserting-desired", err)
 			return db.convertSQLError(err)
 		}
-		return nil
+
+		return db.appendEvents(logger, tx, models.NewDesiredLRPCreatedEvent(desiredLRP))
 	})
 }
 
@@ -171,11 +172,7 @@ func (db *SQLDB) UpdateDesiredLRP(logger lager.Logger, processGuid string, updat
 	var beforeDesiredLRP *models.DesiredLRP
 	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
 		var err error
-		row := db.one(logger, tx, desiredLRPsTable,
-			desiredLRPColumns, LockRow,
-			"process_guid = ?", processGuid,
-		)
-		beforeDesiredLRP, err = db.fetchDesiredLRP(logger, row)
+		beforeDesiredLRP, err = db.fetchDesiredLRPForUpdate(logger, processGuid, tx)
 		if err != nil {
 			logger.Error("failed-lock-desired", err)
 			return err
@@ -206,7 +203,13 @@ func (db *SQLDB) UpdateDesiredLRP(logger lager.Logger, processGuid string, updat
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		afterDesiredLRP, err := db.fetchDesiredLRPForUpdate(logger, processGuid, tx)
+		if err != nil {
+			logger.Error("failed-fetching-updated-desired", err)
+			return err
+		}
+
+		return db.appendEvents(logger, tx, models.NewDesiredLRPChangedEvent(beforeDesiredLRP, afterDesiredLRP))
 	})
 
 	return beforeDesiredLRP, err
@@ -218,7 +221,7 @@ func (db *SQLDB) RemoveDesiredLRP(logger lager.Logger, processGuid string) error
 	defer logger.Info("complete")
 
 	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
-		err := db.lockDesiredLRPByGuidForUpdate(logger, processGuid, tx)
+		desiredLRP, err := db.fetchDesiredLRPForUpdate(logger, processGuid, tx)
 		if err != nil {
 			logger.Error("failed-lock-desired", err)
 			return err
@@ -230,7 +233,7 @@ func (db *SQLDB) RemoveDesiredLRP(logger lager.Logger, processGuid string) error
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		return db.appendEvents(logger, tx, models.NewDesiredLRPRemovedEvent(desiredLRP))
 	})
 }
 
@@ -279,19 +282,12 @@ func (db *SQLDB) fetchDesiredLRPSchedulingInfoAndMore(logger lager.Logger, scann
 	return schedulingInfo, nil
 }
 
-func (db *SQLDB) lockDesiredLRPByGuidForUpdate(logger lager.Logger, processGuid string, tx *sql.Tx) error {
+func (db *SQLDB) fetchDesiredLRPForUpdate(logger lager.Logger, processGuid string, tx *sql.Tx) (*models.DesiredLRP, error) {
 	row := db.one(logger, tx, desiredLRPsTable,
-		ColumnList{"1"}, LockRow,
+		desiredLRPColumns, LockRow,
 		"process_guid = ?", processGuid,
 	)
-	var count int
-	err := row.Scan(&count)
-	if err == sql.ErrNoRows {
-		return models.ErrResourceNotFound
-	} else if err != nil {
-		return db.convertSQLError(err)
-	}
-	return nil
+	return db.fetchDesiredLRP(logger, row)
 }
 
 func (db *SQLDB) fetchDesiredLRP(logger lager.Logger, scanner RowScanner) (*models.DesiredLRP, error) {
@@ -40,8 +40,14 @@ func (db *SQLDB) PerformEncryption(logger lager.Logger) error {
 	go func() {
 		errCh <- db.reEncrypt(logger, actualLRPsTable, "process_guid", "net_info")
 	}()
+	go func() {
+		errCh <- db.reEncrypt(logger, eventOutboxTable, "sequence", "payload")
+	}()
+	go func() {
+		errCh <- db.reEncrypt(logger, archivedTasksTable, "guid", "task")
+	}()
 
-	for i := 0; i < 4; i++ {
+	for i := 0; i < 6; i++ {
 		err := <-errCh
 		if err != nil {
 			return err
@@ -31,7 +31,10 @@ func (db *SQLDB) EvacuateActualLRP(
 		if err == models.ErrResourceNotFound {
 			logger.Debug("creating-evacuating-lrp")
 			actualLRP, err = db.createEvacuatingActualLRP(logger, lrpKey, instanceKey, netInfo, ttl, tx)
-			return err
+			if err != nil {
+				return err
+			}
+			return db.appendEvents(logger, tx, models.NewActualLRPCreatedEvent(&models.ActualLRPGroup{Evacuating: actualLRP}))
 		}
 
 		if err != nil {
@@ -46,6 +49,8 @@ func (db *SQLDB) EvacuateActualLRP(
 			return nil
 		}
 
+		beforeActualLRP := *actualLRP
+
 		now := db.clock.Now().UnixNano()
 		actualLRP.ModificationTag.Increment()
 		actualLRP.ActualLRPKey = *lrpKey
@@ -77,7 +82,10 @@ func (db *SQLDB) EvacuateActualLRP(
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		return db.appendEvents(logger, tx, models.NewActualLRPChangedEvent(
+			&models.ActualLRPGroup{Evacuating: &beforeActualLRP},
+			&models.ActualLRPGroup{Evacuating: actualLRP},
+		))
 	})
 
 	return &models.ActualLRPGroup{Evacuating: actualLRP}, err
@@ -117,7 +125,7 @@ func (db *SQLDB) RemoveEvacuatingActualLRP(logger lager.Logger, lrpKey *models.A
 			return models.ErrActualLRPCannotBeRemoved
 		}
 
-		return nil
+		return db.appendEvents(logger, tx, models.NewActualLRPRemovedEvent(&models.ActualLRPGroup{Evacuating: lrp}))
 	})
 }
 
@@ -0,0 +1,174 @@
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/lager"
+)
+
+// EventsAfter skips, and deletes, events that can no longer be read, so that
+// they do not hold up the ones recorded after them.
+func (db *SQLDB) EventsAfter(logger lager.Logger, sequence uint64, limit int) ([]*models.SequencedEvent, error) {
+	logger = logger.Session("events-after-sqldb", lager.Data{"sequence": sequence, "limit": limit})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE sequence > ?
+		ORDER BY sequence ASC
+		LIMIT %d
+	`, strings.Join(eventOutboxColumns, ", "), eventOutboxTable, limit)
+
+	rows, err := db.db.Query(db.rebind(query), sequence)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	events := []*models.SequencedEvent{}
+	malformedSequences := []uint64{}
+	for rows.Next() {
+		var eventSequence uint64
+		var eventType string
+		var recordedAt int64
+		var payload []byte
+
+		err := rows.Scan(&eventSequence, &eventType, &recordedAt, &payload)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return nil, db.convertSQLError(err)
+		}
+
+		event, err := db.deserializeEvent(logger, eventType, payload)
+		if err != nil {
+			logger.Error("failed-to-deserialize-event", err, lager.Data{"event_sequence": eventSequence, "event_type": eventType})
+			malformedSequences = append(malformedSequences, eventSequence)
+			continue
+		}
+
+		events = append(events, &models.SequencedEvent{Sequence: eventSequence, RecordedAt: recordedAt, Event: event})
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	for _, malformedSequence := range malformedSequences {
+		logger.Info("deleting-malformed-event-from-db", lager.Data{"event_sequence": malformedSequence})
+		_, err := db.delete(logger, db.db, eventOutboxTable, "sequence = ?", malformedSequence)
+		if err != nil {
+			logger.Error("failed-deleting-event", err)
+			return nil, db.convertSQLError(err)
+		}
+	}
+
+	return events, nil
+}
+
+// LastEventSequence returns the sequence of the latest event in the outbox.
+// Pruning always leaves the latest events behind, so it is the last sequence
+// handed out unless that transaction is still to commit.
+func (db *SQLDB) LastEventSequence(logger lager.Logger) (uint64, error) {
+	logger = logger.Session("last-event-sequence-sqldb")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var sequence uint64
+	err := db.one(logger, db.db, eventOutboxTable,
+		ColumnList{"COALESCE(MAX(sequence), 0)"}, NoLockRow,
+		"",
+	).Scan(&sequence)
+	if err != nil {
+		logger.Error("failed-fetching-last-event-sequence", err)
+		return 0, db.convertSQLError(err)
+	}
+
+	return sequence, nil
+}
+
+func (db *SQLDB) PruneEvents(logger lager.Logger, throughSequence uint64) error {
+	logger = logger.Session("prune-events-sqldb", lager.Data{"through_sequence": throughSequence})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	_, err := db.delete(logger, db.db, eventOutboxTable, "sequence <= ?", throughSequence)
+	if err != nil {
+		logger.Error("failed-pruning-events", err)
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
+
+// appendEvents records the events in the outbox as part of the transaction
+// that made the change they describe. The outbox numbers the events itself, so
+// concurrent writers do not wait on each other; as a consequence sequences
+// can become visible out of order, or never if their transaction rolls back,
+// and readers have to allow for the gaps. It should be the last statement of
+// the transaction, so that its events commit soon after they are numbered.
+func (db *SQLDB) appendEvents(logger lager.Logger, tx *sql.Tx, events ...models.Event) error {
+	now := db.clock.Now().UnixNano()
+	for _, event := range events {
+		payload, err := db.serializeEvent(logger, event)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.insert(logger, tx, eventOutboxTable,
+			SQLAttributes{
+				"event_type": event.EventType(),
+				"created_at": now,
+				"payload":    payload,
+			},
+		)
+		if err != nil {
+			logger.Error("failed-inserting-event", err, lager.Data{"event_type": event.EventType()})
+			return db.convertSQLError(err)
+		}
+	}
+
+	return nil
+}
+
+func (db *SQLDB) serializeEvent(logger lager.Logger, event models.Event) ([]byte, error) {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		logger.Error("failed-to-marshal-event", err)
+		return nil, models.NewError(models.Error_InvalidRecord, err.Error())
+	}
+
+	payload, err := format.NewEncoder(db.cryptor).Encode(db.format.Encoding, data)
+	if err != nil {
+		logger.Error("failed-to-encode-event", err)
+		return nil, models.NewError(models.Error_InvalidRecord, err.Error())
+	}
+
+	return payload, nil
+}
+
+func (db *SQLDB) deserializeEvent(logger lager.Logger, eventType string, payload []byte) (models.Event, error) {
+	data, err := format.NewEncoder(db.cryptor).Decode(payload)
+	if err != nil {
+		return nil, models.NewError(models.Error_InvalidRecord, err.Error())
+	}
+
+	event := models.NewEventOfType(eventType)
+	if event == nil {
+		return nil, models.NewError(models.Error_InvalidRecord, "unknown event type: "+eventType)
+	}
+
+	err = proto.Unmarshal(data, event)
+	if err != nil {
+		return nil, models.NewError(models.Error_InvalidRecord, err.Error())
+	}
+
+	return event, nil
+}
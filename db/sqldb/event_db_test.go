@@ -0,0 +1,199 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/test_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventDB", func() {
+	var startSequence uint64
+
+	BeforeEach(func() {
+		var err error
+		startSequence, err = sqlDB.LastEventSequence(logger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	eventTypes := func(events []*models.SequencedEvent) []string {
+		types := []string{}
+		for _, event := range events {
+			types = append(types, event.Event.EventType())
+		}
+		return types
+	}
+
+	Describe("recording events", func() {
+		var task *models.Task
+
+		BeforeEach(func() {
+			task = model_helpers.NewValidTask("the-task-guid")
+			Expect(sqlDB.DesireTask(logger, task.TaskDefinition, task.TaskGuid, task.Domain)).NotTo(BeNil())
+		})
+
+		It("records an event for each change, in the same transaction", func() {
+			events, err := sqlDB.EventsAfter(logger, startSequence, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Sequence).To(Equal(startSequence + 1))
+
+			created, ok := events[0].Event.(*models.TaskCreatedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(created.Task.TaskGuid).To(Equal(task.TaskGuid))
+			Expect(created.Task.State).To(Equal(models.Task_Pending))
+		})
+
+		It("records the task before and after it changed", func() {
+			_, _, err := sqlDB.StartTask(logger, task.TaskGuid, "cell-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := sqlDB.EventsAfter(logger, startSequence+1, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			changed, ok := events[0].Event.(*models.TaskChangedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(changed.Before.State).To(Equal(models.Task_Pending))
+			Expect(changed.After.State).To(Equal(models.Task_Running))
+			Expect(changed.After.CellId).To(Equal("cell-id"))
+		})
+
+		It("hands out sequences in order across the tables", func() {
+			desiredLRP := model_helpers.NewValidDesiredLRP("the-guid")
+			Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+			Expect(sqlDB.RemoveDesiredLRP(logger, desiredLRP.ProcessGuid)).To(Succeed())
+
+			events, err := sqlDB.EventsAfter(logger, startSequence, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(eventTypes(events)).To(Equal([]string{
+				models.EventTypeTaskCreated,
+				models.EventTypeDesiredLRPCreated,
+				models.EventTypeDesiredLRPRemoved,
+			}))
+			for i, event := range events {
+				Expect(event.Sequence).To(Equal(startSequence + uint64(i) + 1))
+			}
+
+			lastSequence, err := sqlDB.LastEventSequence(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastSequence).To(Equal(startSequence + 3))
+		})
+
+		It("records both events when an actual lrp crashes", func() {
+			key := models.NewActualLRPKey("the-guid", 0, "the-domain")
+			instanceKey := models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+			netInfo := models.NewActualLRPNetInfo("127.0.0.1", models.NewPortMapping(8080, 80))
+
+			_, _, err := sqlDB.StartActualLRP(logger, &key, &instanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, err = sqlDB.CrashActualLRP(logger, &key, &instanceKey, "boom")
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := sqlDB.EventsAfter(logger, startSequence+1, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(eventTypes(events)).To(Equal([]string{
+				models.EventTypeActualLRPCreated,
+				models.EventTypeActualLRPCrashed,
+				models.EventTypeActualLRPChanged,
+			}))
+		})
+
+		It("records when each event was recorded", func() {
+			events, err := sqlDB.EventsAfter(logger, startSequence, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].RecordedAt).To(Equal(fakeClock.Now().UnixNano()))
+		})
+
+		It("does not record heartbeats that report no new progress", func() {
+			_, _, err := sqlDB.StartTask(logger, task.TaskGuid, "cell-id")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.TaskHeartbeat(logger, task.TaskGuid, "cell-id", "downloading", 42)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClock.Increment(time.Second)
+			_, err = sqlDB.TaskHeartbeat(logger, task.TaskGuid, "cell-id", "downloading", 42)
+			Expect(err).NotTo(HaveOccurred())
+
+			lastSequence, err := sqlDB.LastEventSequence(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastSequence).To(Equal(startSequence + 3))
+		})
+
+		Context("when the change fails", func() {
+			It("does not record an event", func() {
+				_, err := sqlDB.DesireTask(logger, task.TaskDefinition, task.TaskGuid, task.Domain)
+				Expect(err).To(HaveOccurred())
+
+				lastSequence, err := sqlDB.LastEventSequence(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lastSequence).To(Equal(startSequence + 1))
+			})
+		})
+
+		It("encrypts the recorded events", func() {
+			queryStr := "SELECT payload FROM event_outbox WHERE sequence = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+
+			var payload []byte
+			err := db.QueryRow(queryStr, startSequence+1).Scan(&payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(payload)).NotTo(ContainSubstring(task.TaskGuid))
+		})
+	})
+
+	Describe("EventsAfter", func() {
+		BeforeEach(func() {
+			for _, guid := range []string{"task-1", "task-2", "task-3"} {
+				task := model_helpers.NewValidTask(guid)
+				Expect(sqlDB.DesireTask(logger, task.TaskDefinition, task.TaskGuid, task.Domain)).NotTo(BeNil())
+			}
+		})
+
+		It("returns at most limit events, oldest first", func() {
+			events, err := sqlDB.EventsAfter(logger, startSequence, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Sequence).To(Equal(startSequence + 1))
+			Expect(events[1].Sequence).To(Equal(startSequence + 2))
+		})
+
+		It("returns nothing when there are no newer events", func() {
+			events, err := sqlDB.EventsAfter(logger, startSequence+3, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+	})
+
+	Describe("PruneEvents", func() {
+		BeforeEach(func() {
+			for _, guid := range []string{"task-1", "task-2", "task-3"} {
+				task := model_helpers.NewValidTask(guid)
+				Expect(sqlDB.DesireTask(logger, task.TaskDefinition, task.TaskGuid, task.Domain)).NotTo(BeNil())
+			}
+		})
+
+		It("deletes the events up to and including the sequence", func() {
+			Expect(sqlDB.PruneEvents(logger, startSequence+2)).To(Succeed())
+
+			events, err := sqlDB.EventsAfter(logger, 0, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Sequence).To(Equal(startSequence + 3))
+		})
+
+		It("leaves the last sequence in place", func() {
+			Expect(sqlDB.PruneEvents(logger, startSequence+2)).To(Succeed())
+
+			lastSequence, err := sqlDB.LastEventSequence(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastSequence).To(Equal(startSequence + 3))
+		})
+	})
+})
@@ -0,0 +1,280 @@
+package sqldb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+// TableMetricsInterval is how often the metrics that scan whole tables, the
+// row counts and the run info sizes, are measured. The other metrics are
+// measured every time they are sent.
+const TableMetricsInterval = 5 * time.Minute
+
+const (
+	sqlOpenConnections  = metric.Metric("SQLOpenConnections")
+	sqlIdleConnections  = metric.Metric("SQLIdleConnections")
+	sqlInUseConnections = metric.Metric("SQLInUseConnections")
+
+	sqlDomainsRows     = metric.Metric("SQLDomainsRows")
+	sqlDesiredLRPsRows = metric.Metric("SQLDesiredLRPsRows")
+	sqlActualLRPsRows  = metric.Metric("SQLActualLRPsRows")
+	sqlTasksRows       = metric.Metric("SQLTasksRows")
+
+	sqlRunInfoTotalBytes = metric.Metric("SQLRunInfoTotalBytes")
+	sqlRunInfoMaxBytes   = metric.Metric("SQLRunInfoMaxBytes")
+
+	sqlOldestPendingTaskAge = metric.Duration("SQLOldestPendingTaskAge")
+	sqlReplicationLag       = metric.Duration("SQLReplicationLag")
+)
+
+type SQLMetrics struct {
+	logger lager.Logger
+	db     *sql.DB
+	flavor string
+	clock  clock.Clock
+
+	tableMetricsSentAt     time.Time
+	replicationLagDisabled bool
+}
+
+func NewSQLMetrics(logger lager.Logger, db *sql.DB, flavor string, clock clock.Clock) *SQLMetrics {
+	return &SQLMetrics{
+		logger: logger.Session("sql-metrics"),
+		db:     db,
+		flavor: flavor,
+		clock:  clock,
+	}
+}
+
+func (m *SQLMetrics) Send() {
+	m.sendConnectionStats()
+
+	now := m.clock.Now()
+	if m.tableMetricsSentAt.IsZero() || now.Sub(m.tableMetricsSentAt) >= TableMetricsInterval {
+		m.tableMetricsSentAt = now
+		m.sendRowCounts()
+		m.sendRunInfoSizes()
+	}
+
+	m.sendOldestPendingTask()
+
+	if !m.replicationLagDisabled {
+		m.sendReplicationLag()
+	}
+}
+
+func (m *SQLMetrics) sendConnectionStats() {
+	stats := m.db.Stats()
+
+	err := sqlOpenConnections.Send(stats.OpenConnections)
+	if err != nil {
+		m.logger.Error("failed-to-send-sql-open-connections-metric", err)
+	}
+
+	err = sqlIdleConnections.Send(stats.Idle)
+	if err != nil {
+		m.logger.Error("failed-to-send-sql-idle-connections-metric", err)
+	}
+
+	err = sqlInUseConnections.Send(stats.InUse)
+	if err != nil {
+		m.logger.Error("failed-to-send-sql-in-use-connections-metric", err)
+	}
+}
+
+func (m *SQLMetrics) sendRowCounts() {
+	tables := []struct {
+		name   string
+		metric metric.Metric
+	}{
+		{domainsTable, sqlDomainsRows},
+		{desiredLRPsTable, sqlDesiredLRPsRows},
+		{actualLRPsTable, sqlActualLRPsRows},
+		{tasksTable, sqlTasksRows},
+	}
+
+	for _, table := range tables {
+		var count int
+		err := m.db.QueryRow("SELECT COUNT(*) FROM " + table.name).Scan(&count)
+		if err != nil {
+			m.logger.Error("failed-counting-rows", err, lager.Data{"table": table.name})
+			continue
+		}
+
+		err = table.metric.Send(count)
+		if err != nil {
+			m.logger.Error("failed-to-send-sql-rows-metric", err, lager.Data{"table": table.name})
+		}
+	}
+}
+
+func (m *SQLMetrics) sendRunInfoSizes() {
+	query := `
+		SELECT
+			COALESCE(SUM(OCTET_LENGTH(run_info)), 0) AS total_bytes,
+			COALESCE(MAX(OCTET_LENGTH(run_info)), 0) AS max_bytes
+		FROM desired_lrps
+	`
+
+	var totalBytes, maxBytes int
+	err := m.db.QueryRow(query).Scan(&totalBytes, &maxBytes)
+	if err != nil {
+		m.logger.Error("failed-measuring-run-infos", err)
+		return
+	}
+
+	err = sqlRunInfoTotalBytes.Send(totalBytes)
+	if err != nil {
+		m.logger.Error("failed-to-send-sql-run-info-total-bytes-metric", err)
+	}
+
+	err = sqlRunInfoMaxBytes.Send(maxBytes)
+	if err != nil {
+		m.logger.Error("failed-to-send-sql-run-info-max-bytes-metric", err)
+	}
+}
+
+func (m *SQLMetrics) sendOldestPendingTask() {
+	query := RebindForFlavor(`SELECT MIN(created_at) FROM tasks WHERE state = ?`, m.flavor)
+
+	var createdAt sql.NullInt64
+	err := m.db.QueryRow(query, models.Task_Pending).Scan(&createdAt)
+	if err != nil {
+		m.logger.Error("failed-finding-oldest-pending-task", err)
+		return
+	}
+
+	var age time.Duration
+	if createdAt.Valid {
+		age = m.clock.Now().Sub(time.Unix(0, createdAt.Int64))
+	}
+
+	err = sqlOldestPendingTaskAge.Send(age)
+	if err != nil {
+		m.logger.Error("failed-to-send-sql-oldest-pending-task-age-metric", err)
+	}
+}
+
+// sendReplicationLag reports how far this connection's server is behind its
+// primary. Servers that are not replicas report nothing, and neither do users
+// without the privilege to ask, which is only logged the first time.
+func (m *SQLMetrics) sendReplicationLag() {
+	var lag time.Duration
+	var ok bool
+	var err error
+
+	switch m.flavor {
+	case MySQL:
+		lag, ok, err = m.mysqlReplicationLag()
+	case Postgres:
+		lag, ok, err = m.postgresReplicationLag()
+	}
+
+	if isPermissionError(err) {
+		m.logger.Info("disabling-replication-lag-metric", lager.Data{"error": err.Error()})
+		m.replicationLagDisabled = true
+		return
+	}
+
+	if err != nil {
+		m.logger.Error("failed-measuring-replication-lag", err)
+		return
+	}
+
+	if !ok {
+		return
+	}
+
+	err = sqlReplicationLag.Send(lag)
+	if err != nil {
+		m.logger.Error("failed-to-send-sql-replication-lag-metric", err)
+	}
+}
+
+func (m *SQLMetrics) mysqlReplicationLag() (time.Duration, bool, error) {
+	rows, err := m.db.Query("SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !rows.Next() {
+		return 0, false, rows.Err()
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	err = rows.Scan(dest...)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Master" {
+			continue
+		}
+
+		// NULL when the replica's SQL thread is not running
+		if values[i] == nil {
+			return 0, false, nil
+		}
+
+		seconds, err := time.ParseDuration(string(values[i]) + "s")
+		if err != nil {
+			return 0, false, err
+		}
+		return seconds, true, nil
+	}
+
+	return 0, false, nil
+}
+
+func (m *SQLMetrics) postgresReplicationLag() (time.Duration, bool, error) {
+	query := `
+		SELECT
+			pg_is_in_recovery(),
+			COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	`
+
+	var inRecovery bool
+	var seconds float64
+	err := m.db.QueryRow(query).Scan(&inRecovery, &seconds)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !inRecovery {
+		return 0, false, nil
+	}
+
+	return time.Duration(seconds * float64(time.Second)), true, nil
+}
+
+func isPermissionError(err error) bool {
+	switch err := err.(type) {
+	case *mysql.MySQLError:
+		// ER_DBACCESS_DENIED_ERROR, ER_TABLEACCESS_DENIED_ERROR and
+		// ER_SPECIFIC_ACCESS_DENIED_ERROR
+		return err.Number == 1044 || err.Number == 1142 || err.Number == 1227
+	case *pq.Error:
+		// insufficient_privilege
+		return err.Code == "42501"
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	"github.com/cloudfoundry/dropsonde/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SQLMetrics", func() {
+	var (
+		sender     *fake.FakeMetricSender
+		sqlMetrics *sqldb.SQLMetrics
+	)
+
+	BeforeEach(func() {
+		sender = fake.NewFakeMetricSender()
+		metrics.Initialize(sender, nil)
+
+		sqlMetrics = sqldb.NewSQLMetrics(logger, db, dbFlavor, fakeClock)
+	})
+
+	Context("when the database is empty", func() {
+		BeforeEach(func() {
+			sqlMetrics.Send()
+		})
+
+		It("reports the connection pool", func() {
+			Expect(sender.GetValue("SQLOpenConnections").Value).To(BeNumerically(">", 0))
+			Expect(sender.GetValue("SQLOpenConnections").Unit).To(Equal("Metric"))
+			Expect(sender.GetValue("SQLIdleConnections").Unit).To(Equal("Metric"))
+			Expect(sender.GetValue("SQLInUseConnections").Unit).To(Equal("Metric"))
+		})
+
+		It("reports zero rows", func() {
+			Expect(sender.GetValue("SQLDomainsRows")).To(Equal(fake.Metric{Value: 0, Unit: "Metric"}))
+			Expect(sender.GetValue("SQLDesiredLRPsRows")).To(Equal(fake.Metric{Value: 0, Unit: "Metric"}))
+			Expect(sender.GetValue("SQLActualLRPsRows")).To(Equal(fake.Metric{Value: 0, Unit: "Metric"}))
+			Expect(sender.GetValue("SQLTasksRows")).To(Equal(fake.Metric{Value: 0, Unit: "Metric"}))
+		})
+
+		It("reports no run info bytes", func() {
+			Expect(sender.GetValue("SQLRunInfoTotalBytes")).To(Equal(fake.Metric{Value: 0, Unit: "Metric"}))
+			Expect(sender.GetValue("SQLRunInfoMaxBytes")).To(Equal(fake.Metric{Value: 0, Unit: "Metric"}))
+		})
+
+		It("reports the oldest pending task age as zero", func() {
+			Expect(sender.GetValue("SQLOldestPendingTaskAge")).To(Equal(fake.Metric{Value: 0, Unit: "nanos"}))
+		})
+
+		It("does not report replication lag on a primary", func() {
+			Expect(sender.GetValue("SQLReplicationLag")).To(Equal(fake.Metric{}))
+		})
+	})
+
+	Context("when there is data in the database", func() {
+		BeforeEach(func() {
+			Expect(sqlDB.UpsertDomain(logger, "some-domain", 100)).To(Succeed())
+
+			Expect(sqlDB.DesireLRP(logger, model_helpers.NewValidDesiredLRP("guid-1"))).To(Succeed())
+			Expect(sqlDB.DesireLRP(logger, model_helpers.NewValidDesiredLRP("guid-2"))).To(Succeed())
+
+			taskDef := model_helpers.NewValidTaskDefinition()
+			Expect(sqlDB.DesireTask(logger, taskDef, "old-task", "some-domain")).NotTo(BeNil())
+			fakeClock.Increment(time.Minute)
+			Expect(sqlDB.DesireTask(logger, taskDef, "new-task", "some-domain")).NotTo(BeNil())
+			fakeClock.Increment(time.Minute)
+
+			sqlMetrics.Send()
+		})
+
+		It("reports the row counts", func() {
+			Expect(sender.GetValue("SQLDomainsRows").Value).To(BeEquivalentTo(1))
+			Expect(sender.GetValue("SQLDesiredLRPsRows").Value).To(BeEquivalentTo(2))
+			Expect(sender.GetValue("SQLTasksRows").Value).To(BeEquivalentTo(2))
+		})
+
+		It("reports the size of the run infos", func() {
+			var runInfoLength int
+			err := db.QueryRow(sqldb.RebindForFlavor("SELECT OCTET_LENGTH(run_info) FROM desired_lrps WHERE process_guid = ?", dbFlavor), "guid-1").Scan(&runInfoLength)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sender.GetValue("SQLRunInfoMaxBytes").Value).To(BeNumerically(">=", runInfoLength))
+			Expect(sender.GetValue("SQLRunInfoTotalBytes").Value).To(BeNumerically(">", runInfoLength))
+		})
+
+		It("reports the age of the oldest pending task", func() {
+			Expect(sender.GetValue("SQLOldestPendingTaskAge").Value).To(BeEquivalentTo(2 * time.Minute))
+		})
+
+		Context("when the metrics are sent again", func() {
+			BeforeEach(func() {
+				Expect(sqlDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), "another-task", "some-domain")).NotTo(BeNil())
+			})
+
+			It("does not count the rows again until the table metrics interval has passed", func() {
+				fakeClock.Increment(sqldb.TableMetricsInterval - time.Second)
+				sqlMetrics.Send()
+				Expect(sender.GetValue("SQLTasksRows").Value).To(BeEquivalentTo(2))
+
+				fakeClock.Increment(time.Second)
+				sqlMetrics.Send()
+				Expect(sender.GetValue("SQLTasksRows").Value).To(BeEquivalentTo(3))
+			})
+
+			It("reports the age of the oldest pending task every time", func() {
+				fakeClock.Increment(time.Minute)
+				sqlMetrics.Send()
+				Expect(sender.GetValue("SQLOldestPendingTaskAge").Value).To(BeEquivalentTo(3 * time.Minute))
+			})
+		})
+
+		Context("when the oldest task is no longer pending", func() {
+			BeforeEach(func() {
+				_, _, err := sqlDB.StartTask(logger, "old-task", "some-cell")
+				Expect(err).NotTo(HaveOccurred())
+
+				sqlMetrics.Send()
+			})
+
+			It("reports the age of the oldest task that is still pending", func() {
+				Expect(sender.GetValue("SQLOldestPendingTaskAge").Value).To(BeEquivalentTo(time.Minute))
+			})
+		})
+	})
+})
@@ -31,6 +31,14 @@ const (
 	desiredLRPsTable = "desired_lrps"
 	actualLRPsTable  = "actual_lrps"
 	domainsTable     = "domains"
+	auditTable       = "audit_records"
+
+	taskSchedulesTable    = "task_schedules"
+	taskScheduleRunsTable = "task_schedule_runs"
+	taskCallbacksTable    = "task_callbacks"
+	archivedTasksTable    = "archived_tasks"
+
+	eventOutboxTable = "event_outbox"
 )
 
 var (
@@ -64,6 +72,13 @@ var (
 		tasksTable + ".result",
 		tasksTable + ".failed",
 		tasksTable + ".failure_reason",
+		tasksTable + ".blocked_on",
+		tasksTable + ".attempts",
+		tasksTable + ".callback_dead_lettered",
+		tasksTable + ".callback_failure_reason",
+		tasksTable + ".last_heartbeat_at",
+		tasksTable + ".progress_message",
+		tasksTable + ".progress_percent",
 		tasksTable + ".task_definition",
 	}
 
@@ -84,9 +99,53 @@ var (
 		actualLRPsTable + ".crash_reason",
 	}
 
+	taskScheduleColumns = ColumnList{
+		taskSchedulesTable + ".guid",
+		taskSchedulesTable + ".domain",
+		taskSchedulesTable + ".cron_expression",
+		taskSchedulesTable + ".concurrency_policy",
+		taskSchedulesTable + ".history_limit",
+		taskSchedulesTable + ".created_at",
+		taskSchedulesTable + ".updated_at",
+		taskSchedulesTable + ".last_scheduled_at",
+		taskSchedulesTable + ".task_definition",
+	}
+
+	taskCallbackColumns = ColumnList{
+		taskCallbacksTable + ".task_guid",
+		taskCallbacksTable + ".attempts",
+		taskCallbacksTable + ".enqueued_at",
+		taskCallbacksTable + ".next_attempt_at",
+	}
+
+	archivedTaskColumns = ColumnList{
+		archivedTasksTable + ".guid",
+		archivedTasksTable + ".task",
+	}
+
 	domainColumns = ColumnList{
 		domainsTable + ".domain",
 	}
+
+	auditColumns = ColumnList{
+		auditTable + ".record_index",
+		auditTable + ".recorded_at",
+		auditTable + ".identity",
+		auditTable + ".remote_addr",
+		auditTable + ".route",
+		auditTable + ".target_guid",
+		auditTable + ".changes",
+		auditTable + ".error",
+		auditTable + ".previous_hash",
+		auditTable + ".hash",
+	}
+
+	eventOutboxColumns = ColumnList{
+		eventOutboxTable + ".sequence",
+		eventOutboxTable + ".event_type",
+		eventOutboxTable + ".created_at",
+		eventOutboxTable + ".payload",
+	}
 )
 
 func (db *SQLDB) CreateConfigurationsTable(logger lager.Logger) error {
@@ -344,6 +403,22 @@ func (db *SQLDB) all(logger lager.Logger, q Queryable, table string,
 	return q.Query(db.rebind(query), whereBindings...)
 }
 
+// SELECT <columns> FROM <table> WHERE ... ORDER BY <orderBy> LIMIT <limit>
+func (db *SQLDB) allLimited(logger lager.Logger, q Queryable, table string,
+	columns ColumnList, orderBy string, limit int,
+	wheres string, whereBindings ...interface{},
+) (*sql.Rows, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s\n", strings.Join(columns, ", "), table)
+
+	if len(wheres) > 0 {
+		query += "WHERE " + wheres
+	}
+
+	query += fmt.Sprintf("\nORDER BY %s\nLIMIT %d", orderBy, limit)
+
+	return q.Query(db.rebind(query), whereBindings...)
+}
+
 func (db *SQLDB) upsert(logger lager.Logger, q Queryable, table string, keyAttributes, updateAttributes SQLAttributes) (sql.Result, error) {
 	columns := make([]string, 0, len(keyAttributes)+len(updateAttributes))
 	keyNames := make([]string, 0, len(keyAttributes))
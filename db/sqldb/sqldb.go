@@ -24,6 +24,8 @@ type SQLDB struct {
 	serializer             format.Serializer
 	cryptor                encryption.Cryptor
 	flavor                 string
+	archiveTasks           bool
+	archivedTaskRetention  time.Duration
 }
 
 type RowScanner interface {
@@ -151,6 +151,12 @@ func truncateTables(db *sql.DB) {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(result.RowsAffected()).To(BeEquivalentTo(0))
 	}
+
+	// MySQL restarts the event numbering on truncation, Postgres has to be told
+	if test_helpers.UsePostgres() {
+		_, err := db.Exec("ALTER SEQUENCE event_outbox_sequence_seq RESTART")
+		Expect(err).NotTo(HaveOccurred())
+	}
 }
 
 var truncateTablesSQL = []string{
@@ -159,6 +165,12 @@ var truncateTablesSQL = []string{
 	"TRUNCATE TABLE tasks",
 	"TRUNCATE TABLE desired_lrps",
 	"TRUNCATE TABLE actual_lrps",
+	"TRUNCATE TABLE audit_records",
+	"TRUNCATE TABLE event_outbox",
+	"TRUNCATE TABLE task_schedules",
+	"TRUNCATE TABLE task_schedule_runs",
+	"TRUNCATE TABLE task_callbacks",
+	"TRUNCATE TABLE archived_tasks",
 }
 
 func randStr(strSize int) string {
@@ -0,0 +1,142 @@
+package sqldb
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// EnableTaskArchive makes convergence archive completed tasks in the
+// archived_tasks table when it deletes them, and delete archived tasks that
+// completed longer than the retention ago. It must be called before the
+// database is used.
+func (db *SQLDB) EnableTaskArchive(retention time.Duration) {
+	db.archiveTasks = true
+	db.archivedTaskRetention = retention
+}
+
+func (db *SQLDB) ArchivedTasks(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error) {
+	logger = logger.Session("archived-tasks-sqldb", lager.Data{"filter": filter})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	wheres := []string{}
+	values := []interface{}{}
+
+	if filter.Domain != "" {
+		wheres = append(wheres, "domain = ?")
+		values = append(values, filter.Domain)
+	}
+	if filter.CompletedSince != 0 {
+		wheres = append(wheres, "completed_at >= ?")
+		values = append(values, filter.CompletedSince)
+	}
+	if filter.CompletedUntil != 0 {
+		wheres = append(wheres, "completed_at <= ?")
+		values = append(values, filter.CompletedUntil)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = models.DefaultArchivedTasksLimit
+	}
+
+	rows, err := db.allLimited(logger, db.db, archivedTasksTable,
+		archivedTaskColumns, "completed_at DESC, guid DESC", limit,
+		strings.Join(wheres, " AND "), values...,
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	tasks := []*models.Task{}
+	for rows.Next() {
+		var guid string
+		var taskData []byte
+		err := rows.Scan(&guid, &taskData)
+		if err != nil {
+			logger.Error("failed-reading-row", err)
+			return nil, db.convertSQLError(err)
+		}
+
+		task := &models.Task{}
+		err = db.deserializeModel(logger, taskData, task)
+		if err != nil {
+			logger.Error("failed-deserializing-archived-task", err, lager.Data{"task_guid": guid})
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-fetching-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+		tasks[i], tasks[j] = tasks[j], tasks[i]
+	}
+
+	return tasks, nil
+}
+
+// deleteExpiredArchivedTasks deletes the archived tasks that completed longer
+// than the archive retention ago.
+func (db *SQLDB) deleteExpiredArchivedTasks(logger lager.Logger) {
+	logger = logger.Session("delete-expired-archived-tasks")
+
+	expiredAt := db.clock.Now().Add(-db.archivedTaskRetention).UnixNano()
+	result, err := db.delete(logger, db.db, archivedTasksTable, "completed_at < ?", expiredAt)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("failed-rows-affected", err)
+		return
+	}
+	if rowsAffected > 0 {
+		logger.Info("deleted-expired-archived-tasks", lager.Data{"num_tasks": rowsAffected})
+	}
+}
+
+// archiveTask copies the task into the archive. A task guid that is reused
+// replaces its archived task.
+func (db *SQLDB) archiveTask(logger lager.Logger, tx *sql.Tx, task *models.Task) error {
+	logger = logger.WithData(lager.Data{"task_guid": task.TaskGuid})
+
+	taskData, err := db.serializeModel(logger, task)
+	if err != nil {
+		logger.Error("failed-serializing-task", err)
+		return err
+	}
+
+	_, err = db.delete(logger, tx, archivedTasksTable, "guid = ?", task.TaskGuid)
+	if err != nil {
+		logger.Error("failed-replacing-archived-task", err)
+		return db.convertSQLError(err)
+	}
+
+	_, err = db.insert(logger, tx, archivedTasksTable,
+		SQLAttributes{
+			"guid":         task.TaskGuid,
+			"domain":       task.Domain,
+			"completed_at": task.FirstCompletedAt,
+			"archived_at":  db.clock.Now().UnixNano(),
+			"task":         taskData,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-archiving-task", err)
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
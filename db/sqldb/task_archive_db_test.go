@@ -0,0 +1,187 @@
+package sqldb_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskArchiveDB", func() {
+	const (
+		expireCompletedTaskDuration = time.Hour
+		archivedTaskRetention       = 24 * time.Hour
+	)
+
+	var (
+		archiveDB *sqldb.SQLDB
+		cellSet   models.CellSet
+	)
+
+	BeforeEach(func() {
+		archiveDB = sqldb.NewSQLDB(db, 5, 5, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor)
+		archiveDB.EnableTaskArchive(archivedTaskRetention)
+
+		cellSet = models.NewCellSetFromList([]*models.CellPresence{{CellId: "the-cell"}})
+	})
+
+	completeTask := func(taskGuid, domain string, failed bool, failureReason, result string) {
+		_, err := archiveDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), taskGuid, domain)
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = archiveDB.StartTask(logger, taskGuid, "the-cell")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = archiveDB.CompleteTask(logger, taskGuid, "the-cell", failed, failureReason, result)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	converge := func(db *sqldb.SQLDB) {
+		db.ConvergeTasks(logger, cellSet, time.Minute, time.Hour, expireCompletedTaskDuration)
+	}
+
+	Describe("archiving expired tasks", func() {
+		var completedAt int64
+
+		BeforeEach(func() {
+			completedAt = fakeClock.Now().UnixNano()
+			completeTask("failed-task", "some-domain", true, "it broke", "")
+			completeTask("succeeded-task", "some-domain", false, "", "the result")
+
+			fakeClock.Increment(expireCompletedTaskDuration + time.Second)
+		})
+
+		It("archives the tasks before deleting them", func() {
+			converge(archiveDB)
+
+			_, err := archiveDB.TaskByGuid(logger, "failed-task")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			tasks, err := archiveDB.ArchivedTasks(logger, models.ArchivedTaskFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(HaveLen(2))
+
+			Expect(tasks[0].TaskGuid).To(Equal("failed-task"))
+			Expect(tasks[0].Domain).To(Equal("some-domain"))
+			Expect(tasks[0].Failed).To(BeTrue())
+			Expect(tasks[0].FailureReason).To(Equal("it broke"))
+			Expect(tasks[0].FirstCompletedAt).To(Equal(completedAt))
+			Expect(tasks[0].TaskDefinition).To(Equal(model_helpers.NewValidTaskDefinition()))
+
+			Expect(tasks[1].TaskGuid).To(Equal("succeeded-task"))
+			Expect(tasks[1].Result).To(Equal("the result"))
+		})
+
+		It("replaces the archived task when a task guid is reused", func() {
+			converge(archiveDB)
+
+			completeTask("failed-task", "some-domain", false, "", "the second result")
+			fakeClock.Increment(expireCompletedTaskDuration + time.Second)
+			converge(archiveDB)
+
+			tasks, err := archiveDB.ArchivedTasks(logger, models.ArchivedTaskFilter{Domain: "some-domain"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(HaveLen(2))
+			Expect(tasks[1].TaskGuid).To(Equal("failed-task"))
+			Expect(tasks[1].Result).To(Equal("the second result"))
+		})
+
+		It("deletes archived tasks once the retention has passed", func() {
+			converge(archiveDB)
+
+			fakeClock.Increment(archivedTaskRetention - expireCompletedTaskDuration)
+			completeTask("recent-task", "some-domain", false, "", "")
+			fakeClock.Increment(expireCompletedTaskDuration + time.Second)
+			converge(archiveDB)
+
+			tasks, err := archiveDB.ArchivedTasks(logger, models.ArchivedTaskFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(HaveLen(1))
+			Expect(tasks[0].TaskGuid).To(Equal("recent-task"))
+		})
+
+		Context("when the task archive is not enabled", func() {
+			It("only deletes the tasks", func() {
+				converge(sqlDB)
+
+				_, err := sqlDB.TaskByGuid(logger, "failed-task")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+
+				tasks, err := sqlDB.ArchivedTasks(logger, models.ArchivedTaskFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ArchivedTasks", func() {
+		var firstCompletedAt, secondCompletedAt, thirdCompletedAt int64
+
+		BeforeEach(func() {
+			firstCompletedAt = fakeClock.Now().UnixNano()
+			completeTask("first-task", "domain-a", false, "", "")
+
+			fakeClock.Increment(time.Minute)
+			secondCompletedAt = fakeClock.Now().UnixNano()
+			completeTask("second-task", "domain-b", false, "", "")
+
+			fakeClock.Increment(time.Minute)
+			thirdCompletedAt = fakeClock.Now().UnixNano()
+			completeTask("third-task", "domain-a", false, "", "")
+
+			fakeClock.Increment(expireCompletedTaskDuration + time.Second)
+			converge(archiveDB)
+		})
+
+		archivedGuids := func(filter models.ArchivedTaskFilter) []string {
+			tasks, err := archiveDB.ArchivedTasks(logger, filter)
+			Expect(err).NotTo(HaveOccurred())
+
+			guids := []string{}
+			for _, task := range tasks {
+				guids = append(guids, task.TaskGuid)
+			}
+			return guids
+		}
+
+		It("returns every archived task in the order they completed", func() {
+			Expect(archivedGuids(models.ArchivedTaskFilter{})).To(Equal([]string{"first-task", "second-task", "third-task"}))
+		})
+
+		It("filters by domain", func() {
+			Expect(archivedGuids(models.ArchivedTaskFilter{Domain: "domain-a"})).To(Equal([]string{"first-task", "third-task"}))
+		})
+
+		It("filters by when the tasks completed", func() {
+			filter := models.ArchivedTaskFilter{CompletedSince: secondCompletedAt, CompletedUntil: thirdCompletedAt}
+			Expect(archivedGuids(filter)).To(Equal([]string{"second-task", "third-task"}))
+
+			filter = models.ArchivedTaskFilter{CompletedUntil: firstCompletedAt}
+			Expect(archivedGuids(filter)).To(Equal([]string{"first-task"}))
+		})
+
+		It("returns the most recently completed tasks when limited", func() {
+			Expect(archivedGuids(models.ArchivedTaskFilter{Limit: 2})).To(Equal([]string{"second-task", "third-task"}))
+		})
+
+		Context("when there are more archived tasks than the default limit", func() {
+			BeforeEach(func() {
+				for i := 0; i < models.DefaultArchivedTasksLimit; i++ {
+					completeTask(fmt.Sprintf("later-task-%d", i), "domain-c", false, "", "")
+				}
+				fakeClock.Increment(expireCompletedTaskDuration + time.Second)
+				converge(archiveDB)
+			})
+
+			It("returns at most the default limit", func() {
+				guids := archivedGuids(models.ArchivedTaskFilter{})
+				Expect(guids).To(HaveLen(models.DefaultArchivedTasksLimit))
+				Expect(guids).NotTo(ContainElement("first-task"))
+			})
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package sqldb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *SQLDB) EnqueueTaskCallback(logger lager.Logger, taskGuid string) error {
+	logger = logger.Session("enqueue-task-callback-sqldb", lager.Data{"task_guid": taskGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	now := db.clock.Now().UnixNano()
+	_, err := db.insert(logger, db.db, taskCallbacksTable,
+		SQLAttributes{
+			"task_guid":       taskGuid,
+			"attempts":        0,
+			"enqueued_at":     now,
+			"next_attempt_at": now,
+		},
+	)
+	if err != nil {
+		modelErr := db.convertSQLError(err)
+		if modelErr == models.ErrResourceExists {
+			logger.Debug("task-callback-already-queued")
+			return nil
+		}
+		logger.Error("failed-inserting-task-callback", err)
+		return modelErr
+	}
+
+	return nil
+}
+
+func (db *SQLDB) DueTaskCallbacks(logger lager.Logger, limit int) ([]*models.QueuedTaskCallback, error) {
+	logger = logger.Session("due-task-callbacks-sqldb", lager.Data{"limit": limit})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT %d
+	`, strings.Join(taskCallbackColumns, ", "), taskCallbacksTable, limit)
+
+	rows, err := db.db.Query(db.rebind(query), db.clock.Now().UnixNano())
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	callbacks := []*models.QueuedTaskCallback{}
+	for rows.Next() {
+		callback := &models.QueuedTaskCallback{}
+		err := rows.Scan(&callback.TaskGuid, &callback.Attempts, &callback.EnqueuedAt, &callback.NextAttemptAt)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return nil, db.convertSQLError(err)
+		}
+		callbacks = append(callbacks, callback)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	return callbacks, nil
+}
+
+func (db *SQLDB) RescheduleTaskCallback(logger lager.Logger, taskGuid string, attempts int32, nextAttemptAt int64) error {
+	logger = logger.Session("reschedule-task-callback-sqldb", lager.Data{"task_guid": taskGuid, "attempts": attempts})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	_, err := db.update(logger, db.db, taskCallbacksTable,
+		SQLAttributes{
+			"attempts":        attempts,
+			"next_attempt_at": nextAttemptAt,
+		},
+		"task_guid = ?", taskGuid,
+	)
+	if err != nil {
+		logger.Error("failed-updating-task-callback", err)
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
+
+func (db *SQLDB) RemoveTaskCallback(logger lager.Logger, taskGuid string) error {
+	logger = logger.Session("remove-task-callback-sqldb", lager.Data{"task_guid": taskGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	_, err := db.delete(logger, db.db, taskCallbacksTable, "task_guid = ?", taskGuid)
+	if err != nil {
+		logger.Error("failed-deleting-task-callback", err)
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
+
+func (db *SQLDB) TaskCallbackQueueDepth(logger lager.Logger) (int, error) {
+	logger = logger.Session("task-callback-queue-depth-sqldb")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var depth int
+	err := db.one(logger, db.db, taskCallbacksTable,
+		ColumnList{"COUNT(*)"}, NoLockRow,
+		"",
+	).Scan(&depth)
+	if err != nil {
+		logger.Error("failed-counting-task-callbacks", err)
+		return 0, db.convertSQLError(err)
+	}
+
+	return depth, nil
+}
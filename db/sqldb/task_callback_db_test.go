@@ -0,0 +1,101 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCallbackDB", func() {
+	Describe("EnqueueTaskCallback", func() {
+		It("queues the callback to be attempted now", func() {
+			Expect(sqlDB.EnqueueTaskCallback(logger, "some-task-guid")).To(Succeed())
+
+			callbacks, err := sqlDB.DueTaskCallbacks(logger, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(callbacks).To(ConsistOf(&models.QueuedTaskCallback{
+				TaskGuid:      "some-task-guid",
+				Attempts:      0,
+				EnqueuedAt:    fakeClock.Now().UnixNano(),
+				NextAttemptAt: fakeClock.Now().UnixNano(),
+			}))
+		})
+
+		Context("when the callback is already queued", func() {
+			BeforeEach(func() {
+				Expect(sqlDB.EnqueueTaskCallback(logger, "some-task-guid")).To(Succeed())
+				Expect(sqlDB.RescheduleTaskCallback(logger, "some-task-guid", 2, fakeClock.Now().Add(time.Minute).UnixNano())).To(Succeed())
+			})
+
+			It("leaves the queued callback alone", func() {
+				Expect(sqlDB.EnqueueTaskCallback(logger, "some-task-guid")).To(Succeed())
+
+				depth, err := sqlDB.TaskCallbackQueueDepth(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(depth).To(Equal(1))
+
+				callbacks, err := sqlDB.DueTaskCallbacks(logger, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(callbacks).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DueTaskCallbacks", func() {
+		BeforeEach(func() {
+			Expect(sqlDB.EnqueueTaskCallback(logger, "task-1")).To(Succeed())
+			fakeClock.Increment(time.Second)
+			Expect(sqlDB.EnqueueTaskCallback(logger, "task-2")).To(Succeed())
+			Expect(sqlDB.EnqueueTaskCallback(logger, "task-3")).To(Succeed())
+			Expect(sqlDB.RescheduleTaskCallback(logger, "task-3", 1, fakeClock.Now().Add(time.Minute).UnixNano())).To(Succeed())
+		})
+
+		It("returns the due callbacks, the longest overdue first", func() {
+			callbacks, err := sqlDB.DueTaskCallbacks(logger, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(callbacks).To(HaveLen(2))
+			Expect(callbacks[0].TaskGuid).To(Equal("task-1"))
+			Expect(callbacks[1].TaskGuid).To(Equal("task-2"))
+		})
+
+		It("returns up to the limit", func() {
+			callbacks, err := sqlDB.DueTaskCallbacks(logger, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(callbacks).To(HaveLen(1))
+			Expect(callbacks[0].TaskGuid).To(Equal("task-1"))
+		})
+
+		It("returns rescheduled callbacks once they are due", func() {
+			fakeClock.Increment(time.Minute)
+
+			callbacks, err := sqlDB.DueTaskCallbacks(logger, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(callbacks).To(HaveLen(3))
+			Expect(callbacks[2].TaskGuid).To(Equal("task-3"))
+			Expect(callbacks[2].Attempts).To(BeEquivalentTo(1))
+		})
+	})
+
+	Describe("RemoveTaskCallback", func() {
+		BeforeEach(func() {
+			Expect(sqlDB.EnqueueTaskCallback(logger, "task-1")).To(Succeed())
+			Expect(sqlDB.EnqueueTaskCallback(logger, "task-2")).To(Succeed())
+		})
+
+		It("removes the callback from the queue", func() {
+			Expect(sqlDB.RemoveTaskCallback(logger, "task-1")).To(Succeed())
+
+			depth, err := sqlDB.TaskCallbackQueueDepth(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(depth).To(Equal(1))
+
+			callbacks, err := sqlDB.DueTaskCallbacks(logger, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(callbacks).To(HaveLen(1))
+			Expect(callbacks[0].TaskGuid).To(Equal("task-2"))
+		})
+	})
+})
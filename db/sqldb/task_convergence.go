@@ -1,10 +1,12 @@
 package sqldb
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/cloudfoundry-incubator/auctioneer"
+	bbsdb "github.com/cloudfoundry-incubator/bbs/db"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/runtime-schema/metric"
 	"github.com/pivotal-golang/lager"
@@ -23,7 +25,7 @@ const (
 	resolvingTasks = metric.Metric("TasksResolving")
 )
 
-func (db *SQLDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) ([]*auctioneer.TaskStartRequest, []*models.Task) {
+func (db *SQLDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) bbsdb.TaskConvergenceResult {
 	logger.Info("starting")
 	defer logger.Info("completed")
 
@@ -39,26 +41,68 @@ func (db *SQLDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kick
 
 	var tasksPruned, tasksKicked uint64
 
-	rowsAffected := db.failExpiredPendingTasks(logger, expirePendingTaskDuration)
-	tasksKicked += uint64(rowsAffected)
+	changes := []*models.TaskChange{}
+	retriedTasks := []*models.Task{}
+	tasksToCancel := []*models.Task{}
+	failedTasksToComplete := []*models.Task{}
+
+	deadlineFailures := db.failTasksPastDeadline(logger)
+	changes = append(changes, deadlineFailures...)
+	tasksKicked += uint64(len(deadlineFailures))
+	for _, change := range deadlineFailures {
+		if change.Before.State == models.Task_Running {
+			tasksToCancel = append(tasksToCancel, change.Before)
+		}
+
+		if change.After.State == models.Task_Pending {
+			retriedTasks = append(retriedTasks, change.After)
+		} else if change.After.CompletionCallbackUrl != "" {
+			failedTasksToComplete = append(failedTasksToComplete, change.After)
+		}
+	}
+
+	expiredPendingChanges := db.failExpiredPendingTasks(logger, expirePendingTaskDuration)
+	changes = append(changes, expiredPendingChanges...)
+	tasksKicked += uint64(len(expiredPendingChanges))
+	retriedTasks = append(retriedTasks, retriedTasksOf(expiredPendingChanges)...)
 
-	tasksToAuction, failedFetches := db.getTaskStartRequestsForKickablePendingTasks(logger, kickTasksDuration, expirePendingTaskDuration)
+	kickedTasks, failedFetches := db.getKickablePendingTasks(logger, kickTasksDuration)
 	tasksPruned += failedFetches
-	tasksKicked += uint64(len(tasksToAuction))
+	tasksKicked += uint64(len(kickedTasks))
 
-	rowsAffected = db.failTasksWithDisappearedCells(logger, cellSet)
-	tasksKicked += uint64(rowsAffected)
+	releasedChanges, releasedTasks := db.releaseBlockedTasks(logger)
+	changes = append(changes, releasedChanges...)
+	kickedTasks = append(kickedTasks, releasedTasks...)
+	tasksKicked += uint64(len(releasedTasks))
+
+	disappearedCellChanges := db.failTasksWithDisappearedCells(logger, cellSet)
+	changes = append(changes, disappearedCellChanges...)
+	tasksKicked += uint64(len(disappearedCellChanges))
+
+	retriedTasks = append(retriedTasks, retriedTasksOf(disappearedCellChanges)...)
+	kickedTasks = append(kickedTasks, db.retriedTasksToAuction(retriedTasks)...)
+
+	// the auctioneer takes start requests in the order they are sent, so sort
+	// them all together for the highest priority tasks to be placed first
+	models.SortTasksByPriority(kickedTasks)
+	tasksToAuction := taskStartRequestsFor(kickedTasks)
 
 	// do this first so that we now have "Completed" tasks before cleaning up
 	// or re-sending the completion callback
-	db.demoteKickableResolvingTasks(logger, kickTasksDuration)
+	changes = append(changes, db.demoteKickableResolvingTasks(logger, kickTasksDuration)...)
 
-	rowsAffected = db.deleteExpiredCompletedTasks(logger, expireCompletedTaskDuration)
-	tasksPruned += uint64(rowsAffected)
+	expiredCompletedChanges := db.deleteExpiredCompletedTasks(logger, expireCompletedTaskDuration)
+	changes = append(changes, expiredCompletedChanges...)
+	tasksPruned += uint64(len(expiredCompletedChanges))
+
+	if db.archiveTasks && db.archivedTaskRetention > 0 {
+		db.deleteExpiredArchivedTasks(logger)
+	}
 
 	tasksToComplete, failedFetches := db.getKickableCompleteTasksForCompletion(logger, kickTasksDuration)
 	tasksPruned += failedFetches
 	tasksKicked += uint64(len(tasksToComplete))
+	tasksToComplete = append(tasksToComplete, failedTasksToComplete...)
 
 	pendingCount, runningCount, completedCount, resolvingCount := db.countTasksByState(logger.Session("count-tasks"), db.db)
 
@@ -67,44 +111,95 @@ func (db *SQLDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kick
 	tasksKickedCounter.Add(tasksKicked)
 	tasksPrunedCounter.Add(tasksPruned)
 
-	return tasksToAuction, tasksToComplete
+	return bbsdb.TaskConvergenceResult{
+		TasksToAuction:  tasksToAuction,
+		TasksToComplete: tasksToComplete,
+		TasksToCancel:   tasksToCancel,
+		Changes:         changes,
+	}
 }
 
-func (db *SQLDB) failExpiredPendingTasks(logger lager.Logger, expirePendingTaskDuration time.Duration) int64 {
+// failTasksPastDeadline fails the tasks that have been pending, or running,
+// for longer than their definitions allow. Pending tasks are timed from when
+// they last became pending, blocked tasks from when they were desired, and
+// running tasks from when they started. Running tasks also fail once their
+// heartbeat timeout passes without a heartbeat since they started.
+func (db *SQLDB) failTasksPastDeadline(logger lager.Logger) []*models.TaskChange {
+	logger = logger.Session("fail-tasks-past-deadline")
+
+	now := db.clock.Now().UnixNano()
+	deadlines := []struct {
+		failureReason string
+		wheres        string
+		whereBindings []interface{}
+	}{
+		{
+			models.MaxPendingTimeExceededReason,
+			"max_pending_time > 0 AND ((state = ? AND updated_at <= ? - max_pending_time) OR (state = ? AND created_at <= ? - max_pending_time))",
+			[]interface{}{models.Task_Pending, now, models.Task_Blocked, now},
+		},
+		{
+			models.MaxRunTimeExceededReason,
+			"max_run_time > 0 AND state = ? AND updated_at <= ? - max_run_time",
+			[]interface{}{models.Task_Running, now},
+		},
+		{
+			models.HeartbeatTimeoutReason,
+			"heartbeat_timeout > 0 AND state = ? AND updated_at <= ? - heartbeat_timeout AND last_heartbeat_at <= ? - heartbeat_timeout",
+			[]interface{}{models.Task_Running, now, now},
+		},
+	}
+
+	changes := []*models.TaskChange{}
+	for _, deadline := range deadlines {
+		failed, err := db.failTasks(logger, deadline.failureReason, deadline.wheres, deadline.whereBindings...)
+		if err != nil {
+			logger.Error("failed-query", err, lager.Data{"failure_reason": deadline.failureReason})
+			continue
+		}
+		changes = append(changes, failed...)
+	}
+
+	return changes
+}
+
+// failExpiredPendingTasks fails tasks that have been pending, or blocked, for
+// too long. Pending tasks are timed from when they last became pending, and
+// retried tasks from when their backoff ends, so that retried tasks get a
+// fresh window to start in.
+func (db *SQLDB) failExpiredPendingTasks(logger lager.Logger, expirePendingTaskDuration time.Duration) []*models.TaskChange {
 	logger = logger.Session("fail-expired-pending-tasks")
 
-	now := db.clock.Now()
+	expiredAt := db.clock.Now().Add(-expirePendingTaskDuration).UnixNano()
 
-	result, err := db.update(logger, db.db, tasksTable,
-		SQLAttributes{
-			"failed":             true,
-			"failure_reason":     "not started within time limit",
-			"result":             "",
-			"state":              models.Task_Completed,
-			"first_completed_at": now.UnixNano(),
-			"updated_at":         now.UnixNano(),
-		},
-		"state = ? AND created_at < ?", models.Task_Pending, now.Add(-expirePendingTaskDuration).UnixNano())
-	if err != nil {
-		logger.Error("failed-query", err)
-		return 0
+	// the end of the backoff is not stored in a column
+	expired := func(task *models.Task) bool {
+		return task.State != models.Task_Pending || task.RetryBackoffEndsAt() < expiredAt
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	changes, err := db.failMatchingTasks(logger, "not started within time limit", expired,
+		"(state = ? AND updated_at < ?) OR (state = ? AND created_at < ?)",
+		models.Task_Pending, expiredAt, models.Task_Blocked, expiredAt,
+	)
 	if err != nil {
-		logger.Error("failed-rows-affected", err)
-		return 0
+		logger.Error("failed-query", err)
+		return nil
 	}
-	return rowsAffected
+
+	return changes
 }
 
-func (db *SQLDB) getTaskStartRequestsForKickablePendingTasks(logger lager.Logger, kickTasksDuration, expirePendingTaskDuration time.Duration) ([]*auctioneer.TaskStartRequest, uint64) {
-	logger = logger.Session("get-task-start-requests-for-kickable-pending-tasks")
+// getKickablePendingTasks returns the pending tasks that have gone kickTasksDuration without starting and are not
+// waiting out a retry backoff. It runs after the expired pending tasks have
+// been failed, so the tasks left are those still within their time limit.
+func (db *SQLDB) getKickablePendingTasks(logger lager.Logger, kickTasksDuration time.Duration) ([]*models.Task, uint64) {
+	logger = logger.Session("get-kickable-pending-tasks")
 
+	now := db.clock.Now().UnixNano()
 	rows, err := db.all(logger, db.db, tasksTable,
 		taskColumns, NoLockRow,
-		"state = ? AND updated_at < ? AND created_at > ?",
-		models.Task_Pending, db.clock.Now().Add(-kickTasksDuration).UnixNano(), db.clock.Now().Add(-expirePendingTaskDuration).UnixNano(),
+		"state = ? AND updated_at < ?",
+		models.Task_Pending, db.clock.Now().Add(-kickTasksDuration).UnixNano(),
 	)
 	if err != nil {
 		logger.Error("failed-query", err)
@@ -112,7 +207,7 @@ func (db *SQLDB) getTaskStartRequestsForKickablePendingTasks(logger lager.Logger
 	defer rows.Close()
 
 	var failedFetches uint64
-	tasksToAuction := []*auctioneer.TaskStartRequest{}
+	tasks := []*models.Task{}
 	for rows.Next() {
 		task, err := db.fetchTask(logger, rows, db.db)
 		if err != nil {
@@ -120,9 +215,8 @@ func (db *SQLDB) getTaskStartRequestsForKickablePendingTasks(logger lager.Logger
 			if err == models.ErrDeserialize {
 				failedFetches++
 			}
-		} else {
-			taskStartRequest := auctioneer.NewTaskStartRequestFromModel(task.TaskGuid, task.Domain, task.TaskDefinition)
-			tasksToAuction = append(tasksToAuction, &taskStartRequest)
+		} else if task.RetryBackoffElapsed(now) {
+			tasks = append(tasks, task)
 		}
 	}
 
@@ -130,10 +224,68 @@ func (db *SQLDB) getTaskStartRequestsForKickablePendingTasks(logger lager.Logger
 		logger.Error("failed-getting-next-row", rows.Err())
 	}
 
-	return tasksToAuction, failedFetches
+	return tasks, failedFetches
 }
 
-func (db *SQLDB) failTasksWithDisappearedCells(logger lager.Logger, cellSet models.CellSet) int64 {
+// releaseBlockedTasks resolves blocked tasks whose dependencies have already
+// completed, in case the release was missed when they completed. It returns
+// how it changed the tasks, and the tasks it unblocked.
+func (db *SQLDB) releaseBlockedTasks(logger lager.Logger) ([]*models.TaskChange, []*models.Task) {
+	logger = logger.Session("release-blocked-tasks")
+
+	rows, err := db.all(logger, db.db, tasksTable,
+		ColumnList{"blocked_on"}, NoLockRow,
+		"state = ?", models.Task_Blocked,
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, nil
+	}
+
+	dependencyGuids := []string{}
+	seen := map[string]struct{}{}
+	for rows.Next() {
+		var blockedOn sql.NullString
+		err := rows.Scan(&blockedOn)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			continue
+		}
+		for _, guid := range decodeBlockedOn(blockedOn.String) {
+			if _, ok := seen[guid]; !ok {
+				seen[guid] = struct{}{}
+				dependencyGuids = append(dependencyGuids, guid)
+			}
+		}
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+	}
+	rows.Close()
+
+	releasedChanges := []*models.TaskChange{}
+	tasks := []*models.Task{}
+	for _, guid := range dependencyGuids {
+		changes, err := db.ReleaseDependentTasks(logger, guid)
+		if err != nil {
+			if err != models.ErrResourceNotFound {
+				logger.Error("failed-releasing-dependent-tasks", err, lager.Data{"task_guid": guid})
+			}
+			continue
+		}
+		releasedChanges = append(releasedChanges, changes...)
+		for _, change := range changes {
+			if change.After.State == models.Task_Pending {
+				tasks = append(tasks, change.After)
+			}
+		}
+	}
+
+	return releasedChanges, tasks
+}
+
+func (db *SQLDB) failTasksWithDisappearedCells(logger lager.Logger, cellSet models.CellSet) []*models.TaskChange {
 	logger = logger.Session("fail-tasks-with-disappeared-cells")
 
 	values := make([]interface{}, 0, 1+len(cellSet))
@@ -147,70 +299,60 @@ func (db *SQLDB) failTasksWithDisappearedCells(logger lager.Logger, cellSet mode
 	if len(cellSet) != 0 {
 		wheres += fmt.Sprintf(" AND cell_id NOT IN (%s)", questionMarks(len(cellSet)))
 	}
-	now := db.clock.Now().UnixNano()
 
-	result, err := db.update(logger, db.db, tasksTable,
-		SQLAttributes{
-			"failed":             true,
-			"failure_reason":     "cell disappeared before completion",
-			"result":             "",
-			"state":              models.Task_Completed,
-			"first_completed_at": now,
-			"updated_at":         now,
-		},
-		wheres, values...,
-	)
+	changes, err := db.failTasks(logger, "cell disappeared before completion", wheres, values...)
 	if err != nil {
 		logger.Error("failed-updating-tasks", err)
-		return 0
+		return nil
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		logger.Error("failed-rows-affected", err)
-		return 0
-	}
-
-	return rowsAffected
+	return changes
 }
 
-func (db *SQLDB) demoteKickableResolvingTasks(logger lager.Logger, kickTasksDuration time.Duration) {
+func (db *SQLDB) demoteKickableResolvingTasks(logger lager.Logger, kickTasksDuration time.Duration) []*models.TaskChange {
 	logger = logger.Session("demote-kickable-resolving-tasks")
-	_, err := db.update(logger, db.db, tasksTable,
+	changes, err := db.updateTasks(logger,
 		SQLAttributes{"state": models.Task_Completed},
 		"state = ? AND updated_at < ?",
 		models.Task_Resolving, db.clock.Now().Add(-kickTasksDuration).UnixNano(),
 	)
 	if err != nil {
 		logger.Error("failed-updating-tasks", err)
+		return nil
 	}
+
+	return changes
 }
 
-func (db *SQLDB) deleteExpiredCompletedTasks(logger lager.Logger, expireCompletedTaskDuration time.Duration) int64 {
+// deleteExpiredCompletedTasks deletes completed tasks once their retention
+// period has passed, which is the given expiry unless the task has its own.
+// When the task archive is enabled they are archived in the same transaction.
+func (db *SQLDB) deleteExpiredCompletedTasks(logger lager.Logger, expireCompletedTaskDuration time.Duration) []*models.TaskChange {
 	logger = logger.Session("delete-expired-completed-tasks")
 
-	result, err := db.delete(logger, db.db, tasksTable, "state = ? AND first_completed_at < ?", models.Task_Completed, db.clock.Now().Add(-expireCompletedTaskDuration).UnixNano())
+	now := db.clock.Now()
+	changes, err := db.deleteTasks(logger,
+		"state = ? AND ((completed_retention = 0 AND first_completed_at < ?) OR (completed_retention > 0 AND first_completed_at + completed_retention < ?))",
+		models.Task_Completed, now.Add(-expireCompletedTaskDuration).UnixNano(), now.UnixNano(),
+	)
 	if err != nil {
 		logger.Error("failed-query", err)
-		return 0
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		logger.Error("failed-rows-affected", err)
-		return 0
+		return nil
 	}
 
-	return rowsAffected
+	return changes
 }
 
+// getKickableCompleteTasksForCompletion returns the completed tasks to retry
+// the completion of. Tasks whose callbacks were dead-lettered are left to
+// expire.
 func (db *SQLDB) getKickableCompleteTasksForCompletion(logger lager.Logger, kickTasksDuration time.Duration) ([]*models.Task, uint64) {
 	logger = logger.Session("get-kickable-complete-tasks-for-completion")
 
 	rows, err := db.all(logger, db.db, tasksTable,
 		taskColumns, NoLockRow,
-		"state = ? AND updated_at < ?",
-		models.Task_Completed, db.clock.Now().Add(-kickTasksDuration).UnixNano(),
+		"state = ? AND updated_at < ? AND callback_dead_lettered = ?",
+		models.Task_Completed, db.clock.Now().Add(-kickTasksDuration).UnixNano(), false,
 	)
 	if err != nil {
 		logger.Error("failed-query", err)
@@ -238,6 +380,205 @@ func (db *SQLDB) getKickableCompleteTasksForCompletion(logger lager.Logger, kick
 	return tasksToComplete, failedFetches
 }
 
+// retriedTasksToAuction returns the retried tasks that can be auctioned right
+// away. Tasks with a retry backoff are left for a later convergence to kick.
+func (db *SQLDB) retriedTasksToAuction(tasks []*models.Task) []*models.Task {
+	now := db.clock.Now().UnixNano()
+
+	tasksToAuction := []*models.Task{}
+	for _, task := range tasks {
+		if task.RetryBackoffElapsed(now) {
+			tasksToAuction = append(tasksToAuction, task)
+		}
+	}
+
+	return tasksToAuction
+}
+
+func taskStartRequestsFor(tasks []*models.Task) []*auctioneer.TaskStartRequest {
+	taskStartRequests := make([]*auctioneer.TaskStartRequest, 0, len(tasks))
+	for _, task := range tasks {
+		taskStartRequest := auctioneer.NewTaskStartRequestFromModel(task.TaskGuid, task.Domain, task.TaskDefinition)
+		taskStartRequests = append(taskStartRequests, &taskStartRequest)
+	}
+	return taskStartRequests
+}
+
+// failTasks fails every task matching the wheres in a single transaction,
+// unless its retry policy allows another attempt. It returns how each task
+// it failed, or moved back to pending to be retried, changed.
+func (db *SQLDB) failTasks(logger lager.Logger, failureReason string, wheres string, whereBindings ...interface{}) ([]*models.TaskChange, error) {
+	return db.failMatchingTasks(logger, failureReason, nil, wheres, whereBindings...)
+}
+
+// failMatchingTasks is failTasks for conditions that cannot all be checked in
+// SQL: of the tasks matching the wheres, it only fails those that matches
+// returns true for. A nil matches fails them all.
+func (db *SQLDB) failMatchingTasks(logger lager.Logger, failureReason string, matches func(*models.Task) bool, wheres string, whereBindings ...interface{}) ([]*models.TaskChange, error) {
+	var changes []*models.TaskChange
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		changes = []*models.TaskChange{}
+
+		guids, err := db.lockTaskGuids(logger, tx, wheres, whereBindings...)
+		if err != nil {
+			return err
+		}
+
+		for _, guid := range guids {
+			task, err := db.fetchTaskForUpdate(logger, guid, tx)
+			if err != nil {
+				logger.Error("failed-fetching-task", err, lager.Data{"task_guid": guid})
+				continue
+			}
+			if matches != nil && !matches(task) {
+				continue
+			}
+
+			before := *task
+			err = db.failTask(logger, task, failureReason, tx)
+			if err != nil {
+				return err
+			}
+
+			changes = append(changes, &models.TaskChange{Before: &before, After: task})
+		}
+
+		return nil
+	})
+
+	return changes, err
+}
+
+// retriedTasksOf returns the tasks the changes moved back to pending.
+func retriedTasksOf(changes []*models.TaskChange) []*models.Task {
+	tasks := []*models.Task{}
+	for _, change := range changes {
+		if change.After.State == models.Task_Pending {
+			tasks = append(tasks, change.After)
+		}
+	}
+	return tasks
+}
+
+// updateTasks applies the updates to every task matching the wheres in a
+// single transaction, recording a changed event for each task it updates. It
+// returns how each task changed.
+func (db *SQLDB) updateTasks(logger lager.Logger, updates SQLAttributes, wheres string, whereBindings ...interface{}) ([]*models.TaskChange, error) {
+	var changes []*models.TaskChange
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		changes = []*models.TaskChange{}
+
+		guids, err := db.lockTaskGuids(logger, tx, wheres, whereBindings...)
+		if err != nil {
+			return err
+		}
+
+		events := []models.Event{}
+		for _, guid := range guids {
+			before, err := db.fetchTaskForUpdate(logger, guid, tx)
+			if err != nil {
+				logger.Error("failed-fetching-task", err, lager.Data{"task_guid": guid})
+				continue
+			}
+
+			_, err = db.update(logger, tx, tasksTable, updates, "guid = ?", guid)
+			if err != nil {
+				return db.convertSQLError(err)
+			}
+
+			after, err := db.fetchTaskForUpdate(logger, guid, tx)
+			if err != nil {
+				return err
+			}
+
+			events = append(events, models.NewTaskChangedEvent(before, after))
+			changes = append(changes, &models.TaskChange{Before: before, After: after})
+		}
+
+		return db.appendEvents(logger, tx, events...)
+	})
+
+	return changes, err
+}
+
+// deleteTasks deletes every task matching the wheres in a single
+// transaction, recording a removed event for each task it deletes. It
+// returns a change with no After for each task it deleted.
+func (db *SQLDB) deleteTasks(logger lager.Logger, wheres string, whereBindings ...interface{}) ([]*models.TaskChange, error) {
+	var changes []*models.TaskChange
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		changes = []*models.TaskChange{}
+
+		guids, err := db.lockTaskGuids(logger, tx, wheres, whereBindings...)
+		if err != nil {
+			return err
+		}
+
+		events := []models.Event{}
+		for _, guid := range guids {
+			task, err := db.fetchTaskForUpdate(logger, guid, tx)
+			if err != nil {
+				logger.Error("failed-fetching-task", err, lager.Data{"task_guid": guid})
+				continue
+			}
+
+			if db.archiveTasks {
+				err = db.archiveTask(logger, tx, task)
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = db.delete(logger, tx, tasksTable, "guid = ?", guid)
+			if err != nil {
+				return db.convertSQLError(err)
+			}
+
+			events = append(events, models.NewTaskRemovedEvent(task))
+			changes = append(changes, &models.TaskChange{Before: task})
+		}
+
+		return db.appendEvents(logger, tx, events...)
+	})
+
+	return changes, err
+}
+
+// lockTaskGuids returns the guids of the tasks matching the wheres, locking
+// their rows until the transaction ends.
+func (db *SQLDB) lockTaskGuids(logger lager.Logger, tx *sql.Tx, wheres string, whereBindings ...interface{}) ([]string, error) {
+	rows, err := db.all(logger, tx, tasksTable,
+		ColumnList{"guid"}, LockRow,
+		wheres, whereBindings...,
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	guids := []string{}
+	for rows.Next() {
+		var guid string
+		err := rows.Scan(&guid)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return nil, db.convertSQLError(err)
+		}
+		guids = append(guids, guid)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	return guids, nil
+}
+
 func sendTaskMetrics(logger lager.Logger, pendingCount, runningCount, completedCount, resolvingCount int) {
 	err := pendingTasks.Send(pendingCount)
 	if err != nil {
@@ -38,6 +38,8 @@ var _ = Describe("Convergence of Tasks", func() {
 			domain          string
 			tasksToAuction  []*auctioneer.TaskStartRequest
 			tasksToComplete []*models.Task
+			tasksToCancel   []*models.Task
+			changes         []*models.TaskChange
 			cellSet         models.CellSet
 
 			taskDef *models.TaskDefinition
@@ -52,57 +54,57 @@ var _ = Describe("Convergence of Tasks", func() {
 			taskDef = model_helpers.NewValidTaskDefinition()
 
 			fakeClock.IncrementBySeconds(-expirePendingTaskDurationInSeconds)
-			err = sqlDB.DesireTask(logger, taskDef, "pending-expired-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "pending-expired-task", domain)
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.IncrementBySeconds(expirePendingTaskDurationInSeconds)
 
 			fakeClock.IncrementBySeconds(-kickTasksDurationInSeconds)
-			err = sqlDB.DesireTask(logger, taskDef, "pending-kickable-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "pending-kickable-task", domain)
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.IncrementBySeconds(kickTasksDurationInSeconds)
 
 			fakeClock.IncrementBySeconds(-kickTasksDurationInSeconds)
-			err = sqlDB.DesireTask(logger, taskDef, "pending-kickable-invalid-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "pending-kickable-invalid-task", domain)
 			Expect(err).NotTo(HaveOccurred())
 			_, err = db.Exec("UPDATE tasks SET task_definition = 'garbage' WHERE guid = 'pending-kickable-invalid-task'")
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.IncrementBySeconds(kickTasksDurationInSeconds)
 
-			err = sqlDB.DesireTask(logger, taskDef, "pending-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "pending-task", domain)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = sqlDB.DesireTask(logger, taskDef, "running-task-no-cell", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "running-task-no-cell", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "running-task-no-cell", "non-existant-cell")
+			_, _, err = sqlDB.StartTask(logger, "running-task-no-cell", "non-existant-cell")
 			Expect(err).NotTo(HaveOccurred())
 
-			err = sqlDB.DesireTask(logger, taskDef, "running-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "running-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "running-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "running-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 
 			fakeClock.Increment(-expireCompletedTaskDuration)
-			err = sqlDB.DesireTask(logger, taskDef, "completed-expired-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "completed-expired-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "completed-expired-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "completed-expired-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 			_, err = sqlDB.CompleteTask(logger, "completed-expired-task", "existing-cell", false, "", "")
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.Increment(expireCompletedTaskDuration)
 
 			fakeClock.IncrementBySeconds(-kickTasksDurationInSeconds)
-			err = sqlDB.DesireTask(logger, taskDef, "completed-kickable-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "completed-kickable-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "completed-kickable-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "completed-kickable-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 			_, err = sqlDB.CompleteTask(logger, "completed-kickable-task", "existing-cell", false, "", "")
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.IncrementBySeconds(kickTasksDurationInSeconds)
 
 			fakeClock.IncrementBySeconds(-kickTasksDurationInSeconds)
-			err = sqlDB.DesireTask(logger, taskDef, "completed-kickable-invalid-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "completed-kickable-invalid-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "completed-kickable-invalid-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "completed-kickable-invalid-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 			_, err = sqlDB.CompleteTask(logger, "completed-kickable-invalid-task", "existing-cell", false, "", "")
 			Expect(err).NotTo(HaveOccurred())
@@ -110,49 +112,51 @@ var _ = Describe("Convergence of Tasks", func() {
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.IncrementBySeconds(kickTasksDurationInSeconds)
 
-			err = sqlDB.DesireTask(logger, taskDef, "completed-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "completed-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "completed-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "completed-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 			_, err = sqlDB.CompleteTask(logger, "completed-task", "existing-cell", false, "", "")
 			Expect(err).NotTo(HaveOccurred())
 
 			fakeClock.Increment(-expireCompletedTaskDuration)
-			err = sqlDB.DesireTask(logger, taskDef, "resolving-expired-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "resolving-expired-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "resolving-expired-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "resolving-expired-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 			_, err = sqlDB.CompleteTask(logger, "resolving-expired-task", "existing-cell", false, "", "")
 			Expect(err).NotTo(HaveOccurred())
-			err = sqlDB.ResolvingTask(logger, "resolving-expired-task")
+			_, err = sqlDB.ResolvingTask(logger, "resolving-expired-task")
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.Increment(expireCompletedTaskDuration)
 
 			fakeClock.IncrementBySeconds(-kickTasksDurationInSeconds)
-			err = sqlDB.DesireTask(logger, taskDef, "resolving-kickable-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "resolving-kickable-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "resolving-kickable-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "resolving-kickable-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 			_, err = sqlDB.CompleteTask(logger, "resolving-kickable-task", "existing-cell", false, "", "")
 			Expect(err).NotTo(HaveOccurred())
-			err = sqlDB.ResolvingTask(logger, "resolving-kickable-task")
+			_, err = sqlDB.ResolvingTask(logger, "resolving-kickable-task")
 			Expect(err).NotTo(HaveOccurred())
 			fakeClock.IncrementBySeconds(kickTasksDurationInSeconds)
 
-			err = sqlDB.DesireTask(logger, taskDef, "resolving-task", domain)
+			_, err = sqlDB.DesireTask(logger, taskDef, "resolving-task", domain)
 			Expect(err).NotTo(HaveOccurred())
-			_, err = sqlDB.StartTask(logger, "resolving-task", "existing-cell")
+			_, _, err = sqlDB.StartTask(logger, "resolving-task", "existing-cell")
 			Expect(err).NotTo(HaveOccurred())
 			_, err = sqlDB.CompleteTask(logger, "resolving-task", "existing-cell", false, "", "")
 			Expect(err).NotTo(HaveOccurred())
-			err = sqlDB.ResolvingTask(logger, "resolving-task")
+			_, err = sqlDB.ResolvingTask(logger, "resolving-task")
 			Expect(err).NotTo(HaveOccurred())
 
 			fakeClock.IncrementBySeconds(1)
 		})
 
 		JustBeforeEach(func() {
-			tasksToAuction, tasksToComplete = sqlDB.ConvergeTasks(logger, cellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			result := sqlDB.ConvergeTasks(logger, cellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			tasksToAuction, tasksToComplete, tasksToCancel = result.TasksToAuction, result.TasksToComplete, result.TasksToCancel
+			changes = result.Changes
 		})
 
 		It("bumps the convergence counter", func() {
@@ -205,6 +209,54 @@ var _ = Describe("Convergence of Tasks", func() {
 				Expect(err).To(Equal(models.ErrResourceNotFound))
 			})
 
+			Context("when kicked tasks have priorities", func() {
+				BeforeEach(func() {
+					fakeClock.IncrementBySeconds(-kickTasksDurationInSeconds - 1)
+					urgentTaskDef := model_helpers.NewValidTaskDefinition()
+					urgentTaskDef.Priority = 90
+					_, err := sqlDB.DesireTask(logger, urgentTaskDef, "pending-kickable-urgent-task", domain)
+					Expect(err).NotTo(HaveOccurred())
+					fakeClock.IncrementBySeconds(kickTasksDurationInSeconds + 1)
+				})
+
+				It("returns them highest priority first, then oldest first", func() {
+					guids := []string{}
+					for _, taskRequest := range tasksToAuction {
+						guids = append(guids, taskRequest.TaskGuid)
+					}
+					Expect(guids).To(Equal([]string{"pending-kickable-urgent-task", "pending-kickable-task"}))
+				})
+			})
+
+			Context("when a retried task is still backing off", func() {
+				BeforeEach(func() {
+					retriedTaskDef := model_helpers.NewValidTaskDefinition()
+					retriedTaskDef.RetryPolicy = &models.RetryPolicy{
+						MaxAttempts:             2,
+						BackoffMs:               60000,
+						RetryableFailureReasons: []string{"cell disappeared before completion"},
+					}
+					fakeClock.IncrementBySeconds(-expirePendingTaskDurationInSeconds - 1)
+					_, err := sqlDB.DesireTask(logger, retriedTaskDef, "pending-backing-off-task", domain)
+					Expect(err).NotTo(HaveOccurred())
+					fakeClock.IncrementBySeconds(expirePendingTaskDurationInSeconds + 1)
+
+					_, err = db.Exec("UPDATE tasks SET attempts = 1 WHERE guid = 'pending-backing-off-task'")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("neither fails nor auctions it before its backoff ends", func() {
+					task, err := sqlDB.TaskByGuid(logger, "pending-backing-off-task")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task.State).To(Equal(models.Task_Pending))
+					Expect(task.Failed).To(BeFalse())
+
+					for _, taskRequest := range tasksToAuction {
+						Expect(taskRequest.TaskGuid).NotTo(Equal("pending-backing-off-task"))
+					}
+				})
+			})
+
 			It("doesn't do anything with unexpired tasks that should not be kicked", func() {
 				taskRequest := auctioneer.NewTaskStartRequestFromModel("pending-task", domain, taskDef)
 				Expect(tasksToAuction).NotTo(ContainElement(&taskRequest))
@@ -216,6 +268,48 @@ var _ = Describe("Convergence of Tasks", func() {
 			})
 		})
 
+		Context("blocked tasks", func() {
+			BeforeEach(func() {
+				blockedTaskDef := model_helpers.NewValidTaskDefinition()
+
+				blockedTaskDef.DependsOn = []string{"completed-task"}
+				_, err := sqlDB.DesireTask(logger, blockedTaskDef, "blocked-on-completed-task", domain)
+				Expect(err).NotTo(HaveOccurred())
+
+				blockedTaskDef.DependsOn = []string{"pending-task"}
+				_, err = sqlDB.DesireTask(logger, blockedTaskDef, "blocked-on-pending-task", domain)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeClock.IncrementBySeconds(-expirePendingTaskDurationInSeconds - 1)
+				_, err = sqlDB.DesireTask(logger, blockedTaskDef, "blocked-expired-task", domain)
+				Expect(err).NotTo(HaveOccurred())
+				fakeClock.IncrementBySeconds(expirePendingTaskDurationInSeconds + 1)
+			})
+
+			It("unblocks and auctions tasks whose dependencies have completed", func() {
+				task, err := sqlDB.TaskByGuid(logger, "blocked-on-completed-task")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Pending))
+
+				taskRequest := auctioneer.NewTaskStartRequestFromModel("blocked-on-completed-task", domain, task.TaskDefinition)
+				Expect(tasksToAuction).To(ContainElement(&taskRequest))
+			})
+
+			It("leaves tasks whose dependencies have not completed blocked", func() {
+				task, err := sqlDB.TaskByGuid(logger, "blocked-on-pending-task")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Blocked))
+			})
+
+			It("fails expired tasks", func() {
+				task, err := sqlDB.TaskByGuid(logger, "blocked-expired-task")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Completed))
+				Expect(task.Failed).To(BeTrue())
+				Expect(task.FailureReason).To(Equal("not started within time limit"))
+			})
+		})
+
 		Context("running tasks", func() {
 			It("fails them when their cells are not present", func() {
 				task, err := sqlDB.TaskByGuid(logger, "running-task-no-cell")
@@ -228,6 +322,46 @@ var _ = Describe("Convergence of Tasks", func() {
 				Expect(task.FirstCompletedAt).To(Equal(fakeClock.Now().UnixNano()))
 			})
 
+			It("returns how it changed them", func() {
+				var change *models.TaskChange
+				for _, c := range changes {
+					if c.Before.TaskGuid == "running-task-no-cell" {
+						change = c
+					}
+				}
+				Expect(change).NotTo(BeNil())
+				Expect(change.Before.State).To(Equal(models.Task_Running))
+				Expect(change.After.State).To(Equal(models.Task_Completed))
+				Expect(change.After.FailureReason).To(Equal("cell disappeared before completion"))
+			})
+
+			Context("when their retry policy retries the failure", func() {
+				var retriedTaskDef *models.TaskDefinition
+
+				BeforeEach(func() {
+					retriedTaskDef = model_helpers.NewValidTaskDefinition()
+					retriedTaskDef.RetryPolicy = &models.RetryPolicy{
+						MaxAttempts:             2,
+						RetryableFailureReasons: []string{"cell disappeared before completion"},
+					}
+					_, err := sqlDB.DesireTask(logger, retriedTaskDef, "running-retried-task-no-cell", domain)
+					Expect(err).NotTo(HaveOccurred())
+					_, _, err = sqlDB.StartTask(logger, "running-retried-task-no-cell", "non-existant-cell")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("moves them back to pending and returns them to be auctioned", func() {
+					task, err := sqlDB.TaskByGuid(logger, "running-retried-task-no-cell")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task.State).To(Equal(models.Task_Pending))
+					Expect(task.Attempts).To(BeEquivalentTo(1))
+					Expect(task.FailureReason).To(Equal("cell disappeared before completion"))
+
+					taskRequest := auctioneer.NewTaskStartRequestFromModel("running-retried-task-no-cell", domain, retriedTaskDef)
+					Expect(tasksToAuction).To(ContainElement(&taskRequest))
+				})
+			})
+
 			It("doesn't do anything when their cells are present", func() {
 				taskRequest := auctioneer.NewTaskStartRequestFromModel("running-task", domain, taskDef)
 				Expect(tasksToAuction).NotTo(ContainElement(taskRequest))
@@ -240,12 +374,128 @@ var _ = Describe("Convergence of Tasks", func() {
 			})
 		})
 
+		Context("tasks past their deadlines", func() {
+			BeforeEach(func() {
+				pendingTaskDef := model_helpers.NewValidTaskDefinition()
+				pendingTaskDef.MaxPendingTimeMs = 5000
+				runningTaskDef := model_helpers.NewValidTaskDefinition()
+				runningTaskDef.MaxRunTimeMs = 5000
+				runningTaskDef.CompletionCallbackUrl = "http://example.com/callback"
+				withinDeadlineTaskDef := model_helpers.NewValidTaskDefinition()
+				withinDeadlineTaskDef.MaxPendingTimeMs = 60000
+
+				fakeClock.Increment(-6 * time.Second)
+				_, err := sqlDB.DesireTask(logger, pendingTaskDef, "pending-past-deadline", domain)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = sqlDB.DesireTask(logger, withinDeadlineTaskDef, "pending-within-deadline", domain)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = sqlDB.DesireTask(logger, runningTaskDef, "running-past-deadline", domain)
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = sqlDB.StartTask(logger, "running-past-deadline", "existing-cell")
+				Expect(err).NotTo(HaveOccurred())
+				fakeClock.Increment(6 * time.Second)
+			})
+
+			It("fails pending tasks past their max pending time", func() {
+				task, err := sqlDB.TaskByGuid(logger, "pending-past-deadline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Completed))
+				Expect(task.Failed).To(BeTrue())
+				Expect(task.FailureReason).To(Equal(models.MaxPendingTimeExceededReason))
+			})
+
+			It("leaves tasks within their deadlines alone", func() {
+				task, err := sqlDB.TaskByGuid(logger, "pending-within-deadline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Pending))
+			})
+
+			It("fails running tasks past their max run time and returns them to be cancelled", func() {
+				task, err := sqlDB.TaskByGuid(logger, "running-past-deadline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Completed))
+				Expect(task.Failed).To(BeTrue())
+				Expect(task.FailureReason).To(Equal(models.MaxRunTimeExceededReason))
+
+				Expect(tasksToCancel).To(HaveLen(1))
+				Expect(tasksToCancel[0].TaskGuid).To(Equal("running-past-deadline"))
+				Expect(tasksToCancel[0].CellId).To(Equal("existing-cell"))
+			})
+
+			It("returns the failed tasks with callbacks to be completed", func() {
+				guids := []string{}
+				for _, task := range tasksToComplete {
+					guids = append(guids, task.TaskGuid)
+				}
+				Expect(guids).To(ContainElement("running-past-deadline"))
+			})
+		})
+
+		Context("running tasks whose heartbeats stopped", func() {
+			BeforeEach(func() {
+				heartbeatTaskDef := model_helpers.NewValidTaskDefinition()
+				heartbeatTaskDef.HeartbeatTimeoutMs = 5000
+
+				fakeClock.Increment(-12 * time.Second)
+				for _, taskGuid := range []string{"heartbeat-stopped", "heartbeat-alive", "heartbeat-never-sent"} {
+					_, err := sqlDB.DesireTask(logger, heartbeatTaskDef, taskGuid, domain)
+					Expect(err).NotTo(HaveOccurred())
+					_, _, err = sqlDB.StartTask(logger, taskGuid, "existing-cell")
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				fakeClock.Increment(6 * time.Second)
+				_, err := sqlDB.TaskHeartbeat(logger, "heartbeat-stopped", "existing-cell", "", 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeClock.Increment(4 * time.Second)
+				_, err = sqlDB.TaskHeartbeat(logger, "heartbeat-alive", "existing-cell", "", 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeClock.Increment(2 * time.Second)
+			})
+
+			It("fails tasks with no heartbeat within their heartbeat timeout", func() {
+				for _, taskGuid := range []string{"heartbeat-stopped", "heartbeat-never-sent"} {
+					task, err := sqlDB.TaskByGuid(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task.State).To(Equal(models.Task_Completed))
+					Expect(task.Failed).To(BeTrue())
+					Expect(task.FailureReason).To(Equal(models.HeartbeatTimeoutReason))
+				}
+
+				guids := []string{}
+				for _, task := range tasksToCancel {
+					guids = append(guids, task.TaskGuid)
+				}
+				Expect(guids).To(ConsistOf("heartbeat-stopped", "heartbeat-never-sent"))
+			})
+
+			It("leaves tasks that are still sending heartbeats running", func() {
+				task, err := sqlDB.TaskByGuid(logger, "heartbeat-alive")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Running))
+			})
+		})
+
 		Context("completed tasks", func() {
 			It("deletes expired tasks", func() {
 				_, err := sqlDB.TaskByGuid(logger, "completed-expired-task")
 				Expect(err).To(Equal(models.ErrResourceNotFound))
 			})
 
+			It("returns the expired tasks it deleted as removed", func() {
+				var change *models.TaskChange
+				for _, c := range changes {
+					if c.Before.TaskGuid == "completed-expired-task" {
+						change = c
+					}
+				}
+				Expect(change).NotTo(BeNil())
+				Expect(change.Before.State).To(Equal(models.Task_Completed))
+				Expect(change.After).To(BeNil())
+			})
+
 			It("returns tasks that should be kicked for completion", func() {
 				task, err := sqlDB.TaskByGuid(logger, "completed-kickable-task")
 				Expect(err).NotTo(HaveOccurred())
@@ -262,6 +512,41 @@ var _ = Describe("Convergence of Tasks", func() {
 				_, err := sqlDB.TaskByGuid(logger, "completed-kickable-invalid-task")
 				Expect(err).To(Equal(models.ErrResourceNotFound))
 			})
+
+			Context("when tasks have their own retention period", func() {
+				BeforeEach(func() {
+					longLived := model_helpers.NewValidTaskDefinition()
+					longLived.CompletedRetentionMs = int64(2 * expireCompletedTaskDuration / time.Millisecond)
+					shortLived := model_helpers.NewValidTaskDefinition()
+					shortLived.CompletedRetentionMs = 1000
+
+					fakeClock.Increment(-expireCompletedTaskDuration)
+					completeTask := func(taskDef *models.TaskDefinition, taskGuid string) {
+						_, err := sqlDB.DesireTask(logger, taskDef, taskGuid, domain)
+						Expect(err).NotTo(HaveOccurred())
+						_, _, err = sqlDB.StartTask(logger, taskGuid, "existing-cell")
+						Expect(err).NotTo(HaveOccurred())
+						_, err = sqlDB.CompleteTask(logger, taskGuid, "existing-cell", false, "", "")
+						Expect(err).NotTo(HaveOccurred())
+					}
+					completeTask(longLived, "completed-long-lived-task")
+					fakeClock.Increment(expireCompletedTaskDuration)
+
+					fakeClock.Increment(-2 * time.Second)
+					completeTask(shortLived, "completed-short-lived-task")
+					fakeClock.Increment(2 * time.Second)
+				})
+
+				It("deletes tasks whose own retention period has passed", func() {
+					_, err := sqlDB.TaskByGuid(logger, "completed-short-lived-task")
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+
+				It("keeps tasks with a longer retention period past the default expiry", func() {
+					_, err := sqlDB.TaskByGuid(logger, "completed-long-lived-task")
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
 		})
 
 		Context("resolving tasks", func() {
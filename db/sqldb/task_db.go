@@ -3,41 +3,73 @@ package sqldb
 import (
 	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/pivotal-golang/lager"
 )
 
-func (db *SQLDB) DesireTask(logger lager.Logger, taskDef *models.TaskDefinition, taskGuid, domain string) error {
+func (db *SQLDB) DesireTask(logger lager.Logger, taskDef *models.TaskDefinition, taskGuid, domain string) (*models.Task, error) {
 	logger = logger.Session("desire-task-sql", lager.Data{"task_guid": taskGuid})
 	logger.Info("starting")
 	defer logger.Info("complete")
 
+	var task *models.Task
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		var err error
+		task, err = db.insertTask(logger, taskDef, taskGuid, domain, tx)
+		return err
+	})
+
+	return task, err
+}
+
+// insertTask creates the task, pending or blocked on its dependencies, and
+// records its creation in the event outbox.
+func (db *SQLDB) insertTask(logger lager.Logger, taskDef *models.TaskDefinition, taskGuid, domain string, tx *sql.Tx) (*models.Task, error) {
 	taskDefData, err := db.serializeModel(logger, taskDef)
 	if err != nil {
 		logger.Error("failed-serializing-task-definition", err)
-		return err
+		return nil, err
+	}
+
+	state := models.Task_Pending
+	if len(taskDef.DependsOn) > 0 {
+		state = models.Task_Blocked
 	}
 
 	now := db.clock.Now().UnixNano()
 
-	_, err = db.insert(logger, db.db, tasksTable,
+	_, err = db.insert(logger, tx, tasksTable,
 		SQLAttributes{
-			"guid":               taskGuid,
-			"domain":             domain,
-			"created_at":         now,
-			"updated_at":         now,
-			"first_completed_at": 0,
-			"state":              models.Task_Pending,
-			"task_definition":    taskDefData,
+			"guid":                taskGuid,
+			"domain":              domain,
+			"created_at":          now,
+			"updated_at":          now,
+			"first_completed_at":  0,
+			"state":               state,
+			"priority":            taskDef.Priority,
+			"completed_retention": (time.Duration(taskDef.CompletedRetentionMs) * time.Millisecond).Nanoseconds(),
+			"max_pending_time":    (time.Duration(taskDef.MaxPendingTimeMs) * time.Millisecond).Nanoseconds(),
+			"max_run_time":        (time.Duration(taskDef.MaxRunTimeMs) * time.Millisecond).Nanoseconds(),
+			"heartbeat_timeout":   (time.Duration(taskDef.HeartbeatTimeoutMs) * time.Millisecond).Nanoseconds(),
+			"blocked_on":          encodeBlockedOn(taskDef.DependsOn),
+			"task_definition":     taskDefData,
 		},
 	)
 	if err != nil {
 		logger.Error("failed-inserting-task", err)
-		return db.convertSQLError(err)
+		return nil, db.convertSQLError(err)
 	}
 
-	return nil
+	task, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+	if err != nil {
+		logger.Error("failed-fetching-task", err)
+		return nil, err
+	}
+
+	return task, db.appendEvents(logger, tx, models.NewTaskCreatedEvent(task))
 }
 
 func (db *SQLDB) Tasks(logger lager.Logger, filter models.TaskFilter) ([]*models.Task, error) {
@@ -45,22 +77,10 @@ func (db *SQLDB) Tasks(logger lager.Logger, filter models.TaskFilter) ([]*models
 	logger.Debug("starting")
 	defer logger.Debug("complete")
 
-	wheres := []string{}
-	values := []interface{}{}
-
-	if filter.Domain != "" {
-		wheres = append(wheres, "domain = ?")
-		values = append(values, filter.Domain)
-	}
-
-	if filter.CellID != "" {
-		wheres = append(wheres, "cell_id = ?")
-		values = append(values, filter.CellID)
-	}
-
+	wheres, values := taskFilterWheres(filter)
 	rows, err := db.all(logger, db.db, tasksTable,
 		taskColumns, NoLockRow,
-		strings.Join(wheres, " AND "), values...,
+		wheres, values...,
 	)
 	if err != nil {
 		logger.Error("failed-query", err)
@@ -86,6 +106,39 @@ func (db *SQLDB) Tasks(logger lager.Logger, filter models.TaskFilter) ([]*models
 	return results, nil
 }
 
+// taskFilterWheres returns the wheres selecting the tasks matching the filter.
+func taskFilterWheres(filter models.TaskFilter) (string, []interface{}) {
+	wheres := []string{}
+	values := []interface{}{}
+
+	if filter.Domain != "" {
+		wheres = append(wheres, "domain = ?")
+		values = append(values, filter.Domain)
+	}
+
+	if filter.CellID != "" {
+		wheres = append(wheres, "cell_id = ?")
+		values = append(values, filter.CellID)
+	}
+
+	if filter.State != models.Task_Invalid {
+		wheres = append(wheres, "state = ?")
+		values = append(values, filter.State)
+	}
+
+	if filter.UpdatedBefore != 0 {
+		wheres = append(wheres, "updated_at <= ?")
+		values = append(values, filter.UpdatedBefore)
+	}
+
+	if filter.MinPriority > 0 {
+		wheres = append(wheres, "priority >= ?")
+		values = append(values, filter.MinPriority)
+	}
+
+	return strings.Join(wheres, " AND "), values
+}
+
 func (db *SQLDB) TaskByGuid(logger lager.Logger, taskGuid string) (*models.Task, error) {
 	logger = logger.Session("task-by-guid-sql", lager.Data{"task_guid": taskGuid})
 	logger.Debug("starting")
@@ -98,9 +151,10 @@ func (db *SQLDB) TaskByGuid(logger lager.Logger, taskGuid string) (*models.Task,
 	return db.fetchTask(logger, row, db.db)
 }
 
-func (db *SQLDB) StartTask(logger lager.Logger, taskGuid, cellId string) (bool, error) {
+func (db *SQLDB) StartTask(logger lager.Logger, taskGuid, cellId string) (*models.TaskChange, bool, error) {
 	logger = logger.Session("start-task-sql", lager.Data{"task_guid": taskGuid, "cell_id": cellId})
 
+	var change *models.TaskChange
 	var started bool
 
 	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
@@ -135,53 +189,113 @@ func (db *SQLDB) StartTask(logger lager.Logger, taskGuid, cellId string) (bool,
 			return db.convertSQLError(err)
 		}
 
+		after, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+		if err != nil {
+			logger.Error("failed-fetching-task", err)
+			return err
+		}
+
+		change = &models.TaskChange{Before: task, After: after}
 		started = true
-		return nil
+		return db.appendEvents(logger, tx, models.NewTaskChangedEvent(task, after))
 	})
 
-	return started, err
+	return change, started, err
 }
 
-func (db *SQLDB) CancelTask(logger lager.Logger, taskGuid string) (*models.Task, string, error) {
+func (db *SQLDB) CancelTask(logger lager.Logger, taskGuid, reason string) (*models.TaskChange, string, error) {
 	logger = logger.Session("cancel-task-sql", lager.Data{"task_guid": taskGuid})
 	logger.Info("starting")
 	defer logger.Info("complete")
 
-	var task *models.Task
+	var change *models.TaskChange
 	var cellID string
 
 	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
-		var err error
-		task, err = db.fetchTaskForUpdate(logger, taskGuid, tx)
+		task, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
 		if err != nil {
 			logger.Error("failed-locking-task", err)
 			return err
 		}
 
 		cellID = task.CellId
+		change = &models.TaskChange{Before: task.Copy(), After: task}
+		return db.cancelTask(logger, task, reason, tx)
+	})
 
-		if err = task.ValidateTransitionTo(models.Task_Completed); err != nil {
-			if task.State != models.Task_Pending {
-				logger.Error("failed-to-transition-task-to-completed", err)
+	return change, cellID, err
+}
+
+// CancelTasks cancels every task matching the filter in a single transaction.
+// It returns how each cancelled task changed, and the outcome for every task
+// the filter matched.
+func (db *SQLDB) CancelTasks(logger lager.Logger, filter models.TaskFilter, reason string) ([]*models.TaskChange, []*models.TaskResult, error) {
+	logger = logger.Session("cancel-tasks-sql", lager.Data{"filter": filter})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	var changes []*models.TaskChange
+	var results []*models.TaskResult
+
+	wheres, values := taskFilterWheres(filter)
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		changes = []*models.TaskChange{}
+		results = []*models.TaskResult{}
+
+		guids, err := db.lockTaskGuids(logger, tx, wheres, values...)
+		if err != nil {
+			return err
+		}
+
+		for _, guid := range guids {
+			task, err := db.fetchTaskForUpdate(logger, guid, tx)
+			if err != nil {
+				logger.Error("failed-fetching-task", err, lager.Data{"task_guid": guid})
+				results = append(results, &models.TaskResult{TaskGuid: guid, Error: models.ConvertError(err)})
+				continue
+			}
+
+			before := task.Copy()
+			err = db.cancelTask(logger, task, reason, tx)
+			if bbsErr, ok := err.(*models.Error); ok && bbsErr.Type == models.Error_InvalidStateTransition {
+				results = append(results, &models.TaskResult{TaskGuid: guid, Error: bbsErr})
+				continue
+			}
+			if err != nil {
 				return err
 			}
+
+			changes = append(changes, &models.TaskChange{Before: before, After: task})
+			results = append(results, &models.TaskResult{TaskGuid: guid})
 		}
-		return db.completeTask(logger, task, true, "task was cancelled", "", tx)
+
+		return nil
 	})
 
-	return task, cellID, err
+	return changes, results, err
+}
+
+// cancelTask completes the task as failed. Pending and blocked tasks can be
+// cancelled even though they were never started.
+func (db *SQLDB) cancelTask(logger lager.Logger, task *models.Task, reason string, tx *sql.Tx) error {
+	if err := task.ValidateTransitionTo(models.Task_Completed); err != nil {
+		if task.State != models.Task_Pending && task.State != models.Task_Blocked {
+			logger.Error("failed-to-transition-task-to-completed", err, lager.Data{"task_guid": task.TaskGuid})
+			return err
+		}
+	}
+	return db.completeTask(logger, task, true, reason, "", tx)
 }
 
-func (db *SQLDB) CompleteTask(logger lager.Logger, taskGuid, cellID string, failed bool, failureReason, taskResult string) (*models.Task, error) {
+func (db *SQLDB) CompleteTask(logger lager.Logger, taskGuid, cellID string, failed bool, failureReason, taskResult string) (*models.TaskChange, error) {
 	logger = logger.Session("complete-task-sql", lager.Data{"task_guid": taskGuid, "cell_id": cellID})
 	logger.Info("starting")
 	defer logger.Info("complete")
 
-	var task *models.Task
+	var change *models.TaskChange
 
 	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
-		var err error
-		task, err = db.fetchTaskForUpdate(logger, taskGuid, tx)
+		task, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
 		if err != nil {
 			logger.Error("failed-locking-task", err)
 			return err
@@ -197,48 +311,139 @@ func (db *SQLDB) CompleteTask(logger lager.Logger, taskGuid, cellID string, fail
 			return err
 		}
 
+		change = &models.TaskChange{Before: task.Copy(), After: task}
+		if failed && task.ShouldRetry(failureReason) {
+			return db.retryTask(logger, task, failureReason, tx)
+		}
+
 		return db.completeTask(logger, task, failed, failureReason, taskResult, tx)
 	})
 
-	return task, err
+	return change, err
 }
 
-func (db *SQLDB) FailTask(logger lager.Logger, taskGuid, failureReason string) (*models.Task, error) {
+func (db *SQLDB) FailTask(logger lager.Logger, taskGuid, failureReason string) (*models.TaskChange, error) {
 	logger = logger.Session("fail-task-sql", lager.Data{"task_guid": taskGuid})
 	logger.Info("starting")
 	defer logger.Info("complete")
 
-	var task *models.Task
+	var change *models.TaskChange
 
 	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
-		var err error
-		task, err = db.fetchTaskForUpdate(logger, taskGuid, tx)
+		task, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
 		if err != nil {
 			logger.Error("failed-locking-task", err)
 			return err
 		}
 
 		if err = task.ValidateTransitionTo(models.Task_Completed); err != nil {
-			if task.State != models.Task_Pending {
+			if task.State != models.Task_Pending && task.State != models.Task_Blocked {
 				logger.Error("failed-to-transition-task-to-completed", err)
 				return err
 			}
 		}
 
-		return db.completeTask(logger, task, true, failureReason, "", tx)
+		change = &models.TaskChange{Before: task.Copy(), After: task}
+		return db.failTask(logger, task, failureReason, tx)
 	})
 
-	return task, err
+	return change, err
+}
+
+// ReleaseDependentTasks updates the tasks blocked on the given task once it has
+// completed: they are unblocked if it succeeded, and failed if it failed.
+func (db *SQLDB) ReleaseDependentTasks(logger lager.Logger, taskGuid string) ([]*models.TaskChange, error) {
+	logger = logger.Session("release-dependent-tasks-sql", lager.Data{"task_guid": taskGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var changes []*models.TaskChange
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		changes = []*models.TaskChange{}
+
+		row := db.one(logger, tx, tasksTable,
+			taskColumns, NoLockRow,
+			"guid = ?", taskGuid,
+		)
+		dependency, err := db.fetchTask(logger, row, tx)
+		if err != nil {
+			logger.Error("failed-fetching-task", err)
+			return err
+		}
+
+		if !dependency.HasCompleted() {
+			return nil
+		}
+
+		guids, err := db.lockTaskGuids(logger, tx, blockedOnWheres, blockedOnBindings(taskGuid)...)
+		if err != nil {
+			return err
+		}
+
+		for _, guid := range guids {
+			task, err := db.fetchTaskForUpdate(logger, guid, tx)
+			if err != nil {
+				logger.Error("failed-fetching-blocked-task", err, lager.Data{"blocked_task_guid": guid})
+				continue
+			}
+
+			if !task.IsBlockedOn(taskGuid) {
+				continue
+			}
+
+			before := *task
+			if dependency.Failed {
+				err = db.completeTask(logger, task, true, models.DependencyFailureReason(taskGuid), "", tx)
+			} else {
+				err = db.unblockTask(logger, task, taskGuid, tx)
+			}
+			if err != nil {
+				return err
+			}
+
+			changes = append(changes, &models.TaskChange{Before: &before, After: task})
+		}
+
+		return nil
+	})
+
+	return changes, err
+}
+
+func (db *SQLDB) unblockTask(logger lager.Logger, task *models.Task, dependencyGuid string, tx *sql.Tx) error {
+	before := *task
+	now := db.clock.Now().UnixNano()
+
+	task.Unblock(dependencyGuid)
+	task.UpdatedAt = now
+
+	_, err := db.update(logger, tx, tasksTable,
+		SQLAttributes{
+			"state":      task.State,
+			"blocked_on": encodeBlockedOn(task.BlockedOn),
+			"updated_at": now,
+		},
+		"guid = ?", task.TaskGuid,
+	)
+	if err != nil {
+		logger.Error("failed-updating-tasks", err)
+		return db.convertSQLError(err)
+	}
+
+	return db.appendEvents(logger, tx, models.NewTaskChangedEvent(&before, task))
 }
 
 // The stager calls this when it wants to claim a completed task.  This ensures that only one
 // stager ever attempts to handle a completed task
-func (db *SQLDB) ResolvingTask(logger lager.Logger, taskGuid string) error {
+func (db *SQLDB) ResolvingTask(logger lager.Logger, taskGuid string) (*models.TaskChange, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 	logger.Info("starting")
 	defer logger.Info("complete")
 
-	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+	var change *models.TaskChange
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
 		task, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
 		if err != nil {
 			logger.Error("failed-locking-task", err)
@@ -263,16 +468,125 @@ func (db *SQLDB) ResolvingTask(logger lager.Logger, taskGuid string) error {
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		after, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+		if err != nil {
+			logger.Error("failed-fetching-task", err)
+			return err
+		}
+
+		change = &models.TaskChange{Before: task, After: after}
+		return db.appendEvents(logger, tx, models.NewTaskChangedEvent(task, after))
+	})
+
+	return change, err
+}
+
+func (db *SQLDB) TaskHeartbeat(logger lager.Logger, taskGuid, cellID, progressMessage string, progressPercent int32) (*models.TaskChange, error) {
+	logger = logger.Session("task-heartbeat-sql", lager.Data{"task_guid": taskGuid, "cell_id": cellID})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var change *models.TaskChange
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		before, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+		if err != nil {
+			logger.Error("failed-locking-task", err)
+			return err
+		}
+
+		task := *before
+		err = task.Heartbeat(cellID, progressMessage, progressPercent, db.clock.Now().UnixNano())
+		if err != nil {
+			logger.Error("failed-recording-heartbeat", err)
+			return err
+		}
+
+		_, err = db.update(logger, tx, tasksTable,
+			SQLAttributes{
+				"last_heartbeat_at": task.LastHeartbeatAt,
+				"progress_message":  task.ProgressMessage,
+				"progress_percent":  task.ProgressPercent,
+			},
+			"guid = ?", taskGuid,
+		)
+		if err != nil {
+			logger.Error("failed-updating-tasks", err)
+			return db.convertSQLError(err)
+		}
+
+		after, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+		if err != nil {
+			logger.Error("failed-fetching-task", err)
+			return err
+		}
+
+		change = &models.TaskChange{Before: before, After: after}
+		if change.HeartbeatOnly() {
+			return nil
+		}
+		return db.appendEvents(logger, tx, models.NewTaskChangedEvent(before, after))
 	})
+
+	return change, err
 }
 
-func (db *SQLDB) DeleteTask(logger lager.Logger, taskGuid string) error {
+func (db *SQLDB) DeadLetterTask(logger lager.Logger, taskGuid, callbackFailureReason string) (*models.TaskChange, error) {
+	logger = logger.Session("dead-letter-task-sql", lager.Data{"task_guid": taskGuid})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	var change *models.TaskChange
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		before, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+		if err != nil {
+			logger.Error("failed-locking-task", err)
+			return err
+		}
+
+		task := *before
+		err = task.DeadLetterCallback(callbackFailureReason, db.clock.Now().UnixNano())
+		if err != nil {
+			logger.Error("invalid-state-transition", err)
+			return err
+		}
+
+		_, err = db.update(logger, tx, tasksTable,
+			SQLAttributes{
+				"state":                   task.State,
+				"callback_dead_lettered":  task.CallbackDeadLettered,
+				"callback_failure_reason": task.CallbackFailureReason,
+				"updated_at":              task.UpdatedAt,
+			},
+			"guid = ?", taskGuid,
+		)
+		if err != nil {
+			logger.Error("failed-updating-tasks", err)
+			return db.convertSQLError(err)
+		}
+
+		after, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+		if err != nil {
+			logger.Error("failed-fetching-task", err)
+			return err
+		}
+
+		change = &models.TaskChange{Before: before, After: after}
+		return db.appendEvents(logger, tx, models.NewTaskChangedEvent(before, after))
+	})
+
+	return change, err
+}
+
+func (db *SQLDB) DeleteTask(logger lager.Logger, taskGuid string) (*models.Task, error) {
 	logger = logger.Session("delete-task-sql", lager.Data{"task_guid": taskGuid})
 	logger.Info("starting")
 	defer logger.Info("complete")
 
-	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+	var deleted *models.Task
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
 		task, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
 		if err != nil {
 			logger.Error("failed-locking-task", err)
@@ -291,11 +605,15 @@ func (db *SQLDB) DeleteTask(logger lager.Logger, taskGuid string) error {
 			return db.convertSQLError(err)
 		}
 
-		return nil
+		deleted = task
+		return db.appendEvents(logger, tx, models.NewTaskRemovedEvent(task))
 	})
+
+	return deleted, err
 }
 
 func (db *SQLDB) completeTask(logger lager.Logger, task *models.Task, failed bool, failureReason, result string, tx *sql.Tx) error {
+	before := *task
 	now := db.clock.Now().UnixNano()
 	_, err := db.update(logger, tx, tasksTable,
 		SQLAttributes{
@@ -322,7 +640,45 @@ func (db *SQLDB) completeTask(logger lager.Logger, task *models.Task, failed boo
 	task.Result = result
 	task.CellId = ""
 
-	return nil
+	return db.appendEvents(logger, tx, models.NewTaskChangedEvent(&before, task))
+}
+
+// failTask fails the task, unless its retry policy allows another attempt.
+func (db *SQLDB) failTask(logger lager.Logger, task *models.Task, failureReason string, tx *sql.Tx) error {
+	if task.ShouldRetry(failureReason) {
+		return db.retryTask(logger, task, failureReason, tx)
+	}
+	return db.completeTask(logger, task, true, failureReason, "", tx)
+}
+
+func (db *SQLDB) retryTask(logger lager.Logger, task *models.Task, failureReason string, tx *sql.Tx) error {
+	logger.Info("retrying-task", lager.Data{"task_guid": task.TaskGuid, "attempts": task.Attempts + 1, "failure_reason": failureReason})
+
+	before := *task
+	task.Retry(failureReason, db.clock.Now().UnixNano())
+
+	_, err := db.update(logger, tx, tasksTable,
+		SQLAttributes{
+			"failed":         false,
+			"failure_reason": task.FailureReason,
+			"result":         "",
+			"state":          task.State,
+			"attempts":       task.Attempts,
+			"updated_at":     task.UpdatedAt,
+			"cell_id":        "",
+
+			"last_heartbeat_at": 0,
+			"progress_message":  "",
+			"progress_percent":  0,
+		},
+		"guid = ?", task.TaskGuid,
+	)
+	if err != nil {
+		logger.Error("failed-updating-tasks", err)
+		return db.convertSQLError(err)
+	}
+
+	return db.appendEvents(logger, tx, models.NewTaskChangedEvent(&before, task))
 }
 
 func (db *SQLDB) fetchTaskForUpdate(logger lager.Logger, taskGuid string, tx *sql.Tx) (*models.Task, error) {
@@ -335,10 +691,11 @@ func (db *SQLDB) fetchTaskForUpdate(logger lager.Logger, taskGuid string, tx *sq
 
 func (db *SQLDB) fetchTask(logger lager.Logger, scanner RowScanner, tx Queryable) (*models.Task, error) {
 	var guid, domain, cellID, failureReason string
-	var result sql.NullString
-	var createdAt, updatedAt, firstCompletedAt int64
-	var state int32
-	var failed bool
+	var result, blockedOn sql.NullString
+	var createdAt, updatedAt, firstCompletedAt, lastHeartbeatAt int64
+	var state, attempts, progressPercent int32
+	var failed, callbackDeadLettered bool
+	var callbackFailureReason, progressMessage string
 	var taskDefData []byte
 
 	err := scanner.Scan(
@@ -352,6 +709,13 @@ func (db *SQLDB) fetchTask(logger lager.Logger, scanner RowScanner, tx Queryable
 		&result,
 		&failed,
 		&failureReason,
+		&blockedOn,
+		&attempts,
+		&callbackDeadLettered,
+		&callbackFailureReason,
+		&lastHeartbeatAt,
+		&progressMessage,
+		&progressPercent,
 		&taskDefData,
 	)
 	if err != nil {
@@ -382,7 +746,48 @@ func (db *SQLDB) fetchTask(logger lager.Logger, scanner RowScanner, tx Queryable
 		Result:           result.String,
 		Failed:           failed,
 		FailureReason:    failureReason,
-		TaskDefinition:   &taskDef,
+		BlockedOn:        decodeBlockedOn(blockedOn.String),
+		Attempts:         attempts,
+
+		CallbackDeadLettered:  callbackDeadLettered,
+		CallbackFailureReason: callbackFailureReason,
+
+		LastHeartbeatAt: lastHeartbeatAt,
+		ProgressMessage: progressMessage,
+		ProgressPercent: progressPercent,
+
+		TaskDefinition: &taskDef,
 	}
 	return task, nil
 }
+
+// Task guids cannot contain commas, so the guids of the tasks a task is
+// blocked on are stored as a comma-separated list.
+func encodeBlockedOn(taskGuids []string) string {
+	return strings.Join(taskGuids, ",")
+}
+
+func decodeBlockedOn(blockedOn string) []string {
+	if blockedOn == "" {
+		return nil
+	}
+	return strings.Split(blockedOn, ",")
+}
+
+// blockedOnWheres matches the blocked tasks whose blocked_on list includes
+// the guid given to blockedOnBindings, as the only, first, last or a middle
+// entry.
+const blockedOnWheres = "state = ? AND (blocked_on = ? OR blocked_on LIKE ? OR blocked_on LIKE ? OR blocked_on LIKE ?)"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func blockedOnBindings(taskGuid string) []interface{} {
+	guid := likeEscaper.Replace(taskGuid)
+	return []interface{}{
+		models.Task_Blocked,
+		taskGuid,
+		guid + ",%",
+		"%," + guid,
+		"%," + guid + ",%",
+	}
+}
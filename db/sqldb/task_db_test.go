@@ -2,6 +2,7 @@ package sqldb_test
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/format"
@@ -22,7 +23,7 @@ var _ = Describe("TaskDB", func() {
 		)
 
 		JustBeforeEach(func() {
-			errDesire = sqlDB.DesireTask(logger, taskDef, taskGuid, taskDomain)
+			_, errDesire = sqlDB.DesireTask(logger, taskDef, taskGuid, taskDomain)
 		})
 
 		BeforeEach(func() {
@@ -36,7 +37,9 @@ var _ = Describe("TaskDB", func() {
 			It("persists the task", func() {
 				Expect(errDesire).NotTo(HaveOccurred())
 
-				queryStr := "SELECT * FROM tasks WHERE guid = ?"
+				queryStr := `SELECT guid, domain, created_at, updated_at, first_completed_at, state,
+					cell_id, result, failed, failure_reason, task_definition
+					FROM tasks WHERE guid = ?`
 				if test_helpers.UsePostgres() {
 					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
 				}
@@ -85,10 +88,25 @@ var _ = Describe("TaskDB", func() {
 			})
 		})
 
+		Context("when the task depends on other tasks", func() {
+			BeforeEach(func() {
+				taskDef.DependsOn = []string{"first-guid", "second-guid"}
+			})
+
+			It("persists the task as blocked on its dependencies", func() {
+				Expect(errDesire).NotTo(HaveOccurred())
+
+				task, err := sqlDB.TaskByGuid(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Blocked))
+				Expect(task.BlockedOn).To(Equal([]string{"first-guid", "second-guid"}))
+			})
+		})
+
 		Context("when a task is already present with the desired task guid", func() {
 			BeforeEach(func() {
 				otherDomain := "my-other-domain"
-				err := sqlDB.DesireTask(logger, taskDef, taskGuid, otherDomain)
+				_, err := sqlDB.DesireTask(logger, taskDef, taskGuid, otherDomain)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -119,9 +137,13 @@ var _ = Describe("TaskDB", func() {
 				task2 := model_helpers.NewValidTask("b-guid")
 				task2.Domain = "domain-2"
 				task2.CellId = "cell-2"
+				task2.State = models.Task_Running
+				task2.UpdatedAt = task1.UpdatedAt + 1
 				task3 := model_helpers.NewValidTask("c-guid")
 				task3.Domain = "domain-2"
 				task3.CellId = "cell-1"
+				task3.Priority = 50
+				task3.UpdatedAt = task1.UpdatedAt + 1
 				expectedTasks = []*models.Task{task1, task2, task3}
 
 				for _, t := range expectedTasks {
@@ -155,6 +177,27 @@ var _ = Describe("TaskDB", func() {
 				Expect(tasks).To(HaveLen(1))
 				Expect(tasks[0]).To(Equal(expectedTasks[2]))
 			})
+
+			It("can filter by minimum priority", func() {
+				tasks, err := sqlDB.Tasks(logger, models.TaskFilter{MinPriority: 50})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(HaveLen(1))
+				Expect(tasks[0]).To(Equal(expectedTasks[2]))
+			})
+
+			It("can filter by state", func() {
+				tasks, err := sqlDB.Tasks(logger, models.TaskFilter{State: models.Task_Running})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(HaveLen(1))
+				Expect(tasks[0]).To(Equal(expectedTasks[1]))
+			})
+
+			It("can filter by when the tasks last changed", func() {
+				tasks, err := sqlDB.Tasks(logger, models.TaskFilter{UpdatedBefore: expectedTasks[0].UpdatedAt})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(HaveLen(1))
+				Expect(tasks[0]).To(Equal(expectedTasks[0]))
+			})
 		})
 
 		Context("when there are no tasks", func() {
@@ -222,7 +265,7 @@ var _ = Describe("TaskDB", func() {
 
 		BeforeEach(func() {
 			expectedTask = model_helpers.NewValidTask("task-guid")
-			err := sqlDB.DesireTask(logger, expectedTask.TaskDefinition, expectedTask.TaskGuid, expectedTask.Domain)
+			_, err := sqlDB.DesireTask(logger, expectedTask.TaskDefinition, expectedTask.TaskGuid, expectedTask.Domain)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -237,7 +280,7 @@ var _ = Describe("TaskDB", func() {
 
 			expectedTask.CellId = "expectedCellId"
 
-			started, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, expectedTask.CellId)
+			_, started, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, expectedTask.CellId)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(started).To(BeTrue())
 
@@ -253,7 +296,7 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the cell id is toooooo long", func() {
 			It("returns a BadRequest error", func() {
-				started, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, randStr(256))
+				_, started, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, randStr(256))
 				Expect(err).To(Equal(models.ErrBadRequest))
 				Expect(started).To(BeFalse())
 			})
@@ -261,7 +304,7 @@ var _ = Describe("TaskDB", func() {
 
 		Context("When starting a Task that is already started", func() {
 			BeforeEach(func() {
-				started, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, "cell-id")
+				_, started, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, "cell-id")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(started).To(BeTrue())
 			})
@@ -270,7 +313,7 @@ var _ = Describe("TaskDB", func() {
 				It("returns shouldStart as false", func() {
 					fakeClock.IncrementBySeconds(1)
 
-					changed, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, "cell-id")
+					_, changed, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, "cell-id")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(changed).To(BeFalse())
 
@@ -284,7 +327,7 @@ var _ = Describe("TaskDB", func() {
 				It("returns an error", func() {
 					fakeClock.IncrementBySeconds(1)
 
-					_, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, "some-other-cell")
+					_, _, err := sqlDB.StartTask(logger, expectedTask.TaskGuid, "some-other-cell")
 					modelErr := models.ConvertError(err)
 					Expect(modelErr).NotTo(BeNil())
 					Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
@@ -298,7 +341,7 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task does not exist", func() {
 			It("returns an error", func() {
-				started, err := sqlDB.StartTask(logger, "invalid-guid", "cell-id")
+				_, started, err := sqlDB.StartTask(logger, "invalid-guid", "cell-id")
 				Expect(err).To(Equal(models.ErrResourceNotFound))
 				Expect(started).To(BeFalse())
 			})
@@ -313,7 +356,7 @@ var _ = Describe("TaskDB", func() {
 			})
 
 			It("returns an invalid state transition", func() {
-				started, err := sqlDB.StartTask(logger, "task-other-guid", "completed-guid")
+				_, started, err := sqlDB.StartTask(logger, "task-other-guid", "completed-guid")
 				modelErr := models.ConvertError(err)
 				Expect(modelErr).NotTo(BeNil())
 				Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
@@ -340,7 +383,7 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task is pending", func() {
 			BeforeEach(func() {
-				err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
+				_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -348,16 +391,16 @@ var _ = Describe("TaskDB", func() {
 				fakeClock.Increment(time.Second)
 				now := fakeClock.Now().UnixNano()
 
-				task, cellID, err := sqlDB.CancelTask(logger, taskGuid)
+				change, cellID, err := sqlDB.CancelTask(logger, taskGuid, "cancelled by operator")
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(task.State).To(Equal(models.Task_Completed))
-				Expect(task.UpdatedAt).To(Equal(now))
-				Expect(task.FirstCompletedAt).To(Equal(now))
-				Expect(task.Failed).To(BeTrue())
-				Expect(task.FailureReason).To(Equal("task was cancelled"))
-				Expect(task.Result).To(Equal(""))
-				Expect(task.CellId).To(Equal(""))
+				Expect(change.After.State).To(Equal(models.Task_Completed))
+				Expect(change.After.UpdatedAt).To(Equal(now))
+				Expect(change.After.FirstCompletedAt).To(Equal(now))
+				Expect(change.After.Failed).To(BeTrue())
+				Expect(change.After.FailureReason).To(Equal("cancelled by operator"))
+				Expect(change.After.Result).To(Equal(""))
+				Expect(change.After.CellId).To(Equal(""))
 				Expect(cellID).To(Equal(""))
 			})
 
@@ -366,7 +409,7 @@ var _ = Describe("TaskDB", func() {
 
 				BeforeEach(func() {
 					anotherTaskGuid := "the-other-task-guid"
-					err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
+					_, err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
 					Expect(err).NotTo(HaveOccurred())
 
 					anotherTask, err = sqlDB.TaskByGuid(logger, anotherTaskGuid)
@@ -377,13 +420,13 @@ var _ = Describe("TaskDB", func() {
 					fakeClock.Increment(time.Second)
 					now := fakeClock.Now().UnixNano()
 
-					task, _, err := sqlDB.CancelTask(logger, taskGuid)
+					change, _, err := sqlDB.CancelTask(logger, taskGuid, "cancelled by operator")
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(task.State).To(Equal(models.Task_Completed))
-					Expect(task.UpdatedAt).To(Equal(now))
+					Expect(change.After.State).To(Equal(models.Task_Completed))
+					Expect(change.After.UpdatedAt).To(Equal(now))
 
-					task, err = sqlDB.TaskByGuid(logger, anotherTask.TaskGuid)
+					task, err := sqlDB.TaskByGuid(logger, anotherTask.TaskGuid)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(task).To(BeEquivalentTo(anotherTask))
 				})
@@ -392,10 +435,10 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task is running", func() {
 			BeforeEach(func() {
-				err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
+				_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
 				Expect(err).NotTo(HaveOccurred())
 
-				started, err := sqlDB.StartTask(logger, taskGuid, "the-cell")
+				_, started, err := sqlDB.StartTask(logger, taskGuid, "the-cell")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(started).To(BeTrue())
 			})
@@ -404,16 +447,16 @@ var _ = Describe("TaskDB", func() {
 				fakeClock.Increment(time.Second)
 				now := fakeClock.Now().UnixNano()
 
-				task, cellID, err := sqlDB.CancelTask(logger, taskGuid)
+				change, cellID, err := sqlDB.CancelTask(logger, taskGuid, "cancelled by operator")
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(task.State).To(Equal(models.Task_Completed))
-				Expect(task.UpdatedAt).To(Equal(now))
-				Expect(task.FirstCompletedAt).To(Equal(now))
-				Expect(task.Failed).To(BeTrue())
-				Expect(task.FailureReason).To(Equal("task was cancelled"))
-				Expect(task.Result).To(Equal(""))
-				Expect(task.CellId).To(Equal(""))
+				Expect(change.After.State).To(Equal(models.Task_Completed))
+				Expect(change.After.UpdatedAt).To(Equal(now))
+				Expect(change.After.FirstCompletedAt).To(Equal(now))
+				Expect(change.After.Failed).To(BeTrue())
+				Expect(change.After.FailureReason).To(Equal("cancelled by operator"))
+				Expect(change.After.Result).To(Equal(""))
+				Expect(change.After.CellId).To(Equal(""))
 				Expect(cellID).To(Equal("the-cell"))
 			})
 		})
@@ -422,10 +465,10 @@ var _ = Describe("TaskDB", func() {
 			var beforeTask *models.Task
 
 			BeforeEach(func() {
-				err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
+				_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, _, err = sqlDB.CancelTask(logger, taskGuid)
+				_, _, err = sqlDB.CancelTask(logger, taskGuid, "cancelled by operator")
 				Expect(err).NotTo(HaveOccurred())
 
 				beforeTask, err = sqlDB.TaskByGuid(logger, taskGuid)
@@ -433,7 +476,7 @@ var _ = Describe("TaskDB", func() {
 			})
 
 			It("returns an InvalidStateTransition error", func() {
-				_, _, err := sqlDB.CancelTask(logger, taskGuid)
+				_, _, err := sqlDB.CancelTask(logger, taskGuid, "cancelled by operator")
 				modelErr := models.ConvertError(err)
 				Expect(modelErr).NotTo(BeNil())
 				Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
@@ -454,7 +497,7 @@ var _ = Describe("TaskDB", func() {
 			})
 
 			It("returns an InvalidStateTransition error", func() {
-				_, _, err := sqlDB.CancelTask(logger, taskGuid)
+				_, _, err := sqlDB.CancelTask(logger, taskGuid, "cancelled by operator")
 				modelErr := models.ConvertError(err)
 				Expect(modelErr).NotTo(BeNil())
 				Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
@@ -467,12 +510,86 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task does not exist", func() {
 			It("returns an InvalidStateTransition error", func() {
-				_, _, err := sqlDB.CancelTask(logger, taskGuid)
+				_, _, err := sqlDB.CancelTask(logger, taskGuid, "cancelled by operator")
 				Expect(err).To(Equal(models.ErrResourceNotFound))
 			})
 		})
 	})
 
+	Describe("CancelTasks", func() {
+		var taskDefinition *models.TaskDefinition
+
+		BeforeEach(func() {
+			taskDefinition = model_helpers.NewValidTaskDefinition()
+
+			_, err := sqlDB.DesireTask(logger, taskDefinition, "pending-task", "the-domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.DesireTask(logger, taskDefinition, "running-task", "the-domain")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = sqlDB.StartTask(logger, "running-task", "the-cell")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.DesireTask(logger, taskDefinition, "completed-task", "the-domain")
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = sqlDB.CancelTask(logger, "completed-task", "cancelled earlier")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.DesireTask(logger, taskDefinition, "other-domain-task", "other-domain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("cancels the matching tasks and reports the outcome for each of them", func() {
+			changes, results, err := sqlDB.CancelTasks(logger, models.TaskFilter{Domain: "the-domain"}, "cancelled by operator")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(HaveLen(2))
+			cellIDs := map[string]string{}
+			for _, change := range changes {
+				Expect(change.After.State).To(Equal(models.Task_Completed))
+				Expect(change.After.Failed).To(BeTrue())
+				Expect(change.After.FailureReason).To(Equal("cancelled by operator"))
+				cellIDs[change.After.TaskGuid] = change.Before.CellId
+			}
+			Expect(cellIDs).To(Equal(map[string]string{"pending-task": "", "running-task": "the-cell"}))
+
+			Expect(results).To(HaveLen(3))
+			for _, result := range results {
+				if result.TaskGuid == "completed-task" {
+					Expect(result.Error).NotTo(BeNil())
+					Expect(result.Error.Type).To(Equal(models.Error_InvalidStateTransition))
+				} else {
+					Expect(result.Error).To(BeNil())
+				}
+			}
+
+			task, err := sqlDB.TaskByGuid(logger, "other-domain-task")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.State).To(Equal(models.Task_Pending))
+		})
+
+		It("records an event for each cancelled task", func() {
+			lastSequence, err := sqlDB.LastEventSequence(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = sqlDB.CancelTasks(logger, models.TaskFilter{Domain: "the-domain"}, "cancelled by operator")
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := sqlDB.EventsAfter(logger, lastSequence, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+		})
+
+		It("selects the tasks by state", func() {
+			changes, results, err := sqlDB.CancelTasks(logger, models.TaskFilter{State: models.Task_Running}, "cancelled by operator")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].After.TaskGuid).To(Equal("running-task"))
+			Expect(results).To(Equal([]*models.TaskResult{{TaskGuid: "running-task"}}))
+		})
+	})
+
 	Describe("CompleteTask", func() {
 		var (
 			taskGuid, taskDomain, cellID string
@@ -496,10 +613,10 @@ var _ = Describe("TaskDB", func() {
 
 			Context("when the task is running", func() {
 				BeforeEach(func() {
-					err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
+					_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
 					Expect(err).NotTo(HaveOccurred())
 
-					started, err := sqlDB.StartTask(logger, taskGuid, cellID)
+					_, started, err := sqlDB.StartTask(logger, taskGuid, cellID)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(started).To(BeTrue())
 				})
@@ -510,18 +627,18 @@ var _ = Describe("TaskDB", func() {
 						nowTruncateMicroseconds := fakeClock.Now()
 						now := fakeClock.Now()
 
-						task, err := sqlDB.CompleteTask(logger, taskGuid, cellID, true, "it blew up", "i am the result")
+						change, err := sqlDB.CompleteTask(logger, taskGuid, cellID, true, "it blew up", "i am the result")
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(task.State).To(Equal(models.Task_Completed))
-						Expect(task.UpdatedAt).To(Equal(now.UnixNano()))
-						Expect(task.FirstCompletedAt).To(Equal(now.UnixNano()))
-						Expect(task.Failed).To(BeTrue())
-						Expect(task.FailureReason).To(Equal("it blew up"))
-						Expect(task.Result).To(Equal("i am the result"))
-						Expect(task.CellId).To(Equal(""))
+						Expect(change.After.State).To(Equal(models.Task_Completed))
+						Expect(change.After.UpdatedAt).To(Equal(now.UnixNano()))
+						Expect(change.After.FirstCompletedAt).To(Equal(now.UnixNano()))
+						Expect(change.After.Failed).To(BeTrue())
+						Expect(change.After.FailureReason).To(Equal("it blew up"))
+						Expect(change.After.Result).To(Equal("i am the result"))
+						Expect(change.After.CellId).To(Equal(""))
 
-						task, err = sqlDB.TaskByGuid(logger, taskGuid)
+						task, err := sqlDB.TaskByGuid(logger, taskGuid)
 						Expect(err).NotTo(HaveOccurred())
 
 						Expect(task.State).To(Equal(models.Task_Completed))
@@ -533,6 +650,48 @@ var _ = Describe("TaskDB", func() {
 						Expect(task.CellId).To(Equal(""))
 					})
 
+					Context("when the retry policy of the task retries the failure", func() {
+						BeforeEach(func() {
+							taskDefinition.RetryPolicy = &models.RetryPolicy{
+								MaxAttempts:             2,
+								RetryableFailureReasons: []string{"it blew up"},
+							}
+						})
+
+						It("moves the task back to pending for another attempt", func() {
+							fakeClock.Increment(time.Second)
+
+							change, err := sqlDB.CompleteTask(logger, taskGuid, cellID, true, "it blew up", "")
+							Expect(err).NotTo(HaveOccurred())
+							Expect(change.After.State).To(Equal(models.Task_Pending))
+							Expect(change.After.Attempts).To(BeEquivalentTo(1))
+
+							task, err := sqlDB.TaskByGuid(logger, taskGuid)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(task.State).To(Equal(models.Task_Pending))
+							Expect(task.Attempts).To(BeEquivalentTo(1))
+							Expect(task.UpdatedAt).To(Equal(fakeClock.Now().UnixNano()))
+							Expect(task.FirstCompletedAt).To(BeZero())
+							Expect(task.Failed).To(BeFalse())
+							Expect(task.FailureReason).To(Equal("it blew up"))
+							Expect(task.CellId).To(Equal(""))
+						})
+
+						It("fails the task once its attempts run out", func() {
+							_, err := sqlDB.CompleteTask(logger, taskGuid, cellID, true, "it blew up", "")
+							Expect(err).NotTo(HaveOccurred())
+							_, started, err := sqlDB.StartTask(logger, taskGuid, cellID)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(started).To(BeTrue())
+
+							change, err := sqlDB.CompleteTask(logger, taskGuid, cellID, true, "it blew up", "")
+							Expect(err).NotTo(HaveOccurred())
+							Expect(change.After.State).To(Equal(models.Task_Completed))
+							Expect(change.After.Failed).To(BeTrue())
+							Expect(change.After.Attempts).To(BeEquivalentTo(1))
+						})
+					})
+
 					Context("with an invalid failure reason", func() {
 						It("returns an error and does not update the record", func() {
 							_, err := sqlDB.CompleteTask(logger, taskGuid, cellID, true, randStr(256), "i am the result")
@@ -545,10 +704,10 @@ var _ = Describe("TaskDB", func() {
 
 						BeforeEach(func() {
 							anotherTaskGuid := "another-task-guid"
-							err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
+							_, err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
 							Expect(err).NotTo(HaveOccurred())
 
-							started, err := sqlDB.StartTask(logger, anotherTaskGuid, cellID)
+							_, started, err := sqlDB.StartTask(logger, anotherTaskGuid, cellID)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(started).To(BeTrue())
 
@@ -624,7 +783,7 @@ var _ = Describe("TaskDB", func() {
 				taskDefinition = model_helpers.NewValidTaskDefinition()
 				failureReason = "I failed."
 
-				err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
+				_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -634,18 +793,18 @@ var _ = Describe("TaskDB", func() {
 					nowTruncateMicroseconds := fakeClock.Now()
 					now := fakeClock.Now()
 
-					task, err := sqlDB.FailTask(logger, taskGuid, failureReason)
+					change, err := sqlDB.FailTask(logger, taskGuid, failureReason)
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(task.State).To(Equal(models.Task_Completed))
-					Expect(task.UpdatedAt).To(Equal(now.UnixNano()))
-					Expect(task.FirstCompletedAt).To(Equal(now.UnixNano()))
-					Expect(task.Failed).To(BeTrue())
-					Expect(task.FailureReason).To(Equal("I failed."))
-					Expect(task.Result).To(Equal(""))
-					Expect(task.CellId).To(Equal(""))
+					Expect(change.After.State).To(Equal(models.Task_Completed))
+					Expect(change.After.UpdatedAt).To(Equal(now.UnixNano()))
+					Expect(change.After.FirstCompletedAt).To(Equal(now.UnixNano()))
+					Expect(change.After.Failed).To(BeTrue())
+					Expect(change.After.FailureReason).To(Equal("I failed."))
+					Expect(change.After.Result).To(Equal(""))
+					Expect(change.After.CellId).To(Equal(""))
 
-					task, err = sqlDB.TaskByGuid(logger, taskGuid)
+					task, err := sqlDB.TaskByGuid(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(task.State).To(Equal(models.Task_Completed))
@@ -661,7 +820,7 @@ var _ = Describe("TaskDB", func() {
 					var anotherTask *models.Task
 					BeforeEach(func() {
 						anotherTaskGuid := "another-task-guid"
-						err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
+						_, err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
 						Expect(err).NotTo(HaveOccurred())
 
 						anotherTask, err = sqlDB.TaskByGuid(logger, anotherTaskGuid)
@@ -689,7 +848,7 @@ var _ = Describe("TaskDB", func() {
 			Context("when the task is running", func() {
 				BeforeEach(func() {
 					cellID = "the-cell-id"
-					started, err := sqlDB.StartTask(logger, taskGuid, cellID)
+					_, started, err := sqlDB.StartTask(logger, taskGuid, cellID)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(started).To(BeTrue())
 				})
@@ -701,18 +860,18 @@ var _ = Describe("TaskDB", func() {
 
 					failureReason := "I failed."
 
-					task, err := sqlDB.FailTask(logger, taskGuid, failureReason)
+					change, err := sqlDB.FailTask(logger, taskGuid, failureReason)
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(task.State).To(Equal(models.Task_Completed))
-					Expect(task.UpdatedAt).To(Equal(now.UnixNano()))
-					Expect(task.FirstCompletedAt).To(Equal(now.UnixNano()))
-					Expect(task.Failed).To(BeTrue())
-					Expect(task.FailureReason).To(Equal("I failed."))
-					Expect(task.Result).To(Equal(""))
-					Expect(task.CellId).To(Equal(""))
+					Expect(change.After.State).To(Equal(models.Task_Completed))
+					Expect(change.After.UpdatedAt).To(Equal(now.UnixNano()))
+					Expect(change.After.FirstCompletedAt).To(Equal(now.UnixNano()))
+					Expect(change.After.Failed).To(BeTrue())
+					Expect(change.After.FailureReason).To(Equal("I failed."))
+					Expect(change.After.Result).To(Equal(""))
+					Expect(change.After.CellId).To(Equal(""))
 
-					task, err = sqlDB.TaskByGuid(logger, taskGuid)
+					task, err := sqlDB.TaskByGuid(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(task.State).To(Equal(models.Task_Completed))
@@ -730,7 +889,7 @@ var _ = Describe("TaskDB", func() {
 
 				BeforeEach(func() {
 					cellID = "the-cell-id"
-					started, err := sqlDB.StartTask(logger, taskGuid, cellID)
+					_, started, err := sqlDB.StartTask(logger, taskGuid, cellID)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(started).To(BeTrue())
 
@@ -807,10 +966,10 @@ var _ = Describe("TaskDB", func() {
 				cellID = "the-cell-id"
 				taskDefinition = model_helpers.NewValidTaskDefinition()
 
-				err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
+				_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
 				Expect(err).NotTo(HaveOccurred())
 
-				started, err := sqlDB.StartTask(logger, taskGuid, cellID)
+				_, started, err := sqlDB.StartTask(logger, taskGuid, cellID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(started).To(BeTrue())
 			})
@@ -825,7 +984,7 @@ var _ = Describe("TaskDB", func() {
 					fakeClock.Increment(time.Second)
 					nowTruncateMicroseconds := fakeClock.Now()
 
-					err := sqlDB.ResolvingTask(logger, taskGuid)
+					_, err := sqlDB.ResolvingTask(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 
 					task, err := sqlDB.TaskByGuid(logger, taskGuid)
@@ -840,10 +999,10 @@ var _ = Describe("TaskDB", func() {
 
 					BeforeEach(func() {
 						anotherTaskGuid := "another-guid"
-						err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
+						_, err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
 						Expect(err).NotTo(HaveOccurred())
 
-						started, err := sqlDB.StartTask(logger, anotherTaskGuid, cellID)
+						_, started, err := sqlDB.StartTask(logger, anotherTaskGuid, cellID)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(started).To(BeTrue())
 
@@ -855,7 +1014,7 @@ var _ = Describe("TaskDB", func() {
 					})
 
 					It("should only update the task with the corresponding guid", func() {
-						err := sqlDB.ResolvingTask(logger, taskGuid)
+						_, err := sqlDB.ResolvingTask(logger, taskGuid)
 						Expect(err).NotTo(HaveOccurred())
 
 						task, err := sqlDB.TaskByGuid(logger, anotherTask.TaskGuid)
@@ -876,7 +1035,7 @@ var _ = Describe("TaskDB", func() {
 				})
 
 				It("errors and does not change the task", func() {
-					err := sqlDB.ResolvingTask(logger, taskGuid)
+					_, err := sqlDB.ResolvingTask(logger, taskGuid)
 					modelErr := models.ConvertError(err)
 					Expect(modelErr).NotTo(BeNil())
 					Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
@@ -894,7 +1053,7 @@ var _ = Describe("TaskDB", func() {
 					_, err := sqlDB.CompleteTask(logger, taskGuid, cellID, false, "", "some-result")
 					Expect(err).NotTo(HaveOccurred())
 
-					err = sqlDB.ResolvingTask(logger, taskGuid)
+					_, err = sqlDB.ResolvingTask(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 
 					taskBefore, err = sqlDB.TaskByGuid(logger, taskGuid)
@@ -902,7 +1061,7 @@ var _ = Describe("TaskDB", func() {
 				})
 
 				It("errors and does not change the task", func() {
-					err := sqlDB.ResolvingTask(logger, taskGuid)
+					_, err := sqlDB.ResolvingTask(logger, taskGuid)
 					modelErr := models.ConvertError(err)
 					Expect(modelErr).NotTo(BeNil())
 					Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
@@ -916,7 +1075,241 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task does not exist", func() {
 			It("returns a ResourceNotFound error", func() {
-				err := sqlDB.ResolvingTask(logger, taskGuid)
+				_, err := sqlDB.ResolvingTask(logger, taskGuid)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Describe("ReleaseDependentTasks", func() {
+		var (
+			taskGuid, cellID string
+			taskDefinition   *models.TaskDefinition
+		)
+
+		BeforeEach(func() {
+			taskGuid = "the-task-guid"
+			cellID = "the-cell-id"
+			taskDefinition = model_helpers.NewValidTaskDefinition()
+
+			_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, "the-task-domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			dependentDefinition := model_helpers.NewValidTaskDefinition()
+			dependentDefinition.DependsOn = []string{taskGuid}
+			_, err = sqlDB.DesireTask(logger, dependentDefinition, "dependent-guid", "the-task-domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			dependentDefinition.DependsOn = []string{taskGuid, "other-guid"}
+			_, err = sqlDB.DesireTask(logger, dependentDefinition, "partially-dependent-guid", "the-task-domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, started, err := sqlDB.StartTask(logger, taskGuid, cellID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		Context("when the task has not completed", func() {
+			It("does not release any tasks", func() {
+				changes, err := sqlDB.ReleaseDependentTasks(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changes).To(BeEmpty())
+
+				task, err := sqlDB.TaskByGuid(logger, "dependent-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Blocked))
+			})
+		})
+
+		Context("when the task succeeded", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.CompleteTask(logger, taskGuid, cellID, false, "", "some-result")
+				Expect(err).NotTo(HaveOccurred())
+				fakeClock.Increment(time.Second)
+			})
+
+			It("unblocks the tasks blocked on it", func() {
+				changes, err := sqlDB.ReleaseDependentTasks(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changes).To(HaveLen(2))
+
+				task, err := sqlDB.TaskByGuid(logger, "dependent-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Pending))
+				Expect(task.BlockedOn).To(BeEmpty())
+				Expect(task.UpdatedAt).To(Equal(fakeClock.Now().UnixNano()))
+
+				task, err = sqlDB.TaskByGuid(logger, "partially-dependent-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal(models.Task_Blocked))
+				Expect(task.BlockedOn).To(Equal([]string{"other-guid"}))
+			})
+
+			Context("when other tasks are blocked on similar guids", func() {
+				BeforeEach(func() {
+					definition := model_helpers.NewValidTaskDefinition()
+					definition.DependsOn = []string{"other-guid", taskGuid, "another-guid"}
+					_, err := sqlDB.DesireTask(logger, definition, "middle-dependent-guid", "the-task-domain")
+					Expect(err).NotTo(HaveOccurred())
+
+					for guid, dependsOn := range map[string]string{
+						"prefix-dependent-guid":    taskGuid + "-2",
+						"suffix-dependent-guid":    "not-" + taskGuid,
+						"unrelated-dependent-guid": "other-guid",
+					} {
+						definition := model_helpers.NewValidTaskDefinition()
+						definition.DependsOn = []string{dependsOn}
+						_, err := sqlDB.DesireTask(logger, definition, guid, "the-task-domain")
+						Expect(err).NotTo(HaveOccurred())
+					}
+				})
+
+				It("only releases the tasks blocked on it", func() {
+					changes, err := sqlDB.ReleaseDependentTasks(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(changes).To(HaveLen(3))
+
+					task, err := sqlDB.TaskByGuid(logger, "middle-dependent-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task.BlockedOn).To(Equal([]string{"other-guid", "another-guid"}))
+
+					for _, guid := range []string{"prefix-dependent-guid", "suffix-dependent-guid", "unrelated-dependent-guid"} {
+						task, err := sqlDB.TaskByGuid(logger, guid)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(task.State).To(Equal(models.Task_Blocked))
+						Expect(task.BlockedOn).To(HaveLen(1))
+					}
+				})
+			})
+		})
+
+		Context("when the task failed", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.CompleteTask(logger, taskGuid, cellID, true, "boom", "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails the tasks blocked on it", func() {
+				changes, err := sqlDB.ReleaseDependentTasks(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changes).To(HaveLen(2))
+
+				for _, guid := range []string{"dependent-guid", "partially-dependent-guid"} {
+					task, err := sqlDB.TaskByGuid(logger, guid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task.State).To(Equal(models.Task_Completed))
+					Expect(task.Failed).To(BeTrue())
+					Expect(task.FailureReason).To(Equal(models.DependencyFailureReason(taskGuid)))
+				}
+			})
+		})
+
+		Context("when the task does not exist", func() {
+			It("returns a ResourceNotFound error", func() {
+				_, err := sqlDB.ReleaseDependentTasks(logger, "missing-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Describe("TaskHeartbeat", func() {
+		var taskGuid, cellID string
+
+		BeforeEach(func() {
+			taskGuid = "the-task-guid"
+			cellID = "the-cell-id"
+
+			_, err := sqlDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), taskGuid, "the-task-domain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the task is running on the cell", func() {
+			var startedAt int64
+
+			BeforeEach(func() {
+				_, _, err := sqlDB.StartTask(logger, taskGuid, cellID)
+				Expect(err).NotTo(HaveOccurred())
+				startedAt = fakeClock.Now().UnixNano()
+			})
+
+			It("records the heartbeat and progress without changing UpdatedAt", func() {
+				fakeClock.Increment(time.Second)
+
+				change, err := sqlDB.TaskHeartbeat(logger, taskGuid, cellID, "downloading", 42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(change.After.LastHeartbeatAt).To(Equal(fakeClock.Now().UnixNano()))
+				Expect(change.After.ProgressMessage).To(Equal("downloading"))
+				Expect(change.After.ProgressPercent).To(BeEquivalentTo(42))
+				Expect(change.After.UpdatedAt).To(Equal(startedAt))
+
+				stored, err := sqlDB.TaskByGuid(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).To(Equal(change.After))
+			})
+
+			It("fails for another cell", func() {
+				_, err := sqlDB.TaskHeartbeat(logger, taskGuid, "other-cell-id", "downloading", 42)
+				Expect(models.ConvertError(err).Type).To(Equal(models.Error_RunningOnDifferentCell))
+			})
+		})
+
+		Context("when the task is not running", func() {
+			It("returns an invalid state transition error", func() {
+				_, err := sqlDB.TaskHeartbeat(logger, taskGuid, cellID, "downloading", 42)
+				Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidStateTransition))
+			})
+		})
+	})
+
+	Describe("DeadLetterTask", func() {
+		var taskGuid, cellID string
+
+		BeforeEach(func() {
+			taskGuid = "the-task-guid"
+			cellID = "the-cell-id"
+
+			_, err := sqlDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), taskGuid, "the-task-domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = sqlDB.StartTask(logger, taskGuid, cellID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the task is resolving", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.CompleteTask(logger, taskGuid, cellID, false, "", "some-result")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = sqlDB.ResolvingTask(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the task to completed and marks its callback as dead-lettered", func() {
+				fakeClock.Increment(time.Second)
+
+				change, err := sqlDB.DeadLetterTask(logger, taskGuid, "callback failed")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(change.After.State).To(Equal(models.Task_Completed))
+				Expect(change.After.CallbackDeadLettered).To(BeTrue())
+				Expect(change.After.CallbackFailureReason).To(Equal("callback failed"))
+				Expect(change.After.UpdatedAt).To(Equal(fakeClock.Now().UnixNano()))
+
+				stored, err := sqlDB.TaskByGuid(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).To(Equal(change.After))
+			})
+		})
+
+		Context("when the task is still running", func() {
+			It("returns an invalid state transition error", func() {
+				_, err := sqlDB.DeadLetterTask(logger, taskGuid, "callback failed")
+				Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidStateTransition))
+			})
+		})
+
+		Context("when the task does not exist", func() {
+			It("returns a resource not found error", func() {
+				_, err := sqlDB.DeadLetterTask(logger, "unknown-task-guid", "callback failed")
 				Expect(err).To(Equal(models.ErrResourceNotFound))
 			})
 		})
@@ -940,10 +1333,10 @@ var _ = Describe("TaskDB", func() {
 				cellID = "the-cell-id"
 				taskDefinition = model_helpers.NewValidTaskDefinition()
 
-				err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
+				_, err := sqlDB.DesireTask(logger, taskDefinition, taskGuid, taskDomain)
 				Expect(err).NotTo(HaveOccurred())
 
-				started, err := sqlDB.StartTask(logger, taskGuid, cellID)
+				_, started, err := sqlDB.StartTask(logger, taskGuid, cellID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(started).To(BeTrue())
 
@@ -953,12 +1346,12 @@ var _ = Describe("TaskDB", func() {
 
 			Context("and the task is resolving", func() {
 				BeforeEach(func() {
-					err := sqlDB.ResolvingTask(logger, taskGuid)
+					_, err := sqlDB.ResolvingTask(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
 				It("removes the task from the database", func() {
-					err := sqlDB.DeleteTask(logger, taskGuid)
+					_, err := sqlDB.DeleteTask(logger, taskGuid)
 					Expect(err).NotTo(HaveOccurred())
 
 					_, err = sqlDB.TaskByGuid(logger, taskGuid)
@@ -971,17 +1364,17 @@ var _ = Describe("TaskDB", func() {
 					BeforeEach(func() {
 						anotherTaskGuid := "another-guid"
 
-						err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
+						_, err := sqlDB.DesireTask(logger, taskDefinition, anotherTaskGuid, taskDomain)
 						Expect(err).NotTo(HaveOccurred())
 
-						started, err := sqlDB.StartTask(logger, anotherTaskGuid, cellID)
+						_, started, err := sqlDB.StartTask(logger, anotherTaskGuid, cellID)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(started).To(BeTrue())
 
 						_, err = sqlDB.CompleteTask(logger, anotherTaskGuid, cellID, false, "", "some-result")
 						Expect(err).NotTo(HaveOccurred())
 
-						err = sqlDB.ResolvingTask(logger, anotherTaskGuid)
+						_, err = sqlDB.ResolvingTask(logger, anotherTaskGuid)
 						Expect(err).NotTo(HaveOccurred())
 
 						anotherTask, err = sqlDB.TaskByGuid(logger, anotherTaskGuid)
@@ -989,7 +1382,7 @@ var _ = Describe("TaskDB", func() {
 					})
 
 					It("only removes the task with the corresponding guid", func() {
-						err := sqlDB.DeleteTask(logger, taskGuid)
+						_, err := sqlDB.DeleteTask(logger, taskGuid)
 						Expect(err).NotTo(HaveOccurred())
 
 						task, err := sqlDB.TaskByGuid(logger, anotherTask.TaskGuid)
@@ -1001,7 +1394,7 @@ var _ = Describe("TaskDB", func() {
 
 			Context("and the task is not resolving", func() {
 				It("returns an error", func() {
-					err := sqlDB.DeleteTask(logger, taskGuid)
+					_, err := sqlDB.DeleteTask(logger, taskGuid)
 					expectedErr := models.NewTaskTransitionError(models.Task_Completed, models.Task_Resolving)
 					Expect(err).To(Equal(expectedErr))
 				})
@@ -1010,7 +1403,7 @@ var _ = Describe("TaskDB", func() {
 
 		Context("when the task does not exist", func() {
 			It("returns a ResourceNotFound error", func() {
-				err := sqlDB.DeleteTask(logger, taskGuid)
+				_, err := sqlDB.DeleteTask(logger, taskGuid)
 				Expect(err).To(Equal(models.ErrResourceNotFound))
 			})
 		})
@@ -1027,8 +1420,8 @@ func insertTask(db *sql.DB, serializer format.Serializer, task *models.Task, mal
 
 	queryStr := `INSERT INTO tasks
 						  (guid, domain, created_at, updated_at, first_completed_at, state,
-							cell_id, result, failed, failure_reason, task_definition)
-					    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+							cell_id, result, failed, failure_reason, priority, blocked_on, attempts, task_definition)
+					    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	if test_helpers.UsePostgres() {
 		queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
 	}
@@ -1044,6 +1437,9 @@ func insertTask(db *sql.DB, serializer format.Serializer, task *models.Task, mal
 		task.Result,
 		task.Failed,
 		task.FailureReason,
+		task.TaskDefinition.GetPriority(),
+		strings.Join(task.BlockedOn, ","),
+		task.Attempts,
 		taskDefData,
 	)
 	Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,423 @@
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *SQLDB) TaskSchedules(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error) {
+	logger = logger.Session("task-schedules-sqldb", lager.Data{"filter": filter})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	wheres := ""
+	values := []interface{}{}
+	if filter.Domain != "" {
+		wheres = "domain = ?"
+		values = append(values, filter.Domain)
+	}
+
+	rows, err := db.all(logger, db.db, taskSchedulesTable,
+		taskScheduleColumns, NoLockRow,
+		wheres, values...,
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	schedules := []*models.TaskSchedule{}
+	for rows.Next() {
+		schedule, err := db.fetchTaskSchedule(logger, rows)
+		if err != nil {
+			logger.Error("failed-reading-row", err)
+			continue
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-fetching-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	return schedules, nil
+}
+
+func (db *SQLDB) TaskScheduleByGuid(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error) {
+	logger = logger.Session("task-schedule-by-guid-sqldb", lager.Data{"schedule_guid": scheduleGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	row := db.one(logger, db.db, taskSchedulesTable,
+		taskScheduleColumns, NoLockRow,
+		"guid = ?", scheduleGuid,
+	)
+	return db.fetchTaskSchedule(logger, row)
+}
+
+func (db *SQLDB) DesireTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	logger = logger.Session("desire-task-schedule-sqldb", lager.Data{"schedule_guid": schedule.ScheduleGuid})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	taskDefData, err := db.serializeModel(logger, schedule.TaskDefinition)
+	if err != nil {
+		logger.Error("failed-serializing-task-definition", err)
+		return err
+	}
+
+	now := db.clock.Now().UnixNano()
+
+	_, err = db.insert(logger, db.db, taskSchedulesTable,
+		SQLAttributes{
+			"guid":               schedule.ScheduleGuid,
+			"domain":             schedule.Domain,
+			"cron_expression":    schedule.CronExpression,
+			"concurrency_policy": schedule.ConcurrencyPolicy,
+			"history_limit":      schedule.HistoryLimit,
+			"created_at":         now,
+			"updated_at":         now,
+			"last_scheduled_at":  0,
+			"task_definition":    taskDefData,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-inserting-task-schedule", err)
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
+
+// UpdateTaskSchedule replaces the definition of the schedule. Runs are
+// scheduled from the time of the update, so a changed cron expression does
+// not create a run for a time that has already passed.
+func (db *SQLDB) UpdateTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	logger = logger.Session("update-task-schedule-sqldb", lager.Data{"schedule_guid": schedule.ScheduleGuid})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	taskDefData, err := db.serializeModel(logger, schedule.TaskDefinition)
+	if err != nil {
+		logger.Error("failed-serializing-task-definition", err)
+		return err
+	}
+
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		_, err := db.fetchTaskScheduleForUpdate(logger, schedule.ScheduleGuid, tx)
+		if err != nil {
+			logger.Error("failed-locking-task-schedule", err)
+			return err
+		}
+
+		_, err = db.update(logger, tx, taskSchedulesTable,
+			SQLAttributes{
+				"domain":             schedule.Domain,
+				"cron_expression":    schedule.CronExpression,
+				"concurrency_policy": schedule.ConcurrencyPolicy,
+				"history_limit":      schedule.HistoryLimit,
+				"updated_at":         db.clock.Now().UnixNano(),
+				"task_definition":    taskDefData,
+			},
+			"guid = ?", schedule.ScheduleGuid,
+		)
+		if err != nil {
+			logger.Error("failed-updating-task-schedule", err)
+			return db.convertSQLError(err)
+		}
+
+		return nil
+	})
+}
+
+// RemoveTaskSchedule stops the schedule from creating tasks. The tasks it has
+// already created are left to run.
+func (db *SQLDB) RemoveTaskSchedule(logger lager.Logger, scheduleGuid string) error {
+	logger = logger.Session("remove-task-schedule-sqldb", lager.Data{"schedule_guid": scheduleGuid})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		result, err := db.delete(logger, tx, taskSchedulesTable, "guid = ?", scheduleGuid)
+		if err != nil {
+			logger.Error("failed-deleting-task-schedule", err)
+			return db.convertSQLError(err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			logger.Error("failed-getting-rows-affected", err)
+			return err
+		}
+		if rowsAffected == 0 {
+			return models.ErrResourceNotFound
+		}
+
+		_, err = db.delete(logger, tx, taskScheduleRunsTable, "schedule_guid = ?", scheduleGuid)
+		if err != nil {
+			logger.Error("failed-deleting-task-schedule-runs", err)
+			return db.convertSQLError(err)
+		}
+
+		return nil
+	})
+}
+
+func (db *SQLDB) ScheduledTasks(logger lager.Logger, scheduleGuid string) ([]*models.Task, error) {
+	logger = logger.Session("scheduled-tasks-sqldb", lager.Data{"schedule_guid": scheduleGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		JOIN %s ON %s.guid = %s.task_guid
+		WHERE %s.schedule_guid = ?
+		ORDER BY %s.scheduled_at ASC
+	`, strings.Join(taskColumns, ", "), tasksTable,
+		taskScheduleRunsTable, tasksTable, taskScheduleRunsTable,
+		taskScheduleRunsTable,
+		taskScheduleRunsTable,
+	)
+
+	rows, err := db.db.Query(db.rebind(query), scheduleGuid)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	tasks := []*models.Task{}
+	for rows.Next() {
+		task, err := db.fetchTask(logger, rows, db.db)
+		if err != nil {
+			logger.Error("failed-reading-row", err)
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-fetching-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	return tasks, nil
+}
+
+func (db *SQLDB) ScheduleTask(logger lager.Logger, schedule *models.TaskSchedule, scheduledAt int64) (*models.Task, error) {
+	taskGuid := schedule.TaskGuidForRun(scheduledAt)
+	logger = logger.Session("schedule-task-sqldb", lager.Data{"schedule_guid": schedule.ScheduleGuid, "task_guid": taskGuid})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	var task *models.Task
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		err := db.markScheduled(logger, schedule.ScheduleGuid, scheduledAt, tx)
+		if err != nil {
+			return err
+		}
+
+		task, err = db.insertTask(logger, schedule.TaskDefinition, taskGuid, schedule.Domain, tx)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.insert(logger, tx, taskScheduleRunsTable,
+			SQLAttributes{
+				"task_guid":     taskGuid,
+				"schedule_guid": schedule.ScheduleGuid,
+				"scheduled_at":  scheduledAt,
+			},
+		)
+		if err != nil {
+			logger.Error("failed-inserting-task-schedule-run", err)
+			return db.convertSQLError(err)
+		}
+
+		return nil
+	})
+
+	return task, err
+}
+
+func (db *SQLDB) SkipScheduledRun(logger lager.Logger, scheduleGuid string, scheduledAt int64) error {
+	logger = logger.Session("skip-scheduled-run-sqldb", lager.Data{"schedule_guid": scheduleGuid, "scheduled_at": scheduledAt})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		return db.markScheduled(logger, scheduleGuid, scheduledAt, tx)
+	})
+}
+
+// PruneScheduledTasks keeps the most recent historyLimit completed tasks of
+// the schedule. Tasks whose completion is being resolved are left for their
+// callbacks, and runs whose tasks have already been deleted are forgotten.
+func (db *SQLDB) PruneScheduledTasks(logger lager.Logger, scheduleGuid string, historyLimit int32) (int, error) {
+	logger = logger.Session("prune-scheduled-tasks-sqldb", lager.Data{"schedule_guid": scheduleGuid, "history_limit": historyLimit})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	pruned := 0
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		pruned = 0
+
+		query := fmt.Sprintf(`
+			SELECT task_guid FROM %s
+			WHERE schedule_guid = ?
+			ORDER BY scheduled_at DESC
+			FOR UPDATE
+		`, taskScheduleRunsTable)
+
+		rows, err := tx.Query(db.rebind(query), scheduleGuid)
+		if err != nil {
+			logger.Error("failed-query", err)
+			return db.convertSQLError(err)
+		}
+
+		taskGuids := []string{}
+		for rows.Next() {
+			var taskGuid string
+			err = rows.Scan(&taskGuid)
+			if err != nil {
+				rows.Close()
+				logger.Error("failed-scanning-row", err)
+				return db.convertSQLError(err)
+			}
+			taskGuids = append(taskGuids, taskGuid)
+		}
+		rows.Close()
+
+		if rows.Err() != nil {
+			logger.Error("failed-fetching-row", rows.Err())
+			return db.convertSQLError(rows.Err())
+		}
+
+		var completed int32
+		for _, taskGuid := range taskGuids {
+			task, err := db.fetchTaskForUpdate(logger, taskGuid, tx)
+			if err == models.ErrResourceNotFound {
+				err = db.deleteScheduledRun(logger, taskGuid, tx)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				logger.Error("failed-locking-task", err)
+				return err
+			}
+
+			if !task.HasCompleted() {
+				continue
+			}
+
+			completed++
+			if completed <= historyLimit || task.State != models.Task_Completed {
+				continue
+			}
+
+			_, err = db.delete(logger, tx, tasksTable, "guid = ?", taskGuid)
+			if err != nil {
+				logger.Error("failed-deleting-task", err)
+				return db.convertSQLError(err)
+			}
+
+			err = db.deleteScheduledRun(logger, taskGuid, tx)
+			if err != nil {
+				return err
+			}
+
+			err = db.appendEvents(logger, tx, models.NewTaskRemovedEvent(task))
+			if err != nil {
+				return err
+			}
+			pruned++
+		}
+
+		return nil
+	})
+
+	return pruned, err
+}
+
+func (db *SQLDB) markScheduled(logger lager.Logger, scheduleGuid string, scheduledAt int64, tx *sql.Tx) error {
+	_, err := db.fetchTaskScheduleForUpdate(logger, scheduleGuid, tx)
+	if err != nil {
+		logger.Error("failed-locking-task-schedule", err)
+		return err
+	}
+
+	_, err = db.update(logger, tx, taskSchedulesTable,
+		SQLAttributes{"last_scheduled_at": scheduledAt},
+		"guid = ?", scheduleGuid,
+	)
+	if err != nil {
+		logger.Error("failed-updating-last-scheduled-at", err)
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
+
+func (db *SQLDB) deleteScheduledRun(logger lager.Logger, taskGuid string, tx *sql.Tx) error {
+	_, err := db.delete(logger, tx, taskScheduleRunsTable, "task_guid = ?", taskGuid)
+	if err != nil {
+		logger.Error("failed-deleting-task-schedule-run", err)
+		return db.convertSQLError(err)
+	}
+	return nil
+}
+
+func (db *SQLDB) fetchTaskScheduleForUpdate(logger lager.Logger, scheduleGuid string, tx *sql.Tx) (*models.TaskSchedule, error) {
+	row := db.one(logger, tx, taskSchedulesTable,
+		taskScheduleColumns, LockRow,
+		"guid = ?", scheduleGuid,
+	)
+	return db.fetchTaskSchedule(logger, row)
+}
+
+func (db *SQLDB) fetchTaskSchedule(logger lager.Logger, scanner RowScanner) (*models.TaskSchedule, error) {
+	schedule := &models.TaskSchedule{}
+	var concurrencyPolicy int32
+	var taskDefData []byte
+
+	err := scanner.Scan(
+		&schedule.ScheduleGuid,
+		&schedule.Domain,
+		&schedule.CronExpression,
+		&concurrencyPolicy,
+		&schedule.HistoryLimit,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+		&schedule.LastScheduledAt,
+		&taskDefData,
+	)
+	if err == sql.ErrNoRows {
+		return nil, models.ErrResourceNotFound
+	}
+	if err != nil {
+		logger.Error("failed-scanning-row", err)
+		return nil, db.convertSQLError(err)
+	}
+
+	schedule.ConcurrencyPolicy = models.TaskSchedule_ConcurrencyPolicy(concurrencyPolicy)
+
+	var taskDef models.TaskDefinition
+	err = db.deserializeModel(logger, taskDefData, &taskDef)
+	if err != nil {
+		logger.Error("failed-deserializing-task-definition", err)
+		return nil, models.ErrDeserialize
+	}
+	schedule.TaskDefinition = &taskDef
+
+	return schedule, nil
+}
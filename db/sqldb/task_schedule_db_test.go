@@ -0,0 +1,224 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskScheduleDB", func() {
+	var schedule *models.TaskSchedule
+
+	BeforeEach(func() {
+		schedule = &models.TaskSchedule{
+			ScheduleGuid:      "some-schedule-guid",
+			Domain:            "some-domain",
+			CronExpression:    "*/10 * * * *",
+			TaskDefinition:    model_helpers.NewValidTaskDefinition(),
+			ConcurrencyPolicy: models.TaskSchedule_Forbid,
+			HistoryLimit:      1,
+		}
+	})
+
+	Describe("DesireTaskSchedule", func() {
+		It("persists the schedule", func() {
+			Expect(sqlDB.DesireTaskSchedule(logger, schedule)).To(Succeed())
+
+			stored, err := sqlDB.TaskScheduleByGuid(logger, "some-schedule-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.CronExpression).To(Equal("*/10 * * * *"))
+			Expect(stored.ConcurrencyPolicy).To(Equal(models.TaskSchedule_Forbid))
+			Expect(stored.HistoryLimit).To(BeEquivalentTo(1))
+			Expect(stored.TaskDefinition).To(Equal(schedule.TaskDefinition))
+			Expect(stored.CreatedAt).To(Equal(fakeClock.Now().UnixNano()))
+			Expect(stored.UpdatedAt).To(Equal(fakeClock.Now().UnixNano()))
+			Expect(stored.LastScheduledAt).To(BeZero())
+		})
+
+		Context("when the schedule already exists", func() {
+			BeforeEach(func() {
+				Expect(sqlDB.DesireTaskSchedule(logger, schedule)).To(Succeed())
+			})
+
+			It("returns a resource exists error", func() {
+				err := sqlDB.DesireTaskSchedule(logger, schedule)
+				Expect(err).To(Equal(models.ErrResourceExists))
+			})
+		})
+	})
+
+	Describe("TaskSchedules", func() {
+		BeforeEach(func() {
+			Expect(sqlDB.DesireTaskSchedule(logger, schedule)).To(Succeed())
+
+			other := *schedule
+			other.ScheduleGuid = "other-schedule-guid"
+			other.Domain = "other-domain"
+			Expect(sqlDB.DesireTaskSchedule(logger, &other)).To(Succeed())
+		})
+
+		It("lists the schedules", func() {
+			schedules, err := sqlDB.TaskSchedules(logger, models.TaskScheduleFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedules).To(HaveLen(2))
+		})
+
+		It("filters the schedules by domain", func() {
+			schedules, err := sqlDB.TaskSchedules(logger, models.TaskScheduleFilter{Domain: "other-domain"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedules).To(HaveLen(1))
+			Expect(schedules[0].ScheduleGuid).To(Equal("other-schedule-guid"))
+		})
+	})
+
+	Describe("TaskScheduleByGuid", func() {
+		It("returns a resource not found error for unknown schedules", func() {
+			_, err := sqlDB.TaskScheduleByGuid(logger, "unknown-schedule-guid")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+	})
+
+	Describe("UpdateTaskSchedule", func() {
+		It("replaces the definition of the schedule", func() {
+			Expect(sqlDB.DesireTaskSchedule(logger, schedule)).To(Succeed())
+			fakeClock.Increment(time.Minute)
+
+			schedule.CronExpression = "@hourly"
+			schedule.ConcurrencyPolicy = models.TaskSchedule_Replace
+			Expect(sqlDB.UpdateTaskSchedule(logger, schedule)).To(Succeed())
+
+			stored, err := sqlDB.TaskScheduleByGuid(logger, "some-schedule-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.CronExpression).To(Equal("@hourly"))
+			Expect(stored.ConcurrencyPolicy).To(Equal(models.TaskSchedule_Replace))
+			Expect(stored.UpdatedAt).To(Equal(fakeClock.Now().UnixNano()))
+			Expect(stored.CreatedAt).To(BeNumerically("<", stored.UpdatedAt))
+		})
+
+		It("returns a resource not found error for unknown schedules", func() {
+			err := sqlDB.UpdateTaskSchedule(logger, schedule)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+	})
+
+	Describe("RemoveTaskSchedule", func() {
+		It("removes the schedule", func() {
+			Expect(sqlDB.DesireTaskSchedule(logger, schedule)).To(Succeed())
+			Expect(sqlDB.RemoveTaskSchedule(logger, "some-schedule-guid")).To(Succeed())
+
+			_, err := sqlDB.TaskScheduleByGuid(logger, "some-schedule-guid")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("returns a resource not found error for unknown schedules", func() {
+			err := sqlDB.RemoveTaskSchedule(logger, "unknown-schedule-guid")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+	})
+
+	Describe("scheduling runs", func() {
+		var scheduledAt int64
+
+		BeforeEach(func() {
+			Expect(sqlDB.DesireTaskSchedule(logger, schedule)).To(Succeed())
+			scheduledAt = time.Unix(1466000000, 0).UnixNano()
+		})
+
+		Describe("ScheduleTask", func() {
+			It("creates the task of the run and records the run", func() {
+				task, err := sqlDB.ScheduleTask(logger, schedule, scheduledAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.TaskGuid).To(Equal("some-schedule-guid-1466000000"))
+				Expect(task.Domain).To(Equal("some-domain"))
+				Expect(task.State).To(Equal(models.Task_Pending))
+
+				tasks, err := sqlDB.ScheduledTasks(logger, "some-schedule-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(Equal([]*models.Task{task}))
+
+				stored, err := sqlDB.TaskScheduleByGuid(logger, "some-schedule-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.LastScheduledAt).To(Equal(scheduledAt))
+			})
+
+			It("creates each run only once", func() {
+				_, err := sqlDB.ScheduleTask(logger, schedule, scheduledAt)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = sqlDB.ScheduleTask(logger, schedule, scheduledAt)
+				Expect(err).To(Equal(models.ErrResourceExists))
+			})
+
+			It("fails when the schedule has been removed", func() {
+				Expect(sqlDB.RemoveTaskSchedule(logger, "some-schedule-guid")).To(Succeed())
+
+				_, err := sqlDB.ScheduleTask(logger, schedule, scheduledAt)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+
+				_, err = sqlDB.TaskByGuid(logger, "some-schedule-guid-1466000000")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Describe("SkipScheduledRun", func() {
+			It("records the run without creating a task", func() {
+				Expect(sqlDB.SkipScheduledRun(logger, "some-schedule-guid", scheduledAt)).To(Succeed())
+
+				stored, err := sqlDB.TaskScheduleByGuid(logger, "some-schedule-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.LastScheduledAt).To(Equal(scheduledAt))
+
+				tasks, err := sqlDB.ScheduledTasks(logger, "some-schedule-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(BeEmpty())
+			})
+		})
+
+		Describe("PruneScheduledTasks", func() {
+			var taskGuids []string
+
+			BeforeEach(func() {
+				taskGuids = nil
+				for i := int64(0); i < 3; i++ {
+					task, err := sqlDB.ScheduleTask(logger, schedule, scheduledAt+i*int64(10*time.Minute))
+					Expect(err).NotTo(HaveOccurred())
+					taskGuids = append(taskGuids, task.TaskGuid)
+				}
+			})
+
+			It("keeps the most recent completed tasks up to the history limit", func() {
+				for _, taskGuid := range taskGuids[:2] {
+					_, _, err := sqlDB.CancelTask(logger, taskGuid, models.TaskCancelledReason)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				pruned, err := sqlDB.PruneScheduledTasks(logger, "some-schedule-guid", 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pruned).To(Equal(1))
+
+				_, err = sqlDB.TaskByGuid(logger, taskGuids[0])
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+
+				tasks, err := sqlDB.ScheduledTasks(logger, "some-schedule-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(HaveLen(2))
+				Expect(tasks[0].TaskGuid).To(Equal(taskGuids[1]))
+				Expect(tasks[1].TaskGuid).To(Equal(taskGuids[2]))
+			})
+
+			It("leaves tasks that have not completed", func() {
+				pruned, err := sqlDB.PruneScheduledTasks(logger, "some-schedule-guid", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pruned).To(BeZero())
+
+				tasks, err := sqlDB.ScheduledTasks(logger, "some-schedule-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(HaveLen(3))
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package db
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . TaskArchiveDB
+type TaskArchiveDB interface {
+	// ArchivedTasks returns the most recently completed archived tasks
+	// matching the filter, up to its limit, in the order they completed.
+	ArchivedTasks(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error)
+}
@@ -0,0 +1,21 @@
+package db
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . TaskCallbackDB
+type TaskCallbackDB interface {
+	// EnqueueTaskCallback queues the completion callback of the task to be
+	// delivered now, unless it is already queued.
+	EnqueueTaskCallback(logger lager.Logger, taskGuid string) error
+	// DueTaskCallbacks returns up to limit of the queued callbacks whose next
+	// attempt is due, the longest overdue first.
+	DueTaskCallbacks(logger lager.Logger, limit int) ([]*models.QueuedTaskCallback, error)
+	// RescheduleTaskCallback records the attempts made at the callback and
+	// when to attempt it next.
+	RescheduleTaskCallback(logger lager.Logger, taskGuid string, attempts int32, nextAttemptAt int64) error
+	RemoveTaskCallback(logger lager.Logger, taskGuid string) error
+	TaskCallbackQueueDepth(logger lager.Logger) (int, error)
+}
@@ -10,22 +10,37 @@ import (
 
 type CompleteTaskWork func(logger lager.Logger, taskDB TaskDB, task *models.Task) func()
 
+// TaskConvergenceResult is the work task convergence leaves to its caller.
+// TasksToCancel are the running tasks it failed for exceeding their
+// deadlines, which their cells still have to be told to stop. Changes are
+// how it changed tasks, with no After for the tasks it removed.
+type TaskConvergenceResult struct {
+	TasksToAuction  []*auctioneer.TaskStartRequest
+	TasksToComplete []*models.Task
+	TasksToCancel   []*models.Task
+	Changes         []*models.TaskChange
+}
+
 //go:generate counterfeiter . TaskDB
 type TaskDB interface {
 	Tasks(logger lager.Logger, filter models.TaskFilter) ([]*models.Task, error)
 	TaskByGuid(logger lager.Logger, taskGuid string) (*models.Task, error)
 
-	DesireTask(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid, domain string) error
-	StartTask(logger lager.Logger, taskGuid, cellId string) (bool, error)
-	CancelTask(logger lager.Logger, taskGuid string) (task *models.Task, cellID string, err error)
-	FailTask(logger lager.Logger, taskGuid, failureReason string) (task *models.Task, err error)
-	CompleteTask(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) (task *models.Task, err error)
-	ResolvingTask(logger lager.Logger, taskGuid string) error
-	DeleteTask(logger lager.Logger, taskGuid string) error
+	DesireTask(logger lager.Logger, taskDefinition *models.TaskDefinition, taskGuid, domain string) (task *models.Task, err error)
+	StartTask(logger lager.Logger, taskGuid, cellId string) (change *models.TaskChange, shouldStart bool, err error)
+	TaskHeartbeat(logger lager.Logger, taskGuid, cellId, progressMessage string, progressPercent int32) (change *models.TaskChange, err error)
+	CancelTask(logger lager.Logger, taskGuid, reason string) (change *models.TaskChange, cellID string, err error)
+	CancelTasks(logger lager.Logger, filter models.TaskFilter, reason string) (changes []*models.TaskChange, results []*models.TaskResult, err error)
+	FailTask(logger lager.Logger, taskGuid, failureReason string) (change *models.TaskChange, err error)
+	CompleteTask(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) (change *models.TaskChange, err error)
+	ResolvingTask(logger lager.Logger, taskGuid string) (change *models.TaskChange, err error)
+	DeleteTask(logger lager.Logger, taskGuid string) (task *models.Task, err error)
+	DeadLetterTask(logger lager.Logger, taskGuid, callbackFailureReason string) (change *models.TaskChange, err error)
+	ReleaseDependentTasks(logger lager.Logger, taskGuid string) ([]*models.TaskChange, error)
 
 	ConvergeTasks(
 		logger lager.Logger,
 		cellSet models.CellSet,
 		kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration,
-	) (tasksToAuction []*auctioneer.TaskStartRequest, tasksToComplete []*models.Task)
+	) TaskConvergenceResult
 }
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . TaskScheduleDB
+type TaskScheduleDB interface {
+	TaskSchedules(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error)
+	TaskScheduleByGuid(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error)
+
+	DesireTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error
+	UpdateTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error
+	RemoveTaskSchedule(logger lager.Logger, scheduleGuid string) error
+
+	// ScheduledTasks returns the tasks created for the runs of the schedule
+	// that still exist, oldest first.
+	ScheduledTasks(logger lager.Logger, scheduleGuid string) ([]*models.Task, error)
+	// ScheduleTask creates the task for the run of the schedule due at the
+	// given time and records the run as the last one scheduled.
+	ScheduleTask(logger lager.Logger, schedule *models.TaskSchedule, scheduledAt int64) (*models.Task, error)
+	// SkipScheduledRun records the run due at the given time as the last one
+	// scheduled without creating a task for it.
+	SkipScheduledRun(logger lager.Logger, scheduleGuid string, scheduledAt int64) error
+	// PruneScheduledTasks removes the completed tasks of the schedule beyond
+	// the most recent historyLimit of them, and returns how many it removed.
+	PruneScheduledTasks(logger lager.Logger, scheduleGuid string, historyLimit int32) (int, error)
+}
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/gogo/protobuf/proto"
@@ -16,6 +19,22 @@ var ErrUnrecognizedEventType = errors.New("unrecognized event type")
 
 var ErrSourceClosed = errors.New("source closed")
 
+var ErrResyncRequired = errors.New("event stream could not be resumed, re-list required")
+
+var ErrHeartbeatMissed = errors.New("event stream missed its heartbeats")
+
+const (
+	// HeartbeatParam is the query parameter with which a client asks to be
+	// sent heartbeat events rather than SSE comments, which it cannot see.
+	HeartbeatParam = "heartbeat"
+
+	HeartbeatEventName = "heartbeat"
+
+	// missedHeartbeats is how many heartbeat intervals an EventSource waits
+	// for data before it gives up on the stream.
+	missedHeartbeats = 2
+)
+
 type invalidPayloadError struct {
 	payloadType string
 	protoErr    error
@@ -53,7 +72,7 @@ func (e closeError) Error() string {
 	return fmt.Sprintf("error closing raw source: %s", e.err.Error())
 }
 
-func NewEventFromModelEvent(eventID int, event models.Event) (sse.Event, error) {
+func NewEventFromModelEvent(eventID uint64, event models.Event) (sse.Event, error) {
 	payload, err := proto.Marshal(event)
 	if err != nil {
 		return sse.Event{}, err
@@ -61,18 +80,42 @@ func NewEventFromModelEvent(eventID int, event models.Event) (sse.Event, error)
 
 	encodedPayload := base64.StdEncoding.EncodeToString(payload)
 	return sse.Event{
-		ID:   strconv.Itoa(eventID),
+		ID:   strconv.FormatUint(eventID, 10),
 		Name: string(event.EventType()),
 		Data: []byte(encodedPayload),
 	}, nil
 }
 
+// WriteHeartbeatEvent writes a heartbeat event, carrying the heartbeat
+// interval in milliseconds. It has no id, so it does not change the id a
+// client resumes from.
+func WriteHeartbeatEvent(w io.Writer, interval time.Duration) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %d\n\n", HeartbeatEventName, interval/time.Millisecond)
+	return err
+}
+
+// WriteHeartbeatComment writes a heartbeat as an SSE comment, which keeps the
+// connection from looking idle without clients seeing it.
+func WriteHeartbeatComment(w io.Writer) error {
+	_, err := fmt.Fprintf(w, ": %s\n\n", HeartbeatEventName)
+	return err
+}
+
 //go:generate counterfeiter -o eventfakes/fake_event_source.go . EventSource
 
 // EventSource provides sequential access to a stream of events.
 type EventSource interface {
 	// Next reads the next event from the source. If the connection is lost, it
-	// automatically reconnects.
+	// automatically reconnects and resumes after the last event it read.
+	//
+	// If the events missed while disconnected are no longer available,
+	// ErrResyncRequired is returned once and the source carries on with new
+	// events. The caller should re-list whatever state it is tracking.
+	//
+	// Once the stream has sent a heartbeat, it is expected to send data at
+	// least every two heartbeat intervals. If it does not, the source is
+	// closed and ErrHeartbeatMissed is returned once, so that the caller can
+	// subscribe again rather than wait on a dead connection.
 	//
 	// If the end of the stream is reached cleanly (which should actually never
 	// happen), io.EOF is returned. If called after or during Close,
@@ -92,7 +135,13 @@ type RawEventSource interface {
 }
 
 type eventSource struct {
-	rawEventSource RawEventSource
+	rawEventSource   RawEventSource
+	connect          func() (RawEventSource, error)
+	closed           bool
+	heartbeatTimeout time.Duration
+	heartbeatTimer   *time.Timer
+	heartbeatMissed  bool
+	lock             sync.Mutex
 }
 
 func NewEventSource(raw RawEventSource) EventSource {
@@ -101,102 +150,157 @@ func NewEventSource(raw RawEventSource) EventSource {
 	}
 }
 
+// NewResumableEventSource returns an EventSource that uses connect to start a
+// new stream when the server can no longer resume the current one.
+func NewResumableEventSource(raw RawEventSource, connect func() (RawEventSource, error)) EventSource {
+	return &eventSource{
+		rawEventSource: raw,
+		connect:        connect,
+	}
+}
+
 func (e *eventSource) Next() (models.Event, error) {
-	rawEvent, err := e.rawEventSource.Next()
-	if err != nil {
-		switch err {
-		case io.EOF:
-			return nil, err
+	for {
+		e.lock.Lock()
+		raw := e.rawEventSource
+		e.lock.Unlock()
 
-		case sse.ErrSourceClosed:
-			return nil, ErrSourceClosed
+		e.watchHeartbeats(true)
+		rawEvent, err := raw.Next()
+		e.watchHeartbeats(false)
 
-		default:
-			return nil, NewRawEventSourceError(err)
+		if err != nil {
+			e.lock.Lock()
+			heartbeatMissed := e.heartbeatMissed
+			e.heartbeatMissed = false
+			e.lock.Unlock()
+
+			if heartbeatMissed {
+				return nil, ErrHeartbeatMissed
+			}
+
+			switch err {
+			case io.EOF:
+				return nil, err
+
+			case sse.ErrSourceClosed:
+				return nil, ErrSourceClosed
+
+			default:
+				if e.connect != nil && isGone(err) {
+					return nil, e.reconnect(raw)
+				}
+				return nil, NewRawEventSourceError(err)
+			}
 		}
-	}
 
-	return parseRawEvent(rawEvent)
+		if rawEvent.Name == HeartbeatEventName {
+			interval, err := strconv.ParseInt(string(rawEvent.Data), 10, 64)
+			if err == nil && interval > 0 {
+				e.lock.Lock()
+				e.heartbeatTimeout = missedHeartbeats * time.Duration(interval) * time.Millisecond
+				e.lock.Unlock()
+			}
+			continue
+		}
+
+		return parseRawEvent(rawEvent)
+	}
 }
 
-func (e *eventSource) Close() error {
-	err := e.rawEventSource.Close()
-	if err != nil {
-		return NewCloseError(err)
+// watchHeartbeats starts or stops the wait for data while Next blocks on the
+// raw source. Nothing is waited for until the stream has sent a heartbeat, and
+// time spent by the caller between calls to Next does not count.
+func (e *eventSource) watchHeartbeats(watch bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !watch {
+		if e.heartbeatTimer != nil {
+			e.heartbeatTimer.Stop()
+		}
+		return
 	}
 
-	return nil
-}
+	if e.closed || e.heartbeatTimeout == 0 {
+		return
+	}
 
-func parseRawEvent(rawEvent sse.Event) (models.Event, error) {
-	data, err := base64.StdEncoding.DecodeString(string(rawEvent.Data))
-	if len(data) == 0 || err != nil {
-		return nil, NewInvalidPayloadError(rawEvent.Name, err)
+	if e.heartbeatTimer == nil {
+		e.heartbeatTimer = time.AfterFunc(e.heartbeatTimeout, e.missHeartbeat)
+		return
 	}
-	switch rawEvent.Name {
-	case models.EventTypeDesiredLRPCreated:
-		event := new(models.DesiredLRPCreatedEvent)
-		err := proto.Unmarshal(data, event)
-		if err != nil {
-			return nil, NewInvalidPayloadError(rawEvent.Name, err)
-		}
+	e.heartbeatTimer.Reset(e.heartbeatTimeout)
+}
 
-		return event, nil
+func (e *eventSource) missHeartbeat() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
 
-	case models.EventTypeDesiredLRPChanged:
-		event := new(models.DesiredLRPChangedEvent)
-		err := proto.Unmarshal(data, event)
-		if err != nil {
-			return nil, NewInvalidPayloadError(rawEvent.Name, err)
-		}
+	if e.closed {
+		return
+	}
 
-		return event, nil
+	e.closed = true
+	e.heartbeatMissed = true
+	e.rawEventSource.Close()
+}
 
-	case models.EventTypeDesiredLRPRemoved:
-		event := new(models.DesiredLRPRemovedEvent)
-		err := proto.Unmarshal(data, event)
-		if err != nil {
-			return nil, NewInvalidPayloadError(rawEvent.Name, err)
-		}
+func (e *eventSource) Close() error {
+	e.lock.Lock()
+	e.closed = true
+	if e.heartbeatTimer != nil {
+		e.heartbeatTimer.Stop()
+	}
+	raw := e.rawEventSource
+	e.lock.Unlock()
 
-		return event, nil
+	err := raw.Close()
+	if err != nil {
+		return NewCloseError(err)
+	}
 
-	case models.EventTypeActualLRPCreated:
-		event := new(models.ActualLRPCreatedEvent)
-		err := proto.Unmarshal(data, event)
-		if err != nil {
-			return nil, NewInvalidPayloadError(rawEvent.Name, err)
-		}
+	return nil
+}
 
-		return event, nil
+func (e *eventSource) reconnect(stale RawEventSource) error {
+	raw, err := e.connect()
+	if err != nil {
+		return NewRawEventSourceError(err)
+	}
 
-	case models.EventTypeActualLRPChanged:
-		event := new(models.ActualLRPChangedEvent)
-		err := proto.Unmarshal(data, event)
-		if err != nil {
-			return nil, NewInvalidPayloadError(rawEvent.Name, err)
-		}
+	e.lock.Lock()
+	defer e.lock.Unlock()
 
-		return event, nil
+	if e.closed {
+		raw.Close()
+		return ErrSourceClosed
+	}
 
-	case models.EventTypeActualLRPRemoved:
-		event := new(models.ActualLRPRemovedEvent)
-		err := proto.Unmarshal(data, event)
-		if err != nil {
-			return nil, NewInvalidPayloadError(rawEvent.Name, err)
-		}
+	stale.Close()
+	e.rawEventSource = raw
+	return ErrResyncRequired
+}
 
-		return event, nil
+func isGone(err error) bool {
+	badResponse, ok := err.(sse.BadResponseError)
+	return ok && badResponse.Response != nil && badResponse.Response.StatusCode == http.StatusGone
+}
 
-	case models.EventTypeActualLRPCrashed:
-		event := new(models.ActualLRPCrashedEvent)
-		err := proto.Unmarshal(data, event)
-		if err != nil {
-			return nil, NewInvalidPayloadError(rawEvent.Name, err)
-		}
+func parseRawEvent(rawEvent sse.Event) (models.Event, error) {
+	data, err := base64.StdEncoding.DecodeString(string(rawEvent.Data))
+	if len(data) == 0 || err != nil {
+		return nil, NewInvalidPayloadError(rawEvent.Name, err)
+	}
+	event := models.NewEventOfType(rawEvent.Name)
+	if event == nil {
+		return nil, ErrUnrecognizedEventType
+	}
 
-		return event, nil
+	err = proto.Unmarshal(data, event)
+	if err != nil {
+		return nil, NewInvalidPayloadError(rawEvent.Name, err)
 	}
 
-	return nil, ErrUnrecognizedEventType
+	return event, nil
 }
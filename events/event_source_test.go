@@ -4,10 +4,13 @@ import (
 	"encoding/base64"
 	"errors"
 	"io"
+	"net/http"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
 	"github.com/gogo/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -251,6 +254,255 @@ var _ = Describe("EventSource", func() {
 					Expect(actualLRPCrashedEvent).To(Equal(expectedEvent))
 				})
 			})
+
+			Context("when receiving a ActualLRPInstanceCreatedEvent", func() {
+				var expectedEvent *models.ActualLRPInstanceCreatedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewActualLRPInstanceCreatedEvent(actualLRP)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					actualLRPInstanceCreatedEvent, ok := event.(*models.ActualLRPInstanceCreatedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(actualLRPInstanceCreatedEvent).To(Equal(expectedEvent))
+				})
+			})
+
+			Context("when receiving a ActualLRPInstanceChangedEvent", func() {
+				var expectedEvent *models.ActualLRPInstanceChangedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewActualLRPInstanceChangedEvent(actualLRP, actualLRP.WithPresence(models.ActualLRP_Evacuating))
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					actualLRPInstanceChangedEvent, ok := event.(*models.ActualLRPInstanceChangedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(actualLRPInstanceChangedEvent).To(Equal(expectedEvent))
+				})
+			})
+
+			Context("when receiving a ActualLRPInstanceRemovedEvent", func() {
+				var expectedEvent *models.ActualLRPInstanceRemovedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewActualLRPInstanceRemovedEvent(actualLRP)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					actualLRPInstanceRemovedEvent, ok := event.(*models.ActualLRPInstanceRemovedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(actualLRPInstanceRemovedEvent).To(Equal(expectedEvent))
+				})
+			})
+		})
+
+		Describe("Task events", func() {
+			var task *models.Task
+
+			BeforeEach(func() {
+				task = model_helpers.NewValidTask("some-guid")
+			})
+
+			Context("when receiving a TaskCreatedEvent", func() {
+				var expectedEvent *models.TaskCreatedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewTaskCreatedEvent(task)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					taskCreatedEvent, ok := event.(*models.TaskCreatedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(taskCreatedEvent).To(Equal(expectedEvent))
+				})
+			})
+
+			Context("when receiving a TaskChangedEvent", func() {
+				var expectedEvent *models.TaskChangedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewTaskChangedEvent(task, task)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					taskChangedEvent, ok := event.(*models.TaskChangedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(taskChangedEvent).To(Equal(expectedEvent))
+				})
+			})
+
+			Context("when receiving a TaskRemovedEvent", func() {
+				var expectedEvent *models.TaskRemovedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewTaskRemovedEvent(task)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					taskRemovedEvent, ok := event.(*models.TaskRemovedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(taskRemovedEvent).To(Equal(expectedEvent))
+				})
+			})
+		})
+
+		Describe("Cell events", func() {
+			var cellPresence *models.CellPresence
+
+			BeforeEach(func() {
+				presence := models.NewCellPresence("cell-id", "cell.example.com", "the-zone", models.NewCellCapacity(128, 1024, 6), nil, nil)
+				cellPresence = &presence
+			})
+
+			Context("when receiving a CellAppearedEvent", func() {
+				var expectedEvent *models.CellAppearedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewCellAppearedEvent(cellPresence)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					cellAppearedEvent, ok := event.(*models.CellAppearedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(cellAppearedEvent).To(Equal(expectedEvent))
+				})
+			})
+
+			Context("when receiving a CellDisappearedEvent", func() {
+				var expectedEvent *models.CellDisappearedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewCellDisappearedEvent(cellPresence)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					cellDisappearedEvent, ok := event.(*models.CellDisappearedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(cellDisappearedEvent).To(Equal(expectedEvent))
+				})
+			})
 		})
 
 		Context("when receiving an unrecognized event", func() {
@@ -362,6 +614,178 @@ var _ = Describe("EventSource", func() {
 				Expect(err).To(Equal(events.ErrSourceClosed))
 			})
 		})
+
+		Context("when the raw event source returns a bad response", func() {
+			var goneError error
+
+			BeforeEach(func() {
+				goneError = sse.BadResponseError{Response: &http.Response{StatusCode: http.StatusGone}}
+				fakeRawEventSource.NextReturns(sse.Event{}, goneError)
+			})
+
+			It("propagates the error", func() {
+				_, err := eventSource.Next()
+				Expect(err).To(Equal(events.NewRawEventSourceError(goneError)))
+			})
+
+			Context("when the event source is resumable", func() {
+				var (
+					newRawEventSource *eventfakes.FakeRawEventSource
+					connectErr        error
+					connectCount      int
+				)
+
+				BeforeEach(func() {
+					newRawEventSource = new(eventfakes.FakeRawEventSource)
+					connectErr = nil
+					connectCount = 0
+
+					eventSource = events.NewResumableEventSource(fakeRawEventSource, func() (events.RawEventSource, error) {
+						connectCount++
+						if connectErr != nil {
+							return nil, connectErr
+						}
+						return newRawEventSource, nil
+					})
+				})
+
+				Context("when the stream could not be resumed", func() {
+					It("reconnects and requires a re-list", func() {
+						_, err := eventSource.Next()
+						Expect(err).To(Equal(events.ErrResyncRequired))
+
+						Expect(connectCount).To(Equal(1))
+						Expect(fakeRawEventSource.CloseCallCount()).To(Equal(1))
+					})
+
+					It("reads subsequent events from the new stream", func() {
+						_, err := eventSource.Next()
+						Expect(err).To(Equal(events.ErrResyncRequired))
+
+						expectedEvent := models.NewTaskRemovedEvent(model_helpers.NewValidTask("some-guid"))
+						payload, err := proto.Marshal(expectedEvent)
+						Expect(err).NotTo(HaveOccurred())
+						newRawEventSource.NextReturns(sse.Event{
+							ID:   "1",
+							Name: string(expectedEvent.EventType()),
+							Data: []byte(base64.StdEncoding.EncodeToString(payload)),
+						}, nil)
+
+						event, err := eventSource.Next()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(event).To(Equal(expectedEvent))
+					})
+
+					Context("when reconnecting fails", func() {
+						BeforeEach(func() {
+							connectErr = errors.New("connect-error")
+						})
+
+						It("propagates the error", func() {
+							_, err := eventSource.Next()
+							Expect(err).To(Equal(events.NewRawEventSourceError(connectErr)))
+							Expect(fakeRawEventSource.CloseCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the event source is closed while reconnecting", func() {
+						It("closes the new stream", func() {
+							Expect(eventSource.Close()).To(Succeed())
+
+							_, err := eventSource.Next()
+							Expect(err).To(Equal(events.ErrSourceClosed))
+							Expect(newRawEventSource.CloseCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when the response is not a 410 Gone", func() {
+					BeforeEach(func() {
+						fakeRawEventSource.NextReturns(sse.Event{}, sse.BadResponseError{
+							Response: &http.Response{StatusCode: http.StatusInternalServerError},
+						})
+					})
+
+					It("propagates the error without reconnecting", func() {
+						_, err := eventSource.Next()
+						Expect(err).To(BeAssignableToTypeOf(events.NewRawEventSourceError(nil)))
+						Expect(connectCount).To(Equal(0))
+					})
+				})
+			})
+		})
+
+		Context("when the stream sends heartbeats", func() {
+			var (
+				expectedEvent models.Event
+				rawEvent      sse.Event
+				rawClosed     chan struct{}
+			)
+
+			heartbeat := sse.Event{ID: "1", Name: events.HeartbeatEventName, Data: []byte("10")}
+
+			BeforeEach(func() {
+				expectedEvent = models.NewTaskRemovedEvent(model_helpers.NewValidTask("some-guid"))
+				payload, err := proto.Marshal(expectedEvent)
+				Expect(err).NotTo(HaveOccurred())
+				rawEvent = sse.Event{
+					ID:   "1",
+					Name: string(expectedEvent.EventType()),
+					Data: []byte(base64.StdEncoding.EncodeToString(payload)),
+				}
+
+				rawClosed = make(chan struct{})
+				fakeRawEventSource.CloseStub = func() error {
+					close(rawClosed)
+					return nil
+				}
+			})
+
+			returnInTurn := func(rawEvents ...sse.Event) {
+				fakeRawEventSource.NextStub = func() (sse.Event, error) {
+					return rawEvents[fakeRawEventSource.NextCallCount()-1], nil
+				}
+			}
+
+			It("skips them", func() {
+				returnInTurn(heartbeat, rawEvent)
+
+				Expect(eventSource.Next()).To(Equal(expectedEvent))
+				Expect(fakeRawEventSource.NextCallCount()).To(Equal(2))
+			})
+
+			Context("when no data arrives within two heartbeat intervals", func() {
+				BeforeEach(func() {
+					fakeRawEventSource.NextStub = func() (sse.Event, error) {
+						if fakeRawEventSource.NextCallCount() == 1 {
+							return heartbeat, nil
+						}
+						<-rawClosed
+						return sse.Event{}, sse.ErrSourceClosed
+					}
+				})
+
+				It("closes the source and returns ErrHeartbeatMissed", func() {
+					_, err := eventSource.Next()
+					Expect(err).To(Equal(events.ErrHeartbeatMissed))
+					Expect(fakeRawEventSource.CloseCallCount()).To(Equal(1))
+
+					_, err = eventSource.Next()
+					Expect(err).To(Equal(events.ErrSourceClosed))
+				})
+			})
+
+			Context("when the caller takes a while between events", func() {
+				It("does not count that time", func() {
+					returnInTurn(heartbeat, rawEvent, rawEvent)
+
+					Expect(eventSource.Next()).To(Equal(expectedEvent))
+					time.Sleep(50 * time.Millisecond)
+					Expect(eventSource.Next()).To(Equal(expectedEvent))
+					Expect(fakeRawEventSource.CloseCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("Close", func() {
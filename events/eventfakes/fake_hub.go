@@ -9,25 +9,43 @@ import (
 )
 
 type FakeHub struct {
-	SubscribeStub        func() (events.EventSource, error)
+	SubscribeStub        func(filter models.EventFilter) (events.SequencedEventSource, error)
 	subscribeMutex       sync.RWMutex
-	subscribeArgsForCall []struct{}
-	subscribeReturns     struct {
-		result1 events.EventSource
+	subscribeArgsForCall []struct {
+		filter models.EventFilter
+	}
+	subscribeReturns struct {
+		result1 events.SequencedEventSource
 		result2 error
 	}
-	EmitStub        func(models.Event)
+	SubscribeAfterStub        func(filter models.EventFilter, lastEventID uint64) (events.SequencedEventSource, error)
+	subscribeAfterMutex       sync.RWMutex
+	subscribeAfterArgsForCall []struct {
+		filter      models.EventFilter
+		lastEventID uint64
+	}
+	subscribeAfterReturns struct {
+		result1 events.SequencedEventSource
+		result2 error
+	}
+	EmitStub        func(arg1 models.Event)
 	emitMutex       sync.RWMutex
 	emitArgsForCall []struct {
 		arg1 models.Event
 	}
+	EmitSequencedStub        func(id uint64, event models.Event)
+	emitSequencedMutex       sync.RWMutex
+	emitSequencedArgsForCall []struct {
+		id    uint64
+		event models.Event
+	}
 	CloseStub        func() error
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct{}
 	closeReturns     struct {
 		result1 error
 	}
-	RegisterCallbackStub        func(func(count int))
+	RegisterCallbackStub        func(arg1 func(count int))
 	registerCallbackMutex       sync.RWMutex
 	registerCallbackArgsForCall []struct {
 		arg1 func(count int)
@@ -35,14 +53,22 @@ type FakeHub struct {
 	UnregisterCallbackStub        func()
 	unregisterCallbackMutex       sync.RWMutex
 	unregisterCallbackArgsForCall []struct{}
+	StatsStub                     func() events.HubStats
+	statsMutex                    sync.RWMutex
+	statsArgsForCall              []struct{}
+	statsReturns                  struct {
+		result1 events.HubStats
+	}
 }
 
-func (fake *FakeHub) Subscribe() (events.EventSource, error) {
+func (fake *FakeHub) Subscribe(filter models.EventFilter) (events.SequencedEventSource, error) {
 	fake.subscribeMutex.Lock()
-	fake.subscribeArgsForCall = append(fake.subscribeArgsForCall, struct{}{})
+	fake.subscribeArgsForCall = append(fake.subscribeArgsForCall, struct {
+		filter models.EventFilter
+	}{filter})
 	fake.subscribeMutex.Unlock()
 	if fake.SubscribeStub != nil {
-		return fake.SubscribeStub()
+		return fake.SubscribeStub(filter)
 	} else {
 		return fake.subscribeReturns.result1, fake.subscribeReturns.result2
 	}
@@ -54,10 +80,50 @@ func (fake *FakeHub) SubscribeCallCount() int {
 	return len(fake.subscribeArgsForCall)
 }
 
-func (fake *FakeHub) SubscribeReturns(result1 events.EventSource, result2 error) {
+func (fake *FakeHub) SubscribeArgsForCall(i int) models.EventFilter {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	return fake.subscribeArgsForCall[i].filter
+}
+
+func (fake *FakeHub) SubscribeReturns(result1 events.SequencedEventSource, result2 error) {
 	fake.SubscribeStub = nil
 	fake.subscribeReturns = struct {
-		result1 events.EventSource
+		result1 events.SequencedEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHub) SubscribeAfter(filter models.EventFilter, lastEventID uint64) (events.SequencedEventSource, error) {
+	fake.subscribeAfterMutex.Lock()
+	fake.subscribeAfterArgsForCall = append(fake.subscribeAfterArgsForCall, struct {
+		filter      models.EventFilter
+		lastEventID uint64
+	}{filter, lastEventID})
+	fake.subscribeAfterMutex.Unlock()
+	if fake.SubscribeAfterStub != nil {
+		return fake.SubscribeAfterStub(filter, lastEventID)
+	} else {
+		return fake.subscribeAfterReturns.result1, fake.subscribeAfterReturns.result2
+	}
+}
+
+func (fake *FakeHub) SubscribeAfterCallCount() int {
+	fake.subscribeAfterMutex.RLock()
+	defer fake.subscribeAfterMutex.RUnlock()
+	return len(fake.subscribeAfterArgsForCall)
+}
+
+func (fake *FakeHub) SubscribeAfterArgsForCall(i int) (models.EventFilter, uint64) {
+	fake.subscribeAfterMutex.RLock()
+	defer fake.subscribeAfterMutex.RUnlock()
+	return fake.subscribeAfterArgsForCall[i].filter, fake.subscribeAfterArgsForCall[i].lastEventID
+}
+
+func (fake *FakeHub) SubscribeAfterReturns(result1 events.SequencedEventSource, result2 error) {
+	fake.SubscribeAfterStub = nil
+	fake.subscribeAfterReturns = struct {
+		result1 events.SequencedEventSource
 		result2 error
 	}{result1, result2}
 }
@@ -85,6 +151,30 @@ func (fake *FakeHub) EmitArgsForCall(i int) models.Event {
 	return fake.emitArgsForCall[i].arg1
 }
 
+func (fake *FakeHub) EmitSequenced(id uint64, event models.Event) {
+	fake.emitSequencedMutex.Lock()
+	fake.emitSequencedArgsForCall = append(fake.emitSequencedArgsForCall, struct {
+		id    uint64
+		event models.Event
+	}{id, event})
+	fake.emitSequencedMutex.Unlock()
+	if fake.EmitSequencedStub != nil {
+		fake.EmitSequencedStub(id, event)
+	}
+}
+
+func (fake *FakeHub) EmitSequencedCallCount() int {
+	fake.emitSequencedMutex.RLock()
+	defer fake.emitSequencedMutex.RUnlock()
+	return len(fake.emitSequencedArgsForCall)
+}
+
+func (fake *FakeHub) EmitSequencedArgsForCall(i int) (uint64, models.Event) {
+	fake.emitSequencedMutex.RLock()
+	defer fake.emitSequencedMutex.RUnlock()
+	return fake.emitSequencedArgsForCall[i].id, fake.emitSequencedArgsForCall[i].event
+}
+
 func (fake *FakeHub) Close() error {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct{}{})
@@ -147,4 +237,28 @@ func (fake *FakeHub) UnregisterCallbackCallCount() int {
 	return len(fake.unregisterCallbackArgsForCall)
 }
 
+func (fake *FakeHub) Stats() events.HubStats {
+	fake.statsMutex.Lock()
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct{}{})
+	fake.statsMutex.Unlock()
+	if fake.StatsStub != nil {
+		return fake.StatsStub()
+	} else {
+		return fake.statsReturns.result1
+	}
+}
+
+func (fake *FakeHub) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *FakeHub) StatsReturns(result1 events.HubStats) {
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 events.HubStats
+	}{result1}
+}
+
 var _ events.Hub = new(FakeHub)
@@ -0,0 +1,110 @@
+// This file was generated by counterfeiter
+package eventfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+)
+
+type FakeSequencedEventSource struct {
+	NextStub        func() (models.Event, error)
+	nextMutex       sync.RWMutex
+	nextArgsForCall []struct{}
+	nextReturns     struct {
+		result1 models.Event
+		result2 error
+	}
+	CloseStub        func() error
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct{}
+	closeReturns     struct {
+		result1 error
+	}
+	NextSequencedStub        func() (uint64, models.Event, error)
+	nextSequencedMutex       sync.RWMutex
+	nextSequencedArgsForCall []struct{}
+	nextSequencedReturns     struct {
+		result1 uint64
+		result2 models.Event
+		result3 error
+	}
+}
+
+func (fake *FakeSequencedEventSource) Next() (models.Event, error) {
+	fake.nextMutex.Lock()
+	fake.nextArgsForCall = append(fake.nextArgsForCall, struct{}{})
+	fake.nextMutex.Unlock()
+	if fake.NextStub != nil {
+		return fake.NextStub()
+	} else {
+		return fake.nextReturns.result1, fake.nextReturns.result2
+	}
+}
+
+func (fake *FakeSequencedEventSource) NextCallCount() int {
+	fake.nextMutex.RLock()
+	defer fake.nextMutex.RUnlock()
+	return len(fake.nextArgsForCall)
+}
+
+func (fake *FakeSequencedEventSource) NextReturns(result1 models.Event, result2 error) {
+	fake.NextStub = nil
+	fake.nextReturns = struct {
+		result1 models.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSequencedEventSource) Close() error {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct{}{})
+	fake.closeMutex.Unlock()
+	if fake.CloseStub != nil {
+		return fake.CloseStub()
+	} else {
+		return fake.closeReturns.result1
+	}
+}
+
+func (fake *FakeSequencedEventSource) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeSequencedEventSource) CloseReturns(result1 error) {
+	fake.CloseStub = nil
+	fake.closeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSequencedEventSource) NextSequenced() (uint64, models.Event, error) {
+	fake.nextSequencedMutex.Lock()
+	fake.nextSequencedArgsForCall = append(fake.nextSequencedArgsForCall, struct{}{})
+	fake.nextSequencedMutex.Unlock()
+	if fake.NextSequencedStub != nil {
+		return fake.NextSequencedStub()
+	} else {
+		return fake.nextSequencedReturns.result1, fake.nextSequencedReturns.result2, fake.nextSequencedReturns.result3
+	}
+}
+
+func (fake *FakeSequencedEventSource) NextSequencedCallCount() int {
+	fake.nextSequencedMutex.RLock()
+	defer fake.nextSequencedMutex.RUnlock()
+	return len(fake.nextSequencedArgsForCall)
+}
+
+func (fake *FakeSequencedEventSource) NextSequencedReturns(result1 uint64, result2 models.Event, result3 error) {
+	fake.NextSequencedStub = nil
+	fake.nextSequencedReturns = struct {
+		result1 uint64
+		result2 models.Event
+		result3 error
+	}{result1, result2, result3}
+}
+
+var _ events.SequencedEventSource = new(FakeSequencedEventSource)
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"net/url"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+)
+
+const (
+	FilterDomainParam      = "domain"
+	FilterProcessGuidParam = "process_guid"
+	FilterCellIDParam      = "cell_id"
+	FilterEventTypeParam   = "event_type"
+)
+
+// NewFilterQuery encodes the filter as the query of an event stream request.
+func NewFilterQuery(filter models.EventFilter) url.Values {
+	query := url.Values{}
+	if filter.Domain != "" {
+		query.Set(FilterDomainParam, filter.Domain)
+	}
+	for _, processGuid := range filter.ProcessGuids {
+		query.Add(FilterProcessGuidParam, processGuid)
+	}
+	if filter.CellID != "" {
+		query.Set(FilterCellIDParam, filter.CellID)
+	}
+	for _, eventType := range filter.EventTypes {
+		query.Add(FilterEventTypeParam, eventType)
+	}
+	return query
+}
+
+// NewFilterFromQuery decodes the filter from the query of an event stream
+// request.
+func NewFilterFromQuery(query url.Values) models.EventFilter {
+	return models.EventFilter{
+		Domain:       query.Get(FilterDomainParam),
+		ProcessGuids: query[FilterProcessGuidParam],
+		CellID:       query.Get(FilterCellIDParam),
+		EventTypes:   query[FilterEventTypeParam],
+	}
+}
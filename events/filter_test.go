@@ -0,0 +1,30 @@
+package events_test
+
+import (
+	"net/url"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	It("round trips the filter through the query", func() {
+		filter := models.EventFilter{
+			Domain:       "some-domain",
+			ProcessGuids: []string{"guid-1", "guid-2"},
+			CellID:       "some-cell",
+			EventTypes:   []string{models.EventTypeActualLRPCreated, models.EventTypeActualLRPRemoved},
+		}
+
+		query, err := url.ParseQuery(events.NewFilterQuery(filter).Encode())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events.NewFilterFromQuery(query)).To(Equal(filter))
+	})
+
+	It("leaves the query empty for an empty filter", func() {
+		Expect(events.NewFilterQuery(models.EventFilter{})).To(BeEmpty())
+		Expect(events.NewFilterFromQuery(url.Values{})).To(Equal(models.EventFilter{}))
+	})
+})
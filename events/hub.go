@@ -2,12 +2,16 @@ package events
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
 )
 
 const MAX_PENDING_SUBSCRIBER_EVENTS = 1024
+const MAX_REPLAY_EVENTS = 1024
 
 var ErrReadFromClosedSource = errors.New("read from closed source")
 var ErrSendToClosedSource = errors.New("send to closed source")
@@ -16,28 +20,205 @@ var ErrSlowConsumer = errors.New("slow consumer")
 
 var ErrSubscribedToClosedHub = errors.New("subscribed to closed hub")
 var ErrHubAlreadyClosed = errors.New("hub already closed")
+var ErrEventsExpired = errors.New("events to resume from are no longer available")
+
+//go:generate counterfeiter -o eventfakes/fake_sequenced_event_source.go . SequencedEventSource
+
+// SequencedEventSource is an EventSource that also reports the id the hub
+// assigned to each event, so that a subscriber can later resume after it.
+type SequencedEventSource interface {
+	EventSource
+
+	NextSequenced() (uint64, models.Event, error)
+}
 
 //go:generate counterfeiter -o eventfakes/fake_hub.go . Hub
 type Hub interface {
-	Subscribe() (EventSource, error)
+	// Subscribe streams the events emitted from now on that match the filter.
+	// Events are filtered before they are queued, so events that do not match
+	// never count towards MAX_PENDING_SUBSCRIBER_EVENTS.
+	Subscribe(filter models.EventFilter) (SequencedEventSource, error)
+
+	// SubscribeAfter replays the buffered events emitted after lastEventID
+	// before streaming new ones. If any of those events are no longer buffered,
+	// ErrEventsExpired is returned and the subscriber has to re-list instead.
+	SubscribeAfter(filter models.EventFilter, lastEventID uint64) (SequencedEventSource, error)
+
 	Emit(models.Event)
+
+	// EmitSequenced emits an event under an id assigned outside of the hub,
+	// such as its position in the event outbox. Ids must increase, but need not
+	// be contiguous; an event whose id is not above the last one emitted is
+	// dropped.
+	EmitSequenced(id uint64, event models.Event)
+
 	Close() error
 
 	RegisterCallback(func(count int))
 	UnregisterCallback()
+
+	// Stats reports the queue of every current subscriber, and totals that
+	// also count the subscribers that have gone.
+	Stats() HubStats
+}
+
+// SlowConsumerPolicy decides what happens to a subscriber whose queue is full
+// when another event arrives for it.
+type SlowConsumerPolicy string
+
+const (
+	// DisconnectSlowConsumers closes the subscriber's source once it has read
+	// the events already queued, so that it can resume or re-list.
+	DisconnectSlowConsumers SlowConsumerPolicy = "disconnect"
+
+	// DropOldestEvents discards the oldest queued event to make room.
+	DropOldestEvents SlowConsumerPolicy = "drop-oldest"
+
+	// CoalesceEvents discards the queued event about the same resource (the
+	// same DesiredLRP, ActualLRP instance index, Task or cell) as the new one,
+	// so that the subscriber still learns of its latest state. If no queued
+	// event is about that resource, the subscriber is disconnected.
+	CoalesceEvents SlowConsumerPolicy = "coalesce"
+)
+
+func (p SlowConsumerPolicy) Valid() bool {
+	switch p {
+	case DisconnectSlowConsumers, DropOldestEvents, CoalesceEvents:
+		return true
+	}
+	return false
+}
+
+type HubConfig struct {
+	MaxPendingEvents   int
+	SlowConsumerPolicy SlowConsumerPolicy
+}
+
+func DefaultHubConfig() HubConfig {
+	return HubConfig{
+		MaxPendingEvents:   MAX_PENDING_SUBSCRIBER_EVENTS,
+		SlowConsumerPolicy: DisconnectSlowConsumers,
+	}
+}
+
+type DisconnectReason string
+
+const (
+	DisconnectedSlowConsumer DisconnectReason = "slow_consumer"
+	DisconnectedBySubscriber DisconnectReason = "closed_by_subscriber"
+	DisconnectedHubClosed    DisconnectReason = "hub_closed"
+)
+
+type SubscriberStats struct {
+	ID              uint64 `json:"id"`
+	Filter          string `json:"filter"`
+	QueueDepth      int    `json:"queue_depth"`
+	MaxQueueDepth   int    `json:"max_queue_depth"`
+	DroppedEvents   uint64 `json:"dropped_events"`
+	CoalescedEvents uint64 `json:"coalesced_events"`
+}
+
+type HubStats struct {
+	SlowConsumerPolicy SlowConsumerPolicy          `json:"slow_consumer_policy"`
+	Subscribers        []SubscriberStats           `json:"subscribers"`
+	DroppedEvents      uint64                      `json:"dropped_events"`
+	CoalescedEvents    uint64                      `json:"coalesced_events"`
+	Disconnects        map[DisconnectReason]uint64 `json:"disconnects"`
+}
+
+// MaxQueueDepth returns the depth of the fullest subscriber queue.
+func (stats HubStats) MaxQueueDepth() int {
+	max := 0
+	for _, subscriber := range stats.Subscribers {
+		if subscriber.QueueDepth > max {
+			max = subscriber.QueueDepth
+		}
+	}
+	return max
+}
+
+type sequencedEvent struct {
+	id    uint64
+	event models.Event
 }
 
 type hub struct {
 	subscribers map[*hubSource]struct{}
 	closed      bool
 	lock        sync.Mutex
+	config      HubConfig
+
+	lastSubscriberID uint64
+
+	// The counts of the subscribers that have gone.
+	droppedEvents   uint64
+	coalescedEvents uint64
+	disconnects     map[DisconnectReason]uint64
+
+	// sequence is the id of the last emitted event. Unless the ids come from
+	// EmitSequenced, it starts at the time the hub was created, so ids handed
+	// out by a previous hub (e.g. before a restart) are always older than the
+	// events buffered by this one.
+	sequence  uint64
+	sequenced bool
+
+	// replay is a ring of the most recent events, oldest at replayStart.
+	// expiredThrough is the newest id that can no longer be replayed.
+	replay         []sequencedEvent
+	replayStart    int
+	buffered       int
+	expiredThrough uint64
 
 	cb func(count int)
 }
 
 func NewHub() Hub {
+	return NewHubWithConfig(DefaultHubConfig())
+}
+
+func NewHubWithConfig(config HubConfig) Hub {
+	hub := newHub(config)
+	hub.sequence = uint64(time.Now().UnixNano())
+	hub.expiredThrough = hub.sequence
+	return hub
+}
+
+// NewSequencedHub returns a hub whose event ids are assigned by the caller
+// through EmitSequenced, so that they can carry on across hubs on different
+// BBS instances. It has no ids to give events passed to Emit, so Emit panics;
+// code that would emit to it is given an OutboxOnly view instead. Until its
+// first event the hub cannot tell which earlier ids it has missed, so a
+// subscriber resuming from before that event is asked to re-list.
+func NewSequencedHub() Hub {
+	return NewSequencedHubWithConfig(DefaultHubConfig())
+}
+
+func NewSequencedHubWithConfig(config HubConfig) Hub {
+	hub := newHub(config)
+	hub.sequenced = true
+	return hub
+}
+
+// OutboxOnly returns a view of a sequenced hub for the code that makes changes
+// through a database with an event outbox. Each of those changes records its
+// own event in the outbox, and the outbox publisher is the one path by which
+// events reach the hub, so Emit on the view does nothing.
+func OutboxOnly(hub Hub) Hub {
+	return outboxOnlyHub{hub}
+}
+
+type outboxOnlyHub struct {
+	Hub
+}
+
+func (outboxOnlyHub) Emit(models.Event) {}
+
+func newHub(config HubConfig) *hub {
 	return &hub{
 		subscribers: make(map[*hubSource]struct{}),
+		config:      config,
+		disconnects: make(map[DisconnectReason]uint64),
+		replay:      make([]sequencedEvent, MAX_REPLAY_EVENTS),
 	}
 }
 
@@ -57,7 +238,19 @@ func (hub *hub) UnregisterCallback() {
 	hub.lock.Unlock()
 }
 
-func (hub *hub) Subscribe() (EventSource, error) {
+func (hub *hub) Subscribe(filter models.EventFilter) (SequencedEventSource, error) {
+	hub.lock.Lock()
+
+	if hub.closed {
+		hub.lock.Unlock()
+
+		return nil, ErrSubscribedToClosedHub
+	}
+
+	return hub.addSubscriber(filter, nil)
+}
+
+func (hub *hub) SubscribeAfter(filter models.EventFilter, lastEventID uint64) (SequencedEventSource, error) {
 	hub.lock.Lock()
 
 	if hub.closed {
@@ -66,7 +259,28 @@ func (hub *hub) Subscribe() (EventSource, error) {
 		return nil, ErrSubscribedToClosedHub
 	}
 
-	sub := newSource(MAX_PENDING_SUBSCRIBER_EVENTS, hub.subscriberClosed)
+	if lastEventID > hub.sequence || lastEventID < hub.expiredThrough {
+		hub.lock.Unlock()
+
+		return nil, ErrEventsExpired
+	}
+
+	missed := []sequencedEvent{}
+	for i := 0; i < hub.buffered; i++ {
+		event := hub.replay[(hub.replayStart+i)%MAX_REPLAY_EVENTS]
+		if event.id > lastEventID && filter.Matches(event.event) {
+			missed = append(missed, event)
+		}
+	}
+
+	return hub.addSubscriber(filter, missed)
+}
+
+// addSubscriber must be called with the lock held, and releases it.
+func (hub *hub) addSubscriber(filter models.EventFilter, missed []sequencedEvent) (SequencedEventSource, error) {
+	hub.lastSubscriberID++
+	sub := newSource(hub.lastSubscriberID, hub.config.MaxPendingEvents+len(missed), hub.config.SlowConsumerPolicy, filter, hub.subscriberClosed)
+	sub.queue = append(sub.queue, missed...)
 	hub.subscribers[sub] = struct{}{}
 	cb := hub.cb
 	size := len(hub.subscribers)
@@ -80,10 +294,51 @@ func (hub *hub) Subscribe() (EventSource, error) {
 
 func (hub *hub) Emit(event models.Event) {
 	hub.lock.Lock()
+
+	if hub.sequenced {
+		hub.lock.Unlock()
+		panic("events: Emit called on a sequenced hub")
+	}
+
+	hub.emit(hub.sequence+1, event)
+}
+
+func (hub *hub) EmitSequenced(id uint64, event models.Event) {
+	hub.lock.Lock()
+
+	if id <= hub.sequence {
+		hub.lock.Unlock()
+		return
+	}
+
+	if hub.sequenced && hub.sequence == 0 {
+		hub.expiredThrough = id - 1
+	}
+
+	hub.emit(id, event)
+}
+
+// emit must be called with the lock held, and releases it.
+func (hub *hub) emit(id uint64, event models.Event) {
 	size := len(hub.subscribers)
 
+	hub.sequence = id
+	sequenced := sequencedEvent{id: id, event: event}
+	if hub.buffered < MAX_REPLAY_EVENTS {
+		hub.replay[(hub.replayStart+hub.buffered)%MAX_REPLAY_EVENTS] = sequenced
+		hub.buffered++
+	} else {
+		hub.expiredThrough = hub.replay[hub.replayStart].id
+		hub.replay[hub.replayStart] = sequenced
+		hub.replayStart = (hub.replayStart + 1) % MAX_REPLAY_EVENTS
+	}
+
 	for sub, _ := range hub.subscribers {
-		err := sub.send(event)
+		if !sub.filter.Matches(event) {
+			continue
+		}
+
+		err := sub.send(sequenced)
 		if err != nil {
 			delete(hub.subscribers, sub)
 		}
@@ -117,16 +372,55 @@ func (hub *hub) Close() error {
 	return nil
 }
 
+func (hub *hub) Stats() HubStats {
+	hub.lock.Lock()
+	defer hub.lock.Unlock()
+
+	stats := HubStats{
+		SlowConsumerPolicy: hub.config.SlowConsumerPolicy,
+		Subscribers:        []SubscriberStats{},
+		DroppedEvents:      hub.droppedEvents,
+		CoalescedEvents:    hub.coalescedEvents,
+		Disconnects:        make(map[DisconnectReason]uint64),
+	}
+	for reason, count := range hub.disconnects {
+		stats.Disconnects[reason] = count
+	}
+
+	for sub, _ := range hub.subscribers {
+		subStats := sub.stats()
+		stats.Subscribers = append(stats.Subscribers, subStats)
+		stats.DroppedEvents += subStats.DroppedEvents
+		stats.CoalescedEvents += subStats.CoalescedEvents
+	}
+	sort.Sort(byID(stats.Subscribers))
+
+	return stats
+}
+
+type byID []SubscriberStats
+
+func (s byID) Len() int           { return len(s) }
+func (s byID) Less(i, j int) bool { return s[i].ID < s[j].ID }
+func (s byID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
 func (hub *hub) closeSubscribers() {
 	for sub, _ := range hub.subscribers {
-		_ = sub.Close()
+		_ = sub.close(DisconnectedHubClosed)
 	}
 	hub.subscribers = nil
 }
 
+// subscriberClosed is called once for each source, whichever way it is
+// closed, and moves its counts into the hub's totals.
 func (hub *hub) subscriberClosed(source *hubSource) {
+	stats := source.stats()
+
 	hub.lock.Lock()
 	delete(hub.subscribers, source)
+	hub.droppedEvents += stats.DroppedEvents
+	hub.coalescedEvents += stats.CoalescedEvents
+	hub.disconnects[source.disconnectReason()]++
 	cb := hub.cb
 	count := len(hub.subscribers)
 	hub.lock.Unlock()
@@ -137,60 +431,187 @@ func (hub *hub) subscriberClosed(source *hubSource) {
 }
 
 type hubSource struct {
-	events        chan models.Event
-	closeCallback func(*hubSource)
-	closed        bool
-	lock          sync.Mutex
+	id               uint64
+	filter           models.EventFilter
+	maxPendingEvents int
+	policy           SlowConsumerPolicy
+	closeCallback    func(*hubSource)
+
+	lock            sync.Mutex
+	queue           []sequencedEvent
+	pending         chan struct{}
+	closed          bool
+	reason          DisconnectReason
+	droppedEvents   uint64
+	coalescedEvents uint64
 }
 
-func newSource(maxPendingEvents int, closeCallback func(*hubSource)) *hubSource {
+func newSource(id uint64, maxPendingEvents int, policy SlowConsumerPolicy, filter models.EventFilter, closeCallback func(*hubSource)) *hubSource {
 	return &hubSource{
-		events:        make(chan models.Event, maxPendingEvents),
-		closeCallback: closeCallback,
+		id:               id,
+		filter:           filter,
+		maxPendingEvents: maxPendingEvents,
+		policy:           policy,
+		closeCallback:    closeCallback,
+		pending:          make(chan struct{}, 1),
 	}
 }
 
 func (source *hubSource) Next() (models.Event, error) {
-	event, ok := <-source.events
-	if !ok {
-		return nil, ErrReadFromClosedSource
+	_, event, err := source.NextSequenced()
+	return event, err
+}
+
+// NextSequenced returns the events queued before the source was closed before
+// reporting that it is closed.
+func (source *hubSource) NextSequenced() (uint64, models.Event, error) {
+	for {
+		source.lock.Lock()
+		if len(source.queue) > 0 {
+			event := source.queue[0]
+			source.queue[0] = sequencedEvent{}
+			source.queue = source.queue[1:]
+			source.lock.Unlock()
+			return event.id, event.event, nil
+		}
+		closed := source.closed
+		source.lock.Unlock()
+
+		if closed {
+			return 0, nil, ErrReadFromClosedSource
+		}
+		<-source.pending
 	}
-	return event, nil
 }
 
 func (source *hubSource) Close() error {
+	return source.close(DisconnectedBySubscriber)
+}
+
+func (source *hubSource) close(reason DisconnectReason) error {
 	source.lock.Lock()
 	defer source.lock.Unlock()
 
+	return source.closeLocked(reason)
+}
+
+func (source *hubSource) closeLocked(reason DisconnectReason) error {
 	if source.closed {
 		return ErrSourceAlreadyClosed
 	}
-	close(source.events)
 	source.closed = true
+	source.reason = reason
+	source.notify()
 	go source.closeCallback(source)
 	return nil
 }
 
-func (source *hubSource) send(event models.Event) error {
+func (source *hubSource) notify() {
+	select {
+	case source.pending <- struct{}{}:
+	default:
+	}
+}
+
+func (source *hubSource) send(event sequencedEvent) error {
 	source.lock.Lock()
+	defer source.lock.Unlock()
 
 	if source.closed {
-		source.lock.Unlock()
 		return ErrSendToClosedSource
 	}
 
-	select {
-	case source.events <- event:
-		source.lock.Unlock()
-		return nil
-
-	default:
-		source.lock.Unlock()
-		err := source.Close()
+	if len(source.queue) >= source.maxPendingEvents && !source.makeRoom(event) {
+		err := source.closeLocked(DisconnectedSlowConsumer)
 		if err != nil {
 			return err
 		}
-
 		return ErrSlowConsumer
 	}
+
+	source.queue = append(source.queue, event)
+	source.notify()
+	return nil
+}
+
+// makeRoom applies the slow consumer policy to a full queue, and reports
+// whether the event can now be queued.
+func (source *hubSource) makeRoom(event sequencedEvent) bool {
+	switch source.policy {
+	case DropOldestEvents:
+		source.queue[0] = sequencedEvent{}
+		source.queue = source.queue[1:]
+		source.droppedEvents++
+		return true
+
+	case CoalesceEvents:
+		key := coalesceKey(event.event)
+		for i := len(source.queue) - 1; i >= 0; i-- {
+			if coalesceKey(source.queue[i].event) == key {
+				source.queue = append(source.queue[:i], source.queue[i+1:]...)
+				source.coalescedEvents++
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (source *hubSource) stats() SubscriberStats {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	return SubscriberStats{
+		ID:              source.id,
+		Filter:          NewFilterQuery(source.filter).Encode(),
+		QueueDepth:      len(source.queue),
+		MaxQueueDepth:   source.maxPendingEvents,
+		DroppedEvents:   source.droppedEvents,
+		CoalescedEvents: source.coalescedEvents,
+	}
+}
+
+func (source *hubSource) disconnectReason() DisconnectReason {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+	return source.reason
+}
+
+// coalesceKey identifies the resource an event is about. Crashes are kept
+// apart from the other ActualLRP events so that a later change does not hide
+// them.
+func coalesceKey(event models.Event) string {
+	switch event := event.(type) {
+	case *models.DesiredLRPCreatedEvent, *models.DesiredLRPChangedEvent, *models.DesiredLRPRemovedEvent:
+		return "desired_lrp:" + event.Key()
+
+	case *models.ActualLRPCreatedEvent:
+		return actualLRPKey(event.ActualLrpGroup)
+	case *models.ActualLRPChangedEvent:
+		return actualLRPKey(event.Before)
+	case *models.ActualLRPRemovedEvent:
+		return actualLRPKey(event.ActualLrpGroup)
+	case *models.ActualLRPCrashedEvent:
+		return fmt.Sprintf("actual_lrp_crash:%s/%d", event.ActualLRPKey.ProcessGuid, event.ActualLRPKey.Index)
+
+	case *models.TaskCreatedEvent, *models.TaskChangedEvent, *models.TaskRemovedEvent:
+		return "task:" + event.Key()
+
+	case *models.CellAppearedEvent, *models.CellDisappearedEvent:
+		return "cell:" + event.Key()
+	}
+
+	return event.EventType() + ":" + event.Key()
+}
+
+func actualLRPKey(group *models.ActualLRPGroup) string {
+	actualLRP := group.GetInstance()
+	if actualLRP == nil {
+		actualLRP = group.GetEvacuating()
+	}
+	if actualLRP == nil {
+		return "actual_lrp:"
+	}
+	return fmt.Sprintf("actual_lrp:%s/%d", actualLRP.ProcessGuid, actualLRP.Index)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -26,7 +28,7 @@ var _ = Describe("Hub", func() {
 
 			BeforeEach(func() {
 				var err error
-				eventSource, err = hub.Subscribe()
+				eventSource, err = hub.Subscribe(models.EventFilter{})
 				Expect(err).NotTo(HaveOccurred())
 
 				counts = make(chan int, 1)
@@ -44,7 +46,7 @@ var _ = Describe("Hub", func() {
 				BeforeEach(func() {
 					Eventually(counts).Should(Receive())
 
-					_, err := hub.Subscribe()
+					_, err := hub.Subscribe(models.EventFilter{})
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -85,9 +87,9 @@ var _ = Describe("Hub", func() {
 	})
 
 	It("fans-out events emitted to it to all subscribers", func() {
-		source1, err := hub.Subscribe()
+		source1, err := hub.Subscribe(models.EventFilter{})
 		Expect(err).NotTo(HaveOccurred())
-		source2, err := hub.Subscribe()
+		source2, err := hub.Subscribe(models.EventFilter{})
 		Expect(err).NotTo(HaveOccurred())
 
 		hub.Emit(eventfakes.FakeEvent{Token: "1"})
@@ -100,7 +102,7 @@ var _ = Describe("Hub", func() {
 	})
 
 	It("closes slow consumers after MAX_PENDING_SUBSCRIBER_EVENTS missed events", func() {
-		slowConsumer, err := hub.Subscribe()
+		slowConsumer, err := hub.Subscribe(models.EventFilter{})
 		Expect(err).NotTo(HaveOccurred())
 
 		By("filling the 'buffer'")
@@ -134,9 +136,276 @@ var _ = Describe("Hub", func() {
 		Expect(err).To(Equal(events.ErrReadFromClosedSource))
 	})
 
+	Describe("filtering", func() {
+		var (
+			matching    models.Event
+			notMatching models.Event
+			filter      models.EventFilter
+		)
+
+		BeforeEach(func() {
+			matching = models.NewTaskCreatedEvent(&models.Task{TaskGuid: "a", Domain: "some-domain"})
+			notMatching = models.NewTaskCreatedEvent(&models.Task{TaskGuid: "b", Domain: "other-domain"})
+			filter = models.EventFilter{Domain: "some-domain"}
+		})
+
+		It("only sends the events matching the subscriber's filter", func() {
+			filtered, err := hub.Subscribe(filter)
+			Expect(err).NotTo(HaveOccurred())
+			unfiltered, err := hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(notMatching)
+			hub.Emit(matching)
+
+			Expect(filtered.Next()).To(Equal(matching))
+			Expect(unfiltered.Next()).To(Equal(notMatching))
+			Expect(unfiltered.Next()).To(Equal(matching))
+		})
+
+		It("does not count filtered events towards MAX_PENDING_SUBSCRIBER_EVENTS", func() {
+			filtered, err := hub.Subscribe(filter)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i <= events.MAX_PENDING_SUBSCRIBER_EVENTS; i++ {
+				hub.Emit(notMatching)
+			}
+			hub.Emit(matching)
+
+			Expect(filtered.Next()).To(Equal(matching))
+		})
+
+		It("only replays the missed events matching the filter", func() {
+			source, err := hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(matching)
+			lastEventID, _, err := source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(notMatching)
+			hub.Emit(matching)
+
+			resumed, err := hub.SubscribeAfter(filter, lastEventID)
+			Expect(err).NotTo(HaveOccurred())
+
+			id, event, err := resumed.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(lastEventID + 2))
+			Expect(event).To(Equal(matching))
+		})
+	})
+
+	Describe("resuming a subscription", func() {
+		var lastEventID uint64
+
+		BeforeEach(func() {
+			source, err := hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(eventfakes.FakeEvent{Token: "A"})
+			lastEventID, _, err = source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("numbers events consecutively", func() {
+			source, err := hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(eventfakes.FakeEvent{Token: "B"})
+			hub.Emit(eventfakes.FakeEvent{Token: "C"})
+
+			id, _, err := source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(lastEventID + 1))
+
+			id, _, err = source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(lastEventID + 2))
+		})
+
+		It("replays the events emitted after the last event id", func() {
+			hub.Emit(eventfakes.FakeEvent{Token: "B"})
+			hub.Emit(eventfakes.FakeEvent{Token: "C"})
+
+			source, err := hub.SubscribeAfter(models.EventFilter{}, lastEventID)
+			Expect(err).NotTo(HaveOccurred())
+
+			id, event, err := source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(lastEventID + 1))
+			Expect(event).To(Equal(eventfakes.FakeEvent{Token: "B"}))
+
+			id, event, err = source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(lastEventID + 2))
+			Expect(event).To(Equal(eventfakes.FakeEvent{Token: "C"}))
+
+			hub.Emit(eventfakes.FakeEvent{Token: "D"})
+			Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "D"}))
+		})
+
+		Context("when no events were missed", func() {
+			It("streams only new events", func() {
+				source, err := hub.SubscribeAfter(models.EventFilter{}, lastEventID)
+				Expect(err).NotTo(HaveOccurred())
+
+				hub.Emit(eventfakes.FakeEvent{Token: "B"})
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "B"}))
+			})
+		})
+
+		Context("when the missed events are no longer buffered", func() {
+			BeforeEach(func() {
+				for eventToken := 0; eventToken <= events.MAX_REPLAY_EVENTS; eventToken++ {
+					hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(eventToken)})
+				}
+			})
+
+			It("errors", func() {
+				_, err := hub.SubscribeAfter(models.EventFilter{}, lastEventID)
+				Expect(err).To(Equal(events.ErrEventsExpired))
+			})
+
+			It("can still resume from the oldest buffered event", func() {
+				source, err := hub.SubscribeAfter(models.EventFilter{}, lastEventID+1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "1"}))
+			})
+		})
+
+		Context("when the last event id was not handed out by the hub", func() {
+			It("errors", func() {
+				_, err := hub.SubscribeAfter(models.EventFilter{}, lastEventID+1)
+				Expect(err).To(Equal(events.ErrEventsExpired))
+
+				_, err = hub.SubscribeAfter(models.EventFilter{}, 0)
+				Expect(err).To(Equal(events.ErrEventsExpired))
+			})
+		})
+
+		Context("when the hub is closed", func() {
+			It("errors", func() {
+				Expect(hub.Close()).To(Succeed())
+
+				_, err := hub.SubscribeAfter(models.EventFilter{}, lastEventID)
+				Expect(err).To(Equal(events.ErrSubscribedToClosedHub))
+			})
+		})
+	})
+
+	Describe("sequenced hubs", func() {
+		BeforeEach(func() {
+			hub = events.NewSequencedHub()
+		})
+
+		It("streams events under the ids they were emitted with", func() {
+			source, err := hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.EmitSequenced(10, eventfakes.FakeEvent{Token: "A"})
+			hub.EmitSequenced(15, eventfakes.FakeEvent{Token: "B"})
+
+			id, event, err := source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(BeEquivalentTo(10))
+			Expect(event).To(Equal(eventfakes.FakeEvent{Token: "A"}))
+
+			id, event, err = source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(BeEquivalentTo(15))
+			Expect(event).To(Equal(eventfakes.FakeEvent{Token: "B"}))
+		})
+
+		It("drops events that are not newer than the last one", func() {
+			source, err := hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.EmitSequenced(10, eventfakes.FakeEvent{Token: "A"})
+			hub.EmitSequenced(10, eventfakes.FakeEvent{Token: "duplicate"})
+			hub.EmitSequenced(5, eventfakes.FakeEvent{Token: "older"})
+			hub.EmitSequenced(11, eventfakes.FakeEvent{Token: "B"})
+
+			Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "A"}))
+			Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "B"}))
+		})
+
+		It("refuses events without an id", func() {
+			Expect(func() {
+				hub.Emit(eventfakes.FakeEvent{Token: "unsequenced"})
+			}).To(Panic())
+		})
+
+		Describe("an OutboxOnly view", func() {
+			var view events.Hub
+
+			BeforeEach(func() {
+				view = events.OutboxOnly(hub)
+			})
+
+			It("does not emit events without an id", func() {
+				source, err := view.Subscribe(models.EventFilter{})
+				Expect(err).NotTo(HaveOccurred())
+
+				view.Emit(eventfakes.FakeEvent{Token: "unsequenced"})
+				hub.EmitSequenced(10, eventfakes.FakeEvent{Token: "A"})
+
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "A"}))
+			})
+		})
+
+		It("replays the events emitted after the last event id", func() {
+			hub.EmitSequenced(10, eventfakes.FakeEvent{Token: "A"})
+			hub.EmitSequenced(15, eventfakes.FakeEvent{Token: "B"})
+			hub.EmitSequenced(20, eventfakes.FakeEvent{Token: "C"})
+
+			source, err := hub.SubscribeAfter(models.EventFilter{}, 12)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "B"}))
+			Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "C"}))
+		})
+
+		It("can resume from just before the first event", func() {
+			hub.EmitSequenced(10, eventfakes.FakeEvent{Token: "A"})
+
+			source, err := hub.SubscribeAfter(models.EventFilter{}, 9)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "A"}))
+
+			_, err = hub.SubscribeAfter(models.EventFilter{}, 8)
+			Expect(err).To(Equal(events.ErrEventsExpired))
+		})
+
+		Context("before any event was emitted", func() {
+			It("errors when resuming", func() {
+				_, err := hub.SubscribeAfter(models.EventFilter{}, 10)
+				Expect(err).To(Equal(events.ErrEventsExpired))
+			})
+		})
+
+		Context("when the missed events are no longer buffered", func() {
+			BeforeEach(func() {
+				for i := 0; i <= events.MAX_REPLAY_EVENTS; i++ {
+					hub.EmitSequenced(uint64(10*(i+1)), eventfakes.FakeEvent{Token: strconv.Itoa(i)})
+				}
+			})
+
+			It("errors", func() {
+				_, err := hub.SubscribeAfter(models.EventFilter{}, 5)
+				Expect(err).To(Equal(events.ErrEventsExpired))
+			})
+
+			It("can still resume from the newest expired event", func() {
+				source, err := hub.SubscribeAfter(models.EventFilter{}, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "1"}))
+			})
+		})
+	})
+
 	Describe("closing an event source", func() {
 		It("prevents current events from propagating to the source", func() {
-			source, err := hub.Subscribe()
+			source, err := hub.Subscribe(models.EventFilter{})
 			Expect(err).NotTo(HaveOccurred())
 
 			hub.Emit(eventfakes.FakeEvent{Token: "1"})
@@ -150,7 +419,7 @@ var _ = Describe("Hub", func() {
 		})
 
 		It("prevents future events from propagating to the source", func() {
-			source, err := hub.Subscribe()
+			source, err := hub.Subscribe(models.EventFilter{})
 			Expect(err).NotTo(HaveOccurred())
 
 			err = source.Close()
@@ -163,7 +432,7 @@ var _ = Describe("Hub", func() {
 		})
 
 		It("immediately removes the closed event source from its subscribers", func() {
-			source, err := hub.Subscribe()
+			source, err := hub.Subscribe(models.EventFilter{})
 			Expect(err).NotTo(HaveOccurred())
 
 			counts := make(chan int, 1)
@@ -182,7 +451,7 @@ var _ = Describe("Hub", func() {
 
 		Context("when the source is already closed", func() {
 			It("errors", func() {
-				source, err := hub.Subscribe()
+				source, err := hub.Subscribe(models.EventFilter{})
 				Expect(err).NotTo(HaveOccurred())
 
 				err = source.Close()
@@ -196,7 +465,7 @@ var _ = Describe("Hub", func() {
 
 	Describe("closing the hub", func() {
 		It("all subscribers receive errors", func() {
-			source, err := hub.Subscribe()
+			source, err := hub.Subscribe(models.EventFilter{})
 			Expect(err).NotTo(HaveOccurred())
 
 			err = hub.Close()
@@ -210,7 +479,7 @@ var _ = Describe("Hub", func() {
 			err := hub.Close()
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = hub.Subscribe()
+			_, err = hub.Subscribe(models.EventFilter{})
 			Expect(err).To(Equal(events.ErrSubscribedToClosedHub))
 		})
 
@@ -226,4 +495,139 @@ var _ = Describe("Hub", func() {
 			})
 		})
 	})
+
+	Describe("slow consumer policies", func() {
+		const maxPendingEvents = 3
+
+		var source events.EventSource
+
+		desiredEvent := func(processGuid string, instances int32) models.Event {
+			desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+			desiredLRP.Instances = instances
+			return models.NewDesiredLRPCreatedEvent(desiredLRP)
+		}
+
+		subscribe := func(policy events.SlowConsumerPolicy) {
+			var err error
+			hub = events.NewHubWithConfig(events.HubConfig{
+				MaxPendingEvents:   maxPendingEvents,
+				SlowConsumerPolicy: policy,
+			})
+			source, err = hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Context("when disconnecting slow consumers", func() {
+			BeforeEach(func() {
+				subscribe(events.DisconnectSlowConsumers)
+			})
+
+			It("closes the source once its queue overflows", func() {
+				for i := 0; i < maxPendingEvents+1; i++ {
+					hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(i)})
+				}
+
+				for i := 0; i < maxPendingEvents; i++ {
+					Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: strconv.Itoa(i)}))
+				}
+				_, err := source.Next()
+				Expect(err).To(Equal(events.ErrReadFromClosedSource))
+
+				Eventually(func() map[events.DisconnectReason]uint64 {
+					return hub.Stats().Disconnects
+				}).Should(Equal(map[events.DisconnectReason]uint64{events.DisconnectedSlowConsumer: 1}))
+			})
+		})
+
+		Context("when dropping the oldest events", func() {
+			BeforeEach(func() {
+				subscribe(events.DropOldestEvents)
+			})
+
+			It("keeps the most recent events", func() {
+				for i := 0; i < maxPendingEvents+2; i++ {
+					hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(i)})
+				}
+
+				stats := hub.Stats()
+				Expect(stats.SlowConsumerPolicy).To(Equal(events.DropOldestEvents))
+				Expect(stats.DroppedEvents).To(BeEquivalentTo(2))
+				Expect(stats.Subscribers).To(HaveLen(1))
+				Expect(stats.Subscribers[0].QueueDepth).To(Equal(maxPendingEvents))
+				Expect(stats.Subscribers[0].DroppedEvents).To(BeEquivalentTo(2))
+
+				for i := 2; i < maxPendingEvents+2; i++ {
+					Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: strconv.Itoa(i)}))
+				}
+			})
+		})
+
+		Context("when coalescing events", func() {
+			BeforeEach(func() {
+				subscribe(events.CoalesceEvents)
+			})
+
+			It("replaces the queued event about the same resource", func() {
+				hub.Emit(desiredEvent("guid-a", 1))
+				hub.Emit(desiredEvent("guid-b", 1))
+				hub.Emit(desiredEvent("guid-c", 1))
+				hub.Emit(desiredEvent("guid-a", 2))
+
+				Expect(hub.Stats().CoalescedEvents).To(BeEquivalentTo(1))
+				Expect(source.Next()).To(Equal(desiredEvent("guid-b", 1)))
+				Expect(source.Next()).To(Equal(desiredEvent("guid-c", 1)))
+				Expect(source.Next()).To(Equal(desiredEvent("guid-a", 2)))
+			})
+
+			It("disconnects the subscriber when no queued event is about the same resource", func() {
+				hub.Emit(desiredEvent("guid-a", 1))
+				hub.Emit(desiredEvent("guid-b", 1))
+				hub.Emit(desiredEvent("guid-c", 1))
+				hub.Emit(desiredEvent("guid-d", 1))
+
+				for i := 0; i < maxPendingEvents; i++ {
+					_, err := source.Next()
+					Expect(err).NotTo(HaveOccurred())
+				}
+				_, err := source.Next()
+				Expect(err).To(Equal(events.ErrReadFromClosedSource))
+			})
+		})
+	})
+
+	Describe("Stats", func() {
+		It("reports each subscriber's queue", func() {
+			_, err := hub.Subscribe(models.EventFilter{Domain: "some-domain"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(eventfakes.FakeEvent{Token: "1"})
+
+			stats := hub.Stats()
+			Expect(stats.SlowConsumerPolicy).To(Equal(events.DisconnectSlowConsumers))
+			Expect(stats.Subscribers).To(Equal([]events.SubscriberStats{
+				{ID: 1, Filter: "domain=some-domain", QueueDepth: 0, MaxQueueDepth: events.MAX_PENDING_SUBSCRIBER_EVENTS},
+				{ID: 2, Filter: "", QueueDepth: 1, MaxQueueDepth: events.MAX_PENDING_SUBSCRIBER_EVENTS},
+			}))
+			Expect(stats.MaxQueueDepth()).To(Equal(1))
+		})
+
+		It("counts why subscribers went away", func() {
+			source, err := hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = hub.Subscribe(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(source.Close()).To(Succeed())
+			Expect(hub.Close()).To(Succeed())
+
+			Eventually(func() map[events.DisconnectReason]uint64 {
+				return hub.Stats().Disconnects
+			}).Should(Equal(map[events.DisconnectReason]uint64{
+				events.DisconnectedBySubscriber: 1,
+				events.DisconnectedHubClosed:    1,
+			}))
+		})
+	})
 })
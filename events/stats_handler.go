@@ -0,0 +1,23 @@
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// NewStatsHandler serves the stats of each hub as a JSON object keyed by the
+// hub's name.
+func NewStatsHandler(hubs map[string]Hub) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats := make(map[string]HubStats, len(hubs))
+		for name, hub := range hubs {
+			stats[name] = hub.Stats()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(stats)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
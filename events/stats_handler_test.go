@@ -0,0 +1,43 @@
+package events_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatsHandler", func() {
+	It("serves the stats of every hub", func() {
+		taskHub := events.NewHubWithConfig(events.HubConfig{
+			MaxPendingEvents:   10,
+			SlowConsumerPolicy: events.DropOldestEvents,
+		})
+		_, err := taskHub.Subscribe(models.EventFilter{CellID: "cell-1"})
+		Expect(err).NotTo(HaveOccurred())
+
+		handler := events.NewStatsHandler(map[string]events.Hub{"Task": taskHub})
+
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest("GET", "/debug/event-subscribers", nil)
+		Expect(err).NotTo(HaveOccurred())
+		handler.ServeHTTP(recorder, request)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var stats map[string]events.HubStats
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &stats)).To(Succeed())
+		Expect(stats).To(HaveKey("Task"))
+		Expect(stats["Task"].SlowConsumerPolicy).To(Equal(events.DropOldestEvents))
+		Expect(stats["Task"].Subscribers).To(Equal([]events.SubscriberStats{
+			{ID: 1, Filter: "cell_id=cell-1", MaxQueueDepth: 10},
+		}))
+
+	})
+})
@@ -0,0 +1,216 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WebSocketFormatParam      = "format"
+	WebSocketLastEventIDParam = "last_event_id"
+)
+
+// WebSocketFormat is the encoding of the events sent over a WebSocket.
+// Protobuf events are sent as binary messages holding a models.EventFrame,
+// and JSON events as text messages holding a WebSocketJSONFrame.
+type WebSocketFormat string
+
+const (
+	WebSocketProtobufFormat WebSocketFormat = "protobuf"
+	WebSocketJSONFormat     WebSocketFormat = "json"
+)
+
+func (f WebSocketFormat) Valid() bool {
+	return f == WebSocketProtobufFormat || f == WebSocketJSONFormat
+}
+
+var ErrUnexpectedWebSocketMessage = errors.New("unexpected websocket message type")
+
+type WebSocketJSONFrame struct {
+	ID   uint64          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// EncodeWebSocketFrame returns the WebSocket message type and payload that
+// carry the event in the given format.
+func EncodeWebSocketFrame(format WebSocketFormat, eventID uint64, event models.Event) (int, []byte, error) {
+	if format == WebSocketJSONFormat {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		frame, err := json.Marshal(WebSocketJSONFrame{ID: eventID, Type: event.EventType(), Data: data})
+		if err != nil {
+			return 0, nil, err
+		}
+		return websocket.TextMessage, frame, nil
+	}
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	frame, err := proto.Marshal(&models.EventFrame{Id: eventID, Type: event.EventType(), Payload: payload})
+	if err != nil {
+		return 0, nil, err
+	}
+	return websocket.BinaryMessage, frame, nil
+}
+
+// DecodeWebSocketFrame parses a message written by EncodeWebSocketFrame in
+// either format.
+func DecodeWebSocketFrame(messageType int, data []byte) (uint64, models.Event, error) {
+	switch messageType {
+	case websocket.BinaryMessage:
+		var frame models.EventFrame
+		err := proto.Unmarshal(data, &frame)
+		if err != nil {
+			return 0, nil, NewInvalidPayloadError("", err)
+		}
+
+		event := models.NewEventOfType(frame.Type)
+		if event == nil {
+			return 0, nil, NewInvalidPayloadError(frame.Type, errors.New("unknown event type"))
+		}
+
+		err = proto.Unmarshal(frame.Payload, event)
+		if err != nil {
+			return 0, nil, NewInvalidPayloadError(frame.Type, err)
+		}
+		return frame.Id, event, nil
+
+	case websocket.TextMessage:
+		var frame WebSocketJSONFrame
+		err := json.Unmarshal(data, &frame)
+		if err != nil {
+			return 0, nil, NewInvalidPayloadError("", err)
+		}
+
+		event := models.NewEventOfType(frame.Type)
+		if event == nil {
+			return 0, nil, NewInvalidPayloadError(frame.Type, errors.New("unknown event type"))
+		}
+
+		err = json.Unmarshal(frame.Data, event)
+		if err != nil {
+			return 0, nil, NewInvalidPayloadError(frame.Type, err)
+		}
+		return frame.ID, event, nil
+	}
+
+	return 0, nil, ErrUnexpectedWebSocketMessage
+}
+
+// WebSocketDialer opens a WebSocket event stream that resumes after the given
+// event id, or starts afresh if it is zero. The response is that of the
+// opening handshake, when there is one.
+type WebSocketDialer func(lastEventID uint64) (*websocket.Conn, *http.Response, error)
+
+type webSocketEventSource struct {
+	dial        WebSocketDialer
+	lock        sync.Mutex
+	conn        *websocket.Conn
+	lastEventID uint64
+	closed      bool
+}
+
+// NewWebSocketEventSource returns an EventSource reading from a WebSocket
+// event stream. When the stream breaks, it dials again to resume after the
+// last event it read; if the server can no longer resume it, it starts a new
+// stream and returns ErrResyncRequired.
+func NewWebSocketEventSource(dial WebSocketDialer) (EventSource, error) {
+	conn, _, err := dial(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webSocketEventSource{dial: dial, conn: conn}, nil
+}
+
+func (e *webSocketEventSource) Next() (models.Event, error) {
+	for {
+		e.lock.Lock()
+		conn := e.conn
+		e.lock.Unlock()
+
+		messageType, data, err := conn.ReadMessage()
+		if err == nil {
+			id, event, err := DecodeWebSocketFrame(messageType, data)
+			if err != nil {
+				return nil, err
+			}
+
+			e.lock.Lock()
+			e.lastEventID = id
+			e.lock.Unlock()
+			return event, nil
+		}
+
+		err = e.reconnect(conn)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (e *webSocketEventSource) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.closed {
+		return ErrSourceClosed
+	}
+	e.closed = true
+
+	err := e.conn.Close()
+	if err != nil {
+		return NewCloseError(err)
+	}
+	return nil
+}
+
+func (e *webSocketEventSource) reconnect(stale *websocket.Conn) error {
+	e.lock.Lock()
+	closed := e.closed
+	lastEventID := e.lastEventID
+	e.lock.Unlock()
+
+	if closed {
+		return ErrSourceClosed
+	}
+
+	resync := false
+	conn, response, err := e.dial(lastEventID)
+	if err != nil && lastEventID != 0 && response != nil && response.StatusCode == http.StatusGone {
+		resync = true
+		conn, _, err = e.dial(0)
+	}
+	if err != nil {
+		return NewRawEventSourceError(err)
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.closed {
+		conn.Close()
+		return ErrSourceClosed
+	}
+
+	stale.Close()
+	e.conn = conn
+	if resync {
+		e.lastEventID = 0
+		return ErrResyncRequired
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package events_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebSocket", func() {
+	Describe("frames", func() {
+		var event models.Event
+
+		BeforeEach(func() {
+			event = models.NewDesiredLRPCreatedEvent(model_helpers.NewValidDesiredLRP("some-guid"))
+		})
+
+		DescribeTable("round trips the event",
+			func(format events.WebSocketFormat, expectedMessageType int) {
+				messageType, data, err := events.EncodeWebSocketFrame(format, 42, event)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(messageType).To(Equal(expectedMessageType))
+
+				id, decoded, err := events.DecodeWebSocketFrame(messageType, data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(BeEquivalentTo(42))
+				Expect(decoded).To(Equal(event))
+			},
+			Entry("protobuf", events.WebSocketProtobufFormat, websocket.BinaryMessage),
+			Entry("json", events.WebSocketJSONFormat, websocket.TextMessage),
+		)
+
+		It("errors on unknown event types", func() {
+			_, _, err := events.DecodeWebSocketFrame(websocket.TextMessage, []byte(`{"id":1,"type":"bogus","data":{}}`))
+			Expect(err).To(BeAssignableToTypeOf(events.NewInvalidPayloadError("", nil)))
+		})
+
+		It("errors on other message types", func() {
+			_, _, err := events.DecodeWebSocketFrame(websocket.PingMessage, nil)
+			Expect(err).To(Equal(events.ErrUnexpectedWebSocketMessage))
+		})
+	})
+
+	Describe("EventSource", func() {
+		var (
+			server      *httptest.Server
+			hub         events.Hub
+			eventSource events.EventSource
+			desiredLRP  *models.DesiredLRP
+			dropConns   chan struct{}
+			lastIDs     chan string
+		)
+
+		BeforeEach(func() {
+			hub = events.NewHub()
+			desiredLRP = model_helpers.NewValidDesiredLRP("some-guid")
+			dropConns = make(chan struct{})
+			lastIDs = make(chan string, 10)
+
+			upgrader := websocket.Upgrader{}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lastID := r.URL.Query().Get(events.WebSocketLastEventIDParam)
+				lastIDs <- lastID
+
+				var source events.SequencedEventSource
+				var err error
+				if lastID == "" {
+					source, err = hub.Subscribe(models.EventFilter{})
+				} else {
+					id, _ := strconv.ParseUint(lastID, 10, 64)
+					source, err = hub.SubscribeAfter(models.EventFilter{}, id)
+				}
+				if err == events.ErrEventsExpired {
+					w.WriteHeader(http.StatusGone)
+					return
+				}
+				Expect(err).NotTo(HaveOccurred())
+				defer source.Close()
+
+				conn, err := upgrader.Upgrade(w, r, nil)
+				Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+
+				go func() {
+					<-dropConns
+					source.Close()
+				}()
+
+				for {
+					id, event, err := source.NextSequenced()
+					if err != nil {
+						return
+					}
+					messageType, data, err := events.EncodeWebSocketFrame(events.WebSocketProtobufFormat, id, event)
+					Expect(err).NotTo(HaveOccurred())
+					if conn.WriteMessage(messageType, data) != nil {
+						return
+					}
+				}
+			}))
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			eventSource, err = events.NewWebSocketEventSource(func(lastEventID uint64) (*websocket.Conn, *http.Response, error) {
+				url := "ws" + strings.TrimPrefix(server.URL, "http")
+				if lastEventID != 0 {
+					url += "?" + events.WebSocketLastEventIDParam + "=" + strconv.FormatUint(lastEventID, 10)
+				}
+				return websocket.DefaultDialer.Dial(url, nil)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(lastIDs).Should(Receive(Equal("")))
+		})
+
+		AfterEach(func() {
+			eventSource.Close()
+			hub.Close()
+			server.Close()
+		})
+
+		It("reads the events", func() {
+			event := models.NewDesiredLRPCreatedEvent(desiredLRP)
+			hub.Emit(event)
+			Expect(eventSource.Next()).To(Equal(event))
+		})
+
+		Context("when the connection drops", func() {
+			It("resumes after the last event it read", func() {
+				hub.Emit(models.NewDesiredLRPCreatedEvent(desiredLRP))
+				_, err := eventSource.Next()
+				Expect(err).NotTo(HaveOccurred())
+
+				nextEvent := make(chan models.Event)
+				go func() {
+					defer GinkgoRecover()
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+					nextEvent <- event
+				}()
+
+				dropConns <- struct{}{}
+				Eventually(lastIDs).Should(Receive(Not(BeEmpty())))
+
+				removed := models.NewDesiredLRPRemovedEvent(desiredLRP)
+				hub.Emit(removed)
+				Eventually(nextEvent).Should(Receive(Equal(removed)))
+			})
+		})
+
+		Context("when the events it missed are no longer available", func() {
+			It("starts a new stream and requires a resync", func() {
+				hub.Emit(models.NewDesiredLRPCreatedEvent(desiredLRP))
+				_, err := eventSource.Next()
+				Expect(err).NotTo(HaveOccurred())
+
+				dropConns <- struct{}{}
+				hub.Close()
+				hub = events.NewHub()
+
+				_, err = eventSource.Next()
+				Expect(err).To(Equal(events.ErrResyncRequired))
+
+				removed := models.NewDesiredLRPRemovedEvent(desiredLRP)
+				hub.Emit(removed)
+				Expect(eventSource.Next()).To(Equal(removed))
+			})
+		})
+
+		Context("when closed", func() {
+			It("returns ErrSourceClosed", func() {
+				Expect(eventSource.Close()).To(Succeed())
+				_, err := eventSource.Next()
+				Expect(err).To(Equal(events.ErrSourceClosed))
+				Expect(eventSource.Close()).To(Equal(events.ErrSourceClosed))
+			})
+		})
+	})
+})
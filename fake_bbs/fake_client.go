@@ -232,6 +232,202 @@ type FakeClient struct {
 		result1 []*models.CellPresence
 		result2 error
 	}
+	AuditRecordsStub        func(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error)
+	auditRecordsMutex       sync.RWMutex
+	auditRecordsArgsForCall []struct {
+		logger lager.Logger
+		filter models.AuditRecordFilter
+	}
+	auditRecordsReturns struct {
+		result1 []*models.AuditRecord
+		result2 error
+	}
+	SubscribeToTaskEventsStub        func(logger lager.Logger) (events.EventSource, error)
+	subscribeToTaskEventsMutex       sync.RWMutex
+	subscribeToTaskEventsArgsForCall []struct {
+		logger lager.Logger
+	}
+	subscribeToTaskEventsReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	SubscribeToTaskEventsByFilterStub        func(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+	subscribeToTaskEventsByFilterMutex       sync.RWMutex
+	subscribeToTaskEventsByFilterArgsForCall []struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}
+	subscribeToTaskEventsByFilterReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	SubscribeToDesiredLRPEventsByFilterStub        func(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+	subscribeToDesiredLRPEventsByFilterMutex       sync.RWMutex
+	subscribeToDesiredLRPEventsByFilterArgsForCall []struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}
+	subscribeToDesiredLRPEventsByFilterReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	SubscribeToActualLRPEventsByFilterStub        func(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+	subscribeToActualLRPEventsByFilterMutex       sync.RWMutex
+	subscribeToActualLRPEventsByFilterArgsForCall []struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}
+	subscribeToActualLRPEventsByFilterReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	SubscribeToCellEventsStub        func(logger lager.Logger) (events.EventSource, error)
+	subscribeToCellEventsMutex       sync.RWMutex
+	subscribeToCellEventsArgsForCall []struct {
+		logger lager.Logger
+	}
+	subscribeToCellEventsReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	SubscribeToDesiredLRPEventsOverWebSocketStub        func(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+	subscribeToDesiredLRPEventsOverWebSocketMutex       sync.RWMutex
+	subscribeToDesiredLRPEventsOverWebSocketArgsForCall []struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}
+	subscribeToDesiredLRPEventsOverWebSocketReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	SubscribeToActualLRPEventsOverWebSocketStub        func(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+	subscribeToActualLRPEventsOverWebSocketMutex       sync.RWMutex
+	subscribeToActualLRPEventsOverWebSocketArgsForCall []struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}
+	subscribeToActualLRPEventsOverWebSocketReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	ActualLRPsStub        func(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRP, error)
+	actualLRPsMutex       sync.RWMutex
+	actualLRPsArgsForCall []struct {
+		logger lager.Logger
+		filter models.ActualLRPFilter
+	}
+	actualLRPsReturns struct {
+		result1 []*models.ActualLRP
+		result2 error
+	}
+	SubscribeToLRPInstanceEventsStub        func(logger lager.Logger, filter models.EventFilter) (events.EventSource, error)
+	subscribeToLRPInstanceEventsMutex       sync.RWMutex
+	subscribeToLRPInstanceEventsArgsForCall []struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}
+	subscribeToLRPInstanceEventsReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
+	TasksByFilterStub        func(logger lager.Logger, filter models.TaskFilter) ([]*models.Task, error)
+	tasksByFilterMutex       sync.RWMutex
+	tasksByFilterArgsForCall []struct {
+		logger lager.Logger
+		filter models.TaskFilter
+	}
+	tasksByFilterReturns struct {
+		result1 []*models.Task
+		result2 error
+	}
+	TaskSchedulesStub        func(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error)
+	taskSchedulesMutex       sync.RWMutex
+	taskSchedulesArgsForCall []struct {
+		logger lager.Logger
+		filter models.TaskScheduleFilter
+	}
+	taskSchedulesReturns struct {
+		result1 []*models.TaskSchedule
+		result2 error
+	}
+	TaskScheduleByGuidStub        func(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error)
+	taskScheduleByGuidMutex       sync.RWMutex
+	taskScheduleByGuidArgsForCall []struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}
+	taskScheduleByGuidReturns struct {
+		result1 *models.TaskSchedule
+		result2 error
+	}
+	DesireTaskScheduleStub        func(logger lager.Logger, schedule *models.TaskSchedule) error
+	desireTaskScheduleMutex       sync.RWMutex
+	desireTaskScheduleArgsForCall []struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}
+	desireTaskScheduleReturns struct {
+		result1 error
+	}
+	UpdateTaskScheduleStub        func(logger lager.Logger, schedule *models.TaskSchedule) error
+	updateTaskScheduleMutex       sync.RWMutex
+	updateTaskScheduleArgsForCall []struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}
+	updateTaskScheduleReturns struct {
+		result1 error
+	}
+	RemoveTaskScheduleStub        func(logger lager.Logger, scheduleGuid string) error
+	removeTaskScheduleMutex       sync.RWMutex
+	removeTaskScheduleArgsForCall []struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}
+	removeTaskScheduleReturns struct {
+		result1 error
+	}
+	CancelTaskWithReasonStub        func(logger lager.Logger, taskGuid string, reason string) error
+	cancelTaskWithReasonMutex       sync.RWMutex
+	cancelTaskWithReasonArgsForCall []struct {
+		logger   lager.Logger
+		taskGuid string
+		reason   string
+	}
+	cancelTaskWithReasonReturns struct {
+		result1 error
+	}
+	CancelTasksStub        func(logger lager.Logger, selector *models.TaskSelector, reason string) ([]*models.TaskResult, error)
+	cancelTasksMutex       sync.RWMutex
+	cancelTasksArgsForCall []struct {
+		logger   lager.Logger
+		selector *models.TaskSelector
+		reason   string
+	}
+	cancelTasksReturns struct {
+		result1 []*models.TaskResult
+		result2 error
+	}
+	DeleteTasksStub        func(logger lager.Logger, selector *models.TaskSelector) ([]*models.TaskResult, error)
+	deleteTasksMutex       sync.RWMutex
+	deleteTasksArgsForCall []struct {
+		logger   lager.Logger
+		selector *models.TaskSelector
+	}
+	deleteTasksReturns struct {
+		result1 []*models.TaskResult
+		result2 error
+	}
+	ArchivedTasksStub        func(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error)
+	archivedTasksMutex       sync.RWMutex
+	archivedTasksArgsForCall []struct {
+		logger lager.Logger
+		filter models.ArchivedTaskFilter
+	}
+	archivedTasksReturns struct {
+		result1 []*models.Task
+		result2 error
+	}
 }
 
 func (fake *FakeClient) DesireTask(logger lager.Logger, guid string, domain string, def *models.TaskDefinition) error {
@@ -1006,4 +1202,680 @@ func (fake *FakeClient) CellsReturns(result1 []*models.CellPresence, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeClient) AuditRecords(logger lager.Logger, filter models.AuditRecordFilter) ([]*models.AuditRecord, error) {
+	fake.auditRecordsMutex.Lock()
+	fake.auditRecordsArgsForCall = append(fake.auditRecordsArgsForCall, struct {
+		logger lager.Logger
+		filter models.AuditRecordFilter
+	}{logger, filter})
+	fake.auditRecordsMutex.Unlock()
+	if fake.AuditRecordsStub != nil {
+		return fake.AuditRecordsStub(logger, filter)
+	} else {
+		return fake.auditRecordsReturns.result1, fake.auditRecordsReturns.result2
+	}
+}
+
+func (fake *FakeClient) AuditRecordsCallCount() int {
+	fake.auditRecordsMutex.RLock()
+	defer fake.auditRecordsMutex.RUnlock()
+	return len(fake.auditRecordsArgsForCall)
+}
+
+func (fake *FakeClient) AuditRecordsArgsForCall(i int) (lager.Logger, models.AuditRecordFilter) {
+	fake.auditRecordsMutex.RLock()
+	defer fake.auditRecordsMutex.RUnlock()
+	return fake.auditRecordsArgsForCall[i].logger, fake.auditRecordsArgsForCall[i].filter
+}
+
+func (fake *FakeClient) AuditRecordsReturns(result1 []*models.AuditRecord, result2 error) {
+	fake.AuditRecordsStub = nil
+	fake.auditRecordsReturns = struct {
+		result1 []*models.AuditRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToTaskEvents(logger lager.Logger) (events.EventSource, error) {
+	fake.subscribeToTaskEventsMutex.Lock()
+	fake.subscribeToTaskEventsArgsForCall = append(fake.subscribeToTaskEventsArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.subscribeToTaskEventsMutex.Unlock()
+	if fake.SubscribeToTaskEventsStub != nil {
+		return fake.SubscribeToTaskEventsStub(logger)
+	} else {
+		return fake.subscribeToTaskEventsReturns.result1, fake.subscribeToTaskEventsReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToTaskEventsCallCount() int {
+	fake.subscribeToTaskEventsMutex.RLock()
+	defer fake.subscribeToTaskEventsMutex.RUnlock()
+	return len(fake.subscribeToTaskEventsArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToTaskEventsArgsForCall(i int) lager.Logger {
+	fake.subscribeToTaskEventsMutex.RLock()
+	defer fake.subscribeToTaskEventsMutex.RUnlock()
+	return fake.subscribeToTaskEventsArgsForCall[i].logger
+}
+
+func (fake *FakeClient) SubscribeToTaskEventsReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToTaskEventsStub = nil
+	fake.subscribeToTaskEventsReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToTaskEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	fake.subscribeToTaskEventsByFilterMutex.Lock()
+	fake.subscribeToTaskEventsByFilterArgsForCall = append(fake.subscribeToTaskEventsByFilterArgsForCall, struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}{logger, filter})
+	fake.subscribeToTaskEventsByFilterMutex.Unlock()
+	if fake.SubscribeToTaskEventsByFilterStub != nil {
+		return fake.SubscribeToTaskEventsByFilterStub(logger, filter)
+	} else {
+		return fake.subscribeToTaskEventsByFilterReturns.result1, fake.subscribeToTaskEventsByFilterReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToTaskEventsByFilterCallCount() int {
+	fake.subscribeToTaskEventsByFilterMutex.RLock()
+	defer fake.subscribeToTaskEventsByFilterMutex.RUnlock()
+	return len(fake.subscribeToTaskEventsByFilterArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToTaskEventsByFilterArgsForCall(i int) (lager.Logger, models.EventFilter) {
+	fake.subscribeToTaskEventsByFilterMutex.RLock()
+	defer fake.subscribeToTaskEventsByFilterMutex.RUnlock()
+	return fake.subscribeToTaskEventsByFilterArgsForCall[i].logger, fake.subscribeToTaskEventsByFilterArgsForCall[i].filter
+}
+
+func (fake *FakeClient) SubscribeToTaskEventsByFilterReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToTaskEventsByFilterStub = nil
+	fake.subscribeToTaskEventsByFilterReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	fake.subscribeToDesiredLRPEventsByFilterMutex.Lock()
+	fake.subscribeToDesiredLRPEventsByFilterArgsForCall = append(fake.subscribeToDesiredLRPEventsByFilterArgsForCall, struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}{logger, filter})
+	fake.subscribeToDesiredLRPEventsByFilterMutex.Unlock()
+	if fake.SubscribeToDesiredLRPEventsByFilterStub != nil {
+		return fake.SubscribeToDesiredLRPEventsByFilterStub(logger, filter)
+	} else {
+		return fake.subscribeToDesiredLRPEventsByFilterReturns.result1, fake.subscribeToDesiredLRPEventsByFilterReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsByFilterCallCount() int {
+	fake.subscribeToDesiredLRPEventsByFilterMutex.RLock()
+	defer fake.subscribeToDesiredLRPEventsByFilterMutex.RUnlock()
+	return len(fake.subscribeToDesiredLRPEventsByFilterArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsByFilterArgsForCall(i int) (lager.Logger, models.EventFilter) {
+	fake.subscribeToDesiredLRPEventsByFilterMutex.RLock()
+	defer fake.subscribeToDesiredLRPEventsByFilterMutex.RUnlock()
+	return fake.subscribeToDesiredLRPEventsByFilterArgsForCall[i].logger, fake.subscribeToDesiredLRPEventsByFilterArgsForCall[i].filter
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsByFilterReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToDesiredLRPEventsByFilterStub = nil
+	fake.subscribeToDesiredLRPEventsByFilterReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsByFilter(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	fake.subscribeToActualLRPEventsByFilterMutex.Lock()
+	fake.subscribeToActualLRPEventsByFilterArgsForCall = append(fake.subscribeToActualLRPEventsByFilterArgsForCall, struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}{logger, filter})
+	fake.subscribeToActualLRPEventsByFilterMutex.Unlock()
+	if fake.SubscribeToActualLRPEventsByFilterStub != nil {
+		return fake.SubscribeToActualLRPEventsByFilterStub(logger, filter)
+	} else {
+		return fake.subscribeToActualLRPEventsByFilterReturns.result1, fake.subscribeToActualLRPEventsByFilterReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsByFilterCallCount() int {
+	fake.subscribeToActualLRPEventsByFilterMutex.RLock()
+	defer fake.subscribeToActualLRPEventsByFilterMutex.RUnlock()
+	return len(fake.subscribeToActualLRPEventsByFilterArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsByFilterArgsForCall(i int) (lager.Logger, models.EventFilter) {
+	fake.subscribeToActualLRPEventsByFilterMutex.RLock()
+	defer fake.subscribeToActualLRPEventsByFilterMutex.RUnlock()
+	return fake.subscribeToActualLRPEventsByFilterArgsForCall[i].logger, fake.subscribeToActualLRPEventsByFilterArgsForCall[i].filter
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsByFilterReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToActualLRPEventsByFilterStub = nil
+	fake.subscribeToActualLRPEventsByFilterReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToCellEvents(logger lager.Logger) (events.EventSource, error) {
+	fake.subscribeToCellEventsMutex.Lock()
+	fake.subscribeToCellEventsArgsForCall = append(fake.subscribeToCellEventsArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.subscribeToCellEventsMutex.Unlock()
+	if fake.SubscribeToCellEventsStub != nil {
+		return fake.SubscribeToCellEventsStub(logger)
+	} else {
+		return fake.subscribeToCellEventsReturns.result1, fake.subscribeToCellEventsReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToCellEventsCallCount() int {
+	fake.subscribeToCellEventsMutex.RLock()
+	defer fake.subscribeToCellEventsMutex.RUnlock()
+	return len(fake.subscribeToCellEventsArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToCellEventsArgsForCall(i int) lager.Logger {
+	fake.subscribeToCellEventsMutex.RLock()
+	defer fake.subscribeToCellEventsMutex.RUnlock()
+	return fake.subscribeToCellEventsArgsForCall[i].logger
+}
+
+func (fake *FakeClient) SubscribeToCellEventsReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToCellEventsStub = nil
+	fake.subscribeToCellEventsReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsOverWebSocket(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	fake.subscribeToDesiredLRPEventsOverWebSocketMutex.Lock()
+	fake.subscribeToDesiredLRPEventsOverWebSocketArgsForCall = append(fake.subscribeToDesiredLRPEventsOverWebSocketArgsForCall, struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}{logger, filter})
+	fake.subscribeToDesiredLRPEventsOverWebSocketMutex.Unlock()
+	if fake.SubscribeToDesiredLRPEventsOverWebSocketStub != nil {
+		return fake.SubscribeToDesiredLRPEventsOverWebSocketStub(logger, filter)
+	} else {
+		return fake.subscribeToDesiredLRPEventsOverWebSocketReturns.result1, fake.subscribeToDesiredLRPEventsOverWebSocketReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsOverWebSocketCallCount() int {
+	fake.subscribeToDesiredLRPEventsOverWebSocketMutex.RLock()
+	defer fake.subscribeToDesiredLRPEventsOverWebSocketMutex.RUnlock()
+	return len(fake.subscribeToDesiredLRPEventsOverWebSocketArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsOverWebSocketArgsForCall(i int) (lager.Logger, models.EventFilter) {
+	fake.subscribeToDesiredLRPEventsOverWebSocketMutex.RLock()
+	defer fake.subscribeToDesiredLRPEventsOverWebSocketMutex.RUnlock()
+	return fake.subscribeToDesiredLRPEventsOverWebSocketArgsForCall[i].logger, fake.subscribeToDesiredLRPEventsOverWebSocketArgsForCall[i].filter
+}
+
+func (fake *FakeClient) SubscribeToDesiredLRPEventsOverWebSocketReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToDesiredLRPEventsOverWebSocketStub = nil
+	fake.subscribeToDesiredLRPEventsOverWebSocketReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsOverWebSocket(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	fake.subscribeToActualLRPEventsOverWebSocketMutex.Lock()
+	fake.subscribeToActualLRPEventsOverWebSocketArgsForCall = append(fake.subscribeToActualLRPEventsOverWebSocketArgsForCall, struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}{logger, filter})
+	fake.subscribeToActualLRPEventsOverWebSocketMutex.Unlock()
+	if fake.SubscribeToActualLRPEventsOverWebSocketStub != nil {
+		return fake.SubscribeToActualLRPEventsOverWebSocketStub(logger, filter)
+	} else {
+		return fake.subscribeToActualLRPEventsOverWebSocketReturns.result1, fake.subscribeToActualLRPEventsOverWebSocketReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsOverWebSocketCallCount() int {
+	fake.subscribeToActualLRPEventsOverWebSocketMutex.RLock()
+	defer fake.subscribeToActualLRPEventsOverWebSocketMutex.RUnlock()
+	return len(fake.subscribeToActualLRPEventsOverWebSocketArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsOverWebSocketArgsForCall(i int) (lager.Logger, models.EventFilter) {
+	fake.subscribeToActualLRPEventsOverWebSocketMutex.RLock()
+	defer fake.subscribeToActualLRPEventsOverWebSocketMutex.RUnlock()
+	return fake.subscribeToActualLRPEventsOverWebSocketArgsForCall[i].logger, fake.subscribeToActualLRPEventsOverWebSocketArgsForCall[i].filter
+}
+
+func (fake *FakeClient) SubscribeToActualLRPEventsOverWebSocketReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToActualLRPEventsOverWebSocketStub = nil
+	fake.subscribeToActualLRPEventsOverWebSocketReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ActualLRPs(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRP, error) {
+	fake.actualLRPsMutex.Lock()
+	fake.actualLRPsArgsForCall = append(fake.actualLRPsArgsForCall, struct {
+		logger lager.Logger
+		filter models.ActualLRPFilter
+	}{logger, filter})
+	fake.actualLRPsMutex.Unlock()
+	if fake.ActualLRPsStub != nil {
+		return fake.ActualLRPsStub(logger, filter)
+	} else {
+		return fake.actualLRPsReturns.result1, fake.actualLRPsReturns.result2
+	}
+}
+
+func (fake *FakeClient) ActualLRPsCallCount() int {
+	fake.actualLRPsMutex.RLock()
+	defer fake.actualLRPsMutex.RUnlock()
+	return len(fake.actualLRPsArgsForCall)
+}
+
+func (fake *FakeClient) ActualLRPsArgsForCall(i int) (lager.Logger, models.ActualLRPFilter) {
+	fake.actualLRPsMutex.RLock()
+	defer fake.actualLRPsMutex.RUnlock()
+	return fake.actualLRPsArgsForCall[i].logger, fake.actualLRPsArgsForCall[i].filter
+}
+
+func (fake *FakeClient) ActualLRPsReturns(result1 []*models.ActualLRP, result2 error) {
+	fake.ActualLRPsStub = nil
+	fake.actualLRPsReturns = struct {
+		result1 []*models.ActualLRP
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SubscribeToLRPInstanceEvents(logger lager.Logger, filter models.EventFilter) (events.EventSource, error) {
+	fake.subscribeToLRPInstanceEventsMutex.Lock()
+	fake.subscribeToLRPInstanceEventsArgsForCall = append(fake.subscribeToLRPInstanceEventsArgsForCall, struct {
+		logger lager.Logger
+		filter models.EventFilter
+	}{logger, filter})
+	fake.subscribeToLRPInstanceEventsMutex.Unlock()
+	if fake.SubscribeToLRPInstanceEventsStub != nil {
+		return fake.SubscribeToLRPInstanceEventsStub(logger, filter)
+	} else {
+		return fake.subscribeToLRPInstanceEventsReturns.result1, fake.subscribeToLRPInstanceEventsReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToLRPInstanceEventsCallCount() int {
+	fake.subscribeToLRPInstanceEventsMutex.RLock()
+	defer fake.subscribeToLRPInstanceEventsMutex.RUnlock()
+	return len(fake.subscribeToLRPInstanceEventsArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToLRPInstanceEventsArgsForCall(i int) (lager.Logger, models.EventFilter) {
+	fake.subscribeToLRPInstanceEventsMutex.RLock()
+	defer fake.subscribeToLRPInstanceEventsMutex.RUnlock()
+	return fake.subscribeToLRPInstanceEventsArgsForCall[i].logger, fake.subscribeToLRPInstanceEventsArgsForCall[i].filter
+}
+
+func (fake *FakeClient) SubscribeToLRPInstanceEventsReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToLRPInstanceEventsStub = nil
+	fake.subscribeToLRPInstanceEventsReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) TasksByFilter(logger lager.Logger, filter models.TaskFilter) ([]*models.Task, error) {
+	fake.tasksByFilterMutex.Lock()
+	fake.tasksByFilterArgsForCall = append(fake.tasksByFilterArgsForCall, struct {
+		logger lager.Logger
+		filter models.TaskFilter
+	}{logger, filter})
+	fake.tasksByFilterMutex.Unlock()
+	if fake.TasksByFilterStub != nil {
+		return fake.TasksByFilterStub(logger, filter)
+	} else {
+		return fake.tasksByFilterReturns.result1, fake.tasksByFilterReturns.result2
+	}
+}
+
+func (fake *FakeClient) TasksByFilterCallCount() int {
+	fake.tasksByFilterMutex.RLock()
+	defer fake.tasksByFilterMutex.RUnlock()
+	return len(fake.tasksByFilterArgsForCall)
+}
+
+func (fake *FakeClient) TasksByFilterArgsForCall(i int) (lager.Logger, models.TaskFilter) {
+	fake.tasksByFilterMutex.RLock()
+	defer fake.tasksByFilterMutex.RUnlock()
+	return fake.tasksByFilterArgsForCall[i].logger, fake.tasksByFilterArgsForCall[i].filter
+}
+
+func (fake *FakeClient) TasksByFilterReturns(result1 []*models.Task, result2 error) {
+	fake.TasksByFilterStub = nil
+	fake.tasksByFilterReturns = struct {
+		result1 []*models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) TaskSchedules(logger lager.Logger, filter models.TaskScheduleFilter) ([]*models.TaskSchedule, error) {
+	fake.taskSchedulesMutex.Lock()
+	fake.taskSchedulesArgsForCall = append(fake.taskSchedulesArgsForCall, struct {
+		logger lager.Logger
+		filter models.TaskScheduleFilter
+	}{logger, filter})
+	fake.taskSchedulesMutex.Unlock()
+	if fake.TaskSchedulesStub != nil {
+		return fake.TaskSchedulesStub(logger, filter)
+	} else {
+		return fake.taskSchedulesReturns.result1, fake.taskSchedulesReturns.result2
+	}
+}
+
+func (fake *FakeClient) TaskSchedulesCallCount() int {
+	fake.taskSchedulesMutex.RLock()
+	defer fake.taskSchedulesMutex.RUnlock()
+	return len(fake.taskSchedulesArgsForCall)
+}
+
+func (fake *FakeClient) TaskSchedulesArgsForCall(i int) (lager.Logger, models.TaskScheduleFilter) {
+	fake.taskSchedulesMutex.RLock()
+	defer fake.taskSchedulesMutex.RUnlock()
+	return fake.taskSchedulesArgsForCall[i].logger, fake.taskSchedulesArgsForCall[i].filter
+}
+
+func (fake *FakeClient) TaskSchedulesReturns(result1 []*models.TaskSchedule, result2 error) {
+	fake.TaskSchedulesStub = nil
+	fake.taskSchedulesReturns = struct {
+		result1 []*models.TaskSchedule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) TaskScheduleByGuid(logger lager.Logger, scheduleGuid string) (*models.TaskSchedule, error) {
+	fake.taskScheduleByGuidMutex.Lock()
+	fake.taskScheduleByGuidArgsForCall = append(fake.taskScheduleByGuidArgsForCall, struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}{logger, scheduleGuid})
+	fake.taskScheduleByGuidMutex.Unlock()
+	if fake.TaskScheduleByGuidStub != nil {
+		return fake.TaskScheduleByGuidStub(logger, scheduleGuid)
+	} else {
+		return fake.taskScheduleByGuidReturns.result1, fake.taskScheduleByGuidReturns.result2
+	}
+}
+
+func (fake *FakeClient) TaskScheduleByGuidCallCount() int {
+	fake.taskScheduleByGuidMutex.RLock()
+	defer fake.taskScheduleByGuidMutex.RUnlock()
+	return len(fake.taskScheduleByGuidArgsForCall)
+}
+
+func (fake *FakeClient) TaskScheduleByGuidArgsForCall(i int) (lager.Logger, string) {
+	fake.taskScheduleByGuidMutex.RLock()
+	defer fake.taskScheduleByGuidMutex.RUnlock()
+	return fake.taskScheduleByGuidArgsForCall[i].logger, fake.taskScheduleByGuidArgsForCall[i].scheduleGuid
+}
+
+func (fake *FakeClient) TaskScheduleByGuidReturns(result1 *models.TaskSchedule, result2 error) {
+	fake.TaskScheduleByGuidStub = nil
+	fake.taskScheduleByGuidReturns = struct {
+		result1 *models.TaskSchedule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DesireTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	fake.desireTaskScheduleMutex.Lock()
+	fake.desireTaskScheduleArgsForCall = append(fake.desireTaskScheduleArgsForCall, struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}{logger, schedule})
+	fake.desireTaskScheduleMutex.Unlock()
+	if fake.DesireTaskScheduleStub != nil {
+		return fake.DesireTaskScheduleStub(logger, schedule)
+	} else {
+		return fake.desireTaskScheduleReturns.result1
+	}
+}
+
+func (fake *FakeClient) DesireTaskScheduleCallCount() int {
+	fake.desireTaskScheduleMutex.RLock()
+	defer fake.desireTaskScheduleMutex.RUnlock()
+	return len(fake.desireTaskScheduleArgsForCall)
+}
+
+func (fake *FakeClient) DesireTaskScheduleArgsForCall(i int) (lager.Logger, *models.TaskSchedule) {
+	fake.desireTaskScheduleMutex.RLock()
+	defer fake.desireTaskScheduleMutex.RUnlock()
+	return fake.desireTaskScheduleArgsForCall[i].logger, fake.desireTaskScheduleArgsForCall[i].schedule
+}
+
+func (fake *FakeClient) DesireTaskScheduleReturns(result1 error) {
+	fake.DesireTaskScheduleStub = nil
+	fake.desireTaskScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateTaskSchedule(logger lager.Logger, schedule *models.TaskSchedule) error {
+	fake.updateTaskScheduleMutex.Lock()
+	fake.updateTaskScheduleArgsForCall = append(fake.updateTaskScheduleArgsForCall, struct {
+		logger   lager.Logger
+		schedule *models.TaskSchedule
+	}{logger, schedule})
+	fake.updateTaskScheduleMutex.Unlock()
+	if fake.UpdateTaskScheduleStub != nil {
+		return fake.UpdateTaskScheduleStub(logger, schedule)
+	} else {
+		return fake.updateTaskScheduleReturns.result1
+	}
+}
+
+func (fake *FakeClient) UpdateTaskScheduleCallCount() int {
+	fake.updateTaskScheduleMutex.RLock()
+	defer fake.updateTaskScheduleMutex.RUnlock()
+	return len(fake.updateTaskScheduleArgsForCall)
+}
+
+func (fake *FakeClient) UpdateTaskScheduleArgsForCall(i int) (lager.Logger, *models.TaskSchedule) {
+	fake.updateTaskScheduleMutex.RLock()
+	defer fake.updateTaskScheduleMutex.RUnlock()
+	return fake.updateTaskScheduleArgsForCall[i].logger, fake.updateTaskScheduleArgsForCall[i].schedule
+}
+
+func (fake *FakeClient) UpdateTaskScheduleReturns(result1 error) {
+	fake.UpdateTaskScheduleStub = nil
+	fake.updateTaskScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RemoveTaskSchedule(logger lager.Logger, scheduleGuid string) error {
+	fake.removeTaskScheduleMutex.Lock()
+	fake.removeTaskScheduleArgsForCall = append(fake.removeTaskScheduleArgsForCall, struct {
+		logger       lager.Logger
+		scheduleGuid string
+	}{logger, scheduleGuid})
+	fake.removeTaskScheduleMutex.Unlock()
+	if fake.RemoveTaskScheduleStub != nil {
+		return fake.RemoveTaskScheduleStub(logger, scheduleGuid)
+	} else {
+		return fake.removeTaskScheduleReturns.result1
+	}
+}
+
+func (fake *FakeClient) RemoveTaskScheduleCallCount() int {
+	fake.removeTaskScheduleMutex.RLock()
+	defer fake.removeTaskScheduleMutex.RUnlock()
+	return len(fake.removeTaskScheduleArgsForCall)
+}
+
+func (fake *FakeClient) RemoveTaskScheduleArgsForCall(i int) (lager.Logger, string) {
+	fake.removeTaskScheduleMutex.RLock()
+	defer fake.removeTaskScheduleMutex.RUnlock()
+	return fake.removeTaskScheduleArgsForCall[i].logger, fake.removeTaskScheduleArgsForCall[i].scheduleGuid
+}
+
+func (fake *FakeClient) RemoveTaskScheduleReturns(result1 error) {
+	fake.RemoveTaskScheduleStub = nil
+	fake.removeTaskScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CancelTaskWithReason(logger lager.Logger, taskGuid string, reason string) error {
+	fake.cancelTaskWithReasonMutex.Lock()
+	fake.cancelTaskWithReasonArgsForCall = append(fake.cancelTaskWithReasonArgsForCall, struct {
+		logger   lager.Logger
+		taskGuid string
+		reason   string
+	}{logger, taskGuid, reason})
+	fake.cancelTaskWithReasonMutex.Unlock()
+	if fake.CancelTaskWithReasonStub != nil {
+		return fake.CancelTaskWithReasonStub(logger, taskGuid, reason)
+	} else {
+		return fake.cancelTaskWithReasonReturns.result1
+	}
+}
+
+func (fake *FakeClient) CancelTaskWithReasonCallCount() int {
+	fake.cancelTaskWithReasonMutex.RLock()
+	defer fake.cancelTaskWithReasonMutex.RUnlock()
+	return len(fake.cancelTaskWithReasonArgsForCall)
+}
+
+func (fake *FakeClient) CancelTaskWithReasonArgsForCall(i int) (lager.Logger, string, string) {
+	fake.cancelTaskWithReasonMutex.RLock()
+	defer fake.cancelTaskWithReasonMutex.RUnlock()
+	return fake.cancelTaskWithReasonArgsForCall[i].logger, fake.cancelTaskWithReasonArgsForCall[i].taskGuid, fake.cancelTaskWithReasonArgsForCall[i].reason
+}
+
+func (fake *FakeClient) CancelTaskWithReasonReturns(result1 error) {
+	fake.CancelTaskWithReasonStub = nil
+	fake.cancelTaskWithReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CancelTasks(logger lager.Logger, selector *models.TaskSelector, reason string) ([]*models.TaskResult, error) {
+	fake.cancelTasksMutex.Lock()
+	fake.cancelTasksArgsForCall = append(fake.cancelTasksArgsForCall, struct {
+		logger   lager.Logger
+		selector *models.TaskSelector
+		reason   string
+	}{logger, selector, reason})
+	fake.cancelTasksMutex.Unlock()
+	if fake.CancelTasksStub != nil {
+		return fake.CancelTasksStub(logger, selector, reason)
+	} else {
+		return fake.cancelTasksReturns.result1, fake.cancelTasksReturns.result2
+	}
+}
+
+func (fake *FakeClient) CancelTasksCallCount() int {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	return len(fake.cancelTasksArgsForCall)
+}
+
+func (fake *FakeClient) CancelTasksArgsForCall(i int) (lager.Logger, *models.TaskSelector, string) {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	return fake.cancelTasksArgsForCall[i].logger, fake.cancelTasksArgsForCall[i].selector, fake.cancelTasksArgsForCall[i].reason
+}
+
+func (fake *FakeClient) CancelTasksReturns(result1 []*models.TaskResult, result2 error) {
+	fake.CancelTasksStub = nil
+	fake.cancelTasksReturns = struct {
+		result1 []*models.TaskResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DeleteTasks(logger lager.Logger, selector *models.TaskSelector) ([]*models.TaskResult, error) {
+	fake.deleteTasksMutex.Lock()
+	fake.deleteTasksArgsForCall = append(fake.deleteTasksArgsForCall, struct {
+		logger   lager.Logger
+		selector *models.TaskSelector
+	}{logger, selector})
+	fake.deleteTasksMutex.Unlock()
+	if fake.DeleteTasksStub != nil {
+		return fake.DeleteTasksStub(logger, selector)
+	} else {
+		return fake.deleteTasksReturns.result1, fake.deleteTasksReturns.result2
+	}
+}
+
+func (fake *FakeClient) DeleteTasksCallCount() int {
+	fake.deleteTasksMutex.RLock()
+	defer fake.deleteTasksMutex.RUnlock()
+	return len(fake.deleteTasksArgsForCall)
+}
+
+func (fake *FakeClient) DeleteTasksArgsForCall(i int) (lager.Logger, *models.TaskSelector) {
+	fake.deleteTasksMutex.RLock()
+	defer fake.deleteTasksMutex.RUnlock()
+	return fake.deleteTasksArgsForCall[i].logger, fake.deleteTasksArgsForCall[i].selector
+}
+
+func (fake *FakeClient) DeleteTasksReturns(result1 []*models.TaskResult, result2 error) {
+	fake.DeleteTasksStub = nil
+	fake.deleteTasksReturns = struct {
+		result1 []*models.TaskResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ArchivedTasks(logger lager.Logger, filter models.ArchivedTaskFilter) ([]*models.Task, error) {
+	fake.archivedTasksMutex.Lock()
+	fake.archivedTasksArgsForCall = append(fake.archivedTasksArgsForCall, struct {
+		logger lager.Logger
+		filter models.ArchivedTaskFilter
+	}{logger, filter})
+	fake.archivedTasksMutex.Unlock()
+	if fake.ArchivedTasksStub != nil {
+		return fake.ArchivedTasksStub(logger, filter)
+	} else {
+		return fake.archivedTasksReturns.result1, fake.archivedTasksReturns.result2
+	}
+}
+
+func (fake *FakeClient) ArchivedTasksCallCount() int {
+	fake.archivedTasksMutex.RLock()
+	defer fake.archivedTasksMutex.RUnlock()
+	return len(fake.archivedTasksArgsForCall)
+}
+
+func (fake *FakeClient) ArchivedTasksArgsForCall(i int) (lager.Logger, models.ArchivedTaskFilter) {
+	fake.archivedTasksMutex.RLock()
+	defer fake.archivedTasksMutex.RUnlock()
+	return fake.archivedTasksArgsForCall[i].logger, fake.archivedTasksArgsForCall[i].filter
+}
+
+func (fake *FakeClient) ArchivedTasksReturns(result1 []*models.Task, result2 error) {
+	fake.ArchivedTasksStub = nil
+	fake.archivedTasksReturns = struct {
+		result1 []*models.Task
+		result2 error
+	}{result1, result2}
+}
+
 var _ bbs.Client = new(FakeClient)
@@ -41,6 +41,24 @@ func (h *ActualLRPHandler) ActualLRPGroups(w http.ResponseWriter, req *http.Requ
 	exitIfUnrecoverable(logger, h.exitChan, response.Error)
 }
 
+func (h *ActualLRPHandler) ActualLRPs(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("actual-lrps")
+
+	request := &models.ActualLRPsRequest{}
+	response := &models.ActualLRPsResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		response.ActualLrps, err = h.db.ActualLRPs(logger, request.Filter())
+	}
+
+	response.Error = models.ConvertError(err)
+
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
 func (h *ActualLRPHandler) ActualLRPGroupsByProcessGuid(w http.ResponseWriter, req *http.Request) {
 	var err error
 	logger := h.logger.Session("actual-lrp-groups-by-process-guid")
@@ -188,6 +188,104 @@ var _ = Describe("ActualLRP Handlers", func() {
 		})
 	})
 
+	Describe("ActualLRPs", func() {
+		var requestBody interface{}
+
+		BeforeEach(func() {
+			requestBody = &models.ActualLRPsRequest{}
+			actualLRP1 = models.ActualLRP{
+				ActualLRPKey:         models.NewActualLRPKey("process-guid-0", 1, "domain-0"),
+				ActualLRPInstanceKey: models.NewActualLRPInstanceKey("instance-guid-0", "cell-id-0"),
+				State:                models.ActualLRPStateRunning,
+				Since:                1138,
+			}
+
+			evacuatingLRP2 = actualLRP1
+			evacuatingLRP2.Presence = models.ActualLRP_Evacuating
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.ActualLRPs(responseRecorder, request)
+		})
+
+		Context("when reading actual lrps from DB succeeds", func() {
+			var actualLRPs []*models.ActualLRP
+
+			BeforeEach(func() {
+				actualLRPs = []*models.ActualLRP{&actualLRP1, &evacuatingLRP2}
+				fakeActualLRPDB.ActualLRPsReturns(actualLRPs, nil)
+			})
+
+			It("returns a list of actual lrps", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := models.ActualLRPsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(BeNil())
+				Expect(response.ActualLrps).To(Equal(actualLRPs))
+			})
+
+			Context("and filtering by every field", func() {
+				var index int32
+
+				BeforeEach(func() {
+					index = 1
+					requestBody = &models.ActualLRPsRequest{
+						Domain:      "domain-0",
+						CellId:      "cell-id-0",
+						ProcessGuid: "process-guid-0",
+						Index:       &index,
+					}
+				})
+
+				It("calls the DB with the filter", func() {
+					Expect(fakeActualLRPDB.ActualLRPsCallCount()).To(Equal(1))
+					_, filter := fakeActualLRPDB.ActualLRPsArgsForCall(0)
+					Expect(filter).To(Equal(models.ActualLRPFilter{
+						Domain:      "domain-0",
+						CellID:      "cell-id-0",
+						ProcessGuid: "process-guid-0",
+						Index:       &index,
+					}))
+				})
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				index := int32(-1)
+				requestBody = &models.ActualLRPsRequest{Index: &index}
+			})
+
+			It("responds with a bad request error", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := &models.ActualLRPsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeActualLRPDB.ActualLRPsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.ActualLRPsReturns(nil, models.ErrUnknownError)
+			})
+
+			It("provides relevant error information", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := &models.ActualLRPsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+			})
+		})
+	})
+
 	Describe("ActualLRPGroupsByProcessGuid", func() {
 		var (
 			processGuid = "process-guid"
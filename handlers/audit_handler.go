@@ -0,0 +1,603 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/audit"
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/lager"
+)
+
+const (
+	auditRecordFailureCount = metric.Counter("AuditRecordFailures")
+
+	maxAuditValueLength = 128
+	maxAuditDepth       = 2
+)
+
+type AuditHandler struct {
+	db       db.AuditDB
+	exitChan chan<- struct{}
+	logger   lager.Logger
+}
+
+// NewAuditHandler returns a handler for querying the audit log. The db is nil
+// when the audit log is not kept in SQL.
+func NewAuditHandler(logger lager.Logger, db db.AuditDB, exitChan chan<- struct{}) *AuditHandler {
+	return &AuditHandler{
+		db:       db,
+		exitChan: exitChan,
+		logger:   logger.Session("audit-handler"),
+	}
+}
+
+func (h *AuditHandler) AuditRecords(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("audit-records")
+
+	request := &models.AuditRecordsRequest{}
+	response := &models.AuditRecordsResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		if h.db == nil {
+			err = models.NewError(models.Error_InvalidRequest, "the audit log is not stored in the database")
+		} else {
+			response.AuditRecords, err = h.db.AuditRecords(logger, request.Filter())
+		}
+	}
+
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
+type errorResponse interface {
+	proto.Message
+	GetError() *models.Error
+}
+
+// auditDBs are the databases the state of audited targets is read from. The
+// TaskScheduleDB is nil when task schedules are not kept in SQL.
+type auditDBs struct {
+	db.DB
+	TaskScheduleDB db.TaskScheduleDB
+}
+
+type auditState func(logger lager.Logger, db auditDBs, request proto.Message) (interface{}, error)
+
+type auditedRoute struct {
+	newRequest  func() proto.Message
+	newResponse func() errorResponse
+	before      auditState
+	after       auditState
+}
+
+type auditedDomain struct {
+	Domain string  `json:"domain"`
+	Ttl    *uint32 `json:"ttl"`
+}
+
+type auditedActualLRP struct {
+	Instance   *models.ActualLRP `json:"instance"`
+	Evacuating *models.ActualLRP `json:"evacuating"`
+}
+
+var (
+	desiredLRPAudit = auditState(func(logger lager.Logger, db auditDBs, request proto.Message) (interface{}, error) {
+		return db.DesiredLRPByProcessGuid(logger, auditTargetGuid(request))
+	})
+
+	taskAudit = auditState(func(logger lager.Logger, db auditDBs, request proto.Message) (interface{}, error) {
+		return db.TaskByGuid(logger, auditTargetGuid(request))
+	})
+
+	taskScheduleAudit = auditState(func(logger lager.Logger, db auditDBs, request proto.Message) (interface{}, error) {
+		if db.TaskScheduleDB == nil {
+			return nil, nil
+		}
+		return db.TaskScheduleDB.TaskScheduleByGuid(logger, auditTargetGuid(request))
+	})
+
+	actualLRPAudit = auditState(func(logger lager.Logger, db auditDBs, request proto.Message) (interface{}, error) {
+		key := request.(*models.RetireActualLRPRequest).GetActualLrpKey()
+		group, err := db.ActualLRPGroupByProcessGuidAndIndex(logger, key.GetProcessGuid(), key.GetIndex())
+		if err != nil {
+			return nil, err
+		}
+		return &auditedActualLRP{Instance: group.Instance, Evacuating: group.Evacuating}, nil
+	})
+
+	domainBeforeAudit = auditState(func(logger lager.Logger, db auditDBs, request proto.Message) (interface{}, error) {
+		domain := request.(*models.UpsertDomainRequest).Domain
+		domains, err := db.Domains(logger)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range domains {
+			if d == domain {
+				return &auditedDomain{Domain: domain}, nil
+			}
+		}
+		return nil, nil
+	})
+
+	domainAfterAudit = auditState(func(logger lager.Logger, db auditDBs, request proto.Message) (interface{}, error) {
+		upsert := request.(*models.UpsertDomainRequest)
+		state, err := domainBeforeAudit(logger, db, request)
+		if state != nil {
+			state.(*auditedDomain).Ttl = &upsert.Ttl
+		}
+		return state, err
+	})
+)
+
+func newDesiredLRPLifecycleResponse() errorResponse   { return &models.DesiredLRPLifecycleResponse{} }
+func newTaskLifecycleResponse() errorResponse         { return &models.TaskLifecycleResponse{} }
+func newTaskScheduleLifecycleResponse() errorResponse { return &models.TaskScheduleLifecycleResponse{} }
+
+// auditedRoutes are the routes whose changes are recorded in the audit log.
+var auditedRoutes = map[string]auditedRoute{
+	bbs.DesireDesiredLRPRoute: {
+		newRequest:  func() proto.Message { return &models.DesireLRPRequest{} },
+		newResponse: newDesiredLRPLifecycleResponse,
+		before:      desiredLRPAudit,
+		after:       desiredLRPAudit,
+	},
+	bbs.DesireDesiredLRPRoute_r0: {
+		newRequest:  func() proto.Message { return &models.DesireLRPRequest{} },
+		newResponse: newDesiredLRPLifecycleResponse,
+		before:      desiredLRPAudit,
+		after:       desiredLRPAudit,
+	},
+	bbs.UpdateDesiredLRPRoute: {
+		newRequest:  func() proto.Message { return &models.UpdateDesiredLRPRequest{} },
+		newResponse: newDesiredLRPLifecycleResponse,
+		before:      desiredLRPAudit,
+		after:       desiredLRPAudit,
+	},
+	bbs.RemoveDesiredLRPRoute: {
+		newRequest:  func() proto.Message { return &models.RemoveDesiredLRPRequest{} },
+		newResponse: newDesiredLRPLifecycleResponse,
+		before:      desiredLRPAudit,
+		after:       desiredLRPAudit,
+	},
+
+	bbs.DesireTaskRoute: {
+		newRequest:  func() proto.Message { return &models.DesireTaskRequest{} },
+		newResponse: newTaskLifecycleResponse,
+		before:      taskAudit,
+		after:       taskAudit,
+	},
+	bbs.DesireTaskRoute_r0: {
+		newRequest:  func() proto.Message { return &models.DesireTaskRequest{} },
+		newResponse: newTaskLifecycleResponse,
+		before:      taskAudit,
+		after:       taskAudit,
+	},
+	bbs.CancelTaskRoute: {
+		newRequest:  func() proto.Message { return &models.CancelTaskRequest{} },
+		newResponse: newTaskLifecycleResponse,
+		before:      taskAudit,
+		after:       taskAudit,
+	},
+	bbs.DeleteTaskRoute: {
+		newRequest:  func() proto.Message { return &models.TaskGuidRequest{} },
+		newResponse: newTaskLifecycleResponse,
+		before:      taskAudit,
+		after:       taskAudit,
+	},
+
+	bbs.DesireTaskScheduleRoute: {
+		newRequest:  func() proto.Message { return &models.DesireTaskScheduleRequest{} },
+		newResponse: newTaskScheduleLifecycleResponse,
+		before:      taskScheduleAudit,
+		after:       taskScheduleAudit,
+	},
+	bbs.UpdateTaskScheduleRoute: {
+		newRequest:  func() proto.Message { return &models.UpdateTaskScheduleRequest{} },
+		newResponse: newTaskScheduleLifecycleResponse,
+		before:      taskScheduleAudit,
+		after:       taskScheduleAudit,
+	},
+	bbs.RemoveTaskScheduleRoute: {
+		newRequest:  func() proto.Message { return &models.TaskScheduleGuidRequest{} },
+		newResponse: newTaskScheduleLifecycleResponse,
+		before:      taskScheduleAudit,
+		after:       taskScheduleAudit,
+	},
+
+	bbs.UpsertDomainRoute: {
+		newRequest:  func() proto.Message { return &models.UpsertDomainRequest{} },
+		newResponse: func() errorResponse { return &models.UpsertDomainResponse{} },
+		before:      domainBeforeAudit,
+		after:       domainAfterAudit,
+	},
+
+	bbs.RetireActualLRPRoute: {
+		newRequest:  func() proto.Message { return &models.RetireActualLRPRequest{} },
+		newResponse: func() errorResponse { return &models.ActualLRPLifecycleResponse{} },
+		before:      actualLRPAudit,
+		after:       actualLRPAudit,
+	},
+}
+
+func auditTargetGuid(request proto.Message) string {
+	switch r := request.(type) {
+	case *models.DesireLRPRequest:
+		return r.GetDesiredLrp().GetProcessGuid()
+	case *models.UpdateDesiredLRPRequest:
+		return r.ProcessGuid
+	case *models.RemoveDesiredLRPRequest:
+		return r.ProcessGuid
+	case *models.DesireTaskRequest:
+		return r.TaskGuid
+	case *models.TaskGuidRequest:
+		return r.TaskGuid
+	case *models.CancelTaskRequest:
+		return r.TaskGuid
+	case *models.DesireTaskScheduleRequest:
+		return r.GetTaskSchedule().GetScheduleGuid()
+	case *models.UpdateTaskScheduleRequest:
+		return r.GetTaskSchedule().GetScheduleGuid()
+	case *models.TaskScheduleGuidRequest:
+		return r.ScheduleGuid
+	case *models.UpsertDomainRequest:
+		return r.Domain
+	case *models.RetireActualLRPRequest:
+		return r.GetActualLrpKey().GetProcessGuid()
+	}
+	return ""
+}
+
+// auditedBulkRoute is a route that changes every task its selector matches.
+// Each task it reports a result for is recorded as a separate target.
+type auditedBulkRoute struct {
+	newRequest  func() taskSelectorRequest
+	newResponse func() taskResultsResponse
+}
+
+type taskSelectorRequest interface {
+	proto.Message
+	GetSelector() *models.TaskSelector
+}
+
+type taskResultsResponse interface {
+	errorResponse
+	GetResults() []*models.TaskResult
+}
+
+// auditedBulkRoutes are the routes whose changes to many tasks are recorded in
+// the audit log.
+var auditedBulkRoutes = map[string]auditedBulkRoute{
+	bbs.CancelTasksRoute: {
+		newRequest:  func() taskSelectorRequest { return &models.CancelTasksRequest{} },
+		newResponse: func() taskResultsResponse { return &models.CancelTasksResponse{} },
+	},
+	bbs.DeleteTasksRoute: {
+		newRequest:  func() taskSelectorRequest { return &models.DeleteTasksRequest{} },
+		newResponse: func() taskResultsResponse { return &models.DeleteTasksResponse{} },
+	},
+}
+
+// AuditWrap records who called an audited route, what it targeted, and a
+// summary of how the target changed. Requests that cannot be parsed change
+// nothing and are not recorded. Other routes are passed through.
+func AuditWrap(logger lager.Logger, auditor audit.Auditor, db db.DB, taskScheduleDB db.TaskScheduleDB, route string, handler http.Handler) http.Handler {
+	if auditor == nil {
+		return handler
+	}
+	if bulk, ok := auditedBulkRoutes[route]; ok {
+		return auditBulkWrap(logger, auditor, db, route, bulk, handler)
+	}
+	audited, ok := auditedRoutes[route]
+	if !ok {
+		return handler
+	}
+	dbs := auditDBs{DB: db, TaskScheduleDB: taskScheduleDB}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		logger := logger.Session("audit", lager.Data{"route": route})
+
+		request := audited.newRequest()
+		parsed, err := readAuditedRequest(req, request)
+		if err != nil {
+			logger.Error("failed-to-read-body", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !parsed {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		before := auditLookup(logger, audited.before, dbs, request)
+
+		capture := &responseCapture{ResponseWriter: w}
+		handler.ServeHTTP(capture, req)
+
+		after := auditLookup(logger, audited.after, dbs, request)
+
+		record := &models.AuditRecord{
+			Identity:   requestIdentity(req),
+			RemoteAddr: req.RemoteAddr,
+			Route:      route,
+			TargetGuid: auditTargetGuid(request),
+			Changes:    auditChanges(before, after),
+		}
+
+		response := audited.newResponse()
+		if proto.Unmarshal(capture.body.Bytes(), response) == nil && response.GetError() != nil {
+			record.Error = response.GetError().Error()
+		}
+
+		recordAudit(logger, auditor, record)
+	})
+}
+
+// auditBulkWrap records a bulk task route once for every task it reports a
+// result for, along with the selector that matched the task. A request that
+// fails as a whole is recorded once without a target.
+func auditBulkWrap(logger lager.Logger, auditor audit.Auditor, db db.DB, route string, audited auditedBulkRoute, handler http.Handler) http.Handler {
+	dbs := auditDBs{DB: db}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		logger := logger.Session("audit", lager.Data{"route": route})
+
+		request := audited.newRequest()
+		parsed, err := readAuditedRequest(req, request)
+		if err != nil {
+			logger.Error("failed-to-read-body", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !parsed {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		selector := request.GetSelector()
+		candidates := map[string]*models.Task{}
+		if selector != nil {
+			// Tasks may only become old enough by the time the handler
+			// selects them, so the candidates are fetched regardless of age.
+			filter := selector.Filter(time.Now().UnixNano())
+			filter.UpdatedBefore = 0
+			tasks, err := db.Tasks(logger, filter)
+			if err != nil {
+				logger.Error("failed-fetching-audit-state", err)
+			}
+			for _, task := range tasks {
+				candidates[task.TaskGuid] = task
+			}
+		}
+
+		capture := &responseCapture{ResponseWriter: w}
+		handler.ServeHTTP(capture, req)
+
+		selectorChanges := auditSelectorChanges(selector)
+
+		response := audited.newResponse()
+		if proto.Unmarshal(capture.body.Bytes(), response) != nil || response.GetError() != nil {
+			record := &models.AuditRecord{
+				Identity:   requestIdentity(req),
+				RemoteAddr: req.RemoteAddr,
+				Route:      route,
+				Changes:    selectorChanges,
+			}
+			if response.GetError() != nil {
+				record.Error = response.GetError().Error()
+			}
+			recordAudit(logger, auditor, record)
+			return
+		}
+
+		for _, result := range response.GetResults() {
+			var before interface{}
+			if task, ok := candidates[result.TaskGuid]; ok {
+				before = task
+			}
+			after := auditLookup(logger, taskAudit, dbs, &models.TaskGuidRequest{TaskGuid: result.TaskGuid})
+
+			changes := append([]*models.AuditChange{}, selectorChanges...)
+			record := &models.AuditRecord{
+				Identity:   requestIdentity(req),
+				RemoteAddr: req.RemoteAddr,
+				Route:      route,
+				TargetGuid: result.TaskGuid,
+				Changes:    append(changes, auditChanges(before, after)...),
+			}
+			if result.Error != nil {
+				record.Error = result.Error.Error()
+			}
+			recordAudit(logger, auditor, record)
+		}
+	})
+}
+
+// readAuditedRequest parses the request body and restores it for the handler.
+// It returns false when the body cannot be parsed, which leaves the request to
+// the handler to reject.
+func readAuditedRequest(req *http.Request, request proto.Message) (bool, error) {
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return false, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	return proto.Unmarshal(data, request) == nil, nil
+}
+
+// auditSelectorChanges lists the fields of the selector that are set, under a
+// selector prefix, so that bulk records show why a task was targeted.
+func auditSelectorChanges(selector *models.TaskSelector) []*models.AuditChange {
+	changes := auditChanges(nil, selector)
+	for _, change := range changes {
+		change.Field = "selector." + change.Field
+		change.Before = ""
+	}
+	return changes
+}
+
+func recordAudit(logger lager.Logger, auditor audit.Auditor, record *models.AuditRecord) {
+	err := auditor.Record(logger, record)
+	if err != nil {
+		logger.Error("failed-to-record-audit", err)
+		sendErr := auditRecordFailureCount.Increment()
+		if sendErr != nil {
+			logger.Error("failed-to-send-audit-record-failures-metric", sendErr)
+		}
+	}
+}
+
+func auditLookup(logger lager.Logger, state auditState, db auditDBs, request proto.Message) interface{} {
+	value, err := state(logger, db, request)
+	if err == models.ErrResourceNotFound {
+		return nil
+	}
+	if err != nil {
+		logger.Error("failed-fetching-audit-state", err)
+		return nil
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	return value
+}
+
+func requestIdentity(req *http.Request) string {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+	return req.TLS.PeerCertificates[0].Subject.CommonName
+}
+
+type responseCapture struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseCapture) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// auditChanges summarizes the fields that differ between two states of the
+// same record. A nil state is treated as the zero record, so creations and
+// deletions list every field that is set. Collections are summarized by their
+// size and nesting beyond a couple of levels is reported as changed, which
+// keeps large or sensitive values such as environment variables out of the log.
+func auditChanges(before, after interface{}) []*models.AuditChange {
+	if before == nil && after == nil {
+		return nil
+	}
+
+	var b, a reflect.Value
+	if before != nil {
+		b = reflect.ValueOf(before)
+	}
+	if after != nil {
+		a = reflect.ValueOf(after)
+	}
+	if !b.IsValid() {
+		b = reflect.Zero(a.Type())
+	}
+	if !a.IsValid() {
+		a = reflect.Zero(b.Type())
+	}
+
+	changes := []*models.AuditChange{}
+	diffValues(&changes, "", b, a, 0)
+	return changes
+}
+
+func diffValues(changes *[]*models.AuditChange, field string, before, after reflect.Value, depth int) {
+	if before.Kind() == reflect.Ptr && before.Type().Elem().Kind() == reflect.Struct {
+		if before.IsNil() && after.IsNil() {
+			return
+		}
+		if before.IsNil() {
+			before = reflect.New(before.Type().Elem())
+		}
+		if after.IsNil() {
+			after = reflect.New(after.Type().Elem())
+		}
+		before, after = before.Elem(), after.Elem()
+	}
+
+	if reflect.DeepEqual(before.Interface(), after.Interface()) {
+		return
+	}
+
+	if before.Kind() != reflect.Struct {
+		*changes = append(*changes, &models.AuditChange{
+			Field:  field,
+			Before: summarizeValue(before),
+			After:  summarizeValue(after),
+		})
+		return
+	}
+
+	if depth > maxAuditDepth {
+		*changes = append(*changes, &models.AuditChange{Field: field, Before: "...", After: "(changed)"})
+		return
+	}
+
+	for i := 0; i < before.NumField(); i++ {
+		structField := before.Type().Field(i)
+		if structField.PkgPath != "" || strings.HasPrefix(structField.Name, "XXX_") {
+			continue
+		}
+
+		name := fieldName(structField)
+		childDepth := depth + 1
+		if structField.Anonymous {
+			name = field
+			childDepth = depth
+		} else if field != "" {
+			name = field + "." + name
+		}
+
+		diffValues(changes, name, before.Field(i), after.Field(i), childDepth)
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func summarizeValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return summarizeValue(v.Elem())
+	case reflect.Slice, reflect.Map:
+		if v.Len() == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%d items", v.Len())
+	}
+
+	summary := fmt.Sprint(v.Interface())
+	if len(summary) > maxAuditValueLength {
+		summary = summary[:maxAuditValueLength] + "..."
+	}
+	return summary
+}
@@ -0,0 +1,560 @@
+package handlers_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/audit/auditfakes"
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Handlers", func() {
+	var (
+		logger           *lagertest.TestLogger
+		responseRecorder *httptest.ResponseRecorder
+		exitCh           chan struct{}
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		responseRecorder = httptest.NewRecorder()
+		exitCh = make(chan struct{}, 1)
+	})
+
+	Describe("AuditRecords", func() {
+		var (
+			fakeAuditDB *dbfakes.FakeAuditDB
+			handler     *handlers.AuditHandler
+			requestBody interface{}
+			records     []*models.AuditRecord
+		)
+
+		BeforeEach(func() {
+			fakeAuditDB = new(dbfakes.FakeAuditDB)
+			handler = handlers.NewAuditHandler(logger, fakeAuditDB, exitCh)
+
+			records = []*models.AuditRecord{
+				{Index: 1, Route: bbs.DesireTaskRoute, TargetGuid: "some-guid"},
+				{Index: 2, Route: bbs.CancelTaskRoute, TargetGuid: "some-guid"},
+			}
+			fakeAuditDB.AuditRecordsReturns(records, nil)
+
+			requestBody = &models.AuditRecordsRequest{TargetGuid: "some-guid", Limit: 10}
+		})
+
+		JustBeforeEach(func() {
+			handler.AuditRecords(responseRecorder, newTestRequest(requestBody))
+		})
+
+		It("queries the db with the filter", func() {
+			Expect(fakeAuditDB.AuditRecordsCallCount()).To(Equal(1))
+			_, filter := fakeAuditDB.AuditRecordsArgsForCall(0)
+			Expect(filter).To(Equal(models.AuditRecordFilter{TargetGuid: "some-guid", Limit: 10}))
+		})
+
+		It("returns the records", func() {
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+			response := models.AuditRecordsResponse{}
+			Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+
+			Expect(response.Error).To(BeNil())
+			Expect(response.AuditRecords).To(Equal(records))
+		})
+
+		Context("when the db fails", func() {
+			BeforeEach(func() {
+				fakeAuditDB.AuditRecordsReturns(nil, models.ErrUnknownError)
+			})
+
+			It("returns the error", func() {
+				response := models.AuditRecordsResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.AuditRecordsRequest{Limit: -1}
+			})
+
+			It("returns a bad request error", func() {
+				response := models.AuditRecordsResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeAuditDB.AuditRecordsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the audit log is not stored in the database", func() {
+			BeforeEach(func() {
+				handler = handlers.NewAuditHandler(logger, nil, exitCh)
+			})
+
+			It("returns an error", func() {
+				response := models.AuditRecordsResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+			})
+		})
+	})
+
+	Describe("AuditWrap", func() {
+		var (
+			fakeDB         *dbfakes.FakeDB
+			fakeScheduleDB *dbfakes.FakeTaskScheduleDB
+			taskScheduleDB db.TaskScheduleDB
+			fakeAuditor    *auditfakes.FakeAuditor
+			sender         *fake.FakeMetricSender
+
+			route        string
+			request      *http.Request
+			responseBody proto.Message
+			handlerBody  []byte
+		)
+
+		BeforeEach(func() {
+			fakeDB = new(dbfakes.FakeDB)
+			fakeScheduleDB = new(dbfakes.FakeTaskScheduleDB)
+			taskScheduleDB = fakeScheduleDB
+			fakeAuditor = new(auditfakes.FakeAuditor)
+			sender = fake.NewFakeMetricSender()
+			dropsonde_metrics.Initialize(sender, nil)
+
+			responseBody = &models.DesiredLRPLifecycleResponse{}
+			handlerBody = nil
+		})
+
+		serve := func(auditor *auditfakes.FakeAuditor) {
+			inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var err error
+				handlerBody, err = ioutil.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				data, err := proto.Marshal(responseBody)
+				Expect(err).NotTo(HaveOccurred())
+				w.Write(data)
+			})
+
+			var wrapped http.Handler
+			if auditor == nil {
+				wrapped = handlers.AuditWrap(logger, nil, fakeDB, taskScheduleDB, route, inner)
+			} else {
+				wrapped = handlers.AuditWrap(logger, auditor, fakeDB, taskScheduleDB, route, inner)
+			}
+			wrapped.ServeHTTP(responseRecorder, request)
+		}
+
+		recorded := func() *models.AuditRecord {
+			Expect(fakeAuditor.RecordCallCount()).To(Equal(1))
+			_, record := fakeAuditor.RecordArgsForCall(0)
+			return record
+		}
+
+		Context("when updating a desired lrp", func() {
+			var requestBody *models.UpdateDesiredLRPRequest
+
+			BeforeEach(func() {
+				route = bbs.UpdateDesiredLRPRoute
+
+				instances := int32(3)
+				requestBody = &models.UpdateDesiredLRPRequest{
+					ProcessGuid: "some-guid",
+					Update:      &models.DesiredLRPUpdate{Instances: &instances},
+				}
+				request = newTestRequest(requestBody)
+				request.RemoteAddr = "10.0.0.1:1234"
+				request.TLS = &tls.ConnectionState{
+					PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "some-operator"}}},
+				}
+
+				before := model_helpers.NewValidDesiredLRP("some-guid")
+				before.Instances = 1
+				after := model_helpers.NewValidDesiredLRP("some-guid")
+				after.Instances = 3
+				after.ModificationTag.Index = before.ModificationTag.Index + 1
+
+				fakeDB.DesiredLRPByProcessGuidStub = func(_ lager.Logger, _ string) (*models.DesiredLRP, error) {
+					if fakeDB.DesiredLRPByProcessGuidCallCount() == 1 {
+						return before, nil
+					}
+					return after, nil
+				}
+			})
+
+			It("passes the unread request on to the handler", func() {
+				serve(fakeAuditor)
+
+				expected, err := proto.Marshal(requestBody)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handlerBody).To(Equal(expected))
+			})
+
+			It("records who changed what", func() {
+				serve(fakeAuditor)
+
+				record := recorded()
+				Expect(record.Identity).To(Equal("some-operator"))
+				Expect(record.RemoteAddr).To(Equal("10.0.0.1:1234"))
+				Expect(record.Route).To(Equal(bbs.UpdateDesiredLRPRoute))
+				Expect(record.TargetGuid).To(Equal("some-guid"))
+				Expect(record.Error).To(BeEmpty())
+			})
+
+			It("summarizes the changed fields", func() {
+				serve(fakeAuditor)
+
+				Expect(recorded().Changes).To(ConsistOf(
+					&models.AuditChange{Field: "instances", Before: "1", After: "3"},
+					&models.AuditChange{Field: "modification_tag.index", Before: "0", After: "1"},
+				))
+			})
+
+			Context("when the handler responds with an error", func() {
+				BeforeEach(func() {
+					responseBody = &models.DesiredLRPLifecycleResponse{Error: models.ErrResourceConflict}
+				})
+
+				It("records the error", func() {
+					serve(fakeAuditor)
+					Expect(recorded().Error).To(Equal(models.ErrResourceConflict.Error()))
+				})
+			})
+
+			Context("when recording fails", func() {
+				BeforeEach(func() {
+					fakeAuditor.RecordReturns(errors.New("boom"))
+				})
+
+				It("still responds and counts the failure", func() {
+					serve(fakeAuditor)
+
+					Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+					Expect(sender.GetCounter("AuditRecordFailures")).To(Equal(uint64(1)))
+				})
+			})
+
+			Context("when there is no auditor", func() {
+				It("passes the request through", func() {
+					serve(nil)
+
+					Expect(handlerBody).NotTo(BeEmpty())
+					Expect(fakeDB.DesiredLRPByProcessGuidCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when desiring a task", func() {
+			BeforeEach(func() {
+				route = bbs.DesireTaskRoute
+				responseBody = &models.TaskLifecycleResponse{}
+				request = newTestRequest(&models.DesireTaskRequest{
+					TaskGuid:       "task-guid",
+					Domain:         "some-domain",
+					TaskDefinition: model_helpers.NewValidTaskDefinition(),
+				})
+
+				task := model_helpers.NewValidTask("task-guid")
+				fakeDB.TaskByGuidStub = func(_ lager.Logger, _ string) (*models.Task, error) {
+					if fakeDB.TaskByGuidCallCount() == 1 {
+						return nil, models.ErrResourceNotFound
+					}
+					return task, nil
+				}
+			})
+
+			It("records the fields of the created task", func() {
+				serve(fakeAuditor)
+
+				record := recorded()
+				Expect(record.TargetGuid).To(Equal("task-guid"))
+				Expect(record.Changes).To(ContainElement(&models.AuditChange{Field: "task_guid", After: "task-guid"}))
+				Expect(record.Changes).To(ContainElement(&models.AuditChange{Field: "domain", After: "some-domain"}))
+			})
+
+			It("summarizes collections by their size", func() {
+				serve(fakeAuditor)
+
+				Expect(recorded().Changes).To(ContainElement(&models.AuditChange{Field: "env", After: "1 items"}))
+			})
+		})
+
+		Context("when upserting a domain", func() {
+			BeforeEach(func() {
+				route = bbs.UpsertDomainRoute
+				responseBody = &models.UpsertDomainResponse{}
+				request = newTestRequest(&models.UpsertDomainRequest{Domain: "some-domain", Ttl: 120})
+
+				fakeDB.DomainsStub = func(_ lager.Logger) ([]string, error) {
+					if fakeDB.DomainsCallCount() == 1 {
+						return []string{}, nil
+					}
+					return []string{"some-domain"}, nil
+				}
+			})
+
+			It("records the domain and its ttl", func() {
+				serve(fakeAuditor)
+
+				Expect(recorded().Changes).To(ConsistOf(
+					&models.AuditChange{Field: "domain", After: "some-domain"},
+					&models.AuditChange{Field: "ttl", After: "120"},
+				))
+			})
+		})
+
+		Context("when updating a task schedule", func() {
+			var before, after *models.TaskSchedule
+
+			BeforeEach(func() {
+				route = bbs.UpdateTaskScheduleRoute
+				responseBody = &models.TaskScheduleLifecycleResponse{}
+
+				before = &models.TaskSchedule{
+					ScheduleGuid:   "schedule-guid",
+					Domain:         "some-domain",
+					CronExpression: "@hourly",
+					TaskDefinition: model_helpers.NewValidTaskDefinition(),
+				}
+				after = &models.TaskSchedule{
+					ScheduleGuid:   "schedule-guid",
+					Domain:         "some-domain",
+					CronExpression: "@daily",
+					TaskDefinition: model_helpers.NewValidTaskDefinition(),
+				}
+				request = newTestRequest(&models.UpdateTaskScheduleRequest{TaskSchedule: after})
+
+				fakeScheduleDB.TaskScheduleByGuidStub = func(_ lager.Logger, _ string) (*models.TaskSchedule, error) {
+					if fakeScheduleDB.TaskScheduleByGuidCallCount() == 1 {
+						return before, nil
+					}
+					return after, nil
+				}
+			})
+
+			It("records the changed fields of the schedule", func() {
+				serve(fakeAuditor)
+
+				_, guid := fakeScheduleDB.TaskScheduleByGuidArgsForCall(0)
+				Expect(guid).To(Equal("schedule-guid"))
+
+				record := recorded()
+				Expect(record.Route).To(Equal(bbs.UpdateTaskScheduleRoute))
+				Expect(record.TargetGuid).To(Equal("schedule-guid"))
+				Expect(record.Changes).To(ConsistOf(
+					&models.AuditChange{Field: "cron_expression", Before: "@hourly", After: "@daily"},
+				))
+			})
+
+			Context("when task schedules are not kept in the database", func() {
+				BeforeEach(func() {
+					taskScheduleDB = nil
+				})
+
+				It("records the request without changes", func() {
+					serve(fakeAuditor)
+
+					record := recorded()
+					Expect(record.TargetGuid).To(Equal("schedule-guid"))
+					Expect(record.Changes).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when removing a task schedule", func() {
+			BeforeEach(func() {
+				route = bbs.RemoveTaskScheduleRoute
+				responseBody = &models.TaskScheduleLifecycleResponse{}
+				request = newTestRequest(&models.TaskScheduleGuidRequest{ScheduleGuid: "schedule-guid"})
+
+				schedule := &models.TaskSchedule{ScheduleGuid: "schedule-guid", CronExpression: "@hourly"}
+				fakeScheduleDB.TaskScheduleByGuidStub = func(_ lager.Logger, _ string) (*models.TaskSchedule, error) {
+					if fakeScheduleDB.TaskScheduleByGuidCallCount() == 1 {
+						return schedule, nil
+					}
+					return nil, models.ErrResourceNotFound
+				}
+			})
+
+			It("records the fields of the removed schedule", func() {
+				serve(fakeAuditor)
+
+				record := recorded()
+				Expect(record.TargetGuid).To(Equal("schedule-guid"))
+				Expect(record.Changes).To(ContainElement(&models.AuditChange{Field: "cron_expression", Before: "@hourly"}))
+			})
+		})
+
+		Context("when cancelling tasks in bulk", func() {
+			var pendingTask, runningTask *models.Task
+
+			BeforeEach(func() {
+				route = bbs.CancelTasksRoute
+				request = newTestRequest(&models.CancelTasksRequest{
+					Selector: &models.TaskSelector{Domain: "some-domain"},
+				})
+				responseBody = &models.CancelTasksResponse{
+					Results: []*models.TaskResult{
+						{TaskGuid: "pending-task"},
+						{TaskGuid: "running-task", Error: models.ErrResourceNotFound},
+					},
+				}
+
+				pendingTask = model_helpers.NewValidTask("pending-task")
+				pendingTask.State = models.Task_Pending
+				runningTask = model_helpers.NewValidTask("running-task")
+				runningTask.State = models.Task_Running
+				fakeDB.TasksReturns([]*models.Task{pendingTask, runningTask}, nil)
+
+				fakeDB.TaskByGuidStub = func(_ lager.Logger, taskGuid string) (*models.Task, error) {
+					if taskGuid == "running-task" {
+						return runningTask, nil
+					}
+					task := *pendingTask
+					task.State = models.Task_Completed
+					return &task, nil
+				}
+			})
+
+			It("fetches the candidate tasks using the selector", func() {
+				serve(fakeAuditor)
+
+				Expect(fakeDB.TasksCallCount()).To(Equal(1))
+				_, filter := fakeDB.TasksArgsForCall(0)
+				Expect(filter).To(Equal(models.TaskFilter{Domain: "some-domain"}))
+			})
+
+			Context("when the selector matches on age", func() {
+				BeforeEach(func() {
+					request = newTestRequest(&models.CancelTasksRequest{
+						Selector: &models.TaskSelector{Domain: "some-domain", MinAge: int64(time.Hour)},
+					})
+				})
+
+				It("fetches the candidate tasks regardless of their age", func() {
+					serve(fakeAuditor)
+
+					_, filter := fakeDB.TasksArgsForCall(0)
+					Expect(filter).To(Equal(models.TaskFilter{Domain: "some-domain"}))
+				})
+			})
+
+			It("writes one audit record per task", func() {
+				serve(fakeAuditor)
+
+				Expect(fakeAuditor.RecordCallCount()).To(Equal(2))
+				_, first := fakeAuditor.RecordArgsForCall(0)
+				Expect(first.Route).To(Equal(bbs.CancelTasksRoute))
+				Expect(first.TargetGuid).To(Equal("pending-task"))
+				Expect(first.Error).To(BeEmpty())
+				_, second := fakeAuditor.RecordArgsForCall(1)
+				Expect(second.TargetGuid).To(Equal("running-task"))
+				Expect(second.Error).To(Equal(models.ErrResourceNotFound.Error()))
+			})
+
+			It("records the selector and how each task changed", func() {
+				serve(fakeAuditor)
+
+				_, first := fakeAuditor.RecordArgsForCall(0)
+				Expect(first.Changes).To(ConsistOf(
+					&models.AuditChange{Field: "selector.domain", After: "some-domain"},
+					&models.AuditChange{Field: "state", Before: "Pending", After: "Completed"},
+				))
+				_, second := fakeAuditor.RecordArgsForCall(1)
+				Expect(second.Changes).To(ConsistOf(
+					&models.AuditChange{Field: "selector.domain", After: "some-domain"},
+				))
+			})
+
+			Context("when the request fails as a whole", func() {
+				BeforeEach(func() {
+					responseBody = &models.CancelTasksResponse{Error: models.ErrBadRequest}
+				})
+
+				It("records the error once without a target", func() {
+					serve(fakeAuditor)
+
+					record := recorded()
+					Expect(record.TargetGuid).To(BeEmpty())
+					Expect(record.Error).To(Equal(models.ErrBadRequest.Error()))
+					Expect(record.Changes).To(ConsistOf(
+						&models.AuditChange{Field: "selector.domain", After: "some-domain"},
+					))
+				})
+			})
+		})
+
+		Context("when deleting tasks in bulk", func() {
+			BeforeEach(func() {
+				route = bbs.DeleteTasksRoute
+				request = newTestRequest(&models.DeleteTasksRequest{
+					Selector: &models.TaskSelector{State: models.Task_Completed},
+				})
+				responseBody = &models.DeleteTasksResponse{
+					Results: []*models.TaskResult{{TaskGuid: "completed-task"}},
+				}
+
+				task := model_helpers.NewValidTask("completed-task")
+				task.State = models.Task_Completed
+				fakeDB.TasksReturns([]*models.Task{task}, nil)
+				fakeDB.TaskByGuidReturns(nil, models.ErrResourceNotFound)
+			})
+
+			It("records the fields of each deleted task", func() {
+				serve(fakeAuditor)
+
+				record := recorded()
+				Expect(record.TargetGuid).To(Equal("completed-task"))
+				Expect(record.Changes).To(ContainElement(&models.AuditChange{Field: "selector.state", After: "Completed"}))
+				Expect(record.Changes).To(ContainElement(&models.AuditChange{Field: "task_guid", Before: "completed-task"}))
+			})
+		})
+
+		Context("when the request cannot be parsed", func() {
+			BeforeEach(func() {
+				route = bbs.CancelTaskRoute
+				request = newTestRequest("garbage")
+			})
+
+			It("does not record it", func() {
+				serve(fakeAuditor)
+
+				Expect(handlerBody).To(Equal([]byte("garbage")))
+				Expect(fakeAuditor.RecordCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("for a route that is not audited", func() {
+			BeforeEach(func() {
+				route = bbs.StartTaskRoute
+				request = newTestRequest(&models.StartTaskRequest{TaskGuid: "task-guid", CellId: "cell-id"})
+			})
+
+			It("does not record it", func() {
+				serve(fakeAuditor)
+
+				Expect(handlerBody).NotTo(BeEmpty())
+				Expect(fakeAuditor.RecordCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/lager"
+)
+
+const cellIdentityMismatchCount = metric.Counter("CellIdentityMismatchCount")
+
+type instanceKeyRequest interface {
+	GetActualLrpInstanceKey() *models.ActualLRPInstanceKey
+}
+
+type cellIdRequest interface {
+	GetCellId() string
+}
+
+// cellScopedRoutes are the routes a cell uses to change the state of the
+// ActualLRPs and Tasks it is running.
+var cellScopedRoutes = map[string]func() proto.Message{
+	bbs.ClaimActualLRPRoute: func() proto.Message { return &models.ClaimActualLRPRequest{} },
+	bbs.StartActualLRPRoute: func() proto.Message { return &models.StartActualLRPRequest{} },
+	bbs.CrashActualLRPRoute: func() proto.Message { return &models.CrashActualLRPRequest{} },
+
+	bbs.RemoveEvacuatingActualLRPRoute: func() proto.Message { return &models.RemoveEvacuatingActualLRPRequest{} },
+	bbs.EvacuateClaimedActualLRPRoute:  func() proto.Message { return &models.EvacuateClaimedActualLRPRequest{} },
+	bbs.EvacuateCrashedActualLRPRoute:  func() proto.Message { return &models.EvacuateCrashedActualLRPRequest{} },
+	bbs.EvacuateStoppedActualLRPRoute:  func() proto.Message { return &models.EvacuateStoppedActualLRPRequest{} },
+	bbs.EvacuateRunningActualLRPRoute:  func() proto.Message { return &models.EvacuateRunningActualLRPRequest{} },
+
+	bbs.StartTaskRoute:     func() proto.Message { return &models.StartTaskRequest{} },
+	bbs.TaskHeartbeatRoute: func() proto.Message { return &models.TaskHeartbeatRequest{} },
+	bbs.CompleteTaskRoute:  func() proto.Message { return &models.CompleteTaskRequest{} },
+}
+
+// CellIdentityWrap rejects requests to cell scoped routes whose cell id does
+// not match the common name of the client certificate, so that a cell can only
+// change the state of its own work. Other routes are passed through.
+func CellIdentityWrap(logger lager.Logger, route string, handler http.Handler) http.Handler {
+	newRequest, ok := cellScopedRoutes[route]
+	if !ok {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		logger := logger.Session("cell-identity", lager.Data{"route": route})
+
+		if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+			rejectCellIdentity(logger, w, "", "")
+			return
+		}
+		identity := req.TLS.PeerCertificates[0].Subject.CommonName
+
+		data, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			logger.Error("failed-to-read-body", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+		// requests that fail to parse are left for the handler to reject
+		request := newRequest()
+		if proto.Unmarshal(data, request) != nil {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		cellId := requestCellId(request)
+		if cellId != identity {
+			rejectCellIdentity(logger, w, identity, cellId)
+			return
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func requestCellId(request proto.Message) string {
+	switch r := request.(type) {
+	case instanceKeyRequest:
+		return r.GetActualLrpInstanceKey().GetCellId()
+	case cellIdRequest:
+		return r.GetCellId()
+	}
+	return ""
+}
+
+func rejectCellIdentity(logger lager.Logger, w http.ResponseWriter, identity, cellId string) {
+	logger.Info("cell-identity-mismatch", lager.Data{"identity": identity, "cell_id": cellId})
+
+	err := cellIdentityMismatchCount.Increment()
+	if err != nil {
+		logger.Error("failed-to-send-cell-identity-mismatch-count-metric", err)
+	}
+
+	w.WriteHeader(http.StatusForbidden)
+}
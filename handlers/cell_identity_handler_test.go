@@ -0,0 +1,171 @@
+package handlers_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+	"github.com/gogo/protobuf/proto"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cell Identity Handler", func() {
+	var (
+		logger *lagertest.TestLogger
+		sender *fake.FakeMetricSender
+
+		route      string
+		called     bool
+		calledBody []byte
+
+		request          *http.Request
+		responseRecorder *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		sender = fake.NewFakeMetricSender()
+		dropsonde_metrics.Initialize(sender, nil)
+
+		called = false
+		calledBody = nil
+		responseRecorder = httptest.NewRecorder()
+	})
+
+	serve := func() {
+		inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			var err error
+			calledBody, err = ioutil.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		handlers.CellIdentityWrap(logger, route, inner).ServeHTTP(responseRecorder, request)
+	}
+
+	withCert := func(cn string) {
+		request.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: cn}}},
+		}
+	}
+
+	Context("for a route keyed by actual lrp instance key", func() {
+		var requestBody *models.ClaimActualLRPRequest
+
+		BeforeEach(func() {
+			route = bbs.ClaimActualLRPRoute
+			instanceKey := models.NewActualLRPInstanceKey("instance-guid", "cell-1")
+			requestBody = &models.ClaimActualLRPRequest{
+				ProcessGuid:          "process-guid",
+				Index:                1,
+				ActualLrpInstanceKey: &instanceKey,
+			}
+			request = newTestRequest(requestBody)
+		})
+
+		Context("when the certificate identifies the cell in the request", func() {
+			BeforeEach(func() {
+				withCert("cell-1")
+			})
+
+			It("passes the unread request on to the handler", func() {
+				serve()
+
+				Expect(called).To(BeTrue())
+				expectedBody, err := proto.Marshal(requestBody)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(calledBody).To(Equal(expectedBody))
+			})
+		})
+
+		Context("when the certificate identifies another cell", func() {
+			BeforeEach(func() {
+				withCert("cell-2")
+			})
+
+			It("rejects the request", func() {
+				serve()
+
+				Expect(called).To(BeFalse())
+				Expect(responseRecorder.Code).To(Equal(http.StatusForbidden))
+			})
+
+			It("logs and counts the rejection", func() {
+				serve()
+
+				Expect(logger).To(gbytes.Say("cell-identity-mismatch"))
+				Expect(sender.GetCounter("CellIdentityMismatchCount")).To(Equal(uint64(1)))
+			})
+		})
+
+		Context("when there is no client certificate", func() {
+			It("rejects the request", func() {
+				serve()
+
+				Expect(called).To(BeFalse())
+				Expect(responseRecorder.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when the request cannot be parsed", func() {
+			BeforeEach(func() {
+				request = newTestRequest("garbage")
+				withCert("cell-1")
+			})
+
+			It("leaves the request for the handler to reject", func() {
+				serve()
+
+				Expect(called).To(BeTrue())
+				Expect(calledBody).To(Equal([]byte("garbage")))
+			})
+		})
+	})
+
+	Context("for a route keyed by cell id", func() {
+		BeforeEach(func() {
+			route = bbs.CompleteTaskRoute
+			request = newTestRequest(&models.CompleteTaskRequest{
+				TaskGuid: "task-guid",
+				CellId:   "cell-1",
+			})
+		})
+
+		It("passes requests from the cell on to the handler", func() {
+			withCert("cell-1")
+			serve()
+			Expect(called).To(BeTrue())
+		})
+
+		It("rejects requests from another cell", func() {
+			withCert("cell-2")
+			serve()
+			Expect(called).To(BeFalse())
+			Expect(responseRecorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("for a route that is not cell scoped", func() {
+		BeforeEach(func() {
+			route = bbs.DesireTaskRoute
+			request = newTestRequest("anything")
+		})
+
+		It("passes the request on to the handler", func() {
+			serve()
+			Expect(called).To(BeTrue())
+		})
+	})
+})
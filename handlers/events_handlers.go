@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/models"
@@ -9,35 +13,89 @@ import (
 )
 
 type EventHandler struct {
-	logger     lager.Logger
-	desiredHub events.Hub
-	actualHub  events.Hub
+	logger            lager.Logger
+	desiredHub        events.Hub
+	actualHub         events.Hub
+	taskHub           events.Hub
+	cellHub           events.Hub
+	heartbeatInterval time.Duration
 }
 
-func NewEventHandler(logger lager.Logger, desiredHub, actualHub events.Hub) *EventHandler {
+// NewEventHandler returns a handler for the event streams. Every
+// heartbeatInterval without events, the streams send a heartbeat, and
+// WebSocket streams are pinged every heartbeatInterval; zero disables both.
+func NewEventHandler(logger lager.Logger, desiredHub, actualHub, taskHub, cellHub events.Hub, heartbeatInterval time.Duration) *EventHandler {
 	return &EventHandler{
-		desiredHub: desiredHub,
-		actualHub:  actualHub,
-		logger:     logger.Session("events-handler"),
+		desiredHub:        desiredHub,
+		actualHub:         actualHub,
+		taskHub:           taskHub,
+		cellHub:           cellHub,
+		heartbeatInterval: heartbeatInterval,
+		logger:            logger.Session("events-handler"),
 	}
 }
 
-func streamEventsToResponse(logger lager.Logger, w http.ResponseWriter, eventChan <-chan models.Event, errorChan <-chan error) {
-	w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
-	w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Header().Add("Connection", "keep-alive")
+// subscribeToHub streams the events of the hub to the response, identified by
+// the id the hub assigned to them. A client reconnecting with the
+// Last-Event-ID header resumes after that event, or gets a 410 Gone if the
+// events it missed are no longer available and it has to re-list instead.
+// Only the events matching the filter given in the query are streamed.
+func subscribeToHub(logger lager.Logger, hub events.Hub, heartbeatInterval time.Duration, w http.ResponseWriter, req *http.Request) {
+	subscribeToTranslatedHub(logger, hub, nil, heartbeatInterval, w, req)
+}
 
-	w.WriteHeader(http.StatusOK)
+// eventTranslator turns an event of a hub into the events streamed to the
+// client in its place, if any.
+type eventTranslator func(models.Event) []models.Event
+
+// subscribeToTranslatedHub streams the events of the hub like subscribeToHub,
+// translated by translate, with the filter given in the query applying to the
+// translated events. When an event is translated into several, only the last
+// carries the id of the hub event; the others have no id, which leaves the
+// client's last event id as it was, so that a client that disconnects part way
+// through resumes from the start of them.
+func subscribeToTranslatedHub(logger lager.Logger, hub events.Hub, translate eventTranslator, heartbeatInterval time.Duration, w http.ResponseWriter, req *http.Request) {
+	filter, ok := filterFromRequest(logger, w, req)
+	if !ok {
+		return
+	}
+
+	hubFilter := filter
+	if translate != nil {
+		hubFilter.EventTypes = nil
+	} else {
+		translate = func(event models.Event) []models.Event { return []models.Event{event} }
+	}
+
+	source, ok := subscribeFromRequest(logger, hub, hubFilter, w, req)
+	if !ok {
+		return
+	}
+	defer source.Close()
+
+	eventChan := make(chan sequencedEvent)
+	errorChan := make(chan error)
+	closeChan := make(chan struct{})
+	defer close(closeChan)
+
+	go streamSequencedSource(eventChan, errorChan, closeChan, source)
+
+	writeEventStreamHeader(w)
 
 	flusher := w.(http.Flusher)
-	flusher.Flush()
-	var event models.Event
-	eventID := 0
 	closeNotifier := w.(http.CloseNotifier).CloseNotify()
+	heartbeats := newHeartbeater(w, req, heartbeatInterval)
+	defer heartbeats.stop()
 
 	for {
+		var next sequencedEvent
 		select {
-		case event = <-eventChan:
+		case next = <-eventChan:
+		case <-heartbeats.c:
+			if !heartbeats.write() {
+				return
+			}
+			continue
 		case err := <-errorChan:
 			logger.Error("failed-to-get-next-event", err)
 			return
@@ -45,23 +103,221 @@ func streamEventsToResponse(logger lager.Logger, w http.ResponseWriter, eventCha
 			return
 		}
 
-		sseEvent, err := events.NewEventFromModelEvent(eventID, event)
-		if err != nil {
-			logger.Error("failed-to-marshal-event", err)
+		streamed := []models.Event{}
+		for _, event := range translate(next.event) {
+			if filter.Matches(event) {
+				streamed = append(streamed, event)
+			}
+		}
+		if len(streamed) == 0 {
+			continue
+		}
+
+		for i, event := range streamed {
+			id := ""
+			if i == len(streamed)-1 {
+				id = strconv.FormatUint(next.id, 10)
+			}
+			if !writeEvent(logger, w, id, event) {
+				return
+			}
+		}
+
+		flusher.Flush()
+		heartbeats.reset()
+	}
+}
+
+// filterFromRequest reads the event filter given in the query. If it is
+// invalid, it writes a 400 Bad Request and returns false.
+func filterFromRequest(logger lager.Logger, w http.ResponseWriter, req *http.Request) (models.EventFilter, bool) {
+	filter := events.NewFilterFromQuery(req.URL.Query())
+	err := filter.Validate()
+	if err != nil {
+		logger.Error("invalid-event-filter", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.EventFilter{}, false
+	}
+	return filter, true
+}
+
+// lastEventIDFromRequest returns the id of the last event the client read,
+// from the Last-Event-ID header or the last_event_id query parameter.
+func lastEventIDFromRequest(req *http.Request) string {
+	lastEventID := req.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = req.URL.Query().Get(events.WebSocketLastEventIDParam)
+	}
+	return lastEventID
+}
+
+// subscribeFromRequest subscribes to the hub with the filter, resuming after
+// the last event the client read. If it cannot, it writes the error status to
+// the response and returns false.
+func subscribeFromRequest(logger lager.Logger, hub events.Hub, filter models.EventFilter, w http.ResponseWriter, req *http.Request) (events.SequencedEventSource, bool) {
+	var source events.SequencedEventSource
+	var err error
+
+	lastEventID := lastEventIDFromRequest(req)
+	if lastEventID == "" {
+		source, err = hub.Subscribe(filter)
+	} else {
+		logger = logger.WithData(lager.Data{"last_event_id": lastEventID})
+
+		var id uint64
+		id, err = strconv.ParseUint(lastEventID, 10, 64)
+		if err == nil {
+			source, err = hub.SubscribeAfter(filter, id)
+		} else {
+			err = events.ErrEventsExpired
+		}
+	}
+
+	if err == events.ErrEventsExpired {
+		logger.Info("cannot-resume-event-stream")
+		w.WriteHeader(http.StatusGone)
+		return nil, false
+	}
+	if err != nil {
+		logger.Error("failed-to-subscribe-to-event-hub", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return source, true
+}
+
+func streamEventsToResponse(logger lager.Logger, heartbeatInterval time.Duration, w http.ResponseWriter, req *http.Request, eventChan <-chan models.Event, errorChan <-chan error) {
+	writeEventStreamHeader(w)
+
+	flusher := w.(http.Flusher)
+	var event models.Event
+	var eventID uint64
+	closeNotifier := w.(http.CloseNotifier).CloseNotify()
+	heartbeats := newHeartbeater(w, req, heartbeatInterval)
+	defer heartbeats.stop()
+
+	for {
+		select {
+		case event = <-eventChan:
+		case <-heartbeats.c:
+			if !heartbeats.write() {
+				return
+			}
+			continue
+		case err := <-errorChan:
+			logger.Error("failed-to-get-next-event", err)
+			return
+		case <-closeNotifier:
 			return
 		}
 
-		err = sseEvent.Write(w)
-		if err != nil {
+		if !writeEvent(logger, w, strconv.FormatUint(eventID, 10), event) {
 			return
 		}
 
 		flusher.Flush()
+		heartbeats.reset()
 
 		eventID++
 	}
 }
 
+// heartbeater fires when an event stream has been idle for the heartbeat
+// interval. Clients that ask for heartbeats with the heartbeat query parameter
+// are sent heartbeat events; the others are sent SSE comments, which keep the
+// connection alive without them having to understand heartbeats.
+type heartbeater struct {
+	c        <-chan time.Time
+	w        http.ResponseWriter
+	interval time.Duration
+	events   bool
+	timer    *time.Timer
+}
+
+func newHeartbeater(w http.ResponseWriter, req *http.Request, interval time.Duration) *heartbeater {
+	h := &heartbeater{
+		w:        w,
+		interval: interval,
+		events:   req.URL.Query().Get(events.HeartbeatParam) == "true",
+	}
+
+	if interval > 0 {
+		h.timer = time.NewTimer(interval)
+		h.c = h.timer.C
+		// Tell the client the interval straight away, so that it can tell a
+		// dead connection from an idle one before the first event.
+		if h.events {
+			h.write()
+		}
+	}
+
+	return h
+}
+
+func (h *heartbeater) write() bool {
+	var err error
+	if h.events {
+		err = events.WriteHeartbeatEvent(h.w, h.interval)
+	} else {
+		err = events.WriteHeartbeatComment(h.w)
+	}
+	if err != nil {
+		return false
+	}
+
+	h.w.(http.Flusher).Flush()
+	h.reset()
+	return true
+}
+
+func (h *heartbeater) reset() {
+	if h.timer == nil {
+		return
+	}
+	if !h.timer.Stop() {
+		select {
+		case <-h.timer.C:
+		default:
+		}
+	}
+	h.timer.Reset(h.interval)
+}
+
+func (h *heartbeater) stop() {
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+}
+
+func writeEventStreamHeader(w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Add("Connection", "keep-alive")
+
+	w.WriteHeader(http.StatusOK)
+
+	w.(http.Flusher).Flush()
+}
+
+func writeEvent(logger lager.Logger, w http.ResponseWriter, eventID string, event models.Event) bool {
+	sseEvent, err := events.NewEventFromModelEvent(0, event)
+	if err != nil {
+		logger.Error("failed-to-marshal-event", err)
+		return false
+	}
+	sseEvent.ID = eventID
+
+	encoded := sseEvent.Encode()
+	if eventID == "" {
+		// an empty id line would reset the client's last event id
+		encoded = strings.TrimPrefix(encoded, "id: \n")
+	}
+
+	_, err = io.WriteString(w, encoded)
+	return err == nil
+}
+
 type EventFetcher func() (models.Event, error)
 
 func streamSource(eventChan chan<- models.Event, errorChan chan<- error, closeChan chan struct{}, fetchEvent EventFetcher) {
@@ -81,3 +337,26 @@ func streamSource(eventChan chan<- models.Event, errorChan chan<- error, closeCh
 		}
 	}
 }
+
+type sequencedEvent struct {
+	id    uint64
+	event models.Event
+}
+
+func streamSequencedSource(eventChan chan<- sequencedEvent, errorChan chan<- error, closeChan chan struct{}, source events.SequencedEventSource) {
+	for {
+		id, event, err := source.NextSequenced()
+		if err != nil {
+			select {
+			case errorChan <- err:
+			case <-closeChan:
+			}
+			return
+		}
+		select {
+		case eventChan <- sequencedEvent{id: id, event: event}:
+		case <-closeChan:
+			return
+		}
+	}
+}
@@ -8,21 +8,12 @@ import (
 
 func (h *EventHandler) SubscribeToActualLRPEvents(w http.ResponseWriter, req *http.Request) {
 	logger := h.logger.Session("subscribe-desired")
+	subscribeToHub(logger, h.actualHub, h.heartbeatInterval, w, req)
+}
 
-	source, err := h.actualHub.Subscribe()
-	if err != nil {
-		logger.Error("failed-to-subscribe-to-event-hub", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	defer source.Close()
-
-	eventChan := make(chan models.Event)
-	errorChan := make(chan error)
-	closeChan := make(chan struct{})
-	defer close(closeChan)
-
-	go streamSource(eventChan, errorChan, closeChan, source.Next)
-
-	streamEventsToResponse(logger, w, eventChan, errorChan)
+// SubscribeToLRPInstanceEvents streams the events of the ActualLRP hub as
+// events about individual ActualLRPs rather than ActualLRP groups.
+func (h *EventHandler) SubscribeToLRPInstanceEvents(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("subscribe-lrp-instances")
+	subscribeToTranslatedHub(logger, h.actualHub, models.ActualLRPInstanceEvents, h.heartbeatInterval, w, req)
 }
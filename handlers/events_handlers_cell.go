@@ -0,0 +1,8 @@
+package handlers
+
+import "net/http"
+
+func (h *EventHandler) SubscribeToCellEvents(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("subscribe-cell")
+	subscribeToHub(logger, h.cellHub, h.heartbeatInterval, w, req)
+}
@@ -9,7 +9,7 @@ import (
 func (h *EventHandler) Subscribe_r0(w http.ResponseWriter, req *http.Request) {
 	logger := h.logger.Session("subscribe-r0")
 
-	desiredSource, err := h.desiredHub.Subscribe()
+	desiredSource, err := h.desiredHub.Subscribe(models.EventFilter{})
 	if err != nil {
 		logger.Error("failed-to-subscribe-to-desired-event-hub", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -17,7 +17,7 @@ func (h *EventHandler) Subscribe_r0(w http.ResponseWriter, req *http.Request) {
 	}
 	defer desiredSource.Close()
 
-	actualSource, err := h.actualHub.Subscribe()
+	actualSource, err := h.actualHub.Subscribe(models.EventFilter{})
 	if err != nil {
 		logger.Error("failed-to-subscribe-to-actual-event-hub", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -42,5 +42,5 @@ func (h *EventHandler) Subscribe_r0(w http.ResponseWriter, req *http.Request) {
 	go streamSource(eventChan, errorChan, closeChan, desiredEventsFetcher)
 	go streamSource(eventChan, errorChan, closeChan, actualSource.Next)
 
-	streamEventsToResponse(logger, w, eventChan, errorChan)
+	streamEventsToResponse(logger, h.heartbeatInterval, w, req, eventChan, errorChan)
 }
@@ -0,0 +1,8 @@
+package handlers
+
+import "net/http"
+
+func (h *EventHandler) SubscribeToTaskEvents(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("subscribe-task")
+	subscribeToHub(logger, h.taskHub, h.heartbeatInterval, w, req)
+}
@@ -1,10 +1,15 @@
 package handlers_test
 
 import (
+	"bufio"
 	"encoding/base64"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
@@ -12,6 +17,8 @@ import (
 	"github.com/cloudfoundry-incubator/bbs/handlers"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/websocket"
 	"github.com/pivotal-golang/lager"
 	"github.com/pivotal-golang/lager/lagertest"
 	"github.com/vito/go-sse/sse"
@@ -25,24 +32,41 @@ var _ = Describe("Event Handlers", func() {
 		logger     lager.Logger
 		desiredHub events.Hub
 		actualHub  events.Hub
-
-		handler         *handlers.EventHandler
-		eventStreamDone chan struct{}
-		server          *httptest.Server
+		taskHub    events.Hub
+		cellHub    events.Hub
+
+		handler             *handlers.EventHandler
+		heartbeatInterval   time.Duration
+		eventStreamDone     chan struct{}
+		eventStreamDoneOnce *sync.Once
+		server              *httptest.Server
 	)
 
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		desiredHub = events.NewHub()
 		actualHub = events.NewHub()
-		handler = handlers.NewEventHandler(logger, desiredHub, actualHub)
+		taskHub = events.NewHub()
+		cellHub = events.NewHub()
+		heartbeatInterval = 0
 
 		eventStreamDone = make(chan struct{})
+		eventStreamDoneOnce = new(sync.Once)
+	})
+
+	JustBeforeEach(func() {
+		handler = handlers.NewEventHandler(logger, desiredHub, actualHub, taskHub, cellHub, heartbeatInterval)
 	})
 
+	closeEventStreamDone := func() {
+		eventStreamDoneOnce.Do(func() { close(eventStreamDone) })
+	}
+
 	AfterEach(func() {
 		desiredHub.Close()
 		actualHub.Close()
+		taskHub.Close()
+		cellHub.Close()
 		server.Close()
 	})
 
@@ -78,17 +102,19 @@ var _ = Describe("Event Handlers", func() {
 					hub.Emit(&eventfakes.FakeEvent{Token: "A"})
 					encodedPayload := base64.StdEncoding.EncodeToString([]byte("A"))
 
-					Expect(reader.Next()).To(Equal(sse.Event{
-						ID:   "0",
-						Name: "fake",
-						Data: []byte(encodedPayload),
-					}))
+					first, err := reader.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(first.Name).To(Equal("fake"))
+					Expect(first.Data).To(Equal([]byte(encodedPayload)))
+
+					firstID, err := strconv.ParseUint(first.ID, 10, 64)
+					Expect(err).NotTo(HaveOccurred())
 
 					hub.Emit(&eventfakes.FakeEvent{Token: "B"})
 
 					encodedPayload = base64.StdEncoding.EncodeToString([]byte("B"))
 					Expect(reader.Next()).To(Equal(sse.Event{
-						ID:   "1",
+						ID:   strconv.FormatUint(firstID+1, 10),
 						Name: "fake",
 						Data: []byte(encodedPayload),
 					}))
@@ -139,7 +165,7 @@ var _ = Describe("Event Handlers", func() {
 		BeforeEach(func() {
 			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				handler.Subscribe_r0(w, r)
-				close(eventStreamDone)
+				closeEventStreamDone()
 			}))
 		})
 
@@ -172,16 +198,77 @@ var _ = Describe("Event Handlers", func() {
 		})
 	})
 
+	var ItResumesEventsFromHub = func(hubRef *events.Hub) {
+		Describe("Resuming Events", func() {
+			var hub events.Hub
+
+			BeforeEach(func() {
+				hub = *hubRef
+			})
+
+			getAfter := func(lastEventID string) *http.Response {
+				request, err := http.NewRequest("GET", server.URL, nil)
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Last-Event-ID", lastEventID)
+
+				response, err := http.DefaultClient.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+				return response
+			}
+
+			It("replays the events emitted after the Last-Event-ID", func() {
+				response, err := http.Get(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+				reader := sse.NewReadCloser(response.Body)
+
+				hub.Emit(&eventfakes.FakeEvent{Token: "A"})
+				first, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+
+				hub.Emit(&eventfakes.FakeEvent{Token: "B"})
+				hub.Emit(&eventfakes.FakeEvent{Token: "C"})
+
+				resumed := sse.NewReadCloser(getAfter(first.ID).Body)
+
+				event, err := resumed.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.Data).To(Equal([]byte(base64.StdEncoding.EncodeToString([]byte("B")))))
+
+				event, err = resumed.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.Data).To(Equal([]byte(base64.StdEncoding.EncodeToString([]byte("C")))))
+
+				Expect(reader.Close()).To(Succeed())
+				Expect(resumed.Close()).To(Succeed())
+			})
+
+			Context("when the events after the Last-Event-ID are no longer buffered", func() {
+				It("responds with 410 Gone", func() {
+					response := getAfter("1")
+					Expect(response.StatusCode).To(Equal(http.StatusGone))
+				})
+			})
+
+			Context("when the Last-Event-ID is not an event id", func() {
+				It("responds with 410 Gone", func() {
+					response := getAfter("garbage")
+					Expect(response.StatusCode).To(Equal(http.StatusGone))
+				})
+			})
+		})
+	}
+
 	Describe("SubscribeToDesiredLRPEvents", func() {
 		BeforeEach(func() {
 			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				handler.SubscribeToDesiredLRPEvents(w, r)
-				close(eventStreamDone)
+				closeEventStreamDone()
 			}))
 		})
 
 		Describe("Subscribe to Desired Events", func() {
 			ItStreamsEventsFromHub(&desiredHub)
+			ItResumesEventsFromHub(&desiredHub)
 
 			It("does not migrate desired lrps down to v0", func() {
 				response, err := http.Get(server.URL)
@@ -193,10 +280,71 @@ var _ = Describe("Event Handlers", func() {
 
 				desiredHub.Emit(event)
 
-				expectedEvent, err := events.NewEventFromModelEvent(0, event)
+				streamed, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				eventID, err := strconv.ParseUint(streamed.ID, 10, 64)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(reader.Next()).To(Equal(expectedEvent))
+				expectedEvent, err := events.NewEventFromModelEvent(eventID, event)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(streamed).To(Equal(expectedEvent))
+			})
+		})
+	})
+
+	Describe("Heartbeats", func() {
+		BeforeEach(func() {
+			heartbeatInterval = 20 * time.Millisecond
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handler.SubscribeToDesiredLRPEvents(w, r)
+				closeEventStreamDone()
+			}))
+		})
+
+		It("sends an SSE comment when the stream is idle", func() {
+			response, err := http.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+
+			line, err := bufio.NewReader(response.Body).ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(line).To(Equal(": heartbeat\n"))
+		})
+
+		Context("when the client asks for heartbeat events", func() {
+			var reader *sse.ReadCloser
+
+			JustBeforeEach(func() {
+				response, err := http.Get(server.URL + "?heartbeat=true")
+				Expect(err).NotTo(HaveOccurred())
+				reader = sse.NewReadCloser(response.Body)
+			})
+
+			AfterEach(func() {
+				reader.Close()
+			})
+
+			It("sends one straight away with the interval in milliseconds", func() {
+				event, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.Name).To(Equal(events.HeartbeatEventName))
+				Expect(string(event.Data)).To(Equal("20"))
+			})
+
+			It("keeps the id of the last event", func() {
+				_, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+
+				desiredHub.Emit(models.NewDesiredLRPCreatedEvent(model_helpers.NewValidDesiredLRP("guid")))
+				streamed, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(streamed.Name).To(Equal(models.EventTypeDesiredLRPCreated))
+
+				event, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.Name).To(Equal(events.HeartbeatEventName))
+				Expect(event.ID).To(Equal(streamed.ID))
 			})
 		})
 	})
@@ -205,12 +353,415 @@ var _ = Describe("Event Handlers", func() {
 		BeforeEach(func() {
 			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				handler.SubscribeToActualLRPEvents(w, r)
-				close(eventStreamDone)
+				closeEventStreamDone()
 			}))
 		})
 
 		Describe("Subscribe to Actual Events", func() {
 			ItStreamsEventsFromHub(&actualHub)
+			ItResumesEventsFromHub(&actualHub)
+		})
+	})
+
+	Describe("SubscribeToLRPInstanceEvents", func() {
+		var (
+			instanceLRP   *models.ActualLRP
+			evacuatingLRP *models.ActualLRP
+		)
+
+		BeforeEach(func() {
+			instanceLRP = model_helpers.NewValidActualLRP("guid", 0)
+			evacuatingLRP = model_helpers.NewValidActualLRP("guid", 0)
+			evacuatingLRP.CellId = "other-cell"
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handler.SubscribeToLRPInstanceEvents(w, r)
+				closeEventStreamDone()
+			}))
+		})
+
+		readInstanceEvent := func(reader *sse.ReadCloser) (string, models.Event) {
+			streamed, err := reader.Next()
+			Expect(err).NotTo(HaveOccurred())
+
+			var event models.Event
+			switch streamed.Name {
+			case models.EventTypeActualLRPInstanceCreated:
+				event = new(models.ActualLRPInstanceCreatedEvent)
+			case models.EventTypeActualLRPInstanceRemoved:
+				event = new(models.ActualLRPInstanceRemovedEvent)
+			default:
+				Fail("unexpected event " + streamed.Name)
+			}
+
+			data, err := base64.StdEncoding.DecodeString(string(streamed.Data))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Unmarshal(data, event)).To(Succeed())
+			return streamed.ID, event
+		}
+
+		It("streams the members of ActualLRP groups as individual ActualLRPs", func() {
+			response, err := http.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			reader := sse.NewReadCloser(response.Body)
+			defer reader.Close()
+
+			actualHub.Emit(models.NewActualLRPCreatedEvent(&models.ActualLRPGroup{Instance: instanceLRP, Evacuating: evacuatingLRP}))
+
+			firstID, first := readInstanceEvent(reader)
+			Expect(first).To(Equal(models.NewActualLRPInstanceCreatedEvent(instanceLRP)))
+
+			secondID, second := readInstanceEvent(reader)
+			Expect(second).To(Equal(models.NewActualLRPInstanceCreatedEvent(evacuatingLRP.WithPresence(models.ActualLRP_Evacuating))))
+
+			By("only giving the last event of the group the id of the hub event")
+			Expect(firstID).To(BeEmpty())
+			Expect(secondID).NotTo(BeEmpty())
+
+			By("leaving the id of the client as it was for the others")
+			actualHub.Emit(models.NewActualLRPCreatedEvent(&models.ActualLRPGroup{Instance: instanceLRP, Evacuating: evacuatingLRP}))
+			fourthID, _ := readInstanceEvent(reader)
+			Expect(fourthID).To(Equal(secondID))
+			fifthID, _ := readInstanceEvent(reader)
+			Expect(fifthID).NotTo(Equal(secondID))
+
+			actualHub.Emit(models.NewActualLRPRemovedEvent(&models.ActualLRPGroup{Evacuating: evacuatingLRP}))
+			thirdID, third := readInstanceEvent(reader)
+			Expect(third).To(Equal(models.NewActualLRPInstanceRemovedEvent(evacuatingLRP.WithPresence(models.ActualLRP_Evacuating))))
+			Expect(thirdID).NotTo(Equal(secondID))
+		})
+
+		It("does not send an empty id for the events before the last of a group", func() {
+			response, err := http.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+			body := bufio.NewReader(response.Body)
+
+			actualHub.Emit(models.NewActualLRPCreatedEvent(&models.ActualLRPGroup{Instance: instanceLRP, Evacuating: evacuatingLRP}))
+
+			firstLine, err := body.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(firstLine).To(Equal("event: " + models.EventTypeActualLRPInstanceCreated + "\n"))
+		})
+
+		It("applies the filter to the individual ActualLRPs", func() {
+			response, err := http.Get(server.URL + "?cell_id=other-cell&event_type=" + models.EventTypeActualLRPInstanceCreated)
+			Expect(err).NotTo(HaveOccurred())
+			reader := sse.NewReadCloser(response.Body)
+			defer reader.Close()
+
+			actualHub.Emit(models.NewActualLRPCreatedEvent(&models.ActualLRPGroup{Instance: instanceLRP, Evacuating: evacuatingLRP}))
+
+			_, event := readInstanceEvent(reader)
+			Expect(event).To(Equal(models.NewActualLRPInstanceCreatedEvent(evacuatingLRP.WithPresence(models.ActualLRP_Evacuating))))
+		})
+
+		It("rejects invalid filters", func() {
+			response, err := http.Get(server.URL + "?event_type=bogus")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("SubscribeToTaskEvents", func() {
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handler.SubscribeToTaskEvents(w, r)
+				closeEventStreamDone()
+			}))
+		})
+
+		Describe("Subscribe to Task Events", func() {
+			ItStreamsEventsFromHub(&taskHub)
+			ItResumesEventsFromHub(&taskHub)
+
+			It("streams task events", func() {
+				response, err := http.Get(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+				reader := sse.NewReadCloser(response.Body)
+
+				event := models.NewTaskCreatedEvent(model_helpers.NewValidTask("task-guid"))
+				taskHub.Emit(event)
+
+				streamed, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				eventID, err := strconv.ParseUint(streamed.ID, 10, 64)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedEvent, err := events.NewEventFromModelEvent(eventID, event)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(streamed).To(Equal(expectedEvent))
+			})
+
+			It("streams only the events matching the filter in the query", func() {
+				query := events.NewFilterQuery(models.EventFilter{Domain: "some-domain"})
+				response, err := http.Get(server.URL + "?" + query.Encode())
+				Expect(err).NotTo(HaveOccurred())
+				reader := sse.NewReadCloser(response.Body)
+
+				otherTask := model_helpers.NewValidTask("other-guid")
+				otherTask.Domain = "other-domain"
+				taskHub.Emit(models.NewTaskCreatedEvent(otherTask))
+
+				event := models.NewTaskCreatedEvent(model_helpers.NewValidTask("task-guid"))
+				taskHub.Emit(event)
+
+				streamed, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				expectedEvent, err := events.NewEventFromModelEvent(0, event)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(streamed.Data).To(Equal(expectedEvent.Data))
+			})
+
+			Context("when the filter is invalid", func() {
+				It("responds with 400 Bad Request", func() {
+					query := events.NewFilterQuery(models.EventFilter{EventTypes: []string{"bogus"}})
+					response, err := http.Get(server.URL + "?" + query.Encode())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+	})
+
+	Describe("SubscribeToCellEvents", func() {
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handler.SubscribeToCellEvents(w, r)
+				closeEventStreamDone()
+			}))
+		})
+
+		Describe("Subscribe to Cell Events", func() {
+			ItStreamsEventsFromHub(&cellHub)
+			ItResumesEventsFromHub(&cellHub)
+
+			It("streams cell events", func() {
+				response, err := http.Get(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+				reader := sse.NewReadCloser(response.Body)
+
+				capacity := models.NewCellCapacity(128, 1024, 6)
+				presence := models.NewCellPresence("cell-id", "cell.example.com", "the-zone", capacity, nil, nil)
+				event := models.NewCellAppearedEvent(&presence)
+				cellHub.Emit(event)
+
+				streamed, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				eventID, err := strconv.ParseUint(streamed.ID, 10, 64)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedEvent, err := events.NewEventFromModelEvent(eventID, event)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(streamed).To(Equal(expectedEvent))
+			})
+		})
+	})
+
+	readWebSocketEvent := func(conn *websocket.Conn) (uint64, models.Event, error) {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return 0, nil, err
+		}
+		return events.DecodeWebSocketFrame(messageType, data)
+	}
+
+	Describe("SubscribeToDesiredLRPEventsOverWebSocket", func() {
+		var desiredLRP *models.DesiredLRP
+
+		dial := func(query string) (*websocket.Conn, *http.Response, error) {
+			return websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?"+query, nil)
+		}
+
+		BeforeEach(func() {
+			desiredLRP = model_helpers.NewValidDesiredLRP("guid")
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handler.SubscribeToDesiredLRPEventsOverWebSocket(w, r)
+				closeEventStreamDone()
+			}))
+		})
+
+		It("streams the events as protobuf frames by default", func() {
+			conn, _, err := dial("")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			event := models.NewDesiredLRPCreatedEvent(desiredLRP)
+			desiredHub.Emit(event)
+
+			messageType, data, err := conn.ReadMessage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messageType).To(Equal(websocket.BinaryMessage))
+
+			id, streamed, err := events.DecodeWebSocketFrame(messageType, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).NotTo(BeZero())
+			Expect(streamed).To(Equal(event))
+		})
+
+		It("streams the events as JSON frames when asked to", func() {
+			conn, _, err := dial("format=json")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			event := models.NewDesiredLRPRemovedEvent(desiredLRP)
+			desiredHub.Emit(event)
+
+			messageType, data, err := conn.ReadMessage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messageType).To(Equal(websocket.TextMessage))
+
+			_, streamed, err := events.DecodeWebSocketFrame(messageType, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(streamed).To(Equal(event))
+		})
+
+		It("only streams the events matching the filter", func() {
+			conn, _, err := dial("process_guid=other-guid")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			desiredHub.Emit(models.NewDesiredLRPCreatedEvent(desiredLRP))
+			other := models.NewDesiredLRPCreatedEvent(model_helpers.NewValidDesiredLRP("other-guid"))
+			desiredHub.Emit(other)
+
+			_, streamed, err := readWebSocketEvent(conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(streamed).To(Equal(other))
+		})
+
+		It("resumes after the last_event_id", func() {
+			conn, _, err := dial("")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			desiredHub.Emit(models.NewDesiredLRPCreatedEvent(desiredLRP))
+			id, _, err := readWebSocketEvent(conn)
+			Expect(err).NotTo(HaveOccurred())
+
+			removed := models.NewDesiredLRPRemovedEvent(desiredLRP)
+			desiredHub.Emit(removed)
+
+			resumed, _, err := dial("last_event_id=" + strconv.FormatUint(id, 10))
+			Expect(err).NotTo(HaveOccurred())
+			defer resumed.Close()
+
+			_, streamed, err := readWebSocketEvent(resumed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(streamed).To(Equal(removed))
+		})
+
+		It("refuses the handshake with 410 Gone when the events are no longer buffered", func() {
+			_, response, err := dial("last_event_id=1")
+			Expect(err).To(Equal(websocket.ErrBadHandshake))
+			Expect(response.StatusCode).To(Equal(http.StatusGone))
+		})
+
+		It("refuses the handshake with 403 Forbidden from another origin", func() {
+			header := http.Header{"Origin": []string{"http://example.com"}}
+			_, response, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+			Expect(err).To(Equal(websocket.ErrBadHandshake))
+			Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+		})
+
+		It("accepts the handshake from its own origin", func() {
+			header := http.Header{"Origin": []string{server.URL}}
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+			Expect(err).NotTo(HaveOccurred())
+			conn.Close()
+		})
+
+		It("refuses the handshake with 400 Bad Request for an unknown format", func() {
+			_, response, err := dial("format=xml")
+			Expect(err).To(Equal(websocket.ErrBadHandshake))
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		Context("when the heartbeat interval elapses", func() {
+			BeforeEach(func() {
+				heartbeatInterval = 10 * time.Millisecond
+			})
+
+			It("pings the client", func() {
+				conn, _, err := dial("")
+				Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+
+				pinged := make(chan struct{}, 10)
+				conn.SetPingHandler(func(string) error {
+					pinged <- struct{}{}
+					return nil
+				})
+				go conn.ReadMessage()
+
+				Eventually(pinged).Should(Receive())
+			})
+		})
+
+		Context("when heartbeats are disabled", func() {
+			It("does not ping the client", func() {
+				conn, _, err := dial("")
+				Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+
+				pinged := make(chan struct{}, 10)
+				conn.SetPingHandler(func(string) error {
+					pinged <- struct{}{}
+					return nil
+				})
+				go conn.ReadMessage()
+
+				Consistently(pinged, 50*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+
+		Context("when the hub is closed", func() {
+			It("closes the connection with going away", func() {
+				conn, _, err := dial("")
+				Expect(err).NotTo(HaveOccurred())
+				defer conn.Close()
+
+				desiredHub.Close()
+
+				_, _, err = conn.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.CloseGoingAway)).To(BeTrue())
+				Eventually(eventStreamDone).Should(BeClosed())
+			})
+		})
+
+		Context("when the client goes away", func() {
+			It("stops streaming", func() {
+				conn, _, err := dial("")
+				Expect(err).NotTo(HaveOccurred())
+				conn.Close()
+
+				Eventually(eventStreamDone).Should(BeClosed())
+			})
+		})
+	})
+
+	Describe("SubscribeToActualLRPEventsOverWebSocket", func() {
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handler.SubscribeToActualLRPEventsOverWebSocket(w, r)
+				closeEventStreamDone()
+			}))
+		})
+
+		It("streams actual lrp events", func() {
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			actualLRPGroup := models.NewRunningActualLRPGroup(model_helpers.NewValidActualLRP("guid", 0))
+			event := models.NewActualLRPCreatedEvent(actualLRPGroup)
+			actualHub.Emit(event)
+
+			_, streamed, err := readWebSocketEvent(conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(streamed).To(Equal(event))
 		})
 	})
 })
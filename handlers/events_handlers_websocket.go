@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/gorilla/websocket"
+	"github.com/pivotal-golang/lager"
+)
+
+const webSocketWriteTimeout = 10 * time.Second
+
+// upgrader only accepts WebSocket requests from the BBS's own origin, or with
+// no Origin header as sent by clients other than browsers. A browser would
+// otherwise let any page it visits open an event stream with the client
+// certificate it holds for the BBS.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+func (h *EventHandler) SubscribeToDesiredLRPEventsOverWebSocket(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("subscribe-desired-websocket")
+	subscribeToHubOverWebSocket(logger, h.desiredHub, h.heartbeatInterval, w, req)
+}
+
+func (h *EventHandler) SubscribeToActualLRPEventsOverWebSocket(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("subscribe-actual-websocket")
+	subscribeToHubOverWebSocket(logger, h.actualHub, h.heartbeatInterval, w, req)
+}
+
+// subscribeToHubOverWebSocket streams the events of the hub like
+// subscribeToHub, as one WebSocket message per event in the format given in
+// the query. Since browsers cannot set headers on WebSocket requests, the
+// stream is resumed with the last_event_id query parameter. The client is
+// pinged every heartbeat interval, and disconnected if it has not answered
+// within two; zero disables pings.
+func subscribeToHubOverWebSocket(logger lager.Logger, hub events.Hub, pingInterval time.Duration, w http.ResponseWriter, req *http.Request) {
+	format := events.WebSocketFormat(req.URL.Query().Get(events.WebSocketFormatParam))
+	if format == "" {
+		format = events.WebSocketProtobufFormat
+	}
+	if !format.Valid() {
+		logger.Error("invalid-websocket-format", nil, lager.Data{"format": format})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	filter, ok := filterFromRequest(logger, w, req)
+	if !ok {
+		return
+	}
+
+	source, ok := subscribeFromRequest(logger, hub, filter, w, req)
+	if !ok {
+		return
+	}
+	defer source.Close()
+
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		logger.Error("failed-to-upgrade-to-websocket", err)
+		return
+	}
+	defer conn.Close()
+
+	eventChan := make(chan sequencedEvent)
+	errorChan := make(chan error)
+	closeChan := make(chan struct{})
+	defer close(closeChan)
+
+	go streamSequencedSource(eventChan, errorChan, closeChan, source)
+
+	// The client sends nothing but control messages; reading is what processes
+	// its pongs and notices when it goes away.
+	readDone := make(chan struct{})
+	if pingInterval > 0 {
+		conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * pingInterval))
+		})
+	}
+	go func() {
+		defer close(readDone)
+		for {
+			_, _, err := conn.NextReader()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var pings <-chan time.Time
+	if pingInterval > 0 {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		pings = ticker.C
+	}
+
+	for {
+		select {
+		case next := <-eventChan:
+			messageType, data, err := events.EncodeWebSocketFrame(format, next.id, next.event)
+			if err != nil {
+				logger.Error("failed-to-marshal-event", err)
+				return
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+			err = conn.WriteMessage(messageType, data)
+			if err != nil {
+				logger.Error("failed-to-write-event", err)
+				return
+			}
+
+		case <-pings:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteTimeout))
+			if err != nil {
+				logger.Error("failed-to-ping", err)
+				return
+			}
+
+		case err := <-errorChan:
+			logger.Error("failed-to-get-next-event", err)
+			closeMessage := websocket.FormatCloseMessage(websocket.CloseGoingAway, "event stream ended")
+			conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(webSocketWriteTimeout))
+			return
+
+		case <-readDone:
+			return
+		}
+	}
+}
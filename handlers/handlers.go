@@ -4,9 +4,12 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/cloudfoundry-incubator/auctioneer"
 	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/audit"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
 	"github.com/cloudfoundry-incubator/bbs/db"
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/handlers/middleware"
@@ -14,6 +17,7 @@ import (
 	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
 	"github.com/cloudfoundry-incubator/rep"
 	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/rata"
 )
@@ -23,14 +27,33 @@ func New(
 	updateWorkers int,
 	convergenceWorkersSize int,
 	db db.DB,
-	desiredHub, actualHub events.Hub,
+	desiredHub, actualHub, taskHub, cellHub events.Hub,
+	eventsFromOutbox bool,
+	eventStreamHeartbeatInterval time.Duration,
 	taskCompletionClient taskworkpool.TaskCompletionClient,
 	serviceClient bbs.ServiceClient,
 	auctioneerClient auctioneer.Client,
 	repClientFactory rep.ClientFactory,
+	authorizationPolicy *authorization.Policy,
+	requireCellIdentity bool,
+	auditor audit.Auditor,
+	auditDB db.AuditDB,
+	taskScheduleDB db.TaskScheduleDB,
+	taskArchiveDB db.TaskArchiveDB,
 	migrationsDone <-chan struct{},
+	clock clock.Clock,
 	exitChan chan struct{},
 ) http.Handler {
+	eventsHandler := NewEventHandler(logger, desiredHub, actualHub, taskHub, cellHub, eventStreamHeartbeatInterval)
+
+	// A database with an event outbox records the events for the changes the
+	// handlers make, so they must not emit them as well.
+	if eventsFromOutbox {
+		desiredHub = events.OutboxOnly(desiredHub)
+		actualHub = events.OutboxOnly(actualHub)
+		taskHub = events.OutboxOnly(taskHub)
+	}
+
 	retirer := NewActualLRPRetirer(db, actualHub, repClientFactory, serviceClient)
 	pingHandler := NewPingHandler(logger)
 	domainHandler := NewDomainHandler(logger, db, exitChan)
@@ -39,9 +62,11 @@ func New(
 	evacuationHandler := NewEvacuationHandler(logger, db, db, db, actualHub, auctioneerClient, exitChan)
 	desiredLRPHandler := NewDesiredLRPHandler(logger, updateWorkers, db, db, desiredHub, actualHub, auctioneerClient, repClientFactory, serviceClient, exitChan)
 	lrpConvergenceHandler := NewLRPConvergenceHandler(logger, db, actualHub, auctioneerClient, serviceClient, retirer, convergenceWorkersSize, exitChan)
-	taskHandler := NewTaskHandler(logger, db, taskCompletionClient, auctioneerClient, serviceClient, repClientFactory, exitChan)
-	eventsHandler := NewEventHandler(logger, desiredHub, actualHub)
+	taskHandler := NewTaskHandler(logger, updateWorkers, db, taskHub, taskCompletionClient, auctioneerClient, serviceClient, repClientFactory, clock, exitChan)
 	cellsHandler := NewCellHandler(logger, serviceClient, exitChan)
+	auditHandler := NewAuditHandler(logger, auditDB, exitChan)
+	taskScheduleHandler := NewTaskScheduleHandler(logger, taskScheduleDB, exitChan)
+	taskArchiveHandler := NewTaskArchiveHandler(logger, taskArchiveDB, exitChan)
 
 	emitter := middleware.NewLatencyEmitter(logger)
 
@@ -57,6 +82,7 @@ func New(
 		bbs.ActualLRPGroupsRoute:                     route(emitter.EmitLatency(actualLRPHandler.ActualLRPGroups)),
 		bbs.ActualLRPGroupsByProcessGuidRoute:        route(emitter.EmitLatency(actualLRPHandler.ActualLRPGroupsByProcessGuid)),
 		bbs.ActualLRPGroupByProcessGuidAndIndexRoute: route(emitter.EmitLatency(actualLRPHandler.ActualLRPGroupByProcessGuidAndIndex)),
+		bbs.ActualLRPsRoute:                          route(emitter.EmitLatency(actualLRPHandler.ActualLRPs)),
 
 		// Actual LRP Lifecycle
 		bbs.ClaimActualLRPRoute:  route(emitter.EmitLatency(actualLRPLifecycleHandler.ClaimActualLRP)),
@@ -95,11 +121,14 @@ func New(
 		bbs.TaskByGuidRoute:    route(emitter.EmitLatency(taskHandler.TaskByGuid)),
 		bbs.DesireTaskRoute:    route(emitter.EmitLatency(taskHandler.DesireTask)),
 		bbs.StartTaskRoute:     route(emitter.EmitLatency(taskHandler.StartTask)),
+		bbs.TaskHeartbeatRoute: route(emitter.EmitLatency(taskHandler.TaskHeartbeat)),
 		bbs.CancelTaskRoute:    route(emitter.EmitLatency(taskHandler.CancelTask)),
+		bbs.CancelTasksRoute:   route(emitter.EmitLatency(taskHandler.CancelTasks)),
 		bbs.FailTaskRoute:      route(emitter.EmitLatency(taskHandler.FailTask)),
 		bbs.CompleteTaskRoute:  route(emitter.EmitLatency(taskHandler.CompleteTask)),
 		bbs.ResolvingTaskRoute: route(emitter.EmitLatency(taskHandler.ResolvingTask)),
 		bbs.DeleteTaskRoute:    route(emitter.EmitLatency(taskHandler.DeleteTask)),
+		bbs.DeleteTasksRoute:   route(emitter.EmitLatency(taskHandler.DeleteTasks)),
 		bbs.ConvergeTasksRoute: route(emitter.EmitLatency(taskHandler.ConvergeTasks)),
 
 		bbs.TasksRoute_r1:      route(emitter.EmitLatency(taskHandler.Tasks_r1)),
@@ -108,13 +137,41 @@ func New(
 		bbs.TaskByGuidRoute_r0: route(emitter.EmitLatency(taskHandler.TaskByGuid_r0)),
 		bbs.DesireTaskRoute_r0: route(emitter.EmitLatency(taskHandler.DesireTask_r0)),
 
+		// Task Schedules
+		bbs.TaskSchedulesRoute:      route(emitter.EmitLatency(taskScheduleHandler.TaskSchedules)),
+		bbs.TaskScheduleByGuidRoute: route(emitter.EmitLatency(taskScheduleHandler.TaskScheduleByGuid)),
+		bbs.DesireTaskScheduleRoute: route(emitter.EmitLatency(taskScheduleHandler.DesireTaskSchedule)),
+		bbs.UpdateTaskScheduleRoute: route(emitter.EmitLatency(taskScheduleHandler.UpdateTaskSchedule)),
+		bbs.RemoveTaskScheduleRoute: route(emitter.EmitLatency(taskScheduleHandler.RemoveTaskSchedule)),
+
+		// Task Archive
+		bbs.ArchivedTasksRoute: route(emitter.EmitLatency(taskArchiveHandler.ArchivedTasks)),
+
 		// Events
 		bbs.EventStreamRoute_r0:        route(eventsHandler.Subscribe_r0),
 		bbs.DesiredLRPEventStreamRoute: route(eventsHandler.SubscribeToDesiredLRPEvents),
 		bbs.ActualLRPEventStreamRoute:  route(eventsHandler.SubscribeToActualLRPEvents),
+		bbs.TaskEventStreamRoute:       route(eventsHandler.SubscribeToTaskEvents),
+		bbs.CellEventStreamRoute:       route(eventsHandler.SubscribeToCellEvents),
+
+		bbs.LRPInstanceEventStreamRoute: route(eventsHandler.SubscribeToLRPInstanceEvents),
+
+		bbs.DesiredLRPEventWebSocketRoute: route(eventsHandler.SubscribeToDesiredLRPEventsOverWebSocket),
+		bbs.ActualLRPEventWebSocketRoute:  route(eventsHandler.SubscribeToActualLRPEventsOverWebSocket),
 
 		// Cells
 		bbs.CellsRoute: route(emitter.EmitLatency(cellsHandler.Cells)),
+
+		// Audit
+		bbs.AuditRecordsRoute: route(emitter.EmitLatency(auditHandler.AuditRecords)),
+	}
+
+	for name, h := range actions {
+		h = AuditWrap(logger, auditor, db, taskScheduleDB, name, h)
+		if requireCellIdentity {
+			h = CellIdentityWrap(logger, name, h)
+		}
+		actions[name] = middleware.AuthorizationWrap(logger, authorizationPolicy, name, bbs.RouteRoles[name], h)
 	}
 
 	handler, err := rata.NewRouter(bbs.Routes, actions)
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"crypto/x509"
 	"net/http"
 	"time"
 
+	"github.com/cloudfoundry-incubator/bbs/authorization"
 	"github.com/cloudfoundry-incubator/runtime-schema/metric"
 	"github.com/pivotal-golang/lager"
 )
@@ -11,6 +13,8 @@ import (
 const (
 	requestLatency = metric.Duration("RequestLatency")
 	requestCount   = metric.Counter("RequestCount")
+
+	unauthorizedRequestCount = metric.Counter("UnauthorizedRequestCount")
 )
 
 func LogWrap(logger lager.Logger, handler http.Handler) http.HandlerFunc {
@@ -53,3 +57,39 @@ func RequestCountWrap(handler http.Handler) http.HandlerFunc {
 		handler.ServeHTTP(w, r)
 	}
 }
+
+// AuthorizationWrap rejects requests whose client certificate is not granted
+// one of the allowed roles by the policy. A nil policy permits every request.
+func AuthorizationWrap(logger lager.Logger, policy *authorization.Policy, route string, allowed []authorization.Role, handler http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if policy == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		var cert *x509.Certificate
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			cert = r.TLS.PeerCertificates[0]
+		}
+
+		roles := policy.RolesFor(cert)
+		if !authorization.Permitted(roles, allowed) {
+			data := lager.Data{"route": route, "roles": roles}
+			if cert != nil {
+				data["subject"] = cert.Subject.CommonName
+				data["organizational_units"] = cert.Subject.OrganizationalUnit
+			}
+			logger.Info("unauthorized-request", data)
+
+			err := unauthorizedRequestCount.Increment()
+			if err != nil {
+				logger.Error("failed-to-send-unauthorized-request-count-metric", err)
+			}
+
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}
+}
@@ -1,13 +1,20 @@
 package middleware_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net/http"
+	"net/http/httptest"
 	"time"
 
+	"github.com/cloudfoundry-incubator/bbs/authorization"
 	"github.com/cloudfoundry-incubator/bbs/handlers/middleware"
 	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
 	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -60,4 +67,102 @@ var _ = Describe("Middleware", func() {
 			Expect(sender.GetCounter("RequestCount")).To(Equal(uint64(3)))
 		})
 	})
+
+	Describe("AuthorizationWrap", func() {
+		var (
+			sender  *fake.FakeMetricSender
+			logger  *lagertest.TestLogger
+			policy  *authorization.Policy
+			called  bool
+			handler http.HandlerFunc
+			request *http.Request
+		)
+
+		BeforeEach(func() {
+			sender = fake.NewFakeMetricSender()
+			dropsonde_metrics.Initialize(sender, nil)
+			logger = lagertest.NewTestLogger("test")
+
+			policy = &authorization.Policy{Rules: []authorization.Rule{
+				{Role: authorization.CellRole, OrganizationalUnits: []string{"cell"}},
+				{Role: authorization.ReadOnlyRole, CommonNames: []string{"monitor"}},
+			}}
+			called = false
+
+			var err error
+			request, err = http.NewRequest("POST", "/v1/actual_lrps/claim", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			inner := func(w http.ResponseWriter, r *http.Request) { called = true }
+			handler = middleware.AuthorizationWrap(logger, policy, "ClaimActualLRP", []authorization.Role{authorization.CellRole}, http.HandlerFunc(inner))
+		})
+
+		withCert := func(cn string, ous ...string) {
+			request.TLS = &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{
+					{Subject: pkix.Name{CommonName: cn, OrganizationalUnit: ous}},
+				},
+			}
+		}
+
+		Context("when the client has an allowed role", func() {
+			BeforeEach(func() {
+				withCert("cell-z1-0", "cell")
+			})
+
+			It("calls the handler", func() {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+
+				Expect(called).To(BeTrue())
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+				Expect(sender.GetCounter("UnauthorizedRequestCount")).To(BeZero())
+			})
+		})
+
+		Context("when the client has no allowed role", func() {
+			BeforeEach(func() {
+				withCert("monitor")
+			})
+
+			It("rejects the request", func() {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+
+				Expect(called).To(BeFalse())
+				Expect(recorder.Code).To(Equal(http.StatusForbidden))
+			})
+
+			It("logs and counts the rejection", func() {
+				handler.ServeHTTP(httptest.NewRecorder(), request)
+
+				Expect(logger).To(gbytes.Say("unauthorized-request"))
+				Expect(logger).To(gbytes.Say(`"route":"ClaimActualLRP"`))
+				Expect(sender.GetCounter("UnauthorizedRequestCount")).To(Equal(uint64(1)))
+			})
+		})
+
+		Context("when the client presents no certificate", func() {
+			It("rejects the request", func() {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, request)
+
+				Expect(called).To(BeFalse())
+				Expect(recorder.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when there is no policy", func() {
+			BeforeEach(func() {
+				policy = nil
+			})
+
+			It("calls the handler", func() {
+				handler.ServeHTTP(httptest.NewRecorder(), request)
+				Expect(called).To(BeTrue())
+			})
+		})
+	})
 })
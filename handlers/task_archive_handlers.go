@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type TaskArchiveHandler struct {
+	db       db.TaskArchiveDB
+	exitChan chan<- struct{}
+	logger   lager.Logger
+}
+
+// NewTaskArchiveHandler returns a handler for querying archived tasks. The db
+// is nil when completed tasks are not archived.
+func NewTaskArchiveHandler(logger lager.Logger, db db.TaskArchiveDB, exitChan chan<- struct{}) *TaskArchiveHandler {
+	return &TaskArchiveHandler{
+		db:       db,
+		exitChan: exitChan,
+		logger:   logger.Session("task-archive-handler"),
+	}
+}
+
+func (h *TaskArchiveHandler) ArchivedTasks(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("archived-tasks")
+
+	request := &models.ArchivedTasksRequest{}
+	response := &models.ArchivedTasksResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		if h.db == nil {
+			err = models.NewError(models.Error_InvalidRequest, "completed tasks are not archived")
+		} else {
+			response.Tasks, err = h.db.ArchivedTasks(logger, request.Filter())
+		}
+	}
+
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Task Archive Handlers", func() {
+	var (
+		logger           *lagertest.TestLogger
+		fakeArchiveDB    *dbfakes.FakeTaskArchiveDB
+		responseRecorder *httptest.ResponseRecorder
+		handler          *handlers.TaskArchiveHandler
+		exitCh           chan struct{}
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeArchiveDB = new(dbfakes.FakeTaskArchiveDB)
+		responseRecorder = httptest.NewRecorder()
+		exitCh = make(chan struct{}, 1)
+		handler = handlers.NewTaskArchiveHandler(logger, fakeArchiveDB, exitCh)
+	})
+
+	Describe("ArchivedTasks", func() {
+		var (
+			requestBody interface{}
+			task        *models.Task
+		)
+
+		BeforeEach(func() {
+			requestBody = &models.ArchivedTasksRequest{
+				Domain:         "some-domain",
+				CompletedSince: 10,
+				CompletedUntil: 20,
+				Limit:          5,
+			}
+			task = model_helpers.NewValidTask("some-task")
+			fakeArchiveDB.ArchivedTasksReturns([]*models.Task{task}, nil)
+		})
+
+		JustBeforeEach(func() {
+			handler.ArchivedTasks(responseRecorder, newTestRequest(requestBody))
+		})
+
+		It("lists the archived tasks matching the filter", func() {
+			Expect(fakeArchiveDB.ArchivedTasksCallCount()).To(Equal(1))
+			_, filter := fakeArchiveDB.ArchivedTasksArgsForCall(0)
+			Expect(filter).To(Equal(models.ArchivedTaskFilter{
+				Domain:         "some-domain",
+				CompletedSince: 10,
+				CompletedUntil: 20,
+				Limit:          5,
+			}))
+
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+			response := models.ArchivedTasksResponse{}
+			Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+			Expect(response.Error).To(BeNil())
+			Expect(response.Tasks).To(Equal([]*models.Task{task}))
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.ArchivedTasksRequest{CompletedSince: 20, CompletedUntil: 10}
+			})
+
+			It("returns an error without querying the archive", func() {
+				response := models.ArchivedTasksResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeArchiveDB.ArchivedTasksCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the db fails", func() {
+			BeforeEach(func() {
+				fakeArchiveDB.ArchivedTasksReturns(nil, models.ErrUnknownError)
+			})
+
+			It("returns the error", func() {
+				response := models.ArchivedTasksResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+			})
+		})
+
+		Context("when completed tasks are not archived", func() {
+			BeforeEach(func() {
+				handler = handlers.NewTaskArchiveHandler(logger, nil, exitCh)
+			})
+
+			It("returns an error", func() {
+				response := models.ArchivedTasksResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+			})
+		})
+	})
+})
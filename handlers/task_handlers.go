@@ -7,38 +7,50 @@ import (
 	"github.com/cloudfoundry-incubator/auctioneer"
 	"github.com/cloudfoundry-incubator/bbs"
 	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
 	"github.com/cloudfoundry-incubator/rep"
+	"github.com/cloudfoundry/gunk/workpool"
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 )
 
 type TaskHandler struct {
+	updateWorkersCount   int
 	db                   db.TaskDB
+	taskHub              events.Hub
 	logger               lager.Logger
 	taskCompletionClient taskworkpool.TaskCompletionClient
 	auctioneerClient     auctioneer.Client
 	serviceClient        bbs.ServiceClient
 	repClientFactory     rep.ClientFactory
+	clock                clock.Clock
 	exitChan             chan<- struct{}
 }
 
 func NewTaskHandler(
 	logger lager.Logger,
+	updateWorkersCount int,
 	db db.TaskDB,
+	taskHub events.Hub,
 	taskCompletionClient taskworkpool.TaskCompletionClient,
 	auctioneerClient auctioneer.Client,
 	serviceClient bbs.ServiceClient,
 	repClientFactory rep.ClientFactory,
+	clock clock.Clock,
 	exitChan chan<- struct{},
 ) *TaskHandler {
 	return &TaskHandler{
+		updateWorkersCount:   updateWorkersCount,
 		db:                   db,
+		taskHub:              taskHub,
 		logger:               logger.Session("task-handler"),
 		taskCompletionClient: taskCompletionClient,
 		auctioneerClient:     auctioneerClient,
 		serviceClient:        serviceClient,
 		repClientFactory:     repClientFactory,
+		clock:                clock,
 		exitChan:             exitChan,
 	}
 }
@@ -52,7 +64,7 @@ func (h *TaskHandler) Tasks(w http.ResponseWriter, req *http.Request) {
 
 	err = parseRequest(logger, req, request)
 	if err == nil {
-		filter := models.TaskFilter{Domain: request.Domain, CellID: request.CellId}
+		filter := request.Filter()
 		response.Tasks, err = h.db.Tasks(logger, filter)
 	}
 
@@ -95,12 +107,20 @@ func (h *TaskHandler) DesireTask(w http.ResponseWriter, req *http.Request) {
 	}
 
 	logger = logger.WithData(lager.Data{"task_guid": request.TaskGuid})
-	err = h.db.DesireTask(logger, request.TaskDefinition, request.TaskGuid, request.Domain)
+	task, err := h.db.DesireTask(logger, request.TaskDefinition, request.TaskGuid, request.Domain)
 	if err != nil {
 		response.Error = models.ConvertError(err)
 		return
 	}
 
+	h.taskHub.Emit(models.NewTaskCreatedEvent(task))
+
+	if dependsOn := request.TaskDefinition.DependsOn; len(dependsOn) > 0 {
+		// dependencies that have already completed release the task right away
+		h.releaseDependentTasks(logger, dependsOn...)
+		return
+	}
+
 	logger.Debug("start-task-auction-request")
 	taskStartRequest := auctioneer.NewTaskStartRequestFromModel(request.TaskGuid, request.Domain, request.TaskDefinition)
 	err = h.auctioneerClient.RequestTaskAuctions([]*auctioneer.TaskStartRequest{&taskStartRequest})
@@ -122,7 +142,11 @@ func (h *TaskHandler) StartTask(w http.ResponseWriter, req *http.Request) {
 	err = parseRequest(logger, req, request)
 	if err == nil {
 		logger = logger.WithData(lager.Data{"task_guid": request.TaskGuid, "cell_id": request.CellId})
-		response.ShouldStart, err = h.db.StartTask(logger, request.TaskGuid, request.CellId)
+		var change *models.TaskChange
+		change, response.ShouldStart, err = h.db.StartTask(logger, request.TaskGuid, request.CellId)
+		if err == nil && response.ShouldStart {
+			h.emitTaskChanged(change)
+		}
 	}
 
 	response.Error = models.ConvertError(err)
@@ -130,10 +154,36 @@ func (h *TaskHandler) StartTask(w http.ResponseWriter, req *http.Request) {
 	exitIfUnrecoverable(logger, h.exitChan, response.Error)
 }
 
+func (h *TaskHandler) TaskHeartbeat(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("task-heartbeat")
+
+	request := &models.TaskHeartbeatRequest{}
+	response := &models.TaskLifecycleResponse{}
+
+	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
+	defer writeResponse(w, response)
+
+	err = parseRequest(logger, req, request)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	change, err := h.db.TaskHeartbeat(logger, request.TaskGuid, request.CellId, request.ProgressMessage, request.ProgressPercent)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+	if !change.HeartbeatOnly() {
+		h.emitTaskChanged(change)
+	}
+}
+
 func (h *TaskHandler) CancelTask(w http.ResponseWriter, req *http.Request) {
 	logger := h.logger.Session("cancel-task")
 
-	request := &models.TaskGuidRequest{}
+	request := &models.CancelTaskRequest{}
 	response := &models.TaskLifecycleResponse{}
 	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
 	defer writeResponse(w, response)
@@ -145,37 +195,84 @@ func (h *TaskHandler) CancelTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	task, cellID, err := h.db.CancelTask(logger, request.TaskGuid)
+	err = h.CancelTaskWithReason(logger, request.TaskGuid, request.FailureReason())
+	response.Error = models.ConvertError(err)
+}
+
+// CancelTaskWithReason cancels the task, recording the reason as its failure
+// reason. It releases the tasks that depend on it, submits its completion
+// callback and stops it on its cell, like any other cancelled task.
+func (h *TaskHandler) CancelTaskWithReason(logger lager.Logger, taskGuid, reason string) error {
+	change, cellID, err := h.db.CancelTask(logger, taskGuid, reason)
 	if err != nil {
-		response.Error = models.ConvertError(err)
-		return
+		return err
 	}
+	h.emitTaskChanged(change)
 
-	if task.CompletionCallbackUrl != "" {
-		logger.Info("task-client-completing-task")
-		go h.taskCompletionClient.Submit(h.db, task)
+	task := change.After
+	h.releaseDependentTasks(logger, task.TaskGuid)
+	h.submitCancelledTask(logger, task)
+
+	if cellID != "" {
+		h.cancelTaskOnCell(logger, taskGuid, cellID)
 	}
+	return nil
+}
 
-	if cellID == "" {
+// CancelTasks cancels every task matching the selector, reporting the outcome
+// for each of them. Tasks running on cells are then cancelled on their cells
+// concurrently.
+func (h *TaskHandler) CancelTasks(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("cancel-tasks")
+
+	request := &models.CancelTasksRequest{}
+	response := &models.CancelTasksResponse{}
+	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
+	defer writeResponse(w, response)
+
+	err := parseRequest(logger, req, request)
+	if err != nil {
+		logger.Error("failed-parsing-request", err)
+		response.Error = models.ConvertError(err)
 		return
 	}
 
-	logger.Info("start-check-cell-presence", lager.Data{"cell_id": cellID})
-	cellPresence, err := h.serviceClient.CellById(logger, cellID)
+	filter := request.Selector.Filter(h.clock.Now().UnixNano())
+	changes, results, err := h.db.CancelTasks(logger, filter, request.FailureReason())
 	if err != nil {
-		logger.Error("failed-fetching-cell-presence", err)
+		logger.Error("failed-cancelling-tasks", err)
+		response.Error = models.ConvertError(err)
 		return
 	}
-	logger.Info("finished-check-cell-presence", lager.Data{"cell_id": cellID})
+	response.Results = results
+
+	cancelledGuids := make([]string, 0, len(changes))
+	works := []func(){}
+	for _, change := range changes {
+		h.emitTaskChanged(change)
+		task := change.After
+		h.submitCancelledTask(logger, task)
+		cancelledGuids = append(cancelledGuids, task.TaskGuid)
+
+		if cellID := change.Before.CellId; cellID != "" {
+			taskGuid := task.TaskGuid
+			works = append(works, func() { h.cancelTaskOnCell(logger, taskGuid, cellID) })
+		}
+	}
 
-	repClient := h.repClientFactory.CreateClient(cellPresence.RepAddress)
-	logger.Info("start-rep-cancel-task", lager.Data{"task_guid": request.TaskGuid})
-	repClient.CancelTask(request.TaskGuid)
+	logger.Info("cancelled-tasks", lager.Data{"selected": len(results), "cancelled": len(cancelledGuids)})
+	h.releaseDependentTasks(logger, cancelledGuids...)
+
+	if len(works) == 0 {
+		return
+	}
+
+	throttler, err := workpool.NewThrottler(h.updateWorkersCount, works)
 	if err != nil {
-		logger.Error("failed-rep-cancel-task", err)
+		logger.Error("failed-constructing-throttler", err, lager.Data{"max_workers": h.updateWorkersCount, "num_works": len(works)})
 		return
 	}
-	logger.Info("finished-rep-cancel-task", lager.Data{"task_guid": request.TaskGuid})
+	throttler.Work()
 }
 
 func (h *TaskHandler) FailTask(w http.ResponseWriter, req *http.Request) {
@@ -194,15 +291,25 @@ func (h *TaskHandler) FailTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	task, err := h.db.FailTask(logger, request.TaskGuid, request.FailureReason)
+	change, err := h.db.FailTask(logger, request.TaskGuid, request.FailureReason)
 	if err != nil {
 		response.Error = models.ConvertError(err)
 		return
 	}
+	h.emitTaskChanged(change)
+
+	task := change.After
+
+	if task.State == models.Task_Pending && task.Attempts > 0 {
+		h.auctionRetriedTask(logger, task)
+		return
+	}
+
+	h.releaseDependentTasks(logger, task.TaskGuid)
 
 	if task.CompletionCallbackUrl != "" {
 		logger.Info("task-client-completing-task")
-		go h.taskCompletionClient.Submit(h.db, task)
+		go h.taskCompletionClient.Submit(h.db, h.taskHub, task)
 	}
 }
 
@@ -222,15 +329,25 @@ func (h *TaskHandler) CompleteTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	task, err := h.db.CompleteTask(logger, request.TaskGuid, request.CellId, request.Failed, request.FailureReason, request.Result)
+	change, err := h.db.CompleteTask(logger, request.TaskGuid, request.CellId, request.Failed, request.FailureReason, request.Result)
 	if err != nil {
 		response.Error = models.ConvertError(err)
 		return
 	}
+	h.emitTaskChanged(change)
+
+	task := change.After
+
+	if task.State == models.Task_Pending && task.Attempts > 0 {
+		h.auctionRetriedTask(logger, task)
+		return
+	}
+
+	h.releaseDependentTasks(logger, task.TaskGuid)
 
 	if task.CompletionCallbackUrl != "" {
 		logger.Info("task-client-completing-task")
-		go h.taskCompletionClient.Submit(h.db, task)
+		go h.taskCompletionClient.Submit(h.db, h.taskHub, task)
 	}
 }
 
@@ -243,7 +360,11 @@ func (h *TaskHandler) ResolvingTask(w http.ResponseWriter, req *http.Request) {
 
 	err = parseRequest(logger, req, request)
 	if err == nil {
-		err = h.db.ResolvingTask(logger, request.TaskGuid)
+		var change *models.TaskChange
+		change, err = h.db.ResolvingTask(logger, request.TaskGuid)
+		if err == nil {
+			h.emitTaskChanged(change)
+		}
 	}
 
 	response.Error = models.ConvertError(err)
@@ -260,7 +381,11 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, req *http.Request) {
 
 	err = parseRequest(logger, req, request)
 	if err == nil {
-		err = h.db.DeleteTask(logger, request.TaskGuid)
+		var task *models.Task
+		task, err = h.db.DeleteTask(logger, request.TaskGuid)
+		if err == nil {
+			h.taskHub.Emit(models.NewTaskRemovedEvent(task))
+		}
 	}
 
 	response.Error = models.ConvertError(err)
@@ -268,6 +393,67 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, req *http.Request) {
 	exitIfUnrecoverable(logger, h.exitChan, response.Error)
 }
 
+// DeleteTasks deletes every completed or resolving task matching the
+// selector, reporting the outcome for each of them. Completed tasks are
+// resolved first, so their completion callbacks are never made.
+func (h *TaskHandler) DeleteTasks(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("delete-tasks")
+
+	request := &models.DeleteTasksRequest{}
+	response := &models.DeleteTasksResponse{}
+	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
+	defer writeResponse(w, response)
+
+	err := parseRequest(logger, req, request)
+	if err != nil {
+		logger.Error("failed-parsing-request", err)
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	tasks, err := h.selectTasks(logger, request.Selector)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	deleted := 0
+	response.Results = make([]*models.TaskResult, 0, len(tasks))
+	for _, selected := range tasks {
+		task, err := h.deleteTask(logger, selected)
+		response.Results = append(response.Results, &models.TaskResult{TaskGuid: selected.TaskGuid, Error: models.ConvertError(err)})
+		if err != nil {
+			logger.Error("failed-deleting-task", err, lager.Data{"task_guid": selected.TaskGuid})
+			continue
+		}
+
+		deleted++
+		h.taskHub.Emit(models.NewTaskRemovedEvent(task))
+	}
+
+	logger.Info("deleted-tasks", lager.Data{"selected": len(tasks), "deleted": deleted})
+}
+
+func (h *TaskHandler) deleteTask(logger lager.Logger, task *models.Task) (*models.Task, error) {
+	if task.State == models.Task_Completed {
+		_, err := h.db.ResolvingTask(logger, task.TaskGuid)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return h.db.DeleteTask(logger, task.TaskGuid)
+}
+
+// selectTasks lists the tasks matching the selector.
+func (h *TaskHandler) selectTasks(logger lager.Logger, selector *models.TaskSelector) ([]*models.Task, error) {
+	tasks, err := h.db.Tasks(logger, selector.Filter(h.clock.Now().UnixNano()))
+	if err != nil {
+		logger.Error("failed-fetching-tasks", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
 func (h *TaskHandler) ConvergeTasks(w http.ResponseWriter, req *http.Request) {
 	var err error
 	logger := h.logger.Session("converge-tasks")
@@ -296,7 +482,7 @@ func (h *TaskHandler) ConvergeTasks(w http.ResponseWriter, req *http.Request) {
 	}
 	logger.Debug("succeeded-listing-cells")
 
-	tasksToAuction, tasksToComplete := h.db.ConvergeTasks(
+	result := h.db.ConvergeTasks(
 		logger,
 		cellSet,
 		time.Duration(request.KickTaskDuration),
@@ -304,6 +490,13 @@ func (h *TaskHandler) ConvergeTasks(w http.ResponseWriter, req *http.Request) {
 		time.Duration(request.ExpireCompletedTaskDuration),
 	)
 
+	h.emitConvergedTasks(result.Changes)
+
+	for _, task := range result.TasksToCancel {
+		h.cancelTaskOnCell(logger.WithData(lager.Data{"task_guid": task.TaskGuid}), task.TaskGuid, task.CellId)
+	}
+
+	tasksToAuction := result.TasksToAuction
 	if len(tasksToAuction) > 0 {
 		logger.Debug("requesting-task-auctions", lager.Data{"num_tasks_to_auction": len(tasksToAuction)})
 		if err := h.auctioneerClient.RequestTaskAuctions(tasksToAuction); err != nil {
@@ -317,9 +510,130 @@ func (h *TaskHandler) ConvergeTasks(w http.ResponseWriter, req *http.Request) {
 		logger.Debug("done-requesting-task-auctions", lager.Data{"num_tasks_to_auction": len(tasksToAuction)})
 	}
 
+	tasksToComplete := result.TasksToComplete
 	logger.Debug("submitting-tasks-to-be-completed", lager.Data{"num_tasks_to_complete": len(tasksToComplete)})
 	for _, task := range tasksToComplete {
-		h.taskCompletionClient.Submit(h.db, task)
+		h.taskCompletionClient.Submit(h.db, h.taskHub, task)
 	}
 	logger.Debug("done-submitting-tasks-to-be-completed", lager.Data{"num_tasks_to_complete": len(tasksToComplete)})
 }
+
+// submitCancelledTask makes the completion callback of a cancelled task.
+func (h *TaskHandler) submitCancelledTask(logger lager.Logger, task *models.Task) {
+	if task.CompletionCallbackUrl != "" {
+		logger.Info("task-client-completing-task", lager.Data{"task_guid": task.TaskGuid})
+		go h.taskCompletionClient.Submit(h.db, h.taskHub, task)
+	}
+}
+
+func (h *TaskHandler) cancelTaskOnCell(logger lager.Logger, taskGuid, cellID string) {
+	logger.Info("start-check-cell-presence", lager.Data{"cell_id": cellID})
+	cellPresence, err := h.serviceClient.CellById(logger, cellID)
+	if err != nil {
+		logger.Error("failed-fetching-cell-presence", err)
+		return
+	}
+	logger.Info("finished-check-cell-presence", lager.Data{"cell_id": cellID})
+
+	repClient := h.repClientFactory.CreateClient(cellPresence.RepAddress)
+	logger.Info("start-rep-cancel-task", lager.Data{"task_guid": taskGuid})
+	err = repClient.CancelTask(taskGuid)
+	if err != nil {
+		logger.Error("failed-rep-cancel-task", err)
+		return
+	}
+	logger.Info("finished-rep-cancel-task", lager.Data{"task_guid": taskGuid})
+}
+
+// auctionRetriedTask auctions a task its retry policy moved back to pending.
+// Tasks with a retry backoff are left for convergence to kick once it has
+// elapsed.
+func (h *TaskHandler) auctionRetriedTask(logger lager.Logger, task *models.Task) {
+	logger = logger.WithData(lager.Data{"task_guid": task.TaskGuid, "attempts": task.Attempts})
+	if !task.RetryBackoffElapsed(h.clock.Now().UnixNano()) {
+		logger.Info("deferring-retried-task-auction")
+		return
+	}
+
+	logger.Debug("start-task-auction-request")
+	taskStartRequest := auctioneer.NewTaskStartRequestFromModel(task.TaskGuid, task.Domain, task.TaskDefinition)
+	err := h.auctioneerClient.RequestTaskAuctions([]*auctioneer.TaskStartRequest{&taskStartRequest})
+	if err != nil {
+		logger.Error("failed-requesting-task-auction", err)
+		// The retry succeeded, the auction request error can be dropped
+	} else {
+		logger.Debug("succeeded-requesting-task-auction")
+	}
+}
+
+// releaseDependentTasks releases the tasks blocked on the given tasks. Tasks
+// it unblocks are auctioned together, highest priority first, and tasks it
+// fails release their own dependents in turn.
+func (h *TaskHandler) releaseDependentTasks(logger lager.Logger, taskGuids ...string) {
+	queue := append([]string{}, taskGuids...)
+	releasedTasks := []*models.Task{}
+
+	for len(queue) > 0 {
+		taskGuid := queue[0]
+		queue = queue[1:]
+
+		changes, err := h.db.ReleaseDependentTasks(logger, taskGuid)
+		if err != nil {
+			logger.Error("failed-releasing-dependent-tasks", err, lager.Data{"task_guid": taskGuid})
+			continue
+		}
+
+		for _, change := range changes {
+			h.emitTaskChanged(change)
+
+			task := change.After
+
+			switch task.State {
+			case models.Task_Pending:
+				releasedTasks = append(releasedTasks, task)
+			case models.Task_Completed:
+				queue = append(queue, task.TaskGuid)
+				if task.CompletionCallbackUrl != "" {
+					logger.Info("task-client-completing-task", lager.Data{"task_guid": task.TaskGuid})
+					go h.taskCompletionClient.Submit(h.db, h.taskHub, task)
+				}
+			}
+		}
+	}
+
+	if len(releasedTasks) == 0 {
+		return
+	}
+
+	models.SortTasksByPriority(releasedTasks)
+	tasksToAuction := make([]*auctioneer.TaskStartRequest, 0, len(releasedTasks))
+	for _, task := range releasedTasks {
+		taskStartRequest := auctioneer.NewTaskStartRequestFromModel(task.TaskGuid, task.Domain, task.TaskDefinition)
+		tasksToAuction = append(tasksToAuction, &taskStartRequest)
+	}
+
+	logger.Debug("start-task-auction-request", lager.Data{"num_tasks_to_auction": len(tasksToAuction)})
+	err := h.auctioneerClient.RequestTaskAuctions(tasksToAuction)
+	if err != nil {
+		logger.Error("failed-requesting-task-auction", err)
+		// The release succeeded, the auction request error can be dropped
+	} else {
+		logger.Debug("succeeded-requesting-task-auction")
+	}
+}
+
+func (h *TaskHandler) emitTaskChanged(change *models.TaskChange) {
+	h.taskHub.Emit(models.NewTaskChangedEvent(change.Before, change.After))
+}
+
+// emitConvergedTasks emits an event for each task convergence changed or
+// removed.
+func (h *TaskHandler) emitConvergedTasks(changes []*models.TaskChange) {
+	for _, change := range changes {
+		if change.After == nil {
+			h.taskHub.Emit(models.NewTaskRemovedEvent(change.Before))
+		} else {
+			h.emitTaskChanged(change)
+		}
+	}
+}
@@ -19,7 +19,7 @@ func (h *TaskHandler) Tasks_r0(w http.ResponseWriter, req *http.Request) {
 
 	err = parseRequest(logger, req, request)
 	if err == nil {
-		filter := models.TaskFilter{Domain: request.Domain, CellID: request.CellId}
+		filter := request.Filter()
 		response.Tasks, err = h.db.Tasks(logger, filter)
 		if err == nil {
 			for i := range response.Tasks {
@@ -46,7 +46,7 @@ func (h *TaskHandler) Tasks_r1(w http.ResponseWriter, req *http.Request) {
 
 	err = parseRequest(logger, req, request)
 	if err == nil {
-		filter := models.TaskFilter{Domain: request.Domain, CellID: request.CellId}
+		filter := request.Filter()
 		response.Tasks, err = h.db.Tasks(logger, filter)
 		if err == nil {
 			for i := range response.Tasks {
@@ -120,12 +120,20 @@ func (h *TaskHandler) DesireTask_r0(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	err = h.db.DesireTask(logger, request.TaskDefinition, request.TaskGuid, request.Domain)
+	task, err := h.db.DesireTask(logger, request.TaskDefinition, request.TaskGuid, request.Domain)
 	if err != nil {
 		response.Error = models.ConvertError(err)
 		return
 	}
 
+	h.taskHub.Emit(models.NewTaskCreatedEvent(task))
+
+	if dependsOn := request.TaskDefinition.DependsOn; len(dependsOn) > 0 {
+		// dependencies that have already completed release the task right away
+		h.releaseDependentTasks(logger, dependsOn...)
+		return
+	}
+
 	taskStartRequest := auctioneer.NewTaskStartRequestFromModel(request.TaskGuid, request.Domain, request.TaskDefinition)
 	err = h.auctioneerClient.RequestTaskAuctions([]*auctioneer.TaskStartRequest{&taskStartRequest})
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/cloudfoundry-incubator/auctioneer"
 	"github.com/cloudfoundry-incubator/auctioneer/auctioneerfakes"
 	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
 	"github.com/cloudfoundry-incubator/bbs/format"
 	"github.com/cloudfoundry-incubator/bbs/handlers"
 	"github.com/cloudfoundry-incubator/bbs/models"
@@ -17,6 +18,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/pivotal-golang/lager/lagertest"
 )
 
@@ -42,7 +44,7 @@ var _ = Describe("Task Handlers", func() {
 		logger = lagertest.NewTestLogger("test")
 		responseRecorder = httptest.NewRecorder()
 		exitCh = make(chan struct{}, 1)
-		handler = handlers.NewTaskHandler(logger, fakeTaskDB, nil, fakeAuctioneerClient, fakeServiceClient, fakeRepClientFactory, exitCh)
+		handler = handlers.NewTaskHandler(logger, 2, fakeTaskDB, new(eventfakes.FakeHub), nil, fakeAuctioneerClient, fakeServiceClient, fakeRepClientFactory, fakeclock.NewFakeClock(time.Now()), exitCh)
 	})
 
 	Describe("Tasks_r0", func() {
@@ -664,7 +666,7 @@ var _ = Describe("Task Handlers", func() {
 
 		Context("when the DB returns an unrecoverable error", func() {
 			BeforeEach(func() {
-				fakeTaskDB.DesireTaskReturns(models.NewUnrecoverableError(nil))
+				fakeTaskDB.DesireTaskReturns(nil, models.NewUnrecoverableError(nil))
 			})
 
 			It("logs and writes to the exit channel", func() {
@@ -675,7 +677,7 @@ var _ = Describe("Task Handlers", func() {
 
 		Context("when desiring the task fails", func() {
 			BeforeEach(func() {
-				fakeTaskDB.DesireTaskReturns(models.ErrUnknownError)
+				fakeTaskDB.DesireTaskReturns(nil, models.ErrUnknownError)
 			})
 
 			It("responds with an error", func() {
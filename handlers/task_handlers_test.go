@@ -8,7 +8,9 @@ import (
 
 	"github.com/cloudfoundry-incubator/auctioneer"
 	"github.com/cloudfoundry-incubator/auctioneer/auctioneerfakes"
+	"github.com/cloudfoundry-incubator/bbs/db"
 	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
 	"github.com/cloudfoundry-incubator/bbs/handlers"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
@@ -17,6 +19,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
 	"github.com/pivotal-golang/lager/lagertest"
 )
 
@@ -26,6 +30,8 @@ var _ = Describe("Task Handlers", func() {
 		fakeTaskDB               *dbfakes.FakeTaskDB
 		fakeAuctioneerClient     *auctioneerfakes.FakeClient
 		fakeTaskCompletionClient *taskworkpoolfakes.FakeTaskCompletionClient
+		taskHub                  *eventfakes.FakeHub
+		fakeClock                *fakeclock.FakeClock
 
 		responseRecorder *httptest.ResponseRecorder
 
@@ -41,11 +47,13 @@ var _ = Describe("Task Handlers", func() {
 		fakeTaskDB = new(dbfakes.FakeTaskDB)
 		fakeAuctioneerClient = new(auctioneerfakes.FakeClient)
 		fakeTaskCompletionClient = new(taskworkpoolfakes.FakeTaskCompletionClient)
+		taskHub = new(eventfakes.FakeHub)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1466000000, 0))
 
 		logger = lagertest.NewTestLogger("test")
 		responseRecorder = httptest.NewRecorder()
 		exitCh = make(chan struct{}, 1)
-		handler = handlers.NewTaskHandler(logger, fakeTaskDB, fakeTaskCompletionClient, fakeAuctioneerClient, fakeServiceClient, fakeRepClientFactory, exitCh)
+		handler = handlers.NewTaskHandler(logger, 2, fakeTaskDB, taskHub, fakeTaskCompletionClient, fakeAuctioneerClient, fakeServiceClient, fakeRepClientFactory, fakeClock, exitCh)
 	})
 
 	Describe("Tasks", func() {
@@ -116,6 +124,20 @@ var _ = Describe("Task Handlers", func() {
 					Expect(filter.CellID).To(Equal("cell-id"))
 				})
 			})
+
+			Context("and filtering by minimum priority", func() {
+				BeforeEach(func() {
+					requestBody = &models.TasksRequest{
+						MinPriority: 50,
+					}
+				})
+
+				It("calls the DB with a priority filter", func() {
+					Expect(fakeTaskDB.TasksCallCount()).To(Equal(1))
+					_, filter := fakeTaskDB.TasksArgsForCall(0)
+					Expect(filter.MinPriority).To(BeEquivalentTo(50))
+				})
+			})
 		})
 
 		Context("when the DB returns an unrecoverable error", func() {
@@ -252,6 +274,20 @@ var _ = Describe("Task Handlers", func() {
 				Expect(response.Error).To(BeNil())
 			})
 
+			Context("when the database returns the created task", func() {
+				var task *models.Task
+
+				BeforeEach(func() {
+					task = model_helpers.NewValidTask(taskGuid)
+					fakeTaskDB.DesireTaskReturns(task, nil)
+				})
+
+				It("emits a task created event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(1))
+					Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskCreatedEvent(task)))
+				})
+			})
+
 			It("requests an auction", func() {
 				Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
 
@@ -313,9 +349,51 @@ var _ = Describe("Task Handlers", func() {
 			})
 		})
 
+		Context("when the task depends on other tasks", func() {
+			BeforeEach(func() {
+				taskDef.DependsOn = []string{"first-guid", "second-guid"}
+			})
+
+			It("releases the task against its dependencies instead of auctioning it", func() {
+				Expect(fakeTaskDB.ReleaseDependentTasksCallCount()).To(Equal(2))
+				_, firstGuid := fakeTaskDB.ReleaseDependentTasksArgsForCall(0)
+				_, secondGuid := fakeTaskDB.ReleaseDependentTasksArgsForCall(1)
+				Expect([]string{firstGuid, secondGuid}).To(Equal([]string{"first-guid", "second-guid"}))
+
+				Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(0))
+			})
+
+			Context("when its dependencies have already completed", func() {
+				var blocked, unblocked *models.Task
+
+				BeforeEach(func() {
+					blocked = model_helpers.NewValidTask(taskGuid)
+					blocked.Domain = domain
+					blocked.State = models.Task_Blocked
+					unblocked = model_helpers.NewValidTask(taskGuid)
+					unblocked.Domain = domain
+					unblocked.State = models.Task_Pending
+
+					fakeTaskDB.ReleaseDependentTasksStub = func(_ lager.Logger, guid string) ([]*models.TaskChange, error) {
+						if guid == "second-guid" {
+							return []*models.TaskChange{{Before: blocked, After: unblocked}}, nil
+						}
+						return []*models.TaskChange{}, nil
+					}
+				})
+
+				It("auctions the released task", func() {
+					Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+					requestedTasks := fakeAuctioneerClient.RequestTaskAuctionsArgsForCall(0)
+					Expect(requestedTasks).To(HaveLen(1))
+					Expect(requestedTasks[0].TaskGuid).To(Equal(taskGuid))
+				})
+			})
+		})
+
 		Context("when the DB returns an unrecoverable error", func() {
 			BeforeEach(func() {
-				fakeTaskDB.DesireTaskReturns(models.NewUnrecoverableError(nil))
+				fakeTaskDB.DesireTaskReturns(nil, models.NewUnrecoverableError(nil))
 			})
 
 			It("logs and writes to the exit channel", func() {
@@ -326,7 +404,11 @@ var _ = Describe("Task Handlers", func() {
 
 		Context("when desiring the task fails", func() {
 			BeforeEach(func() {
-				fakeTaskDB.DesireTaskReturns(models.ErrUnknownError)
+				fakeTaskDB.DesireTaskReturns(nil, models.ErrUnknownError)
+			})
+
+			It("does not emit an event", func() {
+				Expect(taskHub.EmitCallCount()).To(Equal(0))
 			})
 
 			It("responds with an error", func() {
@@ -363,8 +445,20 @@ var _ = Describe("Task Handlers", func() {
 			})
 
 			Context("when the task should start", func() {
+				var before, after *models.Task
+
 				BeforeEach(func() {
-					fakeTaskDB.StartTaskReturns(true, nil)
+					before = model_helpers.NewValidTask("task-guid")
+					after = model_helpers.NewValidTask("task-guid")
+					after.State = models.Task_Running
+					after.CellId = "cell-id"
+
+					fakeTaskDB.StartTaskReturns(&models.TaskChange{Before: before, After: after}, true, nil)
+				})
+
+				It("emits a task changed event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(1))
+					Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskChangedEvent(before, after)))
 				})
 
 				It("responds with true", func() {
@@ -380,7 +474,11 @@ var _ = Describe("Task Handlers", func() {
 
 			Context("when the task should not start", func() {
 				BeforeEach(func() {
-					fakeTaskDB.StartTaskReturns(false, nil)
+					fakeTaskDB.StartTaskReturns(nil, false, nil)
+				})
+
+				It("does not emit an event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(0))
 				})
 
 				It("responds with false", func() {
@@ -396,7 +494,7 @@ var _ = Describe("Task Handlers", func() {
 
 			Context("when the DB returns an unrecoverable error", func() {
 				BeforeEach(func() {
-					fakeTaskDB.StartTaskReturns(false, models.NewUnrecoverableError(nil))
+					fakeTaskDB.StartTaskReturns(nil, false, models.NewUnrecoverableError(nil))
 				})
 
 				It("logs and writes to the exit channel", func() {
@@ -407,7 +505,7 @@ var _ = Describe("Task Handlers", func() {
 
 			Context("when the DB fails", func() {
 				BeforeEach(func() {
-					fakeTaskDB.StartTaskReturns(false, models.ErrResourceExists)
+					fakeTaskDB.StartTaskReturns(nil, false, models.ErrResourceExists)
 				})
 
 				It("bubbles up the underlying model error", func() {
@@ -422,6 +520,109 @@ var _ = Describe("Task Handlers", func() {
 		})
 	})
 
+	Describe("TaskHeartbeat", func() {
+		BeforeEach(func() {
+			requestBody = &models.TaskHeartbeatRequest{
+				TaskGuid:        "task-guid",
+				CellId:          "cell-id",
+				ProgressMessage: "downloading",
+				ProgressPercent: 42,
+			}
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.TaskHeartbeat(responseRecorder, request)
+		})
+
+		Context("when the heartbeat is recorded", func() {
+			var before, after *models.Task
+
+			BeforeEach(func() {
+				before = model_helpers.NewValidTask("task-guid")
+				before.State = models.Task_Running
+				before.CellId = "cell-id"
+
+				after = model_helpers.NewValidTask("task-guid")
+				after.State = models.Task_Running
+				after.CellId = "cell-id"
+				after.ProgressMessage = "downloading"
+				after.ProgressPercent = 42
+
+				fakeTaskDB.TaskHeartbeatReturns(&models.TaskChange{Before: before, After: after}, nil)
+			})
+
+			It("records the heartbeat and progress", func() {
+				Expect(fakeTaskDB.TaskHeartbeatCallCount()).To(Equal(1))
+				_, taskGuid, cellID, progressMessage, progressPercent := fakeTaskDB.TaskHeartbeatArgsForCall(0)
+				Expect(taskGuid).To(Equal("task-guid"))
+				Expect(cellID).To(Equal("cell-id"))
+				Expect(progressMessage).To(Equal("downloading"))
+				Expect(progressPercent).To(BeEquivalentTo(42))
+			})
+
+			It("emits a task changed event", func() {
+				Expect(taskHub.EmitCallCount()).To(Equal(1))
+				Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskChangedEvent(before, after)))
+			})
+
+			Context("when the heartbeat reports no new progress", func() {
+				BeforeEach(func() {
+					before.ProgressMessage = "downloading"
+					before.ProgressPercent = 42
+					after.LastHeartbeatAt = 1138
+				})
+
+				It("does not emit an event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(0))
+				})
+			})
+
+			It("responds without an error", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := &models.TaskLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(BeNil())
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.TaskHeartbeatRequest{
+					TaskGuid:        "task-guid",
+					CellId:          "cell-id",
+					ProgressPercent: 101,
+				}
+			})
+
+			It("does not record the heartbeat", func() {
+				Expect(fakeTaskDB.TaskHeartbeatCallCount()).To(Equal(0))
+
+				response := &models.TaskLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+			})
+		})
+
+		Context("when the task is running on another cell", func() {
+			BeforeEach(func() {
+				fakeTaskDB.TaskHeartbeatReturns(nil, models.NewRunningOnDifferentCellError("cell-id", "other-cell-id"))
+			})
+
+			It("bubbles up the underlying model error", func() {
+				response := &models.TaskLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error.Type).To(Equal(models.Error_RunningOnDifferentCell))
+				Expect(taskHub.EmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("CancelTask", func() {
 		var (
 			request *http.Request
@@ -429,18 +630,19 @@ var _ = Describe("Task Handlers", func() {
 		)
 
 		BeforeEach(func() {
-			requestBody = &models.TaskGuidRequest{
+			requestBody = &models.CancelTaskRequest{
 				TaskGuid: "task-guid",
 			}
 
 			task := model_helpers.NewValidTask("hi-bob")
 			cellID = "the-cell"
-			fakeTaskDB.CancelTaskReturns(task, cellID, nil)
+			fakeTaskDB.CancelTaskReturns(&models.TaskChange{After: task}, cellID, nil)
 
 			request = newTestRequest(requestBody)
 		})
 
 		JustBeforeEach(func() {
+			request = newTestRequest(requestBody)
 			handler.CancelTask(responseRecorder, request)
 			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
 		})
@@ -452,11 +654,29 @@ var _ = Describe("Task Handlers", func() {
 					fakeServiceClient.CellByIdReturns(&cellPresence, nil)
 				})
 
+				Context("when the database returns the change", func() {
+					var before, after *models.Task
+
+					BeforeEach(func() {
+						before = model_helpers.NewValidTask("hi-bob")
+						after = model_helpers.NewValidTask("hi-bob")
+						after.State = models.Task_Completed
+						after.Failed = true
+						fakeTaskDB.CancelTaskReturns(&models.TaskChange{Before: before, After: after}, cellID, nil)
+					})
+
+					It("emits a task changed event", func() {
+						Expect(taskHub.EmitCallCount()).To(Equal(1))
+						Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskChangedEvent(before, after)))
+					})
+				})
+
 				It("returns no error", func() {
 					Expect(fakeTaskDB.CancelTaskCallCount()).To(Equal(1))
-					taskLogger, taskGuid := fakeTaskDB.CancelTaskArgsForCall(0)
+					taskLogger, taskGuid, reason := fakeTaskDB.CancelTaskArgsForCall(0)
 					Expect(taskLogger.SessionName()).To(ContainSubstring("cancel-task"))
 					Expect(taskGuid).To(Equal("task-guid"))
+					Expect(reason).To(Equal(models.TaskCancelledReason))
 
 					response := &models.TaskLifecycleResponse{}
 					err := response.Unmarshal(responseRecorder.Body.Bytes())
@@ -465,11 +685,26 @@ var _ = Describe("Task Handlers", func() {
 					Expect(response.Error).To(BeNil())
 				})
 
+				Context("when a reason is given", func() {
+					BeforeEach(func() {
+						requestBody = &models.CancelTaskRequest{
+							TaskGuid: "task-guid",
+							Reason:   "the build was superseded",
+						}
+					})
+
+					It("cancels the task with the reason", func() {
+						Expect(fakeTaskDB.CancelTaskCallCount()).To(Equal(1))
+						_, _, reason := fakeTaskDB.CancelTaskArgsForCall(0)
+						Expect(reason).To(Equal("the build was superseded"))
+					})
+				})
+
 				Context("and the task has a complete URL", func() {
 					BeforeEach(func() {
 						task := model_helpers.NewValidTask("hi-bob")
 						task.CompletionCallbackUrl = "bogus"
-						fakeTaskDB.CancelTaskReturns(task, cellID, nil)
+						fakeTaskDB.CancelTaskReturns(&models.TaskChange{After: task}, cellID, nil)
 					})
 
 					It("causes the workpool to complete its callback work", func() {
@@ -480,7 +715,7 @@ var _ = Describe("Task Handlers", func() {
 				Context("but the task has no complete URL", func() {
 					BeforeEach(func() {
 						task := model_helpers.NewValidTask("hi-bob")
-						fakeTaskDB.CancelTaskReturns(task, cellID, nil)
+						fakeTaskDB.CancelTaskReturns(&models.TaskChange{After: task}, cellID, nil)
 					})
 
 					It("does not complete the task callback", func() {
@@ -501,7 +736,7 @@ var _ = Describe("Task Handlers", func() {
 				Context("when the task has no cell id", func() {
 					BeforeEach(func() {
 						task := model_helpers.NewValidTask("hi-bob")
-						fakeTaskDB.CancelTaskReturns(task, "", nil)
+						fakeTaskDB.CancelTaskReturns(&models.TaskChange{After: task}, "", nil)
 					})
 
 					It("does not return an error", func() {
@@ -549,6 +784,10 @@ var _ = Describe("Task Handlers", func() {
 					fakeTaskDB.CancelTaskReturns(nil, "", models.ErrUnknownError)
 				})
 
+				It("does not emit an event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(0))
+				})
+
 				It("responds with an error", func() {
 					response := &models.TaskLifecycleResponse{}
 					err := response.Unmarshal(responseRecorder.Body.Bytes())
@@ -561,7 +800,7 @@ var _ = Describe("Task Handlers", func() {
 
 		Context("when the cancel task request is not valid", func() {
 			BeforeEach(func() {
-				request = newTestRequest("{{")
+				requestBody = "{{"
 			})
 
 			It("returns an BadRequest error", func() {
@@ -574,6 +813,222 @@ var _ = Describe("Task Handlers", func() {
 		})
 	})
 
+	Describe("CancelTasks", func() {
+		var runningTask, pendingTask *models.Task
+
+		BeforeEach(func() {
+			runningTask = model_helpers.NewValidTask("running-task")
+			runningTask.Domain = "the-domain"
+			runningTask.State = models.Task_Running
+			runningTask.CellId = "the-cell"
+
+			pendingTask = model_helpers.NewValidTask("pending-task")
+			pendingTask.Domain = "the-domain"
+			pendingTask.State = models.Task_Pending
+			pendingTask.CellId = ""
+
+			fakeTaskDB.CancelTasksStub = func(_ lager.Logger, _ models.TaskFilter, reason string) ([]*models.TaskChange, []*models.TaskResult, error) {
+				changes := []*models.TaskChange{}
+				results := []*models.TaskResult{}
+				for _, before := range []*models.Task{runningTask, pendingTask} {
+					task := before.Copy()
+					task.State = models.Task_Completed
+					task.Failed = true
+					task.FailureReason = reason
+					task.CellId = ""
+					changes = append(changes, &models.TaskChange{Before: before, After: task})
+					results = append(results, &models.TaskResult{TaskGuid: task.TaskGuid})
+				}
+				return changes, results, nil
+			}
+
+			cellPresence := models.NewCellPresence("the-cell", "1.1.1.1", "z1", models.CellCapacity{}, nil, nil)
+			fakeServiceClient.CellByIdReturns(&cellPresence, nil)
+
+			requestBody = &models.CancelTasksRequest{
+				Selector: &models.TaskSelector{Domain: "the-domain"},
+				Reason:   "clearing stale tasks",
+			}
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.CancelTasks(responseRecorder, request)
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+		})
+
+		It("cancels the tasks matching the selector in one call, with the reason", func() {
+			Expect(fakeTaskDB.CancelTasksCallCount()).To(Equal(1))
+			_, filter, reason := fakeTaskDB.CancelTasksArgsForCall(0)
+			Expect(filter).To(Equal(models.TaskFilter{Domain: "the-domain"}))
+			Expect(reason).To(Equal("clearing stale tasks"))
+
+			Expect(fakeTaskDB.CancelTaskCallCount()).To(Equal(0))
+			Expect(fakeTaskDB.TasksCallCount()).To(Equal(0))
+		})
+
+		It("returns the outcome for each task", func() {
+			response := &models.CancelTasksResponse{}
+			err := response.Unmarshal(responseRecorder.Body.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(response.Error).To(BeNil())
+			Expect(response.Results).To(Equal([]*models.TaskResult{
+				{TaskGuid: "running-task"},
+				{TaskGuid: "pending-task"},
+			}))
+		})
+
+		It("emits a task changed event for each cancelled task", func() {
+			Expect(taskHub.EmitCallCount()).To(Equal(2))
+			event := taskHub.EmitArgsForCall(0).(*models.TaskChangedEvent)
+			Expect(event.Before).To(Equal(runningTask))
+			Expect(event.After.State).To(Equal(models.Task_Completed))
+		})
+
+		It("releases the tasks depending on the cancelled tasks", func() {
+			Expect(fakeTaskDB.ReleaseDependentTasksCallCount()).To(Equal(2))
+		})
+
+		It("stops the running tasks on their cells", func() {
+			Expect(fakeServiceClient.CellByIdCallCount()).To(Equal(1))
+			_, cellID := fakeServiceClient.CellByIdArgsForCall(0)
+			Expect(cellID).To(Equal("the-cell"))
+
+			Expect(fakeRepClient.CancelTaskCallCount()).To(Equal(1))
+			Expect(fakeRepClient.CancelTaskArgsForCall(0)).To(Equal("running-task"))
+		})
+
+		Context("when several tasks are running on cells", func() {
+			var concurrent chan bool
+
+			BeforeEach(func() {
+				pendingTask.State = models.Task_Running
+				pendingTask.CellId = "the-cell"
+
+				started := make(chan struct{}, 2)
+				release := make(chan struct{})
+				concurrent = make(chan bool, 1)
+				fakeRepClient.CancelTaskStub = func(string) error {
+					started <- struct{}{}
+					<-release
+					return nil
+				}
+
+				go func() {
+					<-started
+					select {
+					case <-started:
+						concurrent <- true
+					case <-time.After(time.Second):
+						concurrent <- false
+					}
+					close(release)
+				}()
+			})
+
+			It("stops them on their cells concurrently", func() {
+				Expect(fakeRepClient.CancelTaskCallCount()).To(Equal(2))
+				Expect(<-concurrent).To(BeTrue())
+			})
+		})
+
+		Context("when no reason is given", func() {
+			BeforeEach(func() {
+				requestBody = &models.CancelTasksRequest{
+					Selector: &models.TaskSelector{Domain: "the-domain"},
+				}
+			})
+
+			It("cancels the tasks with the default reason", func() {
+				Expect(fakeTaskDB.CancelTasksCallCount()).To(Equal(1))
+				_, _, reason := fakeTaskDB.CancelTasksArgsForCall(0)
+				Expect(reason).To(Equal(models.TaskCancelledReason))
+			})
+		})
+
+		Context("when the selector matches on state and age", func() {
+			BeforeEach(func() {
+				requestBody = &models.CancelTasksRequest{
+					Selector: &models.TaskSelector{
+						Domain: "the-domain",
+						State:  models.Task_Pending,
+						MinAge: int64(time.Minute),
+					},
+				}
+			})
+
+			It("filters on them in the database", func() {
+				_, filter, _ := fakeTaskDB.CancelTasksArgsForCall(0)
+				Expect(filter).To(Equal(models.TaskFilter{
+					Domain:        "the-domain",
+					State:         models.Task_Pending,
+					UpdatedBefore: fakeClock.Now().Add(-time.Minute).UnixNano(),
+				}))
+			})
+		})
+
+		Context("when some tasks cannot be cancelled", func() {
+			BeforeEach(func() {
+				fakeTaskDB.CancelTasksReturns(
+					[]*models.TaskChange{{Before: pendingTask, After: model_helpers.NewValidTask("pending-task")}},
+					[]*models.TaskResult{
+						{TaskGuid: "running-task", Error: models.ErrResourceConflict},
+						{TaskGuid: "pending-task"},
+					},
+					nil,
+				)
+			})
+
+			It("reports the error for those tasks and handles the rest", func() {
+				response := &models.CancelTasksResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(BeNil())
+				Expect(response.Results).To(Equal([]*models.TaskResult{
+					{TaskGuid: "running-task", Error: models.ErrResourceConflict},
+					{TaskGuid: "pending-task"},
+				}))
+
+				Expect(taskHub.EmitCallCount()).To(Equal(1))
+				Expect(fakeRepClient.CancelTaskCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the selector is empty", func() {
+			BeforeEach(func() {
+				requestBody = &models.CancelTasksRequest{Selector: &models.TaskSelector{}}
+			})
+
+			It("responds with an error and cancels nothing", func() {
+				response := &models.CancelTasksResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).NotTo(BeNil())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeTaskDB.CancelTasksCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when cancelling the tasks fails", func() {
+			BeforeEach(func() {
+				fakeTaskDB.CancelTasksReturns(nil, nil, models.ErrUnknownError)
+			})
+
+			It("responds with an error", func() {
+				response := &models.CancelTasksResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+				Expect(response.Results).To(BeEmpty())
+				Expect(taskHub.EmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("FailTask", func() {
 		var (
 			taskGuid      string
@@ -585,7 +1040,7 @@ var _ = Describe("Task Handlers", func() {
 			failureReason = "just cuz ;)"
 
 			task := model_helpers.NewValidTask("hi-bob")
-			fakeTaskDB.FailTaskReturns(task, nil)
+			fakeTaskDB.FailTaskReturns(&models.TaskChange{After: task}, nil)
 
 			requestBody = &models.FailTaskRequest{
 				TaskGuid:      taskGuid,
@@ -612,11 +1067,29 @@ var _ = Describe("Task Handlers", func() {
 				Expect(response.Error).To(BeNil())
 			})
 
+			Context("when the database returns the change", func() {
+				var before, after *models.Task
+
+				BeforeEach(func() {
+					before = model_helpers.NewValidTask(taskGuid)
+					after = model_helpers.NewValidTask(taskGuid)
+					after.State = models.Task_Completed
+					after.Failed = true
+					after.FailureReason = failureReason
+					fakeTaskDB.FailTaskReturns(&models.TaskChange{Before: before, After: after}, nil)
+				})
+
+				It("emits a task changed event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(1))
+					Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskChangedEvent(before, after)))
+				})
+			})
+
 			Context("and the task has a complete URL", func() {
 				BeforeEach(func() {
 					task := model_helpers.NewValidTask("hi-bob")
 					task.CompletionCallbackUrl = "bogus"
-					fakeTaskDB.FailTaskReturns(task, nil)
+					fakeTaskDB.FailTaskReturns(&models.TaskChange{After: task}, nil)
 				})
 
 				It("causes the workpool to complete its callback work", func() {
@@ -627,7 +1100,7 @@ var _ = Describe("Task Handlers", func() {
 			Context("but the task has no complete URL", func() {
 				BeforeEach(func() {
 					task := model_helpers.NewValidTask("hi-bob")
-					fakeTaskDB.FailTaskReturns(task, nil)
+					fakeTaskDB.FailTaskReturns(&models.TaskChange{After: task}, nil)
 				})
 
 				It("does not complete the task callback", func() {
@@ -680,7 +1153,7 @@ var _ = Describe("Task Handlers", func() {
 			result = "yeah"
 
 			task := model_helpers.NewValidTask("hi-bob")
-			fakeTaskDB.CompleteTaskReturns(task, nil)
+			fakeTaskDB.CompleteTaskReturns(&models.TaskChange{After: task}, nil)
 
 			requestBody = &models.CompleteTaskRequest{
 				TaskGuid:      taskGuid,
@@ -714,12 +1187,31 @@ var _ = Describe("Task Handlers", func() {
 				Expect(response.Error).To(BeNil())
 			})
 
+			Context("when the database returns the change", func() {
+				var before, after *models.Task
+
+				BeforeEach(func() {
+					before = model_helpers.NewValidTask(taskGuid)
+					before.State = models.Task_Running
+					before.CellId = cellId
+					after = model_helpers.NewValidTask(taskGuid)
+					after.State = models.Task_Completed
+					after.Result = result
+					fakeTaskDB.CompleteTaskReturns(&models.TaskChange{Before: before, After: after}, nil)
+				})
+
+				It("emits a task changed event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(1))
+					Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskChangedEvent(before, after)))
+				})
+			})
+
 			Context("and completing succeeds", func() {
 				Context("and the task has a complete URL", func() {
 					BeforeEach(func() {
 						task := model_helpers.NewValidTask("hi-bob")
 						task.CompletionCallbackUrl = "bogus"
-						fakeTaskDB.CompleteTaskReturns(task, nil)
+						fakeTaskDB.CompleteTaskReturns(&models.TaskChange{After: task}, nil)
 					})
 
 					It("causes the workpool to complete its callback work", func() {
@@ -730,7 +1222,7 @@ var _ = Describe("Task Handlers", func() {
 				Context("but the task has no complete URL", func() {
 					BeforeEach(func() {
 						task := model_helpers.NewValidTask("hi-bob")
-						fakeTaskDB.CompleteTaskReturns(task, nil)
+						fakeTaskDB.CompleteTaskReturns(&models.TaskChange{After: task}, nil)
 					})
 
 					It("does not complete the task callback", func() {
@@ -740,6 +1232,143 @@ var _ = Describe("Task Handlers", func() {
 			})
 		})
 
+		Context("when the retry policy of the task retries it", func() {
+			var retriedTask *models.Task
+
+			BeforeEach(func() {
+				retriedTask = model_helpers.NewValidTask(taskGuid)
+				retriedTask.State = models.Task_Pending
+				retriedTask.Attempts = 1
+				retriedTask.CompletionCallbackUrl = "bogus"
+				retriedTask.RetryPolicy = &models.RetryPolicy{MaxAttempts: 3}
+				fakeTaskDB.CompleteTaskReturns(&models.TaskChange{After: retriedTask}, nil)
+			})
+
+			It("auctions the task again", func() {
+				Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+				requestedTasks := fakeAuctioneerClient.RequestTaskAuctionsArgsForCall(0)
+				Expect(requestedTasks).To(HaveLen(1))
+				Expect(requestedTasks[0].TaskGuid).To(Equal(taskGuid))
+			})
+
+			It("does not complete the task callback or release its dependents", func() {
+				Consistently(fakeTaskCompletionClient.SubmitCallCount).Should(Equal(0))
+				Expect(fakeTaskDB.ReleaseDependentTasksCallCount()).To(Equal(0))
+			})
+
+			Context("when the retry policy has a backoff", func() {
+				BeforeEach(func() {
+					retriedTask.UpdatedAt = fakeClock.Now().UnixNano()
+					retriedTask.RetryPolicy.BackoffMs = int64(time.Hour / time.Millisecond)
+				})
+
+				It("leaves the task for convergence to auction", func() {
+					Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(0))
+				})
+
+				Context("and the backoff has elapsed", func() {
+					BeforeEach(func() {
+						fakeClock.Increment(time.Hour)
+					})
+
+					It("auctions the task again", func() {
+						Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+					})
+				})
+			})
+		})
+
+		Context("when tasks depend on the completed task", func() {
+			var (
+				dependent, grandchild *models.Task
+				unblocked, failed     *models.Task
+			)
+
+			BeforeEach(func() {
+				task := model_helpers.NewValidTask(taskGuid)
+				task.State = models.Task_Completed
+				fakeTaskDB.CompleteTaskReturns(&models.TaskChange{After: task}, nil)
+
+				dependent = model_helpers.NewValidTask("dependent-guid")
+				dependent.State = models.Task_Blocked
+				unblocked = model_helpers.NewValidTask("dependent-guid")
+				unblocked.State = models.Task_Pending
+
+				grandchild = model_helpers.NewValidTask("grandchild-guid")
+				grandchild.State = models.Task_Blocked
+				failed = model_helpers.NewValidTask("grandchild-guid")
+				failed.State = models.Task_Completed
+				failed.Failed = true
+				failed.CompletionCallbackUrl = "bogus"
+
+				fakeTaskDB.ReleaseDependentTasksStub = func(_ lager.Logger, guid string) ([]*models.TaskChange, error) {
+					switch guid {
+					case taskGuid:
+						return []*models.TaskChange{
+							{Before: dependent, After: unblocked},
+							{Before: grandchild, After: failed},
+						}, nil
+					default:
+						return []*models.TaskChange{}, nil
+					}
+				}
+			})
+
+			It("releases the dependents of the task, and of the tasks it failed", func() {
+				Expect(fakeTaskDB.ReleaseDependentTasksCallCount()).To(Equal(2))
+				_, guid := fakeTaskDB.ReleaseDependentTasksArgsForCall(0)
+				Expect(guid).To(Equal(taskGuid))
+				_, guid = fakeTaskDB.ReleaseDependentTasksArgsForCall(1)
+				Expect(guid).To(Equal("grandchild-guid"))
+			})
+
+			It("auctions the unblocked tasks", func() {
+				Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+				requestedTasks := fakeAuctioneerClient.RequestTaskAuctionsArgsForCall(0)
+				Expect(requestedTasks).To(HaveLen(1))
+				Expect(requestedTasks[0].TaskGuid).To(Equal("dependent-guid"))
+			})
+
+			Context("when an unblocked task has a higher priority", func() {
+				BeforeEach(func() {
+					urgent := model_helpers.NewValidTask("urgent-dependent-guid")
+					urgent.State = models.Task_Pending
+					urgent.Priority = 90
+
+					fakeTaskDB.ReleaseDependentTasksStub = func(_ lager.Logger, guid string) ([]*models.TaskChange, error) {
+						switch guid {
+						case taskGuid:
+							return []*models.TaskChange{
+								{Before: dependent, After: unblocked},
+								{Before: dependent, After: urgent},
+							}, nil
+						default:
+							return []*models.TaskChange{}, nil
+						}
+					}
+				})
+
+				It("auctions it first", func() {
+					Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+					requestedTasks := fakeAuctioneerClient.RequestTaskAuctionsArgsForCall(0)
+					Expect(requestedTasks).To(HaveLen(2))
+					Expect(requestedTasks[0].TaskGuid).To(Equal("urgent-dependent-guid"))
+					Expect(requestedTasks[1].TaskGuid).To(Equal("dependent-guid"))
+				})
+			})
+
+			It("emits a task changed event for each released task", func() {
+				Expect(taskHub.EmitArgsForCall(taskHub.EmitCallCount() - 2)).To(Equal(models.NewTaskChangedEvent(dependent, unblocked)))
+				Expect(taskHub.EmitArgsForCall(taskHub.EmitCallCount() - 1)).To(Equal(models.NewTaskChangedEvent(grandchild, failed)))
+			})
+
+			It("completes the callbacks of the failed tasks", func() {
+				Eventually(fakeTaskCompletionClient.SubmitCallCount).Should(Equal(1))
+				_, _, task := fakeTaskCompletionClient.SubmitArgsForCall(0)
+				Expect(task).To(Equal(failed))
+			})
+		})
+
 		Context("when the DB returns an unrecoverable error", func() {
 			BeforeEach(func() {
 				fakeTaskDB.CompleteTaskReturns(nil, models.NewUnrecoverableError(nil))
@@ -780,6 +1409,17 @@ var _ = Describe("Task Handlers", func() {
 			})
 
 			Context("when resolvinging the task succeeds", func() {
+				var before, after *models.Task
+
+				BeforeEach(func() {
+					before = model_helpers.NewValidTask("task-guid")
+					before.State = models.Task_Completed
+					after = model_helpers.NewValidTask("task-guid")
+					after.State = models.Task_Resolving
+
+					fakeTaskDB.ResolvingTaskReturns(&models.TaskChange{Before: before, After: after}, nil)
+				})
+
 				It("returns no error", func() {
 					Expect(fakeTaskDB.ResolvingTaskCallCount()).To(Equal(1))
 					_, taskGuid := fakeTaskDB.ResolvingTaskArgsForCall(0)
@@ -792,11 +1432,16 @@ var _ = Describe("Task Handlers", func() {
 
 					Expect(response.Error).To(BeNil())
 				})
+
+				It("emits a task changed event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(1))
+					Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskChangedEvent(before, after)))
+				})
 			})
 
 			Context("when the DB returns an unrecoverable error", func() {
 				BeforeEach(func() {
-					fakeTaskDB.ResolvingTaskReturns(models.NewUnrecoverableError(nil))
+					fakeTaskDB.ResolvingTaskReturns(nil, models.NewUnrecoverableError(nil))
 				})
 
 				It("logs and writes to the exit channel", func() {
@@ -807,7 +1452,7 @@ var _ = Describe("Task Handlers", func() {
 
 			Context("when desiring the task fails", func() {
 				BeforeEach(func() {
-					fakeTaskDB.ResolvingTaskReturns(models.ErrUnknownError)
+					fakeTaskDB.ResolvingTaskReturns(nil, models.ErrUnknownError)
 				})
 
 				It("responds with an error", func() {
@@ -847,11 +1492,26 @@ var _ = Describe("Task Handlers", func() {
 
 					Expect(response.Error).To(BeNil())
 				})
+
+				Context("when the database returns the deleted task", func() {
+					var task *models.Task
+
+					BeforeEach(func() {
+						task = model_helpers.NewValidTask("task-guid")
+						task.State = models.Task_Resolving
+						fakeTaskDB.DeleteTaskReturns(task, nil)
+					})
+
+					It("emits a task removed event", func() {
+						Expect(taskHub.EmitCallCount()).To(Equal(1))
+						Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskRemovedEvent(task)))
+					})
+				})
 			})
 
 			Context("when the DB returns an unrecoverable error", func() {
 				BeforeEach(func() {
-					fakeTaskDB.DeleteTaskReturns(models.NewUnrecoverableError(nil))
+					fakeTaskDB.DeleteTaskReturns(nil, models.NewUnrecoverableError(nil))
 				})
 
 				It("logs and writes to the exit channel", func() {
@@ -862,7 +1522,11 @@ var _ = Describe("Task Handlers", func() {
 
 			Context("when desiring the task fails", func() {
 				BeforeEach(func() {
-					fakeTaskDB.DeleteTaskReturns(models.ErrUnknownError)
+					fakeTaskDB.DeleteTaskReturns(nil, models.ErrUnknownError)
+				})
+
+				It("does not emit an event", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(0))
 				})
 
 				It("responds with an error", func() {
@@ -877,6 +1541,116 @@ var _ = Describe("Task Handlers", func() {
 		})
 	})
 
+	Describe("DeleteTasks", func() {
+		var completedTask, resolvingTask, pendingTask *models.Task
+
+		BeforeEach(func() {
+			staleAt := fakeClock.Now().Add(-time.Hour).UnixNano()
+
+			completedTask = model_helpers.NewValidTask("completed-task")
+			completedTask.State = models.Task_Completed
+			completedTask.UpdatedAt = staleAt
+
+			resolvingTask = model_helpers.NewValidTask("resolving-task")
+			resolvingTask.State = models.Task_Resolving
+			resolvingTask.UpdatedAt = staleAt
+
+			pendingTask = model_helpers.NewValidTask("pending-task")
+			pendingTask.State = models.Task_Pending
+			pendingTask.UpdatedAt = staleAt
+
+			fakeTaskDB.TasksReturns([]*models.Task{completedTask, resolvingTask, pendingTask}, nil)
+			fakeTaskDB.DeleteTaskStub = func(_ lager.Logger, taskGuid string) (*models.Task, error) {
+				switch taskGuid {
+				case "completed-task":
+					return completedTask, nil
+				case "resolving-task":
+					return resolvingTask, nil
+				}
+				return nil, models.ErrResourceConflict
+			}
+
+			requestBody = &models.DeleteTasksRequest{
+				Selector: &models.TaskSelector{MinAge: int64(time.Minute)},
+			}
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.DeleteTasks(responseRecorder, request)
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+		})
+
+		It("fetches the tasks matching the selector", func() {
+			Expect(fakeTaskDB.TasksCallCount()).To(Equal(1))
+			_, filter := fakeTaskDB.TasksArgsForCall(0)
+			Expect(filter).To(Equal(models.TaskFilter{UpdatedBefore: fakeClock.Now().Add(-time.Minute).UnixNano()}))
+		})
+
+		It("resolves the completed tasks before deleting them", func() {
+			Expect(fakeTaskDB.ResolvingTaskCallCount()).To(Equal(1))
+			_, taskGuid := fakeTaskDB.ResolvingTaskArgsForCall(0)
+			Expect(taskGuid).To(Equal("completed-task"))
+		})
+
+		It("deletes the selected tasks", func() {
+			Expect(fakeTaskDB.DeleteTaskCallCount()).To(Equal(3))
+			_, taskGuid := fakeTaskDB.DeleteTaskArgsForCall(0)
+			Expect(taskGuid).To(Equal("completed-task"))
+			_, taskGuid = fakeTaskDB.DeleteTaskArgsForCall(1)
+			Expect(taskGuid).To(Equal("resolving-task"))
+			_, taskGuid = fakeTaskDB.DeleteTaskArgsForCall(2)
+			Expect(taskGuid).To(Equal("pending-task"))
+		})
+
+		It("returns the outcome for each task", func() {
+			response := &models.DeleteTasksResponse{}
+			err := response.Unmarshal(responseRecorder.Body.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(response.Error).To(BeNil())
+			Expect(response.Results).To(Equal([]*models.TaskResult{
+				{TaskGuid: "completed-task"},
+				{TaskGuid: "resolving-task"},
+				{TaskGuid: "pending-task", Error: models.ErrResourceConflict},
+			}))
+		})
+
+		It("emits a task removed event for each deleted task", func() {
+			Expect(taskHub.EmitCallCount()).To(Equal(2))
+			Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskRemovedEvent(completedTask)))
+			Expect(taskHub.EmitArgsForCall(1)).To(Equal(models.NewTaskRemovedEvent(resolvingTask)))
+		})
+
+		Context("when resolving a completed task fails", func() {
+			BeforeEach(func() {
+				fakeTaskDB.ResolvingTaskReturns(nil, models.ErrResourceConflict)
+			})
+
+			It("does not delete it", func() {
+				Expect(fakeTaskDB.DeleteTaskCallCount()).To(Equal(2))
+				_, taskGuid := fakeTaskDB.DeleteTaskArgsForCall(0)
+				Expect(taskGuid).To(Equal("resolving-task"))
+			})
+		})
+
+		Context("when the selector is missing", func() {
+			BeforeEach(func() {
+				requestBody = &models.DeleteTasksRequest{}
+			})
+
+			It("responds with an error and deletes nothing", func() {
+				response := &models.DeleteTasksResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).NotTo(BeNil())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeTaskDB.DeleteTaskCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("ConvergeTasks", func() {
 		Context("when the request is normal", func() {
 			var (
@@ -961,22 +1735,23 @@ var _ = Describe("Task Handlers", func() {
 				BeforeEach(func() {
 					task1 := model_helpers.NewValidTask(taskGuid1)
 					task2 := model_helpers.NewValidTask(taskGuid2)
-					fakeTaskDB.ConvergeTasksReturns(nil, []*models.Task{task1, task2})
+					fakeTaskDB.ConvergeTasksReturns(db.TaskConvergenceResult{TasksToComplete: []*models.Task{task1, task2}})
 				})
 
 				It("submits the tasks to the workpool", func() {
 					expectedCallCount := 2
 					Expect(fakeTaskCompletionClient.SubmitCallCount()).To(Equal(expectedCallCount))
 
-					_, submittedTask1 := fakeTaskCompletionClient.SubmitArgsForCall(0)
-					_, submittedTask2 := fakeTaskCompletionClient.SubmitArgsForCall(1)
+					_, _, submittedTask1 := fakeTaskCompletionClient.SubmitArgsForCall(0)
+					_, _, submittedTask2 := fakeTaskCompletionClient.SubmitArgsForCall(1)
 					Expect([]string{submittedTask1.TaskGuid, submittedTask2.TaskGuid}).To(ConsistOf(taskGuid1, taskGuid2))
 
 					task1Completions := 0
 					task2Completions := 0
 					for i := 0; i < expectedCallCount; i++ {
-						db, task := fakeTaskCompletionClient.SubmitArgsForCall(i)
+						db, hub, task := fakeTaskCompletionClient.SubmitArgsForCall(i)
 						Expect(db).To(Equal(fakeTaskDB))
+						Expect(hub).To(Equal(taskHub))
 						if task.TaskGuid == taskGuid1 {
 							task1Completions++
 						} else if task.TaskGuid == taskGuid2 {
@@ -989,6 +1764,62 @@ var _ = Describe("Task Handlers", func() {
 				})
 			})
 
+			Context("when convergence changes tasks", func() {
+				var running, runningFailed, expired *models.Task
+
+				BeforeEach(func() {
+					running = model_helpers.NewValidTask("running")
+					running.State = models.Task_Running
+					runningFailed = model_helpers.NewValidTask("running")
+					runningFailed.State = models.Task_Completed
+					runningFailed.Failed = true
+					expired = model_helpers.NewValidTask("expired")
+					expired.State = models.Task_Completed
+
+					fakeTaskDB.ConvergeTasksReturns(db.TaskConvergenceResult{
+						Changes: []*models.TaskChange{
+							{Before: running, After: runningFailed},
+							{Before: expired},
+						},
+					})
+				})
+
+				It("does not list the tasks", func() {
+					Expect(fakeTaskDB.TasksCallCount()).To(Equal(0))
+				})
+
+				It("emits events for the changed and removed tasks", func() {
+					Expect(taskHub.EmitCallCount()).To(Equal(2))
+					Expect(taskHub.EmitArgsForCall(0)).To(Equal(models.NewTaskChangedEvent(running, runningFailed)))
+					Expect(taskHub.EmitArgsForCall(1)).To(Equal(models.NewTaskRemovedEvent(expired)))
+				})
+			})
+
+			Context("when there are running tasks to cancel", func() {
+				BeforeEach(func() {
+					running := model_helpers.NewValidTask("running")
+					running.State = models.Task_Running
+					running.CellId = "cell-id"
+					fakeTaskDB.ConvergeTasksReturns(db.TaskConvergenceResult{TasksToCancel: []*models.Task{running}})
+					fakeServiceClient.CellByIdReturns(&models.CellPresence{RepAddress: "some-address"}, nil)
+				})
+
+				It("cancels the tasks on their cells", func() {
+					Expect(fakeServiceClient.CellByIdCallCount()).To(Equal(1))
+					_, cellID := fakeServiceClient.CellByIdArgsForCall(0)
+					Expect(cellID).To(Equal("cell-id"))
+
+					Expect(fakeRepClientFactory.CreateClientCallCount()).To(Equal(1))
+					Expect(fakeRepClientFactory.CreateClientArgsForCall(0)).To(Equal("some-address"))
+					Expect(fakeRepClient.CancelTaskCallCount()).To(Equal(1))
+					Expect(fakeRepClient.CancelTaskArgsForCall(0)).To(Equal("running"))
+				})
+
+				It("does not fail the tasks itself", func() {
+					Expect(fakeTaskDB.FailTaskCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when there are tasks to auction", func() {
 				const taskGuid1 = "to-auction-1"
 				const taskGuid2 = "to-auction-2"
@@ -996,7 +1827,9 @@ var _ = Describe("Task Handlers", func() {
 				BeforeEach(func() {
 					taskStartRequest1 := auctioneer.NewTaskStartRequestFromModel(taskGuid1, "domain", model_helpers.NewValidTaskDefinition())
 					taskStartRequest2 := auctioneer.NewTaskStartRequestFromModel(taskGuid2, "domain", model_helpers.NewValidTaskDefinition())
-					fakeTaskDB.ConvergeTasksReturns([]*auctioneer.TaskStartRequest{&taskStartRequest1, &taskStartRequest2}, nil)
+					fakeTaskDB.ConvergeTasksReturns(db.TaskConvergenceResult{
+						TasksToAuction: []*auctioneer.TaskStartRequest{&taskStartRequest1, &taskStartRequest2},
+					})
 				})
 
 				It("requests an auction", func() {
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+var errTaskSchedulesUnavailable = models.NewError(models.Error_InvalidRequest, "task schedules are only stored in a SQL database")
+
+type TaskScheduleHandler struct {
+	db       db.TaskScheduleDB
+	exitChan chan<- struct{}
+	logger   lager.Logger
+}
+
+// NewTaskScheduleHandler returns a handler for managing task schedules. The
+// db is nil when the BBS is not backed by SQL.
+func NewTaskScheduleHandler(logger lager.Logger, db db.TaskScheduleDB, exitChan chan<- struct{}) *TaskScheduleHandler {
+	return &TaskScheduleHandler{
+		db:       db,
+		exitChan: exitChan,
+		logger:   logger.Session("task-schedule-handler"),
+	}
+}
+
+func (h *TaskScheduleHandler) TaskSchedules(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("task-schedules")
+
+	request := &models.TaskSchedulesRequest{}
+	response := &models.TaskSchedulesResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		if h.db == nil {
+			err = errTaskSchedulesUnavailable
+		} else {
+			response.TaskSchedules, err = h.db.TaskSchedules(logger, request.Filter())
+		}
+	}
+
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
+func (h *TaskScheduleHandler) TaskScheduleByGuid(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("task-schedule-by-guid")
+
+	request := &models.TaskScheduleGuidRequest{}
+	response := &models.TaskScheduleResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		if h.db == nil {
+			err = errTaskSchedulesUnavailable
+		} else {
+			response.TaskSchedule, err = h.db.TaskScheduleByGuid(logger, request.ScheduleGuid)
+		}
+	}
+
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
+func (h *TaskScheduleHandler) DesireTaskSchedule(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("desire-task-schedule")
+
+	request := &models.DesireTaskScheduleRequest{}
+	response := &models.TaskScheduleLifecycleResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		if h.db == nil {
+			err = errTaskSchedulesUnavailable
+		} else {
+			err = h.db.DesireTaskSchedule(logger, request.TaskSchedule)
+		}
+	}
+
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
+func (h *TaskScheduleHandler) UpdateTaskSchedule(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("update-task-schedule")
+
+	request := &models.UpdateTaskScheduleRequest{}
+	response := &models.TaskScheduleLifecycleResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		if h.db == nil {
+			err = errTaskSchedulesUnavailable
+		} else {
+			err = h.db.UpdateTaskSchedule(logger, request.TaskSchedule)
+		}
+	}
+
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
+func (h *TaskScheduleHandler) RemoveTaskSchedule(w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger := h.logger.Session("remove-task-schedule")
+
+	request := &models.TaskScheduleGuidRequest{}
+	response := &models.TaskScheduleLifecycleResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		if h.db == nil {
+			err = errTaskSchedulesUnavailable
+		} else {
+			err = h.db.RemoveTaskSchedule(logger, request.ScheduleGuid)
+		}
+	}
+
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
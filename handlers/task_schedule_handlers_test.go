@@ -0,0 +1,217 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Task Schedule Handlers", func() {
+	var (
+		logger           *lagertest.TestLogger
+		fakeScheduleDB   *dbfakes.FakeTaskScheduleDB
+		responseRecorder *httptest.ResponseRecorder
+		handler          *handlers.TaskScheduleHandler
+		exitCh           chan struct{}
+
+		schedule *models.TaskSchedule
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeScheduleDB = new(dbfakes.FakeTaskScheduleDB)
+		responseRecorder = httptest.NewRecorder()
+		exitCh = make(chan struct{}, 1)
+		handler = handlers.NewTaskScheduleHandler(logger, fakeScheduleDB, exitCh)
+
+		schedule = &models.TaskSchedule{
+			ScheduleGuid:      "some-schedule-guid",
+			Domain:            "some-domain",
+			CronExpression:    "@hourly",
+			TaskDefinition:    model_helpers.NewValidTaskDefinition(),
+			ConcurrencyPolicy: models.TaskSchedule_Replace,
+			HistoryLimit:      5,
+		}
+	})
+
+	Describe("TaskSchedules", func() {
+		var requestBody interface{}
+
+		BeforeEach(func() {
+			requestBody = &models.TaskSchedulesRequest{Domain: "some-domain"}
+			fakeScheduleDB.TaskSchedulesReturns([]*models.TaskSchedule{schedule}, nil)
+		})
+
+		JustBeforeEach(func() {
+			handler.TaskSchedules(responseRecorder, newTestRequest(requestBody))
+		})
+
+		It("lists the schedules matching the filter", func() {
+			Expect(fakeScheduleDB.TaskSchedulesCallCount()).To(Equal(1))
+			_, filter := fakeScheduleDB.TaskSchedulesArgsForCall(0)
+			Expect(filter).To(Equal(models.TaskScheduleFilter{Domain: "some-domain"}))
+
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+			response := models.TaskSchedulesResponse{}
+			Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+			Expect(response.Error).To(BeNil())
+			Expect(response.TaskSchedules).To(Equal([]*models.TaskSchedule{schedule}))
+		})
+
+		Context("when the db fails", func() {
+			BeforeEach(func() {
+				fakeScheduleDB.TaskSchedulesReturns(nil, models.ErrUnknownError)
+			})
+
+			It("returns the error", func() {
+				response := models.TaskSchedulesResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+			})
+		})
+
+		Context("when task schedules are not stored in the database", func() {
+			BeforeEach(func() {
+				handler = handlers.NewTaskScheduleHandler(logger, nil, exitCh)
+			})
+
+			It("returns an error", func() {
+				response := models.TaskSchedulesResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+			})
+		})
+	})
+
+	Describe("TaskScheduleByGuid", func() {
+		var requestBody interface{}
+
+		BeforeEach(func() {
+			requestBody = &models.TaskScheduleGuidRequest{ScheduleGuid: "some-schedule-guid"}
+			fakeScheduleDB.TaskScheduleByGuidReturns(schedule, nil)
+		})
+
+		JustBeforeEach(func() {
+			handler.TaskScheduleByGuid(responseRecorder, newTestRequest(requestBody))
+		})
+
+		It("returns the schedule", func() {
+			_, guid := fakeScheduleDB.TaskScheduleByGuidArgsForCall(0)
+			Expect(guid).To(Equal("some-schedule-guid"))
+
+			response := models.TaskScheduleResponse{}
+			Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+			Expect(response.Error).To(BeNil())
+			Expect(response.TaskSchedule).To(Equal(schedule))
+		})
+
+		Context("when the schedule does not exist", func() {
+			BeforeEach(func() {
+				fakeScheduleDB.TaskScheduleByGuidReturns(nil, models.ErrResourceNotFound)
+			})
+
+			It("returns a resource not found error", func() {
+				response := models.TaskScheduleResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Describe("DesireTaskSchedule", func() {
+		var requestBody interface{}
+
+		BeforeEach(func() {
+			requestBody = &models.DesireTaskScheduleRequest{TaskSchedule: schedule}
+		})
+
+		JustBeforeEach(func() {
+			handler.DesireTaskSchedule(responseRecorder, newTestRequest(requestBody))
+		})
+
+		It("creates the schedule", func() {
+			Expect(fakeScheduleDB.DesireTaskScheduleCallCount()).To(Equal(1))
+			_, desired := fakeScheduleDB.DesireTaskScheduleArgsForCall(0)
+			Expect(desired).To(Equal(schedule))
+
+			response := models.TaskScheduleLifecycleResponse{}
+			Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+			Expect(response.Error).To(BeNil())
+		})
+
+		Context("when the schedule is invalid", func() {
+			BeforeEach(func() {
+				schedule.CronExpression = "sometimes"
+			})
+
+			It("returns a bad request error", func() {
+				response := models.TaskScheduleLifecycleResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeScheduleDB.DesireTaskScheduleCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the schedule already exists", func() {
+			BeforeEach(func() {
+				fakeScheduleDB.DesireTaskScheduleReturns(models.ErrResourceExists)
+			})
+
+			It("returns the error", func() {
+				response := models.TaskScheduleLifecycleResponse{}
+				Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+				Expect(response.Error).To(Equal(models.ErrResourceExists))
+			})
+		})
+	})
+
+	Describe("UpdateTaskSchedule", func() {
+		JustBeforeEach(func() {
+			handler.UpdateTaskSchedule(responseRecorder, newTestRequest(&models.UpdateTaskScheduleRequest{TaskSchedule: schedule}))
+		})
+
+		It("updates the schedule", func() {
+			Expect(fakeScheduleDB.UpdateTaskScheduleCallCount()).To(Equal(1))
+			_, updated := fakeScheduleDB.UpdateTaskScheduleArgsForCall(0)
+			Expect(updated).To(Equal(schedule))
+
+			response := models.TaskScheduleLifecycleResponse{}
+			Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+			Expect(response.Error).To(BeNil())
+		})
+	})
+
+	Describe("RemoveTaskSchedule", func() {
+		JustBeforeEach(func() {
+			handler.RemoveTaskSchedule(responseRecorder, newTestRequest(&models.TaskScheduleGuidRequest{ScheduleGuid: "some-schedule-guid"}))
+		})
+
+		It("removes the schedule", func() {
+			Expect(fakeScheduleDB.RemoveTaskScheduleCallCount()).To(Equal(1))
+			_, guid := fakeScheduleDB.RemoveTaskScheduleArgsForCall(0)
+			Expect(guid).To(Equal("some-schedule-guid"))
+
+			response := models.TaskScheduleLifecycleResponse{}
+			Expect(response.Unmarshal(responseRecorder.Body.Bytes())).To(Succeed())
+			Expect(response.Error).To(BeNil())
+		})
+
+		Context("when the db fails unrecoverably", func() {
+			BeforeEach(func() {
+				fakeScheduleDB.RemoveTaskScheduleReturns(models.NewUnrecoverableError(nil))
+			})
+
+			It("signals the bbs to exit", func() {
+				Eventually(exitCh).Should(Receive())
+			})
+		})
+	})
+})
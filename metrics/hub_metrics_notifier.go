@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+// HubMetricsNotifier periodically reports how far behind the subscribers of
+// each hub are. Hubs are keyed by the prefix of their metric names, such as
+// "Task" for TaskEventSubscriberMaxQueueDepth. Since subscribers come and go,
+// they are not reported one by one; their queues are listed by the
+// /debug/event-subscribers endpoint of the health server instead.
+type HubMetricsNotifier struct {
+	Interval time.Duration
+	Hubs     map[string]events.Hub
+	Logger   lager.Logger
+	Clock    clock.Clock
+}
+
+func NewHubMetricsNotifier(logger lager.Logger, interval time.Duration, hubs map[string]events.Hub, clock clock.Clock) *HubMetricsNotifier {
+	return &HubMetricsNotifier{
+		Interval: interval,
+		Hubs:     hubs,
+		Logger:   logger,
+		Clock:    clock,
+	}
+}
+
+func (notifier HubMetricsNotifier) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := notifier.Logger.Session("hub-metrics-notifier", lager.Data{"interval": notifier.Interval.String()})
+	logger.Info("starting")
+
+	ticker := notifier.Clock.NewTicker(notifier.Interval)
+	defer ticker.Stop()
+
+	close(ready)
+
+	logger.Info("started")
+	defer logger.Info("finished")
+
+	for {
+		select {
+		case <-ticker.C():
+			for name, hub := range notifier.Hubs {
+				notifier.send(logger, name, hub.Stats())
+			}
+
+		case <-signals:
+			return nil
+		}
+	}
+}
+
+func (notifier HubMetricsNotifier) send(logger lager.Logger, name string, stats events.HubStats) {
+	values := map[metric.Metric]int{
+		metric.Metric(name + "EventSubscriberMaxQueueDepth"):           stats.MaxQueueDepth(),
+		metric.Metric(name + "EventsDropped"):                          int(stats.DroppedEvents),
+		metric.Metric(name + "EventsCoalesced"):                        int(stats.CoalescedEvents),
+		metric.Metric(name + "EventSubscriberSlowConsumerDisconnects"): int(stats.Disconnects[events.DisconnectedSlowConsumer]),
+	}
+
+	for m, value := range values {
+		err := m.Send(value)
+		if err != nil {
+			logger.Error("failed-to-send-hub-metric", err, lager.Data{"metric": string(m)})
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package metrics_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
+	"github.com/cloudfoundry-incubator/bbs/metrics"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HubMetricsNotifier", func() {
+	const reportInterval = 100 * time.Millisecond
+
+	var (
+		sender    *fake.FakeMetricSender
+		fakeClock *fakeclock.FakeClock
+		taskHub   *eventfakes.FakeHub
+		process   ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		sender = fake.NewFakeMetricSender()
+		dropsonde_metrics.Initialize(sender, nil)
+
+		taskHub = new(eventfakes.FakeHub)
+		taskHub.StatsReturns(events.HubStats{
+			Subscribers: []events.SubscriberStats{
+				{ID: 1, QueueDepth: 3},
+				{ID: 2, QueueDepth: 17},
+			},
+			DroppedEvents:   5,
+			CoalescedEvents: 7,
+			Disconnects: map[events.DisconnectReason]uint64{
+				events.DisconnectedSlowConsumer: 2,
+				events.DisconnectedBySubscriber: 9,
+			},
+		})
+
+		process = ifrit.Invoke(metrics.NewHubMetricsNotifier(
+			lagertest.NewTestLogger("test"),
+			reportInterval,
+			map[string]events.Hub{"Task": taskHub},
+			fakeClock,
+		))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait(), 2*time.Second).Should(Receive())
+	})
+
+	It("reports the hub stats every interval", func() {
+		Consistently(taskHub.StatsCallCount).Should(BeZero())
+
+		fakeClock.Increment(reportInterval)
+
+		expected := map[string]float64{
+			"TaskEventSubscriberMaxQueueDepth":           17,
+			"TaskEventsDropped":                          5,
+			"TaskEventsCoalesced":                        7,
+			"TaskEventSubscriberSlowConsumerDisconnects": 2,
+		}
+		for name, value := range expected {
+			name := name
+			Eventually(func() fake.Metric {
+				return sender.GetValue(name)
+			}).Should(Equal(fake.Metric{Value: value, Unit: "Metric"}))
+		}
+	})
+})
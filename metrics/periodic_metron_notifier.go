@@ -1,10 +1,12 @@
 package metrics
 
 import (
+	"database/sql"
 	"os"
 	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
 	"github.com/cloudfoundry-incubator/runtime-schema/metric"
 	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
@@ -17,22 +19,28 @@ const (
 )
 
 type PeriodicMetronNotifier struct {
-	Interval    time.Duration
-	ETCDOptions *etcd.ETCDOptions
-	Logger      lager.Logger
-	Clock       clock.Clock
+	Interval       time.Duration
+	ETCDOptions    *etcd.ETCDOptions
+	SQLConn        *sql.DB
+	DatabaseDriver string
+	Logger         lager.Logger
+	Clock          clock.Clock
 }
 
 func NewPeriodicMetronNotifier(logger lager.Logger,
 	interval time.Duration,
 	etcdOptions *etcd.ETCDOptions,
+	sqlConn *sql.DB,
+	databaseDriver string,
 	clock clock.Clock,
 ) *PeriodicMetronNotifier {
 	return &PeriodicMetronNotifier{
-		Interval:    interval,
-		ETCDOptions: etcdOptions,
-		Logger:      logger,
-		Clock:       clock,
+		Interval:       interval,
+		ETCDOptions:    etcdOptions,
+		SQLConn:        sqlConn,
+		DatabaseDriver: databaseDriver,
+		Logger:         logger,
+		Clock:          clock,
 	}
 }
 
@@ -40,6 +48,7 @@ func (notifier PeriodicMetronNotifier) Run(signals <-chan os.Signal, ready chan<
 	logger := notifier.Logger.Session("metrics-notifier", lager.Data{"interval": notifier.Interval.String()})
 	logger.Info("starting")
 	var etcdMetrics *etcd.ETCDMetrics
+	var sqlMetrics *sqldb.SQLMetrics
 	var err error
 
 	if notifier.ETCDOptions.IsConfigured {
@@ -49,6 +58,10 @@ func (notifier PeriodicMetronNotifier) Run(signals <-chan os.Signal, ready chan<
 		}
 	}
 
+	if notifier.SQLConn != nil {
+		sqlMetrics = sqldb.NewSQLMetrics(notifier.Logger, notifier.SQLConn, notifier.DatabaseDriver, notifier.Clock)
+	}
+
 	ticker := notifier.Clock.NewTicker(notifier.Interval)
 	defer ticker.Stop()
 
@@ -68,6 +81,10 @@ func (notifier PeriodicMetronNotifier) Run(signals <-chan os.Signal, ready chan<
 				etcdMetrics.Send()
 			}
 
+			if sqlMetrics != nil {
+				sqlMetrics.Send()
+			}
+
 			finishedAt := notifier.Clock.Now()
 
 			err = metricsReportingDuration.Send(finishedAt.Sub(startedAt))
@@ -47,6 +47,8 @@ var _ = Describe("PeriodicMetronNotifier", func() {
 			lagertest.NewTestLogger("test"),
 			reportInterval,
 			&etcdOptions,
+			nil,
+			"",
 			fakeClock,
 		))
 	})
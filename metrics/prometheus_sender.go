@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/cloudfoundry/dropsonde/metric_sender"
+)
+
+const (
+	prometheusNamespace   = "bbs"
+	prometheusContentType = "text/plain; version=0.0.4"
+
+	nanosUnit = "nanos"
+)
+
+type prometheusValue struct {
+	value float64
+	unit  string
+}
+
+// PrometheusSender is a dropsonde MetricSender that remembers the latest value
+// of every gauge and the running total of every counter sent through it, and
+// serves them in the Prometheus text exposition format. Every metric is also
+// forwarded to the wrapped sender, if one is given, so metron keeps working.
+type PrometheusSender struct {
+	sender metric_sender.MetricSender
+
+	lock     sync.RWMutex
+	counters map[string]uint64
+	values   map[string]prometheusValue
+}
+
+func NewPrometheusSender(sender metric_sender.MetricSender) *PrometheusSender {
+	return &PrometheusSender{
+		sender:   sender,
+		counters: map[string]uint64{},
+		values:   map[string]prometheusValue{},
+	}
+}
+
+func (p *PrometheusSender) SendValue(name string, value float64, unit string) error {
+	p.lock.Lock()
+	p.values[name] = prometheusValue{value: value, unit: unit}
+	p.lock.Unlock()
+
+	if p.sender == nil {
+		return nil
+	}
+	return p.sender.SendValue(name, value, unit)
+}
+
+func (p *PrometheusSender) IncrementCounter(name string) error {
+	p.lock.Lock()
+	p.counters[name]++
+	p.lock.Unlock()
+
+	if p.sender == nil {
+		return nil
+	}
+	return p.sender.IncrementCounter(name)
+}
+
+func (p *PrometheusSender) AddToCounter(name string, delta uint64) error {
+	p.lock.Lock()
+	p.counters[name] += delta
+	p.lock.Unlock()
+
+	if p.sender == nil {
+		return nil
+	}
+	return p.sender.AddToCounter(name, delta)
+}
+
+func (p *PrometheusSender) SendContainerMetric(applicationId string, instanceIndex int32, cpuPercentage float64, memoryBytes uint64, diskBytes uint64) error {
+	if p.sender == nil {
+		return nil
+	}
+	return p.sender.SendContainerMetric(applicationId, instanceIndex, cpuPercentage, memoryBytes, diskBytes)
+}
+
+func (p *PrometheusSender) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", prometheusContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(p.render())
+}
+
+type prometheusSample struct {
+	labels string
+	value  float64
+}
+
+type prometheusFamily struct {
+	metricType string
+	samples    []prometheusSample
+}
+
+func (p *PrometheusSender) render() []byte {
+	families := map[string]*prometheusFamily{}
+	add := func(name, metricType string, value float64) {
+		familyName, labels := prometheusName(name)
+		switch metricType {
+		case "counter":
+			familyName += "_total"
+		}
+
+		family, ok := families[familyName]
+		if !ok {
+			family = &prometheusFamily{metricType: metricType}
+			families[familyName] = family
+		}
+		family.samples = append(family.samples, prometheusSample{labels: labels, value: value})
+	}
+
+	p.lock.RLock()
+	for name, total := range p.counters {
+		add(name, "counter", float64(total))
+	}
+	for name, v := range p.values {
+		if v.unit == nanosUnit {
+			add(name+"Seconds", "gauge", v.value/1e9)
+		} else {
+			add(name, "gauge", v.value)
+		}
+	}
+	p.lock.RUnlock()
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	for _, name := range names {
+		family := families[name]
+		sort.Sort(byLabels(family.samples))
+
+		fmt.Fprintf(buf, "# TYPE %s %s\n", name, family.metricType)
+		for _, sample := range family.samples {
+			fmt.Fprintf(buf, "%s%s %s\n", name, sample.labels, strconv.FormatFloat(sample.value, 'g', -1, 64))
+		}
+	}
+
+	return buf.Bytes()
+}
+
+type byLabels []prometheusSample
+
+func (s byLabels) Len() int           { return len(s) }
+func (s byLabels) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byLabels) Less(i, j int) bool { return s[i].labels < s[j].labels }
+
+// prometheusName converts a dropsonde metric name into a Prometheus metric
+// name and label set. Names of the form "Prefix.value" (e.g. the domain
+// freshness metrics "Domain.cf-apps") become a single metric with the suffix
+// as a label, i.e. bbs_domain{domain="cf-apps"}.
+func prometheusName(name string) (string, string) {
+	labels := ""
+	if i := strings.Index(name, "."); i > 0 {
+		key := snakeCase(name[:i])
+		labels = fmt.Sprintf("{%s=%s}", key, strconv.Quote(name[i+1:]))
+		name = name[:i]
+	}
+
+	name = snakeCase(name)
+	if !strings.HasPrefix(name, prometheusNamespace+"_") {
+		name = prometheusNamespace + "_" + name
+	}
+
+	return name, labels
+}
+
+// snakeCase turns CamelCase metric names such as "ETCDRaftTerm" or
+// "LRPsDesired" into "etcd_raft_term" and "lrps_desired".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	buf := &bytes.Buffer{}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			buf.WriteRune('_')
+			continue
+		}
+
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				buf.WriteRune('_')
+			} else if unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !isPluralSuffix(runes, i+1) {
+				buf.WriteRune('_')
+			}
+		}
+
+		buf.WriteRune(unicode.ToLower(r))
+	}
+
+	return buf.String()
+}
+
+// isPluralSuffix reports whether runes[i] is the trailing "s" of a pluralised
+// acronym, as in "LRPs".
+func isPluralSuffix(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
@@ -0,0 +1,111 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/metrics"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusSender", func() {
+	var (
+		metronSender     *fake.FakeMetricSender
+		prometheusSender *metrics.PrometheusSender
+	)
+
+	BeforeEach(func() {
+		metronSender = fake.NewFakeMetricSender()
+		prometheusSender = metrics.NewPrometheusSender(metronSender)
+		dropsonde_metrics.Initialize(prometheusSender, nil)
+	})
+
+	scrape := func() (*httptest.ResponseRecorder, string) {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest("GET", "/metrics", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		prometheusSender.ServeHTTP(recorder, request)
+		return recorder, recorder.Body.String()
+	}
+
+	It("forwards metrics to the wrapped sender", func() {
+		metric.Metric("TasksPending").Send(4)
+		metric.Counter("ConvergenceTaskRuns").Increment()
+		metric.Counter("ConvergenceTaskRuns").Add(2)
+
+		Expect(metronSender.GetValue("TasksPending")).To(Equal(fake.Metric{Value: 4, Unit: "Metric"}))
+		Expect(metronSender.GetCounter("ConvergenceTaskRuns")).To(Equal(uint64(3)))
+	})
+
+	It("serves the text exposition format", func() {
+		recorder, _ := scrape()
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("text/plain; version=0.0.4"))
+	})
+
+	It("exposes gauges with their latest value", func() {
+		metric.Metric("LRPsDesired").Send(10)
+		metric.Metric("LRPsDesired").Send(12)
+		metric.Metric("ETCDRaftTerm").Send(3)
+
+		_, body := scrape()
+		Expect(body).To(ContainSubstring("# TYPE bbs_lrps_desired gauge\nbbs_lrps_desired 12\n"))
+		Expect(body).To(ContainSubstring("# TYPE bbs_etcd_raft_term gauge\nbbs_etcd_raft_term 3\n"))
+	})
+
+	It("exposes counters with their running total", func() {
+		metric.Counter("RequestCount").Increment()
+		metric.Counter("RequestCount").Add(4)
+
+		_, body := scrape()
+		Expect(body).To(ContainSubstring("# TYPE bbs_request_count_total counter\nbbs_request_count_total 5\n"))
+	})
+
+	It("does not repeat the bbs namespace", func() {
+		metric.Counter("BBSMasterElected").Increment()
+
+		_, body := scrape()
+		Expect(body).To(ContainSubstring("bbs_master_elected_total 1\n"))
+		Expect(body).NotTo(ContainSubstring("bbs_bbs_"))
+	})
+
+	It("exposes durations in seconds", func() {
+		metric.Duration("RequestLatency").Send(1500 * time.Millisecond)
+
+		_, body := scrape()
+		Expect(body).To(ContainSubstring("# TYPE bbs_request_latency_seconds gauge\nbbs_request_latency_seconds 1.5\n"))
+	})
+
+	It("exposes domain freshness as a labelled gauge", func() {
+		metric.Metric("Domain.cf-apps").Send(1)
+		metric.Metric("Domain.cf-tasks").Send(1)
+
+		_, body := scrape()
+		Expect(body).To(ContainSubstring(
+			"# TYPE bbs_domain gauge\n" +
+				`bbs_domain{domain="cf-apps"} 1` + "\n" +
+				`bbs_domain{domain="cf-tasks"} 1` + "\n",
+		))
+	})
+
+	Context("when there is no wrapped sender", func() {
+		BeforeEach(func() {
+			prometheusSender = metrics.NewPrometheusSender(nil)
+			dropsonde_metrics.Initialize(prometheusSender, nil)
+		})
+
+		It("still records metrics", func() {
+			Expect(metric.Metric("TasksRunning").Send(2)).To(Succeed())
+
+			_, body := scrape()
+			Expect(body).To(ContainSubstring("bbs_tasks_running 2\n"))
+		})
+	})
+})
@@ -18,7 +18,8 @@ import (
 )
 
 const (
-	migrationDuration = metric.Duration("MigrationDuration")
+	migrationDuration   = metric.Duration("MigrationDuration")
+	migrationInProgress = metric.Metric("MigrationInProgress")
 )
 
 type Manager struct {
@@ -168,6 +169,11 @@ func (m *Manager) performMigration(
 ) {
 	migrateStart := m.clock.Now()
 	if version.CurrentVersion != maxMigrationVersion {
+		err := migrationInProgress.Send(1)
+		if err != nil {
+			logger.Error("failed-to-send-migration-in-progress-metric", err)
+		}
+
 		lastVersion := version.CurrentVersion
 		nextVersion := version.CurrentVersion
 
@@ -211,7 +217,7 @@ func (m *Manager) performMigration(
 			}
 		}
 
-		err := m.writeVersion(lastVersion, nextVersion, lastETCDMigrationVersion)
+		err = m.writeVersion(lastVersion, nextVersion, lastETCDMigrationVersion)
 		if err != nil {
 			errorChan <- err
 			return
@@ -228,6 +234,11 @@ func (m *Manager) performMigration(
 }
 
 func (m *Manager) finish(logger lager.Logger, ready chan<- struct{}) {
+	err := migrationInProgress.Send(0)
+	if err != nil {
+		logger.Error("failed-to-send-migration-in-progress-metric", err)
+	}
+
 	close(ready)
 	close(m.migrationsDone)
 	logger.Info("finished-migrations")
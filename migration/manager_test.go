@@ -449,6 +449,25 @@ var _ = Describe("Migration Manager", func() {
 				Expect(reportedDuration.Unit).To(Equal("nanos"))
 			})
 
+			Context("while the migrations are running", func() {
+				var inProgressDuringMigration chan float64
+
+				BeforeEach(func() {
+					inProgressDuringMigration = make(chan float64, 1)
+					fakeMigration.UpStub = func(logger lager.Logger) error {
+						inProgressDuringMigration <- sender.GetValue("MigrationInProgress").Value
+						return nil
+					}
+				})
+
+				It("reports that a migration is in progress until they finish", func() {
+					Eventually(inProgressDuringMigration).Should(Receive(BeEquivalentTo(1)))
+					Eventually(migrationProcess.Ready()).Should(BeClosed())
+
+					Expect(sender.GetValue("MigrationInProgress").Value).To(BeZero())
+				})
+			})
+
 			It("it sorts the migrations and runs them sequentially", func() {
 				Eventually(migrationProcess.Ready()).Should(BeClosed())
 				Expect(migrationsDone).To(BeClosed())
@@ -9,6 +9,7 @@
 		actions.proto
 		actual_lrp.proto
 		actual_lrp_requests.proto
+		audit.proto
 		cached_dependency.proto
 		cells.proto
 		desired_lrp.proto
@@ -25,6 +26,7 @@
 		security_group.proto
 		task.proto
 		task_requests.proto
+		task_schedule.proto
 		volume_mount.proto
 
 	It has these top-level messages:
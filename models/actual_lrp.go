@@ -30,9 +30,29 @@ type ActualLRPChange struct {
 	After  *ActualLRPGroup
 }
 
+// ActualLRPFilter selects ActualLRPs. Empty fields match every ActualLRP;
+// ProcessGuid and Index are only used when listing individual ActualLRPs.
 type ActualLRPFilter struct {
-	Domain string
-	CellID string
+	Domain      string
+	CellID      string
+	ProcessGuid string
+	Index       *int32
+}
+
+func (filter ActualLRPFilter) Matches(actualLRP *ActualLRP) bool {
+	if filter.Domain != "" && filter.Domain != actualLRP.Domain {
+		return false
+	}
+	if filter.CellID != "" && filter.CellID != actualLRP.CellId {
+		return false
+	}
+	if filter.ProcessGuid != "" && filter.ProcessGuid != actualLRP.ProcessGuid {
+		return false
+	}
+	if filter.Index != nil && *filter.Index != actualLRP.Index {
+		return false
+	}
+	return true
 }
 
 func NewActualLRPKey(processGuid string, index int32, domain string) ActualLRPKey {
@@ -164,6 +184,39 @@ func (group ActualLRPGroup) Resolve() (*ActualLRP, bool) {
 	}
 }
 
+// ActualLRPs returns the members of the group as individual ActualLRPs, with
+// their presence set to say which member they are.
+func (group ActualLRPGroup) ActualLRPs() []*ActualLRP {
+	actualLRPs := []*ActualLRP{}
+	if group.Instance != nil {
+		actualLRPs = append(actualLRPs, group.Instance.WithPresence(ActualLRP_Ordinary))
+	}
+	if group.Evacuating != nil {
+		actualLRPs = append(actualLRPs, group.Evacuating.WithPresence(ActualLRP_Evacuating))
+	}
+	return actualLRPs
+}
+
+// FlattenActualLRPGroups returns the members of the groups as individual
+// ActualLRPs.
+func FlattenActualLRPGroups(groups []*ActualLRPGroup) []*ActualLRP {
+	actualLRPs := []*ActualLRP{}
+	for _, group := range groups {
+		actualLRPs = append(actualLRPs, group.ActualLRPs()...)
+	}
+	return actualLRPs
+}
+
+// WithPresence returns a copy of the ActualLRP with the given presence.
+func (actual *ActualLRP) WithPresence(presence ActualLRP_Presence) *ActualLRP {
+	if actual == nil {
+		return nil
+	}
+	copied := *actual
+	copied.Presence = presence
+	return &copied
+}
+
 func NewUnclaimedActualLRP(lrpKey ActualLRPKey, since int64) *ActualLRP {
 	return &ActualLRP{
 		ActualLRPKey: lrpKey,
@@ -9,11 +9,12 @@ import math "math"
 
 // discarding unused import gogoproto "github.com/gogo/protobuf/gogoproto"
 
+import strconv "strconv"
+
 import fmt "fmt"
 import strings "strings"
 import github_com_gogo_protobuf_proto "github.com/gogo/protobuf/proto"
 import sort "sort"
-import strconv "strconv"
 import reflect "reflect"
 
 import io "io"
@@ -22,6 +23,39 @@ import io "io"
 var _ = proto.Marshal
 var _ = math.Inf
 
+type ActualLRP_Presence int32
+
+const (
+	ActualLRP_Ordinary   ActualLRP_Presence = 0
+	ActualLRP_Evacuating ActualLRP_Presence = 1
+)
+
+var ActualLRP_Presence_name = map[int32]string{
+	0: "Ordinary",
+	1: "Evacuating",
+}
+var ActualLRP_Presence_value = map[string]int32{
+	"Ordinary":   0,
+	"Evacuating": 1,
+}
+
+func (x ActualLRP_Presence) Enum() *ActualLRP_Presence {
+	p := new(ActualLRP_Presence)
+	*p = x
+	return p
+}
+func (x ActualLRP_Presence) MarshalJSON() ([]byte, error) {
+	return proto.MarshalJSONEnum(ActualLRP_Presence_name, int32(x))
+}
+func (x *ActualLRP_Presence) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ActualLRP_Presence_value, data, "ActualLRP_Presence")
+	if err != nil {
+		return err
+	}
+	*x = ActualLRP_Presence(value)
+	return nil
+}
+
 type ActualLRPGroup struct {
 	Instance   *ActualLRP `protobuf:"bytes,1,opt,name=instance" json:"instance,omitempty"`
 	Evacuating *ActualLRP `protobuf:"bytes,2,opt,name=evacuating" json:"evacuating,omitempty"`
@@ -144,12 +178,13 @@ type ActualLRP struct {
 	ActualLRPKey         `protobuf:"bytes,1,opt,name=actual_lrp_key,embedded=actual_lrp_key" json:""`
 	ActualLRPInstanceKey `protobuf:"bytes,2,opt,name=actual_lrp_instance_key,embedded=actual_lrp_instance_key" json:""`
 	ActualLRPNetInfo     `protobuf:"bytes,3,opt,name=actual_lrp_net_info,embedded=actual_lrp_net_info" json:""`
-	CrashCount           int32           `protobuf:"varint,4,opt,name=crash_count" json:"crash_count"`
-	CrashReason          string          `protobuf:"bytes,5,opt,name=crash_reason" json:"crash_reason,omitempty"`
-	State                string          `protobuf:"bytes,6,opt,name=state" json:"state"`
-	PlacementError       string          `protobuf:"bytes,7,opt,name=placement_error" json:"placement_error,omitempty"`
-	Since                int64           `protobuf:"varint,8,opt,name=since" json:"since"`
-	ModificationTag      ModificationTag `protobuf:"bytes,9,opt,name=modification_tag" json:"modification_tag"`
+	CrashCount           int32              `protobuf:"varint,4,opt,name=crash_count" json:"crash_count"`
+	CrashReason          string             `protobuf:"bytes,5,opt,name=crash_reason" json:"crash_reason,omitempty"`
+	State                string             `protobuf:"bytes,6,opt,name=state" json:"state"`
+	PlacementError       string             `protobuf:"bytes,7,opt,name=placement_error" json:"placement_error,omitempty"`
+	Since                int64              `protobuf:"varint,8,opt,name=since" json:"since"`
+	ModificationTag      ModificationTag    `protobuf:"bytes,9,opt,name=modification_tag" json:"modification_tag"`
+	Presence             ActualLRP_Presence `protobuf:"varint,10,opt,name=presence,enum=models.ActualLRP_Presence" json:"presence,omitempty"`
 }
 
 func (m *ActualLRP) Reset()      { *m = ActualLRP{} }
@@ -197,6 +232,23 @@ func (m *ActualLRP) GetModificationTag() ModificationTag {
 	return ModificationTag{}
 }
 
+func (m *ActualLRP) GetPresence() ActualLRP_Presence {
+	if m != nil {
+		return m.Presence
+	}
+	return ActualLRP_Ordinary
+}
+
+func init() {
+	proto.RegisterEnum("models.ActualLRP_Presence", ActualLRP_Presence_name, ActualLRP_Presence_value)
+}
+func (x ActualLRP_Presence) String() string {
+	s, ok := ActualLRP_Presence_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
 func (this *ActualLRPGroup) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -392,6 +444,9 @@ func (this *ActualLRP) Equal(that interface{}) bool {
 	if !this.ModificationTag.Equal(&that1.ModificationTag) {
 		return false
 	}
+	if this.Presence != that1.Presence {
+		return false
+	}
 	return true
 }
 func (this *ActualLRPGroup) GoString() string {
@@ -453,7 +508,8 @@ func (this *ActualLRP) GoString() string {
 		`State:` + fmt.Sprintf("%#v", this.State),
 		`PlacementError:` + fmt.Sprintf("%#v", this.PlacementError),
 		`Since:` + fmt.Sprintf("%#v", this.Since),
-		`ModificationTag:` + strings.Replace(this.ModificationTag.GoString(), `&`, ``, 1) + `}`}, ", ")
+		`ModificationTag:` + strings.Replace(this.ModificationTag.GoString(), `&`, ``, 1),
+		`Presence:` + fmt.Sprintf("%#v", this.Presence) + `}`}, ", ")
 	return s
 }
 func valueToGoStringActualLrp(v interface{}, typ string) string {
@@ -697,6 +753,9 @@ func (m *ActualLRP) MarshalTo(data []byte) (int, error) {
 		return 0, err
 	}
 	i += n6
+	data[i] = 0x50
+	i++
+	i = encodeVarintActualLrp(data, i, uint64(m.Presence))
 	return i, nil
 }
 
@@ -803,6 +862,7 @@ func (m *ActualLRP) Size() (n int) {
 	n += 1 + sovActualLrp(uint64(m.Since))
 	l = m.ModificationTag.Size()
 	n += 1 + l + sovActualLrp(uint64(l))
+	n += 1 + sovActualLrp(uint64(m.Presence))
 	return n
 }
 
@@ -889,6 +949,7 @@ func (this *ActualLRP) String() string {
 		`PlacementError:` + fmt.Sprintf("%v", this.PlacementError) + `,`,
 		`Since:` + fmt.Sprintf("%v", this.Since) + `,`,
 		`ModificationTag:` + strings.Replace(strings.Replace(this.ModificationTag.String(), "ModificationTag", "ModificationTag", 1), `&`, ``, 1) + `,`,
+		`Presence:` + fmt.Sprintf("%v", this.Presence) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1621,6 +1682,22 @@ func (m *ActualLRP) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Presence", wireType)
+			}
+			m.Presence = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Presence |= (ActualLRP_Presence(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -4,6 +4,29 @@ func (request *ActualLRPGroupsRequest) Validate() error {
 	return nil
 }
 
+func (request *ActualLRPsRequest) Validate() error {
+	var validationError ValidationError
+
+	if request.Index != nil && *request.Index < 0 {
+		validationError = validationError.Append(ErrInvalidField{"index"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request *ActualLRPsRequest) Filter() ActualLRPFilter {
+	return ActualLRPFilter{
+		Domain:      request.Domain,
+		CellID:      request.CellId,
+		ProcessGuid: request.ProcessGuid,
+		Index:       request.Index,
+	}
+}
+
 func (request *ActualLRPGroupsByProcessGuidRequest) Validate() error {
 	var validationError ValidationError
 
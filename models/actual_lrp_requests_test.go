@@ -24,6 +24,33 @@ var _ = Describe("ActualLRP Requests", func() {
 		})
 	})
 
+	Describe("ActualLRPsRequest", func() {
+		Describe("Validate", func() {
+			var request models.ActualLRPsRequest
+
+			BeforeEach(func() {
+				request = models.ActualLRPsRequest{ProcessGuid: "something"}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the Index is negative", func() {
+				BeforeEach(func() {
+					index := int32(-1)
+					request.Index = &index
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"index"}))
+				})
+			})
+		})
+	})
+
 	Describe("ActualLRPGroupsByProcessGuidRequest", func() {
 		Describe("Validate", func() {
 			var request models.ActualLRPGroupsByProcessGuidRequest
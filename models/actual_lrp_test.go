@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -394,6 +395,86 @@ var _ = Describe("ActualLRP", func() {
 				})
 			})
 		})
+		Describe("ActualLRPs", func() {
+			var instanceLRP, evacuatingLRP *models.ActualLRP
+
+			BeforeEach(func() {
+				lrpKey := models.NewActualLRPKey("process-guid", 1, "domain")
+				instanceLRP = &models.ActualLRP{ActualLRPKey: lrpKey, Since: 1138}
+				evacuatingLRP = &models.ActualLRP{ActualLRPKey: lrpKey, Since: 3417}
+			})
+
+			It("returns the members with their presence", func() {
+				group := models.ActualLRPGroup{Instance: instanceLRP, Evacuating: evacuatingLRP}
+
+				actualLRPs := group.ActualLRPs()
+				Expect(actualLRPs).To(HaveLen(2))
+				Expect(actualLRPs[0].Since).To(BeEquivalentTo(1138))
+				Expect(actualLRPs[0].Presence).To(Equal(models.ActualLRP_Ordinary))
+				Expect(actualLRPs[1].Since).To(BeEquivalentTo(3417))
+				Expect(actualLRPs[1].Presence).To(Equal(models.ActualLRP_Evacuating))
+			})
+
+			It("does not modify the group", func() {
+				group := models.ActualLRPGroup{Evacuating: evacuatingLRP}
+				group.ActualLRPs()
+				Expect(evacuatingLRP.Presence).To(Equal(models.ActualLRP_Ordinary))
+			})
+
+			It("skips missing members", func() {
+				group := models.ActualLRPGroup{Evacuating: evacuatingLRP}
+				Expect(group.ActualLRPs()).To(ConsistOf(evacuatingLRP.WithPresence(models.ActualLRP_Evacuating)))
+				Expect(models.ActualLRPGroup{}.ActualLRPs()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("FlattenActualLRPGroups", func() {
+		It("returns the members of all the groups", func() {
+			lrp1 := model_helpers.NewValidActualLRP("guid-1", 0)
+			lrp2 := model_helpers.NewValidActualLRP("guid-2", 0)
+			groups := []*models.ActualLRPGroup{
+				{Instance: lrp1},
+				{Instance: lrp2, Evacuating: lrp2},
+			}
+
+			Expect(models.FlattenActualLRPGroups(groups)).To(Equal([]*models.ActualLRP{
+				lrp1,
+				lrp2,
+				lrp2.WithPresence(models.ActualLRP_Evacuating),
+			}))
+		})
+	})
+
+	Describe("ActualLRPFilter", func() {
+		var actualLRP *models.ActualLRP
+
+		BeforeEach(func() {
+			actualLRP = model_helpers.NewValidActualLRP("some-guid", 2)
+		})
+
+		It("matches every ActualLRP when empty", func() {
+			Expect(models.ActualLRPFilter{}.Matches(actualLRP)).To(BeTrue())
+		})
+
+		It("matches on every field given", func() {
+			index := int32(2)
+			filter := models.ActualLRPFilter{
+				Domain:      actualLRP.Domain,
+				CellID:      actualLRP.CellId,
+				ProcessGuid: "some-guid",
+				Index:       &index,
+			}
+			Expect(filter.Matches(actualLRP)).To(BeTrue())
+
+			otherIndex := int32(0)
+			filter.Index = &otherIndex
+			Expect(filter.Matches(actualLRP)).To(BeFalse())
+
+			filter.Index = nil
+			filter.ProcessGuid = "other-guid"
+			Expect(filter.Matches(actualLRP)).To(BeFalse())
+		})
 	})
 
 	Describe("ActualLRP", func() {
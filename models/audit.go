@@ -0,0 +1,41 @@
+package models
+
+type AuditRecordFilter struct {
+	Since      int64
+	Until      int64
+	Identity   string
+	Route      string
+	TargetGuid string
+	Limit      int
+}
+
+func (req *AuditRecordsRequest) Validate() error {
+	var validationError ValidationError
+
+	if req.Since < 0 {
+		validationError = validationError.Append(ErrInvalidField{"since"})
+	}
+	if req.Until < 0 || (req.Until != 0 && req.Until < req.Since) {
+		validationError = validationError.Append(ErrInvalidField{"until"})
+	}
+	if req.Limit < 0 {
+		validationError = validationError.Append(ErrInvalidField{"limit"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (req *AuditRecordsRequest) Filter() AuditRecordFilter {
+	return AuditRecordFilter{
+		Since:      req.Since,
+		Until:      req.Until,
+		Identity:   req.Identity,
+		Route:      req.Route,
+		TargetGuid: req.TargetGuid,
+		Limit:      int(req.Limit),
+	}
+}
@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit", func() {
+	Describe("AuditRecordsRequest", func() {
+		Describe("Validate", func() {
+			var request models.AuditRecordsRequest
+
+			BeforeEach(func() {
+				request = models.AuditRecordsRequest{
+					Since: 10,
+					Until: 20,
+					Limit: 5,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when empty", func() {
+				It("returns nil", func() {
+					request = models.AuditRecordsRequest{}
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when until is before since", func() {
+				BeforeEach(func() {
+					request.Until = 5
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"until"}))
+				})
+			})
+
+			Context("when the limit is negative", func() {
+				BeforeEach(func() {
+					request.Limit = -1
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"limit"}))
+				})
+			})
+		})
+
+		Describe("Filter", func() {
+			It("copies the request fields", func() {
+				request := models.AuditRecordsRequest{
+					Since:      1,
+					Until:      2,
+					Identity:   "some-identity",
+					Route:      "some-route",
+					TargetGuid: "some-guid",
+					Limit:      3,
+				}
+
+				Expect(request.Filter()).To(Equal(models.AuditRecordFilter{
+					Since:      1,
+					Until:      2,
+					Identity:   "some-identity",
+					Route:      "some-route",
+					TargetGuid: "some-guid",
+					Limit:      3,
+				}))
+			})
+		})
+	})
+})
@@ -97,28 +97,3 @@ func (c CellPresence) Validate() error {
 
 	return nil
 }
-
-const (
-	EventTypeCellDisappeared = "cell_disappeared"
-)
-
-type CellEvent interface {
-	EventType() string
-	CellIDs() []string
-}
-
-type CellDisappearedEvent struct {
-	IDs []string
-}
-
-func NewCellDisappearedEvent(ids []string) CellDisappearedEvent {
-	return CellDisappearedEvent{ids}
-}
-
-func (CellDisappearedEvent) EventType() string {
-	return EventTypeCellDisappeared
-}
-
-func (e CellDisappearedEvent) CellIDs() []string {
-	return e.IDs
-}
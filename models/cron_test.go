@@ -0,0 +1,102 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CronSchedule", func() {
+	Describe("ParseCronExpression", func() {
+		It("accepts the five standard fields", func() {
+			_, err := models.ParseCronExpression("*/15 0-6,22 1 */2 1-5")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts descriptors", func() {
+			_, err := models.ParseCronExpression("@daily")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the wrong number of fields", func() {
+			_, err := models.ParseCronExpression("* * * *")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects values out of range", func() {
+			_, err := models.ParseCronExpression("60 * * * *")
+			Expect(err).To(HaveOccurred())
+
+			_, err = models.ParseCronExpression("* * 0 * *")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects malformed ranges and steps", func() {
+			_, err := models.ParseCronExpression("5-1 * * * *")
+			Expect(err).To(HaveOccurred())
+
+			_, err = models.ParseCronExpression("*/0 * * * *")
+			Expect(err).To(HaveOccurred())
+
+			_, err = models.ParseCronExpression("a * * * *")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Next", func() {
+		var start time.Time
+
+		BeforeEach(func() {
+			// a Wednesday
+			start = time.Date(2016, time.June, 15, 10, 7, 30, 0, time.UTC)
+		})
+
+		next := func(expression string, t time.Time) time.Time {
+			schedule, err := models.ParseCronExpression(expression)
+			Expect(err).NotTo(HaveOccurred())
+			n, err := schedule.Next(t)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		It("returns the next matching minute", func() {
+			Expect(next("* * * * *", start)).To(Equal(time.Date(2016, time.June, 15, 10, 8, 0, 0, time.UTC)))
+			Expect(next("*/15 * * * *", start)).To(Equal(time.Date(2016, time.June, 15, 10, 15, 0, 0, time.UTC)))
+		})
+
+		It("is strictly after the given time", func() {
+			t := time.Date(2016, time.June, 15, 10, 15, 0, 0, time.UTC)
+			Expect(next("*/15 * * * *", t)).To(Equal(time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)))
+		})
+
+		It("wraps into the next day, month and year", func() {
+			Expect(next("0 3 * * *", start)).To(Equal(time.Date(2016, time.June, 16, 3, 0, 0, 0, time.UTC)))
+			Expect(next("0 0 1 * *", start)).To(Equal(time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(next("@yearly", start)).To(Equal(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("matches the day of week", func() {
+			Expect(next("0 9 * * 1", start)).To(Equal(time.Date(2016, time.June, 20, 9, 0, 0, 0, time.UTC)))
+			Expect(next("0 9 * * 7", start)).To(Equal(time.Date(2016, time.June, 19, 9, 0, 0, 0, time.UTC)))
+		})
+
+		It("matches either day field when both are restricted", func() {
+			Expect(next("0 0 20 * 5", start)).To(Equal(time.Date(2016, time.June, 17, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("skips months without the day", func() {
+			Expect(next("0 0 31 * *", start)).To(Equal(time.Date(2016, time.July, 31, 0, 0, 0, 0, time.UTC)))
+			Expect(next("0 0 29 2 *", start)).To(Equal(time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("fails for expressions that never match", func() {
+			schedule, err := models.ParseCronExpression("0 0 31 2 *")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = schedule.Next(start)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -66,6 +66,11 @@ var (
 		Message: "the request failed due to deadlock",
 	}
 
+	ErrUnauthorized = &Error{
+		Type:    Error_Unauthorized,
+		Message: "the client is not authorized to make the request",
+	}
+
 	ErrBadRequest = &Error{
 		Type:    Error_InvalidRequest,
 		Message: "the request received is invalid",
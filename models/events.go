@@ -23,11 +23,218 @@ const (
 	EventTypeActualLRPRemoved = "actual_lrp_removed"
 	EventTypeActualLRPCrashed = "actual_lrp_crashed"
 
+	EventTypeActualLRPInstanceCreated = "actual_lrp_instance_created"
+	EventTypeActualLRPInstanceChanged = "actual_lrp_instance_changed"
+	EventTypeActualLRPInstanceRemoved = "actual_lrp_instance_removed"
+
 	EventTypeTaskCreated = "task_created"
 	EventTypeTaskChanged = "task_changed"
 	EventTypeTaskRemoved = "task_removed"
+
+	EventTypeCellAppeared    = "cell_appeared"
+	EventTypeCellDisappeared = "cell_disappeared"
 )
 
+// SequencedEvent is an Event along with the position at which it was
+// recorded in the event outbox, and when.
+type SequencedEvent struct {
+	Sequence   uint64
+	RecordedAt int64
+	Event      Event
+}
+
+// CellEvent is an Event about a cell joining or leaving the deployment.
+type CellEvent interface {
+	Event
+	CellIDs() []string
+}
+
+// EventFilter selects the events delivered to an event stream subscriber.
+// Empty fields match every event. An event about a resource that has no value
+// for a field, such as the cell id of a DesiredLRP or the process guid of a
+// Task, does not match a filter on that field.
+type EventFilter struct {
+	Domain       string
+	ProcessGuids []string
+	CellID       string
+	EventTypes   []string
+}
+
+func (filter EventFilter) Validate() error {
+	var validationError ValidationError
+
+	for _, eventType := range filter.EventTypes {
+		if !isEventType(eventType) {
+			validationError = validationError.Append(ErrInvalidField{"event_types"})
+			break
+		}
+	}
+
+	return validationError.ToError()
+}
+
+// Matches returns true if the event passes the filter. Events that describe a
+// transition, such as an ActualLRP moving between cells, match if either side
+// of the transition does.
+func (filter EventFilter) Matches(event Event) bool {
+	if len(filter.EventTypes) > 0 && !contains(filter.EventTypes, event.EventType()) {
+		return false
+	}
+
+	if filter.Domain == "" && len(filter.ProcessGuids) == 0 && filter.CellID == "" {
+		return true
+	}
+
+	for _, subject := range eventSubjects(event) {
+		if filter.matchesSubject(subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (filter EventFilter) matchesSubject(subject eventSubject) bool {
+	if filter.Domain != "" && filter.Domain != subject.domain {
+		return false
+	}
+	if len(filter.ProcessGuids) > 0 && !contains(filter.ProcessGuids, subject.processGuid) {
+		return false
+	}
+	if filter.CellID != "" && filter.CellID != subject.cellID {
+		return false
+	}
+	return true
+}
+
+type eventSubject struct {
+	domain      string
+	processGuid string
+	cellID      string
+}
+
+func eventSubjects(event Event) []eventSubject {
+	switch event := event.(type) {
+	case *DesiredLRPCreatedEvent:
+		return []eventSubject{desiredLRPSubject(event.DesiredLrp)}
+	case *DesiredLRPChangedEvent:
+		return []eventSubject{desiredLRPSubject(event.Before), desiredLRPSubject(event.After)}
+	case *DesiredLRPRemovedEvent:
+		return []eventSubject{desiredLRPSubject(event.DesiredLrp)}
+
+	case *ActualLRPCreatedEvent:
+		return actualLRPGroupSubjects(event.ActualLrpGroup)
+	case *ActualLRPChangedEvent:
+		return append(actualLRPGroupSubjects(event.Before), actualLRPGroupSubjects(event.After)...)
+	case *ActualLRPRemovedEvent:
+		return actualLRPGroupSubjects(event.ActualLrpGroup)
+	case *ActualLRPInstanceCreatedEvent:
+		return []eventSubject{actualLRPSubject(event.ActualLrp)}
+	case *ActualLRPInstanceChangedEvent:
+		return []eventSubject{actualLRPSubject(event.Before), actualLRPSubject(event.After)}
+	case *ActualLRPInstanceRemovedEvent:
+		return []eventSubject{actualLRPSubject(event.ActualLrp)}
+	case *ActualLRPCrashedEvent:
+		return []eventSubject{{
+			domain:      event.ActualLRPKey.Domain,
+			processGuid: event.ActualLRPKey.ProcessGuid,
+			cellID:      event.ActualLRPInstanceKey.CellId,
+		}}
+
+	case *TaskCreatedEvent:
+		return []eventSubject{taskSubject(event.Task)}
+	case *TaskChangedEvent:
+		return []eventSubject{taskSubject(event.Before), taskSubject(event.After)}
+	case *TaskRemovedEvent:
+		return []eventSubject{taskSubject(event.Task)}
+
+	case *CellAppearedEvent:
+		return []eventSubject{{cellID: event.CellPresence.GetCellId()}}
+	case *CellDisappearedEvent:
+		return []eventSubject{{cellID: event.CellPresence.GetCellId()}}
+	}
+
+	return nil
+}
+
+func desiredLRPSubject(desiredLRP *DesiredLRP) eventSubject {
+	return eventSubject{
+		domain:      desiredLRP.GetDomain(),
+		processGuid: desiredLRP.GetProcessGuid(),
+	}
+}
+
+func actualLRPGroupSubjects(group *ActualLRPGroup) []eventSubject {
+	subjects := []eventSubject{}
+	for _, lrp := range []*ActualLRP{group.GetInstance(), group.GetEvacuating()} {
+		if lrp == nil {
+			continue
+		}
+		subjects = append(subjects, actualLRPSubject(lrp))
+	}
+	return subjects
+}
+
+func actualLRPSubject(lrp *ActualLRP) eventSubject {
+	if lrp == nil {
+		return eventSubject{}
+	}
+	return eventSubject{
+		domain:      lrp.Domain,
+		processGuid: lrp.ProcessGuid,
+		cellID:      lrp.CellId,
+	}
+}
+
+func taskSubject(task *Task) eventSubject {
+	return eventSubject{
+		domain: task.GetDomain(),
+		cellID: task.GetCellId(),
+	}
+}
+
+func isEventType(eventType string) bool {
+	return NewEventOfType(eventType) != nil
+}
+
+// NewEventOfType returns an empty event of the given type to decode into, or
+// nil if the type is unknown.
+func NewEventOfType(eventType string) Event {
+	switch eventType {
+	case EventTypeDesiredLRPCreated:
+		return new(DesiredLRPCreatedEvent)
+	case EventTypeDesiredLRPChanged:
+		return new(DesiredLRPChangedEvent)
+	case EventTypeDesiredLRPRemoved:
+		return new(DesiredLRPRemovedEvent)
+	case EventTypeActualLRPCreated:
+		return new(ActualLRPCreatedEvent)
+	case EventTypeActualLRPChanged:
+		return new(ActualLRPChangedEvent)
+	case EventTypeActualLRPRemoved:
+		return new(ActualLRPRemovedEvent)
+	case EventTypeActualLRPCrashed:
+		return new(ActualLRPCrashedEvent)
+	case EventTypeActualLRPInstanceCreated:
+		return new(ActualLRPInstanceCreatedEvent)
+	case EventTypeActualLRPInstanceChanged:
+		return new(ActualLRPInstanceChangedEvent)
+	case EventTypeActualLRPInstanceRemoved:
+		return new(ActualLRPInstanceRemovedEvent)
+	case EventTypeTaskCreated:
+		return new(TaskCreatedEvent)
+	case EventTypeTaskChanged:
+		return new(TaskChangedEvent)
+	case EventTypeTaskRemoved:
+		return new(TaskRemovedEvent)
+	case EventTypeCellAppeared:
+		return new(CellAppearedEvent)
+	case EventTypeCellDisappeared:
+		return new(CellDisappearedEvent)
+	}
+	return nil
+}
+
 func VersionDesiredLRPsToV0(event Event) Event {
 	switch event := event.(type) {
 	case *DesiredLRPCreatedEvent:
@@ -150,3 +357,171 @@ func (event *ActualLRPCreatedEvent) Key() string {
 	actualLRP, _ := event.ActualLrpGroup.Resolve()
 	return actualLRP.GetInstanceGuid()
 }
+
+// ActualLRPInstanceEvents translates an ActualLRP group event into events
+// about the individual ActualLRPs in the group, one for each member that was
+// created, changed or removed. Crashed events, which are already about an
+// individual ActualLRP, are returned as they are; other events are dropped.
+func ActualLRPInstanceEvents(event Event) []Event {
+	switch event := event.(type) {
+	case *ActualLRPCreatedEvent:
+		return actualLRPInstanceEvents(&ActualLRPGroup{}, event.ActualLrpGroup)
+	case *ActualLRPChangedEvent:
+		return actualLRPInstanceEvents(event.Before, event.After)
+	case *ActualLRPRemovedEvent:
+		return actualLRPInstanceEvents(event.ActualLrpGroup, &ActualLRPGroup{})
+	case *ActualLRPCrashedEvent:
+		return []Event{event}
+	}
+	return []Event{}
+}
+
+func actualLRPInstanceEvents(before, after *ActualLRPGroup) []Event {
+	instanceEvents := []Event{}
+
+	pairs := []struct {
+		before, after *ActualLRP
+		presence      ActualLRP_Presence
+	}{
+		{before.GetInstance(), after.GetInstance(), ActualLRP_Ordinary},
+		{before.GetEvacuating(), after.GetEvacuating(), ActualLRP_Evacuating},
+	}
+
+	for _, pair := range pairs {
+		beforeLRP := pair.before.WithPresence(pair.presence)
+		afterLRP := pair.after.WithPresence(pair.presence)
+
+		switch {
+		case beforeLRP == nil && afterLRP != nil:
+			instanceEvents = append(instanceEvents, NewActualLRPInstanceCreatedEvent(afterLRP))
+		case beforeLRP != nil && afterLRP == nil:
+			instanceEvents = append(instanceEvents, NewActualLRPInstanceRemovedEvent(beforeLRP))
+		case beforeLRP != nil && afterLRP != nil:
+			instanceEvents = append(instanceEvents, NewActualLRPInstanceChangedEvent(beforeLRP, afterLRP))
+		}
+	}
+
+	return instanceEvents
+}
+
+func NewActualLRPInstanceCreatedEvent(actualLRP *ActualLRP) *ActualLRPInstanceCreatedEvent {
+	return &ActualLRPInstanceCreatedEvent{
+		ActualLrp: actualLRP,
+	}
+}
+
+func (event *ActualLRPInstanceCreatedEvent) EventType() string {
+	return EventTypeActualLRPInstanceCreated
+}
+
+func (event *ActualLRPInstanceCreatedEvent) Key() string {
+	return event.ActualLrp.GetInstanceGuid()
+}
+
+func NewActualLRPInstanceChangedEvent(before, after *ActualLRP) *ActualLRPInstanceChangedEvent {
+	return &ActualLRPInstanceChangedEvent{
+		Before: before,
+		After:  after,
+	}
+}
+
+func (event *ActualLRPInstanceChangedEvent) EventType() string {
+	return EventTypeActualLRPInstanceChanged
+}
+
+func (event *ActualLRPInstanceChangedEvent) Key() string {
+	return event.Before.GetInstanceGuid()
+}
+
+func NewActualLRPInstanceRemovedEvent(actualLRP *ActualLRP) *ActualLRPInstanceRemovedEvent {
+	return &ActualLRPInstanceRemovedEvent{
+		ActualLrp: actualLRP,
+	}
+}
+
+func (event *ActualLRPInstanceRemovedEvent) EventType() string {
+	return EventTypeActualLRPInstanceRemoved
+}
+
+func (event *ActualLRPInstanceRemovedEvent) Key() string {
+	return event.ActualLrp.GetInstanceGuid()
+}
+
+func NewTaskCreatedEvent(task *Task) *TaskCreatedEvent {
+	return &TaskCreatedEvent{
+		Task: task,
+	}
+}
+
+func (event *TaskCreatedEvent) EventType() string {
+	return EventTypeTaskCreated
+}
+
+func (event *TaskCreatedEvent) Key() string {
+	return event.Task.GetTaskGuid()
+}
+
+func NewTaskChangedEvent(before, after *Task) *TaskChangedEvent {
+	return &TaskChangedEvent{
+		Before: before,
+		After:  after,
+	}
+}
+
+func (event *TaskChangedEvent) EventType() string {
+	return EventTypeTaskChanged
+}
+
+func (event *TaskChangedEvent) Key() string {
+	return event.Before.GetTaskGuid()
+}
+
+func NewTaskRemovedEvent(task *Task) *TaskRemovedEvent {
+	return &TaskRemovedEvent{
+		Task: task,
+	}
+}
+
+func (event *TaskRemovedEvent) EventType() string {
+	return EventTypeTaskRemoved
+}
+
+func (event *TaskRemovedEvent) Key() string {
+	return event.Task.GetTaskGuid()
+}
+
+func NewCellAppearedEvent(cellPresence *CellPresence) *CellAppearedEvent {
+	return &CellAppearedEvent{
+		CellPresence: cellPresence,
+	}
+}
+
+func (event *CellAppearedEvent) EventType() string {
+	return EventTypeCellAppeared
+}
+
+func (event *CellAppearedEvent) Key() string {
+	return event.CellPresence.GetCellId()
+}
+
+func (event *CellAppearedEvent) CellIDs() []string {
+	return []string{event.CellPresence.GetCellId()}
+}
+
+func NewCellDisappearedEvent(cellPresence *CellPresence) *CellDisappearedEvent {
+	return &CellDisappearedEvent{
+		CellPresence: cellPresence,
+	}
+}
+
+func (event *CellDisappearedEvent) EventType() string {
+	return EventTypeCellDisappeared
+}
+
+func (event *CellDisappearedEvent) Key() string {
+	return event.CellPresence.GetCellId()
+}
+
+func (event *CellDisappearedEvent) CellIDs() []string {
+	return []string{event.CellPresence.GetCellId()}
+}
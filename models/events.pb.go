@@ -9,6 +9,8 @@ import math "math"
 
 // discarding unused import gogoproto "github.com/gogo/protobuf/gogoproto"
 
+import bytes "bytes"
+
 import fmt "fmt"
 import strings "strings"
 import github_com_gogo_protobuf_proto "github.com/gogo/protobuf/proto"
@@ -72,6 +74,56 @@ func (m *ActualLRPRemovedEvent) GetActualLrpGroup() *ActualLRPGroup {
 	return nil
 }
 
+type ActualLRPInstanceCreatedEvent struct {
+	ActualLrp *ActualLRP `protobuf:"bytes,1,opt,name=actual_lrp" json:"actual_lrp,omitempty"`
+}
+
+func (m *ActualLRPInstanceCreatedEvent) Reset()      { *m = ActualLRPInstanceCreatedEvent{} }
+func (*ActualLRPInstanceCreatedEvent) ProtoMessage() {}
+
+func (m *ActualLRPInstanceCreatedEvent) GetActualLrp() *ActualLRP {
+	if m != nil {
+		return m.ActualLrp
+	}
+	return nil
+}
+
+type ActualLRPInstanceChangedEvent struct {
+	Before *ActualLRP `protobuf:"bytes,1,opt,name=before" json:"before,omitempty"`
+	After  *ActualLRP `protobuf:"bytes,2,opt,name=after" json:"after,omitempty"`
+}
+
+func (m *ActualLRPInstanceChangedEvent) Reset()      { *m = ActualLRPInstanceChangedEvent{} }
+func (*ActualLRPInstanceChangedEvent) ProtoMessage() {}
+
+func (m *ActualLRPInstanceChangedEvent) GetBefore() *ActualLRP {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *ActualLRPInstanceChangedEvent) GetAfter() *ActualLRP {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+type ActualLRPInstanceRemovedEvent struct {
+	ActualLrp *ActualLRP `protobuf:"bytes,1,opt,name=actual_lrp" json:"actual_lrp,omitempty"`
+}
+
+func (m *ActualLRPInstanceRemovedEvent) Reset()      { *m = ActualLRPInstanceRemovedEvent{} }
+func (*ActualLRPInstanceRemovedEvent) ProtoMessage() {}
+
+func (m *ActualLRPInstanceRemovedEvent) GetActualLrp() *ActualLRP {
+	if m != nil {
+		return m.ActualLrp
+	}
+	return nil
+}
+
 type DesiredLRPCreatedEvent struct {
 	DesiredLrp *DesiredLRP `protobuf:"bytes,1,opt,name=desired_lrp" json:"desired_lrp,omitempty"`
 }
@@ -154,6 +206,114 @@ func (m *ActualLRPCrashedEvent) GetSince() int64 {
 	return 0
 }
 
+type TaskCreatedEvent struct {
+	Task *Task `protobuf:"bytes,1,opt,name=task" json:"task,omitempty"`
+}
+
+func (m *TaskCreatedEvent) Reset()      { *m = TaskCreatedEvent{} }
+func (*TaskCreatedEvent) ProtoMessage() {}
+
+func (m *TaskCreatedEvent) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+type TaskChangedEvent struct {
+	Before *Task `protobuf:"bytes,1,opt,name=before" json:"before,omitempty"`
+	After  *Task `protobuf:"bytes,2,opt,name=after" json:"after,omitempty"`
+}
+
+func (m *TaskChangedEvent) Reset()      { *m = TaskChangedEvent{} }
+func (*TaskChangedEvent) ProtoMessage() {}
+
+func (m *TaskChangedEvent) GetBefore() *Task {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *TaskChangedEvent) GetAfter() *Task {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+type TaskRemovedEvent struct {
+	Task *Task `protobuf:"bytes,1,opt,name=task" json:"task,omitempty"`
+}
+
+func (m *TaskRemovedEvent) Reset()      { *m = TaskRemovedEvent{} }
+func (*TaskRemovedEvent) ProtoMessage() {}
+
+func (m *TaskRemovedEvent) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+type CellAppearedEvent struct {
+	CellPresence *CellPresence `protobuf:"bytes,1,opt,name=cell_presence" json:"cell_presence,omitempty"`
+}
+
+func (m *CellAppearedEvent) Reset()      { *m = CellAppearedEvent{} }
+func (*CellAppearedEvent) ProtoMessage() {}
+
+func (m *CellAppearedEvent) GetCellPresence() *CellPresence {
+	if m != nil {
+		return m.CellPresence
+	}
+	return nil
+}
+
+type CellDisappearedEvent struct {
+	CellPresence *CellPresence `protobuf:"bytes,1,opt,name=cell_presence" json:"cell_presence,omitempty"`
+}
+
+func (m *CellDisappearedEvent) Reset()      { *m = CellDisappearedEvent{} }
+func (*CellDisappearedEvent) ProtoMessage() {}
+
+func (m *CellDisappearedEvent) GetCellPresence() *CellPresence {
+	if m != nil {
+		return m.CellPresence
+	}
+	return nil
+}
+
+type EventFrame struct {
+	Id      uint64 `protobuf:"varint,1,opt,name=id" json:"id"`
+	Type    string `protobuf:"bytes,2,opt,name=type" json:"type"`
+	Payload []byte `protobuf:"bytes,3,opt,name=payload" json:"payload"`
+}
+
+func (m *EventFrame) Reset()      { *m = EventFrame{} }
+func (*EventFrame) ProtoMessage() {}
+
+func (m *EventFrame) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *EventFrame) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *EventFrame) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
 func (this *ActualLRPCreatedEvent) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -232,6 +392,84 @@ func (this *ActualLRPRemovedEvent) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ActualLRPInstanceCreatedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ActualLRPInstanceCreatedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.ActualLrp.Equal(that1.ActualLrp) {
+		return false
+	}
+	return true
+}
+func (this *ActualLRPInstanceChangedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ActualLRPInstanceChangedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Before.Equal(that1.Before) {
+		return false
+	}
+	if !this.After.Equal(that1.After) {
+		return false
+	}
+	return true
+}
+func (this *ActualLRPInstanceRemovedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ActualLRPInstanceRemovedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.ActualLrp.Equal(that1.ActualLrp) {
+		return false
+	}
+	return true
+}
 func (this *DesiredLRPCreatedEvent) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -347,71 +585,306 @@ func (this *ActualLRPCrashedEvent) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *ActualLRPCreatedEvent) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *TaskCreatedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := strings.Join([]string{`&models.ActualLRPCreatedEvent{` +
-		`ActualLrpGroup:` + fmt.Sprintf("%#v", this.ActualLrpGroup) + `}`}, ", ")
-	return s
-}
-func (this *ActualLRPChangedEvent) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*TaskCreatedEvent)
+	if !ok {
+		return false
 	}
-	s := strings.Join([]string{`&models.ActualLRPChangedEvent{` +
-		`Before:` + fmt.Sprintf("%#v", this.Before),
-		`After:` + fmt.Sprintf("%#v", this.After) + `}`}, ", ")
-	return s
-}
-func (this *ActualLRPRemovedEvent) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&models.ActualLRPRemovedEvent{` +
-		`ActualLrpGroup:` + fmt.Sprintf("%#v", this.ActualLrpGroup) + `}`}, ", ")
-	return s
-}
-func (this *DesiredLRPCreatedEvent) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Task.Equal(that1.Task) {
+		return false
 	}
-	s := strings.Join([]string{`&models.DesiredLRPCreatedEvent{` +
-		`DesiredLrp:` + fmt.Sprintf("%#v", this.DesiredLrp) + `}`}, ", ")
-	return s
+	return true
 }
-func (this *DesiredLRPChangedEvent) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *TaskChangedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := strings.Join([]string{`&models.DesiredLRPChangedEvent{` +
-		`Before:` + fmt.Sprintf("%#v", this.Before),
-		`After:` + fmt.Sprintf("%#v", this.After) + `}`}, ", ")
-	return s
-}
-func (this *DesiredLRPRemovedEvent) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*TaskChangedEvent)
+	if !ok {
+		return false
 	}
-	s := strings.Join([]string{`&models.DesiredLRPRemovedEvent{` +
-		`DesiredLrp:` + fmt.Sprintf("%#v", this.DesiredLrp) + `}`}, ", ")
-	return s
-}
-func (this *ActualLRPCrashedEvent) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&models.ActualLRPCrashedEvent{` +
-		`ActualLRPKey:` + strings.Replace(this.ActualLRPKey.GoString(), `&`, ``, 1),
-		`ActualLRPInstanceKey:` + strings.Replace(this.ActualLRPInstanceKey.GoString(), `&`, ``, 1),
-		`CrashCount:` + fmt.Sprintf("%#v", this.CrashCount),
-		`CrashReason:` + fmt.Sprintf("%#v", this.CrashReason),
-		`Since:` + fmt.Sprintf("%#v", this.Since) + `}`}, ", ")
-	return s
-}
-func valueToGoStringEvents(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+	if !this.Before.Equal(that1.Before) {
+		return false
+	}
+	if !this.After.Equal(that1.After) {
+		return false
+	}
+	return true
+}
+func (this *TaskRemovedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskRemovedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Task.Equal(that1.Task) {
+		return false
+	}
+	return true
+}
+func (this *CellAppearedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*CellAppearedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.CellPresence.Equal(that1.CellPresence) {
+		return false
+	}
+	return true
+}
+func (this *CellDisappearedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*CellDisappearedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.CellPresence.Equal(that1.CellPresence) {
+		return false
+	}
+	return true
+}
+func (this *EventFrame) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*EventFrame)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.Id != that1.Id {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	return true
+}
+func (this *ActualLRPCreatedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPCreatedEvent{` +
+		`ActualLrpGroup:` + fmt.Sprintf("%#v", this.ActualLrpGroup) + `}`}, ", ")
+	return s
+}
+func (this *ActualLRPChangedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPChangedEvent{` +
+		`Before:` + fmt.Sprintf("%#v", this.Before),
+		`After:` + fmt.Sprintf("%#v", this.After) + `}`}, ", ")
+	return s
+}
+func (this *ActualLRPRemovedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPRemovedEvent{` +
+		`ActualLrpGroup:` + fmt.Sprintf("%#v", this.ActualLrpGroup) + `}`}, ", ")
+	return s
+}
+func (this *ActualLRPInstanceCreatedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPInstanceCreatedEvent{` +
+		`ActualLrp:` + fmt.Sprintf("%#v", this.ActualLrp) + `}`}, ", ")
+	return s
+}
+func (this *ActualLRPInstanceChangedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPInstanceChangedEvent{` +
+		`Before:` + fmt.Sprintf("%#v", this.Before),
+		`After:` + fmt.Sprintf("%#v", this.After) + `}`}, ", ")
+	return s
+}
+func (this *ActualLRPInstanceRemovedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPInstanceRemovedEvent{` +
+		`ActualLrp:` + fmt.Sprintf("%#v", this.ActualLrp) + `}`}, ", ")
+	return s
+}
+func (this *DesiredLRPCreatedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.DesiredLRPCreatedEvent{` +
+		`DesiredLrp:` + fmt.Sprintf("%#v", this.DesiredLrp) + `}`}, ", ")
+	return s
+}
+func (this *DesiredLRPChangedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.DesiredLRPChangedEvent{` +
+		`Before:` + fmt.Sprintf("%#v", this.Before),
+		`After:` + fmt.Sprintf("%#v", this.After) + `}`}, ", ")
+	return s
+}
+func (this *DesiredLRPRemovedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.DesiredLRPRemovedEvent{` +
+		`DesiredLrp:` + fmt.Sprintf("%#v", this.DesiredLrp) + `}`}, ", ")
+	return s
+}
+func (this *ActualLRPCrashedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPCrashedEvent{` +
+		`ActualLRPKey:` + strings.Replace(this.ActualLRPKey.GoString(), `&`, ``, 1),
+		`ActualLRPInstanceKey:` + strings.Replace(this.ActualLRPInstanceKey.GoString(), `&`, ``, 1),
+		`CrashCount:` + fmt.Sprintf("%#v", this.CrashCount),
+		`CrashReason:` + fmt.Sprintf("%#v", this.CrashReason),
+		`Since:` + fmt.Sprintf("%#v", this.Since) + `}`}, ", ")
+	return s
+}
+func (this *TaskCreatedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskCreatedEvent{` +
+		`Task:` + fmt.Sprintf("%#v", this.Task) + `}`}, ", ")
+	return s
+}
+func (this *TaskChangedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskChangedEvent{` +
+		`Before:` + fmt.Sprintf("%#v", this.Before),
+		`After:` + fmt.Sprintf("%#v", this.After) + `}`}, ", ")
+	return s
+}
+func (this *TaskRemovedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskRemovedEvent{` +
+		`Task:` + fmt.Sprintf("%#v", this.Task) + `}`}, ", ")
+	return s
+}
+func (this *CellAppearedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.CellAppearedEvent{` +
+		`CellPresence:` + fmt.Sprintf("%#v", this.CellPresence) + `}`}, ", ")
+	return s
+}
+func (this *CellDisappearedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.CellDisappearedEvent{` +
+		`CellPresence:` + fmt.Sprintf("%#v", this.CellPresence) + `}`}, ", ")
+	return s
+}
+func (this *EventFrame) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.EventFrame{` +
+		`Id:` + fmt.Sprintf("%#v", this.Id),
+		`Type:` + fmt.Sprintf("%#v", this.Type),
+		`Payload:` + fmt.Sprintf("%#v", this.Payload) + `}`}, ", ")
+	return s
+}
+func valueToGoStringEvents(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
 		return "nil"
 	}
 	pv := reflect.Indirect(rv).Interface()
@@ -528,7 +1001,7 @@ func (m *ActualLRPRemovedEvent) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DesiredLRPCreatedEvent) Marshal() (data []byte, err error) {
+func (m *ActualLRPInstanceCreatedEvent) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -538,16 +1011,16 @@ func (m *DesiredLRPCreatedEvent) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DesiredLRPCreatedEvent) MarshalTo(data []byte) (int, error) {
+func (m *ActualLRPInstanceCreatedEvent) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.DesiredLrp != nil {
+	if m.ActualLrp != nil {
 		data[i] = 0xa
 		i++
-		i = encodeVarintEvents(data, i, uint64(m.DesiredLrp.Size()))
-		n5, err := m.DesiredLrp.MarshalTo(data[i:])
+		i = encodeVarintEvents(data, i, uint64(m.ActualLrp.Size()))
+		n5, err := m.ActualLrp.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
@@ -556,7 +1029,7 @@ func (m *DesiredLRPCreatedEvent) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DesiredLRPChangedEvent) Marshal() (data []byte, err error) {
+func (m *ActualLRPInstanceChangedEvent) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -566,7 +1039,7 @@ func (m *DesiredLRPChangedEvent) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DesiredLRPChangedEvent) MarshalTo(data []byte) (int, error) {
+func (m *ActualLRPInstanceChangedEvent) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -594,7 +1067,7 @@ func (m *DesiredLRPChangedEvent) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DesiredLRPRemovedEvent) Marshal() (data []byte, err error) {
+func (m *ActualLRPInstanceRemovedEvent) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -604,16 +1077,16 @@ func (m *DesiredLRPRemovedEvent) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DesiredLRPRemovedEvent) MarshalTo(data []byte) (int, error) {
+func (m *ActualLRPInstanceRemovedEvent) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.DesiredLrp != nil {
+	if m.ActualLrp != nil {
 		data[i] = 0xa
 		i++
-		i = encodeVarintEvents(data, i, uint64(m.DesiredLrp.Size()))
-		n8, err := m.DesiredLrp.MarshalTo(data[i:])
+		i = encodeVarintEvents(data, i, uint64(m.ActualLrp.Size()))
+		n8, err := m.ActualLrp.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
@@ -622,7 +1095,7 @@ func (m *DesiredLRPRemovedEvent) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ActualLRPCrashedEvent) Marshal() (data []byte, err error) {
+func (m *DesiredLRPCreatedEvent) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -632,37 +1105,312 @@ func (m *ActualLRPCrashedEvent) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *ActualLRPCrashedEvent) MarshalTo(data []byte) (int, error) {
+func (m *DesiredLRPCreatedEvent) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	data[i] = 0xa
-	i++
-	i = encodeVarintEvents(data, i, uint64(m.ActualLRPKey.Size()))
-	n9, err := m.ActualLRPKey.MarshalTo(data[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n9
-	data[i] = 0x12
-	i++
-	i = encodeVarintEvents(data, i, uint64(m.ActualLRPInstanceKey.Size()))
-	n10, err := m.ActualLRPInstanceKey.MarshalTo(data[i:])
-	if err != nil {
-		return 0, err
+	if m.DesiredLrp != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.DesiredLrp.Size()))
+		n9, err := m.DesiredLrp.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	return i, nil
+}
+
+func (m *DesiredLRPChangedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DesiredLRPChangedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Before != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.Before.Size()))
+		n10, err := m.Before.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.After != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.After.Size()))
+		n11, err := m.After.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	return i, nil
+}
+
+func (m *DesiredLRPRemovedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DesiredLRPRemovedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.DesiredLrp != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.DesiredLrp.Size()))
+		n12, err := m.DesiredLrp.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	return i, nil
+}
+
+func (m *ActualLRPCrashedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ActualLRPCrashedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintEvents(data, i, uint64(m.ActualLRPKey.Size()))
+	n13, err := m.ActualLRPKey.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n13
+	data[i] = 0x12
+	i++
+	i = encodeVarintEvents(data, i, uint64(m.ActualLRPInstanceKey.Size()))
+	n14, err := m.ActualLRPInstanceKey.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n14
+	data[i] = 0x18
+	i++
+	i = encodeVarintEvents(data, i, uint64(m.CrashCount))
+	data[i] = 0x22
+	i++
+	i = encodeVarintEvents(data, i, uint64(len(m.CrashReason)))
+	i += copy(data[i:], m.CrashReason)
+	data[i] = 0x28
+	i++
+	i = encodeVarintEvents(data, i, uint64(m.Since))
+	return i, nil
+}
+
+func (m *TaskCreatedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskCreatedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Task != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.Task.Size()))
+		n15, err := m.Task.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	return i, nil
+}
+
+func (m *TaskChangedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskChangedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Before != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.Before.Size()))
+		n16, err := m.Before.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.After != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.After.Size()))
+		n17, err := m.After.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	return i, nil
+}
+
+func (m *TaskRemovedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskRemovedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Task != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.Task.Size()))
+		n18, err := m.Task.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	return i, nil
+}
+
+func (m *CellAppearedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *CellAppearedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.CellPresence != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.CellPresence.Size()))
+		n19, err := m.CellPresence.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	return i, nil
+}
+
+func (m *CellDisappearedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *CellDisappearedEvent) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.CellPresence != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.CellPresence.Size()))
+		n20, err := m.CellPresence.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	return i, nil
+}
+
+func (m *EventFrame) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *EventFrame) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintEvents(data, i, uint64(m.Id))
+	data[i] = 0x12
+	i++
+	i = encodeVarintEvents(data, i, uint64(len(m.Type)))
+	i += copy(data[i:], m.Type)
+	if m.Payload != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintEvents(data, i, uint64(len(m.Payload)))
+		i += copy(data[i:], m.Payload)
 	}
-	i += n10
-	data[i] = 0x18
-	i++
-	i = encodeVarintEvents(data, i, uint64(m.CrashCount))
-	data[i] = 0x22
-	i++
-	i = encodeVarintEvents(data, i, uint64(len(m.CrashReason)))
-	i += copy(data[i:], m.CrashReason)
-	data[i] = 0x28
-	i++
-	i = encodeVarintEvents(data, i, uint64(m.Since))
 	return i, nil
 }
 
@@ -727,17 +1475,17 @@ func (m *ActualLRPRemovedEvent) Size() (n int) {
 	return n
 }
 
-func (m *DesiredLRPCreatedEvent) Size() (n int) {
+func (m *ActualLRPInstanceCreatedEvent) Size() (n int) {
 	var l int
 	_ = l
-	if m.DesiredLrp != nil {
-		l = m.DesiredLrp.Size()
+	if m.ActualLrp != nil {
+		l = m.ActualLrp.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func (m *DesiredLRPChangedEvent) Size() (n int) {
+func (m *ActualLRPInstanceChangedEvent) Size() (n int) {
 	var l int
 	_ = l
 	if m.Before != nil {
@@ -751,7 +1499,17 @@ func (m *DesiredLRPChangedEvent) Size() (n int) {
 	return n
 }
 
-func (m *DesiredLRPRemovedEvent) Size() (n int) {
+func (m *ActualLRPInstanceRemovedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.ActualLrp != nil {
+		l = m.ActualLrp.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *DesiredLRPCreatedEvent) Size() (n int) {
 	var l int
 	_ = l
 	if m.DesiredLrp != nil {
@@ -761,7 +1519,31 @@ func (m *DesiredLRPRemovedEvent) Size() (n int) {
 	return n
 }
 
-func (m *ActualLRPCrashedEvent) Size() (n int) {
+func (m *DesiredLRPChangedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *DesiredLRPRemovedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.DesiredLrp != nil {
+		l = m.DesiredLrp.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *ActualLRPCrashedEvent) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ActualLRPKey.Size()
@@ -775,6 +1557,73 @@ func (m *ActualLRPCrashedEvent) Size() (n int) {
 	return n
 }
 
+func (m *TaskCreatedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.Task != nil {
+		l = m.Task.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *TaskChangedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *TaskRemovedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.Task != nil {
+		l = m.Task.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *CellAppearedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.CellPresence != nil {
+		l = m.CellPresence.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *CellDisappearedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.CellPresence != nil {
+		l = m.CellPresence.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *EventFrame) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovEvents(uint64(m.Id))
+	l = len(m.Type)
+	n += 1 + l + sovEvents(uint64(l))
+	if m.Payload != nil {
+		l = len(m.Payload)
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
 func sovEvents(x uint64) (n int) {
 	for {
 		n++
@@ -819,6 +1668,37 @@ func (this *ActualLRPRemovedEvent) String() string {
 	}, "")
 	return s
 }
+func (this *ActualLRPInstanceCreatedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPInstanceCreatedEvent{`,
+		`ActualLrp:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrp), "ActualLRP", "ActualLRP", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActualLRPInstanceChangedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPInstanceChangedEvent{`,
+		`Before:` + strings.Replace(fmt.Sprintf("%v", this.Before), "ActualLRP", "ActualLRP", 1) + `,`,
+		`After:` + strings.Replace(fmt.Sprintf("%v", this.After), "ActualLRP", "ActualLRP", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActualLRPInstanceRemovedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPInstanceRemovedEvent{`,
+		`ActualLrp:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrp), "ActualLRP", "ActualLRP", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *DesiredLRPCreatedEvent) String() string {
 	if this == nil {
 		return "nil"
@@ -864,15 +1744,768 @@ func (this *ActualLRPCrashedEvent) String() string {
 	}, "")
 	return s
 }
+func (this *TaskCreatedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskCreatedEvent{`,
+		`Task:` + strings.Replace(fmt.Sprintf("%v", this.Task), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskChangedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskChangedEvent{`,
+		`Before:` + strings.Replace(fmt.Sprintf("%v", this.Before), "Task", "Task", 1) + `,`,
+		`After:` + strings.Replace(fmt.Sprintf("%v", this.After), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskRemovedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskRemovedEvent{`,
+		`Task:` + strings.Replace(fmt.Sprintf("%v", this.Task), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CellAppearedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CellAppearedEvent{`,
+		`CellPresence:` + strings.Replace(fmt.Sprintf("%v", this.CellPresence), "CellPresence", "CellPresence", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CellDisappearedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CellDisappearedEvent{`,
+		`CellPresence:` + strings.Replace(fmt.Sprintf("%v", this.CellPresence), "CellPresence", "CellPresence", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *EventFrame) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&EventFrame{`,
+		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringEvents(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
 		return "nil"
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ActualLRPCreatedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpGroup", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActualLrpGroup == nil {
+				m.ActualLrpGroup = &ActualLRPGroup{}
+			}
+			if err := m.ActualLrpGroup.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *ActualLRPChangedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &ActualLRPGroup{}
+			}
+			if err := m.Before.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &ActualLRPGroup{}
+			}
+			if err := m.After.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *ActualLRPRemovedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpGroup", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActualLrpGroup == nil {
+				m.ActualLrpGroup = &ActualLRPGroup{}
+			}
+			if err := m.ActualLrpGroup.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *ActualLRPInstanceCreatedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActualLrp == nil {
+				m.ActualLrp = &ActualLRP{}
+			}
+			if err := m.ActualLrp.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *ActualLRPInstanceChangedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &ActualLRP{}
+			}
+			if err := m.Before.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &ActualLRP{}
+			}
+			if err := m.After.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *ActualLRPInstanceRemovedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActualLrp == nil {
+				m.ActualLrp = &ActualLRP{}
+			}
+			if err := m.ActualLrp.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *DesiredLRPCreatedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DesiredLrp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DesiredLrp == nil {
+				m.DesiredLrp = &DesiredLRP{}
+			}
+			if err := m.DesiredLrp.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *DesiredLRPChangedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &DesiredLRP{}
+			}
+			if err := m.Before.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &DesiredLRP{}
+			}
+			if err := m.After.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
 }
-func (m *ActualLRPCreatedEvent) Unmarshal(data []byte) error {
+func (m *DesiredLRPRemovedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -893,7 +2526,7 @@ func (m *ActualLRPCreatedEvent) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpGroup", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DesiredLrp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -914,10 +2547,10 @@ func (m *ActualLRPCreatedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ActualLrpGroup == nil {
-				m.ActualLrpGroup = &ActualLRPGroup{}
+			if m.DesiredLrp == nil {
+				m.DesiredLrp = &DesiredLRP{}
 			}
-			if err := m.ActualLrpGroup.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.DesiredLrp.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -947,7 +2580,7 @@ func (m *ActualLRPCreatedEvent) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *ActualLRPChangedEvent) Unmarshal(data []byte) error {
+func (m *ActualLRPCrashedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -968,7 +2601,7 @@ func (m *ActualLRPChangedEvent) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLRPKey", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -989,16 +2622,13 @@ func (m *ActualLRPChangedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Before == nil {
-				m.Before = &ActualLRPGroup{}
-			}
-			if err := m.Before.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ActualLRPKey.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLRPInstanceKey", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1019,88 +2649,67 @@ func (m *ActualLRPChangedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.After == nil {
-				m.After = &ActualLRPGroup{}
-			}
-			if err := m.After.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ActualLRPInstanceKey.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CrashCount", wireType)
+			}
+			m.CrashCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.CrashCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
 					break
 				}
 			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipEvents(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthEvents
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func (m *ActualLRPRemovedEvent) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpGroup", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CrashReason", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if msglen < 0 {
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
 				return ErrInvalidLengthEvents
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ActualLrpGroup == nil {
-				m.ActualLrpGroup = &ActualLRPGroup{}
+			m.CrashReason = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
 			}
-			if err := m.ActualLrpGroup.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			m.Since = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Since |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -1127,7 +2736,7 @@ func (m *ActualLRPRemovedEvent) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *DesiredLRPCreatedEvent) Unmarshal(data []byte) error {
+func (m *TaskCreatedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1148,7 +2757,7 @@ func (m *DesiredLRPCreatedEvent) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DesiredLrp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Task", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1169,10 +2778,10 @@ func (m *DesiredLRPCreatedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DesiredLrp == nil {
-				m.DesiredLrp = &DesiredLRP{}
+			if m.Task == nil {
+				m.Task = &Task{}
 			}
-			if err := m.DesiredLrp.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Task.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1202,7 +2811,7 @@ func (m *DesiredLRPCreatedEvent) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *DesiredLRPChangedEvent) Unmarshal(data []byte) error {
+func (m *TaskChangedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1245,7 +2854,7 @@ func (m *DesiredLRPChangedEvent) Unmarshal(data []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Before == nil {
-				m.Before = &DesiredLRP{}
+				m.Before = &Task{}
 			}
 			if err := m.Before.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
@@ -1275,7 +2884,7 @@ func (m *DesiredLRPChangedEvent) Unmarshal(data []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.After == nil {
-				m.After = &DesiredLRP{}
+				m.After = &Task{}
 			}
 			if err := m.After.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
@@ -1307,7 +2916,7 @@ func (m *DesiredLRPChangedEvent) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *DesiredLRPRemovedEvent) Unmarshal(data []byte) error {
+func (m *TaskRemovedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1328,7 +2937,7 @@ func (m *DesiredLRPRemovedEvent) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DesiredLrp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Task", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1349,10 +2958,10 @@ func (m *DesiredLRPRemovedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DesiredLrp == nil {
-				m.DesiredLrp = &DesiredLRP{}
+			if m.Task == nil {
+				m.Task = &Task{}
 			}
-			if err := m.DesiredLrp.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Task.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1382,7 +2991,7 @@ func (m *DesiredLRPRemovedEvent) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *ActualLRPCrashedEvent) Unmarshal(data []byte) error {
+func (m *CellAppearedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1403,7 +3012,7 @@ func (m *ActualLRPCrashedEvent) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActualLRPKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CellPresence", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1424,13 +3033,61 @@ func (m *ActualLRPCrashedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ActualLRPKey.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if m.CellPresence == nil {
+				m.CellPresence = &CellPresence{}
+			}
+			if err := m.CellPresence.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *CellDisappearedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActualLRPInstanceKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CellPresence", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1451,29 +3108,77 @@ func (m *ActualLRPCrashedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ActualLRPInstanceKey.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if m.CellPresence == nil {
+				m.CellPresence = &CellPresence{}
+			}
+			if err := m.CellPresence.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *EventFrame) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CrashCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			m.CrashCount = 0
+			m.Id = 0
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.CrashCount |= (int32(b) & 0x7F) << shift
+				m.Id |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CrashReason", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1494,24 +3199,33 @@ func (m *ActualLRPCrashedEvent) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CrashReason = string(data[iNdEx:postIndex])
+			m.Type = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			m.Since = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.Since |= (int64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
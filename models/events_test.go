@@ -0,0 +1,262 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Events", func() {
+	Describe("EventFilter", func() {
+		var (
+			desiredLRP *models.DesiredLRP
+			actualLRP  *models.ActualLRP
+			task       *models.Task
+		)
+
+		BeforeEach(func() {
+			desiredLRP = model_helpers.NewValidDesiredLRP("process-guid")
+			actualLRP = model_helpers.NewValidActualLRP("process-guid", 0)
+			task = model_helpers.NewValidTask("task-guid")
+		})
+
+		Describe("Validate", func() {
+			It("accepts known event types", func() {
+				filter := models.EventFilter{
+					EventTypes: []string{models.EventTypeDesiredLRPCreated, models.EventTypeTaskRemoved},
+				}
+				Expect(filter.Validate()).To(Succeed())
+			})
+
+			It("rejects unknown event types", func() {
+				filter := models.EventFilter{EventTypes: []string{"bogus"}}
+				Expect(filter.Validate()).To(ConsistOf(models.ErrInvalidField{"event_types"}))
+			})
+		})
+
+		Describe("Matches", func() {
+			Context("when the filter is empty", func() {
+				It("matches every event", func() {
+					filter := models.EventFilter{}
+					Expect(filter.Matches(models.NewDesiredLRPCreatedEvent(desiredLRP))).To(BeTrue())
+					Expect(filter.Matches(models.NewTaskRemovedEvent(task))).To(BeTrue())
+				})
+			})
+
+			Context("when filtering by event type", func() {
+				It("matches only those event types", func() {
+					filter := models.EventFilter{EventTypes: []string{models.EventTypeDesiredLRPRemoved}}
+					Expect(filter.Matches(models.NewDesiredLRPRemovedEvent(desiredLRP))).To(BeTrue())
+					Expect(filter.Matches(models.NewDesiredLRPCreatedEvent(desiredLRP))).To(BeFalse())
+				})
+			})
+
+			Context("when filtering by domain", func() {
+				var filter models.EventFilter
+
+				BeforeEach(func() {
+					filter = models.EventFilter{Domain: "some-domain"}
+				})
+
+				It("matches events in the domain", func() {
+					group := &models.ActualLRPGroup{Instance: actualLRP}
+					Expect(filter.Matches(models.NewDesiredLRPCreatedEvent(desiredLRP))).To(BeTrue())
+					Expect(filter.Matches(models.NewActualLRPCreatedEvent(group))).To(BeTrue())
+					Expect(filter.Matches(models.NewTaskCreatedEvent(task))).To(BeTrue())
+				})
+
+				It("does not match events in other domains", func() {
+					desiredLRP.Domain = "other-domain"
+					task.Domain = "other-domain"
+					Expect(filter.Matches(models.NewDesiredLRPCreatedEvent(desiredLRP))).To(BeFalse())
+					Expect(filter.Matches(models.NewTaskCreatedEvent(task))).To(BeFalse())
+				})
+			})
+
+			Context("when filtering by process guid", func() {
+				var filter models.EventFilter
+
+				BeforeEach(func() {
+					filter = models.EventFilter{ProcessGuids: []string{"other-guid", "process-guid"}}
+				})
+
+				It("matches lrp events for any of the process guids", func() {
+					crashed := models.NewActualLRPCrashedEvent(actualLRP)
+					Expect(filter.Matches(models.NewDesiredLRPRemovedEvent(desiredLRP))).To(BeTrue())
+					Expect(filter.Matches(crashed)).To(BeTrue())
+				})
+
+				It("does not match lrp events for other process guids", func() {
+					Expect(filter.Matches(models.NewDesiredLRPRemovedEvent(model_helpers.NewValidDesiredLRP("another-guid")))).To(BeFalse())
+				})
+
+				It("does not match task events", func() {
+					Expect(filter.Matches(models.NewTaskCreatedEvent(task))).To(BeFalse())
+				})
+			})
+
+			Context("when filtering by cell id", func() {
+				var filter models.EventFilter
+
+				BeforeEach(func() {
+					filter = models.EventFilter{CellID: "some-cell"}
+				})
+
+				It("matches actual lrps on the cell", func() {
+					group := &models.ActualLRPGroup{Instance: actualLRP}
+					Expect(filter.Matches(models.NewActualLRPRemovedEvent(group))).To(BeTrue())
+				})
+
+				It("matches evacuating actual lrps on the cell", func() {
+					evacuating := model_helpers.NewValidActualLRP("process-guid", 0)
+					actualLRP.CellId = "other-cell"
+					group := &models.ActualLRPGroup{Instance: actualLRP, Evacuating: evacuating}
+					Expect(filter.Matches(models.NewActualLRPRemovedEvent(group))).To(BeTrue())
+				})
+
+				It("matches actual lrps moving off the cell", func() {
+					before := &models.ActualLRPGroup{Instance: model_helpers.NewValidActualLRP("process-guid", 0)}
+					actualLRP.CellId = "other-cell"
+					after := &models.ActualLRPGroup{Instance: actualLRP}
+					Expect(filter.Matches(models.NewActualLRPChangedEvent(before, after))).To(BeTrue())
+				})
+
+				It("matches tasks on the cell", func() {
+					task.CellId = "some-cell"
+					Expect(filter.Matches(models.NewTaskChangedEvent(task, task))).To(BeTrue())
+				})
+
+				It("does not match events on other cells", func() {
+					actualLRP.CellId = "other-cell"
+					group := &models.ActualLRPGroup{Instance: actualLRP}
+					Expect(filter.Matches(models.NewActualLRPCreatedEvent(group))).To(BeFalse())
+					Expect(filter.Matches(models.NewTaskCreatedEvent(task))).To(BeFalse())
+				})
+
+				It("does not match desired lrp events", func() {
+					Expect(filter.Matches(models.NewDesiredLRPCreatedEvent(desiredLRP))).To(BeFalse())
+				})
+			})
+
+			Context("when filtering cell events", func() {
+				var cellPresence *models.CellPresence
+
+				BeforeEach(func() {
+					presence := models.NewCellPresence("some-cell", "cell.example.com", "the-zone", models.NewCellCapacity(128, 1024, 6), nil, nil)
+					cellPresence = &presence
+				})
+
+				It("matches the cell id", func() {
+					filter := models.EventFilter{CellID: "some-cell"}
+					Expect(filter.Matches(models.NewCellAppearedEvent(cellPresence))).To(BeTrue())
+					Expect(filter.Matches(models.NewCellDisappearedEvent(cellPresence))).To(BeTrue())
+
+					filter = models.EventFilter{CellID: "other-cell"}
+					Expect(filter.Matches(models.NewCellAppearedEvent(cellPresence))).To(BeFalse())
+				})
+
+				It("matches the event type", func() {
+					filter := models.EventFilter{EventTypes: []string{models.EventTypeCellDisappeared}}
+					Expect(filter.Validate()).To(Succeed())
+					Expect(filter.Matches(models.NewCellAppearedEvent(cellPresence))).To(BeFalse())
+					Expect(filter.Matches(models.NewCellDisappearedEvent(cellPresence))).To(BeTrue())
+				})
+			})
+
+			Context("when filtering by several fields", func() {
+				It("matches only events that match all of them", func() {
+					filter := models.EventFilter{
+						Domain:     "some-domain",
+						CellID:     "some-cell",
+						EventTypes: []string{models.EventTypeActualLRPCreated},
+					}
+
+					group := &models.ActualLRPGroup{Instance: actualLRP}
+					Expect(filter.Matches(models.NewActualLRPCreatedEvent(group))).To(BeTrue())
+					Expect(filter.Matches(models.NewActualLRPRemovedEvent(group))).To(BeFalse())
+
+					actualLRP.Domain = "other-domain"
+					Expect(filter.Matches(models.NewActualLRPCreatedEvent(group))).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("NewEventOfType", func() {
+		It("returns an empty event of the type", func() {
+			for _, eventType := range []string{
+				models.EventTypeDesiredLRPCreated,
+				models.EventTypeActualLRPInstanceChanged,
+				models.EventTypeTaskRemoved,
+				models.EventTypeCellAppeared,
+			} {
+				event := models.NewEventOfType(eventType)
+				Expect(event).NotTo(BeNil())
+				Expect(event.EventType()).To(Equal(eventType))
+			}
+		})
+
+		It("returns nil for an unknown type", func() {
+			Expect(models.NewEventOfType("bogus")).To(BeNil())
+		})
+	})
+
+	Describe("ActualLRPInstanceEvents", func() {
+		var instanceLRP, evacuatingLRP *models.ActualLRP
+
+		BeforeEach(func() {
+			instanceLRP = model_helpers.NewValidActualLRP("process-guid", 0)
+			evacuatingLRP = model_helpers.NewValidActualLRP("process-guid", 0)
+			evacuatingLRP.CellId = "other-cell"
+		})
+
+		It("translates a created group into created instances", func() {
+			event := models.NewActualLRPCreatedEvent(&models.ActualLRPGroup{Instance: instanceLRP})
+			Expect(models.ActualLRPInstanceEvents(event)).To(Equal([]models.Event{
+				models.NewActualLRPInstanceCreatedEvent(instanceLRP),
+			}))
+		})
+
+		It("translates a removed group into removed instances", func() {
+			event := models.NewActualLRPRemovedEvent(&models.ActualLRPGroup{Instance: instanceLRP, Evacuating: evacuatingLRP})
+			Expect(models.ActualLRPInstanceEvents(event)).To(Equal([]models.Event{
+				models.NewActualLRPInstanceRemovedEvent(instanceLRP),
+				models.NewActualLRPInstanceRemovedEvent(evacuatingLRP.WithPresence(models.ActualLRP_Evacuating)),
+			}))
+		})
+
+		Context("when a group changes", func() {
+			It("translates each member by how it changed", func() {
+				changedLRP := *instanceLRP
+				changedLRP.State = models.ActualLRPStateUnclaimed
+
+				event := models.NewActualLRPChangedEvent(
+					&models.ActualLRPGroup{Instance: instanceLRP},
+					&models.ActualLRPGroup{Instance: &changedLRP, Evacuating: evacuatingLRP},
+				)
+				Expect(models.ActualLRPInstanceEvents(event)).To(Equal([]models.Event{
+					models.NewActualLRPInstanceChangedEvent(instanceLRP, &changedLRP),
+					models.NewActualLRPInstanceCreatedEvent(evacuatingLRP.WithPresence(models.ActualLRP_Evacuating)),
+				}))
+			})
+		})
+
+		It("passes crashed events through", func() {
+			event := models.NewActualLRPCrashedEvent(instanceLRP)
+			Expect(models.ActualLRPInstanceEvents(event)).To(Equal([]models.Event{event}))
+		})
+
+		It("drops other events", func() {
+			event := models.NewDesiredLRPCreatedEvent(model_helpers.NewValidDesiredLRP("process-guid"))
+			Expect(models.ActualLRPInstanceEvents(event)).To(BeEmpty())
+		})
+
+		It("can be filtered like any other event", func() {
+			filter := models.EventFilter{CellID: "other-cell", EventTypes: []string{models.EventTypeActualLRPInstanceCreated}}
+			Expect(filter.Matches(models.NewActualLRPInstanceCreatedEvent(evacuatingLRP))).To(BeTrue())
+			Expect(filter.Matches(models.NewActualLRPInstanceCreatedEvent(instanceLRP))).To(BeFalse())
+			Expect(filter.Matches(models.NewActualLRPInstanceRemovedEvent(evacuatingLRP))).To(BeFalse())
+		})
+	})
+})
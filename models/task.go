@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/format"
 	"github.com/pivotal-golang/lager"
@@ -11,14 +13,115 @@ import (
 
 var taskGuidPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
+// MaxTaskPriority is the highest priority a task can be given. Tasks with a
+// higher priority are sent to the auctioneer ahead of those with a lower one
+// whenever the BBS requests several auctions at once. The auctioneer's start
+// requests do not carry the priority, so it only orders the requests and does
+// not reach placement itself.
+const MaxTaskPriority = 100
+
+// MaxTaskDependencies is the most tasks a task can depend on.
+const MaxTaskDependencies = 32
+
+// MaxTaskAttempts is the most attempts a retry policy can allow a task.
+const MaxTaskAttempts = 10
+
+// MaxRetryBackoff is the longest backoff a retry policy can ask for, well
+// under the time convergence lets a task stay pending by default.
+const MaxRetryBackoff = 10 * time.Minute
+
+// MaxProgressMessageLength is the longest progress message a cell can report
+// for a task.
+const MaxProgressMessageLength = 1024
+
+// MaxCancellationReasonLength is the longest reason a task can be cancelled
+// with.
+const MaxCancellationReasonLength = 1024
+
+// TaskCancelledReason is the failure reason recorded for tasks cancelled
+// without a reason of their own.
+const TaskCancelledReason = "task was cancelled"
+
+// TaskChange is how a write changed a task, as read in the transaction that
+// made it.
 type TaskChange struct {
 	Before *Task
 	After  *Task
 }
 
+// HeartbeatOnly returns true if the change only records that the task was
+// heard from again, without any progress to report. Such changes are too
+// frequent, and too uninteresting, to emit events for.
+func (c *TaskChange) HeartbeatOnly() bool {
+	if c.Before == nil || c.After == nil {
+		return false
+	}
+	before := *c.Before
+	before.LastHeartbeatAt = c.After.LastHeartbeatAt
+	return before.Equal(c.After)
+}
+
+// TaskFilter selects tasks by the fields that are set. UpdatedBefore selects
+// the tasks that last changed state no later than the given time.
 type TaskFilter struct {
-	Domain string
-	CellID string
+	Domain        string
+	CellID        string
+	State         Task_State
+	UpdatedBefore int64
+	MinPriority   int32
+}
+
+// DefaultArchivedTasksLimit is the number of archived tasks returned when no
+// limit is given, and MaxArchivedTasksLimit the most that can be asked for.
+const (
+	DefaultArchivedTasksLimit = 100
+	MaxArchivedTasksLimit     = 1000
+)
+
+// ArchivedTaskFilter selects archived tasks by domain and by when they first
+// completed. A zero Limit means DefaultArchivedTasksLimit.
+type ArchivedTaskFilter struct {
+	Domain         string
+	CompletedSince int64
+	CompletedUntil int64
+	Limit          int
+}
+
+func (filter TaskFilter) Matches(task *Task) bool {
+	if filter.Domain != "" && task.Domain != filter.Domain {
+		return false
+	}
+	if filter.CellID != "" && task.CellId != filter.CellID {
+		return false
+	}
+	if filter.State != Task_Invalid && task.State != filter.State {
+		return false
+	}
+	if filter.UpdatedBefore != 0 && task.UpdatedAt > filter.UpdatedBefore {
+		return false
+	}
+	if task.TaskDefinition.GetPriority() < filter.MinPriority {
+		return false
+	}
+	return true
+}
+
+// SortTasksByPriority orders the tasks for auctioning: highest priority first
+// and, within a priority, oldest first.
+func SortTasksByPriority(tasks []*Task) {
+	sort.Stable(byPriority(tasks))
+}
+
+type byPriority []*Task
+
+func (t byPriority) Len() int      { return len(t) }
+func (t byPriority) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+func (t byPriority) Less(i, j int) bool {
+	iPriority, jPriority := t[i].TaskDefinition.GetPriority(), t[j].TaskDefinition.GetPriority()
+	if iPriority != jPriority {
+		return iPriority > jPriority
+	}
+	return t[i].CreatedAt < t[j].CreatedAt
 }
 
 func (t *Task) Version() format.Version {
@@ -49,6 +152,8 @@ func (task *Task) Validate() error {
 		validationError = validationError.Append(ErrInvalidField{"task_definition"})
 	} else if defErr := task.TaskDefinition.Validate(); defErr != nil {
 		validationError = validationError.Append(defErr)
+	} else if task.DependsOnTask(task.TaskGuid) {
+		validationError = validationError.Append(ErrInvalidField{"depends_on"})
 	}
 
 	if !validationError.Empty() {
@@ -100,6 +205,201 @@ func (t *Task) ValidateTransitionTo(to Task_State) error {
 	return nil
 }
 
+// DeadLetterCallback gives up on delivering the completion callback of a
+// task. The task is left completed, marked with why its callback failed, so
+// that convergence stops kicking it and it remains visible until it expires.
+func (t *Task) DeadLetterCallback(failureReason string, now int64) error {
+	if t.State != Task_Resolving && t.State != Task_Completed {
+		return NewError(
+			Error_InvalidStateTransition,
+			fmt.Sprintf("Cannot dead-letter the callback of a task in state %s", t.State.String()),
+		)
+	}
+
+	t.State = Task_Completed
+	t.CallbackDeadLettered = true
+	t.CallbackFailureReason = failureReason
+	t.UpdatedAt = now
+	return nil
+}
+
+// Heartbeat records that the cell running the task is still running it,
+// along with its progress. It leaves UpdatedAt alone, so that the run time
+// of the task is still timed from when it started.
+func (t *Task) Heartbeat(cellID, progressMessage string, progressPercent int32, now int64) error {
+	if t.State != Task_Running {
+		return NewError(
+			Error_InvalidStateTransition,
+			fmt.Sprintf("Cannot heartbeat a task in state %s", t.State.String()),
+		)
+	}
+	if t.CellId != cellID {
+		return NewRunningOnDifferentCellError(cellID, t.CellId)
+	}
+
+	t.LastHeartbeatAt = now
+	t.ProgressMessage = progressMessage
+	t.ProgressPercent = progressPercent
+	return nil
+}
+
+// DependsOnTask returns true if the task has to wait for the given task to
+// complete successfully before it can start.
+func (t *Task) DependsOnTask(taskGuid string) bool {
+	for _, guid := range t.TaskDefinition.GetDependsOn() {
+		if guid == taskGuid {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlockedOn returns true if the task is blocked and still waiting for the
+// given task to complete.
+func (t *Task) IsBlockedOn(taskGuid string) bool {
+	if t.State != Task_Blocked {
+		return false
+	}
+	for _, guid := range t.BlockedOn {
+		if guid == taskGuid {
+			return true
+		}
+	}
+	return false
+}
+
+// Unblock records that the given task, which the task depends on, completed
+// successfully. Once it is no longer waiting for any of its dependencies, the
+// task becomes pending.
+func (t *Task) Unblock(taskGuid string) {
+	blockedOn := []string{}
+	for _, guid := range t.BlockedOn {
+		if guid != taskGuid {
+			blockedOn = append(blockedOn, guid)
+		}
+	}
+
+	t.BlockedOn = blockedOn
+	if len(blockedOn) == 0 {
+		t.BlockedOn = nil
+		t.State = Task_Pending
+	}
+}
+
+// HasCompleted returns true once the task has run, or been failed or
+// cancelled, whether or not its completion has been handled.
+func (t *Task) HasCompleted() bool {
+	return t.State == Task_Completed || t.State == Task_Resolving
+}
+
+// DependencyFailureReason is the reason a task is failed with when a task it
+// depends on fails.
+func DependencyFailureReason(taskGuid string) string {
+	return fmt.Sprintf("dependency %s failed", taskGuid)
+}
+
+// ShouldRetry returns true if the retry policy of the task allows another
+// attempt after it failed with the given reason. Blocked tasks are never
+// retried, since their dependencies have not been met.
+func (t *Task) ShouldRetry(failureReason string) bool {
+	policy := t.TaskDefinition.GetRetryPolicy()
+	if policy == nil || t.State == Task_Blocked || t.Attempts+1 >= policy.MaxAttempts {
+		return false
+	}
+
+	for _, reason := range policy.RetryableFailureReasons {
+		if reason == failureReason {
+			return true
+		}
+	}
+	return false
+}
+
+// Retry moves a failed task back to pending for another attempt, recording
+// why the previous attempt failed.
+func (t *Task) Retry(failureReason string, now int64) {
+	t.State = Task_Pending
+	t.Attempts++
+	t.CellId = ""
+	t.Failed = false
+	t.FailureReason = failureReason
+	t.Result = ""
+	t.UpdatedAt = now
+	t.LastHeartbeatAt = 0
+	t.ProgressMessage = ""
+	t.ProgressPercent = 0
+}
+
+// RetryBackoffElapsed returns true once a retried task has waited out the
+// backoff of its retry policy and can be auctioned again.
+func (t *Task) RetryBackoffElapsed(now int64) bool {
+	return now >= t.RetryBackoffEndsAt()
+}
+
+// RetryBackoffEndsAt returns when a retried task can be auctioned again. Tasks
+// that have not been retried can be auctioned from when they last changed.
+func (t *Task) RetryBackoffEndsAt() int64 {
+	if t.Attempts == 0 {
+		return t.UpdatedAt
+	}
+
+	backoff := time.Duration(t.TaskDefinition.GetRetryPolicy().GetBackoffMs()) * time.Millisecond
+	return t.UpdatedAt + backoff.Nanoseconds()
+}
+
+// Failure reasons for tasks that exceeded the time limits of their definition.
+const (
+	MaxPendingTimeExceededReason = "not started within task time limit"
+	MaxRunTimeExceededReason     = "exceeded maximum run time"
+	HeartbeatTimeoutReason       = "stopped sending heartbeats"
+)
+
+// ExceededDeadline returns the reason to fail the task with if it has been
+// waiting to start, or running, for longer than its definition allows.
+// Pending tasks are timed from when they last became pending and running
+// tasks from when they started. A running task whose definition sets a
+// heartbeat timeout also fails if its cell stops sending heartbeats for it.
+func (t *Task) ExceededDeadline(now int64) (string, bool) {
+	switch t.State {
+	case Task_Pending:
+		if exceeded(t.TaskDefinition.GetMaxPendingTimeMs(), t.UpdatedAt, now) {
+			return MaxPendingTimeExceededReason, true
+		}
+	case Task_Blocked:
+		if exceeded(t.TaskDefinition.GetMaxPendingTimeMs(), t.CreatedAt, now) {
+			return MaxPendingTimeExceededReason, true
+		}
+	case Task_Running:
+		if exceeded(t.TaskDefinition.GetMaxRunTimeMs(), t.UpdatedAt, now) {
+			return MaxRunTimeExceededReason, true
+		}
+		lastHeartbeat := t.UpdatedAt
+		if t.LastHeartbeatAt > lastHeartbeat {
+			lastHeartbeat = t.LastHeartbeatAt
+		}
+		if exceeded(t.TaskDefinition.GetHeartbeatTimeoutMs(), lastHeartbeat, now) {
+			return HeartbeatTimeoutReason, true
+		}
+	}
+	return "", false
+}
+
+// CompletedRetention returns how long the task is kept once it has
+// completed, which is its own retention period if its definition sets one.
+func (t *Task) CompletedRetention(defaultRetention time.Duration) time.Duration {
+	if retentionMs := t.TaskDefinition.GetCompletedRetentionMs(); retentionMs > 0 {
+		return time.Duration(retentionMs) * time.Millisecond
+	}
+	return defaultRetention
+}
+
+func exceeded(limitMs, since, now int64) bool {
+	if limitMs <= 0 {
+		return false
+	}
+	return now-since >= (time.Duration(limitMs) * time.Millisecond).Nanoseconds()
+}
+
 func newTaskDefWithCachedDependenciesAsActions(t *TaskDefinition) *TaskDefinition {
 	t = t.Copy()
 	if len(t.CachedDependencies) > 0 {
@@ -158,6 +458,39 @@ func (def *TaskDefinition) Validate() error {
 		validationError = validationError.Append(ErrInvalidField{"cpu_weight"})
 	}
 
+	if def.Priority < 0 || def.Priority > MaxTaskPriority {
+		validationError = validationError.Append(ErrInvalidField{"priority"})
+	}
+
+	if !validDependencies(def.DependsOn) {
+		validationError = validationError.Append(ErrInvalidField{"depends_on"})
+	}
+
+	if def.RetryPolicy != nil {
+		if err := def.RetryPolicy.Validate(); err != nil {
+			validationError = validationError.Append(ErrInvalidField{"retry_policy"})
+			validationError = validationError.Append(err)
+		}
+	}
+
+	if def.MaxPendingTimeMs < 0 {
+		validationError = validationError.Append(ErrInvalidField{"max_pending_time_ms"})
+	} else if def.MaxPendingTimeMs > 0 && def.RetryPolicy.GetBackoffMs() >= def.MaxPendingTimeMs {
+		validationError = validationError.Append(ErrInvalidField{"backoff_ms"})
+	}
+
+	if def.MaxRunTimeMs < 0 {
+		validationError = validationError.Append(ErrInvalidField{"max_run_time_ms"})
+	}
+
+	if def.CompletedRetentionMs < 0 {
+		validationError = validationError.Append(ErrInvalidField{"completed_retention_ms"})
+	}
+
+	if def.HeartbeatTimeoutMs < 0 {
+		validationError = validationError.Append(ErrInvalidField{"heartbeat_timeout_ms"})
+	}
+
 	if len(def.Annotation) > maximumAnnotationLength {
 		validationError = validationError.Append(ErrInvalidField{"annotation"})
 	}
@@ -181,6 +514,55 @@ func (def *TaskDefinition) Validate() error {
 	return nil
 }
 
+func (p *RetryPolicy) Validate() error {
+	var validationError ValidationError
+
+	if p.MaxAttempts < 1 || p.MaxAttempts > MaxTaskAttempts {
+		validationError = validationError.Append(ErrInvalidField{"max_attempts"})
+	}
+
+	if p.BackoffMs < 0 || time.Duration(p.BackoffMs)*time.Millisecond > MaxRetryBackoff {
+		validationError = validationError.Append(ErrInvalidField{"backoff_ms"})
+	}
+
+	for _, reason := range p.RetryableFailureReasons {
+		if reason == "" {
+			validationError = validationError.Append(ErrInvalidField{"retryable_failure_reasons"})
+			break
+		}
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
 func (t *TaskDefinition) Version() format.Version {
 	return format.V2
 }
+
+func validDependencies(taskGuids []string) bool {
+	if len(taskGuids) > MaxTaskDependencies {
+		return false
+	}
+
+	seen := make(map[string]bool, len(taskGuids))
+	for _, guid := range taskGuids {
+		if !taskGuidPattern.MatchString(guid) || seen[guid] {
+			return false
+		}
+		seen[guid] = true
+	}
+	return true
+}
+
+// QueuedTaskCallback is an entry in the queue of task completion callbacks
+// waiting to be delivered.
+type QueuedTaskCallback struct {
+	TaskGuid      string
+	Attempts      int32
+	EnqueuedAt    int64
+	NextAttemptAt int64
+}
@@ -31,6 +31,7 @@ const (
 	Task_Running   Task_State = 2
 	Task_Completed Task_State = 3
 	Task_Resolving Task_State = 4
+	Task_Blocked   Task_State = 5
 )
 
 var Task_State_name = map[int32]string{
@@ -39,6 +40,7 @@ var Task_State_name = map[int32]string{
 	2: "Running",
 	3: "Completed",
 	4: "Resolving",
+	5: "Blocked",
 }
 var Task_State_value = map[string]int32{
 	"Invalid":   0,
@@ -46,6 +48,7 @@ var Task_State_value = map[string]int32{
 	"Running":   2,
 	"Completed": 3,
 	"Resolving": 4,
+	"Blocked":   5,
 }
 
 func (x Task_State) Enum() *Task_State {
@@ -85,6 +88,13 @@ type TaskDefinition struct {
 	TrustedSystemCertificatesPath string                 `protobuf:"bytes,17,opt,name=trusted_system_certificates_path" json:"trusted_system_certificates_path,omitempty"`
 	VolumeMounts                  []*VolumeMount         `protobuf:"bytes,18,rep,name=volume_mounts" json:"volume_mounts,omitempty"`
 	Network                       *Network               `protobuf:"bytes,19,opt,name=network" json:"network,omitempty"`
+	Priority                      int32                  `protobuf:"varint,20,opt,name=priority" json:"priority,omitempty"`
+	DependsOn                     []string               `protobuf:"bytes,21,rep,name=depends_on" json:"depends_on,omitempty"`
+	RetryPolicy                   *RetryPolicy           `protobuf:"bytes,22,opt,name=retry_policy" json:"retry_policy,omitempty"`
+	MaxPendingTimeMs              int64                  `protobuf:"varint,23,opt,name=max_pending_time_ms" json:"max_pending_time_ms,omitempty"`
+	MaxRunTimeMs                  int64                  `protobuf:"varint,24,opt,name=max_run_time_ms" json:"max_run_time_ms,omitempty"`
+	CompletedRetentionMs          int64                  `protobuf:"varint,25,opt,name=completed_retention_ms" json:"completed_retention_ms,omitempty"`
+	HeartbeatTimeoutMs            int64                  `protobuf:"varint,26,opt,name=heartbeat_timeout_ms" json:"heartbeat_timeout_ms,omitempty"`
 }
 
 func (m *TaskDefinition) Reset()      { *m = TaskDefinition{} }
@@ -223,18 +233,104 @@ func (m *TaskDefinition) GetNetwork() *Network {
 	return nil
 }
 
+func (m *TaskDefinition) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *TaskDefinition) GetDependsOn() []string {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
+}
+
+func (m *TaskDefinition) GetRetryPolicy() *RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+func (m *TaskDefinition) GetMaxPendingTimeMs() int64 {
+	if m != nil {
+		return m.MaxPendingTimeMs
+	}
+	return 0
+}
+
+func (m *TaskDefinition) GetMaxRunTimeMs() int64 {
+	if m != nil {
+		return m.MaxRunTimeMs
+	}
+	return 0
+}
+
+func (m *TaskDefinition) GetCompletedRetentionMs() int64 {
+	if m != nil {
+		return m.CompletedRetentionMs
+	}
+	return 0
+}
+
+func (m *TaskDefinition) GetHeartbeatTimeoutMs() int64 {
+	if m != nil {
+		return m.HeartbeatTimeoutMs
+	}
+	return 0
+}
+
+type RetryPolicy struct {
+	MaxAttempts             int32    `protobuf:"varint,1,opt,name=max_attempts" json:"max_attempts"`
+	BackoffMs               int64    `protobuf:"varint,2,opt,name=backoff_ms" json:"backoff_ms,omitempty"`
+	RetryableFailureReasons []string `protobuf:"bytes,3,rep,name=retryable_failure_reasons" json:"retryable_failure_reasons,omitempty"`
+}
+
+func (m *RetryPolicy) Reset()      { *m = RetryPolicy{} }
+func (*RetryPolicy) ProtoMessage() {}
+
+func (m *RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoffMs() int64 {
+	if m != nil {
+		return m.BackoffMs
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetRetryableFailureReasons() []string {
+	if m != nil {
+		return m.RetryableFailureReasons
+	}
+	return nil
+}
+
 type Task struct {
-	*TaskDefinition  `protobuf:"bytes,1,opt,name=task_definition,embedded=task_definition" json:""`
-	TaskGuid         string     `protobuf:"bytes,2,opt,name=task_guid" json:"task_guid"`
-	Domain           string     `protobuf:"bytes,3,opt,name=domain" json:"domain"`
-	CreatedAt        int64      `protobuf:"varint,4,opt,name=created_at" json:"created_at"`
-	UpdatedAt        int64      `protobuf:"varint,5,opt,name=updated_at" json:"updated_at"`
-	FirstCompletedAt int64      `protobuf:"varint,6,opt,name=first_completed_at" json:"first_completed_at"`
-	State            Task_State `protobuf:"varint,7,opt,name=state,enum=models.Task_State" json:"state"`
-	CellId           string     `protobuf:"bytes,8,opt,name=cell_id" json:"cell_id"`
-	Result           string     `protobuf:"bytes,9,opt,name=result" json:"result"`
-	Failed           bool       `protobuf:"varint,10,opt,name=failed" json:"failed"`
-	FailureReason    string     `protobuf:"bytes,11,opt,name=failure_reason" json:"failure_reason"`
+	*TaskDefinition       `protobuf:"bytes,1,opt,name=task_definition,embedded=task_definition" json:""`
+	TaskGuid              string     `protobuf:"bytes,2,opt,name=task_guid" json:"task_guid"`
+	Domain                string     `protobuf:"bytes,3,opt,name=domain" json:"domain"`
+	CreatedAt             int64      `protobuf:"varint,4,opt,name=created_at" json:"created_at"`
+	UpdatedAt             int64      `protobuf:"varint,5,opt,name=updated_at" json:"updated_at"`
+	FirstCompletedAt      int64      `protobuf:"varint,6,opt,name=first_completed_at" json:"first_completed_at"`
+	State                 Task_State `protobuf:"varint,7,opt,name=state,enum=models.Task_State" json:"state"`
+	CellId                string     `protobuf:"bytes,8,opt,name=cell_id" json:"cell_id"`
+	Result                string     `protobuf:"bytes,9,opt,name=result" json:"result"`
+	Failed                bool       `protobuf:"varint,10,opt,name=failed" json:"failed"`
+	FailureReason         string     `protobuf:"bytes,11,opt,name=failure_reason" json:"failure_reason"`
+	BlockedOn             []string   `protobuf:"bytes,12,rep,name=blocked_on" json:"blocked_on,omitempty"`
+	Attempts              int32      `protobuf:"varint,13,opt,name=attempts" json:"attempts,omitempty"`
+	CallbackDeadLettered  bool       `protobuf:"varint,14,opt,name=callback_dead_lettered" json:"callback_dead_lettered,omitempty"`
+	CallbackFailureReason string     `protobuf:"bytes,15,opt,name=callback_failure_reason" json:"callback_failure_reason,omitempty"`
+	LastHeartbeatAt       int64      `protobuf:"varint,16,opt,name=last_heartbeat_at" json:"last_heartbeat_at,omitempty"`
+	ProgressMessage       string     `protobuf:"bytes,17,opt,name=progress_message" json:"progress_message,omitempty"`
+	ProgressPercent       int32      `protobuf:"varint,18,opt,name=progress_percent" json:"progress_percent,omitempty"`
 }
 
 func (m *Task) Reset()      { *m = Task{} }
@@ -310,6 +406,55 @@ func (m *Task) GetFailureReason() string {
 	return ""
 }
 
+func (m *Task) GetBlockedOn() []string {
+	if m != nil {
+		return m.BlockedOn
+	}
+	return nil
+}
+
+func (m *Task) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *Task) GetCallbackDeadLettered() bool {
+	if m != nil {
+		return m.CallbackDeadLettered
+	}
+	return false
+}
+
+func (m *Task) GetCallbackFailureReason() string {
+	if m != nil {
+		return m.CallbackFailureReason
+	}
+	return ""
+}
+
+func (m *Task) GetLastHeartbeatAt() int64 {
+	if m != nil {
+		return m.LastHeartbeatAt
+	}
+	return 0
+}
+
+func (m *Task) GetProgressMessage() string {
+	if m != nil {
+		return m.ProgressMessage
+	}
+	return ""
+}
+
+func (m *Task) GetProgressPercent() int32 {
+	if m != nil {
+		return m.ProgressPercent
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("models.Task_State", Task_State_name, Task_State_value)
 }
@@ -417,6 +562,68 @@ func (this *TaskDefinition) Equal(that interface{}) bool {
 	if !this.Network.Equal(that1.Network) {
 		return false
 	}
+	if this.Priority != that1.Priority {
+		return false
+	}
+	if len(this.DependsOn) != len(that1.DependsOn) {
+		return false
+	}
+	for i := range this.DependsOn {
+		if this.DependsOn[i] != that1.DependsOn[i] {
+			return false
+		}
+	}
+	if !this.RetryPolicy.Equal(that1.RetryPolicy) {
+		return false
+	}
+	if this.MaxPendingTimeMs != that1.MaxPendingTimeMs {
+		return false
+	}
+	if this.MaxRunTimeMs != that1.MaxRunTimeMs {
+		return false
+	}
+	if this.CompletedRetentionMs != that1.CompletedRetentionMs {
+		return false
+	}
+	if this.HeartbeatTimeoutMs != that1.HeartbeatTimeoutMs {
+		return false
+	}
+	return true
+}
+func (this *RetryPolicy) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*RetryPolicy)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.MaxAttempts != that1.MaxAttempts {
+		return false
+	}
+	if this.BackoffMs != that1.BackoffMs {
+		return false
+	}
+	if len(this.RetryableFailureReasons) != len(that1.RetryableFailureReasons) {
+		return false
+	}
+	for i := range this.RetryableFailureReasons {
+		if this.RetryableFailureReasons[i] != that1.RetryableFailureReasons[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *Task) Equal(that interface{}) bool {
@@ -472,6 +679,32 @@ func (this *Task) Equal(that interface{}) bool {
 	if this.FailureReason != that1.FailureReason {
 		return false
 	}
+	if len(this.BlockedOn) != len(that1.BlockedOn) {
+		return false
+	}
+	for i := range this.BlockedOn {
+		if this.BlockedOn[i] != that1.BlockedOn[i] {
+			return false
+		}
+	}
+	if this.Attempts != that1.Attempts {
+		return false
+	}
+	if this.CallbackDeadLettered != that1.CallbackDeadLettered {
+		return false
+	}
+	if this.CallbackFailureReason != that1.CallbackFailureReason {
+		return false
+	}
+	if this.LastHeartbeatAt != that1.LastHeartbeatAt {
+		return false
+	}
+	if this.ProgressMessage != that1.ProgressMessage {
+		return false
+	}
+	if this.ProgressPercent != that1.ProgressPercent {
+		return false
+	}
 	return true
 }
 func (this *TaskDefinition) GoString() string {
@@ -497,7 +730,24 @@ func (this *TaskDefinition) GoString() string {
 		`LegacyDownloadUser:` + fmt.Sprintf("%#v", this.LegacyDownloadUser),
 		`TrustedSystemCertificatesPath:` + fmt.Sprintf("%#v", this.TrustedSystemCertificatesPath),
 		`VolumeMounts:` + fmt.Sprintf("%#v", this.VolumeMounts),
-		`Network:` + fmt.Sprintf("%#v", this.Network) + `}`}, ", ")
+		`Network:` + fmt.Sprintf("%#v", this.Network),
+		`Priority:` + fmt.Sprintf("%#v", this.Priority),
+		`DependsOn:` + fmt.Sprintf("%#v", this.DependsOn),
+		`RetryPolicy:` + fmt.Sprintf("%#v", this.RetryPolicy),
+		`MaxPendingTimeMs:` + fmt.Sprintf("%#v", this.MaxPendingTimeMs),
+		`MaxRunTimeMs:` + fmt.Sprintf("%#v", this.MaxRunTimeMs),
+		`CompletedRetentionMs:` + fmt.Sprintf("%#v", this.CompletedRetentionMs),
+		`HeartbeatTimeoutMs:` + fmt.Sprintf("%#v", this.HeartbeatTimeoutMs) + `}`}, ", ")
+	return s
+}
+func (this *RetryPolicy) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.RetryPolicy{` +
+		`MaxAttempts:` + fmt.Sprintf("%#v", this.MaxAttempts),
+		`BackoffMs:` + fmt.Sprintf("%#v", this.BackoffMs),
+		`RetryableFailureReasons:` + fmt.Sprintf("%#v", this.RetryableFailureReasons) + `}`}, ", ")
 	return s
 }
 func (this *Task) GoString() string {
@@ -515,7 +765,14 @@ func (this *Task) GoString() string {
 		`CellId:` + fmt.Sprintf("%#v", this.CellId),
 		`Result:` + fmt.Sprintf("%#v", this.Result),
 		`Failed:` + fmt.Sprintf("%#v", this.Failed),
-		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason) + `}`}, ", ")
+		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason),
+		`BlockedOn:` + fmt.Sprintf("%#v", this.BlockedOn),
+		`Attempts:` + fmt.Sprintf("%#v", this.Attempts),
+		`CallbackDeadLettered:` + fmt.Sprintf("%#v", this.CallbackDeadLettered),
+		`CallbackFailureReason:` + fmt.Sprintf("%#v", this.CallbackFailureReason),
+		`LastHeartbeatAt:` + fmt.Sprintf("%#v", this.LastHeartbeatAt),
+		`ProgressMessage:` + fmt.Sprintf("%#v", this.ProgressMessage),
+		`ProgressPercent:` + fmt.Sprintf("%#v", this.ProgressPercent) + `}`}, ", ")
 	return s
 }
 func valueToGoStringTask(v interface{}, typ string) string {
@@ -687,6 +944,99 @@ func (m *TaskDefinition) MarshalTo(data []byte) (int, error) {
 		}
 		i += n2
 	}
+	data[i] = 0xa0
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(m.Priority))
+	if len(m.DependsOn) > 0 {
+		for _, s := range m.DependsOn {
+			data[i] = 0xaa
+			i++
+			data[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				data[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			data[i] = uint8(l)
+			i++
+			i += copy(data[i:], s)
+		}
+	}
+	if m.RetryPolicy != nil {
+		data[i] = 0xb2
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintTask(data, i, uint64(m.RetryPolicy.Size()))
+		n3, err := m.RetryPolicy.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	data[i] = 0xb8
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(m.MaxPendingTimeMs))
+	data[i] = 0xc0
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(m.MaxRunTimeMs))
+	data[i] = 0xc8
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(m.CompletedRetentionMs))
+	data[i] = 0xd0
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(m.HeartbeatTimeoutMs))
+	return i, nil
+}
+
+func (m *RetryPolicy) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *RetryPolicy) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintTask(data, i, uint64(m.MaxAttempts))
+	data[i] = 0x10
+	i++
+	i = encodeVarintTask(data, i, uint64(m.BackoffMs))
+	if len(m.RetryableFailureReasons) > 0 {
+		for _, s := range m.RetryableFailureReasons {
+			data[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				data[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			data[i] = uint8(l)
+			i++
+			i += copy(data[i:], s)
+		}
+	}
 	return i, nil
 }
 
@@ -709,11 +1059,11 @@ func (m *Task) MarshalTo(data []byte) (int, error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintTask(data, i, uint64(m.TaskDefinition.Size()))
-		n3, err := m.TaskDefinition.MarshalTo(data[i:])
+		n4, err := m.TaskDefinition.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	data[i] = 0x12
 	i++
@@ -755,6 +1105,52 @@ func (m *Task) MarshalTo(data []byte) (int, error) {
 	i++
 	i = encodeVarintTask(data, i, uint64(len(m.FailureReason)))
 	i += copy(data[i:], m.FailureReason)
+	if len(m.BlockedOn) > 0 {
+		for _, s := range m.BlockedOn {
+			data[i] = 0x62
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				data[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			data[i] = uint8(l)
+			i++
+			i += copy(data[i:], s)
+		}
+	}
+	data[i] = 0x68
+	i++
+	i = encodeVarintTask(data, i, uint64(m.Attempts))
+	data[i] = 0x70
+	i++
+	if m.CallbackDeadLettered {
+		data[i] = 1
+	} else {
+		data[i] = 0
+	}
+	i++
+	data[i] = 0x7a
+	i++
+	i = encodeVarintTask(data, i, uint64(len(m.CallbackFailureReason)))
+	i += copy(data[i:], m.CallbackFailureReason)
+	data[i] = 0x80
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(m.LastHeartbeatAt))
+	data[i] = 0x8a
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(len(m.ProgressMessage)))
+	i += copy(data[i:], m.ProgressMessage)
+	data[i] = 0x90
+	i++
+	data[i] = 0x1
+	i++
+	i = encodeVarintTask(data, i, uint64(m.ProgressPercent))
 	return i, nil
 }
 
@@ -842,6 +1238,35 @@ func (m *TaskDefinition) Size() (n int) {
 		l = m.Network.Size()
 		n += 2 + l + sovTask(uint64(l))
 	}
+	n += 2 + sovTask(uint64(m.Priority))
+	if len(m.DependsOn) > 0 {
+		for _, s := range m.DependsOn {
+			l = len(s)
+			n += 2 + l + sovTask(uint64(l))
+		}
+	}
+	if m.RetryPolicy != nil {
+		l = m.RetryPolicy.Size()
+		n += 2 + l + sovTask(uint64(l))
+	}
+	n += 2 + sovTask(uint64(m.MaxPendingTimeMs))
+	n += 2 + sovTask(uint64(m.MaxRunTimeMs))
+	n += 2 + sovTask(uint64(m.CompletedRetentionMs))
+	n += 2 + sovTask(uint64(m.HeartbeatTimeoutMs))
+	return n
+}
+
+func (m *RetryPolicy) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovTask(uint64(m.MaxAttempts))
+	n += 1 + sovTask(uint64(m.BackoffMs))
+	if len(m.RetryableFailureReasons) > 0 {
+		for _, s := range m.RetryableFailureReasons {
+			l = len(s)
+			n += 1 + l + sovTask(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -867,6 +1292,20 @@ func (m *Task) Size() (n int) {
 	n += 2
 	l = len(m.FailureReason)
 	n += 1 + l + sovTask(uint64(l))
+	if len(m.BlockedOn) > 0 {
+		for _, s := range m.BlockedOn {
+			l = len(s)
+			n += 1 + l + sovTask(uint64(l))
+		}
+	}
+	n += 1 + sovTask(uint64(m.Attempts))
+	n += 2
+	l = len(m.CallbackFailureReason)
+	n += 1 + l + sovTask(uint64(l))
+	n += 2 + sovTask(uint64(m.LastHeartbeatAt))
+	l = len(m.ProgressMessage)
+	n += 2 + l + sovTask(uint64(l))
+	n += 2 + sovTask(uint64(m.ProgressPercent))
 	return n
 }
 
@@ -907,17 +1346,36 @@ func (this *TaskDefinition) String() string {
 		`TrustedSystemCertificatesPath:` + fmt.Sprintf("%v", this.TrustedSystemCertificatesPath) + `,`,
 		`VolumeMounts:` + strings.Replace(fmt.Sprintf("%v", this.VolumeMounts), "VolumeMount", "VolumeMount", 1) + `,`,
 		`Network:` + strings.Replace(fmt.Sprintf("%v", this.Network), "Network", "Network", 1) + `,`,
+		`Priority:` + fmt.Sprintf("%v", this.Priority) + `,`,
+		`DependsOn:` + fmt.Sprintf("%v", this.DependsOn) + `,`,
+		`RetryPolicy:` + strings.Replace(fmt.Sprintf("%v", this.RetryPolicy), "RetryPolicy", "RetryPolicy", 1) + `,`,
+		`MaxPendingTimeMs:` + fmt.Sprintf("%v", this.MaxPendingTimeMs) + `,`,
+		`MaxRunTimeMs:` + fmt.Sprintf("%v", this.MaxRunTimeMs) + `,`,
+		`CompletedRetentionMs:` + fmt.Sprintf("%v", this.CompletedRetentionMs) + `,`,
+		`HeartbeatTimeoutMs:` + fmt.Sprintf("%v", this.HeartbeatTimeoutMs) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *Task) String() string {
+func (this *RetryPolicy) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&Task{`,
-		`TaskDefinition:` + strings.Replace(fmt.Sprintf("%v", this.TaskDefinition), "TaskDefinition", "TaskDefinition", 1) + `,`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+	s := strings.Join([]string{`&RetryPolicy{`,
+		`MaxAttempts:` + fmt.Sprintf("%v", this.MaxAttempts) + `,`,
+		`BackoffMs:` + fmt.Sprintf("%v", this.BackoffMs) + `,`,
+		`RetryableFailureReasons:` + fmt.Sprintf("%v", this.RetryableFailureReasons) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Task) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Task{`,
+		`TaskDefinition:` + strings.Replace(fmt.Sprintf("%v", this.TaskDefinition), "TaskDefinition", "TaskDefinition", 1) + `,`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
 		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
 		`CreatedAt:` + fmt.Sprintf("%v", this.CreatedAt) + `,`,
 		`UpdatedAt:` + fmt.Sprintf("%v", this.UpdatedAt) + `,`,
@@ -927,6 +1385,13 @@ func (this *Task) String() string {
 		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
 		`Failed:` + fmt.Sprintf("%v", this.Failed) + `,`,
 		`FailureReason:` + fmt.Sprintf("%v", this.FailureReason) + `,`,
+		`BlockedOn:` + fmt.Sprintf("%v", this.BlockedOn) + `,`,
+		`Attempts:` + fmt.Sprintf("%v", this.Attempts) + `,`,
+		`CallbackDeadLettered:` + fmt.Sprintf("%v", this.CallbackDeadLettered) + `,`,
+		`CallbackFailureReason:` + fmt.Sprintf("%v", this.CallbackFailureReason) + `,`,
+		`LastHeartbeatAt:` + fmt.Sprintf("%v", this.LastHeartbeatAt) + `,`,
+		`ProgressMessage:` + fmt.Sprintf("%v", this.ProgressMessage) + `,`,
+		`ProgressPercent:` + fmt.Sprintf("%v", this.ProgressPercent) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1420,6 +1885,243 @@ func (m *TaskDefinition) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Priority |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DependsOn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTask
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DependsOn = append(m.DependsOn, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTask
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RetryPolicy == nil {
+				m.RetryPolicy = &RetryPolicy{}
+			}
+			if err := m.RetryPolicy.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPendingTimeMs", wireType)
+			}
+			m.MaxPendingTimeMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxPendingTimeMs |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRunTimeMs", wireType)
+			}
+			m.MaxRunTimeMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxRunTimeMs |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompletedRetentionMs", wireType)
+			}
+			m.CompletedRetentionMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.CompletedRetentionMs |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatTimeoutMs", wireType)
+			}
+			m.HeartbeatTimeoutMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.HeartbeatTimeoutMs |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTask(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTask
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *RetryPolicy) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAttempts", wireType)
+			}
+			m.MaxAttempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxAttempts |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BackoffMs", wireType)
+			}
+			m.BackoffMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.BackoffMs |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryableFailureReasons", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTask
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RetryableFailureReasons = append(m.RetryableFailureReasons, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -1701,6 +2403,146 @@ func (m *Task) Unmarshal(data []byte) error {
 			}
 			m.FailureReason = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockedOn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTask
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockedOn = append(m.BlockedOn, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempts", wireType)
+			}
+			m.Attempts = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Attempts |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallbackDeadLettered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CallbackDeadLettered = bool(v != 0)
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallbackFailureReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTask
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CallbackFailureReason = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHeartbeatAt", wireType)
+			}
+			m.LastHeartbeatAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.LastHeartbeatAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProgressMessage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTask
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProgressMessage = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProgressPercent", wireType)
+			}
+			m.ProgressPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ProgressPercent |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -41,6 +41,134 @@ func (req *StartTaskRequest) Validate() error {
 	return nil
 }
 
+func (req *TaskHeartbeatRequest) Validate() error {
+	var validationError ValidationError
+
+	if !taskGuidPattern.MatchString(req.TaskGuid) {
+		validationError = validationError.Append(ErrInvalidField{"task_guid"})
+	}
+	if req.CellId == "" {
+		validationError = validationError.Append(ErrInvalidField{"cell_id"})
+	}
+	if len(req.ProgressMessage) > MaxProgressMessageLength {
+		validationError = validationError.Append(ErrInvalidField{"progress_message"})
+	}
+	if req.ProgressPercent < 0 || req.ProgressPercent > 100 {
+		validationError = validationError.Append(ErrInvalidField{"progress_percent"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (req *CancelTaskRequest) Validate() error {
+	var validationError ValidationError
+
+	if req.TaskGuid == "" {
+		validationError = validationError.Append(ErrInvalidField{"task_guid"})
+	}
+	if len(req.Reason) > MaxCancellationReasonLength {
+		validationError = validationError.Append(ErrInvalidField{"reason"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+// FailureReason is the failure reason to record on the cancelled task.
+func (req *CancelTaskRequest) FailureReason() string {
+	return cancellationFailureReason(req.Reason)
+}
+
+func cancellationFailureReason(reason string) string {
+	if reason == "" {
+		return TaskCancelledReason
+	}
+	return reason
+}
+
+// Validate rejects empty selectors, so that a single request cannot act on
+// every task by accident.
+func (s *TaskSelector) Validate() error {
+	var validationError ValidationError
+
+	if s.Domain == "" && s.State == Task_Invalid && s.CellId == "" && s.MinAge == 0 {
+		validationError = validationError.Append(ErrInvalidField{"selector"})
+	}
+	if _, ok := Task_State_name[int32(s.State)]; !ok {
+		validationError = validationError.Append(ErrInvalidField{"state"})
+	}
+	if s.MinAge < 0 {
+		validationError = validationError.Append(ErrInvalidField{"min_age"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+// Filter returns the filter selecting the same tasks as the selector at the
+// given time. The age of a task is the time since it last changed state.
+func (s *TaskSelector) Filter(now int64) TaskFilter {
+	filter := TaskFilter{
+		Domain: s.Domain,
+		CellID: s.CellId,
+		State:  s.State,
+	}
+	if s.MinAge > 0 {
+		filter.UpdatedBefore = now - s.MinAge
+	}
+	return filter
+}
+
+func (req *CancelTasksRequest) Validate() error {
+	var validationError ValidationError
+
+	if req.Selector == nil {
+		validationError = validationError.Append(ErrInvalidField{"selector"})
+	} else if err := req.Selector.Validate(); err != nil {
+		validationError = validationError.Append(err)
+	}
+	if len(req.Reason) > MaxCancellationReasonLength {
+		validationError = validationError.Append(ErrInvalidField{"reason"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+// FailureReason is the failure reason to record on the cancelled tasks.
+func (req *CancelTasksRequest) FailureReason() string {
+	return cancellationFailureReason(req.Reason)
+}
+
+func (req *DeleteTasksRequest) Validate() error {
+	var validationError ValidationError
+
+	if req.Selector == nil {
+		validationError = validationError.Append(ErrInvalidField{"selector"})
+	} else if err := req.Selector.Validate(); err != nil {
+		validationError = validationError.Append(err)
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
 func (req *CompleteTaskRequest) Validate() error {
 	var validationError ValidationError
 
@@ -76,9 +204,27 @@ func (req *FailTaskRequest) Validate() error {
 }
 
 func (req *TasksRequest) Validate() error {
+	var validationError ValidationError
+
+	if req.MinPriority < 0 || req.MinPriority > MaxTaskPriority {
+		validationError = validationError.Append(ErrInvalidField{"min_priority"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
 	return nil
 }
 
+func (req *TasksRequest) Filter() TaskFilter {
+	return TaskFilter{
+		Domain:      req.Domain,
+		CellID:      req.CellId,
+		MinPriority: req.MinPriority,
+	}
+}
+
 func (request *TaskByGuidRequest) Validate() error {
 	var validationError ValidationError
 
@@ -110,3 +256,37 @@ func (request *TaskGuidRequest) Validate() error {
 func (request *ConvergeTasksRequest) Validate() error {
 	return nil
 }
+
+func (req *ArchivedTasksRequest) Validate() error {
+	var validationError ValidationError
+
+	if req.CompletedSince < 0 {
+		validationError = validationError.Append(ErrInvalidField{"completed_since"})
+	}
+	if req.CompletedUntil < 0 || (req.CompletedUntil != 0 && req.CompletedUntil < req.CompletedSince) {
+		validationError = validationError.Append(ErrInvalidField{"completed_until"})
+	}
+	if req.Limit < 0 || req.Limit > MaxArchivedTasksLimit {
+		validationError = validationError.Append(ErrInvalidField{"limit"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (req *ArchivedTasksRequest) Filter() ArchivedTaskFilter {
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultArchivedTasksLimit
+	}
+
+	return ArchivedTaskFilter{
+		Domain:         req.Domain,
+		CompletedSince: req.CompletedSince,
+		CompletedUntil: req.CompletedUntil,
+		Limit:          limit,
+	}
+}
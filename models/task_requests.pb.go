@@ -110,6 +110,44 @@ func (m *StartTaskResponse) GetShouldStart() bool {
 	return false
 }
 
+type TaskHeartbeatRequest struct {
+	TaskGuid        string `protobuf:"bytes,1,opt,name=task_guid" json:"task_guid"`
+	CellId          string `protobuf:"bytes,2,opt,name=cell_id" json:"cell_id"`
+	ProgressMessage string `protobuf:"bytes,3,opt,name=progress_message" json:"progress_message,omitempty"`
+	ProgressPercent int32  `protobuf:"varint,4,opt,name=progress_percent" json:"progress_percent,omitempty"`
+}
+
+func (m *TaskHeartbeatRequest) Reset()      { *m = TaskHeartbeatRequest{} }
+func (*TaskHeartbeatRequest) ProtoMessage() {}
+
+func (m *TaskHeartbeatRequest) GetTaskGuid() string {
+	if m != nil {
+		return m.TaskGuid
+	}
+	return ""
+}
+
+func (m *TaskHeartbeatRequest) GetCellId() string {
+	if m != nil {
+		return m.CellId
+	}
+	return ""
+}
+
+func (m *TaskHeartbeatRequest) GetProgressMessage() string {
+	if m != nil {
+		return m.ProgressMessage
+	}
+	return ""
+}
+
+func (m *TaskHeartbeatRequest) GetProgressPercent() int32 {
+	if m != nil {
+		return m.ProgressPercent
+	}
+	return 0
+}
+
 type FailTaskRequest struct {
 	TaskGuid      string `protobuf:"bytes,1,opt,name=task_guid" json:"task_guid"`
 	FailureReason string `protobuf:"bytes,2,opt,name=failure_reason" json:"failure_reason"`
@@ -146,6 +184,168 @@ func (m *TaskGuidRequest) GetTaskGuid() string {
 	return ""
 }
 
+type CancelTaskRequest struct {
+	TaskGuid string `protobuf:"bytes,1,opt,name=task_guid" json:"task_guid"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *CancelTaskRequest) Reset()      { *m = CancelTaskRequest{} }
+func (*CancelTaskRequest) ProtoMessage() {}
+
+func (m *CancelTaskRequest) GetTaskGuid() string {
+	if m != nil {
+		return m.TaskGuid
+	}
+	return ""
+}
+
+func (m *CancelTaskRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type TaskSelector struct {
+	Domain string     `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
+	State  Task_State `protobuf:"varint,2,opt,name=state,enum=models.Task_State" json:"state,omitempty"`
+	CellId string     `protobuf:"bytes,3,opt,name=cell_id" json:"cell_id,omitempty"`
+	MinAge int64      `protobuf:"varint,4,opt,name=min_age" json:"min_age,omitempty"`
+}
+
+func (m *TaskSelector) Reset()      { *m = TaskSelector{} }
+func (*TaskSelector) ProtoMessage() {}
+
+func (m *TaskSelector) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *TaskSelector) GetState() Task_State {
+	if m != nil {
+		return m.State
+	}
+	return Task_Invalid
+}
+
+func (m *TaskSelector) GetCellId() string {
+	if m != nil {
+		return m.CellId
+	}
+	return ""
+}
+
+func (m *TaskSelector) GetMinAge() int64 {
+	if m != nil {
+		return m.MinAge
+	}
+	return 0
+}
+
+type TaskResult struct {
+	TaskGuid string `protobuf:"bytes,1,opt,name=task_guid" json:"task_guid"`
+	Error    *Error `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TaskResult) Reset()      { *m = TaskResult{} }
+func (*TaskResult) ProtoMessage() {}
+
+func (m *TaskResult) GetTaskGuid() string {
+	if m != nil {
+		return m.TaskGuid
+	}
+	return ""
+}
+
+func (m *TaskResult) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type CancelTasksRequest struct {
+	Selector *TaskSelector `protobuf:"bytes,1,opt,name=selector" json:"selector,omitempty"`
+	Reason   string        `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *CancelTasksRequest) Reset()      { *m = CancelTasksRequest{} }
+func (*CancelTasksRequest) ProtoMessage() {}
+
+func (m *CancelTasksRequest) GetSelector() *TaskSelector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (m *CancelTasksRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type CancelTasksResponse struct {
+	Error   *Error        `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	Results []*TaskResult `protobuf:"bytes,2,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *CancelTasksResponse) Reset()      { *m = CancelTasksResponse{} }
+func (*CancelTasksResponse) ProtoMessage() {}
+
+func (m *CancelTasksResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *CancelTasksResponse) GetResults() []*TaskResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type DeleteTasksRequest struct {
+	Selector *TaskSelector `protobuf:"bytes,1,opt,name=selector" json:"selector,omitempty"`
+}
+
+func (m *DeleteTasksRequest) Reset()      { *m = DeleteTasksRequest{} }
+func (*DeleteTasksRequest) ProtoMessage() {}
+
+func (m *DeleteTasksRequest) GetSelector() *TaskSelector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+type DeleteTasksResponse struct {
+	Error   *Error        `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	Results []*TaskResult `protobuf:"bytes,2,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *DeleteTasksResponse) Reset()      { *m = DeleteTasksResponse{} }
+func (*DeleteTasksResponse) ProtoMessage() {}
+
+func (m *DeleteTasksResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *DeleteTasksResponse) GetResults() []*TaskResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 type CompleteTaskRequest struct {
 	TaskGuid      string `protobuf:"bytes,1,opt,name=task_guid" json:"task_guid"`
 	CellId        string `protobuf:"bytes,2,opt,name=cell_id" json:"cell_id"`
@@ -291,8 +491,9 @@ func (m *ConvergeTasksResponse) GetError() *Error {
 }
 
 type TasksRequest struct {
-	Domain string `protobuf:"bytes,1,opt,name=domain" json:"domain"`
-	CellId string `protobuf:"bytes,2,opt,name=cell_id" json:"cell_id"`
+	Domain      string `protobuf:"bytes,1,opt,name=domain" json:"domain"`
+	CellId      string `protobuf:"bytes,2,opt,name=cell_id" json:"cell_id"`
+	MinPriority int32  `protobuf:"varint,3,opt,name=min_priority" json:"min_priority,omitempty"`
 }
 
 func (m *TasksRequest) Reset()      { *m = TasksRequest{} }
@@ -312,6 +513,13 @@ func (m *TasksRequest) GetCellId() string {
 	return ""
 }
 
+func (m *TasksRequest) GetMinPriority() int32 {
+	if m != nil {
+		return m.MinPriority
+	}
+	return 0
+}
+
 type TasksResponse struct {
 	Error *Error  `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
 	Tasks []*Task `protobuf:"bytes,2,rep,name=tasks" json:"tasks,omitempty"`
@@ -370,6 +578,66 @@ func (m *TaskResponse) GetTask() *Task {
 	return nil
 }
 
+type ArchivedTasksRequest struct {
+	Domain         string `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
+	CompletedSince int64  `protobuf:"varint,2,opt,name=completed_since" json:"completed_since,omitempty"`
+	CompletedUntil int64  `protobuf:"varint,3,opt,name=completed_until" json:"completed_until,omitempty"`
+	Limit          int32  `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ArchivedTasksRequest) Reset()      { *m = ArchivedTasksRequest{} }
+func (*ArchivedTasksRequest) ProtoMessage() {}
+
+func (m *ArchivedTasksRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *ArchivedTasksRequest) GetCompletedSince() int64 {
+	if m != nil {
+		return m.CompletedSince
+	}
+	return 0
+}
+
+func (m *ArchivedTasksRequest) GetCompletedUntil() int64 {
+	if m != nil {
+		return m.CompletedUntil
+	}
+	return 0
+}
+
+func (m *ArchivedTasksRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ArchivedTasksResponse struct {
+	Error *Error  `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	Tasks []*Task `protobuf:"bytes,2,rep,name=tasks" json:"tasks,omitempty"`
+}
+
+func (m *ArchivedTasksResponse) Reset()      { *m = ArchivedTasksResponse{} }
+func (*ArchivedTasksResponse) ProtoMessage() {}
+
+func (m *ArchivedTasksResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ArchivedTasksResponse) GetTasks() []*Task {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
 func (this *TaskLifecycleResponse) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -482,7 +750,7 @@ func (this *StartTaskResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *FailTaskRequest) Equal(that interface{}) bool {
+func (this *TaskHeartbeatRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -490,7 +758,7 @@ func (this *FailTaskRequest) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*FailTaskRequest)
+	that1, ok := that.(*TaskHeartbeatRequest)
 	if !ok {
 		return false
 	}
@@ -505,12 +773,18 @@ func (this *FailTaskRequest) Equal(that interface{}) bool {
 	if this.TaskGuid != that1.TaskGuid {
 		return false
 	}
-	if this.FailureReason != that1.FailureReason {
+	if this.CellId != that1.CellId {
+		return false
+	}
+	if this.ProgressMessage != that1.ProgressMessage {
+		return false
+	}
+	if this.ProgressPercent != that1.ProgressPercent {
 		return false
 	}
 	return true
 }
-func (this *TaskGuidRequest) Equal(that interface{}) bool {
+func (this *FailTaskRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -518,7 +792,7 @@ func (this *TaskGuidRequest) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*TaskGuidRequest)
+	that1, ok := that.(*FailTaskRequest)
 	if !ok {
 		return false
 	}
@@ -533,9 +807,12 @@ func (this *TaskGuidRequest) Equal(that interface{}) bool {
 	if this.TaskGuid != that1.TaskGuid {
 		return false
 	}
+	if this.FailureReason != that1.FailureReason {
+		return false
+	}
 	return true
 }
-func (this *CompleteTaskRequest) Equal(that interface{}) bool {
+func (this *TaskGuidRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -543,7 +820,7 @@ func (this *CompleteTaskRequest) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*CompleteTaskRequest)
+	that1, ok := that.(*TaskGuidRequest)
 	if !ok {
 		return false
 	}
@@ -558,21 +835,9 @@ func (this *CompleteTaskRequest) Equal(that interface{}) bool {
 	if this.TaskGuid != that1.TaskGuid {
 		return false
 	}
-	if this.CellId != that1.CellId {
-		return false
-	}
-	if this.Failed != that1.Failed {
-		return false
-	}
-	if this.FailureReason != that1.FailureReason {
-		return false
-	}
-	if this.Result != that1.Result {
-		return false
-	}
 	return true
 }
-func (this *TaskCallbackResponse) Equal(that interface{}) bool {
+func (this *CancelTaskRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -580,7 +845,7 @@ func (this *TaskCallbackResponse) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*TaskCallbackResponse)
+	that1, ok := that.(*CancelTaskRequest)
 	if !ok {
 		return false
 	}
@@ -595,24 +860,12 @@ func (this *TaskCallbackResponse) Equal(that interface{}) bool {
 	if this.TaskGuid != that1.TaskGuid {
 		return false
 	}
-	if this.Failed != that1.Failed {
-		return false
-	}
-	if this.FailureReason != that1.FailureReason {
-		return false
-	}
-	if this.Result != that1.Result {
-		return false
-	}
-	if this.Annotation != that1.Annotation {
-		return false
-	}
-	if this.CreatedAt != that1.CreatedAt {
+	if this.Reason != that1.Reason {
 		return false
 	}
 	return true
 }
-func (this *ConvergeTasksRequest) Equal(that interface{}) bool {
+func (this *TaskSelector) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -620,7 +873,7 @@ func (this *ConvergeTasksRequest) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*ConvergeTasksRequest)
+	that1, ok := that.(*TaskSelector)
 	if !ok {
 		return false
 	}
@@ -632,18 +885,21 @@ func (this *ConvergeTasksRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.KickTaskDuration != that1.KickTaskDuration {
+	if this.Domain != that1.Domain {
 		return false
 	}
-	if this.ExpirePendingTaskDuration != that1.ExpirePendingTaskDuration {
+	if this.State != that1.State {
 		return false
 	}
-	if this.ExpireCompletedTaskDuration != that1.ExpireCompletedTaskDuration {
+	if this.CellId != that1.CellId {
+		return false
+	}
+	if this.MinAge != that1.MinAge {
 		return false
 	}
 	return true
 }
-func (this *ConvergeTasksResponse) Equal(that interface{}) bool {
+func (this *TaskResult) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -651,7 +907,7 @@ func (this *ConvergeTasksResponse) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*ConvergeTasksResponse)
+	that1, ok := that.(*TaskResult)
 	if !ok {
 		return false
 	}
@@ -663,12 +919,15 @@ func (this *ConvergeTasksResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.TaskGuid != that1.TaskGuid {
+		return false
+	}
 	if !this.Error.Equal(that1.Error) {
 		return false
 	}
 	return true
 }
-func (this *TasksRequest) Equal(that interface{}) bool {
+func (this *CancelTasksRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -676,7 +935,7 @@ func (this *TasksRequest) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*TasksRequest)
+	that1, ok := that.(*CancelTasksRequest)
 	if !ok {
 		return false
 	}
@@ -688,15 +947,15 @@ func (this *TasksRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Domain != that1.Domain {
+	if !this.Selector.Equal(that1.Selector) {
 		return false
 	}
-	if this.CellId != that1.CellId {
+	if this.Reason != that1.Reason {
 		return false
 	}
 	return true
 }
-func (this *TasksResponse) Equal(that interface{}) bool {
+func (this *CancelTasksResponse) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -704,7 +963,7 @@ func (this *TasksResponse) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*TasksResponse)
+	that1, ok := that.(*CancelTasksResponse)
 	if !ok {
 		return false
 	}
@@ -719,17 +978,17 @@ func (this *TasksResponse) Equal(that interface{}) bool {
 	if !this.Error.Equal(that1.Error) {
 		return false
 	}
-	if len(this.Tasks) != len(that1.Tasks) {
+	if len(this.Results) != len(that1.Results) {
 		return false
 	}
-	for i := range this.Tasks {
-		if !this.Tasks[i].Equal(that1.Tasks[i]) {
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *TaskByGuidRequest) Equal(that interface{}) bool {
+func (this *DeleteTasksRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -737,7 +996,7 @@ func (this *TaskByGuidRequest) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*TaskByGuidRequest)
+	that1, ok := that.(*DeleteTasksRequest)
 	if !ok {
 		return false
 	}
@@ -749,12 +1008,12 @@ func (this *TaskByGuidRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.TaskGuid != that1.TaskGuid {
+	if !this.Selector.Equal(that1.Selector) {
 		return false
 	}
 	return true
 }
-func (this *TaskResponse) Equal(that interface{}) bool {
+func (this *DeleteTasksResponse) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -762,7 +1021,7 @@ func (this *TaskResponse) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*TaskResponse)
+	that1, ok := that.(*DeleteTasksResponse)
 	if !ok {
 		return false
 	}
@@ -777,550 +1036,650 @@ func (this *TaskResponse) Equal(that interface{}) bool {
 	if !this.Error.Equal(that1.Error) {
 		return false
 	}
-	if !this.Task.Equal(that1.Task) {
+	if len(this.Results) != len(that1.Results) {
 		return false
 	}
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *TaskLifecycleResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&models.TaskLifecycleResponse{` +
-		`Error:` + fmt.Sprintf("%#v", this.Error) + `}`}, ", ")
-	return s
-}
-func (this *DesireTaskRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *CompleteTaskRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := strings.Join([]string{`&models.DesireTaskRequest{` +
-		`TaskDefinition:` + fmt.Sprintf("%#v", this.TaskDefinition),
-		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
-		`Domain:` + fmt.Sprintf("%#v", this.Domain) + `}`}, ", ")
-	return s
-}
-func (this *StartTaskRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*CompleteTaskRequest)
+	if !ok {
+		return false
 	}
-	s := strings.Join([]string{`&models.StartTaskRequest{` +
-		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
-		`CellId:` + fmt.Sprintf("%#v", this.CellId) + `}`}, ", ")
-	return s
-}
-func (this *StartTaskResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&models.StartTaskResponse{` +
-		`Error:` + fmt.Sprintf("%#v", this.Error),
-		`ShouldStart:` + fmt.Sprintf("%#v", this.ShouldStart) + `}`}, ", ")
-	return s
-}
-func (this *FailTaskRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.TaskGuid != that1.TaskGuid {
+		return false
 	}
-	s := strings.Join([]string{`&models.FailTaskRequest{` +
-		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
-		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason) + `}`}, ", ")
-	return s
-}
-func (this *TaskGuidRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.CellId != that1.CellId {
+		return false
 	}
-	s := strings.Join([]string{`&models.TaskGuidRequest{` +
-		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid) + `}`}, ", ")
-	return s
-}
-func (this *CompleteTaskRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Failed != that1.Failed {
+		return false
 	}
-	s := strings.Join([]string{`&models.CompleteTaskRequest{` +
-		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
-		`CellId:` + fmt.Sprintf("%#v", this.CellId),
-		`Failed:` + fmt.Sprintf("%#v", this.Failed),
-		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason),
-		`Result:` + fmt.Sprintf("%#v", this.Result) + `}`}, ", ")
-	return s
-}
-func (this *TaskCallbackResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.FailureReason != that1.FailureReason {
+		return false
 	}
-	s := strings.Join([]string{`&models.TaskCallbackResponse{` +
-		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
-		`Failed:` + fmt.Sprintf("%#v", this.Failed),
-		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason),
-		`Result:` + fmt.Sprintf("%#v", this.Result),
-		`Annotation:` + fmt.Sprintf("%#v", this.Annotation),
-		`CreatedAt:` + fmt.Sprintf("%#v", this.CreatedAt) + `}`}, ", ")
-	return s
-}
-func (this *ConvergeTasksRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Result != that1.Result {
+		return false
 	}
-	s := strings.Join([]string{`&models.ConvergeTasksRequest{` +
-		`KickTaskDuration:` + fmt.Sprintf("%#v", this.KickTaskDuration),
-		`ExpirePendingTaskDuration:` + fmt.Sprintf("%#v", this.ExpirePendingTaskDuration),
-		`ExpireCompletedTaskDuration:` + fmt.Sprintf("%#v", this.ExpireCompletedTaskDuration) + `}`}, ", ")
-	return s
+	return true
 }
-func (this *ConvergeTasksResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *TaskCallbackResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := strings.Join([]string{`&models.ConvergeTasksResponse{` +
-		`Error:` + fmt.Sprintf("%#v", this.Error) + `}`}, ", ")
-	return s
-}
-func (this *TasksRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*TaskCallbackResponse)
+	if !ok {
+		return false
 	}
-	s := strings.Join([]string{`&models.TasksRequest{` +
-		`Domain:` + fmt.Sprintf("%#v", this.Domain),
-		`CellId:` + fmt.Sprintf("%#v", this.CellId) + `}`}, ", ")
-	return s
-}
-func (this *TasksResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&models.TasksResponse{` +
-		`Error:` + fmt.Sprintf("%#v", this.Error),
-		`Tasks:` + fmt.Sprintf("%#v", this.Tasks) + `}`}, ", ")
-	return s
-}
-func (this *TaskByGuidRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.TaskGuid != that1.TaskGuid {
+		return false
 	}
-	s := strings.Join([]string{`&models.TaskByGuidRequest{` +
-		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid) + `}`}, ", ")
-	return s
-}
-func (this *TaskResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Failed != that1.Failed {
+		return false
 	}
-	s := strings.Join([]string{`&models.TaskResponse{` +
-		`Error:` + fmt.Sprintf("%#v", this.Error),
-		`Task:` + fmt.Sprintf("%#v", this.Task) + `}`}, ", ")
-	return s
-}
-func valueToGoStringTaskRequests(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if this.FailureReason != that1.FailureReason {
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-func extensionToGoStringTaskRequests(e map[int32]github_com_gogo_protobuf_proto.Extension) string {
-	if e == nil {
-		return "nil"
+	if this.Result != that1.Result {
+		return false
 	}
-	s := "map[int32]proto.Extension{"
-	keys := make([]int, 0, len(e))
-	for k := range e {
-		keys = append(keys, int(k))
+	if this.Annotation != that1.Annotation {
+		return false
 	}
-	sort.Ints(keys)
-	ss := []string{}
-	for _, k := range keys {
-		ss = append(ss, strconv.Itoa(k)+": "+e[int32(k)].GoString())
+	if this.CreatedAt != that1.CreatedAt {
+		return false
 	}
-	s += strings.Join(ss, ",") + "}"
-	return s
+	return true
 }
-func (m *TaskLifecycleResponse) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (this *ConvergeTasksRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	return data[:n], nil
-}
 
-func (m *TaskLifecycleResponse) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Error != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
-		n1, err := m.Error.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*ConvergeTasksRequest)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
 		}
-		i += n1
+		return false
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *DesireTaskRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+	if this.KickTaskDuration != that1.KickTaskDuration {
+		return false
 	}
-	return data[:n], nil
+	if this.ExpirePendingTaskDuration != that1.ExpirePendingTaskDuration {
+		return false
+	}
+	if this.ExpireCompletedTaskDuration != that1.ExpireCompletedTaskDuration {
+		return false
+	}
+	return true
 }
+func (this *ConvergeTasksResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *DesireTaskRequest) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.TaskDefinition != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintTaskRequests(data, i, uint64(m.TaskDefinition.Size()))
-		n2, err := m.TaskDefinition.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*ConvergeTasksResponse)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
 		}
-		i += n2
+		return false
+	} else if this == nil {
+		return false
 	}
-	data[i] = 0x12
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
-	i += copy(data[i:], m.TaskGuid)
-	data[i] = 0x1a
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.Domain)))
-	i += copy(data[i:], m.Domain)
-	return i, nil
-}
-
-func (m *StartTaskRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+	if !this.Error.Equal(that1.Error) {
+		return false
 	}
-	return data[:n], nil
-}
-
-func (m *StartTaskRequest) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	data[i] = 0xa
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
-	i += copy(data[i:], m.TaskGuid)
-	data[i] = 0x12
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
-	i += copy(data[i:], m.CellId)
-	return i, nil
+	return true
 }
+func (this *TasksRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *StartTaskResponse) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*TasksRequest)
+	if !ok {
+		return false
 	}
-	return data[:n], nil
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.Domain != that1.Domain {
+		return false
+	}
+	if this.CellId != that1.CellId {
+		return false
+	}
+	if this.MinPriority != that1.MinPriority {
+		return false
+	}
+	return true
 }
+func (this *TasksResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *StartTaskResponse) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Error != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
-		n3, err := m.Error.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*TasksResponse)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
 		}
-		i += n3
+		return false
+	} else if this == nil {
+		return false
 	}
-	data[i] = 0x10
-	i++
-	if m.ShouldStart {
-		data[i] = 1
-	} else {
-		data[i] = 0
+	if !this.Error.Equal(that1.Error) {
+		return false
 	}
-	i++
-	return i, nil
+	if len(this.Tasks) != len(that1.Tasks) {
+		return false
+	}
+	for i := range this.Tasks {
+		if !this.Tasks[i].Equal(that1.Tasks[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *TaskByGuidRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *FailTaskRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*TaskByGuidRequest)
+	if !ok {
+		return false
 	}
-	return data[:n], nil
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.TaskGuid != that1.TaskGuid {
+		return false
+	}
+	return true
 }
+func (this *TaskResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *FailTaskRequest) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	data[i] = 0xa
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
-	i += copy(data[i:], m.TaskGuid)
-	data[i] = 0x12
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.FailureReason)))
-	i += copy(data[i:], m.FailureReason)
-	return i, nil
+	that1, ok := that.(*TaskResponse)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if !this.Task.Equal(that1.Task) {
+		return false
+	}
+	return true
 }
+func (this *ArchivedTasksRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *TaskGuidRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ArchivedTasksRequest)
+	if !ok {
+		return false
 	}
-	return data[:n], nil
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.Domain != that1.Domain {
+		return false
+	}
+	if this.CompletedSince != that1.CompletedSince {
+		return false
+	}
+	if this.CompletedUntil != that1.CompletedUntil {
+		return false
+	}
+	if this.Limit != that1.Limit {
+		return false
+	}
+	return true
 }
+func (this *ArchivedTasksResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
 
-func (m *TaskGuidRequest) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	data[i] = 0xa
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
-	i += copy(data[i:], m.TaskGuid)
-	return i, nil
+	that1, ok := that.(*ArchivedTasksResponse)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if len(this.Tasks) != len(that1.Tasks) {
+		return false
+	}
+	for i := range this.Tasks {
+		if !this.Tasks[i].Equal(that1.Tasks[i]) {
+			return false
+		}
+	}
+	return true
 }
-
-func (m *CompleteTaskRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (this *TaskLifecycleResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return data[:n], nil
+	s := strings.Join([]string{`&models.TaskLifecycleResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error) + `}`}, ", ")
+	return s
 }
-
-func (m *CompleteTaskRequest) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	data[i] = 0xa
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
-	i += copy(data[i:], m.TaskGuid)
-	data[i] = 0x12
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
-	i += copy(data[i:], m.CellId)
-	data[i] = 0x18
-	i++
-	if m.Failed {
-		data[i] = 1
-	} else {
-		data[i] = 0
+func (this *DesireTaskRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	i++
-	data[i] = 0x22
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.FailureReason)))
-	i += copy(data[i:], m.FailureReason)
-	data[i] = 0x2a
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.Result)))
-	i += copy(data[i:], m.Result)
-	return i, nil
+	s := strings.Join([]string{`&models.DesireTaskRequest{` +
+		`TaskDefinition:` + fmt.Sprintf("%#v", this.TaskDefinition),
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`Domain:` + fmt.Sprintf("%#v", this.Domain) + `}`}, ", ")
+	return s
 }
-
-func (m *TaskCallbackResponse) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (this *StartTaskRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return data[:n], nil
+	s := strings.Join([]string{`&models.StartTaskRequest{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`CellId:` + fmt.Sprintf("%#v", this.CellId) + `}`}, ", ")
+	return s
 }
-
-func (m *TaskCallbackResponse) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	data[i] = 0xa
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
-	i += copy(data[i:], m.TaskGuid)
-	data[i] = 0x10
-	i++
-	if m.Failed {
-		data[i] = 1
-	} else {
-		data[i] = 0
+func (this *StartTaskResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	i++
-	data[i] = 0x1a
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.FailureReason)))
-	i += copy(data[i:], m.FailureReason)
-	data[i] = 0x22
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.Result)))
-	i += copy(data[i:], m.Result)
-	data[i] = 0x2a
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.Annotation)))
-	i += copy(data[i:], m.Annotation)
-	data[i] = 0x30
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(m.CreatedAt))
-	return i, nil
+	s := strings.Join([]string{`&models.StartTaskResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`ShouldStart:` + fmt.Sprintf("%#v", this.ShouldStart) + `}`}, ", ")
+	return s
 }
-
-func (m *ConvergeTasksRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (this *TaskHeartbeatRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return data[:n], nil
+	s := strings.Join([]string{`&models.TaskHeartbeatRequest{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`CellId:` + fmt.Sprintf("%#v", this.CellId),
+		`ProgressMessage:` + fmt.Sprintf("%#v", this.ProgressMessage),
+		`ProgressPercent:` + fmt.Sprintf("%#v", this.ProgressPercent) + `}`}, ", ")
+	return s
 }
-
-func (m *ConvergeTasksRequest) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	data[i] = 0x8
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(m.KickTaskDuration))
-	data[i] = 0x10
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(m.ExpirePendingTaskDuration))
-	data[i] = 0x18
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(m.ExpireCompletedTaskDuration))
-	return i, nil
+func (this *FailTaskRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.FailTaskRequest{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason) + `}`}, ", ")
+	return s
 }
-
-func (m *ConvergeTasksResponse) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (this *TaskGuidRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return data[:n], nil
+	s := strings.Join([]string{`&models.TaskGuidRequest{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid) + `}`}, ", ")
+	return s
 }
-
-func (m *ConvergeTasksResponse) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Error != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
-		n4, err := m.Error.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
+func (this *CancelTaskRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return i, nil
+	s := strings.Join([]string{`&models.CancelTaskRequest{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`Reason:` + fmt.Sprintf("%#v", this.Reason) + `}`}, ", ")
+	return s
 }
-
-func (m *TasksRequest) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (this *TaskSelector) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return data[:n], nil
+	s := strings.Join([]string{`&models.TaskSelector{` +
+		`Domain:` + fmt.Sprintf("%#v", this.Domain),
+		`State:` + fmt.Sprintf("%#v", this.State),
+		`CellId:` + fmt.Sprintf("%#v", this.CellId),
+		`MinAge:` + fmt.Sprintf("%#v", this.MinAge) + `}`}, ", ")
+	return s
 }
-
-func (m *TasksRequest) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	data[i] = 0xa
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.Domain)))
-	i += copy(data[i:], m.Domain)
-	data[i] = 0x12
-	i++
-	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
-	i += copy(data[i:], m.CellId)
-	return i, nil
+func (this *TaskResult) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskResult{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`Error:` + fmt.Sprintf("%#v", this.Error) + `}`}, ", ")
+	return s
 }
-
-func (m *TasksResponse) Marshal() (data []byte, err error) {
-	size := m.Size()
-	data = make([]byte, size)
-	n, err := m.MarshalTo(data)
-	if err != nil {
-		return nil, err
+func (this *CancelTasksRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return data[:n], nil
+	s := strings.Join([]string{`&models.CancelTasksRequest{` +
+		`Selector:` + fmt.Sprintf("%#v", this.Selector),
+		`Reason:` + fmt.Sprintf("%#v", this.Reason) + `}`}, ", ")
+	return s
 }
-
-func (m *TasksResponse) MarshalTo(data []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
+func (this *CancelTasksResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.CancelTasksResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`Results:` + fmt.Sprintf("%#v", this.Results) + `}`}, ", ")
+	return s
+}
+func (this *DeleteTasksRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.DeleteTasksRequest{` +
+		`Selector:` + fmt.Sprintf("%#v", this.Selector) + `}`}, ", ")
+	return s
+}
+func (this *DeleteTasksResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.DeleteTasksResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`Results:` + fmt.Sprintf("%#v", this.Results) + `}`}, ", ")
+	return s
+}
+func (this *CompleteTaskRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.CompleteTaskRequest{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`CellId:` + fmt.Sprintf("%#v", this.CellId),
+		`Failed:` + fmt.Sprintf("%#v", this.Failed),
+		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason),
+		`Result:` + fmt.Sprintf("%#v", this.Result) + `}`}, ", ")
+	return s
+}
+func (this *TaskCallbackResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskCallbackResponse{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid),
+		`Failed:` + fmt.Sprintf("%#v", this.Failed),
+		`FailureReason:` + fmt.Sprintf("%#v", this.FailureReason),
+		`Result:` + fmt.Sprintf("%#v", this.Result),
+		`Annotation:` + fmt.Sprintf("%#v", this.Annotation),
+		`CreatedAt:` + fmt.Sprintf("%#v", this.CreatedAt) + `}`}, ", ")
+	return s
+}
+func (this *ConvergeTasksRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ConvergeTasksRequest{` +
+		`KickTaskDuration:` + fmt.Sprintf("%#v", this.KickTaskDuration),
+		`ExpirePendingTaskDuration:` + fmt.Sprintf("%#v", this.ExpirePendingTaskDuration),
+		`ExpireCompletedTaskDuration:` + fmt.Sprintf("%#v", this.ExpireCompletedTaskDuration) + `}`}, ", ")
+	return s
+}
+func (this *ConvergeTasksResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ConvergeTasksResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error) + `}`}, ", ")
+	return s
+}
+func (this *TasksRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TasksRequest{` +
+		`Domain:` + fmt.Sprintf("%#v", this.Domain),
+		`CellId:` + fmt.Sprintf("%#v", this.CellId),
+		`MinPriority:` + fmt.Sprintf("%#v", this.MinPriority) + `}`}, ", ")
+	return s
+}
+func (this *TasksResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TasksResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`Tasks:` + fmt.Sprintf("%#v", this.Tasks) + `}`}, ", ")
+	return s
+}
+func (this *TaskByGuidRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskByGuidRequest{` +
+		`TaskGuid:` + fmt.Sprintf("%#v", this.TaskGuid) + `}`}, ", ")
+	return s
+}
+func (this *TaskResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`Task:` + fmt.Sprintf("%#v", this.Task) + `}`}, ", ")
+	return s
+}
+func (this *ArchivedTasksRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ArchivedTasksRequest{` +
+		`Domain:` + fmt.Sprintf("%#v", this.Domain),
+		`CompletedSince:` + fmt.Sprintf("%#v", this.CompletedSince),
+		`CompletedUntil:` + fmt.Sprintf("%#v", this.CompletedUntil),
+		`Limit:` + fmt.Sprintf("%#v", this.Limit) + `}`}, ", ")
+	return s
+}
+func (this *ArchivedTasksResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ArchivedTasksResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`Tasks:` + fmt.Sprintf("%#v", this.Tasks) + `}`}, ", ")
+	return s
+}
+func valueToGoStringTaskRequests(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func extensionToGoStringTaskRequests(e map[int32]github_com_gogo_protobuf_proto.Extension) string {
+	if e == nil {
+		return "nil"
+	}
+	s := "map[int32]proto.Extension{"
+	keys := make([]int, 0, len(e))
+	for k := range e {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	ss := []string{}
+	for _, k := range keys {
+		ss = append(ss, strconv.Itoa(k)+": "+e[int32(k)].GoString())
+	}
+	s += strings.Join(ss, ",") + "}"
+	return s
+}
+func (m *TaskLifecycleResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskLifecycleResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
 	if m.Error != nil {
 		data[i] = 0xa
 		i++
 		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
-		n5, err := m.Error.MarshalTo(data[i:])
+		n1, err := m.Error.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n1
 	}
-	if len(m.Tasks) > 0 {
-		for _, msg := range m.Tasks {
-			data[i] = 0x12
-			i++
-			i = encodeVarintTaskRequests(data, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(data[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	return i, nil
+}
+
+func (m *DesireTaskRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DesireTaskRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.TaskDefinition != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.TaskDefinition.Size()))
+		n2, err := m.TaskDefinition.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n2
 	}
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	data[i] = 0x1a
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Domain)))
+	i += copy(data[i:], m.Domain)
 	return i, nil
 }
 
-func (m *TaskByGuidRequest) Marshal() (data []byte, err error) {
+func (m *StartTaskRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -1330,7 +1689,7 @@ func (m *TaskByGuidRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *TaskByGuidRequest) MarshalTo(data []byte) (int, error) {
+func (m *StartTaskRequest) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1339,10 +1698,14 @@ func (m *TaskByGuidRequest) MarshalTo(data []byte) (int, error) {
 	i++
 	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
 	i += copy(data[i:], m.TaskGuid)
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
+	i += copy(data[i:], m.CellId)
 	return i, nil
 }
 
-func (m *TaskResponse) Marshal() (data []byte, err error) {
+func (m *StartTaskResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -1352,7 +1715,7 @@ func (m *TaskResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *TaskResponse) MarshalTo(data []byte) (int, error) {
+func (m *StartTaskResponse) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1361,394 +1724,1978 @@ func (m *TaskResponse) MarshalTo(data []byte) (int, error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
-		n6, err := m.Error.MarshalTo(data[i:])
+		n3, err := m.Error.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n6
+		i += n3
 	}
-	if m.Task != nil {
-		data[i] = 0x12
-		i++
-		i = encodeVarintTaskRequests(data, i, uint64(m.Task.Size()))
-		n7, err := m.Task.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
+	data[i] = 0x10
+	i++
+	if m.ShouldStart {
+		data[i] = 1
+	} else {
+		data[i] = 0
 	}
+	i++
 	return i, nil
 }
 
-func encodeFixed64TaskRequests(data []byte, offset int, v uint64) int {
-	data[offset] = uint8(v)
-	data[offset+1] = uint8(v >> 8)
-	data[offset+2] = uint8(v >> 16)
-	data[offset+3] = uint8(v >> 24)
-	data[offset+4] = uint8(v >> 32)
-	data[offset+5] = uint8(v >> 40)
-	data[offset+6] = uint8(v >> 48)
-	data[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32TaskRequests(data []byte, offset int, v uint32) int {
-	data[offset] = uint8(v)
-	data[offset+1] = uint8(v >> 8)
-	data[offset+2] = uint8(v >> 16)
-	data[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintTaskRequests(data []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		data[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *TaskHeartbeatRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	data[offset] = uint8(v)
-	return offset + 1
+	return data[:n], nil
 }
-func (m *TaskLifecycleResponse) Size() (n int) {
+
+func (m *TaskHeartbeatRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovTaskRequests(uint64(l))
-	}
-	return n
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
+	i += copy(data[i:], m.CellId)
+	data[i] = 0x1a
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.ProgressMessage)))
+	i += copy(data[i:], m.ProgressMessage)
+	data[i] = 0x20
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.ProgressPercent))
+	return i, nil
 }
 
-func (m *DesireTaskRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.TaskDefinition != nil {
-		l = m.TaskDefinition.Size()
-		n += 1 + l + sovTaskRequests(uint64(l))
+func (m *FailTaskRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.TaskGuid)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.Domain)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	return n
+	return data[:n], nil
 }
 
-func (m *StartTaskRequest) Size() (n int) {
+func (m *FailTaskRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.TaskGuid)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.CellId)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	return n
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.FailureReason)))
+	i += copy(data[i:], m.FailureReason)
+	return i, nil
 }
 
-func (m *StartTaskResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovTaskRequests(uint64(l))
+func (m *TaskGuidRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	n += 2
-	return n
+	return data[:n], nil
 }
 
-func (m *FailTaskRequest) Size() (n int) {
+func (m *TaskGuidRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.TaskGuid)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.FailureReason)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	return n
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	return i, nil
 }
 
-func (m *TaskGuidRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.TaskGuid)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	return n
+func (m *CancelTaskRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
 }
 
-func (m *CompleteTaskRequest) Size() (n int) {
+func (m *CancelTaskRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.TaskGuid)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.CellId)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	n += 2
-	l = len(m.FailureReason)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.Result)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	return n
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Reason)))
+	i += copy(data[i:], m.Reason)
+	return i, nil
 }
 
-func (m *TaskCallbackResponse) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.TaskGuid)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	n += 2
-	l = len(m.FailureReason)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.Result)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.Annotation)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	n += 1 + sovTaskRequests(uint64(m.CreatedAt))
-	return n
+func (m *TaskSelector) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
 }
 
-func (m *ConvergeTasksRequest) Size() (n int) {
+func (m *TaskSelector) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 1 + sovTaskRequests(uint64(m.KickTaskDuration))
-	n += 1 + sovTaskRequests(uint64(m.ExpirePendingTaskDuration))
-	n += 1 + sovTaskRequests(uint64(m.ExpireCompletedTaskDuration))
-	return n
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Domain)))
+	i += copy(data[i:], m.Domain)
+	data[i] = 0x10
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.State))
+	data[i] = 0x1a
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
+	i += copy(data[i:], m.CellId)
+	data[i] = 0x20
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.MinAge))
+	return i, nil
 }
 
-func (m *ConvergeTasksResponse) Size() (n int) {
+func (m *TaskResult) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskResult) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
 	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovTaskRequests(uint64(l))
+		data[i] = 0x12
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
+		n4, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
 	}
-	return n
+	return i, nil
 }
 
-func (m *TasksRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Domain)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	l = len(m.CellId)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	return n
+func (m *CancelTasksRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
 }
 
-func (m *TasksResponse) Size() (n int) {
+func (m *CancelTasksRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovTaskRequests(uint64(l))
-	}
-	if len(m.Tasks) > 0 {
-		for _, e := range m.Tasks {
-			l = e.Size()
-			n += 1 + l + sovTaskRequests(uint64(l))
+	if m.Selector != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Selector.Size()))
+		n5, err := m.Selector.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n5
 	}
-	return n
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Reason)))
+	i += copy(data[i:], m.Reason)
+	return i, nil
 }
 
-func (m *TaskByGuidRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.TaskGuid)
-	n += 1 + l + sovTaskRequests(uint64(l))
-	return n
+func (m *CancelTasksResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
 }
 
-func (m *TaskResponse) Size() (n int) {
+func (m *CancelTasksResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovTaskRequests(uint64(l))
-	}
-	if m.Task != nil {
-		l = m.Task.Size()
-		n += 1 + l + sovTaskRequests(uint64(l))
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
+		n6, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
 	}
-	return n
-}
-
-func sovTaskRequests(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+	if len(m.Results) > 0 {
+		for _, msg := range m.Results {
+			data[i] = 0x12
+			i++
+			i = encodeVarintTaskRequests(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	return n
-}
-func sozTaskRequests(x uint64) (n int) {
-	return sovTaskRequests(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return i, nil
 }
-func (this *TaskLifecycleResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *DeleteTasksRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&TaskLifecycleResponse{`,
-		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return data[:n], nil
 }
-func (this *DesireTaskRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *DeleteTasksRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Selector != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Selector.Size()))
+		n7, err := m.Selector.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
 	}
-	s := strings.Join([]string{`&DesireTaskRequest{`,
-		`TaskDefinition:` + strings.Replace(fmt.Sprintf("%v", this.TaskDefinition), "TaskDefinition", "TaskDefinition", 1) + `,`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
-		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
-		`}`,
-	}, "")
-	return s
+	return i, nil
 }
-func (this *StartTaskRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *DeleteTasksResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&StartTaskRequest{`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
-		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
-		`}`,
-	}, "")
-	return s
+	return data[:n], nil
 }
-func (this *StartTaskResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *DeleteTasksResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
+		n8, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
-	s := strings.Join([]string{`&StartTaskResponse{`,
-		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
-		`ShouldStart:` + fmt.Sprintf("%v", this.ShouldStart) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *FailTaskRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Results) > 0 {
+		for _, msg := range m.Results {
+			data[i] = 0x12
+			i++
+			i = encodeVarintTaskRequests(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	s := strings.Join([]string{`&FailTaskRequest{`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
-		`FailureReason:` + fmt.Sprintf("%v", this.FailureReason) + `,`,
-		`}`,
-	}, "")
-	return s
+	return i, nil
 }
-func (this *TaskGuidRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *CompleteTaskRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&TaskGuidRequest{`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
-		`}`,
-	}, "")
-	return s
+	return data[:n], nil
 }
-func (this *CompleteTaskRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *CompleteTaskRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
+	i += copy(data[i:], m.CellId)
+	data[i] = 0x18
+	i++
+	if m.Failed {
+		data[i] = 1
+	} else {
+		data[i] = 0
 	}
-	s := strings.Join([]string{`&CompleteTaskRequest{`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
-		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
-		`Failed:` + fmt.Sprintf("%v", this.Failed) + `,`,
-		`FailureReason:` + fmt.Sprintf("%v", this.FailureReason) + `,`,
-		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
-		`}`,
-	}, "")
-	return s
+	i++
+	data[i] = 0x22
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.FailureReason)))
+	i += copy(data[i:], m.FailureReason)
+	data[i] = 0x2a
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Result)))
+	i += copy(data[i:], m.Result)
+	return i, nil
 }
-func (this *TaskCallbackResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *TaskCallbackResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&TaskCallbackResponse{`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
-		`Failed:` + fmt.Sprintf("%v", this.Failed) + `,`,
-		`FailureReason:` + fmt.Sprintf("%v", this.FailureReason) + `,`,
-		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
-		`Annotation:` + fmt.Sprintf("%v", this.Annotation) + `,`,
-		`CreatedAt:` + fmt.Sprintf("%v", this.CreatedAt) + `,`,
-		`}`,
-	}, "")
-	return s
+	return data[:n], nil
 }
-func (this *ConvergeTasksRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *TaskCallbackResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	data[i] = 0x10
+	i++
+	if m.Failed {
+		data[i] = 1
+	} else {
+		data[i] = 0
 	}
-	s := strings.Join([]string{`&ConvergeTasksRequest{`,
-		`KickTaskDuration:` + fmt.Sprintf("%v", this.KickTaskDuration) + `,`,
-		`ExpirePendingTaskDuration:` + fmt.Sprintf("%v", this.ExpirePendingTaskDuration) + `,`,
-		`ExpireCompletedTaskDuration:` + fmt.Sprintf("%v", this.ExpireCompletedTaskDuration) + `,`,
-		`}`,
-	}, "")
-	return s
+	i++
+	data[i] = 0x1a
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.FailureReason)))
+	i += copy(data[i:], m.FailureReason)
+	data[i] = 0x22
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Result)))
+	i += copy(data[i:], m.Result)
+	data[i] = 0x2a
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Annotation)))
+	i += copy(data[i:], m.Annotation)
+	data[i] = 0x30
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.CreatedAt))
+	return i, nil
 }
-func (this *ConvergeTasksResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ConvergeTasksRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ConvergeTasksResponse{`,
-		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return data[:n], nil
 }
-func (this *TasksRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&TasksRequest{`,
-		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
-		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *ConvergeTasksRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.KickTaskDuration))
+	data[i] = 0x10
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.ExpirePendingTaskDuration))
+	data[i] = 0x18
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.ExpireCompletedTaskDuration))
+	return i, nil
 }
-func (this *TasksResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ConvergeTasksResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&TasksResponse{`,
-		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
-		`Tasks:` + strings.Replace(fmt.Sprintf("%v", this.Tasks), "Task", "Task", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return data[:n], nil
 }
-func (this *TaskByGuidRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ConvergeTasksResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
+		n9, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
 	}
-	s := strings.Join([]string{`&TaskByGuidRequest{`,
-		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
-		`}`,
-	}, "")
-	return s
+	return i, nil
 }
-func (this *TaskResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *TasksRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&TaskResponse{`,
-		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
-		`Task:` + strings.Replace(fmt.Sprintf("%v", this.Task), "Task", "Task", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return data[:n], nil
 }
-func valueToStringTaskRequests(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+func (m *TasksRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Domain)))
+	i += copy(data[i:], m.Domain)
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.CellId)))
+	i += copy(data[i:], m.CellId)
+	data[i] = 0x18
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.MinPriority))
+	return i, nil
+}
+
+func (m *TasksResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return data[:n], nil
+}
+
+func (m *TasksResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
+		n10, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if len(m.Tasks) > 0 {
+		for _, msg := range m.Tasks {
+			data[i] = 0x12
+			i++
+			i = encodeVarintTaskRequests(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *TaskByGuidRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskByGuidRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.TaskGuid)))
+	i += copy(data[i:], m.TaskGuid)
+	return i, nil
+}
+
+func (m *TaskResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
+		n11, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.Task != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Task.Size()))
+		n12, err := m.Task.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	return i, nil
+}
+
+func (m *ArchivedTasksRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ArchivedTasksRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(len(m.Domain)))
+	i += copy(data[i:], m.Domain)
+	data[i] = 0x10
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.CompletedSince))
+	data[i] = 0x18
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.CompletedUntil))
+	data[i] = 0x20
+	i++
+	i = encodeVarintTaskRequests(data, i, uint64(m.Limit))
+	return i, nil
+}
+
+func (m *ArchivedTasksResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ArchivedTasksResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskRequests(data, i, uint64(m.Error.Size()))
+		n13, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if len(m.Tasks) > 0 {
+		for _, msg := range m.Tasks {
+			data[i] = 0x12
+			i++
+			i = encodeVarintTaskRequests(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeFixed64TaskRequests(data []byte, offset int, v uint64) int {
+	data[offset] = uint8(v)
+	data[offset+1] = uint8(v >> 8)
+	data[offset+2] = uint8(v >> 16)
+	data[offset+3] = uint8(v >> 24)
+	data[offset+4] = uint8(v >> 32)
+	data[offset+5] = uint8(v >> 40)
+	data[offset+6] = uint8(v >> 48)
+	data[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32TaskRequests(data []byte, offset int, v uint32) int {
+	data[offset] = uint8(v)
+	data[offset+1] = uint8(v >> 8)
+	data[offset+2] = uint8(v >> 16)
+	data[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintTaskRequests(data []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		data[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	data[offset] = uint8(v)
+	return offset + 1
+}
+func (m *TaskLifecycleResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	return n
+}
+
+func (m *DesireTaskRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.TaskDefinition != nil {
+		l = m.TaskDefinition.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.Domain)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *StartTaskRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.CellId)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *StartTaskResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	n += 2
+	return n
+}
+
+func (m *TaskHeartbeatRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.CellId)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.ProgressMessage)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 1 + sovTaskRequests(uint64(m.ProgressPercent))
+	return n
+}
+
+func (m *FailTaskRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.FailureReason)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *TaskGuidRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *CancelTaskRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.Reason)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *TaskSelector) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Domain)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 1 + sovTaskRequests(uint64(m.State))
+	l = len(m.CellId)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 1 + sovTaskRequests(uint64(m.MinAge))
+	return n
+}
+
+func (m *TaskResult) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	return n
+}
+
+func (m *CancelTasksRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Selector != nil {
+		l = m.Selector.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	l = len(m.Reason)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *CancelTasksResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovTaskRequests(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DeleteTasksRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Selector != nil {
+		l = m.Selector.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteTasksResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovTaskRequests(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CompleteTaskRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.CellId)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 2
+	l = len(m.FailureReason)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.Result)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *TaskCallbackResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 2
+	l = len(m.FailureReason)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.Result)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.Annotation)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 1 + sovTaskRequests(uint64(m.CreatedAt))
+	return n
+}
+
+func (m *ConvergeTasksRequest) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovTaskRequests(uint64(m.KickTaskDuration))
+	n += 1 + sovTaskRequests(uint64(m.ExpirePendingTaskDuration))
+	n += 1 + sovTaskRequests(uint64(m.ExpireCompletedTaskDuration))
+	return n
+}
+
+func (m *ConvergeTasksResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	return n
+}
+
+func (m *TasksRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Domain)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	l = len(m.CellId)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 1 + sovTaskRequests(uint64(m.MinPriority))
+	return n
+}
+
+func (m *TasksResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	if len(m.Tasks) > 0 {
+		for _, e := range m.Tasks {
+			l = e.Size()
+			n += 1 + l + sovTaskRequests(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TaskByGuidRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	return n
+}
+
+func (m *TaskResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	if m.Task != nil {
+		l = m.Task.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	return n
+}
+
+func (m *ArchivedTasksRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Domain)
+	n += 1 + l + sovTaskRequests(uint64(l))
+	n += 1 + sovTaskRequests(uint64(m.CompletedSince))
+	n += 1 + sovTaskRequests(uint64(m.CompletedUntil))
+	n += 1 + sovTaskRequests(uint64(m.Limit))
+	return n
+}
+
+func (m *ArchivedTasksResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskRequests(uint64(l))
+	}
+	if len(m.Tasks) > 0 {
+		for _, e := range m.Tasks {
+			l = e.Size()
+			n += 1 + l + sovTaskRequests(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovTaskRequests(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozTaskRequests(x uint64) (n int) {
+	return sovTaskRequests(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *TaskLifecycleResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskLifecycleResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DesireTaskRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DesireTaskRequest{`,
+		`TaskDefinition:` + strings.Replace(fmt.Sprintf("%v", this.TaskDefinition), "TaskDefinition", "TaskDefinition", 1) + `,`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StartTaskRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StartTaskRequest{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StartTaskResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StartTaskResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`ShouldStart:` + fmt.Sprintf("%v", this.ShouldStart) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskHeartbeatRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskHeartbeatRequest{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
+		`ProgressMessage:` + fmt.Sprintf("%v", this.ProgressMessage) + `,`,
+		`ProgressPercent:` + fmt.Sprintf("%v", this.ProgressPercent) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FailTaskRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FailTaskRequest{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`FailureReason:` + fmt.Sprintf("%v", this.FailureReason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskGuidRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskGuidRequest{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CancelTaskRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CancelTaskRequest{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskSelector) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskSelector{`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
+		`MinAge:` + fmt.Sprintf("%v", this.MinAge) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskResult) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskResult{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CancelTasksRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CancelTasksRequest{`,
+		`Selector:` + strings.Replace(fmt.Sprintf("%v", this.Selector), "TaskSelector", "TaskSelector", 1) + `,`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CancelTasksResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CancelTasksResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`Results:` + strings.Replace(fmt.Sprintf("%v", this.Results), "TaskResult", "TaskResult", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteTasksRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteTasksRequest{`,
+		`Selector:` + strings.Replace(fmt.Sprintf("%v", this.Selector), "TaskSelector", "TaskSelector", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteTasksResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteTasksResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`Results:` + strings.Replace(fmt.Sprintf("%v", this.Results), "TaskResult", "TaskResult", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CompleteTaskRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CompleteTaskRequest{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
+		`Failed:` + fmt.Sprintf("%v", this.Failed) + `,`,
+		`FailureReason:` + fmt.Sprintf("%v", this.FailureReason) + `,`,
+		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskCallbackResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskCallbackResponse{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`Failed:` + fmt.Sprintf("%v", this.Failed) + `,`,
+		`FailureReason:` + fmt.Sprintf("%v", this.FailureReason) + `,`,
+		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
+		`Annotation:` + fmt.Sprintf("%v", this.Annotation) + `,`,
+		`CreatedAt:` + fmt.Sprintf("%v", this.CreatedAt) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConvergeTasksRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConvergeTasksRequest{`,
+		`KickTaskDuration:` + fmt.Sprintf("%v", this.KickTaskDuration) + `,`,
+		`ExpirePendingTaskDuration:` + fmt.Sprintf("%v", this.ExpirePendingTaskDuration) + `,`,
+		`ExpireCompletedTaskDuration:` + fmt.Sprintf("%v", this.ExpireCompletedTaskDuration) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConvergeTasksResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConvergeTasksResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TasksRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TasksRequest{`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
+		`MinPriority:` + fmt.Sprintf("%v", this.MinPriority) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TasksResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TasksResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`Tasks:` + strings.Replace(fmt.Sprintf("%v", this.Tasks), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskByGuidRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskByGuidRequest{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`Task:` + strings.Replace(fmt.Sprintf("%v", this.Task), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ArchivedTasksRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ArchivedTasksRequest{`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`CompletedSince:` + fmt.Sprintf("%v", this.CompletedSince) + `,`,
+		`CompletedUntil:` + fmt.Sprintf("%v", this.CompletedUntil) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ArchivedTasksResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ArchivedTasksResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`Tasks:` + strings.Replace(fmt.Sprintf("%v", this.Tasks), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringTaskRequests(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *TaskLifecycleResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *DesireTaskRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskDefinition", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TaskDefinition == nil {
+				m.TaskDefinition = &TaskDefinition{}
+			}
+			if err := m.TaskDefinition.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *StartTaskRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CellId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CellId = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *StartTaskResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShouldStart", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ShouldStart = bool(v != 0)
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskHeartbeatRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CellId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CellId = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProgressMessage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProgressMessage = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProgressPercent", wireType)
+			}
+			m.ProgressPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ProgressPercent |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *FailTaskRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailureReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FailureReason = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskGuidRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
 }
-func (m *TaskLifecycleResponse) Unmarshal(data []byte) error {
+func (m *CancelTaskRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1769,33 +3716,53 @@ func (m *TaskLifecycleResponse) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if msglen < 0 {
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
 				return ErrInvalidLengthTaskRequests
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &Error{}
+			m.TaskGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Reason = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			var sizeOfWire int
@@ -1823,7 +3790,7 @@ func (m *TaskLifecycleResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *DesireTaskRequest) Unmarshal(data []byte) error {
+func (m *TaskSelector) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1844,62 +3811,48 @@ func (m *DesireTaskRequest) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskDefinition", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if msglen < 0 {
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
 				return ErrInvalidLengthTaskRequests
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TaskDefinition == nil {
-				m.TaskDefinition = &TaskDefinition{}
-			}
-			if err := m.TaskDefinition.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Domain = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			var stringLen uint64
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.State |= (Task_State(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + int(stringLen)
-			if stringLen < 0 {
-				return ErrInvalidLengthTaskRequests
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TaskGuid = string(data[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CellId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1920,8 +3873,24 @@ func (m *DesireTaskRequest) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Domain = string(data[iNdEx:postIndex])
+			m.CellId = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinAge", wireType)
+			}
+			m.MinAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MinAge |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -1948,7 +3917,7 @@ func (m *DesireTaskRequest) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *StartTaskRequest) Unmarshal(data []byte) error {
+func (m *TaskResult) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1994,28 +3963,33 @@ func (m *StartTaskRequest) Unmarshal(data []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CellId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + int(stringLen)
-			if stringLen < 0 {
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
 				return ErrInvalidLengthTaskRequests
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CellId = string(data[iNdEx:postIndex])
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			var sizeOfWire int
@@ -2043,7 +4017,7 @@ func (m *StartTaskRequest) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *StartTaskResponse) Unmarshal(data []byte) error {
+func (m *CancelTasksRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2064,7 +4038,7 @@ func (m *StartTaskResponse) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Selector", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2085,30 +4059,38 @@ func (m *StartTaskResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &Error{}
+			if m.Selector == nil {
+				m.Selector = &TaskSelector{}
 			}
-			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Selector.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShouldStart", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ShouldStart = bool(v != 0)
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -2135,7 +4117,7 @@ func (m *StartTaskResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *FailTaskRequest) Unmarshal(data []byte) error {
+func (m *CancelTasksResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2156,53 +4138,61 @@ func (m *FailTaskRequest) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + int(stringLen)
-			if stringLen < 0 {
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
 				return ErrInvalidLengthTaskRequests
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TaskGuid = string(data[iNdEx:postIndex])
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FailureReason", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + int(stringLen)
-			if stringLen < 0 {
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
 				return ErrInvalidLengthTaskRequests
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FailureReason = string(data[iNdEx:postIndex])
+			m.Results = append(m.Results, &TaskResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			var sizeOfWire int
@@ -2230,7 +4220,82 @@ func (m *FailTaskRequest) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *TaskGuidRequest) Unmarshal(data []byte) error {
+func (m *DeleteTasksRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Selector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Selector == nil {
+				m.Selector = &TaskSelector{}
+			}
+			if err := m.Selector.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *DeleteTasksResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2239,40 +4304,73 @@ func (m *TaskGuidRequest) Unmarshal(data []byte) error {
 			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + int(stringLen)
-			if stringLen < 0 {
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
 				return ErrInvalidLengthTaskRequests
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TaskGuid = string(data[iNdEx:postIndex])
+			m.Results = append(m.Results, &TaskResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			var sizeOfWire int
@@ -2877,6 +4975,22 @@ func (m *TasksRequest) Unmarshal(data []byte) error {
 			}
 			m.CellId = string(data[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinPriority", wireType)
+			}
+			m.MinPriority = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MinPriority |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -3181,6 +5295,227 @@ func (m *TaskResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
+func (m *ArchivedTasksRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompletedSince", wireType)
+			}
+			m.CompletedSince = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.CompletedSince |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompletedUntil", wireType)
+			}
+			m.CompletedUntil = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.CompletedUntil |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Limit |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *ArchivedTasksResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tasks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tasks = append(m.Tasks, &Task{})
+			if err := m.Tasks[len(m.Tasks)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskRequests(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
 func skipTaskRequests(data []byte) (n int, err error) {
 	l := len(data)
 	iNdEx := 0
@@ -1,6 +1,8 @@
 package models_test
 
 import (
+	"strings"
+
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
 	. "github.com/onsi/ginkgo"
@@ -8,6 +10,32 @@ import (
 )
 
 var _ = Describe("Task requests", func() {
+	Describe("TasksRequest", func() {
+		Describe("Validate", func() {
+			var request models.TasksRequest
+
+			BeforeEach(func() {
+				request = models.TasksRequest{MinPriority: 10}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the MinPriority is out of range", func() {
+				BeforeEach(func() {
+					request.MinPriority = -1
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"min_priority"}))
+				})
+			})
+		})
+	})
+
 	Describe("TaskByGuidRequest", func() {
 		Describe("Validate", func() {
 			var request models.TaskByGuidRequest
@@ -135,6 +163,180 @@ var _ = Describe("Task requests", func() {
 		})
 	})
 
+	Describe("TaskHeartbeatRequest", func() {
+		Describe("Validate", func() {
+			var request models.TaskHeartbeatRequest
+
+			BeforeEach(func() {
+				request = models.TaskHeartbeatRequest{
+					TaskGuid:        "t-guid",
+					CellId:          "c-id",
+					ProgressMessage: "downloading",
+					ProgressPercent: 42,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the CellId is blank", func() {
+				BeforeEach(func() {
+					request.CellId = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"cell_id"}))
+				})
+			})
+
+			Context("when the ProgressMessage is too long", func() {
+				BeforeEach(func() {
+					request.ProgressMessage = strings.Repeat("a", models.MaxProgressMessageLength+1)
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"progress_message"}))
+				})
+			})
+
+			Context("when the ProgressPercent is out of range", func() {
+				BeforeEach(func() {
+					request.ProgressPercent = 101
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"progress_percent"}))
+				})
+			})
+		})
+	})
+
+	Describe("CancelTaskRequest", func() {
+		Describe("Validate", func() {
+			var request models.CancelTaskRequest
+
+			BeforeEach(func() {
+				request = models.CancelTaskRequest{
+					TaskGuid: "t-guid",
+					Reason:   "superseded",
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the TaskGuid is blank", func() {
+				BeforeEach(func() {
+					request.TaskGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"task_guid"}))
+				})
+			})
+
+			Context("when the Reason is too long", func() {
+				BeforeEach(func() {
+					request.Reason = strings.Repeat("a", models.MaxCancellationReasonLength+1)
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"reason"}))
+				})
+			})
+		})
+
+		Describe("FailureReason", func() {
+			It("returns the reason", func() {
+				request := models.CancelTaskRequest{TaskGuid: "t-guid", Reason: "superseded"}
+				Expect(request.FailureReason()).To(Equal("superseded"))
+			})
+
+			It("defaults to the cancelled reason", func() {
+				request := models.CancelTaskRequest{TaskGuid: "t-guid"}
+				Expect(request.FailureReason()).To(Equal(models.TaskCancelledReason))
+			})
+		})
+	})
+
+	Describe("TaskSelector", func() {
+		Describe("Validate", func() {
+			It("rejects an empty selector", func() {
+				selector := models.TaskSelector{}
+				Expect(selector.Validate()).To(ConsistOf(models.ErrInvalidField{"selector"}))
+			})
+
+			It("rejects an unknown state", func() {
+				selector := models.TaskSelector{Domain: "d", State: models.Task_State(42)}
+				Expect(selector.Validate()).To(ConsistOf(models.ErrInvalidField{"state"}))
+			})
+
+			It("rejects a negative age", func() {
+				selector := models.TaskSelector{Domain: "d", MinAge: -1}
+				Expect(selector.Validate()).To(ConsistOf(models.ErrInvalidField{"min_age"}))
+			})
+		})
+
+		Describe("Filter", func() {
+			It("filters on every field that is set", func() {
+				selector := models.TaskSelector{Domain: "the-domain", State: models.Task_Running, CellId: "the-cell", MinAge: 500}
+				Expect(selector.Filter(1500)).To(Equal(models.TaskFilter{
+					Domain:        "the-domain",
+					CellID:        "the-cell",
+					State:         models.Task_Running,
+					UpdatedBefore: 1000,
+				}))
+			})
+
+			It("does not filter on age when no age is given", func() {
+				selector := models.TaskSelector{Domain: "the-domain"}
+				Expect(selector.Filter(1500)).To(Equal(models.TaskFilter{Domain: "the-domain"}))
+			})
+		})
+	})
+
+	Describe("CancelTasksRequest", func() {
+		Describe("Validate", func() {
+			It("rejects a missing selector", func() {
+				request := models.CancelTasksRequest{}
+				Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"selector"}))
+			})
+
+			It("rejects an invalid selector", func() {
+				request := models.CancelTasksRequest{Selector: &models.TaskSelector{}}
+				Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"selector"}))
+			})
+
+			It("rejects a reason that is too long", func() {
+				request := models.CancelTasksRequest{
+					Selector: &models.TaskSelector{Domain: "d"},
+					Reason:   strings.Repeat("a", models.MaxCancellationReasonLength+1),
+				}
+				Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"reason"}))
+			})
+		})
+	})
+
+	Describe("DeleteTasksRequest", func() {
+		Describe("Validate", func() {
+			It("accepts a valid selector", func() {
+				request := models.DeleteTasksRequest{Selector: &models.TaskSelector{State: models.Task_Completed}}
+				Expect(request.Validate()).To(BeNil())
+			})
+
+			It("rejects a missing selector", func() {
+				request := models.DeleteTasksRequest{}
+				Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"selector"}))
+			})
+		})
+	})
+
 	Describe("FailTaskRequest", func() {
 		Describe("Validate", func() {
 			var request models.FailTaskRequest
@@ -173,4 +375,78 @@ var _ = Describe("Task requests", func() {
 			})
 		})
 	})
+	Describe("ArchivedTasksRequest", func() {
+		Describe("Validate", func() {
+			var request models.ArchivedTasksRequest
+
+			BeforeEach(func() {
+				request = models.ArchivedTasksRequest{
+					Domain:         "some-domain",
+					CompletedSince: 10,
+					CompletedUntil: 20,
+					Limit:          5,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when empty", func() {
+				It("returns nil", func() {
+					request = models.ArchivedTasksRequest{}
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when completed_until is before completed_since", func() {
+				BeforeEach(func() {
+					request.CompletedUntil = 5
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"completed_until"}))
+				})
+			})
+
+			Context("when the limit is negative", func() {
+				BeforeEach(func() {
+					request.Limit = -1
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"limit"}))
+				})
+			})
+
+			Context("when the limit is over the maximum", func() {
+				BeforeEach(func() {
+					request.Limit = models.MaxArchivedTasksLimit + 1
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"limit"}))
+				})
+			})
+		})
+
+		Describe("Filter", func() {
+			It("returns the filter for the request", func() {
+				request := models.ArchivedTasksRequest{Domain: "some-domain", CompletedSince: 10, CompletedUntil: 20, Limit: 5}
+				Expect(request.Filter()).To(Equal(models.ArchivedTaskFilter{
+					Domain:         "some-domain",
+					CompletedSince: 10,
+					CompletedUntil: 20,
+					Limit:          5,
+				}))
+			})
+
+			It("defaults the limit", func() {
+				request := models.ArchivedTasksRequest{Domain: "some-domain"}
+				Expect(request.Filter().Limit).To(Equal(models.DefaultArchivedTasksLimit))
+			})
+		})
+	})
 })
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+// MaxTaskScheduleHistoryLimit is the most completed runs a schedule can keep.
+const MaxTaskScheduleHistoryLimit = 100
+
+type TaskScheduleFilter struct {
+	Domain string
+}
+
+func (s *TaskSchedule) LagerData() lager.Data {
+	return lager.Data{
+		"schedule_guid":      s.ScheduleGuid,
+		"domain":             s.Domain,
+		"cron_expression":    s.CronExpression,
+		"concurrency_policy": s.ConcurrencyPolicy,
+	}
+}
+
+func (s *TaskSchedule) Validate() error {
+	var validationError ValidationError
+
+	if !taskGuidPattern.MatchString(s.ScheduleGuid) {
+		validationError = validationError.Append(ErrInvalidField{"schedule_guid"})
+	}
+
+	if s.Domain == "" {
+		validationError = validationError.Append(ErrInvalidField{"domain"})
+	}
+
+	if _, err := ParseCronExpression(s.CronExpression); err != nil {
+		validationError = validationError.Append(ErrInvalidField{"cron_expression"})
+		validationError = validationError.Append(err)
+	}
+
+	if s.TaskDefinition == nil {
+		validationError = validationError.Append(ErrInvalidField{"task_definition"})
+	} else if defErr := s.TaskDefinition.Validate(); defErr != nil {
+		validationError = validationError.Append(defErr)
+	} else if len(s.TaskDefinition.DependsOn) > 0 {
+		validationError = validationError.Append(ErrInvalidField{"depends_on"})
+	}
+
+	if _, ok := TaskSchedule_ConcurrencyPolicy_name[int32(s.ConcurrencyPolicy)]; !ok {
+		validationError = validationError.Append(ErrInvalidField{"concurrency_policy"})
+	}
+
+	if s.HistoryLimit < 0 || s.HistoryLimit > MaxTaskScheduleHistoryLimit {
+		validationError = validationError.Append(ErrInvalidField{"history_limit"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+// DueRun returns the most recent time the schedule was due to run, if it has
+// come due since it last ran or was last updated. Runs missed while no BBS
+// was scheduling are not made up: only the latest of them is returned.
+func (s *TaskSchedule) DueRun(now int64) (int64, bool, error) {
+	cron, err := ParseCronExpression(s.CronExpression)
+	if err != nil {
+		return 0, false, err
+	}
+
+	since := s.LastScheduledAt
+	if since < s.UpdatedAt {
+		since = s.UpdatedAt
+	}
+
+	var due int64
+	next := time.Unix(0, since)
+	for {
+		next, err = cron.Next(next)
+		if err != nil {
+			return 0, false, err
+		}
+		if next.UnixNano() > now {
+			break
+		}
+		due = next.UnixNano()
+	}
+
+	return due, due != 0, nil
+}
+
+// TaskGuidForRun returns the guid of the task created for the run due at the
+// given time. The guid is derived from the run, so a run is created at most
+// once however many times it is scheduled.
+func (s *TaskSchedule) TaskGuidForRun(scheduledAt int64) string {
+	return fmt.Sprintf("%s-%d", s.ScheduleGuid, time.Unix(0, scheduledAt).Unix())
+}
+
+func (req *DesireTaskScheduleRequest) Validate() error {
+	if req.TaskSchedule == nil {
+		return ErrInvalidField{"task_schedule"}
+	}
+	return req.TaskSchedule.Validate()
+}
+
+func (req *UpdateTaskScheduleRequest) Validate() error {
+	if req.TaskSchedule == nil {
+		return ErrInvalidField{"task_schedule"}
+	}
+	return req.TaskSchedule.Validate()
+}
+
+func (req *TaskScheduleGuidRequest) Validate() error {
+	if !taskGuidPattern.MatchString(req.ScheduleGuid) {
+		return ErrInvalidField{"schedule_guid"}
+	}
+	return nil
+}
+
+func (req *TaskSchedulesRequest) Validate() error {
+	return nil
+}
+
+func (req *TaskSchedulesRequest) Filter() TaskScheduleFilter {
+	return TaskScheduleFilter{Domain: req.Domain}
+}
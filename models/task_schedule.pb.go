@@ -0,0 +1,1987 @@
+// Code generated by protoc-gen-gogo.
+// source: task_schedule.proto
+// DO NOT EDIT!
+
+package models
+
+import proto "github.com/gogo/protobuf/proto"
+import math "math"
+
+// discarding unused import gogoproto "github.com/gogo/protobuf/gogoproto"
+
+import strconv "strconv"
+
+import fmt "fmt"
+import strings "strings"
+import github_com_gogo_protobuf_proto "github.com/gogo/protobuf/proto"
+import sort "sort"
+import reflect "reflect"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = math.Inf
+
+type TaskSchedule_ConcurrencyPolicy int32
+
+const (
+	TaskSchedule_Allow   TaskSchedule_ConcurrencyPolicy = 0
+	TaskSchedule_Forbid  TaskSchedule_ConcurrencyPolicy = 1
+	TaskSchedule_Replace TaskSchedule_ConcurrencyPolicy = 2
+)
+
+var TaskSchedule_ConcurrencyPolicy_name = map[int32]string{
+	0: "Allow",
+	1: "Forbid",
+	2: "Replace",
+}
+var TaskSchedule_ConcurrencyPolicy_value = map[string]int32{
+	"Allow":   0,
+	"Forbid":  1,
+	"Replace": 2,
+}
+
+func (x TaskSchedule_ConcurrencyPolicy) Enum() *TaskSchedule_ConcurrencyPolicy {
+	p := new(TaskSchedule_ConcurrencyPolicy)
+	*p = x
+	return p
+}
+func (x TaskSchedule_ConcurrencyPolicy) MarshalJSON() ([]byte, error) {
+	return proto.MarshalJSONEnum(TaskSchedule_ConcurrencyPolicy_name, int32(x))
+}
+func (x *TaskSchedule_ConcurrencyPolicy) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(TaskSchedule_ConcurrencyPolicy_value, data, "TaskSchedule_ConcurrencyPolicy")
+	if err != nil {
+		return err
+	}
+	*x = TaskSchedule_ConcurrencyPolicy(value)
+	return nil
+}
+
+type TaskSchedule struct {
+	ScheduleGuid      string                         `protobuf:"bytes,1,opt,name=schedule_guid" json:"schedule_guid"`
+	Domain            string                         `protobuf:"bytes,2,opt,name=domain" json:"domain"`
+	CronExpression    string                         `protobuf:"bytes,3,opt,name=cron_expression" json:"cron_expression"`
+	TaskDefinition    *TaskDefinition                `protobuf:"bytes,4,opt,name=task_definition" json:"task_definition"`
+	ConcurrencyPolicy TaskSchedule_ConcurrencyPolicy `protobuf:"varint,5,opt,name=concurrency_policy,enum=models.TaskSchedule_ConcurrencyPolicy" json:"concurrency_policy"`
+	HistoryLimit      int32                          `protobuf:"varint,6,opt,name=history_limit" json:"history_limit"`
+	CreatedAt         int64                          `protobuf:"varint,7,opt,name=created_at" json:"created_at"`
+	UpdatedAt         int64                          `protobuf:"varint,8,opt,name=updated_at" json:"updated_at"`
+	LastScheduledAt   int64                          `protobuf:"varint,9,opt,name=last_scheduled_at" json:"last_scheduled_at,omitempty"`
+}
+
+func (m *TaskSchedule) Reset()      { *m = TaskSchedule{} }
+func (*TaskSchedule) ProtoMessage() {}
+
+func (m *TaskSchedule) GetScheduleGuid() string {
+	if m != nil {
+		return m.ScheduleGuid
+	}
+	return ""
+}
+
+func (m *TaskSchedule) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *TaskSchedule) GetCronExpression() string {
+	if m != nil {
+		return m.CronExpression
+	}
+	return ""
+}
+
+func (m *TaskSchedule) GetTaskDefinition() *TaskDefinition {
+	if m != nil {
+		return m.TaskDefinition
+	}
+	return nil
+}
+
+func (m *TaskSchedule) GetConcurrencyPolicy() TaskSchedule_ConcurrencyPolicy {
+	if m != nil {
+		return m.ConcurrencyPolicy
+	}
+	return TaskSchedule_Allow
+}
+
+func (m *TaskSchedule) GetHistoryLimit() int32 {
+	if m != nil {
+		return m.HistoryLimit
+	}
+	return 0
+}
+
+func (m *TaskSchedule) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *TaskSchedule) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *TaskSchedule) GetLastScheduledAt() int64 {
+	if m != nil {
+		return m.LastScheduledAt
+	}
+	return 0
+}
+
+type TaskScheduleLifecycleResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TaskScheduleLifecycleResponse) Reset()      { *m = TaskScheduleLifecycleResponse{} }
+func (*TaskScheduleLifecycleResponse) ProtoMessage() {}
+
+func (m *TaskScheduleLifecycleResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type DesireTaskScheduleRequest struct {
+	TaskSchedule *TaskSchedule `protobuf:"bytes,1,opt,name=task_schedule" json:"task_schedule,omitempty"`
+}
+
+func (m *DesireTaskScheduleRequest) Reset()      { *m = DesireTaskScheduleRequest{} }
+func (*DesireTaskScheduleRequest) ProtoMessage() {}
+
+func (m *DesireTaskScheduleRequest) GetTaskSchedule() *TaskSchedule {
+	if m != nil {
+		return m.TaskSchedule
+	}
+	return nil
+}
+
+type UpdateTaskScheduleRequest struct {
+	TaskSchedule *TaskSchedule `protobuf:"bytes,1,opt,name=task_schedule" json:"task_schedule,omitempty"`
+}
+
+func (m *UpdateTaskScheduleRequest) Reset()      { *m = UpdateTaskScheduleRequest{} }
+func (*UpdateTaskScheduleRequest) ProtoMessage() {}
+
+func (m *UpdateTaskScheduleRequest) GetTaskSchedule() *TaskSchedule {
+	if m != nil {
+		return m.TaskSchedule
+	}
+	return nil
+}
+
+type TaskScheduleGuidRequest struct {
+	ScheduleGuid string `protobuf:"bytes,1,opt,name=schedule_guid" json:"schedule_guid"`
+}
+
+func (m *TaskScheduleGuidRequest) Reset()      { *m = TaskScheduleGuidRequest{} }
+func (*TaskScheduleGuidRequest) ProtoMessage() {}
+
+func (m *TaskScheduleGuidRequest) GetScheduleGuid() string {
+	if m != nil {
+		return m.ScheduleGuid
+	}
+	return ""
+}
+
+type TaskSchedulesRequest struct {
+	Domain string `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
+}
+
+func (m *TaskSchedulesRequest) Reset()      { *m = TaskSchedulesRequest{} }
+func (*TaskSchedulesRequest) ProtoMessage() {}
+
+func (m *TaskSchedulesRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+type TaskSchedulesResponse struct {
+	Error         *Error          `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	TaskSchedules []*TaskSchedule `protobuf:"bytes,2,rep,name=task_schedules" json:"task_schedules,omitempty"`
+}
+
+func (m *TaskSchedulesResponse) Reset()      { *m = TaskSchedulesResponse{} }
+func (*TaskSchedulesResponse) ProtoMessage() {}
+
+func (m *TaskSchedulesResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *TaskSchedulesResponse) GetTaskSchedules() []*TaskSchedule {
+	if m != nil {
+		return m.TaskSchedules
+	}
+	return nil
+}
+
+type TaskScheduleResponse struct {
+	Error        *Error        `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	TaskSchedule *TaskSchedule `protobuf:"bytes,2,opt,name=task_schedule" json:"task_schedule,omitempty"`
+}
+
+func (m *TaskScheduleResponse) Reset()      { *m = TaskScheduleResponse{} }
+func (*TaskScheduleResponse) ProtoMessage() {}
+
+func (m *TaskScheduleResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *TaskScheduleResponse) GetTaskSchedule() *TaskSchedule {
+	if m != nil {
+		return m.TaskSchedule
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("models.TaskSchedule_ConcurrencyPolicy", TaskSchedule_ConcurrencyPolicy_name, TaskSchedule_ConcurrencyPolicy_value)
+}
+func (x TaskSchedule_ConcurrencyPolicy) String() string {
+	s, ok := TaskSchedule_ConcurrencyPolicy_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *TaskSchedule) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskSchedule)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.ScheduleGuid != that1.ScheduleGuid {
+		return false
+	}
+	if this.Domain != that1.Domain {
+		return false
+	}
+	if this.CronExpression != that1.CronExpression {
+		return false
+	}
+	if !this.TaskDefinition.Equal(that1.TaskDefinition) {
+		return false
+	}
+	if this.ConcurrencyPolicy != that1.ConcurrencyPolicy {
+		return false
+	}
+	if this.HistoryLimit != that1.HistoryLimit {
+		return false
+	}
+	if this.CreatedAt != that1.CreatedAt {
+		return false
+	}
+	if this.UpdatedAt != that1.UpdatedAt {
+		return false
+	}
+	if this.LastScheduledAt != that1.LastScheduledAt {
+		return false
+	}
+	return true
+}
+func (this *TaskScheduleLifecycleResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskScheduleLifecycleResponse)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	return true
+}
+func (this *DesireTaskScheduleRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*DesireTaskScheduleRequest)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.TaskSchedule.Equal(that1.TaskSchedule) {
+		return false
+	}
+	return true
+}
+func (this *UpdateTaskScheduleRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*UpdateTaskScheduleRequest)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.TaskSchedule.Equal(that1.TaskSchedule) {
+		return false
+	}
+	return true
+}
+func (this *TaskScheduleGuidRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskScheduleGuidRequest)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.ScheduleGuid != that1.ScheduleGuid {
+		return false
+	}
+	return true
+}
+func (this *TaskSchedulesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskSchedulesRequest)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.Domain != that1.Domain {
+		return false
+	}
+	return true
+}
+func (this *TaskSchedulesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskSchedulesResponse)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if len(this.TaskSchedules) != len(that1.TaskSchedules) {
+		return false
+	}
+	for i := range this.TaskSchedules {
+		if !this.TaskSchedules[i].Equal(that1.TaskSchedules[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *TaskScheduleResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskScheduleResponse)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if !this.TaskSchedule.Equal(that1.TaskSchedule) {
+		return false
+	}
+	return true
+}
+func (this *TaskSchedule) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskSchedule{` +
+		`ScheduleGuid:` + fmt.Sprintf("%#v", this.ScheduleGuid),
+		`Domain:` + fmt.Sprintf("%#v", this.Domain),
+		`CronExpression:` + fmt.Sprintf("%#v", this.CronExpression),
+		`TaskDefinition:` + fmt.Sprintf("%#v", this.TaskDefinition),
+		`ConcurrencyPolicy:` + fmt.Sprintf("%#v", this.ConcurrencyPolicy),
+		`HistoryLimit:` + fmt.Sprintf("%#v", this.HistoryLimit),
+		`CreatedAt:` + fmt.Sprintf("%#v", this.CreatedAt),
+		`UpdatedAt:` + fmt.Sprintf("%#v", this.UpdatedAt),
+		`LastScheduledAt:` + fmt.Sprintf("%#v", this.LastScheduledAt) + `}`}, ", ")
+	return s
+}
+func (this *TaskScheduleLifecycleResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskScheduleLifecycleResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error) + `}`}, ", ")
+	return s
+}
+func (this *DesireTaskScheduleRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.DesireTaskScheduleRequest{` +
+		`TaskSchedule:` + fmt.Sprintf("%#v", this.TaskSchedule) + `}`}, ", ")
+	return s
+}
+func (this *UpdateTaskScheduleRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.UpdateTaskScheduleRequest{` +
+		`TaskSchedule:` + fmt.Sprintf("%#v", this.TaskSchedule) + `}`}, ", ")
+	return s
+}
+func (this *TaskScheduleGuidRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskScheduleGuidRequest{` +
+		`ScheduleGuid:` + fmt.Sprintf("%#v", this.ScheduleGuid) + `}`}, ", ")
+	return s
+}
+func (this *TaskSchedulesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskSchedulesRequest{` +
+		`Domain:` + fmt.Sprintf("%#v", this.Domain) + `}`}, ", ")
+	return s
+}
+func (this *TaskSchedulesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskSchedulesResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`TaskSchedules:` + fmt.Sprintf("%#v", this.TaskSchedules) + `}`}, ", ")
+	return s
+}
+func (this *TaskScheduleResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskScheduleResponse{` +
+		`Error:` + fmt.Sprintf("%#v", this.Error),
+		`TaskSchedule:` + fmt.Sprintf("%#v", this.TaskSchedule) + `}`}, ", ")
+	return s
+}
+func valueToGoStringTaskSchedule(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func extensionToGoStringTaskSchedule(e map[int32]github_com_gogo_protobuf_proto.Extension) string {
+	if e == nil {
+		return "nil"
+	}
+	s := "map[int32]proto.Extension{"
+	keys := make([]int, 0, len(e))
+	for k := range e {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	ss := []string{}
+	for _, k := range keys {
+		ss = append(ss, strconv.Itoa(k)+": "+e[int32(k)].GoString())
+	}
+	s += strings.Join(ss, ",") + "}"
+	return s
+}
+func (m *TaskSchedule) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskSchedule) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(len(m.ScheduleGuid)))
+	i += copy(data[i:], m.ScheduleGuid)
+	data[i] = 0x12
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(len(m.Domain)))
+	i += copy(data[i:], m.Domain)
+	data[i] = 0x1a
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(len(m.CronExpression)))
+	i += copy(data[i:], m.CronExpression)
+	if m.TaskDefinition != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintTaskSchedule(data, i, uint64(m.TaskDefinition.Size()))
+		n1, err := m.TaskDefinition.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	data[i] = 0x28
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(m.ConcurrencyPolicy))
+	data[i] = 0x30
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(m.HistoryLimit))
+	data[i] = 0x38
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(m.CreatedAt))
+	data[i] = 0x40
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(m.UpdatedAt))
+	data[i] = 0x48
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(m.LastScheduledAt))
+	return i, nil
+}
+
+func (m *TaskScheduleLifecycleResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskScheduleLifecycleResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskSchedule(data, i, uint64(m.Error.Size()))
+		n2, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	return i, nil
+}
+
+func (m *DesireTaskScheduleRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DesireTaskScheduleRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.TaskSchedule != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskSchedule(data, i, uint64(m.TaskSchedule.Size()))
+		n3, err := m.TaskSchedule.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	return i, nil
+}
+
+func (m *UpdateTaskScheduleRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *UpdateTaskScheduleRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.TaskSchedule != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskSchedule(data, i, uint64(m.TaskSchedule.Size()))
+		n4, err := m.TaskSchedule.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	return i, nil
+}
+
+func (m *TaskScheduleGuidRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskScheduleGuidRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(len(m.ScheduleGuid)))
+	i += copy(data[i:], m.ScheduleGuid)
+	return i, nil
+}
+
+func (m *TaskSchedulesRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskSchedulesRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintTaskSchedule(data, i, uint64(len(m.Domain)))
+	i += copy(data[i:], m.Domain)
+	return i, nil
+}
+
+func (m *TaskSchedulesResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskSchedulesResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskSchedule(data, i, uint64(m.Error.Size()))
+		n5, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if len(m.TaskSchedules) > 0 {
+		for _, msg := range m.TaskSchedules {
+			data[i] = 0x12
+			i++
+			i = encodeVarintTaskSchedule(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *TaskScheduleResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskScheduleResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintTaskSchedule(data, i, uint64(m.Error.Size()))
+		n6, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.TaskSchedule != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintTaskSchedule(data, i, uint64(m.TaskSchedule.Size()))
+		n7, err := m.TaskSchedule.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	return i, nil
+}
+
+func encodeFixed64TaskSchedule(data []byte, offset int, v uint64) int {
+	data[offset] = uint8(v)
+	data[offset+1] = uint8(v >> 8)
+	data[offset+2] = uint8(v >> 16)
+	data[offset+3] = uint8(v >> 24)
+	data[offset+4] = uint8(v >> 32)
+	data[offset+5] = uint8(v >> 40)
+	data[offset+6] = uint8(v >> 48)
+	data[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32TaskSchedule(data []byte, offset int, v uint32) int {
+	data[offset] = uint8(v)
+	data[offset+1] = uint8(v >> 8)
+	data[offset+2] = uint8(v >> 16)
+	data[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintTaskSchedule(data []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		data[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	data[offset] = uint8(v)
+	return offset + 1
+}
+func (m *TaskSchedule) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ScheduleGuid)
+	n += 1 + l + sovTaskSchedule(uint64(l))
+	l = len(m.Domain)
+	n += 1 + l + sovTaskSchedule(uint64(l))
+	l = len(m.CronExpression)
+	n += 1 + l + sovTaskSchedule(uint64(l))
+	if m.TaskDefinition != nil {
+		l = m.TaskDefinition.Size()
+		n += 1 + l + sovTaskSchedule(uint64(l))
+	}
+	n += 1 + sovTaskSchedule(uint64(m.ConcurrencyPolicy))
+	n += 1 + sovTaskSchedule(uint64(m.HistoryLimit))
+	n += 1 + sovTaskSchedule(uint64(m.CreatedAt))
+	n += 1 + sovTaskSchedule(uint64(m.UpdatedAt))
+	n += 1 + sovTaskSchedule(uint64(m.LastScheduledAt))
+	return n
+}
+
+func (m *TaskScheduleLifecycleResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskSchedule(uint64(l))
+	}
+	return n
+}
+
+func (m *DesireTaskScheduleRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.TaskSchedule != nil {
+		l = m.TaskSchedule.Size()
+		n += 1 + l + sovTaskSchedule(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateTaskScheduleRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.TaskSchedule != nil {
+		l = m.TaskSchedule.Size()
+		n += 1 + l + sovTaskSchedule(uint64(l))
+	}
+	return n
+}
+
+func (m *TaskScheduleGuidRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ScheduleGuid)
+	n += 1 + l + sovTaskSchedule(uint64(l))
+	return n
+}
+
+func (m *TaskSchedulesRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Domain)
+	n += 1 + l + sovTaskSchedule(uint64(l))
+	return n
+}
+
+func (m *TaskSchedulesResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskSchedule(uint64(l))
+	}
+	if len(m.TaskSchedules) > 0 {
+		for _, e := range m.TaskSchedules {
+			l = e.Size()
+			n += 1 + l + sovTaskSchedule(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TaskScheduleResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTaskSchedule(uint64(l))
+	}
+	if m.TaskSchedule != nil {
+		l = m.TaskSchedule.Size()
+		n += 1 + l + sovTaskSchedule(uint64(l))
+	}
+	return n
+}
+
+func sovTaskSchedule(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozTaskSchedule(x uint64) (n int) {
+	return sovTaskSchedule(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *TaskSchedule) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskSchedule{`,
+		`ScheduleGuid:` + fmt.Sprintf("%v", this.ScheduleGuid) + `,`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`CronExpression:` + fmt.Sprintf("%v", this.CronExpression) + `,`,
+		`TaskDefinition:` + strings.Replace(fmt.Sprintf("%v", this.TaskDefinition), "TaskDefinition", "TaskDefinition", 1) + `,`,
+		`ConcurrencyPolicy:` + fmt.Sprintf("%v", this.ConcurrencyPolicy) + `,`,
+		`HistoryLimit:` + fmt.Sprintf("%v", this.HistoryLimit) + `,`,
+		`CreatedAt:` + fmt.Sprintf("%v", this.CreatedAt) + `,`,
+		`UpdatedAt:` + fmt.Sprintf("%v", this.UpdatedAt) + `,`,
+		`LastScheduledAt:` + fmt.Sprintf("%v", this.LastScheduledAt) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskScheduleLifecycleResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskScheduleLifecycleResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DesireTaskScheduleRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DesireTaskScheduleRequest{`,
+		`TaskSchedule:` + strings.Replace(fmt.Sprintf("%v", this.TaskSchedule), "TaskSchedule", "TaskSchedule", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateTaskScheduleRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateTaskScheduleRequest{`,
+		`TaskSchedule:` + strings.Replace(fmt.Sprintf("%v", this.TaskSchedule), "TaskSchedule", "TaskSchedule", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskScheduleGuidRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskScheduleGuidRequest{`,
+		`ScheduleGuid:` + fmt.Sprintf("%v", this.ScheduleGuid) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskSchedulesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskSchedulesRequest{`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskSchedulesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskSchedulesResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`TaskSchedules:` + strings.Replace(fmt.Sprintf("%v", this.TaskSchedules), "TaskSchedule", "TaskSchedule", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskScheduleResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskScheduleResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`TaskSchedule:` + strings.Replace(fmt.Sprintf("%v", this.TaskSchedule), "TaskSchedule", "TaskSchedule", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringTaskSchedule(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *TaskSchedule) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduleGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScheduleGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CronExpression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CronExpression = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskDefinition", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TaskDefinition == nil {
+				m.TaskDefinition = &TaskDefinition{}
+			}
+			if err := m.TaskDefinition.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConcurrencyPolicy", wireType)
+			}
+			m.ConcurrencyPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ConcurrencyPolicy |= (TaskSchedule_ConcurrencyPolicy(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoryLimit", wireType)
+			}
+			m.HistoryLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.HistoryLimit |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.CreatedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			m.UpdatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.UpdatedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastScheduledAt", wireType)
+			}
+			m.LastScheduledAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.LastScheduledAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskScheduleLifecycleResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *DesireTaskScheduleRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskSchedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TaskSchedule == nil {
+				m.TaskSchedule = &TaskSchedule{}
+			}
+			if err := m.TaskSchedule.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *UpdateTaskScheduleRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskSchedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TaskSchedule == nil {
+				m.TaskSchedule = &TaskSchedule{}
+			}
+			if err := m.TaskSchedule.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskScheduleGuidRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduleGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScheduleGuid = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskSchedulesRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if stringLen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskSchedulesResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskSchedules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskSchedules = append(m.TaskSchedules, &TaskSchedule{})
+			if err := m.TaskSchedules[len(m.TaskSchedules)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskScheduleResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskSchedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if msglen < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TaskSchedule == nil {
+				m.TaskSchedule = &TaskSchedule{}
+			}
+			if err := m.TaskSchedule.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipTaskSchedule(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTaskSchedule
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func skipTaskSchedule(data []byte) (n int, err error) {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if data[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthTaskSchedule
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := data[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipTaskSchedule(data[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthTaskSchedule = fmt.Errorf("proto: negative length found during unmarshaling")
+)
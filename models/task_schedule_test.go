@@ -0,0 +1,155 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskSchedule", func() {
+	var schedule *models.TaskSchedule
+
+	BeforeEach(func() {
+		schedule = &models.TaskSchedule{
+			ScheduleGuid:      "some-schedule-guid",
+			Domain:            "some-domain",
+			CronExpression:    "*/10 * * * *",
+			TaskDefinition:    model_helpers.NewValidTaskDefinition(),
+			ConcurrencyPolicy: models.TaskSchedule_Forbid,
+			HistoryLimit:      3,
+		}
+	})
+
+	Describe("Validate", func() {
+		It("is valid", func() {
+			Expect(schedule.Validate()).To(Succeed())
+		})
+
+		assertInvalidField := func(field string) {
+			err := schedule.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(field))
+		}
+
+		It("requires a valid schedule guid", func() {
+			schedule.ScheduleGuid = "bad guid"
+			assertInvalidField("schedule_guid")
+		})
+
+		It("requires a domain", func() {
+			schedule.Domain = ""
+			assertInvalidField("domain")
+		})
+
+		It("requires a valid cron expression", func() {
+			schedule.CronExpression = "every minute"
+			assertInvalidField("cron_expression")
+		})
+
+		It("requires a valid task definition", func() {
+			schedule.TaskDefinition = nil
+			assertInvalidField("task_definition")
+
+			schedule.TaskDefinition = model_helpers.NewValidTaskDefinition()
+			schedule.TaskDefinition.RootFs = ""
+			assertInvalidField("rootfs")
+		})
+
+		It("does not allow the task to depend on other tasks", func() {
+			schedule.TaskDefinition.DependsOn = []string{"some-task-guid"}
+			assertInvalidField("depends_on")
+		})
+
+		It("requires a known concurrency policy", func() {
+			schedule.ConcurrencyPolicy = 7
+			assertInvalidField("concurrency_policy")
+		})
+
+		It("limits the history", func() {
+			schedule.HistoryLimit = -1
+			assertInvalidField("history_limit")
+
+			schedule.HistoryLimit = models.MaxTaskScheduleHistoryLimit + 1
+			assertInvalidField("history_limit")
+		})
+	})
+
+	Describe("DueRun", func() {
+		var base time.Time
+
+		BeforeEach(func() {
+			base = time.Date(2016, time.June, 15, 10, 0, 0, 0, time.UTC)
+			schedule.CreatedAt = base.Add(-time.Hour).UnixNano()
+			schedule.UpdatedAt = base.Add(-time.Hour).UnixNano()
+			schedule.LastScheduledAt = base.UnixNano()
+		})
+
+		It("is not due before the next run", func() {
+			_, due, err := schedule.DueRun(base.Add(9 * time.Minute).UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeFalse())
+		})
+
+		It("returns the run that has come due", func() {
+			scheduledAt, due, err := schedule.DueRun(base.Add(10*time.Minute + time.Second).UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeTrue())
+			Expect(scheduledAt).To(Equal(base.Add(10 * time.Minute).UnixNano()))
+		})
+
+		It("returns only the latest of several missed runs", func() {
+			scheduledAt, due, err := schedule.DueRun(base.Add(35 * time.Minute).UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeTrue())
+			Expect(scheduledAt).To(Equal(base.Add(30 * time.Minute).UnixNano()))
+		})
+
+		It("schedules a newly created schedule from its creation", func() {
+			schedule.LastScheduledAt = 0
+			schedule.UpdatedAt = base.Add(5 * time.Minute).UnixNano()
+
+			scheduledAt, due, err := schedule.DueRun(base.Add(12 * time.Minute).UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeTrue())
+			Expect(scheduledAt).To(Equal(base.Add(10 * time.Minute).UnixNano()))
+		})
+
+		It("does not schedule runs that passed before the last update", func() {
+			schedule.UpdatedAt = base.Add(25 * time.Minute).UnixNano()
+
+			_, due, err := schedule.DueRun(base.Add(29 * time.Minute).UnixNano())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeFalse())
+		})
+	})
+
+	Describe("TaskGuidForRun", func() {
+		It("derives the guid from the schedule and the run", func() {
+			scheduledAt := time.Unix(1466000000, 0).UnixNano()
+			Expect(schedule.TaskGuidForRun(scheduledAt)).To(Equal("some-schedule-guid-1466000000"))
+		})
+	})
+
+	Describe("TaskScheduleGuidRequest", func() {
+		It("requires a valid schedule guid", func() {
+			request := &models.TaskScheduleGuidRequest{ScheduleGuid: "some-schedule-guid"}
+			Expect(request.Validate()).To(Succeed())
+
+			request.ScheduleGuid = ""
+			Expect(request.Validate()).To(HaveOccurred())
+		})
+	})
+
+	Describe("DesireTaskScheduleRequest", func() {
+		It("requires a valid task schedule", func() {
+			request := &models.DesireTaskScheduleRequest{TaskSchedule: schedule}
+			Expect(request.Validate()).To(Succeed())
+
+			request.TaskSchedule = nil
+			Expect(request.Validate()).To(HaveOccurred())
+		})
+	})
+})
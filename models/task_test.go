@@ -7,6 +7,7 @@ import (
 
 	"github.com/cloudfoundry-incubator/bbs/format"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
 	"github.com/gogo/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -297,6 +298,321 @@ var _ = Describe("Task", func() {
 		})
 	})
 
+	Describe("SortTasksByPriority", func() {
+		newTask := func(guid string, priority int32, createdAt int64) *models.Task {
+			return &models.Task{
+				TaskGuid:       guid,
+				CreatedAt:      createdAt,
+				TaskDefinition: &models.TaskDefinition{Priority: priority},
+			}
+		}
+
+		It("orders the tasks by descending priority, then by age", func() {
+			tasks := []*models.Task{
+				newTask("low-new", 0, 2),
+				newTask("high-new", 50, 4),
+				newTask("low-old", 0, 1),
+				newTask("high-old", 50, 3),
+				newTask("highest", 100, 5),
+			}
+
+			models.SortTasksByPriority(tasks)
+
+			guids := []string{}
+			for _, task := range tasks {
+				guids = append(guids, task.TaskGuid)
+			}
+			Expect(guids).To(Equal([]string{"highest", "high-old", "high-new", "low-old", "low-new"}))
+		})
+	})
+
+	Describe("TaskFilter", func() {
+		It("matches tasks with at least the minimum priority", func() {
+			task := &models.Task{Domain: "some-domain", TaskDefinition: &models.TaskDefinition{Priority: 10}}
+
+			Expect(models.TaskFilter{}.Matches(task)).To(BeTrue())
+			Expect(models.TaskFilter{MinPriority: 10}.Matches(task)).To(BeTrue())
+			Expect(models.TaskFilter{MinPriority: 11}.Matches(task)).To(BeFalse())
+			Expect(models.TaskFilter{Domain: "other-domain"}.Matches(task)).To(BeFalse())
+		})
+
+		It("matches tasks in the state that last changed early enough", func() {
+			task := &models.Task{State: models.Task_Running, UpdatedAt: 1000, TaskDefinition: &models.TaskDefinition{}}
+
+			Expect(models.TaskFilter{State: models.Task_Running, UpdatedBefore: 1000}.Matches(task)).To(BeTrue())
+			Expect(models.TaskFilter{State: models.Task_Pending}.Matches(task)).To(BeFalse())
+			Expect(models.TaskFilter{UpdatedBefore: 999}.Matches(task)).To(BeFalse())
+		})
+	})
+
+	Describe("Unblock", func() {
+		var blockedTask *models.Task
+
+		BeforeEach(func() {
+			blockedTask = &models.Task{
+				TaskGuid:       "task-guid",
+				State:          models.Task_Blocked,
+				BlockedOn:      []string{"first-guid", "second-guid"},
+				TaskDefinition: &models.TaskDefinition{DependsOn: []string{"first-guid", "second-guid"}},
+			}
+		})
+
+		It("stays blocked until every dependency has been released", func() {
+			Expect(blockedTask.IsBlockedOn("first-guid")).To(BeTrue())
+
+			blockedTask.Unblock("first-guid")
+			Expect(blockedTask.State).To(Equal(models.Task_Blocked))
+			Expect(blockedTask.BlockedOn).To(Equal([]string{"second-guid"}))
+			Expect(blockedTask.IsBlockedOn("first-guid")).To(BeFalse())
+			Expect(blockedTask.DependsOnTask("first-guid")).To(BeTrue())
+
+			blockedTask.Unblock("second-guid")
+			Expect(blockedTask.State).To(Equal(models.Task_Pending))
+			Expect(blockedTask.BlockedOn).To(BeNil())
+			Expect(blockedTask.IsBlockedOn("second-guid")).To(BeFalse())
+		})
+	})
+
+	Describe("retries", func() {
+		var retriedTask *models.Task
+
+		BeforeEach(func() {
+			retriedTask = &models.Task{
+				TaskGuid: "task-guid",
+				State:    models.Task_Running,
+				CellId:   "some-cell",
+				TaskDefinition: &models.TaskDefinition{
+					RetryPolicy: &models.RetryPolicy{
+						MaxAttempts:             3,
+						BackoffMs:               1000,
+						RetryableFailureReasons: []string{"cell disappeared before completion"},
+					},
+				},
+			}
+		})
+
+		It("retries retryable failures until the attempts run out", func() {
+			Expect(retriedTask.ShouldRetry("some other failure")).To(BeFalse())
+			Expect(retriedTask.ShouldRetry("cell disappeared before completion")).To(BeTrue())
+
+			retriedTask.LastHeartbeatAt = 50
+			retriedTask.ProgressMessage = "downloading"
+			retriedTask.Retry("cell disappeared before completion", 100)
+			Expect(retriedTask.State).To(Equal(models.Task_Pending))
+			Expect(retriedTask.Attempts).To(BeEquivalentTo(1))
+			Expect(retriedTask.CellId).To(BeEmpty())
+			Expect(retriedTask.Failed).To(BeFalse())
+			Expect(retriedTask.FailureReason).To(Equal("cell disappeared before completion"))
+			Expect(retriedTask.UpdatedAt).To(BeEquivalentTo(100))
+			Expect(retriedTask.LastHeartbeatAt).To(BeZero())
+			Expect(retriedTask.ProgressMessage).To(BeEmpty())
+			Expect(retriedTask.ShouldRetry("cell disappeared before completion")).To(BeTrue())
+
+			retriedTask.Retry("cell disappeared before completion", 200)
+			Expect(retriedTask.ShouldRetry("cell disappeared before completion")).To(BeFalse())
+		})
+
+		It("does not retry tasks without a retry policy", func() {
+			retriedTask.RetryPolicy = nil
+			Expect(retriedTask.ShouldRetry("cell disappeared before completion")).To(BeFalse())
+		})
+
+		It("does not retry blocked tasks", func() {
+			retriedTask.State = models.Task_Blocked
+			Expect(retriedTask.ShouldRetry("cell disappeared before completion")).To(BeFalse())
+		})
+
+		It("waits out the backoff before a retried task is auctioned again", func() {
+			Expect(retriedTask.RetryBackoffElapsed(0)).To(BeTrue())
+
+			retriedTask.Retry("cell disappeared before completion", 0)
+			Expect(retriedTask.RetryBackoffElapsed(int64(999 * time.Millisecond))).To(BeFalse())
+			Expect(retriedTask.RetryBackoffElapsed(int64(time.Second))).To(BeTrue())
+		})
+
+		It("reports when the backoff ends", func() {
+			retriedTask.UpdatedAt = 100
+			Expect(retriedTask.RetryBackoffEndsAt()).To(BeEquivalentTo(100))
+
+			retriedTask.Retry("cell disappeared before completion", 200)
+			Expect(retriedTask.RetryBackoffEndsAt()).To(BeEquivalentTo(200 + int64(time.Second)))
+		})
+	})
+
+	Describe("ExceededDeadline", func() {
+		var deadlineTask *models.Task
+
+		BeforeEach(func() {
+			deadlineTask = model_helpers.NewValidTask("some-guid")
+			deadlineTask.MaxPendingTimeMs = 1000
+			deadlineTask.MaxRunTimeMs = 5000
+			deadlineTask.CreatedAt = 0
+			deadlineTask.UpdatedAt = int64(time.Second)
+		})
+
+		It("fails pending tasks that have waited too long to start", func() {
+			deadlineTask.State = models.Task_Pending
+
+			_, exceeded := deadlineTask.ExceededDeadline(int64(1999 * time.Millisecond))
+			Expect(exceeded).To(BeFalse())
+
+			reason, exceeded := deadlineTask.ExceededDeadline(int64(2 * time.Second))
+			Expect(exceeded).To(BeTrue())
+			Expect(reason).To(Equal(models.MaxPendingTimeExceededReason))
+		})
+
+		It("times blocked tasks from their creation", func() {
+			deadlineTask.State = models.Task_Blocked
+
+			reason, exceeded := deadlineTask.ExceededDeadline(int64(time.Second))
+			Expect(exceeded).To(BeTrue())
+			Expect(reason).To(Equal(models.MaxPendingTimeExceededReason))
+		})
+
+		It("fails running tasks that have run too long", func() {
+			deadlineTask.State = models.Task_Running
+
+			_, exceeded := deadlineTask.ExceededDeadline(int64(5999 * time.Millisecond))
+			Expect(exceeded).To(BeFalse())
+
+			reason, exceeded := deadlineTask.ExceededDeadline(int64(6 * time.Second))
+			Expect(exceeded).To(BeTrue())
+			Expect(reason).To(Equal(models.MaxRunTimeExceededReason))
+		})
+
+		It("fails running tasks whose heartbeats have stopped", func() {
+			deadlineTask.State = models.Task_Running
+			deadlineTask.MaxRunTimeMs = 0
+			deadlineTask.HeartbeatTimeoutMs = 2000
+			deadlineTask.LastHeartbeatAt = int64(2 * time.Second)
+
+			_, exceeded := deadlineTask.ExceededDeadline(int64(3999 * time.Millisecond))
+			Expect(exceeded).To(BeFalse())
+
+			reason, exceeded := deadlineTask.ExceededDeadline(int64(4 * time.Second))
+			Expect(exceeded).To(BeTrue())
+			Expect(reason).To(Equal(models.HeartbeatTimeoutReason))
+		})
+
+		It("times the first heartbeat from when the task started", func() {
+			deadlineTask.State = models.Task_Running
+			deadlineTask.MaxRunTimeMs = 0
+			deadlineTask.HeartbeatTimeoutMs = 2000
+
+			reason, exceeded := deadlineTask.ExceededDeadline(int64(3 * time.Second))
+			Expect(exceeded).To(BeTrue())
+			Expect(reason).To(Equal(models.HeartbeatTimeoutReason))
+		})
+
+		It("does not limit tasks without deadlines", func() {
+			deadlineTask.MaxPendingTimeMs = 0
+			deadlineTask.MaxRunTimeMs = 0
+
+			for _, state := range []models.Task_State{models.Task_Pending, models.Task_Blocked, models.Task_Running} {
+				deadlineTask.State = state
+				_, exceeded := deadlineTask.ExceededDeadline(int64(time.Hour))
+				Expect(exceeded).To(BeFalse())
+			}
+		})
+
+		It("does not limit completed tasks", func() {
+			deadlineTask.State = models.Task_Completed
+			_, exceeded := deadlineTask.ExceededDeadline(int64(time.Hour))
+			Expect(exceeded).To(BeFalse())
+		})
+	})
+
+	Describe("CompletedRetention", func() {
+		It("defaults to the given retention", func() {
+			retained := model_helpers.NewValidTask("some-guid")
+			Expect(retained.CompletedRetention(time.Hour)).To(Equal(time.Hour))
+		})
+
+		It("uses the retention of the task when it has one", func() {
+			retained := model_helpers.NewValidTask("some-guid")
+			retained.CompletedRetentionMs = 2000
+			Expect(retained.CompletedRetention(time.Hour)).To(Equal(2 * time.Second))
+		})
+	})
+
+	Describe("Heartbeat", func() {
+		var runningTask *models.Task
+
+		BeforeEach(func() {
+			runningTask = model_helpers.NewValidTask("some-guid")
+			runningTask.State = models.Task_Running
+			runningTask.CellId = "some-cell"
+			runningTask.UpdatedAt = 10
+		})
+
+		It("records the heartbeat and progress without changing UpdatedAt", func() {
+			err := runningTask.Heartbeat("some-cell", "downloading", 42, 50)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runningTask.LastHeartbeatAt).To(BeEquivalentTo(50))
+			Expect(runningTask.ProgressMessage).To(Equal("downloading"))
+			Expect(runningTask.ProgressPercent).To(BeEquivalentTo(42))
+			Expect(runningTask.UpdatedAt).To(BeEquivalentTo(10))
+		})
+
+		It("fails for a task running on another cell", func() {
+			err := runningTask.Heartbeat("other-cell", "downloading", 42, 50)
+			Expect(models.ConvertError(err).Type).To(Equal(models.Error_RunningOnDifferentCell))
+		})
+
+		It("fails for a task that is not running", func() {
+			runningTask.State = models.Task_Pending
+			err := runningTask.Heartbeat("some-cell", "downloading", 42, 50)
+			Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidStateTransition))
+		})
+
+		Describe("the change it makes", func() {
+			var before models.Task
+
+			BeforeEach(func() {
+				runningTask.ProgressMessage = "downloading"
+				runningTask.ProgressPercent = 42
+				before = *runningTask
+			})
+
+			It("is heartbeat only when the progress is unchanged", func() {
+				Expect(runningTask.Heartbeat("some-cell", "downloading", 42, 50)).To(Succeed())
+				change := &models.TaskChange{Before: &before, After: runningTask}
+				Expect(change.HeartbeatOnly()).To(BeTrue())
+			})
+
+			It("is not heartbeat only when the progress changed", func() {
+				Expect(runningTask.Heartbeat("some-cell", "downloading", 43, 50)).To(Succeed())
+				change := &models.TaskChange{Before: &before, After: runningTask}
+				Expect(change.HeartbeatOnly()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("DeadLetterCallback", func() {
+		var deadLettered *models.Task
+
+		BeforeEach(func() {
+			deadLettered = model_helpers.NewValidTask("some-guid")
+			deadLettered.State = models.Task_Resolving
+		})
+
+		It("completes the task and records the callback failure", func() {
+			err := deadLettered.DeadLetterCallback("callback failed", 42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadLettered.State).To(Equal(models.Task_Completed))
+			Expect(deadLettered.CallbackDeadLettered).To(BeTrue())
+			Expect(deadLettered.CallbackFailureReason).To(Equal("callback failed"))
+			Expect(deadLettered.UpdatedAt).To(BeEquivalentTo(42))
+		})
+
+		It("fails for a task that has not completed", func() {
+			deadLettered.State = models.Task_Running
+			err := deadLettered.DeadLetterCallback("callback failed", 42)
+			Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidStateTransition))
+			Expect(deadLettered.CallbackDeadLettered).To(BeFalse())
+		})
+	})
+
 	Describe("Validate", func() {
 		Context("when the task has a domain, valid guid, stack, and valid action", func() {
 			It("is valid", func() {
@@ -456,6 +772,172 @@ var _ = Describe("Task", func() {
 					},
 				},
 			},
+			{
+				"priority",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						Priority: models.MaxTaskPriority + 1,
+					},
+				},
+			},
+			{
+				"depends_on",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						DependsOn: []string{"task-guid"},
+					},
+				},
+			},
+			{
+				"depends_on",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						DependsOn: []string{"other-guid", "other-guid"},
+					},
+				},
+			},
+			{
+				"retry_policy",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						RetryPolicy: &models.RetryPolicy{MaxAttempts: models.MaxTaskAttempts + 1},
+					},
+				},
+			},
+			{
+				"backoff_ms",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						RetryPolicy: &models.RetryPolicy{MaxAttempts: 2, BackoffMs: -1},
+					},
+				},
+			},
+			{
+				"backoff_ms",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						RetryPolicy: &models.RetryPolicy{MaxAttempts: 2, BackoffMs: int64((models.MaxRetryBackoff + time.Millisecond) / time.Millisecond)},
+					},
+				},
+			},
+			{
+				"backoff_ms",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						RetryPolicy:      &models.RetryPolicy{MaxAttempts: 2, BackoffMs: 1000},
+						MaxPendingTimeMs: 1000,
+					},
+				},
+			},
+			{
+				"max_pending_time_ms",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						MaxPendingTimeMs: -1,
+					},
+				},
+			},
+			{
+				"max_run_time_ms",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						MaxRunTimeMs: -1,
+					},
+				},
+			},
+			{
+				"completed_retention_ms",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						CompletedRetentionMs: -1,
+					},
+				},
+			},
+			{
+				"heartbeat_timeout_ms",
+				&models.Task{
+					Domain:   "some-domain",
+					TaskGuid: "task-guid",
+					TaskDefinition: &models.TaskDefinition{
+						RootFs: "some:rootfs",
+						Action: models.WrapAction(&models.RunAction{
+							Path: "ls",
+							User: "me",
+						}),
+						HeartbeatTimeoutMs: -1,
+					},
+				},
+			},
 			{
 				"egress_rules",
 				&models.Task{
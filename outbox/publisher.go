@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+// EventBatchSize is the number of events read from the outbox at a time.
+const EventBatchSize = 500
+
+// RetainedEvents is the number of published events kept in the outbox, so
+// that a newly elected BBS can refill the replay buffers of all of its hubs.
+const RetainedEvents = 3 * events.MAX_REPLAY_EVENTS
+
+// EventGapTimeout is how long a missing sequence holds up the events recorded
+// after it. The outbox numbers events before their transactions commit, so a
+// later event can be read first; a sequence missing for longer than that
+// belonged to a transaction that rolled back, and is skipped.
+const EventGapTimeout = 5 * time.Second
+
+// Publisher feeds the events recorded in the outbox to the hubs, in the order
+// they were recorded and under their outbox sequence, so that event ids carry
+// on from one BBS to the next.
+type Publisher struct {
+	logger       lager.Logger
+	db           db.EventDB
+	desiredHub   events.Hub
+	actualHub    events.Hub
+	taskHub      events.Hub
+	pollInterval time.Duration
+	clock        clock.Clock
+}
+
+func NewPublisher(
+	logger lager.Logger,
+	db db.EventDB,
+	desiredHub events.Hub,
+	actualHub events.Hub,
+	taskHub events.Hub,
+	pollInterval time.Duration,
+	clock clock.Clock,
+) Publisher {
+	return Publisher{
+		logger:       logger,
+		db:           db,
+		desiredHub:   desiredHub,
+		actualHub:    actualHub,
+		taskHub:      taskHub,
+		pollInterval: pollInterval,
+		clock:        clock,
+	}
+}
+
+func (p Publisher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := p.logger.Session("event-outbox-publisher")
+	logger.Info("starting")
+
+	lastSequence, err := p.db.LastEventSequence(logger)
+	if err != nil {
+		logger.Error("failed-fetching-last-event-sequence", err)
+		return err
+	}
+
+	// Replay the retained events before serving, so that subscribers of the
+	// previous BBS can resume their streams here.
+	var published uint64
+	if lastSequence > RetainedEvents {
+		published = lastSequence - RetainedEvents
+	}
+	published = p.publish(logger, published)
+
+	close(ready)
+	logger.Info("started", lager.Data{"published_sequence": published})
+	defer logger.Info("finished")
+
+	ticker := p.clock.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C():
+			published = p.publish(logger, published)
+		}
+	}
+}
+
+// publish emits the events recorded after the given sequence and returns the
+// sequence of the last event it emitted. It stops short of a recent gap in
+// the sequences, to pick up from there once the gap is filled or times out.
+func (p Publisher) publish(logger lager.Logger, published uint64) uint64 {
+	start := published
+
+	for {
+		sequencedEvents, err := p.db.EventsAfter(logger, published, EventBatchSize)
+		if err != nil {
+			logger.Error("failed-fetching-events", err, lager.Data{"published_sequence": published})
+			break
+		}
+
+		gapped := false
+		for _, sequencedEvent := range sequencedEvents {
+			if sequencedEvent.Sequence > published+1 && p.clock.Since(time.Unix(0, sequencedEvent.RecordedAt)) < EventGapTimeout {
+				logger.Debug("waiting-for-missing-events", lager.Data{"published_sequence": published, "event_sequence": sequencedEvent.Sequence})
+				gapped = true
+				break
+			}
+
+			hub := p.hubFor(sequencedEvent.Event)
+			if hub == nil {
+				logger.Info("unknown-event-type", lager.Data{"event_sequence": sequencedEvent.Sequence, "event_type": sequencedEvent.Event.EventType()})
+			} else {
+				hub.EmitSequenced(sequencedEvent.Sequence, sequencedEvent.Event)
+			}
+			published = sequencedEvent.Sequence
+		}
+
+		if gapped || len(sequencedEvents) < EventBatchSize {
+			break
+		}
+	}
+
+	if published > start && published > RetainedEvents {
+		err := p.db.PruneEvents(logger, published-RetainedEvents)
+		if err != nil {
+			logger.Error("failed-pruning-events", err)
+		}
+	}
+
+	return published
+}
+
+func (p Publisher) hubFor(event models.Event) events.Hub {
+	switch event.(type) {
+	case *models.DesiredLRPCreatedEvent, *models.DesiredLRPChangedEvent, *models.DesiredLRPRemovedEvent:
+		return p.desiredHub
+	case *models.ActualLRPCreatedEvent, *models.ActualLRPChangedEvent, *models.ActualLRPRemovedEvent, *models.ActualLRPCrashedEvent:
+		return p.actualHub
+	case *models.TaskCreatedEvent, *models.TaskChangedEvent, *models.TaskRemovedEvent:
+		return p.taskHub
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package outbox_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/outbox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+)
+
+var _ = Describe("Publisher", func() {
+	const pollInterval = 100 * time.Millisecond
+
+	var (
+		logger    *lagertest.TestLogger
+		fakeDB    *dbfakes.FakeEventDB
+		fakeClock *fakeclock.FakeClock
+
+		desiredHub *eventfakes.FakeHub
+		actualHub  *eventfakes.FakeHub
+		taskHub    *eventfakes.FakeHub
+
+		recordedLock   sync.Mutex
+		recordedEvents []*models.SequencedEvent
+
+		process ifrit.Process
+	)
+
+	record := func(sequence uint64, event models.Event) {
+		recordedLock.Lock()
+		defer recordedLock.Unlock()
+		// keep them in sequence order, as the outbox returns them
+		i := len(recordedEvents)
+		for i > 0 && recordedEvents[i-1].Sequence > sequence {
+			i--
+		}
+		recorded := &models.SequencedEvent{Sequence: sequence, RecordedAt: fakeClock.Now().UnixNano(), Event: event}
+		recordedEvents = append(recordedEvents[:i], append([]*models.SequencedEvent{recorded}, recordedEvents[i:]...)...)
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeDB = new(dbfakes.FakeEventDB)
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		desiredHub = new(eventfakes.FakeHub)
+		actualHub = new(eventfakes.FakeHub)
+		taskHub = new(eventfakes.FakeHub)
+
+		recordedEvents = nil
+
+		fakeDB.EventsAfterStub = func(logger lager.Logger, sequence uint64, limit int) ([]*models.SequencedEvent, error) {
+			recordedLock.Lock()
+			defer recordedLock.Unlock()
+
+			result := []*models.SequencedEvent{}
+			for _, event := range recordedEvents {
+				if event.Sequence > sequence && len(result) < limit {
+					result = append(result, event)
+				}
+			}
+			return result, nil
+		}
+		fakeDB.LastEventSequenceStub = func(logger lager.Logger) (uint64, error) {
+			recordedLock.Lock()
+			defer recordedLock.Unlock()
+
+			if len(recordedEvents) == 0 {
+				return 0, nil
+			}
+			return recordedEvents[len(recordedEvents)-1].Sequence, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		publisher := outbox.NewPublisher(logger, fakeDB, desiredHub, actualHub, taskHub, pollInterval, fakeClock)
+		process = ifrit.Background(publisher)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Kill(process)
+	})
+
+	Context("when events were recorded before it started", func() {
+		var (
+			desiredEvent models.Event
+			actualEvent  models.Event
+			taskEvent    models.Event
+		)
+
+		BeforeEach(func() {
+			desiredEvent = models.NewDesiredLRPCreatedEvent(model_helpers.NewValidDesiredLRP("some-guid"))
+			actualEvent = models.NewActualLRPCrashedEvent(model_helpers.NewValidActualLRP("some-guid", 0))
+			taskEvent = models.NewTaskCreatedEvent(model_helpers.NewValidTask("some-task"))
+
+			record(1, desiredEvent)
+			record(2, actualEvent)
+			record(3, taskEvent)
+		})
+
+		It("emits them to their hubs before becoming ready", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+
+			Expect(desiredHub.EmitSequencedCallCount()).To(Equal(1))
+			sequence, event := desiredHub.EmitSequencedArgsForCall(0)
+			Expect(sequence).To(BeEquivalentTo(1))
+			Expect(event).To(Equal(desiredEvent))
+
+			Expect(actualHub.EmitSequencedCallCount()).To(Equal(1))
+			sequence, event = actualHub.EmitSequencedArgsForCall(0)
+			Expect(sequence).To(BeEquivalentTo(2))
+			Expect(event).To(Equal(actualEvent))
+
+			Expect(taskHub.EmitSequencedCallCount()).To(Equal(1))
+			sequence, event = taskHub.EmitSequencedArgsForCall(0)
+			Expect(sequence).To(BeEquivalentTo(3))
+			Expect(event).To(Equal(taskEvent))
+		})
+	})
+
+	Context("when more events were recorded than are retained", func() {
+		BeforeEach(func() {
+			for sequence := uint64(1); sequence <= outbox.RetainedEvents+10; sequence++ {
+				record(sequence, models.NewTaskRemovedEvent(model_helpers.NewValidTask("some-task")))
+			}
+		})
+
+		It("only replays the retained events, reading them in batches", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+
+			Expect(taskHub.EmitSequencedCallCount()).To(Equal(outbox.RetainedEvents))
+			sequence, _ := taskHub.EmitSequencedArgsForCall(0)
+			Expect(sequence).To(BeEquivalentTo(11))
+
+			Expect(fakeDB.EventsAfterCallCount()).To(BeNumerically(">", 1))
+			_, _, limit := fakeDB.EventsAfterArgsForCall(0)
+			Expect(limit).To(Equal(outbox.EventBatchSize))
+		})
+	})
+
+	Context("when events are recorded after it started", func() {
+		JustBeforeEach(func() {
+			Eventually(process.Ready()).Should(BeClosed())
+		})
+
+		It("emits them on the next poll", func() {
+			record(1, models.NewTaskCreatedEvent(model_helpers.NewValidTask("some-task")))
+			Consistently(taskHub.EmitSequencedCallCount).Should(Equal(0))
+
+			fakeClock.Increment(pollInterval)
+			Eventually(taskHub.EmitSequencedCallCount).Should(Equal(1))
+
+			fakeClock.Increment(pollInterval)
+			Consistently(taskHub.EmitSequencedCallCount).Should(Equal(1))
+
+			_, afterSequence, _ := fakeDB.EventsAfterArgsForCall(fakeDB.EventsAfterCallCount() - 1)
+			Expect(afterSequence).To(BeEquivalentTo(1))
+		})
+
+		It("prunes the events that are no longer retained", func() {
+			for sequence := uint64(1); sequence <= outbox.RetainedEvents+5; sequence++ {
+				record(sequence, models.NewTaskRemovedEvent(model_helpers.NewValidTask("some-task")))
+			}
+
+			fakeClock.Increment(pollInterval)
+			Eventually(fakeDB.PruneEventsCallCount).Should(Equal(1))
+
+			_, throughSequence := fakeDB.PruneEventsArgsForCall(0)
+			Expect(throughSequence).To(BeEquivalentTo(5))
+		})
+
+		Context("when a sequence is missing", func() {
+			JustBeforeEach(func() {
+				record(1, models.NewTaskCreatedEvent(model_helpers.NewValidTask("task-1")))
+				record(3, models.NewTaskCreatedEvent(model_helpers.NewValidTask("task-3")))
+
+				fakeClock.Increment(pollInterval)
+				Eventually(taskHub.EmitSequencedCallCount).Should(Equal(1))
+			})
+
+			It("holds back the later events until it is filled", func() {
+				fakeClock.Increment(pollInterval)
+				Consistently(taskHub.EmitSequencedCallCount).Should(Equal(1))
+
+				record(2, models.NewTaskCreatedEvent(model_helpers.NewValidTask("task-2")))
+				fakeClock.Increment(pollInterval)
+				Eventually(taskHub.EmitSequencedCallCount).Should(Equal(3))
+
+				sequence, _ := taskHub.EmitSequencedArgsForCall(1)
+				Expect(sequence).To(BeEquivalentTo(2))
+				sequence, _ = taskHub.EmitSequencedArgsForCall(2)
+				Expect(sequence).To(BeEquivalentTo(3))
+			})
+
+			It("skips it once it has been missing for too long", func() {
+				fakeClock.Increment(outbox.EventGapTimeout)
+				Eventually(taskHub.EmitSequencedCallCount).Should(Equal(2))
+
+				sequence, _ := taskHub.EmitSequencedArgsForCall(1)
+				Expect(sequence).To(BeEquivalentTo(3))
+			})
+		})
+
+		Context("when reading the outbox fails", func() {
+			BeforeEach(func() {
+				fakeDB.EventsAfterReturns(nil, errors.New("boom"))
+			})
+
+			It("keeps polling", func() {
+				fakeClock.Increment(pollInterval)
+				Eventually(fakeDB.EventsAfterCallCount).Should(Equal(2))
+
+				fakeClock.Increment(pollInterval)
+				Eventually(fakeDB.EventsAfterCallCount).Should(Equal(3))
+			})
+		})
+	})
+
+	Context("when fetching the last sequence fails", func() {
+		BeforeEach(func() {
+			fakeDB.LastEventSequenceReturns(0, errors.New("boom"))
+		})
+
+		It("exits with the error", func() {
+			Eventually(process.Wait()).Should(Receive(MatchError("boom")))
+		})
+	})
+
+	It("exits when signalled", func() {
+		Eventually(process.Ready()).Should(BeClosed())
+
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+})
@@ -1,6 +1,9 @@
 package bbs
 
-import "github.com/tedsuo/rata"
+import (
+	"github.com/cloudfoundry-incubator/bbs/authorization"
+	"github.com/tedsuo/rata"
+)
 
 const (
 	// Ping
@@ -14,6 +17,7 @@ const (
 	ActualLRPGroupsRoute                     = "ActualLRPGroups"
 	ActualLRPGroupsByProcessGuidRoute        = "ActualLRPGroupsByProcessGuid"
 	ActualLRPGroupByProcessGuidAndIndexRoute = "ActualLRPGroupsByProcessGuidAndIndex"
+	ActualLRPsRoute                          = "ActualLRPs"
 
 	// Actual LRP Lifecycle
 	ClaimActualLRPRoute  = "ClaimActualLRP"
@@ -55,11 +59,14 @@ const (
 	TaskByGuidRoute    = "TaskByGuid_r2"
 	DesireTaskRoute    = "DesireTask_r1"
 	StartTaskRoute     = "StartTask"
+	TaskHeartbeatRoute = "TaskHeartbeat"
 	CancelTaskRoute    = "CancelTask"
+	CancelTasksRoute   = "CancelTasks"
 	FailTaskRoute      = "FailTask"
 	CompleteTaskRoute  = "CompleteTask"
 	ResolvingTaskRoute = "ResolvingTask"
 	DeleteTaskRoute    = "DeleteTask"
+	DeleteTasksRoute   = "DeleteTasks"
 	ConvergeTasksRoute = "ConvergeTasks"
 
 	TasksRoute_r1      = "Tasks_r1"      // Deprecated
@@ -69,13 +76,33 @@ const (
 	TasksRoute_r0      = "Tasks"      // Deprecated
 	TaskByGuidRoute_r0 = "TaskByGuid" // Deprecated
 
+	// Task Schedules
+	TaskSchedulesRoute      = "TaskSchedules"
+	TaskScheduleByGuidRoute = "TaskScheduleByGuid"
+	DesireTaskScheduleRoute = "DesireTaskSchedule"
+	UpdateTaskScheduleRoute = "UpdateTaskSchedule"
+	RemoveTaskScheduleRoute = "RemoveTaskSchedule"
+
+	// Task Archive
+	ArchivedTasksRoute = "ArchivedTasks"
+
 	// Event Streaming
 	EventStreamRoute_r0        = "EventStream_r0" // Deprecated
 	DesiredLRPEventStreamRoute = "DesiredLRPEventStreamRoute"
 	ActualLRPEventStreamRoute  = "ActualLRPEventStreamRoute"
+	TaskEventStreamRoute       = "TaskEventStreamRoute"
+	CellEventStreamRoute       = "CellEventStreamRoute"
+
+	LRPInstanceEventStreamRoute = "LRPInstanceEventStreamRoute"
+
+	DesiredLRPEventWebSocketRoute = "DesiredLRPEventWebSocketRoute"
+	ActualLRPEventWebSocketRoute  = "ActualLRPEventWebSocketRoute"
 
 	// Cell Presence
 	CellsRoute = "Cells_r1"
+
+	// Audit
+	AuditRecordsRoute = "AuditRecords"
 )
 
 var Routes = rata.Routes{
@@ -90,6 +117,7 @@ var Routes = rata.Routes{
 	{Path: "/v1/actual_lrp_groups/list", Method: "POST", Name: ActualLRPGroupsRoute},
 	{Path: "/v1/actual_lrp_groups/list_by_process_guid", Method: "POST", Name: ActualLRPGroupsByProcessGuidRoute},
 	{Path: "/v1/actual_lrp_groups/get_by_process_guid_and_index", Method: "POST", Name: ActualLRPGroupByProcessGuidAndIndexRoute},
+	{Path: "/v1/actual_lrps/list", Method: "POST", Name: ActualLRPsRoute},
 
 	// Actual LRP Lifecycle
 	{Path: "/v1/actual_lrps/claim", Method: "POST", Name: ClaimActualLRPRoute},
@@ -135,22 +163,153 @@ var Routes = rata.Routes{
 	// Task Lifecycle
 	{Path: "/v1/tasks/desire.r1", Method: "POST", Name: DesireTaskRoute},
 	{Path: "/v1/tasks/start", Method: "POST", Name: StartTaskRoute},
+	{Path: "/v1/tasks/heartbeat", Method: "POST", Name: TaskHeartbeatRoute},
 	{Path: "/v1/tasks/cancel", Method: "POST", Name: CancelTaskRoute},
 	{Path: "/v1/tasks/fail", Method: "POST", Name: FailTaskRoute},
 	{Path: "/v1/tasks/complete", Method: "POST", Name: CompleteTaskRoute},
 	{Path: "/v1/tasks/resolving", Method: "POST", Name: ResolvingTaskRoute},
 	{Path: "/v1/tasks/delete", Method: "POST", Name: DeleteTaskRoute},
 
+	// Bulk Task Lifecycle
+	{Path: "/v1/tasks/bulk_cancel", Method: "POST", Name: CancelTasksRoute},
+	{Path: "/v1/tasks/bulk_delete", Method: "POST", Name: DeleteTasksRoute},
+
 	{Path: "/v1/tasks/desire", Method: "POST", Name: DesireTaskRoute_r0}, // Deprecated
 
 	// Task Convergence
 	{Path: "/v1/tasks/converge", Method: "POST", Name: ConvergeTasksRoute},
 
+	// Task Schedules
+	{Path: "/v1/task_schedules/list", Method: "POST", Name: TaskSchedulesRoute},
+	{Path: "/v1/task_schedules/get_by_schedule_guid", Method: "POST", Name: TaskScheduleByGuidRoute},
+	{Path: "/v1/task_schedules/desire", Method: "POST", Name: DesireTaskScheduleRoute},
+	{Path: "/v1/task_schedules/update", Method: "POST", Name: UpdateTaskScheduleRoute},
+	{Path: "/v1/task_schedules/remove", Method: "POST", Name: RemoveTaskScheduleRoute},
+
+	// Task Archive
+	{Path: "/v1/archived_tasks/list", Method: "POST", Name: ArchivedTasksRoute},
+
 	// Event Streaming
 	{Path: "/v1/events", Method: "GET", Name: EventStreamRoute_r0},
 	{Path: "/v1/desired_lrp_events", Method: "GET", Name: DesiredLRPEventStreamRoute}, // Experimental
 	{Path: "/v1/actual_lrp_events", Method: "GET", Name: ActualLRPEventStreamRoute},   // Experimental
+	{Path: "/v1/task_events", Method: "GET", Name: TaskEventStreamRoute},
+	{Path: "/v1/cell_events", Method: "GET", Name: CellEventStreamRoute},
+	{Path: "/v1/lrp_instance_events", Method: "GET", Name: LRPInstanceEventStreamRoute},
+	{Path: "/v1/desired_lrp_events/ws", Method: "GET", Name: DesiredLRPEventWebSocketRoute}, // Experimental
+	{Path: "/v1/actual_lrp_events/ws", Method: "GET", Name: ActualLRPEventWebSocketRoute},   // Experimental
 
 	// Cells
 	{Path: "/v1/cells/list.r1", Method: "GET", Name: CellsRoute},
+
+	// Audit
+	{Path: "/v1/audit_records/list", Method: "POST", Name: AuditRecordsRoute},
+}
+
+var (
+	anyRole         = authorization.AllRoles
+	cellRoles       = []authorization.Role{authorization.CellRole, authorization.OperatorRole}
+	controllerRoles = []authorization.Role{authorization.ControllerRole, authorization.OperatorRole}
+	schedulerRoles  = []authorization.Role{authorization.CellRole, authorization.ControllerRole, authorization.OperatorRole}
+	operatorRoles   = []authorization.Role{authorization.OperatorRole}
+)
+
+// RouteRoles lists the roles permitted to call each route when an
+// authorization policy is configured.
+var RouteRoles = map[string][]authorization.Role{
+	// Ping
+	PingRoute: anyRole,
+
+	// Domains
+	DomainsRoute:      anyRole,
+	UpsertDomainRoute: controllerRoles,
+
+	// Actual LRPs
+	ActualLRPGroupsRoute:                     anyRole,
+	ActualLRPGroupsByProcessGuidRoute:        anyRole,
+	ActualLRPGroupByProcessGuidAndIndexRoute: anyRole,
+	ActualLRPsRoute:                          anyRole,
+
+	// Actual LRP Lifecycle
+	ClaimActualLRPRoute:  cellRoles,
+	StartActualLRPRoute:  cellRoles,
+	CrashActualLRPRoute:  cellRoles,
+	FailActualLRPRoute:   schedulerRoles,
+	RemoveActualLRPRoute: cellRoles,
+	RetireActualLRPRoute: controllerRoles,
+
+	// Evacuation
+	RemoveEvacuatingActualLRPRoute: cellRoles,
+	EvacuateClaimedActualLRPRoute:  cellRoles,
+	EvacuateCrashedActualLRPRoute:  cellRoles,
+	EvacuateStoppedActualLRPRoute:  cellRoles,
+	EvacuateRunningActualLRPRoute:  cellRoles,
+
+	// Desired LRPs
+	DesiredLRPsRoute:               anyRole,
+	DesiredLRPSchedulingInfosRoute: anyRole,
+	DesiredLRPByProcessGuidRoute:   anyRole,
+
+	DesiredLRPsRoute_r0:             anyRole,
+	DesiredLRPByProcessGuidRoute_r0: anyRole,
+
+	// Desire LRP Lifecycle
+	DesireDesiredLRPRoute: controllerRoles,
+	UpdateDesiredLRPRoute: controllerRoles,
+	RemoveDesiredLRPRoute: controllerRoles,
+
+	DesireDesiredLRPRoute_r0: controllerRoles,
+
+	// LRP Convergence
+	ConvergeLRPsRoute: controllerRoles,
+
+	// Tasks
+	TasksRoute:         anyRole,
+	TaskByGuidRoute:    anyRole,
+	DesireTaskRoute:    controllerRoles,
+	StartTaskRoute:     cellRoles,
+	TaskHeartbeatRoute: cellRoles,
+	CancelTaskRoute:    controllerRoles,
+	CancelTasksRoute:   controllerRoles,
+	FailTaskRoute:      schedulerRoles,
+	CompleteTaskRoute:  cellRoles,
+	ResolvingTaskRoute: controllerRoles,
+	DeleteTaskRoute:    controllerRoles,
+	DeleteTasksRoute:   controllerRoles,
+	ConvergeTasksRoute: controllerRoles,
+
+	TasksRoute_r1:      anyRole,
+	TaskByGuidRoute_r1: anyRole,
+
+	DesireTaskRoute_r0: controllerRoles,
+	TasksRoute_r0:      anyRole,
+	TaskByGuidRoute_r0: anyRole,
+
+	// Task Schedules
+	TaskSchedulesRoute:      anyRole,
+	TaskScheduleByGuidRoute: anyRole,
+	DesireTaskScheduleRoute: controllerRoles,
+	UpdateTaskScheduleRoute: controllerRoles,
+	RemoveTaskScheduleRoute: controllerRoles,
+
+	// Task Archive
+	ArchivedTasksRoute: anyRole,
+
+	// Event Streaming
+	EventStreamRoute_r0:        anyRole,
+	DesiredLRPEventStreamRoute: anyRole,
+	ActualLRPEventStreamRoute:  anyRole,
+	TaskEventStreamRoute:       anyRole,
+	CellEventStreamRoute:       anyRole,
+
+	LRPInstanceEventStreamRoute: anyRole,
+
+	DesiredLRPEventWebSocketRoute: anyRole,
+	ActualLRPEventWebSocketRoute:  anyRole,
+
+	// Cell Presence
+	CellsRoute: anyRole,
+
+	// Audit
+	AuditRecordsRoute: operatorRoles,
 }
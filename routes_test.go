@@ -0,0 +1,30 @@
+package bbs_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Routes", func() {
+	Describe("RouteRoles", func() {
+		It("only lists registered routes", func() {
+			registered := map[string]bool{}
+			for _, route := range bbs.Routes {
+				registered[route.Name] = true
+			}
+
+			for name := range bbs.RouteRoles {
+				Expect(registered).To(HaveKey(name))
+			}
+		})
+
+		It("permits some role to call every route", func() {
+			for _, route := range bbs.Routes {
+				Expect(bbs.RouteRoles).To(HaveKey(route.Name))
+				Expect(bbs.RouteRoles[route.Name]).NotTo(BeEmpty())
+			}
+		})
+	})
+})
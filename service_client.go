@@ -1,13 +1,12 @@
 package bbs
 
 import (
-	"os"
-	"path"
 	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/consuladapter"
 	"github.com/cloudfoundry-incubator/locket"
+	"github.com/hashicorp/consul/api"
 	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/ifrit"
@@ -18,6 +17,8 @@ const (
 	BBSLockSchemaKey = "bbs_lock"
 )
 
+const CellWatchRetryInterval = time.Second
+
 func CellSchemaRoot() string {
 	return locket.LockSchemaPath(CellSchemaKey)
 }
@@ -35,6 +36,9 @@ func BBSLockSchemaPath() string {
 type ServiceClient interface {
 	CellById(logger lager.Logger, cellId string) (*models.CellPresence, error)
 	Cells(logger lager.Logger) (models.CellSet, error)
+	// CellEvents watches the cell presences in consul and sends a
+	// CellAppearedEvent or CellDisappearedEvent whenever a cell joins or
+	// leaves. Cells present when the watch starts are not reported.
 	CellEvents(logger lager.Logger) <-chan models.CellEvent
 	NewCellPresenceRunner(logger lager.Logger, cellPresence *models.CellPresence, retryInterval, lockTTL time.Duration) ifrit.Runner
 	NewBBSLockRunner(logger lager.Logger, bbsPresence *models.BBSPresence, retryInterval, lockTTL time.Duration) (ifrit.Runner, error)
@@ -80,6 +84,10 @@ func (db *serviceClient) Cells(logger lager.Logger) (models.CellSet, error) {
 		}
 	}
 
+	return cellSetFromKVPairs(logger, kvPairs), nil
+}
+
+func cellSetFromKVPairs(logger lager.Logger, kvPairs api.KVPairs) models.CellSet {
 	cellPresences := models.NewCellSet()
 	for _, kvPair := range kvPairs {
 		if kvPair.Session == "" {
@@ -96,7 +104,7 @@ func (db *serviceClient) Cells(logger lager.Logger) (models.CellSet, error) {
 		cellPresences.Add(presence)
 	}
 
-	return cellPresences, nil
+	return cellPresences
 }
 
 func (db *serviceClient) CellById(logger lager.Logger, cellId string) (*models.CellPresence, error) {
@@ -117,32 +125,57 @@ func (db *serviceClient) CellById(logger lager.Logger, cellId string) (*models.C
 func (db *serviceClient) CellEvents(logger lager.Logger) <-chan models.CellEvent {
 	logger = logger.Session("cell-events")
 
-	disappearanceWatcher, disappeared := locket.NewDisappearanceWatcher(logger, db.consulClient, CellSchemaRoot(), db.clock)
-	process := ifrit.Invoke(disappearanceWatcher)
-
 	events := make(chan models.CellEvent)
-	go func() {
-		for {
-			select {
-			case keys, ok := <-disappeared:
-				if !ok {
-					process.Signal(os.Interrupt)
-					return
-				}
-
-				cellIDs := make([]string, len(keys))
-				for i, key := range keys {
-					cellIDs[i] = path.Base(key)
-				}
-				logger.Info("cell-disappeared", lager.Data{"cell_ids": cellIDs})
-				events <- models.NewCellDisappearedEvent(cellIDs)
-			}
-		}
-	}()
+	go db.watchCells(logger, events)
 
 	return events
 }
 
+// watchCells polls the cell presences with consul blocking queries, so that a
+// new list is only returned once the cells have changed.
+func (db *serviceClient) watchCells(logger lager.Logger, events chan<- models.CellEvent) {
+	var known models.CellSet
+	var waitIndex uint64
+
+	for {
+		kvPairs, meta, err := db.consulClient.KV().List(CellSchemaRoot(), &api.QueryOptions{WaitIndex: waitIndex})
+		if err != nil {
+			logger.Error("failed-to-watch-cells", err)
+			db.clock.Sleep(CellWatchRetryInterval)
+			continue
+		}
+
+		cells := cellSetFromKVPairs(logger, kvPairs)
+		if known != nil {
+			sendCellEvents(logger, known, cells, events)
+		}
+		known = cells
+
+		// the index is reset when the consul cluster is rebuilt
+		if meta.LastIndex < waitIndex {
+			waitIndex = 0
+		} else {
+			waitIndex = meta.LastIndex
+		}
+	}
+}
+
+func sendCellEvents(logger lager.Logger, before, after models.CellSet, events chan<- models.CellEvent) {
+	for cellID, cell := range after {
+		if !before.HasCellID(cellID) {
+			logger.Info("cell-appeared", lager.Data{"cell_id": cellID})
+			events <- models.NewCellAppearedEvent(cell)
+		}
+	}
+
+	for cellID, cell := range before {
+		if !after.HasCellID(cellID) {
+			logger.Info("cell-disappeared", lager.Data{"cell_id": cellID})
+			events <- models.NewCellDisappearedEvent(cell)
+		}
+	}
+}
+
 func (db *serviceClient) NewBBSLockRunner(logger lager.Logger, bbsPresence *models.BBSPresence, retryInterval, lockTTL time.Duration) (ifrit.Runner, error) {
 	bbsPresenceJSON, err := models.ToJSON(bbsPresence)
 	if err != nil {
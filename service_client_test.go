@@ -71,6 +71,32 @@ var _ = Describe("ServiceClient", func() {
 			})
 		})
 	})
+
+	Describe("CellEvents", func() {
+		var cellEvents <-chan models.CellEvent
+
+		BeforeEach(func() {
+			cellEvents = serviceClient.CellEvents(logger)
+		})
+
+		Context("when a cell appears and disappears", func() {
+			var maintainer ifrit.Process
+
+			It("sends events carrying the cell presence", func() {
+				presence := newCellPresence("cell-id")
+				maintainer = ifrit.Invoke(serviceClient.NewCellPresenceRunner(logger, presence, locket.RetryInterval, locket.LockTTL))
+
+				var event models.CellEvent
+				Eventually(cellEvents).Should(Receive(&event))
+				Expect(event).To(Equal(models.NewCellAppearedEvent(presence)))
+
+				ginkgomon.Interrupt(maintainer)
+
+				Eventually(cellEvents).Should(Receive(&event))
+				Expect(event).To(Equal(models.NewCellDisappearedEvent(presence)))
+			})
+		})
+	})
 })
 
 func newCellPresence(cellID string) *models.CellPresence {
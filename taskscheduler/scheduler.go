@@ -0,0 +1,179 @@
+package taskscheduler
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/auctioneer"
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . TaskCanceller
+
+// TaskCanceller cancels tasks the way the CancelTask endpoint does.
+type TaskCanceller interface {
+	CancelTaskWithReason(logger lager.Logger, taskGuid, reason string) error
+}
+
+// Scheduler creates the tasks of the task schedules as they come due. It
+// runs on the master BBS only, so each run is created once.
+//
+// Tasks the Replace policy cancels are cancelled by the TaskCanceller, so
+// they are handled like any other cancelled task.
+type Scheduler struct {
+	logger           lager.Logger
+	scheduleDB       db.TaskScheduleDB
+	taskCanceller    TaskCanceller
+	auctioneerClient auctioneer.Client
+	pollInterval     time.Duration
+	clock            clock.Clock
+}
+
+func NewScheduler(
+	logger lager.Logger,
+	scheduleDB db.TaskScheduleDB,
+	taskCanceller TaskCanceller,
+	auctioneerClient auctioneer.Client,
+	pollInterval time.Duration,
+	clock clock.Clock,
+) Scheduler {
+	return Scheduler{
+		logger:           logger,
+		scheduleDB:       scheduleDB,
+		taskCanceller:    taskCanceller,
+		auctioneerClient: auctioneerClient,
+		pollInterval:     pollInterval,
+		clock:            clock,
+	}
+}
+
+func (s Scheduler) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := s.logger.Session("task-scheduler")
+	logger.Info("starting")
+
+	close(ready)
+	logger.Info("started")
+	defer logger.Info("finished")
+
+	ticker := s.clock.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C():
+			s.scheduleDueTasks(logger)
+		}
+	}
+}
+
+func (s Scheduler) scheduleDueTasks(logger lager.Logger) {
+	schedules, err := s.scheduleDB.TaskSchedules(logger, models.TaskScheduleFilter{})
+	if err != nil {
+		logger.Error("failed-fetching-task-schedules", err)
+		return
+	}
+
+	now := s.clock.Now().UnixNano()
+	scheduledTasks := []*models.Task{}
+
+	for _, schedule := range schedules {
+		scheduleLogger := logger.WithData(lager.Data{"schedule_guid": schedule.ScheduleGuid})
+
+		scheduledAt, due, err := schedule.DueRun(now)
+		if err != nil {
+			scheduleLogger.Error("failed-evaluating-cron-expression", err)
+			continue
+		}
+
+		if due {
+			task := s.scheduleTask(scheduleLogger, schedule, scheduledAt)
+			if task != nil && task.State == models.Task_Pending {
+				scheduledTasks = append(scheduledTasks, task)
+			}
+		}
+
+		pruned, err := s.scheduleDB.PruneScheduledTasks(scheduleLogger, schedule.ScheduleGuid, schedule.HistoryLimit)
+		if err != nil {
+			scheduleLogger.Error("failed-pruning-scheduled-tasks", err)
+		} else if pruned > 0 {
+			scheduleLogger.Info("pruned-scheduled-tasks", lager.Data{"num_tasks": pruned})
+		}
+	}
+
+	if len(scheduledTasks) == 0 {
+		return
+	}
+
+	models.SortTasksByPriority(scheduledTasks)
+	tasksToAuction := make([]*auctioneer.TaskStartRequest, 0, len(scheduledTasks))
+	for _, task := range scheduledTasks {
+		taskStartRequest := auctioneer.NewTaskStartRequestFromModel(task.TaskGuid, task.Domain, task.TaskDefinition)
+		tasksToAuction = append(tasksToAuction, &taskStartRequest)
+	}
+
+	logger.Debug("start-task-auction-request", lager.Data{"num_tasks_to_auction": len(tasksToAuction)})
+	err = s.auctioneerClient.RequestTaskAuctions(tasksToAuction)
+	if err != nil {
+		logger.Error("failed-requesting-task-auction", err)
+		// The tasks were created, convergence will kick their auctions
+	} else {
+		logger.Debug("succeeded-requesting-task-auction")
+	}
+}
+
+// scheduleTask applies the concurrency policy of the schedule to the run due
+// at the given time, and returns the task it created for it, if any.
+func (s Scheduler) scheduleTask(logger lager.Logger, schedule *models.TaskSchedule, scheduledAt int64) *models.Task {
+	logger = logger.WithData(lager.Data{"scheduled_at": scheduledAt})
+
+	if schedule.ConcurrencyPolicy != models.TaskSchedule_Allow {
+		tasks, err := s.scheduleDB.ScheduledTasks(logger, schedule.ScheduleGuid)
+		if err != nil {
+			logger.Error("failed-fetching-scheduled-tasks", err)
+			return nil
+		}
+
+		active := []*models.Task{}
+		for _, task := range tasks {
+			if !task.HasCompleted() {
+				active = append(active, task)
+			}
+		}
+
+		if len(active) > 0 && schedule.ConcurrencyPolicy == models.TaskSchedule_Forbid {
+			logger.Info("skipping-run-of-active-schedule", lager.Data{"num_active_tasks": len(active)})
+			err = s.scheduleDB.SkipScheduledRun(logger, schedule.ScheduleGuid, scheduledAt)
+			if err != nil {
+				logger.Error("failed-skipping-scheduled-run", err)
+			}
+			return nil
+		}
+
+		for _, task := range active {
+			s.cancelTask(logger, task.TaskGuid)
+		}
+	}
+
+	task, err := s.scheduleDB.ScheduleTask(logger, schedule, scheduledAt)
+	if err != nil {
+		logger.Error("failed-scheduling-task", err)
+		return nil
+	}
+
+	logger.Info("scheduled-task", lager.Data{"task_guid": task.TaskGuid})
+	return task
+}
+
+func (s Scheduler) cancelTask(logger lager.Logger, taskGuid string) {
+	logger = logger.Session("replace-task", lager.Data{"task_guid": taskGuid})
+
+	err := s.taskCanceller.CancelTaskWithReason(logger, taskGuid, models.TaskCancelledReason)
+	if err != nil {
+		logger.Error("failed-cancelling-task", err)
+	}
+}
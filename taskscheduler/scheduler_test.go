@@ -0,0 +1,228 @@
+package taskscheduler_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/auctioneer/auctioneerfakes"
+	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/taskscheduler"
+	"github.com/cloudfoundry-incubator/bbs/taskscheduler/taskschedulerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+)
+
+var _ = Describe("Scheduler", func() {
+	const pollInterval = 10 * time.Second
+
+	var (
+		logger               *lagertest.TestLogger
+		fakeScheduleDB       *dbfakes.FakeTaskScheduleDB
+		fakeTaskCanceller    *taskschedulerfakes.FakeTaskCanceller
+		fakeAuctioneerClient *auctioneerfakes.FakeClient
+		fakeClock            *fakeclock.FakeClock
+
+		base     time.Time
+		schedule *models.TaskSchedule
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeScheduleDB = new(dbfakes.FakeTaskScheduleDB)
+		fakeTaskCanceller = new(taskschedulerfakes.FakeTaskCanceller)
+		fakeAuctioneerClient = new(auctioneerfakes.FakeClient)
+
+		base = time.Date(2016, time.June, 15, 10, 0, 0, 0, time.UTC)
+		fakeClock = fakeclock.NewFakeClock(base.Add(30 * time.Second))
+
+		schedule = &models.TaskSchedule{
+			ScheduleGuid:      "some-schedule-guid",
+			Domain:            "some-domain",
+			CronExpression:    "*/10 * * * *",
+			TaskDefinition:    model_helpers.NewValidTaskDefinition(),
+			ConcurrencyPolicy: models.TaskSchedule_Allow,
+			HistoryLimit:      2,
+			CreatedAt:         base.Add(-time.Hour).UnixNano(),
+			UpdatedAt:         base.Add(-time.Hour).UnixNano(),
+			LastScheduledAt:   base.Add(-10 * time.Minute).UnixNano(),
+		}
+
+		fakeScheduleDB.TaskSchedulesReturns([]*models.TaskSchedule{schedule}, nil)
+		fakeScheduleDB.ScheduleTaskStub = func(logger lager.Logger, schedule *models.TaskSchedule, scheduledAt int64) (*models.Task, error) {
+			task := model_helpers.NewValidTask(schedule.TaskGuidForRun(scheduledAt))
+			task.Domain = schedule.Domain
+			task.State = models.Task_Pending
+			return task, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheduler := taskscheduler.NewScheduler(
+			logger,
+			fakeScheduleDB,
+			fakeTaskCanceller,
+			fakeAuctioneerClient,
+			pollInterval,
+			fakeClock,
+		)
+		process = ifrit.Invoke(scheduler)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Kill(process)
+	})
+
+	It("does nothing until it polls", func() {
+		Consistently(fakeScheduleDB.TaskSchedulesCallCount).Should(Equal(0))
+	})
+
+	Context("when a schedule has come due", func() {
+		JustBeforeEach(func() {
+			fakeClock.Increment(pollInterval)
+		})
+
+		It("schedules the task for the run that came due", func() {
+			Eventually(fakeScheduleDB.ScheduleTaskCallCount).Should(Equal(1))
+			_, scheduled, scheduledAt := fakeScheduleDB.ScheduleTaskArgsForCall(0)
+			Expect(scheduled).To(Equal(schedule))
+			Expect(scheduledAt).To(Equal(base.UnixNano()))
+		})
+
+		It("auctions the task", func() {
+			Eventually(fakeAuctioneerClient.RequestTaskAuctionsCallCount).Should(Equal(1))
+			requests := fakeAuctioneerClient.RequestTaskAuctionsArgsForCall(0)
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].TaskGuid).To(Equal(schedule.TaskGuidForRun(base.UnixNano())))
+			Expect(requests[0].Domain).To(Equal("some-domain"))
+		})
+
+		It("prunes the history of the schedule", func() {
+			Eventually(fakeScheduleDB.PruneScheduledTasksCallCount).Should(Equal(1))
+			_, guid, historyLimit := fakeScheduleDB.PruneScheduledTasksArgsForCall(0)
+			Expect(guid).To(Equal("some-schedule-guid"))
+			Expect(historyLimit).To(BeEquivalentTo(2))
+		})
+
+		Context("when scheduling the task fails", func() {
+			BeforeEach(func() {
+				fakeScheduleDB.ScheduleTaskStub = nil
+				fakeScheduleDB.ScheduleTaskReturns(nil, errors.New("boom"))
+			})
+
+			It("does not request an auction", func() {
+				Eventually(fakeScheduleDB.PruneScheduledTasksCallCount).Should(Equal(1))
+				Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the concurrency policy forbids concurrent runs", func() {
+			BeforeEach(func() {
+				schedule.ConcurrencyPolicy = models.TaskSchedule_Forbid
+			})
+
+			Context("and a previous run is still active", func() {
+				BeforeEach(func() {
+					running := model_helpers.NewValidTask("some-schedule-guid-1465984200")
+					running.State = models.Task_Running
+					fakeScheduleDB.ScheduledTasksReturns([]*models.Task{running}, nil)
+				})
+
+				It("skips the run", func() {
+					Eventually(fakeScheduleDB.SkipScheduledRunCallCount).Should(Equal(1))
+					_, guid, scheduledAt := fakeScheduleDB.SkipScheduledRunArgsForCall(0)
+					Expect(guid).To(Equal("some-schedule-guid"))
+					Expect(scheduledAt).To(Equal(base.UnixNano()))
+
+					Expect(fakeScheduleDB.ScheduleTaskCallCount()).To(Equal(0))
+					Expect(fakeTaskCanceller.CancelTaskWithReasonCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and the previous runs have completed", func() {
+				BeforeEach(func() {
+					completed := model_helpers.NewValidTask("some-schedule-guid-1465984200")
+					completed.State = models.Task_Completed
+					fakeScheduleDB.ScheduledTasksReturns([]*models.Task{completed}, nil)
+				})
+
+				It("schedules the task", func() {
+					Eventually(fakeScheduleDB.ScheduleTaskCallCount).Should(Equal(1))
+					Expect(fakeScheduleDB.SkipScheduledRunCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the concurrency policy replaces active runs", func() {
+			BeforeEach(func() {
+				schedule.ConcurrencyPolicy = models.TaskSchedule_Replace
+
+				pending := model_helpers.NewValidTask("pending-task")
+				pending.State = models.Task_Pending
+				running := model_helpers.NewValidTask("running-task")
+				running.State = models.Task_Running
+				completed := model_helpers.NewValidTask("completed-task")
+				completed.State = models.Task_Completed
+				fakeScheduleDB.ScheduledTasksReturns([]*models.Task{completed, pending, running}, nil)
+			})
+
+			It("cancels the active runs before scheduling the task", func() {
+				Eventually(fakeScheduleDB.ScheduleTaskCallCount).Should(Equal(1))
+
+				Expect(fakeTaskCanceller.CancelTaskWithReasonCallCount()).To(Equal(2))
+				_, guid, reason := fakeTaskCanceller.CancelTaskWithReasonArgsForCall(0)
+				Expect(guid).To(Equal("pending-task"))
+				Expect(reason).To(Equal(models.TaskCancelledReason))
+				_, guid, _ = fakeTaskCanceller.CancelTaskWithReasonArgsForCall(1)
+				Expect(guid).To(Equal("running-task"))
+			})
+
+			Context("when cancelling an active run fails", func() {
+				BeforeEach(func() {
+					fakeTaskCanceller.CancelTaskWithReasonReturns(models.ErrResourceConflict)
+				})
+
+				It("still schedules the task", func() {
+					Eventually(fakeScheduleDB.ScheduleTaskCallCount).Should(Equal(1))
+					Expect(fakeTaskCanceller.CancelTaskWithReasonCallCount()).To(Equal(2))
+				})
+			})
+		})
+	})
+
+	Context("when no schedule has come due", func() {
+		BeforeEach(func() {
+			schedule.LastScheduledAt = base.UnixNano()
+		})
+
+		It("schedules nothing but still prunes the history", func() {
+			fakeClock.Increment(pollInterval)
+
+			Eventually(fakeScheduleDB.PruneScheduledTasksCallCount).Should(Equal(1))
+			Expect(fakeScheduleDB.ScheduleTaskCallCount()).To(Equal(0))
+			Expect(fakeAuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when listing the schedules fails", func() {
+		BeforeEach(func() {
+			fakeScheduleDB.TaskSchedulesReturns(nil, errors.New("boom"))
+		})
+
+		It("keeps polling", func() {
+			fakeClock.Increment(pollInterval)
+			Eventually(fakeScheduleDB.TaskSchedulesCallCount).Should(Equal(1))
+
+			fakeClock.Increment(pollInterval)
+			Eventually(fakeScheduleDB.TaskSchedulesCallCount).Should(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package taskscheduler_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTaskScheduler(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Task Scheduler Suite")
+}
@@ -0,0 +1,58 @@
+// This file was generated by counterfeiter
+package taskschedulerfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/taskscheduler"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeTaskCanceller struct {
+	CancelTaskWithReasonStub        func(logger lager.Logger, taskGuid string, reason string) error
+	cancelTaskWithReasonMutex       sync.RWMutex
+	cancelTaskWithReasonArgsForCall []struct {
+		logger   lager.Logger
+		taskGuid string
+		reason   string
+	}
+	cancelTaskWithReasonReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeTaskCanceller) CancelTaskWithReason(logger lager.Logger, taskGuid string, reason string) error {
+	fake.cancelTaskWithReasonMutex.Lock()
+	fake.cancelTaskWithReasonArgsForCall = append(fake.cancelTaskWithReasonArgsForCall, struct {
+		logger   lager.Logger
+		taskGuid string
+		reason   string
+	}{logger, taskGuid, reason})
+	fake.cancelTaskWithReasonMutex.Unlock()
+	if fake.CancelTaskWithReasonStub != nil {
+		return fake.CancelTaskWithReasonStub(logger, taskGuid, reason)
+	} else {
+		return fake.cancelTaskWithReasonReturns.result1
+	}
+}
+
+func (fake *FakeTaskCanceller) CancelTaskWithReasonCallCount() int {
+	fake.cancelTaskWithReasonMutex.RLock()
+	defer fake.cancelTaskWithReasonMutex.RUnlock()
+	return len(fake.cancelTaskWithReasonArgsForCall)
+}
+
+func (fake *FakeTaskCanceller) CancelTaskWithReasonArgsForCall(i int) (lager.Logger, string, string) {
+	fake.cancelTaskWithReasonMutex.RLock()
+	defer fake.cancelTaskWithReasonMutex.RUnlock()
+	return fake.cancelTaskWithReasonArgsForCall[i].logger, fake.cancelTaskWithReasonArgsForCall[i].taskGuid, fake.cancelTaskWithReasonArgsForCall[i].reason
+}
+
+func (fake *FakeTaskCanceller) CancelTaskWithReasonReturns(result1 error) {
+	fake.CancelTaskWithReasonStub = nil
+	fake.cancelTaskWithReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ taskscheduler.TaskCanceller = new(FakeTaskCanceller)
@@ -0,0 +1,252 @@
+package taskworkpool
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/cloudfoundry/gunk/workpool"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+const (
+	taskCallbackQueueDepth = metric.Metric("TaskCallbackQueueDepth")
+	taskCallbackLatency    = metric.Duration("TaskCallbackLatency")
+)
+
+// CallbackQueueBatchSize is the number of due callbacks read from the queue
+// at a time.
+const CallbackQueueBatchSize = 500
+
+// CallbackQueue delivers task completion callbacks from a queue persisted in
+// the database, so that callbacks waiting to be delivered, or to be retried,
+// survive the BBS that accepted them. Due callbacks are attempted by up to
+// maxWorkers workers; a callback that fails is put back in the queue to be
+// attempted again after a backoff, and dead-lettered after its last attempt.
+type CallbackQueue struct {
+	logger       lager.Logger
+	maxWorkers   int
+	httpClient   *http.Client
+	config       *CallbackConfig
+	clock        clock.Clock
+	queueDB      db.TaskCallbackDB
+	taskDB       db.TaskDB
+	taskHub      events.Hub
+	pollInterval time.Duration
+
+	wake chan struct{}
+
+	inFlightLock sync.Mutex
+	inFlight     map[string]struct{}
+}
+
+func NewCallbackQueue(
+	logger lager.Logger,
+	maxWorkers int,
+	httpClient *http.Client,
+	config *CallbackConfig,
+	clock clock.Clock,
+	queueDB db.TaskCallbackDB,
+	taskDB db.TaskDB,
+	taskHub events.Hub,
+	pollInterval time.Duration,
+) *CallbackQueue {
+	return &CallbackQueue{
+		logger:       logger.Session("task-callback-queue"),
+		maxWorkers:   maxWorkers,
+		httpClient:   httpClient,
+		config:       config,
+		clock:        clock,
+		queueDB:      queueDB,
+		taskDB:       taskDB,
+		taskHub:      taskHub,
+		pollInterval: pollInterval,
+		wake:         make(chan struct{}, 1),
+		inFlight:     map[string]struct{}{},
+	}
+}
+
+// Submit queues the callback of the task. The callback is delivered through
+// the task database and hub the queue was created with.
+func (q *CallbackQueue) Submit(taskDB db.TaskDB, taskHub events.Hub, task *models.Task) {
+	if task.CompletionCallbackUrl == "" {
+		return
+	}
+
+	logger := q.logger.Session("submit", lager.Data{"task_guid": task.TaskGuid})
+	err := q.queueDB.EnqueueTaskCallback(logger, task.TaskGuid)
+	if err != nil {
+		logger.Error("failed-enqueuing-task-callback", err)
+		return
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *CallbackQueue) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := q.logger
+	logger.Info("starting")
+
+	workPool, err := workpool.NewWorkPool(q.maxWorkers)
+	if err != nil {
+		logger.Error("creation-failed", err)
+		return err
+	}
+	defer workPool.Stop()
+
+	close(ready)
+	logger.Info("started")
+	defer logger.Info("finished")
+
+	ticker := q.clock.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	q.drain(logger, workPool)
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C():
+		case <-q.wake:
+		}
+		q.drain(logger, workPool)
+	}
+}
+
+// drain hands the due callbacks that are not already being attempted to the
+// workers.
+func (q *CallbackQueue) drain(logger lager.Logger, workPool *workpool.WorkPool) {
+	depth, err := q.queueDB.TaskCallbackQueueDepth(logger)
+	if err != nil {
+		logger.Error("failed-fetching-queue-depth", err)
+	} else {
+		err = taskCallbackQueueDepth.Send(depth)
+		if err != nil {
+			logger.Error("failed-to-send-task-callback-queue-depth-metric", err)
+		}
+	}
+
+	callbacks, err := q.queueDB.DueTaskCallbacks(logger, CallbackQueueBatchSize)
+	if err != nil {
+		logger.Error("failed-fetching-due-task-callbacks", err)
+		return
+	}
+
+	for _, callback := range callbacks {
+		if !q.claim(callback.TaskGuid) {
+			continue
+		}
+
+		callback := callback
+		workPool.Submit(func() {
+			defer q.release(callback.TaskGuid)
+			q.attempt(logger, callback)
+		})
+	}
+}
+
+func (q *CallbackQueue) claim(taskGuid string) bool {
+	q.inFlightLock.Lock()
+	defer q.inFlightLock.Unlock()
+
+	if _, found := q.inFlight[taskGuid]; found {
+		return false
+	}
+	q.inFlight[taskGuid] = struct{}{}
+	return true
+}
+
+func (q *CallbackQueue) release(taskGuid string) {
+	q.inFlightLock.Lock()
+	delete(q.inFlight, taskGuid)
+	q.inFlightLock.Unlock()
+}
+
+// attempt makes one attempt at delivering the callback. A task that is no
+// longer waiting for its callback is dropped from the queue.
+func (q *CallbackQueue) attempt(logger lager.Logger, callback *models.QueuedTaskCallback) {
+	attempts := callback.Attempts + 1
+	logger = logger.Session("attempt-task-callback", lager.Data{"task_guid": callback.TaskGuid, "attempt": attempts})
+
+	task, err := q.taskDB.TaskByGuid(logger, callback.TaskGuid)
+	if err == models.ErrResourceNotFound {
+		logger.Info("task-not-found")
+		q.remove(logger, callback.TaskGuid)
+		return
+	}
+	if err != nil {
+		logger.Error("failed-fetching-task", err)
+		return
+	}
+
+	switch {
+	case task.State == models.Task_Completed && !task.CallbackDeadLettered:
+		if !resolveTask(logger, q.taskDB, q.taskHub, task) {
+			return
+		}
+	case task.State == models.Task_Resolving:
+	default:
+		logger.Info("task-not-awaiting-callback", lager.Data{"state": task.State})
+		q.remove(logger, callback.TaskGuid)
+		return
+	}
+
+	logger = logger.WithData(lager.Data{"callback_url": task.CompletionCallbackUrl})
+
+	body, err := callbackBody(task)
+	if err != nil {
+		logger.Error("marshalling-task-failed", err)
+		deadLetterTask(logger, q.taskDB, q.taskHub, task, int(attempts), err)
+		q.remove(logger, callback.TaskGuid)
+		return
+	}
+
+	request, err := newCallbackRequest(q.config, q.clock, task, body)
+	if err != nil {
+		logger.Error("building-request-failed", err)
+		deadLetterTask(logger, q.taskDB, q.taskHub, task, int(attempts), err)
+		q.remove(logger, callback.TaskGuid)
+		return
+	}
+
+	callbackErr := doCallbackRequest(q.httpClient, request)
+	if callbackErr == nil {
+		deleteResolvedTask(logger, q.taskDB, q.taskHub, task)
+		q.remove(logger, callback.TaskGuid)
+
+		err = taskCallbackLatency.Send(q.clock.Now().Sub(time.Unix(0, callback.EnqueuedAt)))
+		if err != nil {
+			logger.Error("failed-to-send-task-callback-latency-metric", err)
+		}
+		return
+	}
+	logger.Error("callback-attempt-failed", callbackErr)
+
+	if int(attempts) >= q.config.MaxAttempts {
+		deadLetterTask(logger, q.taskDB, q.taskHub, task, int(attempts), callbackErr)
+		q.remove(logger, callback.TaskGuid)
+		return
+	}
+
+	nextAttemptAt := q.clock.Now().Add(q.config.backoff(int(attempts))).UnixNano()
+	err = q.queueDB.RescheduleTaskCallback(logger, callback.TaskGuid, attempts, nextAttemptAt)
+	if err != nil {
+		logger.Error("failed-rescheduling-task-callback", err)
+	}
+}
+
+func (q *CallbackQueue) remove(logger lager.Logger, taskGuid string) {
+	err := q.queueDB.RemoveTaskCallback(logger, taskGuid)
+	if err != nil {
+		logger.Error("failed-removing-task-callback", err)
+	}
+}
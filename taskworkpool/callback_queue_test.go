@@ -0,0 +1,233 @@
+package taskworkpool_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	"github.com/cloudfoundry-incubator/bbs/webhook"
+	"github.com/cloudfoundry-incubator/cf_http"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("CallbackQueue", func() {
+	const pollInterval = 10 * time.Second
+
+	var (
+		fakeServer  *ghttp.Server
+		statusCodes chan int
+
+		queueDB   *dbfakes.FakeTaskCallbackDB
+		taskDB    *dbfakes.FakeTaskDB
+		taskHub   *eventfakes.FakeHub
+		fakeClock *fakeclock.FakeClock
+		sender    *fake.FakeMetricSender
+
+		task          *models.Task
+		queued        *models.QueuedTaskCallback
+		callbackQueue *taskworkpool.CallbackQueue
+		process       ifrit.Process
+	)
+
+	BeforeEach(func() {
+		cf_http.Initialize(time.Second)
+		fakeServer = ghttp.NewServer()
+		statusCodes = make(chan int)
+		fakeServer.RouteToHandler("POST", "/the-callback/url", func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(<-statusCodes)
+		})
+
+		sender = fake.NewFakeMetricSender()
+		dropsonde_metrics.Initialize(sender, nil)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1466000000, 0))
+
+		task = model_helpers.NewValidTask("the-task-guid")
+		task.State = models.Task_Completed
+		task.CompletionCallbackUrl = fakeServer.URL() + "/the-callback/url"
+
+		queued = &models.QueuedTaskCallback{
+			TaskGuid:      "the-task-guid",
+			EnqueuedAt:    fakeClock.Now().Add(-3 * time.Second).UnixNano(),
+			NextAttemptAt: fakeClock.Now().UnixNano(),
+		}
+
+		queueDB = new(dbfakes.FakeTaskCallbackDB)
+		queueDB.DueTaskCallbacksReturns([]*models.QueuedTaskCallback{queued}, nil)
+		queueDB.TaskCallbackQueueDepthReturns(7, nil)
+
+		taskDB = new(dbfakes.FakeTaskDB)
+		taskDB.TaskByGuidReturns(task, nil)
+		taskDB.ResolvingTaskReturns(&models.TaskChange{}, nil)
+		taskDB.DeadLetterTaskReturns(&models.TaskChange{}, nil)
+		taskHub = new(eventfakes.FakeHub)
+
+		config := &taskworkpool.CallbackConfig{
+			MaxAttempts:    3,
+			InitialBackoff: webhook.Duration(time.Second),
+			MaxBackoff:     webhook.Duration(4 * time.Second),
+		}
+
+		callbackQueue = taskworkpool.NewCallbackQueue(
+			lagertest.NewTestLogger("test"),
+			2,
+			cf_http.NewClient(),
+			config,
+			fakeClock,
+			queueDB,
+			taskDB,
+			taskHub,
+			pollInterval,
+		)
+	})
+
+	JustBeforeEach(func() {
+		process = ginkgomon.Invoke(callbackQueue)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Kill(process)
+		close(statusCodes)
+		fakeServer.Close()
+	})
+
+	It("reports the depth of the queue", func() {
+		Eventually(func() fake.Metric {
+			return sender.GetValue("TaskCallbackQueueDepth")
+		}).Should(Equal(fake.Metric{Value: 7, Unit: "Metric"}))
+
+		statusCodes <- 200
+	})
+
+	It("delivers the due callbacks when it starts", func() {
+		statusCodes <- 200
+
+		Eventually(queueDB.RemoveTaskCallbackCallCount).Should(Equal(1))
+		_, taskGuid := queueDB.RemoveTaskCallbackArgsForCall(0)
+		Expect(taskGuid).To(Equal("the-task-guid"))
+
+		Expect(taskDB.ResolvingTaskCallCount()).To(Equal(1))
+		Expect(taskDB.DeleteTaskCallCount()).To(Equal(1))
+		Expect(sender.GetValue("TaskCallbackLatency")).To(Equal(fake.Metric{Value: float64(3 * time.Second), Unit: "nanos"}))
+	})
+
+	It("does not attempt a callback again while it is being attempted", func() {
+		Eventually(fakeServer.ReceivedRequests).Should(HaveLen(1))
+
+		fakeClock.Increment(pollInterval)
+		Eventually(queueDB.DueTaskCallbacksCallCount).Should(Equal(2))
+		Consistently(fakeServer.ReceivedRequests, 0.25).Should(HaveLen(1))
+
+		statusCodes <- 200
+		Eventually(queueDB.RemoveTaskCallbackCallCount).Should(Equal(1))
+	})
+
+	Context("when the task is already resolving", func() {
+		BeforeEach(func() {
+			task.State = models.Task_Resolving
+		})
+
+		It("delivers the callback without resolving the task again", func() {
+			statusCodes <- 200
+
+			Eventually(taskDB.DeleteTaskCallCount).Should(Equal(1))
+			Expect(taskDB.ResolvingTaskCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the task no longer exists", func() {
+		BeforeEach(func() {
+			taskDB.TaskByGuidReturns(nil, models.ErrResourceNotFound)
+		})
+
+		It("drops the callback from the queue", func() {
+			Eventually(queueDB.RemoveTaskCallbackCallCount).Should(Equal(1))
+			Expect(fakeServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Context("when the task is not waiting for its callback", func() {
+		BeforeEach(func() {
+			task.State = models.Task_Completed
+			task.CallbackDeadLettered = true
+		})
+
+		It("drops the callback from the queue", func() {
+			Eventually(queueDB.RemoveTaskCallbackCallCount).Should(Equal(1))
+			Expect(fakeServer.ReceivedRequests()).To(BeEmpty())
+			Expect(taskDB.ResolvingTaskCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the callback fails", func() {
+		It("puts the callback back in the queue with backoff", func() {
+			statusCodes <- 503
+
+			Eventually(queueDB.RescheduleTaskCallbackCallCount).Should(Equal(1))
+			_, taskGuid, attempts, nextAttemptAt := queueDB.RescheduleTaskCallbackArgsForCall(0)
+			Expect(taskGuid).To(Equal("the-task-guid"))
+			Expect(attempts).To(BeEquivalentTo(1))
+			Expect(nextAttemptAt).To(BeNumerically(">=", fakeClock.Now().Add(500*time.Millisecond).UnixNano()))
+			Expect(nextAttemptAt).To(BeNumerically("<=", fakeClock.Now().Add(time.Second).UnixNano()))
+
+			Expect(queueDB.RemoveTaskCallbackCallCount()).To(Equal(0))
+			Expect(taskDB.DeleteTaskCallCount()).To(Equal(0))
+		})
+
+		Context("on the last attempt", func() {
+			BeforeEach(func() {
+				queued.Attempts = 2
+				task.State = models.Task_Resolving
+			})
+
+			It("dead-letters the task and drops the callback from the queue", func() {
+				statusCodes <- 503
+
+				Eventually(taskDB.DeadLetterTaskCallCount).Should(Equal(1))
+				_, taskGuid, reason := taskDB.DeadLetterTaskArgsForCall(0)
+				Expect(taskGuid).To(Equal("the-task-guid"))
+				Expect(reason).To(ContainSubstring("callback failed after 3 attempts"))
+
+				Eventually(queueDB.RemoveTaskCallbackCallCount).Should(Equal(1))
+				Expect(queueDB.RescheduleTaskCallbackCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Submit", func() {
+		BeforeEach(func() {
+			queueDB.DueTaskCallbacksReturns(nil, nil)
+		})
+
+		It("queues the callback and attempts the due callbacks", func() {
+			Eventually(queueDB.DueTaskCallbacksCallCount).Should(Equal(1))
+
+			callbackQueue.Submit(taskDB, taskHub, task)
+
+			Expect(queueDB.EnqueueTaskCallbackCallCount()).To(Equal(1))
+			_, taskGuid := queueDB.EnqueueTaskCallbackArgsForCall(0)
+			Expect(taskGuid).To(Equal("the-task-guid"))
+			Eventually(queueDB.DueTaskCallbacksCallCount).Should(Equal(2))
+		})
+
+		It("does not queue tasks without a callback", func() {
+			task.CompletionCallbackUrl = ""
+			callbackQueue.Submit(taskDB, taskHub, task)
+
+			Expect(queueDB.EnqueueTaskCallbackCallCount()).To(Equal(0))
+		})
+	})
+})
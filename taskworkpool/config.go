@@ -0,0 +1,144 @@
+package taskworkpool
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/webhook"
+	"github.com/cloudfoundry-incubator/cf_http"
+)
+
+// The default backoff keeps all the attempts at a callback within the default
+// kick duration of the task convergence, so that convergence does not demote
+// the task and deliver its callback again while it is still being retried.
+const (
+	DefaultCallbackMaxAttempts    = 5
+	DefaultCallbackInitialBackoff = time.Second
+	DefaultCallbackMaxBackoff     = 8 * time.Second
+)
+
+// CallbackConfig configures the delivery of task completion callbacks. A
+// callback that fails is retried up to MaxAttempts times, backing off
+// exponentially, with jitter, from InitialBackoff up to MaxBackoff. Callbacks
+// of tasks in a domain with a secret in DomainSecrets are signed with it.
+//
+// When a client certificate is configured it is presented to the callback
+// servers, and when a CA certificate is configured the callback servers must
+// present a certificate signed by it.
+type CallbackConfig struct {
+	MaxAttempts    int              `json:"max_attempts,omitempty"`
+	InitialBackoff webhook.Duration `json:"initial_backoff,omitempty"`
+	MaxBackoff     webhook.Duration `json:"max_backoff,omitempty"`
+
+	DomainSecrets map[string]string `json:"domain_secrets,omitempty"`
+
+	ClientCertFile string `json:"client_cert_file,omitempty"`
+	ClientKeyFile  string `json:"client_key_file,omitempty"`
+	CACertFile     string `json:"ca_cert_file,omitempty"`
+}
+
+func DefaultCallbackConfig() *CallbackConfig {
+	config := &CallbackConfig{}
+	config.applyDefaults()
+	return config
+}
+
+func LoadCallbackConfig(path string) (*CallbackConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &CallbackConfig{}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return nil, err
+	}
+
+	config.applyDefaults()
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (c *CallbackConfig) applyDefaults() {
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = DefaultCallbackMaxAttempts
+	}
+	if c.InitialBackoff == 0 {
+		c.InitialBackoff = webhook.Duration(DefaultCallbackInitialBackoff)
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = webhook.Duration(DefaultCallbackMaxBackoff)
+	}
+}
+
+func (c *CallbackConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return errors.New("task callback config has a negative max_attempts")
+	}
+	if c.InitialBackoff < 0 || c.MaxBackoff < c.InitialBackoff {
+		return errors.New("task callback config has invalid backoffs")
+	}
+
+	for domain, secret := range c.DomainSecrets {
+		if secret == "" {
+			return fmt.Errorf("task callback config has an empty secret for domain '%s'", domain)
+		}
+	}
+
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		return errors.New("task callback config requires both client_cert_file and client_key_file")
+	}
+
+	return nil
+}
+
+// NewHTTPClient returns the client to deliver callbacks with, configured with
+// the client certificate and CA certificate, if any.
+func (c *CallbackConfig) NewHTTPClient() (*http.Client, error) {
+	client := cf_http.NewClient()
+	if c.ClientCertFile == "" && c.CACertFile == "" {
+		return client, nil
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("task callback client does not support TLS configuration")
+	}
+
+	tlsConfig := &tls.Config{}
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("task callback CA certificate file '%s' has no certificates", c.CACertFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return client, nil
+}
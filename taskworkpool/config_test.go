@@ -0,0 +1,117 @@
+package taskworkpool_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	"github.com/cloudfoundry-incubator/bbs/webhook"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CallbackConfig", func() {
+	var (
+		tmpDir     string
+		configPath string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "task-callback-config")
+		Expect(err).NotTo(HaveOccurred())
+		configPath = filepath.Join(tmpDir, "config.json")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	writeConfig := func(contents string) {
+		Expect(ioutil.WriteFile(configPath, []byte(contents), 0600)).To(Succeed())
+	}
+
+	Describe("LoadCallbackConfig", func() {
+		It("applies the defaults", func() {
+			writeConfig(`{"domain_secrets": {"some-domain": "some-secret"}}`)
+
+			config, err := taskworkpool.LoadCallbackConfig(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.MaxAttempts).To(Equal(taskworkpool.DefaultCallbackMaxAttempts))
+			Expect(config.InitialBackoff).To(Equal(webhook.Duration(taskworkpool.DefaultCallbackInitialBackoff)))
+			Expect(config.MaxBackoff).To(Equal(webhook.Duration(taskworkpool.DefaultCallbackMaxBackoff)))
+			Expect(config.DomainSecrets).To(Equal(map[string]string{"some-domain": "some-secret"}))
+		})
+
+		It("loads the retries", func() {
+			writeConfig(`{"max_attempts": 10, "initial_backoff": "2s", "max_backoff": "1m"}`)
+
+			config, err := taskworkpool.LoadCallbackConfig(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.MaxAttempts).To(Equal(10))
+			Expect(config.InitialBackoff).To(Equal(webhook.Duration(2 * time.Second)))
+			Expect(config.MaxBackoff).To(Equal(webhook.Duration(time.Minute)))
+		})
+
+		It("rejects a max backoff shorter than the initial backoff", func() {
+			writeConfig(`{"initial_backoff": "1m", "max_backoff": "1s"}`)
+
+			_, err := taskworkpool.LoadCallbackConfig(configPath)
+			Expect(err).To(MatchError(ContainSubstring("invalid backoffs")))
+		})
+
+		It("rejects empty secrets", func() {
+			writeConfig(`{"domain_secrets": {"some-domain": ""}}`)
+
+			_, err := taskworkpool.LoadCallbackConfig(configPath)
+			Expect(err).To(MatchError(ContainSubstring("some-domain")))
+		})
+
+		It("requires a client key with a client certificate", func() {
+			writeConfig(`{"client_cert_file": "client.crt"}`)
+
+			_, err := taskworkpool.LoadCallbackConfig(configPath)
+			Expect(err).To(MatchError(ContainSubstring("client_key_file")))
+		})
+	})
+
+	Describe("NewHTTPClient", func() {
+		var certsPath string
+
+		BeforeEach(func() {
+			certsPath = filepath.Join("..", "cmd", "bbs", "fixtures", "blue-certs")
+		})
+
+		It("returns a client without TLS configuration by default", func() {
+			client, err := taskworkpool.DefaultCallbackConfig().NewHTTPClient()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Transport.(*http.Transport).TLSClientConfig).To(BeNil())
+		})
+
+		It("presents the client certificate and trusts the CA certificate", func() {
+			config := taskworkpool.DefaultCallbackConfig()
+			config.ClientCertFile = filepath.Join(certsPath, "client.crt")
+			config.ClientKeyFile = filepath.Join(certsPath, "client.key")
+			config.CACertFile = filepath.Join(certsPath, "server-ca.crt")
+
+			client, err := config.NewHTTPClient()
+			Expect(err).NotTo(HaveOccurred())
+
+			tlsConfig := client.Transport.(*http.Transport).TLSClientConfig
+			Expect(tlsConfig.Certificates).To(HaveLen(1))
+			Expect(tlsConfig.RootCAs).NotTo(BeNil())
+		})
+
+		It("fails when the CA certificate file has no certificates", func() {
+			config := taskworkpool.DefaultCallbackConfig()
+			config.CACertFile = filepath.Join(certsPath, "server-ca.key")
+
+			_, err := config.NewHTTPClient()
+			Expect(err).To(MatchError(ContainSubstring("has no certificates")))
+		})
+	})
+})
@@ -3,25 +3,42 @@ package taskworkpool
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"os"
-	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/models"
-	"github.com/cloudfoundry-incubator/cf_http"
+	"github.com/cloudfoundry-incubator/bbs/webhook"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
 	"github.com/cloudfoundry/gunk/workpool"
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 )
 
-const MAX_CB_RETRIES = 3
+const (
+	CallbackTimestampHeader = "X-BBS-Callback-Timestamp"
+	CallbackSignatureHeader = "X-BBS-Callback-Signature"
+)
+
+const taskCallbacksDeadLettered = metric.Counter("TaskCallbacksDeadLettered")
+
+// maxCallbackFailureReasonLength is the length callback failure reasons are
+// truncated to so that they fit in the database.
+const maxCallbackFailureReasonLength = 255
 
 //go:generate counterfeiter . TaskCompletionClient
 
-type CompletedTaskHandler func(logger lager.Logger, httpClient *http.Client, taskDB db.TaskDB, task *models.Task)
+type CompletedTaskHandler func(logger lager.Logger, httpClient *http.Client, taskDB db.TaskDB, taskHub events.Hub, task *models.Task)
 
 type TaskCompletionClient interface {
-	Submit(taskDB db.TaskDB, task *models.Task)
+	Submit(taskDB db.TaskDB, taskHub events.Hub, task *models.Task)
 }
 
 type TaskCompletionWorkPool struct {
@@ -32,7 +49,7 @@ type TaskCompletionWorkPool struct {
 	httpClient       *http.Client
 }
 
-func New(logger lager.Logger, maxWorkers int, cbHandler CompletedTaskHandler) *TaskCompletionWorkPool {
+func New(logger lager.Logger, maxWorkers int, httpClient *http.Client, cbHandler CompletedTaskHandler) *TaskCompletionWorkPool {
 	if cbHandler == nil {
 		panic("callbackHandler cannot be nil")
 	}
@@ -40,7 +57,7 @@ func New(logger lager.Logger, maxWorkers int, cbHandler CompletedTaskHandler) *T
 		logger:          logger.Session("task-completion-workpool"),
 		maxWorkers:      maxWorkers,
 		callbackHandler: cbHandler,
-		httpClient:      cf_http.NewClient(),
+		httpClient:      httpClient,
 	}
 }
 
@@ -64,82 +81,169 @@ func (twp *TaskCompletionWorkPool) Run(signals <-chan os.Signal, ready chan<- st
 	return nil
 }
 
-func (twp *TaskCompletionWorkPool) Submit(taskDB db.TaskDB, task *models.Task) {
+func (twp *TaskCompletionWorkPool) Submit(taskDB db.TaskDB, taskHub events.Hub, task *models.Task) {
 	if twp.callbackWorkPool == nil {
 		panic("called submit before workpool was started")
 	}
 	logger := twp.logger
 	twp.callbackWorkPool.Submit(func() {
-		twp.callbackHandler(logger, twp.httpClient, taskDB, task)
+		twp.callbackHandler(logger, twp.httpClient, taskDB, taskHub, task)
 	})
 }
 
-func HandleCompletedTask(logger lager.Logger, httpClient *http.Client, taskDB db.TaskDB, task *models.Task) {
+// NewCompletedTaskHandler returns a handler that delivers the completion
+// callbacks of tasks as configured. A callback is retried when the request
+// fails or the callback server responds with a 5xx status; any other response
+// resolves the task. A task whose callback is still failing after the last
+// attempt is dead-lettered.
+func NewCompletedTaskHandler(config *CallbackConfig, clock clock.Clock) CompletedTaskHandler {
+	return func(logger lager.Logger, httpClient *http.Client, taskDB db.TaskDB, taskHub events.Hub, task *models.Task) {
+		handleCompletedTask(logger, config, clock, httpClient, taskDB, taskHub, task)
+	}
+}
+
+func handleCompletedTask(logger lager.Logger, config *CallbackConfig, clock clock.Clock, httpClient *http.Client, taskDB db.TaskDB, taskHub events.Hub, task *models.Task) {
 	logger = logger.Session("handle-completed-task", lager.Data{"task_guid": task.TaskGuid})
 
-	if task.CompletionCallbackUrl != "" {
-		modelErr := taskDB.ResolvingTask(logger, task.TaskGuid)
-		if modelErr != nil {
-			logger.Error("marking-task-as-resolving-failed", modelErr)
-			return
-		}
+	if task.CompletionCallbackUrl == "" {
+		return
+	}
+
+	if !resolveTask(logger, taskDB, taskHub, task) {
+		return
+	}
 
-		logger = logger.WithData(lager.Data{"callback_url": task.CompletionCallbackUrl})
+	logger = logger.WithData(lager.Data{"callback_url": task.CompletionCallbackUrl})
+
+	body, err := callbackBody(task)
+	if err != nil {
+		logger.Error("marshalling-task-failed", err)
+		return
+	}
 
-		json, err := json.Marshal(&models.TaskCallbackResponse{
-			TaskGuid:      task.TaskGuid,
-			Failed:        task.Failed,
-			FailureReason: task.FailureReason,
-			Result:        task.Result,
-			Annotation:    task.Annotation,
-			CreatedAt:     task.CreatedAt,
-		})
+	for attempt := 1; ; attempt++ {
+		request, err := newCallbackRequest(config, clock, task, body)
 		if err != nil {
-			logger.Error("marshalling-task-failed", err)
+			logger.Error("building-request-failed", err)
 			return
 		}
 
-		var statusCode int
-
-		for i := 0; i < MAX_CB_RETRIES; i++ {
-			request, err := http.NewRequest("POST", task.CompletionCallbackUrl, bytes.NewReader(json))
-			if err != nil {
-				logger.Error("building-request-failed", err)
-				return
-			}
-
-			request.Header.Set("Content-Type", "application/json")
-			response, err := httpClient.Do(request)
-			if err != nil {
-				matched, _ := regexp.MatchString("Client.Timeout|use of closed network connection", err.Error())
-				if matched {
-					continue
-				}
-				logger.Error("doing-request-failed", err)
-				return
-			}
-			defer response.Body.Close()
-
-			statusCode = response.StatusCode
-			if shouldResolve(statusCode) {
-				modelErr := taskDB.DeleteTask(logger, task.TaskGuid)
-				if modelErr != nil {
-					logger.Error("delete-task-failed", modelErr)
-				}
-				return
-			}
+		callbackErr := doCallbackRequest(httpClient, request)
+		if callbackErr == nil {
+			break
 		}
+		logger.Error("callback-attempt-failed", callbackErr, lager.Data{"attempt": attempt})
 
-		logger.Info("callback-failed", lager.Data{"status_code": statusCode})
+		if attempt >= config.MaxAttempts {
+			deadLetterTask(logger, taskDB, taskHub, task, attempt, callbackErr)
+			return
+		}
+
+		clock.Sleep(config.backoff(attempt))
 	}
-	return
+
+	deleteResolvedTask(logger, taskDB, taskHub, task)
 }
 
-func shouldResolve(status int) bool {
-	switch status {
-	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+// resolveTask marks the completed task as resolving, returning whether the
+// callback should be delivered.
+func resolveTask(logger lager.Logger, taskDB db.TaskDB, taskHub events.Hub, task *models.Task) bool {
+	change, modelErr := taskDB.ResolvingTask(logger, task.TaskGuid)
+	if modelErr != nil {
+		logger.Error("marking-task-as-resolving-failed", modelErr)
 		return false
-	default:
-		return true
 	}
+
+	taskHub.Emit(models.NewTaskChangedEvent(change.Before, change.After))
+	return true
+}
+
+func callbackBody(task *models.Task) ([]byte, error) {
+	return json.Marshal(&models.TaskCallbackResponse{
+		TaskGuid:      task.TaskGuid,
+		Failed:        task.Failed,
+		FailureReason: task.FailureReason,
+		Result:        task.Result,
+		Annotation:    task.Annotation,
+		CreatedAt:     task.CreatedAt,
+	})
+}
+
+func deleteResolvedTask(logger lager.Logger, taskDB db.TaskDB, taskHub events.Hub, task *models.Task) {
+	removedTask, modelErr := taskDB.DeleteTask(logger, task.TaskGuid)
+	if modelErr != nil {
+		logger.Error("delete-task-failed", modelErr)
+		return
+	}
+
+	taskHub.Emit(models.NewTaskRemovedEvent(removedTask))
+}
+
+func newCallbackRequest(config *CallbackConfig, clock clock.Clock, task *models.Task, body []byte) (*http.Request, error) {
+	request, err := http.NewRequest("POST", task.CompletionCallbackUrl, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := clock.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(CallbackTimestampHeader, strconv.FormatInt(timestamp, 10))
+	if secret, ok := config.DomainSecrets[task.Domain]; ok {
+		request.Header.Set(CallbackSignatureHeader, webhook.Sign(secret, timestamp, body))
+	}
+
+	return request, nil
+}
+
+// doCallbackRequest returns an error if the callback should be retried,
+// which is when the request fails or the response has a 5xx status.
+func doCallbackRequest(httpClient *http.Client, request *http.Request) error {
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode >= 500 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+func deadLetterTask(logger lager.Logger, taskDB db.TaskDB, taskHub events.Hub, task *models.Task, attempts int, callbackErr error) {
+	failureReason := fmt.Sprintf("callback failed after %d attempts: %s", attempts, callbackErr.Error())
+	if len(failureReason) > maxCallbackFailureReasonLength {
+		failureReason = failureReason[:maxCallbackFailureReasonLength]
+	}
+
+	logger.Info("dead-lettering-task", lager.Data{"callback_failure_reason": failureReason})
+	change, modelErr := taskDB.DeadLetterTask(logger, task.TaskGuid, failureReason)
+	if modelErr != nil {
+		logger.Error("dead-letter-task-failed", modelErr)
+		return
+	}
+
+	err := taskCallbacksDeadLettered.Increment()
+	if err != nil {
+		logger.Error("failed-to-send-task-callbacks-dead-lettered-metric", err)
+	}
+
+	taskHub.Emit(models.NewTaskChangedEvent(change.Before, change.After))
+}
+
+// backoff returns how long to wait after the given attempt: a random duration
+// between half of, and the full, exponential backoff for the attempt.
+func (c *CallbackConfig) backoff(attempt int) time.Duration {
+	backoff := time.Duration(c.InitialBackoff)
+	for i := 1; i < attempt && backoff < time.Duration(c.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if backoff > time.Duration(c.MaxBackoff) {
+		backoff = time.Duration(c.MaxBackoff)
+	}
+
+	half := int64(backoff / 2)
+	return time.Duration(half + rand.Int63n(half+1))
 }
@@ -5,13 +5,17 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/db/dbfakes"
+	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
 	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	"github.com/cloudfoundry-incubator/bbs/webhook"
 	"github.com/cloudfoundry-incubator/cf_http"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/pivotal-golang/lager"
 	"github.com/pivotal-golang/lager/lagertest"
 	"github.com/tedsuo/ifrit"
@@ -46,11 +50,14 @@ var _ = Describe("TaskWorker", func() {
 		var (
 			callbackURL string
 			taskDB      *dbfakes.FakeTaskDB
+			taskHub     *eventfakes.FakeHub
 			statusCodes chan int
 			reqCount    chan struct{}
 			task        *models.Task
 
 			httpClient *http.Client
+			config     *taskworkpool.CallbackConfig
+			fakeClock  *fakeclock.FakeClock
 		)
 
 		BeforeEach(func() {
@@ -58,21 +65,31 @@ var _ = Describe("TaskWorker", func() {
 			statusCodes = make(chan int)
 			reqCount = make(chan struct{})
 
+			config = &taskworkpool.CallbackConfig{
+				MaxAttempts:    3,
+				InitialBackoff: webhook.Duration(time.Second),
+				MaxBackoff:     webhook.Duration(4 * time.Second),
+			}
+			fakeClock = fakeclock.NewFakeClock(time.Unix(1466000000, 0))
+
 			fakeServer.RouteToHandler("POST", "/the-callback/url", func(w http.ResponseWriter, req *http.Request) {
 				w.WriteHeader(<-statusCodes)
 			})
 
 			callbackURL = fakeServer.URL() + "/the-callback/url"
 			taskDB = new(dbfakes.FakeTaskDB)
-			taskDB.ResolvingTaskReturns(nil)
-			taskDB.DeleteTaskReturns(nil)
+			taskDB.ResolvingTaskReturns(&models.TaskChange{}, nil)
+			taskDB.DeleteTaskReturns(nil, nil)
+			taskDB.DeadLetterTaskReturns(&models.TaskChange{}, nil)
+			taskHub = new(eventfakes.FakeHub)
 		})
 
 		simulateTaskCompleting := func(signals <-chan os.Signal, ready chan<- struct{}) error {
 			close(ready)
 			task = model_helpers.NewValidTask("the-task-guid")
 			task.CompletionCallbackUrl = callbackURL
-			taskworkpool.HandleCompletedTask(logger, httpClient, taskDB, task)
+			handler := taskworkpool.NewCompletedTaskHandler(config, fakeClock)
+			handler(logger, httpClient, taskDB, taskHub, task)
 			return nil
 		}
 
@@ -100,15 +117,38 @@ var _ = Describe("TaskWorker", func() {
 
 			Context("when marking the task as resolving fails", func() {
 				BeforeEach(func() {
-					taskDB.ResolvingTaskReturns(models.NewError(models.Error_UnknownError, "failed to resolve task"))
+					taskDB.ResolvingTaskReturns(nil, models.NewError(models.Error_UnknownError, "failed to resolve task"))
 				})
 
 				It("does not make a request to the task's callback URL", func() {
 					Consistently(fakeServer.ReceivedRequests, 0.25).Should(BeEmpty())
 				})
+
+				It("does not emit an event", func() {
+					Consistently(taskHub.EmitCallCount, 0.25).Should(Equal(0))
+				})
 			})
 
 			Context("when marking the task as resolving succeeds", func() {
+				var completedTask, resolvingTask *models.Task
+
+				BeforeEach(func() {
+					completedTask = model_helpers.NewValidTask("the-task-guid")
+					completedTask.State = models.Task_Completed
+					resolvingTask = model_helpers.NewValidTask("the-task-guid")
+					resolvingTask.State = models.Task_Resolving
+					taskDB.ResolvingTaskReturns(&models.TaskChange{Before: completedTask, After: resolvingTask}, nil)
+					taskDB.DeleteTaskReturns(resolvingTask, nil)
+				})
+
+				It("emits a task changed event", func() {
+					Eventually(taskHub.EmitCallCount).Should(Equal(1))
+					event := taskHub.EmitArgsForCall(0)
+					Expect(event).To(Equal(models.NewTaskChangedEvent(completedTask, resolvingTask)))
+
+					statusCodes <- 200
+				})
+
 				It("POSTs to the task's callback URL", func() {
 					statusCodes <- 200
 					Eventually(fakeServer.ReceivedRequests).Should(HaveLen(1))
@@ -138,21 +178,19 @@ var _ = Describe("TaskWorker", func() {
 						_, actualGuid := taskDB.DeleteTaskArgsForCall(0)
 						Expect(actualGuid).To(Equal("the-task-guid"))
 					})
-				})
 
-				Context("when the request fails with a 4xx response code", func() {
-					It("resolves the task", func() {
-						statusCodes <- 403
+					It("emits a task removed event", func() {
+						statusCodes <- 200
 
-						Eventually(taskDB.DeleteTaskCallCount).Should(Equal(1))
-						_, actualGuid := taskDB.DeleteTaskArgsForCall(0)
-						Expect(actualGuid).To(Equal("the-task-guid"))
+						Eventually(taskHub.EmitCallCount).Should(Equal(2))
+						event := taskHub.EmitArgsForCall(1)
+						Expect(event).To(Equal(models.NewTaskRemovedEvent(resolvingTask)))
 					})
 				})
 
-				Context("when the request fails with a 500 response code", func() {
+				Context("when the request fails with a 4xx response code", func() {
 					It("resolves the task", func() {
-						statusCodes <- 500
+						statusCodes <- 403
 
 						Eventually(taskDB.DeleteTaskCallCount).Should(Equal(1))
 						_, actualGuid := taskDB.DeleteTaskArgsForCall(0)
@@ -160,18 +198,21 @@ var _ = Describe("TaskWorker", func() {
 					})
 				})
 
-				Context("when the request fails with a 503 or 504 response code", func() {
-					It("retries the request 2 more times", func() {
+				Context("when the request fails with a 5xx response code", func() {
+					It("retries the request with backoff", func() {
 						Eventually(fakeServer.ReceivedRequests).Should(HaveLen(1))
 
-						statusCodes <- 503
+						statusCodes <- 500
 
-						Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
+						fakeClock.WaitForWatcherAndIncrement(500*time.Millisecond - 1)
+						Consistently(fakeServer.ReceivedRequests, 0.25).Should(HaveLen(1))
+						fakeClock.Increment(500*time.Millisecond + 1)
 						Eventually(fakeServer.ReceivedRequests).Should(HaveLen(2))
 
 						statusCodes <- 504
 
 						Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
+						fakeClock.WaitForWatcherAndIncrement(2 * time.Second)
 						Eventually(fakeServer.ReceivedRequests).Should(HaveLen(3))
 
 						statusCodes <- 200
@@ -179,33 +220,100 @@ var _ = Describe("TaskWorker", func() {
 						Eventually(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(1))
 						_, actualGuid := taskDB.DeleteTaskArgsForCall(0)
 						Expect(actualGuid).To(Equal("the-task-guid"))
+						Expect(taskDB.DeadLetterTaskCallCount()).To(Equal(0))
 					})
 
 					Context("when the request fails every time", func() {
-						It("does not resolve the task", func() {
-							Eventually(fakeServer.ReceivedRequests).Should(HaveLen(1))
+						var deadLetteredTask *models.Task
+
+						BeforeEach(func() {
+							deadLetteredTask = model_helpers.NewValidTask("the-task-guid")
+							deadLetteredTask.State = models.Task_Completed
+							deadLetteredTask.CallbackDeadLettered = true
+							taskDB.DeadLetterTaskReturns(&models.TaskChange{Before: resolvingTask, After: deadLetteredTask}, nil)
+						})
 
+						It("dead-letters the task once it runs out of attempts", func() {
+							Eventually(fakeServer.ReceivedRequests).Should(HaveLen(1))
 							statusCodes <- 503
 
-							Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
+							fakeClock.WaitForWatcherAndIncrement(time.Second)
 							Eventually(fakeServer.ReceivedRequests).Should(HaveLen(2))
+							statusCodes <- 503
 
-							statusCodes <- 504
-
-							Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
+							fakeClock.WaitForWatcherAndIncrement(2 * time.Second)
 							Eventually(fakeServer.ReceivedRequests).Should(HaveLen(3))
-
 							statusCodes <- 503
 
-							Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
+							Eventually(taskDB.DeadLetterTaskCallCount).Should(Equal(1))
+							_, actualGuid, reason := taskDB.DeadLetterTaskArgsForCall(0)
+							Expect(actualGuid).To(Equal("the-task-guid"))
+							Expect(reason).To(Equal("callback failed after 3 attempts: unexpected status code 503"))
+
+							Expect(taskDB.DeleteTaskCallCount()).To(Equal(0))
 							Consistently(fakeServer.ReceivedRequests, 0.25).Should(HaveLen(3))
 						})
+
+						It("emits a task changed event for the dead-lettered task", func() {
+							for i := 0; i < 3; i++ {
+								Eventually(fakeServer.ReceivedRequests).Should(HaveLen(i + 1))
+								statusCodes <- 503
+								if i < 2 {
+									fakeClock.WaitForWatcherAndIncrement(4 * time.Second)
+								}
+							}
+
+							Eventually(taskHub.EmitCallCount).Should(Equal(2))
+							Expect(taskHub.EmitArgsForCall(1)).To(Equal(models.NewTaskChangedEvent(resolvingTask, deadLetteredTask)))
+						})
+					})
+				})
+
+				Context("when the task's domain has a callback secret", func() {
+					var headers chan http.Header
+					var bodies chan []byte
+
+					BeforeEach(func() {
+						config.DomainSecrets = map[string]string{"some-domain": "some-secret"}
+
+						headers = make(chan http.Header, 1)
+						bodies = make(chan []byte, 1)
+						fakeServer.RouteToHandler("POST", "/the-callback/url", func(w http.ResponseWriter, req *http.Request) {
+							data, err := ioutil.ReadAll(req.Body)
+							Expect(err).NotTo(HaveOccurred())
+							headers <- req.Header
+							bodies <- data
+							w.WriteHeader(200)
+						})
+					})
+
+					It("signs the callback with the secret", func() {
+						var header http.Header
+						Eventually(headers).Should(Receive(&header))
+						body := <-bodies
+
+						timestamp := fakeClock.Now().Unix()
+						Expect(header.Get(taskworkpool.CallbackTimestampHeader)).To(Equal(strconv.FormatInt(timestamp, 10)))
+						Expect(header.Get(taskworkpool.CallbackSignatureHeader)).To(Equal(webhook.Sign("some-secret", timestamp, body)))
+					})
+
+					Context("when the secret is for another domain", func() {
+						BeforeEach(func() {
+							config.DomainSecrets = map[string]string{"other-domain": "some-secret"}
+						})
+
+						It("does not sign the callback", func() {
+							var header http.Header
+							Eventually(headers).Should(Receive(&header))
+							Expect(header.Get(taskworkpool.CallbackTimestampHeader)).NotTo(BeEmpty())
+							Expect(header.Get(taskworkpool.CallbackSignatureHeader)).To(BeEmpty())
+						})
 					})
 				})
 
 				Context("when DeleteTask fails", func() {
 					BeforeEach(func() {
-						taskDB.DeleteTaskReturns(&models.Error{})
+						taskDB.DeleteTaskReturns(nil, &models.Error{})
 					})
 
 					It("logs an error and returns", func() {
@@ -215,6 +323,13 @@ var _ = Describe("TaskWorker", func() {
 						Eventually(taskDB.DeleteTaskCallCount).Should(Equal(1))
 						Eventually(logger.TestSink.LogMessages).Should(ContainElement("test.handle-completed-task.delete-task-failed"))
 					})
+
+					It("does not emit a task removed event", func() {
+						statusCodes <- 200
+
+						Eventually(taskDB.DeleteTaskCallCount).Should(Equal(1))
+						Consistently(taskHub.EmitCallCount, 0.25).Should(Equal(1))
+					})
 				})
 
 				Context("when the request fails with a timeout", func() {
@@ -228,19 +343,22 @@ var _ = Describe("TaskWorker", func() {
 						})
 					})
 
-					It("retries the request 2 more times", func() {
+					It("retries the request", func() {
 						sleepCh <- timeout + 100*time.Millisecond
 						Eventually(fakeServer.ReceivedRequests).Should(HaveLen(1))
 
+						fakeClock.WaitForWatcherAndIncrement(time.Second)
 						sleepCh <- timeout + 100*time.Millisecond
 						Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
 						Eventually(fakeServer.ReceivedRequests).Should(HaveLen(2))
 
+						fakeClock.WaitForWatcherAndIncrement(2 * time.Second)
 						sleepCh <- timeout + 100*time.Millisecond
 						Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
 						Eventually(fakeServer.ReceivedRequests).Should(HaveLen(3))
 
-						Eventually(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
+						Eventually(taskDB.DeadLetterTaskCallCount, 2*timeout).Should(Equal(1))
+						Expect(taskDB.DeleteTaskCallCount()).To(Equal(0))
 					})
 
 					Context("when the request fails with timeout once and then succeeds", func() {
@@ -250,6 +368,7 @@ var _ = Describe("TaskWorker", func() {
 							Eventually(fakeServer.ReceivedRequests).Should(HaveLen(1))
 							Consistently(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(0))
 
+							fakeClock.WaitForWatcherAndIncrement(time.Second)
 							sleepCh <- 0
 							Eventually(fakeServer.ReceivedRequests).Should(HaveLen(2))
 							Eventually(taskDB.DeleteTaskCallCount, 0.25).Should(Equal(1))
@@ -5,28 +5,31 @@ import (
 	"sync"
 
 	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
 )
 
 type FakeTaskCompletionClient struct {
-	SubmitStub        func(taskDB db.TaskDB, task *models.Task)
+	SubmitStub        func(taskDB db.TaskDB, taskHub events.Hub, task *models.Task)
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		taskDB db.TaskDB
-		task   *models.Task
+		taskDB  db.TaskDB
+		taskHub events.Hub
+		task    *models.Task
 	}
 }
 
-func (fake *FakeTaskCompletionClient) Submit(taskDB db.TaskDB, task *models.Task) {
+func (fake *FakeTaskCompletionClient) Submit(taskDB db.TaskDB, taskHub events.Hub, task *models.Task) {
 	fake.submitMutex.Lock()
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		taskDB db.TaskDB
-		task   *models.Task
-	}{taskDB, task})
+		taskDB  db.TaskDB
+		taskHub events.Hub
+		task    *models.Task
+	}{taskDB, taskHub, task})
 	fake.submitMutex.Unlock()
 	if fake.SubmitStub != nil {
-		fake.SubmitStub(taskDB, task)
+		fake.SubmitStub(taskDB, taskHub, task)
 	}
 }
 
@@ -36,10 +39,10 @@ func (fake *FakeTaskCompletionClient) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeTaskCompletionClient) SubmitArgsForCall(i int) (db.TaskDB, *models.Task) {
+func (fake *FakeTaskCompletionClient) SubmitArgsForCall(i int) (db.TaskDB, events.Hub, *models.Task) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
-	return fake.submitArgsForCall[i].taskDB, fake.submitArgsForCall[i].task
+	return fake.submitArgsForCall[i].taskDB, fake.submitArgsForCall[i].taskHub, fake.submitArgsForCall[i].task
 }
 
 var _ taskworkpool.TaskCompletionClient = new(FakeTaskCompletionClient)
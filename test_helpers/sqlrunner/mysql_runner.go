@@ -71,6 +71,7 @@ func (m *MySQLRunner) Reset() {
 		"TRUNCATE TABLE tasks",
 		"TRUNCATE TABLE desired_lrps",
 		"TRUNCATE TABLE actual_lrps",
+		"TRUNCATE TABLE audit_records",
 	}
 	for _, query := range truncateTablesSQL {
 		result, err := m.db.Exec(query)
@@ -76,6 +76,7 @@ func (p *PostgresRunner) Reset() {
 		"TRUNCATE TABLE tasks",
 		"TRUNCATE TABLE desired_lrps",
 		"TRUNCATE TABLE actual_lrps",
+		"TRUNCATE TABLE audit_records",
 	}
 	for _, query := range truncateTablesSQL {
 		result, err := p.db.Exec(query)
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+)
+
+const (
+	DefaultBatchSize      = 100
+	DefaultFlushInterval  = time.Second
+	DefaultTimeout        = 10 * time.Second
+	DefaultMaxAttempts    = 5
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = time.Minute
+	DefaultMaxDeadLetters = 100
+)
+
+var validName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Duration is a time.Duration written in JSON as a string such as "1m30s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(duration)
+	return nil
+}
+
+type Filter struct {
+	Domain       string   `json:"domain,omitempty"`
+	ProcessGuids []string `json:"process_guids,omitempty"`
+	CellID       string   `json:"cell_id,omitempty"`
+	EventTypes   []string `json:"event_types,omitempty"`
+}
+
+func (f Filter) EventFilter() models.EventFilter {
+	return models.EventFilter{
+		Domain:       f.Domain,
+		ProcessGuids: f.ProcessGuids,
+		CellID:       f.CellID,
+		EventTypes:   f.EventTypes,
+	}
+}
+
+// An Endpoint receives the events matching its filter as JSON batches,
+// POSTed once BatchSize events are pending or FlushInterval has passed. A
+// batch that still fails after MaxAttempts is moved to the endpoint's
+// dead-letter store, which keeps at most MaxDeadLetters batches.
+type Endpoint struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+	Filter Filter `json:"filter"`
+
+	BatchSize     int      `json:"batch_size,omitempty"`
+	FlushInterval Duration `json:"flush_interval,omitempty"`
+	Timeout       Duration `json:"timeout,omitempty"`
+
+	MaxAttempts    int      `json:"max_attempts,omitempty"`
+	InitialBackoff Duration `json:"initial_backoff,omitempty"`
+	MaxBackoff     Duration `json:"max_backoff,omitempty"`
+
+	MaxDeadLetters int `json:"max_dead_letters,omitempty"`
+}
+
+// Config lists the webhook endpoints. Each endpoint keeps its dead letters in
+// a directory named after it under DeadLetterDir; without a DeadLetterDir
+// failed batches are only logged.
+type Config struct {
+	Endpoints     []Endpoint `json:"endpoints"`
+	DeadLetterDir string     `json:"dead_letter_dir,omitempty"`
+}
+
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return nil, err
+	}
+
+	config.applyDefaults()
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (c *Config) applyDefaults() {
+	for i := range c.Endpoints {
+		endpoint := &c.Endpoints[i]
+		if endpoint.BatchSize == 0 {
+			endpoint.BatchSize = DefaultBatchSize
+		}
+		if endpoint.FlushInterval == 0 {
+			endpoint.FlushInterval = Duration(DefaultFlushInterval)
+		}
+		if endpoint.Timeout == 0 {
+			endpoint.Timeout = Duration(DefaultTimeout)
+		}
+		if endpoint.MaxAttempts == 0 {
+			endpoint.MaxAttempts = DefaultMaxAttempts
+		}
+		if endpoint.InitialBackoff == 0 {
+			endpoint.InitialBackoff = Duration(DefaultInitialBackoff)
+		}
+		if endpoint.MaxBackoff == 0 {
+			endpoint.MaxBackoff = Duration(DefaultMaxBackoff)
+		}
+		if endpoint.MaxDeadLetters == 0 {
+			endpoint.MaxDeadLetters = DefaultMaxDeadLetters
+		}
+	}
+}
+
+func (c *Config) Validate() error {
+	if len(c.Endpoints) == 0 {
+		return errors.New("webhook config has no endpoints")
+	}
+
+	names := map[string]bool{}
+	for i, endpoint := range c.Endpoints {
+		if !validName.MatchString(endpoint.Name) {
+			return fmt.Errorf("webhook endpoint %d has invalid name '%s'", i, endpoint.Name)
+		}
+		if names[endpoint.Name] {
+			return fmt.Errorf("webhook endpoint name '%s' is used more than once", endpoint.Name)
+		}
+		names[endpoint.Name] = true
+
+		u, err := url.Parse(endpoint.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook endpoint '%s' has invalid url '%s'", endpoint.Name, endpoint.URL)
+		}
+
+		err = endpoint.Filter.EventFilter().Validate()
+		if err != nil {
+			return fmt.Errorf("webhook endpoint '%s' has invalid filter: %s", endpoint.Name, err.Error())
+		}
+
+		if endpoint.BatchSize < 0 || endpoint.MaxAttempts < 0 || endpoint.MaxDeadLetters < 0 {
+			return fmt.Errorf("webhook endpoint '%s' has a negative limit", endpoint.Name)
+		}
+		if endpoint.FlushInterval < 0 || endpoint.Timeout < 0 || endpoint.InitialBackoff < 0 || endpoint.MaxBackoff < endpoint.InitialBackoff {
+			return fmt.Errorf("webhook endpoint '%s' has invalid intervals", endpoint.Name)
+		}
+	}
+
+	return nil
+}
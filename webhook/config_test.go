@@ -0,0 +1,115 @@
+package webhook_test
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/webhook"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	var configPath string
+
+	writeConfig := func(contents string) {
+		configFile, err := ioutil.TempFile("", "webhooks")
+		Expect(err).NotTo(HaveOccurred())
+		defer configFile.Close()
+
+		_, err = configFile.WriteString(contents)
+		Expect(err).NotTo(HaveOccurred())
+		configPath = configFile.Name()
+	}
+
+	AfterEach(func() {
+		os.Remove(configPath)
+	})
+
+	It("loads the endpoints", func() {
+		writeConfig(`{
+			"dead_letter_dir": "/var/vcap/data/bbs/webhooks",
+			"endpoints": [{
+				"name": "billing",
+				"url": "https://billing.example.com/events",
+				"secret": "shh",
+				"filter": {"domain": "cf-apps", "event_types": ["actual_lrp_created", "actual_lrp_removed"]},
+				"batch_size": 10,
+				"flush_interval": "5s",
+				"max_attempts": 3,
+				"initial_backoff": "500ms",
+				"max_backoff": "10s",
+				"max_dead_letters": 20
+			}]
+		}`)
+
+		config, err := webhook.LoadConfig(configPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.DeadLetterDir).To(Equal("/var/vcap/data/bbs/webhooks"))
+		Expect(config.Endpoints).To(HaveLen(1))
+
+		endpoint := config.Endpoints[0]
+		Expect(endpoint.Name).To(Equal("billing"))
+		Expect(endpoint.URL).To(Equal("https://billing.example.com/events"))
+		Expect(endpoint.Secret).To(Equal("shh"))
+		Expect(endpoint.Filter.EventFilter()).To(Equal(models.EventFilter{
+			Domain:     "cf-apps",
+			EventTypes: []string{models.EventTypeActualLRPCreated, models.EventTypeActualLRPRemoved},
+		}))
+		Expect(endpoint.BatchSize).To(Equal(10))
+		Expect(endpoint.FlushInterval).To(Equal(webhook.Duration(5 * time.Second)))
+		Expect(endpoint.Timeout).To(Equal(webhook.Duration(webhook.DefaultTimeout)))
+		Expect(endpoint.MaxAttempts).To(Equal(3))
+		Expect(endpoint.InitialBackoff).To(Equal(webhook.Duration(500 * time.Millisecond)))
+		Expect(endpoint.MaxBackoff).To(Equal(webhook.Duration(10 * time.Second)))
+		Expect(endpoint.MaxDeadLetters).To(Equal(20))
+	})
+
+	It("defaults the limits and intervals", func() {
+		writeConfig(`{"endpoints": [{"name": "audit", "url": "http://audit.example.com"}]}`)
+
+		config, err := webhook.LoadConfig(configPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		endpoint := config.Endpoints[0]
+		Expect(endpoint.BatchSize).To(Equal(webhook.DefaultBatchSize))
+		Expect(endpoint.FlushInterval).To(Equal(webhook.Duration(webhook.DefaultFlushInterval)))
+		Expect(endpoint.MaxAttempts).To(Equal(webhook.DefaultMaxAttempts))
+		Expect(endpoint.InitialBackoff).To(Equal(webhook.Duration(webhook.DefaultInitialBackoff)))
+		Expect(endpoint.MaxBackoff).To(Equal(webhook.Duration(webhook.DefaultMaxBackoff)))
+		Expect(endpoint.MaxDeadLetters).To(Equal(webhook.DefaultMaxDeadLetters))
+	})
+
+	It("errors when the file does not exist", func() {
+		_, err := webhook.LoadConfig("/does/not/exist")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("errors on malformed durations", func() {
+		writeConfig(`{"endpoints": [{"name": "audit", "url": "http://audit.example.com", "flush_interval": "soon"}]}`)
+		_, err := webhook.LoadConfig(configPath)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("invalid configs",
+		func(contents, message string) {
+			writeConfig(contents)
+			_, err := webhook.LoadConfig(configPath)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("no endpoints", `{"endpoints": []}`, "no endpoints"),
+		Entry("bad name", `{"endpoints": [{"name": "../etc", "url": "http://a.example.com"}]}`, "invalid name"),
+		Entry("duplicate name", `{"endpoints": [
+			{"name": "audit", "url": "http://a.example.com"},
+			{"name": "audit", "url": "http://b.example.com"}
+		]}`, "used more than once"),
+		Entry("bad url", `{"endpoints": [{"name": "audit", "url": "ftp://a.example.com"}]}`, "invalid url"),
+		Entry("bad event type", `{"endpoints": [{"name": "audit", "url": "http://a.example.com", "filter": {"event_types": ["bogus"]}}]}`, "invalid filter"),
+		Entry("negative limit", `{"endpoints": [{"name": "audit", "url": "http://a.example.com", "batch_size": -1}]}`, "negative limit"),
+		Entry("backoff out of order", `{"endpoints": [{"name": "audit", "url": "http://a.example.com", "initial_backoff": "1m", "max_backoff": "1s"}]}`, "invalid intervals"),
+	)
+})
@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pivotal-golang/lager"
+)
+
+// A DeadLetter is a batch that could not be delivered, along with the error
+// from its last attempt.
+type DeadLetter struct {
+	Endpoint string          `json:"endpoint"`
+	FailedAt int64           `json:"failed_at"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	Batch    json.RawMessage `json:"batch"`
+}
+
+// DeadLetterStore keeps dead letters as JSON files in a directory, one per
+// batch, so that they can be inspected and replayed. Once it holds
+// maxDeadLetters, the oldest ones are removed to make room.
+type DeadLetterStore struct {
+	dir            string
+	maxDeadLetters int
+
+	lock sync.Mutex
+	seq  int64
+}
+
+func NewDeadLetterStore(dir string, maxDeadLetters int) (*DeadLetterStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeadLetterStore{dir: dir, maxDeadLetters: maxDeadLetters}, nil
+}
+
+func (s *DeadLetterStore) Add(logger lager.Logger, letter DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Names sort in the order the letters were added, even when several fail
+	// within the same clock tick.
+	if letter.FailedAt > s.seq {
+		s.seq = letter.FailedAt
+	} else {
+		s.seq++
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("%020d.json", s.seq))
+
+	err = ioutil.WriteFile(path, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	paths, err := s.paths()
+	if err != nil {
+		return err
+	}
+
+	for len(paths) > s.maxDeadLetters {
+		logger.Info("dropping-oldest-dead-letter", lager.Data{"path": paths[0]})
+		err = os.Remove(paths[0])
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		paths = paths[1:]
+	}
+
+	return nil
+}
+
+// DeadLetters returns the stored dead letters, oldest first.
+func (s *DeadLetterStore) DeadLetters() ([]DeadLetter, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	paths, err := s.paths()
+	if err != nil {
+		return nil, err
+	}
+
+	letters := []DeadLetter{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var letter DeadLetter
+		err = json.Unmarshal(data, &letter)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+
+	return letters, nil
+}
+
+func (s *DeadLetterStore) paths() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
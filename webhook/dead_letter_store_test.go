@@ -0,0 +1,78 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry-incubator/bbs/webhook"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/lager/lagertest"
+)
+
+var _ = Describe("DeadLetterStore", func() {
+	var (
+		logger *lagertest.TestLogger
+		dir    string
+		store  *webhook.DeadLetterStore
+	)
+
+	letter := func(failedAt int64) webhook.DeadLetter {
+		return webhook.DeadLetter{
+			Endpoint: "audit",
+			FailedAt: failedAt,
+			Attempts: 3,
+			Error:    "unexpected status code 500",
+			Batch:    json.RawMessage(`{"endpoint":"audit","events":[]}`),
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		logger = lagertest.NewTestLogger("test")
+		dir, err = ioutil.TempDir("", "dead-letters")
+		Expect(err).NotTo(HaveOccurred())
+
+		store, err = webhook.NewDeadLetterStore(filepath.Join(dir, "audit"), 2)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("stores dead letters in order", func() {
+		Expect(store.Add(logger, letter(100))).To(Succeed())
+		Expect(store.Add(logger, letter(100))).To(Succeed())
+
+		letters, err := store.DeadLetters()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(Equal([]webhook.DeadLetter{letter(100), letter(100)}))
+	})
+
+	It("drops the oldest dead letters beyond its capacity", func() {
+		Expect(store.Add(logger, letter(100))).To(Succeed())
+		Expect(store.Add(logger, letter(200))).To(Succeed())
+		Expect(store.Add(logger, letter(300))).To(Succeed())
+
+		letters, err := store.DeadLetters()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(Equal([]webhook.DeadLetter{letter(200), letter(300)}))
+		Expect(logger).To(gbytes.Say("dropping-oldest-dead-letter"))
+	})
+
+	It("keeps the dead letters of a previous store in the directory", func() {
+		Expect(store.Add(logger, letter(100))).To(Succeed())
+
+		store, err := webhook.NewDeadLetterStore(filepath.Join(dir, "audit"), 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Add(logger, letter(200))).To(Succeed())
+
+		letters, err := store.DeadLetters()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(Equal([]webhook.DeadLetter{letter(100), letter(200)}))
+	})
+})
@@ -0,0 +1,315 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+const (
+	TimestampHeader = "X-BBS-Webhook-Timestamp"
+	SignatureHeader = "X-BBS-Webhook-Signature"
+)
+
+const (
+	webhookEventsDelivered     = metric.Counter("WebhookEventsDelivered")
+	webhookBatchesDeadLettered = metric.Counter("WebhookBatchesDeadLettered")
+)
+
+// Batch is the body POSTed to an endpoint.
+type Batch struct {
+	Endpoint string  `json:"endpoint"`
+	Events   []Event `json:"events"`
+}
+
+// Event ids are the ids the event stream would have given the event; they
+// only increase within the events of the same hub.
+type Event struct {
+	ID   uint64       `json:"id"`
+	Type string       `json:"type"`
+	Data models.Event `json:"data"`
+}
+
+// Sign returns the signature of a batch body sent at the given unix
+// timestamp: the hex encoded HMAC-SHA256, keyed by the endpoint secret, of the
+// timestamp, a '.', and the body. Receivers should compare it to the
+// X-BBS-Webhook-Signature header with hmac.Equal.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type received struct {
+	subscription *subscription
+	id           uint64
+	event        models.Event
+	err          error
+}
+
+type subscription struct {
+	hub    events.Hub
+	source events.SequencedEventSource
+	lastID uint64
+}
+
+// Sink delivers the events of the hubs to a single endpoint. It subscribes to
+// each hub like any event stream client: while a delivery is being retried it
+// stops reading, and if the hub drops it as a slow consumer it resumes after
+// the last event it read, as long as the hub still buffers the events since.
+type Sink struct {
+	logger      lager.Logger
+	endpoint    Endpoint
+	hubs        []events.Hub
+	deadLetters *DeadLetterStore
+	client      *http.Client
+	clock       clock.Clock
+}
+
+// NewSink returns a sink for the endpoint. deadLetters may be nil, in which
+// case batches that cannot be delivered are only logged.
+func NewSink(
+	logger lager.Logger,
+	endpoint Endpoint,
+	hubs []events.Hub,
+	deadLetters *DeadLetterStore,
+	clock clock.Clock,
+) *Sink {
+	return &Sink{
+		logger:      logger,
+		endpoint:    endpoint,
+		hubs:        hubs,
+		deadLetters: deadLetters,
+		client:      &http.Client{Timeout: time.Duration(endpoint.Timeout)},
+		clock:       clock,
+	}
+}
+
+func (s *Sink) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := s.logger.Session("webhook-sink", lager.Data{"endpoint": s.endpoint.Name})
+	logger.Info("starting")
+
+	done := make(chan struct{})
+	receivedCh := make(chan received)
+	subscriptions := []*subscription{}
+
+	defer func() {
+		close(done)
+		for _, sub := range subscriptions {
+			if sub.source != nil {
+				sub.source.Close()
+			}
+		}
+	}()
+
+	for _, hub := range s.hubs {
+		sub := &subscription{hub: hub}
+		err := s.subscribe(logger, sub, receivedCh, done)
+		if err != nil {
+			logger.Error("failed-subscribing", err)
+			return err
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+
+	ticker := s.clock.NewTicker(time.Duration(s.endpoint.FlushInterval))
+	defer ticker.Stop()
+
+	close(ready)
+	logger.Info("started")
+	defer logger.Info("finished")
+
+	batch := []Event{}
+	for {
+		select {
+		case <-signals:
+			// Make a single attempt, rather than hold up shutdown with retries.
+			if len(batch) > 0 {
+				s.deliver(logger, batch, 1, signals)
+			}
+			return nil
+
+		case r := <-receivedCh:
+			if r.err != nil {
+				r.subscription.source = nil
+				err := s.subscribe(logger, r.subscription, receivedCh, done)
+				if err == events.ErrSubscribedToClosedHub {
+					logger.Info("hub-closed")
+				} else if err != nil {
+					logger.Error("failed-resubscribing", err)
+				}
+				continue
+			}
+
+			r.subscription.lastID = r.id
+			batch = append(batch, Event{ID: r.id, Type: r.event.EventType(), Data: r.event})
+			if len(batch) < s.endpoint.BatchSize {
+				continue
+			}
+
+		case <-ticker.C():
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		if s.deliver(logger, batch, s.endpoint.MaxAttempts, signals) {
+			return nil
+		}
+		batch = []Event{}
+	}
+}
+
+// subscribe resumes the subscription after the last event it read, or starts
+// it afresh if there is none or it has expired, and reads it until it fails.
+func (s *Sink) subscribe(logger lager.Logger, sub *subscription, receivedCh chan<- received, done <-chan struct{}) error {
+	filter := s.endpoint.Filter.EventFilter()
+
+	var source events.SequencedEventSource
+	var err error
+	if sub.lastID != 0 {
+		source, err = sub.hub.SubscribeAfter(filter, sub.lastID)
+		if err == events.ErrEventsExpired {
+			logger.Error("events-expired", err, lager.Data{"last_event_id": sub.lastID})
+			source, err = sub.hub.Subscribe(filter)
+		}
+	} else {
+		source, err = sub.hub.Subscribe(filter)
+	}
+	if err != nil {
+		return err
+	}
+	sub.source = source
+
+	go func() {
+		for {
+			id, event, err := source.NextSequenced()
+			select {
+			case receivedCh <- received{subscription: sub, id: id, event: event, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// deliver POSTs the batch, retrying with exponential backoff, and moves it to
+// the dead-letter store once it runs out of attempts. It reports whether it
+// was interrupted by a signal.
+func (s *Sink) deliver(logger lager.Logger, batch []Event, maxAttempts int, signals <-chan os.Signal) bool {
+	logger = logger.Session("deliver", lager.Data{"events": len(batch)})
+
+	body, err := json.Marshal(Batch{Endpoint: s.endpoint.Name, Events: batch})
+	if err != nil {
+		logger.Error("failed-marshaling-batch", err)
+		return false
+	}
+
+	backoff := time.Duration(s.endpoint.InitialBackoff)
+	attempt := 1
+	interrupted := false
+	for {
+		err = s.post(body)
+		if err == nil {
+			sendErr := webhookEventsDelivered.Add(uint64(len(batch)))
+			if sendErr != nil {
+				logger.Error("failed-to-send-webhook-events-delivered-metric", sendErr)
+			}
+			return false
+		}
+		logger.Error("failed-posting-batch", err, lager.Data{"attempt": attempt})
+
+		if attempt >= maxAttempts {
+			break
+		}
+
+		timer := s.clock.NewTimer(backoff)
+		select {
+		case <-timer.C():
+		case <-signals:
+			timer.Stop()
+			interrupted = true
+		}
+		if interrupted {
+			break
+		}
+
+		attempt++
+		backoff *= 2
+		if backoff > time.Duration(s.endpoint.MaxBackoff) {
+			backoff = time.Duration(s.endpoint.MaxBackoff)
+		}
+	}
+
+	sendErr := webhookBatchesDeadLettered.Increment()
+	if sendErr != nil {
+		logger.Error("failed-to-send-webhook-batches-dead-lettered-metric", sendErr)
+	}
+
+	if s.deadLetters == nil {
+		logger.Info("dropped-batch", lager.Data{"first_event_id": batch[0].ID, "last_event_id": batch[len(batch)-1].ID})
+		return interrupted
+	}
+
+	err = s.deadLetters.Add(logger, DeadLetter{
+		Endpoint: s.endpoint.Name,
+		FailedAt: s.clock.Now().UnixNano(),
+		Attempts: attempt,
+		Error:    err.Error(),
+		Batch:    body,
+	})
+	if err != nil {
+		logger.Error("failed-storing-dead-letter", err)
+	}
+
+	return interrupted
+}
+
+func (s *Sink) post(body []byte) error {
+	request, err := http.NewRequest("POST", s.endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := s.clock.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	if s.endpoint.Secret != "" {
+		request.Header.Set(SignatureHeader, Sign(s.endpoint.Secret, timestamp, body))
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	return nil
+}
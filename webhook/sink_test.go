@@ -0,0 +1,257 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/test/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/webhook"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+)
+
+type receivedBatch struct {
+	Endpoint string
+	Events   []struct {
+		ID   uint64
+		Type string
+		Data json.RawMessage
+	}
+}
+
+var _ = Describe("Sink", func() {
+	const (
+		flushInterval  = time.Second
+		initialBackoff = 100 * time.Millisecond
+	)
+
+	var (
+		logger    *lagertest.TestLogger
+		fakeClock *fakeclock.FakeClock
+		server    *ghttp.Server
+
+		desiredHub events.Hub
+		taskHub    events.Hub
+
+		endpoint       webhook.Endpoint
+		deadLetterDir  string
+		deadLetters    *webhook.DeadLetterStore
+		receivedChan   chan receivedBatch
+		process        ifrit.Process
+		desiredLRP     *models.DesiredLRP
+		task           *models.Task
+		responseStatus int
+	)
+
+	receive := func(w http.ResponseWriter, req *http.Request) {
+		defer GinkgoRecover()
+
+		body, err := ioutil.ReadAll(req.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		timestamp, err := strconv.ParseInt(req.Header.Get(webhook.TimestampHeader), 10, 64)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timestamp).To(Equal(fakeClock.Now().Unix()))
+		Expect(req.Header.Get(webhook.SignatureHeader)).To(Equal(webhook.Sign("secret", timestamp, body)))
+		Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+		var batch receivedBatch
+		Expect(json.Unmarshal(body, &batch)).To(Succeed())
+		receivedChan <- batch
+	}
+
+	BeforeEach(func() {
+		var err error
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		server = ghttp.NewServer()
+		server.AllowUnhandledRequests = false
+
+		desiredHub = events.NewHub()
+		taskHub = events.NewHub()
+
+		endpoint = webhook.Endpoint{
+			Name:           "audit",
+			URL:            server.URL() + "/events",
+			Secret:         "secret",
+			BatchSize:      2,
+			FlushInterval:  webhook.Duration(flushInterval),
+			Timeout:        webhook.Duration(time.Second),
+			MaxAttempts:    3,
+			InitialBackoff: webhook.Duration(initialBackoff),
+			MaxBackoff:     webhook.Duration(150 * time.Millisecond),
+		}
+
+		deadLetterDir, err = ioutil.TempDir("", "dead-letters")
+		Expect(err).NotTo(HaveOccurred())
+		deadLetters, err = webhook.NewDeadLetterStore(deadLetterDir, 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		receivedChan = make(chan receivedBatch, 10)
+		responseStatus = http.StatusOK
+		server.RouteToHandler("POST", "/events", func(w http.ResponseWriter, req *http.Request) {
+			receive(w, req)
+			w.WriteHeader(responseStatus)
+		})
+
+		desiredLRP = model_helpers.NewValidDesiredLRP("process-guid")
+		task = model_helpers.NewValidTask("task-guid")
+	})
+
+	JustBeforeEach(func() {
+		sink := webhook.NewSink(logger, endpoint, []events.Hub{desiredHub, taskHub}, deadLetters, fakeClock)
+		process = ginkgomon.Invoke(sink)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+		server.Close()
+		os.RemoveAll(deadLetterDir)
+	})
+
+	It("posts a batch once it is full", func() {
+		desiredHub.Emit(models.NewDesiredLRPCreatedEvent(desiredLRP))
+		Consistently(receivedChan).ShouldNot(Receive())
+
+		taskHub.Emit(models.NewTaskCreatedEvent(task))
+
+		var batch receivedBatch
+		Eventually(receivedChan).Should(Receive(&batch))
+		Expect(batch.Endpoint).To(Equal("audit"))
+		Expect(batch.Events).To(HaveLen(2))
+		Expect(batch.Events[0].Type).To(Equal(models.EventTypeDesiredLRPCreated))
+		Expect(batch.Events[0].ID).NotTo(BeZero())
+		Expect(batch.Events[1].Type).To(Equal(models.EventTypeTaskCreated))
+
+		var created models.TaskCreatedEvent
+		Expect(json.Unmarshal(batch.Events[1].Data, &created)).To(Succeed())
+		Expect(created.Task.TaskGuid).To(Equal("task-guid"))
+	})
+
+	It("posts a partial batch after the flush interval", func() {
+		taskHub.Emit(models.NewTaskRemovedEvent(task))
+		Consistently(receivedChan).ShouldNot(Receive())
+
+		fakeClock.Increment(flushInterval)
+
+		var batch receivedBatch
+		Eventually(receivedChan).Should(Receive(&batch))
+		Expect(batch.Events).To(HaveLen(1))
+		Expect(batch.Events[0].Type).To(Equal(models.EventTypeTaskRemoved))
+	})
+
+	Context("when the endpoint has a filter", func() {
+		BeforeEach(func() {
+			endpoint.BatchSize = 1
+			endpoint.Filter = webhook.Filter{EventTypes: []string{models.EventTypeTaskRemoved}}
+		})
+
+		It("only posts the matching events", func() {
+			taskHub.Emit(models.NewTaskCreatedEvent(task))
+			taskHub.Emit(models.NewTaskRemovedEvent(task))
+
+			var batch receivedBatch
+			Eventually(receivedChan).Should(Receive(&batch))
+			Expect(batch.Events).To(HaveLen(1))
+			Expect(batch.Events[0].Type).To(Equal(models.EventTypeTaskRemoved))
+		})
+	})
+
+	Context("when the endpoint fails", func() {
+		BeforeEach(func() {
+			endpoint.BatchSize = 1
+			responseStatus = http.StatusInternalServerError
+		})
+
+		It("retries with backoff", func() {
+			taskHub.Emit(models.NewTaskCreatedEvent(task))
+			Eventually(receivedChan).Should(Receive())
+
+			responseStatus = http.StatusOK
+			fakeClock.WaitForNWatchersAndIncrement(initialBackoff-time.Millisecond, 2)
+			Consistently(receivedChan).ShouldNot(Receive())
+
+			fakeClock.Increment(time.Millisecond)
+			Eventually(receivedChan).Should(Receive())
+
+			Expect(deadLetters.DeadLetters()).To(BeEmpty())
+		})
+
+		It("moves the batch to the dead-letter store after the last attempt", func() {
+			taskHub.Emit(models.NewTaskCreatedEvent(task))
+			Eventually(receivedChan).Should(Receive())
+
+			fakeClock.WaitForNWatchersAndIncrement(initialBackoff, 2)
+			Eventually(receivedChan).Should(Receive())
+
+			fakeClock.WaitForNWatchersAndIncrement(150*time.Millisecond, 2)
+			Eventually(receivedChan).Should(Receive())
+
+			Eventually(deadLetters.DeadLetters).Should(HaveLen(1))
+			letters, err := deadLetters.DeadLetters()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(letters[0].Endpoint).To(Equal("audit"))
+			Expect(letters[0].Attempts).To(Equal(3))
+			Expect(letters[0].Error).To(ContainSubstring("500"))
+
+			var batch receivedBatch
+			Expect(json.Unmarshal(letters[0].Batch, &batch)).To(Succeed())
+			Expect(batch.Events[0].Type).To(Equal(models.EventTypeTaskCreated))
+		})
+
+		It("goes on to the next batch", func() {
+			taskHub.Emit(models.NewTaskCreatedEvent(task))
+			for i := 0; i < 2; i++ {
+				Eventually(receivedChan).Should(Receive())
+				fakeClock.WaitForNWatchersAndIncrement(time.Second, 2)
+			}
+			Eventually(receivedChan).Should(Receive())
+			Eventually(deadLetters.DeadLetters).Should(HaveLen(1))
+
+			responseStatus = http.StatusOK
+			taskHub.Emit(models.NewTaskRemovedEvent(task))
+
+			var batch receivedBatch
+			Eventually(receivedChan).Should(Receive(&batch))
+			Expect(batch.Events[0].Type).To(Equal(models.EventTypeTaskRemoved))
+		})
+	})
+
+	Context("when signalled", func() {
+		It("posts the pending batch", func() {
+			taskHub.Emit(models.NewTaskCreatedEvent(task))
+			Consistently(receivedChan).ShouldNot(Receive())
+
+			ginkgomon.Interrupt(process)
+
+			var batch receivedBatch
+			Expect(receivedChan).To(Receive(&batch))
+			Expect(batch.Events).To(HaveLen(1))
+		})
+	})
+
+	Context("when a hub is closed", func() {
+		It("keeps delivering the events of the other hubs", func() {
+			Expect(desiredHub.Close()).To(Succeed())
+			Eventually(logger).Should(gbytes.Say("hub-closed"))
+
+			taskHub.Emit(models.NewTaskCreatedEvent(task))
+			Eventually(func() int {
+				fakeClock.Increment(flushInterval)
+				return len(receivedChan)
+			}).Should(Equal(1))
+		})
+	})
+})